@@ -7,13 +7,19 @@ import (
 	"time"
 )
 
-//go:generate mockgen -package api -destination mock.go github.com/evcc-io/evcc/api Charger,ChargeState,CurrentLimiter,PhaseSwitcher,Identifier,Meter,MeterEnergy,Vehicle,ChargeRater,Battery,Tariff,BatteryController
+//go:generate mockgen -package api -destination mock.go github.com/evcc-io/evcc/api Charger,ChargeState,CurrentLimiter,PhaseSwitcher,Identifier,Meter,MeterEnergy,Vehicle,ChargeRater,Battery,Tariff,BatteryController,ChargerLock
 
 // Meter provides total active power in W
 type Meter interface {
 	CurrentPower() (float64, error)
 }
 
+// MeterContext provides total active power in W. The caller returns when the context is cancelled,
+// the reading itself is aborted only if the underlying plugin supports cancellation (http, script).
+type MeterContext interface {
+	CurrentPowerContext(context.Context) (float64, error)
+}
+
 // MeterEnergy provides total energy in kWh
 type MeterEnergy interface {
 	TotalEnergy() (float64, error)
@@ -39,6 +45,11 @@ type Battery interface {
 	Soc() (float64, error)
 }
 
+// BackupMode is implemented by inverters and battery systems reporting backup (island) operation during grid outages
+type BackupMode interface {
+	BackupMode() (bool, error)
+}
+
 // BatteryCapacity provides a capacity in kWh
 type BatteryCapacity interface {
 	Capacity() float64
@@ -77,6 +88,18 @@ type ChargerEx interface {
 	MaxCurrentMillis(current float64) error
 }
 
+// ChargerPowerController provides power based charger control for DC chargers without current control.
+// Power is the AC input power including conversion losses.
+type ChargerPowerController interface {
+	MaxPower(power float64) error
+}
+
+// CircuitCurrentLimiter limits the per-phase current available to the charger's own load balancing group.
+// Returns ErrNotAvailable if load balancing coordination is not configured.
+type CircuitCurrentLimiter interface {
+	SetCircuitCurrent(current float64) error
+}
+
 // PhaseSwitcher provides 1p3p switching
 type PhaseSwitcher interface {
 	Phases1p3p(phases int) error
@@ -107,6 +130,18 @@ type Authorizer interface {
 	Authorize(key string) error
 }
 
+// ChargerLock controls the charger's socket lock, e.g. to secure or unplug the cable
+type ChargerLock interface {
+	Lock() error
+	Unlock() error
+}
+
+// SignedMeter provides signed meter data sets (e.g. OCMF) of the current or last transaction for billing.
+// Currently provided by OCPP chargers transmitting signed meter values.
+type SignedMeter interface {
+	SignedMeterValues() (start string, stop string, err error)
+}
+
 // PhaseDescriber returns the number of availablephases
 type PhaseDescriber interface {
 	Phases() int
@@ -141,6 +176,11 @@ type VehicleClimater interface {
 	Climater() (bool, error)
 }
 
+// VehiclePreconditioner starts battery preconditioning for fast charging
+type VehiclePreconditioner interface {
+	Precondition() error
+}
+
 // VehicleOdometer returns the vehicles milage
 type VehicleOdometer interface {
 	Odometer() (float64, error)
@@ -156,12 +196,22 @@ type CurrentLimiter interface {
 	GetMinMaxCurrent() (float64, float64, error)
 }
 
+// ChargerTemperature provides the charger's internal temperature
+type ChargerTemperature interface {
+	Temperature() (float64, error)
+}
+
 // SocLimiter returns the soc limit
 type SocLimiter interface {
 	// TODO rename LimitSoc
 	TargetSoc() (float64, error)
 }
 
+// SocSetter allows setting the soc of vehicles without api
+type SocSetter interface {
+	SetSoc(soc float64) error
+}
+
 // VehicleChargeController allows to start/stop the charging session on the vehicle side
 type VehicleChargeController interface {
 	StartCharge() error
@@ -196,6 +246,16 @@ type FeatureDescriber interface {
 	Features() []Feature
 }
 
+// PhaseSwitchPenaltyDescriber optionally provides the time a vehicle needs to resume charging after a phase switch
+type PhaseSwitchPenaltyDescriber interface {
+	PhaseSwitchPenalty() time.Duration
+}
+
+// ChargeCurveDescriber optionally provides the vehicle's charge curve
+type ChargeCurveDescriber interface {
+	ChargeCurve() ChargeCurve
+}
+
 // CsvWriter converts to csv
 type CsvWriter interface {
 	WriteCsv(context.Context, io.Writer) error
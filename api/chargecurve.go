@@ -0,0 +1,47 @@
+package api
+
+import "slices"
+
+// ChargeCurvePoint is the vehicle's maximum charge power at the given soc
+type ChargeCurvePoint struct {
+	Soc   float64 `json:"soc"`
+	Power float64 `json:"power"`
+}
+
+// ChargeCurve is the vehicle's maximum charge power over soc
+type ChargeCurve []ChargeCurvePoint
+
+// Sort sorts the curve by soc
+func (c ChargeCurve) Sort() {
+	slices.SortFunc(c, func(i, j ChargeCurvePoint) int {
+		switch {
+		case i.Soc < j.Soc:
+			return -1
+		case i.Soc > j.Soc:
+			return +1
+		default:
+			return 0
+		}
+	})
+}
+
+// Power returns the linearly interpolated maximum charge power at soc or 0 if the curve is empty.
+// The curve must be sorted by soc.
+func (c ChargeCurve) Power(soc float64) float64 {
+	if len(c) == 0 {
+		return 0
+	}
+
+	if soc <= c[0].Soc {
+		return c[0].Power
+	}
+
+	for i := 1; i < len(c); i++ {
+		if soc <= c[i].Soc {
+			prev := c[i-1]
+			return prev.Power + (c[i].Power-prev.Power)*(soc-prev.Soc)/(c[i].Soc-prev.Soc)
+		}
+	}
+
+	return c[len(c)-1].Power
+}
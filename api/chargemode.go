@@ -17,6 +17,8 @@ func ChargeModeString(mode string) (ChargeMode, error) {
 		return ModeMinPV, nil
 	case string(ModePV):
 		return ModePV, nil
+	case string(ModeExternal):
+		return ModeExternal, nil
 	case string(ModeOff):
 		return ModeOff, nil
 	default:
@@ -14,6 +14,9 @@ var ErrSponsorRequired = errors.New("sponsorship required, see https://github.co
 // ErrMissingCredentials indicates that user/password are missing
 var ErrMissingCredentials = errors.New("missing credentials")
 
+// ErrLoginRequired indicates that the user needs to log in through the ui, e.g. to link an account or solve a captcha
+var ErrLoginRequired = errors.New("login required")
+
 // ErrOutdated indicates that result is outdated
 var ErrOutdated = errors.New("outdated")
 
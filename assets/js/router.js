@@ -18,6 +18,11 @@ export default function setupRouter(i18n) {
     routes: [
       { path: "/", component: Main, props: true },
       { path: "/config", component: () => import("./views/Config.vue"), props: true },
+      {
+        path: "/dashboard",
+        component: () => import("./views/Dashboard.vue"),
+        props: (route) => ({ token: route.query.token }),
+      },
       {
         path: "/sessions",
         component: () => import("./views/ChargingSessions.vue"),
@@ -1,6 +1,6 @@
 import { reactive } from "vue";
 
-function setProperty(obj, props, value) {
+export function setProperty(obj, props, value) {
   const prop = props.shift();
   if (!obj[prop]) {
     obj[prop] = {};
@@ -0,0 +1,110 @@
+package calendar
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+	"github.com/evcc-io/evcc/util/transport"
+)
+
+// CalDAV queries events from a CalDAV calendar collection
+type CalDAV struct {
+	*request.Helper
+	uri string
+}
+
+const caldavQuery = `<?xml version="1.0" encoding="utf-8"?>
+<c:calendar-query xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">
+  <d:prop>
+    <c:calendar-data/>
+  </d:prop>
+  <c:filter>
+    <c:comp-filter name="VCALENDAR">
+      <c:comp-filter name="VEVENT">
+        <c:time-range start="%s" end="%s"/>
+      </c:comp-filter>
+    </c:comp-filter>
+  </c:filter>
+</c:calendar-query>`
+
+type caldavMultistatus struct {
+	Responses []struct {
+		CalendarData string `xml:"propstat>prop>calendar-data"`
+	} `xml:"response"`
+}
+
+func init() {
+	registry.Add("caldav", NewCalDAVFromConfig)
+}
+
+// NewCalDAVFromConfig creates a CalDAV calendar from generic config
+func NewCalDAVFromConfig(other map[string]interface{}) (Calendar, error) {
+	var cc struct {
+		URI      string
+		User     string
+		Password string
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.URI == "" {
+		return nil, errors.New("missing uri")
+	}
+
+	c := &CalDAV{
+		Helper: request.NewHelper(util.NewLogger("caldav")),
+		uri:    cc.URI,
+	}
+
+	if cc.User != "" {
+		c.Client.Transport = transport.BasicAuth(cc.User, cc.Password, c.Client.Transport)
+	}
+
+	return c, nil
+}
+
+// Events implements the Calendar interface
+func (c *CalDAV) Events(from, to time.Time) ([]Event, error) {
+	const format = "20060102T150405Z"
+
+	data := fmt.Sprintf(caldavQuery, from.UTC().Format(format), to.UTC().Format(format))
+
+	req, err := request.New("REPORT", c.uri, strings.NewReader(data), map[string]string{
+		"Content-Type": "application/xml; charset=utf-8",
+		"Depth":        "1",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := c.DoBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var res caldavMultistatus
+	if err := xml.Unmarshal(b, &res); err != nil {
+		return nil, err
+	}
+
+	var events []Event
+	for _, r := range res.Responses {
+		ev, err := parseEvents(strings.NewReader(r.CalendarData), from, to)
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, ev...)
+	}
+
+	sortEvents(events)
+
+	return events, nil
+}
@@ -0,0 +1,18 @@
+package calendar
+
+import (
+	"time"
+)
+
+// Event is a single occurrence of a calendar event
+type Event struct {
+	Title string
+	Start time.Time
+	End   time.Time
+}
+
+// Calendar provides calendar events
+type Calendar interface {
+	// Events returns all event occurrences starting within the given time range ordered by start time
+	Events(from, to time.Time) ([]Event, error)
+}
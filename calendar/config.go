@@ -0,0 +1,40 @@
+package calendar
+
+import (
+	"fmt"
+	"strings"
+)
+
+type calendarRegistry map[string]func(map[string]interface{}) (Calendar, error)
+
+func (r calendarRegistry) Add(name string, factory func(map[string]interface{}) (Calendar, error)) {
+	if _, exists := r[name]; exists {
+		panic(fmt.Sprintf("cannot register duplicate calendar type: %s", name))
+	}
+	r[name] = factory
+}
+
+func (r calendarRegistry) Get(name string) (func(map[string]interface{}) (Calendar, error), error) {
+	factory, exists := r[name]
+	if !exists {
+		return nil, fmt.Errorf("invalid calendar type: %s", name)
+	}
+	return factory, nil
+}
+
+var registry calendarRegistry = make(map[string]func(map[string]interface{}) (Calendar, error))
+
+// NewFromConfig creates calendar from configuration
+func NewFromConfig(typ string, other map[string]interface{}) (Calendar, error) {
+	factory, err := registry.Get(strings.ToLower(typ))
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := factory(other)
+	if err != nil {
+		err = fmt.Errorf("cannot create calendar type '%s': %w", typ, err)
+	}
+
+	return v, err
+}
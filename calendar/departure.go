@@ -0,0 +1,91 @@
+package calendar
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/evcc-io/evcc/core/vehicle"
+	"github.com/evcc-io/evcc/util"
+)
+
+// Departure derives a vehicle's next departure from calendar events and applies it as charge plan
+type Departure struct {
+	log      *util.Logger
+	calendar Calendar
+	vehicle  vehicle.API
+	pattern  *regexp.Regexp
+	soc      int
+	lead     time.Duration
+	horizon  time.Duration
+	applied  time.Time // last departure applied to the vehicle
+}
+
+// NewDeparture creates a calendar departure for the given vehicle.
+// Events whose title matches pattern are considered departures, lead is the time before
+// the event the vehicle must be charged to soc.
+func NewDeparture(log *util.Logger, calendar Calendar, vehicle vehicle.API, pattern *regexp.Regexp, soc int, lead time.Duration) *Departure {
+	return &Departure{
+		log:      log,
+		calendar: calendar,
+		vehicle:  vehicle,
+		pattern:  pattern,
+		soc:      soc,
+		lead:     lead,
+		horizon:  7 * 24 * time.Hour,
+	}
+}
+
+// Run updates the vehicle's charge plan periodically
+func (d *Departure) Run(interval time.Duration) {
+	for ; true; <-time.Tick(interval) {
+		if err := d.update(time.Now()); err != nil {
+			d.log.ERROR.Println(err)
+		}
+	}
+}
+
+// Next returns the next departure after now or zero time if none is found
+func (d *Departure) Next(events []Event, now time.Time) time.Time {
+	for _, ev := range events {
+		if ts := ev.Start.Add(-d.lead); ts.After(now) && d.pattern.MatchString(ev.Title) {
+			return ts
+		}
+	}
+	return time.Time{}
+}
+
+func (d *Departure) update(now time.Time) error {
+	events, err := d.calendar.Events(now, now.Add(d.horizon))
+	if err != nil {
+		return err
+	}
+
+	ts := d.Next(events, now)
+	if ts.Equal(d.applied) {
+		return nil
+	}
+
+	// only remove plans created from the calendar, leave manual plans untouched
+	if ts.IsZero() {
+		if planTime, _ := d.vehicle.GetPlanSoc(); !planTime.Equal(d.applied) {
+			d.applied = ts
+			return nil
+		}
+		d.log.DEBUG.Printf("%s: no departure found, removing plan", d.vehicle.Name())
+	} else {
+		d.log.DEBUG.Printf("%s: departure at %v", d.vehicle.Name(), ts.Round(time.Second).Local())
+	}
+
+	soc := d.soc
+	if ts.IsZero() {
+		soc = 0
+	}
+
+	if err := d.vehicle.SetPlanSoc(ts, soc); err != nil {
+		return err
+	}
+
+	d.applied = ts
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package calendar
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCalendar = `BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//evcc//test//EN
+BEGIN:VEVENT
+UID:1
+SUMMARY:Office
+DTSTART:20240603T070000Z
+DTEND:20240603T160000Z
+RRULE:FREQ=WEEKLY;BYDAY=MO,WE
+EXDATE:20240605T070000Z
+END:VEVENT
+BEGIN:VEVENT
+UID:2
+SUMMARY:Dentist
+DTSTART:20240604T090000Z
+DTEND:20240604T100000Z
+END:VEVENT
+END:VCALENDAR
+`
+
+func TestParseEvents(t *testing.T) {
+	from := time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC)
+
+	events, err := parseEvents(strings.NewReader(testCalendar), from, from.Add(8*24*time.Hour))
+	require.NoError(t, err)
+
+	var res []string
+	for _, ev := range events {
+		res = append(res, ev.Title+" "+ev.Start.UTC().Format(time.DateTime))
+	}
+
+	assert.Equal(t, []string{
+		"Office 2024-06-03 07:00:00",
+		"Dentist 2024-06-04 09:00:00",
+		"Office 2024-06-10 07:00:00",
+	}, res)
+	assert.Equal(t, 9*time.Hour, events[0].End.Sub(events[0].Start))
+}
+
+func TestDepartureNext(t *testing.T) {
+	from := time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC)
+
+	events, err := parseEvents(strings.NewReader(testCalendar), from, from.Add(8*24*time.Hour))
+	require.NoError(t, err)
+
+	d := NewDeparture(util.NewLogger("foo"), nil, nil, regexp.MustCompile("(?i)office"), 80, 30*time.Minute)
+
+	assert.Equal(t, time.Date(2024, 6, 3, 6, 30, 0, 0, time.UTC), d.Next(events, from).UTC())
+	assert.Equal(t, time.Date(2024, 6, 10, 6, 30, 0, 0, time.UTC), d.Next(events, from.Add(7*time.Hour)).UTC())
+	assert.True(t, d.Next(events, from.Add(8*24*time.Hour)).IsZero())
+}
@@ -0,0 +1,159 @@
+package calendar
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	ics "github.com/arran4/golang-ical"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+	"github.com/evcc-io/evcc/util/transport"
+	"github.com/teambition/rrule-go"
+)
+
+// ICS reads events from an iCalendar file published via http, e.g.
+// Google Calendar's secret address or a CalDAV server's export url
+type ICS struct {
+	*request.Helper
+	uri string
+}
+
+func init() {
+	registry.Add("ics", NewICSFromConfig)
+}
+
+// NewICSFromConfig creates an iCalendar file calendar from generic config
+func NewICSFromConfig(other map[string]interface{}) (Calendar, error) {
+	var cc struct {
+		URI      string
+		User     string
+		Password string
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.URI == "" {
+		return nil, errors.New("missing uri")
+	}
+
+	c := &ICS{
+		Helper: request.NewHelper(util.NewLogger("ics")),
+		uri:    cc.URI,
+	}
+
+	if cc.User != "" {
+		c.Client.Transport = transport.BasicAuth(cc.User, cc.Password, c.Client.Transport)
+	}
+
+	return c, nil
+}
+
+// Events implements the Calendar interface
+func (c *ICS) Events(from, to time.Time) ([]Event, error) {
+	req, err := request.New(http.MethodGet, c.uri, nil, map[string]string{
+		"Accept": "text/calendar",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := c.DoBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseEvents(bytes.NewReader(b), from, to)
+}
+
+// parseEvents parses an iCalendar stream and expands recurring events into the given time range
+func parseEvents(r io.Reader, from, to time.Time) ([]Event, error) {
+	cal, err := ics.ParseCalendar(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []Event
+
+	for _, ev := range cal.Events() {
+		start, err := ev.GetStartAt()
+		if err != nil {
+			if start, err = ev.GetAllDayStartAt(); err != nil {
+				continue
+			}
+		}
+
+		end, err := ev.GetEndAt()
+		if err != nil {
+			end = start
+		}
+
+		var title string
+		if p := ev.GetProperty(ics.ComponentPropertySummary); p != nil {
+			title = p.Value
+		}
+
+		starts := []time.Time{start}
+
+		if p := ev.GetProperty(ics.ComponentPropertyRrule); p != nil {
+			opt, err := rrule.StrToROption(p.Value)
+			if err != nil {
+				continue
+			}
+
+			opt.Dtstart = start
+
+			rr, err := rrule.NewRRule(*opt)
+			if err != nil {
+				continue
+			}
+
+			var set rrule.Set
+			set.RRule(rr)
+
+			for _, p := range ev.Properties {
+				if p.IANAToken != string(ics.ComponentPropertyExdate) {
+					continue
+				}
+
+				for _, ts := range strings.Split(p.Value, ",") {
+					if ex, err := time.ParseInLocation("20060102T150405", ts, start.Location()); err == nil {
+						set.ExDate(ex)
+					} else if ex, err := time.Parse("20060102T150405Z", ts); err == nil {
+						set.ExDate(ex)
+					}
+				}
+			}
+
+			starts = set.Between(from, to, true)
+		}
+
+		for _, ts := range starts {
+			if ts.Before(from) || ts.After(to) {
+				continue
+			}
+
+			res = append(res, Event{
+				Title: title,
+				Start: ts,
+				End:   ts.Add(end.Sub(start)),
+			})
+		}
+	}
+
+	sortEvents(res)
+
+	return res, nil
+}
+
+func sortEvents(events []Event) {
+	slices.SortFunc(events, func(a, b Event) int {
+		return a.Start.Compare(b.Start)
+	})
+}
@@ -35,19 +35,24 @@ func init() {
 
 // NewCfosPowerBrainFromConfig creates a cFos charger from generic config
 func NewCfosPowerBrainFromConfig(other map[string]interface{}) (api.Charger, error) {
-	cc := modbus.TcpSettings{
-		ID: 1,
+	cc := struct {
+		modbus.TcpSettings `mapstructure:",squash"`
+		Phases1p3p         bool // force phase switching if not detected
+	}{
+		TcpSettings: modbus.TcpSettings{
+			ID: 1,
+		},
 	}
 
 	if err := util.DecodeOther(other, &cc); err != nil {
 		return nil, err
 	}
 
-	return NewCfosPowerBrain(cc.URI, cc.ID)
+	return NewCfosPowerBrain(cc.URI, cc.ID, cc.Phases1p3p)
 }
 
 // NewCfosPowerBrain creates a cFos charger
-func NewCfosPowerBrain(uri string, id uint8) (api.Charger, error) {
+func NewCfosPowerBrain(uri string, id uint8, force1p3p bool) (api.Charger, error) {
 	uri = util.DefaultPort(uri, 4701)
 
 	conn, err := modbus.NewConnection(uri, "", "", 0, modbus.Tcp, id)
@@ -74,7 +79,7 @@ func NewCfosPowerBrain(uri string, id uint8) (api.Charger, error) {
 	var phases1p3p func(int) error
 
 	b, err := wb.conn.ReadHoldingRegisters(cfosRegSolarEnabled, 1)
-	if err == nil && binary.BigEndian.Uint16(b)&(1<<8) != 0 {
+	if force1p3p || err == nil && binary.BigEndian.Uint16(b)&(1<<8) != 0 {
 		phases1p3p = wb.phases1p3p
 	}
 
@@ -141,12 +146,23 @@ func (wb *CfosPowerBrain) MaxCurrentMillis(current float64) error {
 	return err
 }
 
-// phases1p3p implements the api.PhaseSwitcher interface
+// phases1p3p implements the api.PhaseSwitcher interface.
+// Relays are only switched while the vehicle is not charging, otherwise the loadpoint pauses charging and retries.
 func (wb *CfosPowerBrain) phases1p3p(phases int) error {
+	status, err := wb.Status()
+	if err != nil {
+		return err
+	}
+
+	if status == api.StatusC || status == api.StatusD {
+		return api.ErrMustRetry
+	}
+
 	if phases == 3 {
 		phases = 0
 	}
-	_, err := wb.conn.WriteSingleRegister(cfosRegRelaySelect, uint16(phases))
+
+	_, err = wb.conn.WriteSingleRegister(cfosRegRelaySelect, uint16(phases))
 	return err
 }
 
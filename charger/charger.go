@@ -22,18 +22,22 @@ func init() {
 	registry.Add(api.Custom, NewConfigurableFromConfig)
 }
 
-//go:generate go run ../cmd/tools/decorate.go -f decorateCustom -b *Charger -r api.Charger -t "api.ChargerEx,MaxCurrentMillis,func(float64) error" -t "api.Identifier,Identify,func() (string, error)" -t "api.PhaseSwitcher,Phases1p3p,func(int) error" -t "api.Resurrector,WakeUp,func() error" -t "api.Battery,Soc,func() (float64, error)"
+//go:generate go run ../cmd/tools/decorate.go -f decorateCustom -b *Charger -r api.Charger -t "api.ChargerEx,MaxCurrentMillis,func(float64) error" -t "api.Identifier,Identify,func() (string, error)" -t "api.PhaseSwitcher,Phases1p3p,func(int) error" -t "api.Resurrector,WakeUp,func() error" -t "api.Battery,Soc,func() (float64, error)" -t "api.ChargerPowerController,MaxPower,func(float64) error" -t "api.Meter,CurrentPower,func() (float64, error)"
 
 // NewConfigurableFromConfig creates a new configurable charger
 func NewConfigurableFromConfig(other map[string]interface{}) (api.Charger, error) {
-	var cc struct {
+	cc := struct {
 		embed                               `mapstructure:",squash"`
 		Status, Enable, Enabled, MaxCurrent provider.Config
 		MaxCurrentMillis                    *provider.Config
 		Identify, Phases1p3p                *provider.Config
 		Wakeup                              *provider.Config
 		Soc                                 *provider.Config
+		MaxPower, Power                     *provider.Config // dc chargers controlled by output power
+		Efficiency                          float64          // dc charger conversion efficiency
 		Tos                                 bool
+	}{
+		Efficiency: 1,
 	}
 
 	if err := util.DecodeOther(other, &cc); err != nil {
@@ -55,9 +59,17 @@ func NewConfigurableFromConfig(other map[string]interface{}) (api.Charger, error
 		return nil, fmt.Errorf("enable: %w", err)
 	}
 
-	maxcurrent, err := provider.NewIntSetterFromConfig("maxcurrent", cc.MaxCurrent)
-	if err != nil {
-		return nil, fmt.Errorf("maxcurrent: %w", err)
+	if cc.Efficiency <= 0 || cc.Efficiency > 1 {
+		return nil, fmt.Errorf("invalid efficiency: %v", cc.Efficiency)
+	}
+
+	// power controlled chargers don't require current control
+	maxcurrent := func(int64) error { return nil }
+	if cc.MaxPower == nil || cc.MaxCurrent.Source != "" {
+		maxcurrent, err = provider.NewIntSetterFromConfig("maxcurrent", cc.MaxCurrent)
+		if err != nil {
+			return nil, fmt.Errorf("maxcurrent: %w", err)
+		}
 	}
 
 	c, err := NewConfigurable(status, enabled, enable, maxcurrent)
@@ -123,7 +135,33 @@ func NewConfigurableFromConfig(other map[string]interface{}) (api.Charger, error
 		}
 	}
 
-	return decorateCustom(c, maxcurrentmillis, identify, phases1p3p, wakeup, soc), nil
+	// decorate power control, setpoints and readings are dc output power
+	var maxpower func(float64) error
+	if cc.MaxPower != nil {
+		maxpowerS, err := provider.NewFloatSetterFromConfig("maxpower", *cc.MaxPower)
+		if err != nil {
+			return nil, fmt.Errorf("maxpower: %w", err)
+		}
+
+		maxpower = func(power float64) error {
+			return maxpowerS(power * cc.Efficiency)
+		}
+	}
+
+	var power func() (float64, error)
+	if cc.Power != nil {
+		powerG, err := provider.NewFloatGetterFromConfig(*cc.Power)
+		if err != nil {
+			return nil, fmt.Errorf("power: %w", err)
+		}
+
+		power = func() (float64, error) {
+			res, err := powerG()
+			return res / cc.Efficiency, err
+		}
+	}
+
+	return decorateCustom(c, maxcurrentmillis, identify, phases1p3p, wakeup, soc, maxpower, power), nil
 }
 
 // NewConfigurable creates a new charger
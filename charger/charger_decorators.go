@@ -6,12 +6,12 @@ import (
 	"github.com/evcc-io/evcc/api"
 )
 
-func decorateCustom(base *Charger, chargerEx func(float64) error, identifier func() (string, error), phaseSwitcher func(int) error, resurrector func() error, battery func() (float64, error)) api.Charger {
+func decorateCustom(base *Charger, chargerEx func(float64) error, identifier func() (string, error), phaseSwitcher func(int) error, resurrector func() error, battery func() (float64, error), chargerPowerController func(float64) error, meter func() (float64, error)) api.Charger {
 	switch {
-	case battery == nil && chargerEx == nil && identifier == nil && phaseSwitcher == nil && resurrector == nil:
+	case battery == nil && chargerEx == nil && chargerPowerController == nil && identifier == nil && meter == nil && phaseSwitcher == nil && resurrector == nil:
 		return base
 
-	case battery == nil && chargerEx != nil && identifier == nil && phaseSwitcher == nil && resurrector == nil:
+	case battery == nil && chargerEx != nil && chargerPowerController == nil && identifier == nil && meter == nil && phaseSwitcher == nil && resurrector == nil:
 		return &struct {
 			*Charger
 			api.ChargerEx
@@ -22,7 +22,7 @@ func decorateCustom(base *Charger, chargerEx func(float64) error, identifier fun
 			},
 		}
 
-	case battery == nil && chargerEx == nil && identifier != nil && phaseSwitcher == nil && resurrector == nil:
+	case battery == nil && chargerEx == nil && chargerPowerController == nil && identifier != nil && meter == nil && phaseSwitcher == nil && resurrector == nil:
 		return &struct {
 			*Charger
 			api.Identifier
@@ -33,7 +33,7 @@ func decorateCustom(base *Charger, chargerEx func(float64) error, identifier fun
 			},
 		}
 
-	case battery == nil && chargerEx != nil && identifier != nil && phaseSwitcher == nil && resurrector == nil:
+	case battery == nil && chargerEx != nil && chargerPowerController == nil && identifier != nil && meter == nil && phaseSwitcher == nil && resurrector == nil:
 		return &struct {
 			*Charger
 			api.ChargerEx
@@ -48,7 +48,7 @@ func decorateCustom(base *Charger, chargerEx func(float64) error, identifier fun
 			},
 		}
 
-	case battery == nil && chargerEx == nil && identifier == nil && phaseSwitcher != nil && resurrector == nil:
+	case battery == nil && chargerEx == nil && chargerPowerController == nil && identifier == nil && meter == nil && phaseSwitcher != nil && resurrector == nil:
 		return &struct {
 			*Charger
 			api.PhaseSwitcher
@@ -59,7 +59,7 @@ func decorateCustom(base *Charger, chargerEx func(float64) error, identifier fun
 			},
 		}
 
-	case battery == nil && chargerEx != nil && identifier == nil && phaseSwitcher != nil && resurrector == nil:
+	case battery == nil && chargerEx != nil && chargerPowerController == nil && identifier == nil && meter == nil && phaseSwitcher != nil && resurrector == nil:
 		return &struct {
 			*Charger
 			api.ChargerEx
@@ -74,7 +74,7 @@ func decorateCustom(base *Charger, chargerEx func(float64) error, identifier fun
 			},
 		}
 
-	case battery == nil && chargerEx == nil && identifier != nil && phaseSwitcher != nil && resurrector == nil:
+	case battery == nil && chargerEx == nil && chargerPowerController == nil && identifier != nil && meter == nil && phaseSwitcher != nil && resurrector == nil:
 		return &struct {
 			*Charger
 			api.Identifier
@@ -89,7 +89,7 @@ func decorateCustom(base *Charger, chargerEx func(float64) error, identifier fun
 			},
 		}
 
-	case battery == nil && chargerEx != nil && identifier != nil && phaseSwitcher != nil && resurrector == nil:
+	case battery == nil && chargerEx != nil && chargerPowerController == nil && identifier != nil && meter == nil && phaseSwitcher != nil && resurrector == nil:
 		return &struct {
 			*Charger
 			api.ChargerEx
@@ -108,7 +108,7 @@ func decorateCustom(base *Charger, chargerEx func(float64) error, identifier fun
 			},
 		}
 
-	case battery == nil && chargerEx == nil && identifier == nil && phaseSwitcher == nil && resurrector != nil:
+	case battery == nil && chargerEx == nil && chargerPowerController == nil && identifier == nil && meter == nil && phaseSwitcher == nil && resurrector != nil:
 		return &struct {
 			*Charger
 			api.Resurrector
@@ -119,7 +119,7 @@ func decorateCustom(base *Charger, chargerEx func(float64) error, identifier fun
 			},
 		}
 
-	case battery == nil && chargerEx != nil && identifier == nil && phaseSwitcher == nil && resurrector != nil:
+	case battery == nil && chargerEx != nil && chargerPowerController == nil && identifier == nil && meter == nil && phaseSwitcher == nil && resurrector != nil:
 		return &struct {
 			*Charger
 			api.ChargerEx
@@ -134,7 +134,7 @@ func decorateCustom(base *Charger, chargerEx func(float64) error, identifier fun
 			},
 		}
 
-	case battery == nil && chargerEx == nil && identifier != nil && phaseSwitcher == nil && resurrector != nil:
+	case battery == nil && chargerEx == nil && chargerPowerController == nil && identifier != nil && meter == nil && phaseSwitcher == nil && resurrector != nil:
 		return &struct {
 			*Charger
 			api.Identifier
@@ -149,7 +149,7 @@ func decorateCustom(base *Charger, chargerEx func(float64) error, identifier fun
 			},
 		}
 
-	case battery == nil && chargerEx != nil && identifier != nil && phaseSwitcher == nil && resurrector != nil:
+	case battery == nil && chargerEx != nil && chargerPowerController == nil && identifier != nil && meter == nil && phaseSwitcher == nil && resurrector != nil:
 		return &struct {
 			*Charger
 			api.ChargerEx
@@ -168,7 +168,7 @@ func decorateCustom(base *Charger, chargerEx func(float64) error, identifier fun
 			},
 		}
 
-	case battery == nil && chargerEx == nil && identifier == nil && phaseSwitcher != nil && resurrector != nil:
+	case battery == nil && chargerEx == nil && chargerPowerController == nil && identifier == nil && meter == nil && phaseSwitcher != nil && resurrector != nil:
 		return &struct {
 			*Charger
 			api.PhaseSwitcher
@@ -183,7 +183,7 @@ func decorateCustom(base *Charger, chargerEx func(float64) error, identifier fun
 			},
 		}
 
-	case battery == nil && chargerEx != nil && identifier == nil && phaseSwitcher != nil && resurrector != nil:
+	case battery == nil && chargerEx != nil && chargerPowerController == nil && identifier == nil && meter == nil && phaseSwitcher != nil && resurrector != nil:
 		return &struct {
 			*Charger
 			api.ChargerEx
@@ -202,7 +202,7 @@ func decorateCustom(base *Charger, chargerEx func(float64) error, identifier fun
 			},
 		}
 
-	case battery == nil && chargerEx == nil && identifier != nil && phaseSwitcher != nil && resurrector != nil:
+	case battery == nil && chargerEx == nil && chargerPowerController == nil && identifier != nil && meter == nil && phaseSwitcher != nil && resurrector != nil:
 		return &struct {
 			*Charger
 			api.Identifier
@@ -221,7 +221,7 @@ func decorateCustom(base *Charger, chargerEx func(float64) error, identifier fun
 			},
 		}
 
-	case battery == nil && chargerEx != nil && identifier != nil && phaseSwitcher != nil && resurrector != nil:
+	case battery == nil && chargerEx != nil && chargerPowerController == nil && identifier != nil && meter == nil && phaseSwitcher != nil && resurrector != nil:
 		return &struct {
 			*Charger
 			api.ChargerEx
@@ -244,7 +244,7 @@ func decorateCustom(base *Charger, chargerEx func(float64) error, identifier fun
 			},
 		}
 
-	case battery != nil && chargerEx == nil && identifier == nil && phaseSwitcher == nil && resurrector == nil:
+	case battery != nil && chargerEx == nil && chargerPowerController == nil && identifier == nil && meter == nil && phaseSwitcher == nil && resurrector == nil:
 		return &struct {
 			*Charger
 			api.Battery
@@ -255,7 +255,7 @@ func decorateCustom(base *Charger, chargerEx func(float64) error, identifier fun
 			},
 		}
 
-	case battery != nil && chargerEx != nil && identifier == nil && phaseSwitcher == nil && resurrector == nil:
+	case battery != nil && chargerEx != nil && chargerPowerController == nil && identifier == nil && meter == nil && phaseSwitcher == nil && resurrector == nil:
 		return &struct {
 			*Charger
 			api.Battery
@@ -270,7 +270,7 @@ func decorateCustom(base *Charger, chargerEx func(float64) error, identifier fun
 			},
 		}
 
-	case battery != nil && chargerEx == nil && identifier != nil && phaseSwitcher == nil && resurrector == nil:
+	case battery != nil && chargerEx == nil && chargerPowerController == nil && identifier != nil && meter == nil && phaseSwitcher == nil && resurrector == nil:
 		return &struct {
 			*Charger
 			api.Battery
@@ -285,7 +285,7 @@ func decorateCustom(base *Charger, chargerEx func(float64) error, identifier fun
 			},
 		}
 
-	case battery != nil && chargerEx != nil && identifier != nil && phaseSwitcher == nil && resurrector == nil:
+	case battery != nil && chargerEx != nil && chargerPowerController == nil && identifier != nil && meter == nil && phaseSwitcher == nil && resurrector == nil:
 		return &struct {
 			*Charger
 			api.Battery
@@ -304,7 +304,7 @@ func decorateCustom(base *Charger, chargerEx func(float64) error, identifier fun
 			},
 		}
 
-	case battery != nil && chargerEx == nil && identifier == nil && phaseSwitcher != nil && resurrector == nil:
+	case battery != nil && chargerEx == nil && chargerPowerController == nil && identifier == nil && meter == nil && phaseSwitcher != nil && resurrector == nil:
 		return &struct {
 			*Charger
 			api.Battery
@@ -319,7 +319,7 @@ func decorateCustom(base *Charger, chargerEx func(float64) error, identifier fun
 			},
 		}
 
-	case battery != nil && chargerEx != nil && identifier == nil && phaseSwitcher != nil && resurrector == nil:
+	case battery != nil && chargerEx != nil && chargerPowerController == nil && identifier == nil && meter == nil && phaseSwitcher != nil && resurrector == nil:
 		return &struct {
 			*Charger
 			api.Battery
@@ -338,7 +338,7 @@ func decorateCustom(base *Charger, chargerEx func(float64) error, identifier fun
 			},
 		}
 
-	case battery != nil && chargerEx == nil && identifier != nil && phaseSwitcher != nil && resurrector == nil:
+	case battery != nil && chargerEx == nil && chargerPowerController == nil && identifier != nil && meter == nil && phaseSwitcher != nil && resurrector == nil:
 		return &struct {
 			*Charger
 			api.Battery
@@ -357,7 +357,7 @@ func decorateCustom(base *Charger, chargerEx func(float64) error, identifier fun
 			},
 		}
 
-	case battery != nil && chargerEx != nil && identifier != nil && phaseSwitcher != nil && resurrector == nil:
+	case battery != nil && chargerEx != nil && chargerPowerController == nil && identifier != nil && meter == nil && phaseSwitcher != nil && resurrector == nil:
 		return &struct {
 			*Charger
 			api.Battery
@@ -380,7 +380,7 @@ func decorateCustom(base *Charger, chargerEx func(float64) error, identifier fun
 			},
 		}
 
-	case battery != nil && chargerEx == nil && identifier == nil && phaseSwitcher == nil && resurrector != nil:
+	case battery != nil && chargerEx == nil && chargerPowerController == nil && identifier == nil && meter == nil && phaseSwitcher == nil && resurrector != nil:
 		return &struct {
 			*Charger
 			api.Battery
@@ -395,7 +395,7 @@ func decorateCustom(base *Charger, chargerEx func(float64) error, identifier fun
 			},
 		}
 
-	case battery != nil && chargerEx != nil && identifier == nil && phaseSwitcher == nil && resurrector != nil:
+	case battery != nil && chargerEx != nil && chargerPowerController == nil && identifier == nil && meter == nil && phaseSwitcher == nil && resurrector != nil:
 		return &struct {
 			*Charger
 			api.Battery
@@ -414,7 +414,7 @@ func decorateCustom(base *Charger, chargerEx func(float64) error, identifier fun
 			},
 		}
 
-	case battery != nil && chargerEx == nil && identifier != nil && phaseSwitcher == nil && resurrector != nil:
+	case battery != nil && chargerEx == nil && chargerPowerController == nil && identifier != nil && meter == nil && phaseSwitcher == nil && resurrector != nil:
 		return &struct {
 			*Charger
 			api.Battery
@@ -433,7 +433,7 @@ func decorateCustom(base *Charger, chargerEx func(float64) error, identifier fun
 			},
 		}
 
-	case battery != nil && chargerEx != nil && identifier != nil && phaseSwitcher == nil && resurrector != nil:
+	case battery != nil && chargerEx != nil && chargerPowerController == nil && identifier != nil && meter == nil && phaseSwitcher == nil && resurrector != nil:
 		return &struct {
 			*Charger
 			api.Battery
@@ -456,7 +456,7 @@ func decorateCustom(base *Charger, chargerEx func(float64) error, identifier fun
 			},
 		}
 
-	case battery != nil && chargerEx == nil && identifier == nil && phaseSwitcher != nil && resurrector != nil:
+	case battery != nil && chargerEx == nil && chargerPowerController == nil && identifier == nil && meter == nil && phaseSwitcher != nil && resurrector != nil:
 		return &struct {
 			*Charger
 			api.Battery
@@ -475,7 +475,7 @@ func decorateCustom(base *Charger, chargerEx func(float64) error, identifier fun
 			},
 		}
 
-	case battery != nil && chargerEx != nil && identifier == nil && phaseSwitcher != nil && resurrector != nil:
+	case battery != nil && chargerEx != nil && chargerPowerController == nil && identifier == nil && meter == nil && phaseSwitcher != nil && resurrector != nil:
 		return &struct {
 			*Charger
 			api.Battery
@@ -498,7 +498,7 @@ func decorateCustom(base *Charger, chargerEx func(float64) error, identifier fun
 			},
 		}
 
-	case battery != nil && chargerEx == nil && identifier != nil && phaseSwitcher != nil && resurrector != nil:
+	case battery != nil && chargerEx == nil && chargerPowerController == nil && identifier != nil && meter == nil && phaseSwitcher != nil && resurrector != nil:
 		return &struct {
 			*Charger
 			api.Battery
@@ -521,7 +521,7 @@ func decorateCustom(base *Charger, chargerEx func(float64) error, identifier fun
 			},
 		}
 
-	case battery != nil && chargerEx != nil && identifier != nil && phaseSwitcher != nil && resurrector != nil:
+	case battery != nil && chargerEx != nil && chargerPowerController == nil && identifier != nil && meter == nil && phaseSwitcher != nil && resurrector != nil:
 		return &struct {
 			*Charger
 			api.Battery
@@ -547,6 +547,2150 @@ func decorateCustom(base *Charger, chargerEx func(float64) error, identifier fun
 				resurrector: resurrector,
 			},
 		}
+
+	case battery == nil && chargerEx == nil && chargerPowerController != nil && identifier == nil && meter == nil && phaseSwitcher == nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.ChargerPowerController
+		}{
+			Charger: base,
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+		}
+
+	case battery == nil && chargerEx != nil && chargerPowerController != nil && identifier == nil && meter == nil && phaseSwitcher == nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.ChargerEx
+			api.ChargerPowerController
+		}{
+			Charger: base,
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+		}
+
+	case battery == nil && chargerEx == nil && chargerPowerController != nil && identifier != nil && meter == nil && phaseSwitcher == nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.ChargerPowerController
+			api.Identifier
+		}{
+			Charger: base,
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+		}
+
+	case battery == nil && chargerEx != nil && chargerPowerController != nil && identifier != nil && meter == nil && phaseSwitcher == nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.ChargerEx
+			api.ChargerPowerController
+			api.Identifier
+		}{
+			Charger: base,
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+		}
+
+	case battery == nil && chargerEx == nil && chargerPowerController != nil && identifier == nil && meter == nil && phaseSwitcher != nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.ChargerPowerController
+			api.PhaseSwitcher
+		}{
+			Charger: base,
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+		}
+
+	case battery == nil && chargerEx != nil && chargerPowerController != nil && identifier == nil && meter == nil && phaseSwitcher != nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.ChargerEx
+			api.ChargerPowerController
+			api.PhaseSwitcher
+		}{
+			Charger: base,
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+		}
+
+	case battery == nil && chargerEx == nil && chargerPowerController != nil && identifier != nil && meter == nil && phaseSwitcher != nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.ChargerPowerController
+			api.Identifier
+			api.PhaseSwitcher
+		}{
+			Charger: base,
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+		}
+
+	case battery == nil && chargerEx != nil && chargerPowerController != nil && identifier != nil && meter == nil && phaseSwitcher != nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.ChargerEx
+			api.ChargerPowerController
+			api.Identifier
+			api.PhaseSwitcher
+		}{
+			Charger: base,
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+		}
+
+	case battery == nil && chargerEx == nil && chargerPowerController != nil && identifier == nil && meter == nil && phaseSwitcher == nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.ChargerPowerController
+			api.Resurrector
+		}{
+			Charger: base,
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery == nil && chargerEx != nil && chargerPowerController != nil && identifier == nil && meter == nil && phaseSwitcher == nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.ChargerEx
+			api.ChargerPowerController
+			api.Resurrector
+		}{
+			Charger: base,
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery == nil && chargerEx == nil && chargerPowerController != nil && identifier != nil && meter == nil && phaseSwitcher == nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.ChargerPowerController
+			api.Identifier
+			api.Resurrector
+		}{
+			Charger: base,
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery == nil && chargerEx != nil && chargerPowerController != nil && identifier != nil && meter == nil && phaseSwitcher == nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.ChargerEx
+			api.ChargerPowerController
+			api.Identifier
+			api.Resurrector
+		}{
+			Charger: base,
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery == nil && chargerEx == nil && chargerPowerController != nil && identifier == nil && meter == nil && phaseSwitcher != nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.ChargerPowerController
+			api.PhaseSwitcher
+			api.Resurrector
+		}{
+			Charger: base,
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery == nil && chargerEx != nil && chargerPowerController != nil && identifier == nil && meter == nil && phaseSwitcher != nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.ChargerEx
+			api.ChargerPowerController
+			api.PhaseSwitcher
+			api.Resurrector
+		}{
+			Charger: base,
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery == nil && chargerEx == nil && chargerPowerController != nil && identifier != nil && meter == nil && phaseSwitcher != nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.ChargerPowerController
+			api.Identifier
+			api.PhaseSwitcher
+			api.Resurrector
+		}{
+			Charger: base,
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery == nil && chargerEx != nil && chargerPowerController != nil && identifier != nil && meter == nil && phaseSwitcher != nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.ChargerEx
+			api.ChargerPowerController
+			api.Identifier
+			api.PhaseSwitcher
+			api.Resurrector
+		}{
+			Charger: base,
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery != nil && chargerEx == nil && chargerPowerController != nil && identifier == nil && meter == nil && phaseSwitcher == nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.ChargerPowerController
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+		}
+
+	case battery != nil && chargerEx != nil && chargerPowerController != nil && identifier == nil && meter == nil && phaseSwitcher == nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.ChargerEx
+			api.ChargerPowerController
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+		}
+
+	case battery != nil && chargerEx == nil && chargerPowerController != nil && identifier != nil && meter == nil && phaseSwitcher == nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.ChargerPowerController
+			api.Identifier
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+		}
+
+	case battery != nil && chargerEx != nil && chargerPowerController != nil && identifier != nil && meter == nil && phaseSwitcher == nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.ChargerEx
+			api.ChargerPowerController
+			api.Identifier
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+		}
+
+	case battery != nil && chargerEx == nil && chargerPowerController != nil && identifier == nil && meter == nil && phaseSwitcher != nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.ChargerPowerController
+			api.PhaseSwitcher
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+		}
+
+	case battery != nil && chargerEx != nil && chargerPowerController != nil && identifier == nil && meter == nil && phaseSwitcher != nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.ChargerEx
+			api.ChargerPowerController
+			api.PhaseSwitcher
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+		}
+
+	case battery != nil && chargerEx == nil && chargerPowerController != nil && identifier != nil && meter == nil && phaseSwitcher != nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.ChargerPowerController
+			api.Identifier
+			api.PhaseSwitcher
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+		}
+
+	case battery != nil && chargerEx != nil && chargerPowerController != nil && identifier != nil && meter == nil && phaseSwitcher != nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.ChargerEx
+			api.ChargerPowerController
+			api.Identifier
+			api.PhaseSwitcher
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+		}
+
+	case battery != nil && chargerEx == nil && chargerPowerController != nil && identifier == nil && meter == nil && phaseSwitcher == nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.ChargerPowerController
+			api.Resurrector
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery != nil && chargerEx != nil && chargerPowerController != nil && identifier == nil && meter == nil && phaseSwitcher == nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.ChargerEx
+			api.ChargerPowerController
+			api.Resurrector
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery != nil && chargerEx == nil && chargerPowerController != nil && identifier != nil && meter == nil && phaseSwitcher == nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.ChargerPowerController
+			api.Identifier
+			api.Resurrector
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery != nil && chargerEx != nil && chargerPowerController != nil && identifier != nil && meter == nil && phaseSwitcher == nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.ChargerEx
+			api.ChargerPowerController
+			api.Identifier
+			api.Resurrector
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery != nil && chargerEx == nil && chargerPowerController != nil && identifier == nil && meter == nil && phaseSwitcher != nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.ChargerPowerController
+			api.PhaseSwitcher
+			api.Resurrector
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery != nil && chargerEx != nil && chargerPowerController != nil && identifier == nil && meter == nil && phaseSwitcher != nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.ChargerEx
+			api.ChargerPowerController
+			api.PhaseSwitcher
+			api.Resurrector
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery != nil && chargerEx == nil && chargerPowerController != nil && identifier != nil && meter == nil && phaseSwitcher != nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.ChargerPowerController
+			api.Identifier
+			api.PhaseSwitcher
+			api.Resurrector
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery != nil && chargerEx != nil && chargerPowerController != nil && identifier != nil && meter == nil && phaseSwitcher != nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.ChargerEx
+			api.ChargerPowerController
+			api.Identifier
+			api.PhaseSwitcher
+			api.Resurrector
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery == nil && chargerEx == nil && chargerPowerController == nil && identifier == nil && meter != nil && phaseSwitcher == nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.Meter
+		}{
+			Charger: base,
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+		}
+
+	case battery == nil && chargerEx != nil && chargerPowerController == nil && identifier == nil && meter != nil && phaseSwitcher == nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.ChargerEx
+			api.Meter
+		}{
+			Charger: base,
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+		}
+
+	case battery == nil && chargerEx == nil && chargerPowerController == nil && identifier != nil && meter != nil && phaseSwitcher == nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.Identifier
+			api.Meter
+		}{
+			Charger: base,
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+		}
+
+	case battery == nil && chargerEx != nil && chargerPowerController == nil && identifier != nil && meter != nil && phaseSwitcher == nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.ChargerEx
+			api.Identifier
+			api.Meter
+		}{
+			Charger: base,
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+		}
+
+	case battery == nil && chargerEx == nil && chargerPowerController == nil && identifier == nil && meter != nil && phaseSwitcher != nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.Meter
+			api.PhaseSwitcher
+		}{
+			Charger: base,
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+		}
+
+	case battery == nil && chargerEx != nil && chargerPowerController == nil && identifier == nil && meter != nil && phaseSwitcher != nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.ChargerEx
+			api.Meter
+			api.PhaseSwitcher
+		}{
+			Charger: base,
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+		}
+
+	case battery == nil && chargerEx == nil && chargerPowerController == nil && identifier != nil && meter != nil && phaseSwitcher != nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.Identifier
+			api.Meter
+			api.PhaseSwitcher
+		}{
+			Charger: base,
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+		}
+
+	case battery == nil && chargerEx != nil && chargerPowerController == nil && identifier != nil && meter != nil && phaseSwitcher != nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.ChargerEx
+			api.Identifier
+			api.Meter
+			api.PhaseSwitcher
+		}{
+			Charger: base,
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+		}
+
+	case battery == nil && chargerEx == nil && chargerPowerController == nil && identifier == nil && meter != nil && phaseSwitcher == nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.Meter
+			api.Resurrector
+		}{
+			Charger: base,
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery == nil && chargerEx != nil && chargerPowerController == nil && identifier == nil && meter != nil && phaseSwitcher == nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.ChargerEx
+			api.Meter
+			api.Resurrector
+		}{
+			Charger: base,
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery == nil && chargerEx == nil && chargerPowerController == nil && identifier != nil && meter != nil && phaseSwitcher == nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.Identifier
+			api.Meter
+			api.Resurrector
+		}{
+			Charger: base,
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery == nil && chargerEx != nil && chargerPowerController == nil && identifier != nil && meter != nil && phaseSwitcher == nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.ChargerEx
+			api.Identifier
+			api.Meter
+			api.Resurrector
+		}{
+			Charger: base,
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery == nil && chargerEx == nil && chargerPowerController == nil && identifier == nil && meter != nil && phaseSwitcher != nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.Meter
+			api.PhaseSwitcher
+			api.Resurrector
+		}{
+			Charger: base,
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery == nil && chargerEx != nil && chargerPowerController == nil && identifier == nil && meter != nil && phaseSwitcher != nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.ChargerEx
+			api.Meter
+			api.PhaseSwitcher
+			api.Resurrector
+		}{
+			Charger: base,
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery == nil && chargerEx == nil && chargerPowerController == nil && identifier != nil && meter != nil && phaseSwitcher != nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.Identifier
+			api.Meter
+			api.PhaseSwitcher
+			api.Resurrector
+		}{
+			Charger: base,
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery == nil && chargerEx != nil && chargerPowerController == nil && identifier != nil && meter != nil && phaseSwitcher != nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.ChargerEx
+			api.Identifier
+			api.Meter
+			api.PhaseSwitcher
+			api.Resurrector
+		}{
+			Charger: base,
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery != nil && chargerEx == nil && chargerPowerController == nil && identifier == nil && meter != nil && phaseSwitcher == nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.Meter
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+		}
+
+	case battery != nil && chargerEx != nil && chargerPowerController == nil && identifier == nil && meter != nil && phaseSwitcher == nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.ChargerEx
+			api.Meter
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+		}
+
+	case battery != nil && chargerEx == nil && chargerPowerController == nil && identifier != nil && meter != nil && phaseSwitcher == nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.Identifier
+			api.Meter
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+		}
+
+	case battery != nil && chargerEx != nil && chargerPowerController == nil && identifier != nil && meter != nil && phaseSwitcher == nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.ChargerEx
+			api.Identifier
+			api.Meter
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+		}
+
+	case battery != nil && chargerEx == nil && chargerPowerController == nil && identifier == nil && meter != nil && phaseSwitcher != nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.Meter
+			api.PhaseSwitcher
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+		}
+
+	case battery != nil && chargerEx != nil && chargerPowerController == nil && identifier == nil && meter != nil && phaseSwitcher != nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.ChargerEx
+			api.Meter
+			api.PhaseSwitcher
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+		}
+
+	case battery != nil && chargerEx == nil && chargerPowerController == nil && identifier != nil && meter != nil && phaseSwitcher != nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.Identifier
+			api.Meter
+			api.PhaseSwitcher
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+		}
+
+	case battery != nil && chargerEx != nil && chargerPowerController == nil && identifier != nil && meter != nil && phaseSwitcher != nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.ChargerEx
+			api.Identifier
+			api.Meter
+			api.PhaseSwitcher
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+		}
+
+	case battery != nil && chargerEx == nil && chargerPowerController == nil && identifier == nil && meter != nil && phaseSwitcher == nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.Meter
+			api.Resurrector
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery != nil && chargerEx != nil && chargerPowerController == nil && identifier == nil && meter != nil && phaseSwitcher == nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.ChargerEx
+			api.Meter
+			api.Resurrector
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery != nil && chargerEx == nil && chargerPowerController == nil && identifier != nil && meter != nil && phaseSwitcher == nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.Identifier
+			api.Meter
+			api.Resurrector
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery != nil && chargerEx != nil && chargerPowerController == nil && identifier != nil && meter != nil && phaseSwitcher == nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.ChargerEx
+			api.Identifier
+			api.Meter
+			api.Resurrector
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery != nil && chargerEx == nil && chargerPowerController == nil && identifier == nil && meter != nil && phaseSwitcher != nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.Meter
+			api.PhaseSwitcher
+			api.Resurrector
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery != nil && chargerEx != nil && chargerPowerController == nil && identifier == nil && meter != nil && phaseSwitcher != nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.ChargerEx
+			api.Meter
+			api.PhaseSwitcher
+			api.Resurrector
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery != nil && chargerEx == nil && chargerPowerController == nil && identifier != nil && meter != nil && phaseSwitcher != nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.Identifier
+			api.Meter
+			api.PhaseSwitcher
+			api.Resurrector
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery != nil && chargerEx != nil && chargerPowerController == nil && identifier != nil && meter != nil && phaseSwitcher != nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.ChargerEx
+			api.Identifier
+			api.Meter
+			api.PhaseSwitcher
+			api.Resurrector
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery == nil && chargerEx == nil && chargerPowerController != nil && identifier == nil && meter != nil && phaseSwitcher == nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.ChargerPowerController
+			api.Meter
+		}{
+			Charger: base,
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+		}
+
+	case battery == nil && chargerEx != nil && chargerPowerController != nil && identifier == nil && meter != nil && phaseSwitcher == nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.ChargerEx
+			api.ChargerPowerController
+			api.Meter
+		}{
+			Charger: base,
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+		}
+
+	case battery == nil && chargerEx == nil && chargerPowerController != nil && identifier != nil && meter != nil && phaseSwitcher == nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.ChargerPowerController
+			api.Identifier
+			api.Meter
+		}{
+			Charger: base,
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+		}
+
+	case battery == nil && chargerEx != nil && chargerPowerController != nil && identifier != nil && meter != nil && phaseSwitcher == nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.ChargerEx
+			api.ChargerPowerController
+			api.Identifier
+			api.Meter
+		}{
+			Charger: base,
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+		}
+
+	case battery == nil && chargerEx == nil && chargerPowerController != nil && identifier == nil && meter != nil && phaseSwitcher != nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.ChargerPowerController
+			api.Meter
+			api.PhaseSwitcher
+		}{
+			Charger: base,
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+		}
+
+	case battery == nil && chargerEx != nil && chargerPowerController != nil && identifier == nil && meter != nil && phaseSwitcher != nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.ChargerEx
+			api.ChargerPowerController
+			api.Meter
+			api.PhaseSwitcher
+		}{
+			Charger: base,
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+		}
+
+	case battery == nil && chargerEx == nil && chargerPowerController != nil && identifier != nil && meter != nil && phaseSwitcher != nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.ChargerPowerController
+			api.Identifier
+			api.Meter
+			api.PhaseSwitcher
+		}{
+			Charger: base,
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+		}
+
+	case battery == nil && chargerEx != nil && chargerPowerController != nil && identifier != nil && meter != nil && phaseSwitcher != nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.ChargerEx
+			api.ChargerPowerController
+			api.Identifier
+			api.Meter
+			api.PhaseSwitcher
+		}{
+			Charger: base,
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+		}
+
+	case battery == nil && chargerEx == nil && chargerPowerController != nil && identifier == nil && meter != nil && phaseSwitcher == nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.ChargerPowerController
+			api.Meter
+			api.Resurrector
+		}{
+			Charger: base,
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery == nil && chargerEx != nil && chargerPowerController != nil && identifier == nil && meter != nil && phaseSwitcher == nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.ChargerEx
+			api.ChargerPowerController
+			api.Meter
+			api.Resurrector
+		}{
+			Charger: base,
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery == nil && chargerEx == nil && chargerPowerController != nil && identifier != nil && meter != nil && phaseSwitcher == nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.ChargerPowerController
+			api.Identifier
+			api.Meter
+			api.Resurrector
+		}{
+			Charger: base,
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery == nil && chargerEx != nil && chargerPowerController != nil && identifier != nil && meter != nil && phaseSwitcher == nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.ChargerEx
+			api.ChargerPowerController
+			api.Identifier
+			api.Meter
+			api.Resurrector
+		}{
+			Charger: base,
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery == nil && chargerEx == nil && chargerPowerController != nil && identifier == nil && meter != nil && phaseSwitcher != nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.ChargerPowerController
+			api.Meter
+			api.PhaseSwitcher
+			api.Resurrector
+		}{
+			Charger: base,
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery == nil && chargerEx != nil && chargerPowerController != nil && identifier == nil && meter != nil && phaseSwitcher != nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.ChargerEx
+			api.ChargerPowerController
+			api.Meter
+			api.PhaseSwitcher
+			api.Resurrector
+		}{
+			Charger: base,
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery == nil && chargerEx == nil && chargerPowerController != nil && identifier != nil && meter != nil && phaseSwitcher != nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.ChargerPowerController
+			api.Identifier
+			api.Meter
+			api.PhaseSwitcher
+			api.Resurrector
+		}{
+			Charger: base,
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery == nil && chargerEx != nil && chargerPowerController != nil && identifier != nil && meter != nil && phaseSwitcher != nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.ChargerEx
+			api.ChargerPowerController
+			api.Identifier
+			api.Meter
+			api.PhaseSwitcher
+			api.Resurrector
+		}{
+			Charger: base,
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery != nil && chargerEx == nil && chargerPowerController != nil && identifier == nil && meter != nil && phaseSwitcher == nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.ChargerPowerController
+			api.Meter
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+		}
+
+	case battery != nil && chargerEx != nil && chargerPowerController != nil && identifier == nil && meter != nil && phaseSwitcher == nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.ChargerEx
+			api.ChargerPowerController
+			api.Meter
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+		}
+
+	case battery != nil && chargerEx == nil && chargerPowerController != nil && identifier != nil && meter != nil && phaseSwitcher == nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.ChargerPowerController
+			api.Identifier
+			api.Meter
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+		}
+
+	case battery != nil && chargerEx != nil && chargerPowerController != nil && identifier != nil && meter != nil && phaseSwitcher == nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.ChargerEx
+			api.ChargerPowerController
+			api.Identifier
+			api.Meter
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+		}
+
+	case battery != nil && chargerEx == nil && chargerPowerController != nil && identifier == nil && meter != nil && phaseSwitcher != nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.ChargerPowerController
+			api.Meter
+			api.PhaseSwitcher
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+		}
+
+	case battery != nil && chargerEx != nil && chargerPowerController != nil && identifier == nil && meter != nil && phaseSwitcher != nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.ChargerEx
+			api.ChargerPowerController
+			api.Meter
+			api.PhaseSwitcher
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+		}
+
+	case battery != nil && chargerEx == nil && chargerPowerController != nil && identifier != nil && meter != nil && phaseSwitcher != nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.ChargerPowerController
+			api.Identifier
+			api.Meter
+			api.PhaseSwitcher
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+		}
+
+	case battery != nil && chargerEx != nil && chargerPowerController != nil && identifier != nil && meter != nil && phaseSwitcher != nil && resurrector == nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.ChargerEx
+			api.ChargerPowerController
+			api.Identifier
+			api.Meter
+			api.PhaseSwitcher
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+		}
+
+	case battery != nil && chargerEx == nil && chargerPowerController != nil && identifier == nil && meter != nil && phaseSwitcher == nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.ChargerPowerController
+			api.Meter
+			api.Resurrector
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery != nil && chargerEx != nil && chargerPowerController != nil && identifier == nil && meter != nil && phaseSwitcher == nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.ChargerEx
+			api.ChargerPowerController
+			api.Meter
+			api.Resurrector
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery != nil && chargerEx == nil && chargerPowerController != nil && identifier != nil && meter != nil && phaseSwitcher == nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.ChargerPowerController
+			api.Identifier
+			api.Meter
+			api.Resurrector
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery != nil && chargerEx != nil && chargerPowerController != nil && identifier != nil && meter != nil && phaseSwitcher == nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.ChargerEx
+			api.ChargerPowerController
+			api.Identifier
+			api.Meter
+			api.Resurrector
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery != nil && chargerEx == nil && chargerPowerController != nil && identifier == nil && meter != nil && phaseSwitcher != nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.ChargerPowerController
+			api.Meter
+			api.PhaseSwitcher
+			api.Resurrector
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery != nil && chargerEx != nil && chargerPowerController != nil && identifier == nil && meter != nil && phaseSwitcher != nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.ChargerEx
+			api.ChargerPowerController
+			api.Meter
+			api.PhaseSwitcher
+			api.Resurrector
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery != nil && chargerEx == nil && chargerPowerController != nil && identifier != nil && meter != nil && phaseSwitcher != nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.ChargerPowerController
+			api.Identifier
+			api.Meter
+			api.PhaseSwitcher
+			api.Resurrector
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
+
+	case battery != nil && chargerEx != nil && chargerPowerController != nil && identifier != nil && meter != nil && phaseSwitcher != nil && resurrector != nil:
+		return &struct {
+			*Charger
+			api.Battery
+			api.ChargerEx
+			api.ChargerPowerController
+			api.Identifier
+			api.Meter
+			api.PhaseSwitcher
+			api.Resurrector
+		}{
+			Charger: base,
+			Battery: &decorateCustomBatteryImpl{
+				battery: battery,
+			},
+			ChargerEx: &decorateCustomChargerExImpl{
+				chargerEx: chargerEx,
+			},
+			ChargerPowerController: &decorateCustomChargerPowerControllerImpl{
+				chargerPowerController: chargerPowerController,
+			},
+			Identifier: &decorateCustomIdentifierImpl{
+				identifier: identifier,
+			},
+			Meter: &decorateCustomMeterImpl{
+				meter: meter,
+			},
+			PhaseSwitcher: &decorateCustomPhaseSwitcherImpl{
+				phaseSwitcher: phaseSwitcher,
+			},
+			Resurrector: &decorateCustomResurrectorImpl{
+				resurrector: resurrector,
+			},
+		}
 	}
 
 	return nil
@@ -568,6 +2712,14 @@ func (impl *decorateCustomChargerExImpl) MaxCurrentMillis(p0 float64) error {
 	return impl.chargerEx(p0)
 }
 
+type decorateCustomChargerPowerControllerImpl struct {
+	chargerPowerController func(float64) error
+}
+
+func (impl *decorateCustomChargerPowerControllerImpl) MaxPower(p0 float64) error {
+	return impl.chargerPowerController(p0)
+}
+
 type decorateCustomIdentifierImpl struct {
 	identifier func() (string, error)
 }
@@ -576,6 +2728,14 @@ func (impl *decorateCustomIdentifierImpl) Identify() (string, error) {
 	return impl.identifier()
 }
 
+type decorateCustomMeterImpl struct {
+	meter func() (float64, error)
+}
+
+func (impl *decorateCustomMeterImpl) CurrentPower() (float64, error) {
+	return impl.meter()
+}
+
 type decorateCustomPhaseSwitcherImpl struct {
 	phaseSwitcher func(int) error
 }
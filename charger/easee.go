@@ -35,6 +35,7 @@ import (
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/request"
 	"github.com/evcc-io/evcc/util/sponsor"
+	"github.com/evcc-io/evcc/util/webhook"
 	"github.com/philippseith/signalr"
 	"github.com/samber/lo"
 	"golang.org/x/oauth2"
@@ -85,6 +86,8 @@ func NewEaseeFromConfig(other map[string]interface{}) (api.Charger, error) {
 		Charger   string
 		Timeout   time.Duration
 		Authorize bool
+		Webhook   string
+		Offline   int
 	}{
 		Timeout: request.Timeout,
 	}
@@ -97,7 +100,22 @@ func NewEaseeFromConfig(other map[string]interface{}) (api.Charger, error) {
 		return nil, api.ErrMissingCredentials
 	}
 
-	return NewEasee(cc.User, cc.Password, cc.Charger, cc.Timeout, cc.Authorize)
+	c, err := NewEasee(cc.User, cc.Password, cc.Charger, cc.Timeout, cc.Authorize)
+	if err != nil {
+		return c, err
+	}
+
+	if cc.Offline > 0 {
+		if err := c.configureOffline(cc.Offline); err != nil {
+			return c, err
+		}
+	}
+
+	if cc.Webhook != "" {
+		err = webhook.Register(cc.Webhook, c.push)
+	}
+
+	return c, err
 }
 
 // NewEasee creates Easee charger
@@ -838,6 +856,70 @@ func (c *Easee) updateSmartCharging() {
 	}
 }
 
+// configureOffline sets the circuit current the charger falls back to when losing its cloud connection.
+// Easee offers no documented local control interface (UDP, BLE or Modbus), evcc therefore cannot control
+// the charger during cloud outages and the charger keeps charging autonomously with this current instead.
+func (c *Easee) configureOffline(current int) error {
+	if current < 6 {
+		return fmt.Errorf("invalid offline current: %dA, minimum is 6A", current)
+	}
+
+	if c.circuit == 0 {
+		return errors.New("offline current requires a single charger per circuit")
+	}
+
+	uri := fmt.Sprintf("%s/sites/%d/circuits/%d/settings", easee.API, c.site, c.circuit)
+
+	var res easee.CircuitSettings
+	if err := c.GetJSON(uri, &res); err != nil {
+		return err
+	}
+
+	if res.OfflineMaxCircuitCurrentP1 != nil && *res.OfflineMaxCircuitCurrentP1 == current &&
+		res.OfflineMaxCircuitCurrentP2 != nil && *res.OfflineMaxCircuitCurrentP2 == current &&
+		res.OfflineMaxCircuitCurrentP3 != nil && *res.OfflineMaxCircuitCurrentP3 == current {
+		return nil
+	}
+
+	c.log.DEBUG.Printf("offline current: %dA", current)
+
+	data := easee.CircuitSettings{
+		OfflineMaxCircuitCurrentP1: &current,
+		OfflineMaxCircuitCurrentP2: &current,
+		OfflineMaxCircuitCurrentP3: &current,
+	}
+
+	_, err := c.postJSONAndWait(uri, data)
+	return err
+}
+
+// push receives single or multiple observations pushed by the Easee cloud webhook
+// and requests an immediate loadpoint update if the op mode changed
+func (c *Easee) push(payload []byte) error {
+	var res []json.RawMessage
+	if err := json.Unmarshal(payload, &res); err != nil {
+		res = []json.RawMessage{payload}
+	}
+
+	c.mux.Lock()
+	opMode := c.opMode
+	c.mux.Unlock()
+
+	for _, obs := range res {
+		c.ProductUpdate(obs)
+	}
+
+	c.mux.Lock()
+	changed := c.opMode != opMode
+	c.mux.Unlock()
+
+	if changed && c.lp != nil {
+		c.lp.RequestUpdate()
+	}
+
+	return nil
+}
+
 var _ loadpoint.Controller = (*Easee)(nil)
 
 // LoadpointControl implements loadpoint.Controller
@@ -24,6 +24,13 @@ const (
 	voltage              float64 = 230
 )
 
+// isISO15118 returns true if the vehicle communicates digitally and may transmit its soc.
+// Same as cemd, any communication standard reported by the charger other than IEC 61851 is treated as ISO 15118.
+func isISO15118(comStandard emobility.EVCommunicationStandardType) bool {
+	return comStandard != emobility.EVCommunicationStandardTypeUnknown &&
+		comStandard != emobility.EVCommunicationStandardTypeIEC61851
+}
+
 type minMax struct {
 	min, max float64
 }
@@ -491,17 +498,31 @@ func (c *EEBus) Identify() (string, error) {
 var _ api.Battery = (*EEBus)(nil)
 
 // Soc implements the api.Vehicle interface
+// Soc is transmitted by vehicles communicating via ISO 15118 with value added services.
 func (c *EEBus) Soc() (float64, error) {
-	if socSupported, err := c.emobility.EVSoCSupported(); err != nil || !socSupported {
+	if !c.isConnected() || !c.emobility.EVConnected() {
 		return 0, api.ErrNotAvailable
 	}
 
-	soc, err := c.emobility.EVSoC()
-	if err != nil {
+	comStandard, err := c.emobility.EVCommunicationStandard()
+	if err == nil && !isISO15118(comStandard) {
 		return 0, api.ErrNotAvailable
 	}
 
-	return soc, nil
+	socSupported, err := c.emobility.EVSoCSupported()
+	if err == nil && socSupported {
+		var soc float64
+		if soc, err = c.emobility.EVSoC(); err == nil {
+			return soc, nil
+		}
+	}
+
+	// vehicle may still be negotiating the high level communication
+	if errors.Is(err, features.ErrDataNotAvailable) && time.Since(c.connectedTime) < maxIdRequestTimespan {
+		return 0, api.ErrMustRetry
+	}
+
+	return 0, api.ErrNotAvailable
 }
 
 var _ loadpoint.Controller = (*EEBus)(nil)
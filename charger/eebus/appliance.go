@@ -0,0 +1,275 @@
+package eebus
+
+import (
+	"sync"
+
+	"github.com/enbility/cemd/util"
+	"github.com/enbility/eebus-go/features"
+	"github.com/enbility/eebus-go/logging"
+	"github.com/enbility/eebus-go/service"
+	"github.com/enbility/eebus-go/spine"
+	"github.com/enbility/eebus-go/spine/model"
+	eebusutil "github.com/enbility/eebus-go/util"
+)
+
+// energy consumed scope of the monitoring of power consumption use case, not part of the spine model yet
+const scopeTypeACEnergyConsumed model.ScopeTypeType = "acEnergyConsumed"
+
+// ApplianceScenario implements the monitoring of power consumption use case for appliances like heat pumps or white goods
+type ApplianceScenario struct {
+	service *service.EEBUSService
+
+	mux           sync.Mutex
+	remoteDevices map[string]*Appliance
+}
+
+func NewApplianceScenario(service *service.EEBUSService) *ApplianceScenario {
+	return &ApplianceScenario{
+		service:       service,
+		remoteDevices: make(map[string]*Appliance),
+	}
+}
+
+// AddFeatures adds the client features required for monitoring to the local entity
+func (s *ApplianceScenario) AddFeatures() {
+	localEntity := s.service.LocalEntity()
+
+	for _, feature := range []model.FeatureTypeType{
+		model.FeatureTypeTypeElectricalConnection,
+		model.FeatureTypeTypeMeasurement,
+	} {
+		_ = localEntity.GetOrAddFeature(feature, model.RoleTypeClient)
+	}
+}
+
+// AddUseCases adds the monitoring of power consumption use case
+func (s *ApplianceScenario) AddUseCases() {
+	_ = spine.NewUseCase(
+		s.service.LocalEntity(),
+		model.UseCaseNameTypeMonitoringOfPowerConsumption,
+		model.SpecificationVersionType("1.0.0"),
+		[]model.UseCaseScenarioSupportType{1, 2, 3, 4, 5})
+}
+
+func (s *ApplianceScenario) RegisterRemoteDevice(details *service.ServiceDetails, _ any) any {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if a, ok := s.remoteDevices[details.SKI()]; ok {
+		return a
+	}
+
+	a := NewAppliance(s.service, details)
+	s.remoteDevices[details.SKI()] = a
+
+	return a
+}
+
+func (s *ApplianceScenario) UnRegisterRemoteDevice(ski string) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	delete(s.remoteDevices, ski)
+
+	return s.service.UnpairRemoteService(ski)
+}
+
+// Appliance is a remote device's entity providing power and energy measurements
+type Appliance struct {
+	service *service.EEBUSService
+	ski     string
+
+	mux                  sync.Mutex
+	entity               *spine.EntityRemoteImpl
+	measurement          *features.Measurement
+	electricalConnection *features.ElectricalConnection
+}
+
+func NewAppliance(service *service.EEBUSService, details *service.ServiceDetails) *Appliance {
+	a := &Appliance{
+		service: service,
+		ski:     eebusutil.NormalizeSKI(details.SKI()),
+	}
+
+	spine.Events.Subscribe(a)
+	service.PairRemoteService(details)
+
+	return a
+}
+
+// monitored returns true if the entity provides measurements
+func monitored(entity *spine.EntityRemoteImpl) bool {
+	if entity.EntityType() == model.EntityTypeTypeDeviceInformation {
+		return false
+	}
+
+	for _, f := range entity.Features() {
+		if f.Type() == model.FeatureTypeTypeMeasurement && f.Role() == model.RoleTypeServer {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HandleEvent implements the spine.EventHandler interface
+func (a *Appliance) HandleEvent(payload spine.EventPayload) {
+	if payload.Ski != a.ski || payload.Device != nil && payload.Device.Ski() != a.ski {
+		return
+	}
+
+	switch payload.EventType {
+	case spine.EventTypeDeviceChange:
+		if payload.ChangeType == spine.ElementChangeRemove {
+			a.disconnected(nil)
+		}
+
+	case spine.EventTypeEntityChange:
+		switch payload.ChangeType {
+		case spine.ElementChangeAdd:
+			if monitored(payload.Entity) {
+				a.connected(payload.Entity)
+			}
+		case spine.ElementChangeRemove:
+			a.disconnected(payload.Entity)
+		}
+
+	case spine.EventTypeDataChange:
+		if _, ok := payload.Data.(*model.MeasurementDescriptionListDataType); ok && payload.ChangeType == spine.ElementChangeUpdate {
+			a.mux.Lock()
+			measurement := a.measurement
+			a.mux.Unlock()
+
+			if measurement != nil {
+				if _, err := measurement.RequestValues(); err != nil {
+					logging.Log.Error("Error getting measurement list values:", err)
+				}
+			}
+		}
+	}
+}
+
+// connected subscribes to the entity's measurements, the first monitored entity is used
+func (a *Appliance) connected(entity *spine.EntityRemoteImpl) {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+
+	if a.entity != nil {
+		return
+	}
+
+	localDevice := a.service.LocalDevice()
+
+	measurement, err := features.NewMeasurement(model.RoleTypeClient, model.RoleTypeServer, localDevice, entity)
+	if err != nil {
+		logging.Log.Error(err)
+		return
+	}
+
+	electricalConnection, err := features.NewElectricalConnection(model.RoleTypeClient, model.RoleTypeServer, localDevice, entity)
+	if err != nil {
+		logging.Log.Error(err)
+		return
+	}
+
+	for _, fun := range []func() error{
+		measurement.SubscribeForEntity,
+		electricalConnection.SubscribeForEntity,
+		electricalConnection.RequestDescriptions,
+		measurement.RequestDescriptions,
+	} {
+		if err := fun(); err != nil {
+			logging.Log.Error(err)
+			return
+		}
+	}
+
+	a.entity = entity
+	a.measurement = measurement
+	a.electricalConnection = electricalConnection
+}
+
+// disconnected releases the monitored entity, nil for device removal
+func (a *Appliance) disconnected(entity *spine.EntityRemoteImpl) {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+
+	if entity != nil && entity != a.entity {
+		return
+	}
+
+	a.entity = nil
+	a.measurement = nil
+	a.electricalConnection = nil
+}
+
+// value returns the first measurement value of given type and scope. Must be called with lock held.
+func (a *Appliance) value(typ model.MeasurementTypeType, scope model.ScopeTypeType) (model.MeasurementDataType, error) {
+	if a.entity == nil {
+		return model.MeasurementDataType{}, util.ErrDeviceDisconnected
+	}
+
+	data, err := a.measurement.GetValuesForTypeCommodityScope(typ, model.CommodityTypeTypeElectricity, scope)
+	if err != nil {
+		return model.MeasurementDataType{}, err
+	}
+
+	if len(data) == 0 || data[0].Value == nil {
+		return model.MeasurementDataType{}, features.ErrDataNotAvailable
+	}
+
+	return data[0], nil
+}
+
+// Power returns the momentary power consumption (W)
+func (a *Appliance) Power() (float64, error) {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+
+	data, err := a.value(model.MeasurementTypeTypePower, model.ScopeTypeTypeACPowerTotal)
+	if err != nil {
+		return 0, err
+	}
+
+	res := data.Value.GetValue()
+
+	// positive values should be consumption, verify energy direction
+	if data.MeasurementId != nil {
+		desc, err := a.electricalConnection.GetDescriptionForMeasurementId(*data.MeasurementId)
+		if err == nil && desc.PositiveEnergyDirection != nil && *desc.PositiveEnergyDirection != model.EnergyDirectionTypeConsume {
+			res = -res
+		}
+	}
+
+	return res, nil
+}
+
+// Energy returns the total consumed energy (kWh)
+func (a *Appliance) Energy() (float64, error) {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+
+	data, err := a.value(model.MeasurementTypeTypeEnergy, scopeTypeACEnergyConsumed)
+	if err != nil {
+		return 0, err
+	}
+
+	res := data.Value.GetValue()
+
+	// energy is expected in Wh unless described otherwise
+	if data.MeasurementId != nil {
+		desc, err := a.measurement.GetDescriptionForMeasurementId(*data.MeasurementId)
+		if err == nil && desc.Unit != nil && *desc.Unit != model.UnitOfMeasurementTypeWh {
+			return res, nil
+		}
+	}
+
+	return res / 1e3, nil
+}
+
+// Connected returns true while a monitored entity is available
+func (a *Appliance) Connected() bool {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+	return a.entity != nil
+}
@@ -14,9 +14,9 @@ import (
 	"strings"
 	"sync"
 
-	"github.com/enbility/cemd/cem"
 	"github.com/enbility/cemd/emobility"
 	"github.com/enbility/eebus-go/service"
+	"github.com/enbility/eebus-go/spine"
 	"github.com/enbility/eebus-go/spine/model"
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/machine"
@@ -33,8 +33,12 @@ type EEBusClientCBs struct {
 	onDisconnect func(string)
 }
 
+// EEBus is the CEM service hosting the emobility and appliance monitoring scenarios.
+// The service is created directly instead of using cemd's CEM to allow registering additional scenarios.
 type EEBus struct {
-	Cem *cem.CemImpl
+	service    *service.EEBUSService
+	emobility  *emobility.EmobilityScenarioImpl
+	appliances *ApplianceScenario
 
 	mux sync.Mutex
 	log *util.Logger
@@ -116,25 +120,37 @@ func NewServer(other map[string]interface{}) (*EEBus, error) {
 		SKI:     ski,
 	}
 
-	c.Cem = cem.NewCEM(configuration, c, c)
-	if err := c.Cem.Setup(); err != nil {
+	c.service = service.NewEEBUSService(configuration, c)
+	c.service.SetLogging(c)
+
+	if err := c.service.Setup(); err != nil {
 		return nil, err
 	}
-	c.Cem.EnableEmobility(emobility.EmobilityConfiguration{
+
+	spine.Events.Subscribe(c)
+
+	c.emobility = emobility.NewEMobilityScenario(c.service, model.CurrencyTypeEur, emobility.EmobilityConfiguration{
 		CoordinatedChargingEnabled: false,
 	})
+	c.emobility.AddFeatures()
+	c.emobility.AddUseCases()
+
+	c.appliances = NewApplianceScenario(c.service)
+	c.appliances.AddFeatures()
+	c.appliances.AddUseCases()
 
 	return c, nil
 }
 
-func (c *EEBus) RegisterEVSE(ski, ip string, connectHandler func(string), disconnectHandler func(string), dataProvider emobility.EmobilityDataProvider) *emobility.EMobilityImpl {
+// register normalizes the ski and registers the client callbacks
+func (c *EEBus) register(ski, ip string, connectHandler func(string), disconnectHandler func(string)) *service.ServiceDetails {
 	ski = strings.ReplaceAll(ski, "-", "")
 	ski = strings.ReplaceAll(ski, " ", "")
 	ski = strings.ToLower(ski)
 	c.log.TRACE.Printf("registering ski: %s", ski)
 
 	if ski == c.SKI {
-		c.log.FATAL.Fatal("The charger SKI can not be identical to the SKI of evcc!")
+		c.log.FATAL.Fatal("The device SKI can not be identical to the SKI of evcc!")
 	}
 
 	serviceDetails := service.NewServiceDetails(ski)
@@ -144,15 +160,64 @@ func (c *EEBus) RegisterEVSE(ski, ip string, connectHandler func(string), discon
 	defer c.mux.Unlock()
 	c.clients[ski] = EEBusClientCBs{onConnect: connectHandler, onDisconnect: disconnectHandler}
 
-	return c.Cem.RegisterEmobilityRemoteDevice(serviceDetails, dataProvider)
+	return serviceDetails
+}
+
+func (c *EEBus) RegisterEVSE(ski, ip string, connectHandler func(string), disconnectHandler func(string), dataProvider emobility.EmobilityDataProvider) *emobility.EMobilityImpl {
+	serviceDetails := c.register(ski, ip, connectHandler, disconnectHandler)
+
+	var impl any
+	if dataProvider != nil {
+		impl = c.emobility.RegisterRemoteDevice(serviceDetails, dataProvider)
+	} else {
+		impl = c.emobility.RegisterRemoteDevice(serviceDetails, nil)
+	}
+
+	return impl.(*emobility.EMobilityImpl)
+}
+
+// RegisterAppliance registers a device providing power consumption monitoring, e.g. heat pumps or white goods
+func (c *EEBus) RegisterAppliance(ski, ip string, connectHandler func(string), disconnectHandler func(string)) *Appliance {
+	serviceDetails := c.register(ski, ip, connectHandler, disconnectHandler)
+	return c.appliances.RegisterRemoteDevice(serviceDetails, nil).(*Appliance)
 }
 
 func (c *EEBus) Run() {
-	c.Cem.Start()
+	c.service.Start()
 }
 
 func (c *EEBus) Shutdown() {
-	c.Cem.Shutdown()
+	c.service.Shutdown()
+}
+
+// HandleEvent starts and stops heartbeats on the remote device's diagnosis subscription
+func (c *EEBus) HandleEvent(payload spine.EventPayload) {
+	if payload.EventType != spine.EventTypeSubscriptionChange {
+		return
+	}
+
+	data, ok := payload.Data.(model.SubscriptionManagementRequestCallType)
+	if !ok || data.ServerFeatureType == nil || *data.ServerFeatureType != model.FeatureTypeTypeDeviceDiagnosis {
+		return
+	}
+
+	remoteDevice := c.service.RemoteDeviceForSki(payload.Ski)
+	if remoteDevice == nil || payload.Feature == nil {
+		return
+	}
+
+	senderAddr := c.service.LocalDevice().FeatureByTypeAndRole(model.FeatureTypeTypeDeviceDiagnosis, model.RoleTypeServer).Address()
+	destinationAddr := payload.Feature.Address()
+	if senderAddr == nil || destinationAddr == nil {
+		return
+	}
+
+	switch payload.ChangeType {
+	case spine.ElementChangeAdd:
+		remoteDevice.StartHeartbeatSend(senderAddr, destinationAddr)
+	case spine.ElementChangeRemove:
+		remoteDevice.Stopheartbeat()
+	}
 }
 
 // EEBUSServiceHandler
@@ -2,7 +2,13 @@ package charger
 
 import (
 	"testing"
+	"time"
 
+	"github.com/enbility/cemd/emobility"
+	"github.com/enbility/eebus-go/features"
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
 
@@ -139,3 +145,43 @@ func TestEEBusIsCharging(t *testing.T) {
 		})
 	}
 }
+
+func TestEEBusSoc(t *testing.T) {
+	tests := []struct {
+		name        string
+		comStandard emobility.EVCommunicationStandardType
+		supported   bool
+		supportErr  error
+		soc         float64
+		expectErr   error
+	}{
+		{"IEC", emobility.EVCommunicationStandardTypeIEC61851, false, nil, 0, api.ErrNotAvailable},
+		{"ISO 15118-2 without VAS", emobility.EVCommunicationStandardTypeISO151182ED2, false, nil, 0, api.ErrNotAvailable},
+		{"ISO 15118-2 with VAS", emobility.EVCommunicationStandardTypeISO151182ED2, true, nil, 42, nil},
+		{"ISO 15118-2 ED1 with VAS", emobility.EVCommunicationStandardTypeISO151182ED1, true, nil, 73, nil},
+		{"ISO 15118-2 negotiating", emobility.EVCommunicationStandardTypeISO151182ED2, false, features.ErrDataNotAvailable, 0, api.ErrMustRetry},
+		{"unknown", emobility.EVCommunicationStandardTypeUnknown, true, nil, 0, api.ErrNotAvailable},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			emobilityMock := NewMockEmobilityI(ctrl)
+			eebus := &EEBus{
+				emobility:     emobilityMock,
+				connected:     true,
+				connectedTime: time.Now(),
+			}
+
+			emobilityMock.EXPECT().EVConnected().Return(true).AnyTimes()
+			emobilityMock.EXPECT().EVCommunicationStandard().Return(tc.comStandard, nil).AnyTimes()
+			emobilityMock.EXPECT().EVSoCSupported().Return(tc.supported, tc.supportErr).AnyTimes()
+			emobilityMock.EXPECT().EVSoC().Return(tc.soc, nil).AnyTimes()
+
+			soc, err := eebus.Soc()
+			require.ErrorIs(t, err, tc.expectErr)
+			assert.Equal(t, tc.soc, soc)
+		})
+	}
+}
@@ -24,17 +24,25 @@ import (
 
 	"github.com/evcc-io/evcc/api"
 	goe "github.com/evcc-io/evcc/charger/go-e"
+	"github.com/evcc-io/evcc/core/loadpoint"
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/sponsor"
+	"github.com/evcc-io/evcc/util/webhook"
 )
 
+// goeWebhookCache is the minimum polling interval while the charger pushes its status
+const goeWebhookCache = time.Minute
+
 // https://go-e.co/app/api.pdf
 // https://github.com/goecharger/go-eCharger-API-v1/
 // https://github.com/goecharger/go-eCharger-API-v2/
 
 // GoE charger implementation
 type GoE struct {
+	log *util.Logger
 	api goe.API
+	lp  loadpoint.API
+	car int
 }
 
 func init() {
@@ -46,9 +54,10 @@ func init() {
 // NewGoEFromConfig creates a go-e charger from generic config
 func NewGoEFromConfig(other map[string]interface{}) (api.Charger, error) {
 	cc := struct {
-		Token string
-		URI   string
-		Cache time.Duration
+		Token   string
+		URI     string
+		Cache   time.Duration
+		Webhook string
 	}{
 		Cache: time.Second,
 	}
@@ -64,14 +73,23 @@ func NewGoEFromConfig(other map[string]interface{}) (api.Charger, error) {
 		return nil, errors.New("must have one of uri/token")
 	}
 
-	return NewGoE(cc.URI, cc.Token, cc.Cache)
+	if cc.Webhook != "" && cc.Token != "" {
+		return nil, errors.New("webhook requires local api")
+	}
+
+	return NewGoE(cc.URI, cc.Token, cc.Cache, cc.Webhook)
 }
 
 // NewGoE creates GoE charger
-func NewGoE(uri, token string, cache time.Duration) (api.Charger, error) {
-	c := &GoE{}
+func NewGoE(uri, token string, cache time.Duration, hook string) (api.Charger, error) {
+	log := util.NewLogger("go-e").Redact(token, hook)
 
-	log := util.NewLogger("go-e").Redact(token)
+	c := &GoE{log: log}
+
+	// pushed status keeps the cache current, poll less often
+	if hook != "" {
+		cache = max(cache, goeWebhookCache)
+	}
 
 	if token != "" {
 		c.api = goe.NewCloud(log, token, cache)
@@ -83,6 +101,16 @@ func NewGoE(uri, token string, cache time.Duration) (api.Charger, error) {
 		return nil, api.ErrSponsorRequired
 	}
 
+	if hook != "" {
+		if !c.api.IsV2() {
+			return nil, errors.New("webhook requires v2 api")
+		}
+
+		if err := webhook.Register(hook, c.push); err != nil {
+			return nil, err
+		}
+	}
+
 	if c.api.IsV2() {
 		return decorateGoE(c, c.phases1p3p), nil
 	}
@@ -203,6 +231,18 @@ func (c *GoE) Identify() (string, error) {
 	return resp.Identify(), nil
 }
 
+var _ api.ChargerTemperature = (*GoE)(nil)
+
+// Temperature implements the api.ChargerTemperature interface
+func (c *GoE) Temperature() (float64, error) {
+	resp, err := c.api.Status()
+	if err != nil {
+		return 0, err
+	}
+
+	return resp.Temperature(), nil
+}
+
 var _ api.MeterEnergy = (*GoE)(nil)
 
 // totalEnergy implements the api.MeterEnergy interface - v2 only
@@ -223,3 +263,30 @@ func (c *GoE) phases1p3p(phases int) error {
 
 	return c.api.Update(fmt.Sprintf("psm=%d", phases))
 }
+
+var _ loadpoint.Controller = (*GoE)(nil)
+
+// LoadpointControl implements loadpoint.Controller
+func (c *GoE) LoadpointControl(lp loadpoint.API) {
+	c.lp = lp
+}
+
+// push receives the status pushed by the charger's event stream and requests
+// an immediate loadpoint update if the car status changed
+func (c *GoE) push(payload []byte) error {
+	resp, err := c.api.Push(payload)
+	if err != nil {
+		return err
+	}
+
+	if car := resp.Status(); car != c.car {
+		c.log.DEBUG.Printf("pushed car status: %d", car)
+		c.car = car
+
+		if c.lp != nil {
+			c.lp.RequestUpdate()
+		}
+	}
+
+	return nil
+}
@@ -1,9 +1,11 @@
 package goe
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/evcc-io/evcc/util"
@@ -22,6 +24,7 @@ type Response interface {
 	Currents() (float64, float64, float64)
 	Voltages() (float64, float64, float64)
 	Identify() string
+	Temperature() float64
 }
 
 type UpdateResponse map[string]interface{}
@@ -30,10 +33,12 @@ type API interface {
 	IsV2() bool
 	Status() (Response, error)
 	Update(payload string) error
+	Push(payload []byte) (Response, error)
 }
 
 type LocalAPI struct {
 	*request.Helper
+	mu      sync.Mutex
 	uri     string
 	v2      bool
 	status  Response
@@ -85,10 +90,13 @@ func (c *LocalAPI) response(partial string, res interface{}) error {
 
 // Status reads a v1/v2 api response
 func (c *LocalAPI) Status() (res Response, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	if time.Since(c.updated) > c.cache {
 		if c.v2 {
 			c.status = new(StatusResponse2)
-			err = c.response("status?filter=alw,car,eto,nrg,wh,trx,cards", &c.status)
+			err = c.response("status?filter=alw,car,eto,nrg,wh,trx,cards,tma", &c.status)
 		} else {
 			c.status = new(StatusResponse)
 			err = c.response("status", &c.status)
@@ -102,7 +110,10 @@ func (c *LocalAPI) Status() (res Response, err error) {
 
 // Update executes a v1/v2 api update and returns the response
 func (c *LocalAPI) Update(payload string) error {
+	c.mu.Lock()
 	c.updated = time.Time{}
+	c.mu.Unlock()
+
 	res := new(UpdateResponse)
 
 	if c.v2 {
@@ -114,6 +125,38 @@ func (c *LocalAPI) Update(payload string) error {
 	return err
 }
 
+// Push merges a v2 status pushed by the charger's event stream into the cached status and returns the result.
+// The event stream only contains changed keys.
+func (c *LocalAPI) Push(payload []byte) (Response, error) {
+	if !c.v2 {
+		return nil, errors.New("push requires v2 api")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// copy cached status as it may still be in use
+	res := new(StatusResponse2)
+	if c.status != nil {
+		b, err := json.Marshal(c.status)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(b, res); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := json.Unmarshal(payload, res); err != nil {
+		return nil, err
+	}
+
+	c.status = res
+	c.updated = time.Now()
+
+	return res, nil
+}
+
 type cloud struct {
 	*request.Helper
 	token   string
@@ -164,6 +207,10 @@ func (c *cloud) Status() (status Response, err error) {
 	return c.status, err
 }
 
+func (c *cloud) Push(payload []byte) (Response, error) {
+	return nil, errors.New("push requires local api")
+}
+
 func (c *cloud) Update(payload string) error {
 	c.updated = time.Time{}
 	_, err := c.response("api", payload)
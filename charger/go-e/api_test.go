@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/evcc-io/evcc/util"
 )
@@ -57,7 +58,7 @@ func TestLocalV2(t *testing.T) {
 	h.expect("/api/status?filter=alw")
 	local := NewLocal(util.NewLogger("foo"), srv.URL, 0)
 
-	h.expect("/api/status?filter=alw,car,eto,nrg,wh,trx,cards")
+	h.expect("/api/status?filter=alw,car,eto,nrg,wh,trx,cards,tma")
 	if _, err := local.Status(); err != nil {
 		t.Error(err)
 	}
@@ -67,3 +68,33 @@ func TestLocalV2(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestLocalV2Push(t *testing.T) {
+	h := &handler{}
+	srv := httptest.NewServer(h)
+
+	h.expect("/api/status?filter=alw")
+	local := NewLocal(util.NewLogger("foo"), srv.URL, time.Hour)
+
+	res, err := local.Push([]byte(`{"car":2,"nrg":[230,230,230,0,10,10,10,0,0,0,0,6900,0,0,0,0]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Status() != 2 || res.CurrentPower() != 6900 {
+		t.Errorf("unexpected status: %+v", res)
+	}
+
+	// partial update keeps previous values
+	if res, err = local.Push([]byte(`{"car":1}`)); err != nil {
+		t.Fatal(err)
+	}
+	if res.Status() != 1 || res.CurrentPower() != 6900 {
+		t.Errorf("unexpected status: %+v", res)
+	}
+
+	// pushed status is cached
+	h.expect("")
+	if res, err := local.Status(); err != nil || res.Status() != 1 {
+		t.Errorf("unexpected status: %+v %v", res, err)
+	}
+}
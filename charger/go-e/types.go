@@ -96,3 +96,7 @@ func (g *StatusResponse) Identify() string {
 		return ""
 	}
 }
+
+func (g *StatusResponse) Temperature() float64 {
+	return float64(g.Tmp)
+}
@@ -1,5 +1,7 @@
 package goe
 
+import "slices"
+
 // StatusResponse2 is the v2 API response
 type StatusResponse2 struct {
 	Fwv   string    // firmware version
@@ -11,6 +13,7 @@ type StatusResponse2 struct {
 	Psm   int       // phase switching
 	Stp   int       // stop state
 	Tmp   int       // temperature [°C]
+	Tma   []float64 // temperature sensors [°C]
 	Trx   int       // transaction
 	Nrg   []float64 // voltage, current, power
 	Wh    float64   // energy [Wh]
@@ -71,3 +74,11 @@ func (g *StatusResponse2) Identify() string {
 
 	return ""
 }
+
+func (g *StatusResponse2) Temperature() float64 {
+	if len(g.Tma) > 0 {
+		return slices.Max(g.Tma)
+	}
+
+	return float64(g.Tmp)
+}
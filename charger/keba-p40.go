@@ -0,0 +1,89 @@
+package charger
+
+import (
+	"errors"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/charger/keba"
+	"github.com/evcc-io/evcc/util"
+)
+
+// KebaP40 is the KEBA P40 series charger. Its UDP interface only supports measurements,
+// charging is controlled via OCPP.
+type KebaP40 struct {
+	api.Charger // ocpp control
+	udp         *KebaUdp
+}
+
+func init() {
+	registry.Add("keba-p40", NewKebaP40FromConfig)
+}
+
+// NewKebaP40FromConfig creates a KEBA P40 charger from generic config
+func NewKebaP40FromConfig(other map[string]interface{}) (api.Charger, error) {
+	cc := struct {
+		URI     string
+		Serial  string
+		Timeout time.Duration
+		OCPP    map[string]interface{}
+	}{
+		Timeout: udpTimeout,
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.URI == "" {
+		return nil, errors.New("missing uri")
+	}
+
+	udp, err := NewKebaUdp(cc.URI, cc.Serial, keba.RFID{}, cc.Timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	ocpp, err := NewOCPPFromConfig(cc.OCPP)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewKebaP40(ocpp, udp), nil
+}
+
+// NewKebaP40 creates a KEBA P40 charger from its OCPP and UDP connections
+func NewKebaP40(ocpp api.Charger, udp *KebaUdp) *KebaP40 {
+	return &KebaP40{
+		Charger: ocpp,
+		udp:     udp,
+	}
+}
+
+var _ api.Meter = (*KebaP40)(nil)
+
+// CurrentPower implements the api.Meter interface
+func (c *KebaP40) CurrentPower() (float64, error) {
+	return c.udp.currentPower()
+}
+
+var _ api.MeterEnergy = (*KebaP40)(nil)
+
+// TotalEnergy implements the api.MeterEnergy interface
+func (c *KebaP40) TotalEnergy() (float64, error) {
+	return c.udp.totalEnergy()
+}
+
+var _ api.PhaseCurrents = (*KebaP40)(nil)
+
+// Currents implements the api.PhaseCurrents interface
+func (c *KebaP40) Currents() (float64, float64, float64, error) {
+	return c.udp.currents()
+}
+
+var _ api.Identifier = (*KebaP40)(nil)
+
+// Identify implements the api.Identifier interface
+func (c *KebaP40) Identify() (string, error) {
+	return c.udp.Identify()
+}
@@ -272,6 +272,27 @@ func (c *KebaUdp) MaxCurrentMillis(current float64) error {
 	return nil
 }
 
+var _ api.ChargerLock = (*KebaUdp)(nil)
+
+// Lock implements the api.ChargerLock interface
+// KEBA locks the socket when the plug is inserted, there is no lock command.
+func (c *KebaUdp) Lock() error {
+	return api.ErrNotAvailable
+}
+
+// Unlock implements the api.ChargerLock interface
+func (c *KebaUdp) Unlock() error {
+	var resp string
+	if err := c.roundtrip("unlock", 0, &resp); err != nil {
+		return err
+	}
+	if resp != keba.OK {
+		return fmt.Errorf("unlock unexpected response: %s", resp)
+	}
+
+	return nil
+}
+
 // currentPower implements the api.Meter interface
 func (c *KebaUdp) currentPower() (float64, error) {
 	var kr keba.Report3
@@ -32,6 +32,7 @@ type OCPP struct {
 	phaseSwitching    bool
 	chargingRateUnit  types.ChargingRateUnitType
 	lp                loadpoint.API
+	socketLocked      bool
 }
 
 const defaultIdTag = "evcc"
@@ -53,6 +54,7 @@ func NewOCPPFromConfig(other map[string]interface{}) (api.Charger, error) {
 		BootNotification *bool
 		GetConfiguration *bool
 		ChargingRateUnit string
+		Phases1p3p       bool // force phase switching if not reported by the charger
 	}{
 		Connector:        1,
 		IdTag:            defaultIdTag,
@@ -92,7 +94,7 @@ func NewOCPPFromConfig(other map[string]interface{}) (api.Charger, error) {
 	}
 
 	var phasesS func(int) error
-	if c.phaseSwitching {
+	if c.phaseSwitching || cc.Phases1p3p {
 		phasesS = c.phases1p3p
 	}
 
@@ -477,13 +479,72 @@ func (c *OCPP) currents() (float64, float64, float64, error) {
 	return c.conn.Currents()
 }
 
-// Phases1p3p implements the api.PhaseSwitcher interface
+var _ api.ChargerLock = (*OCPP)(nil)
+
+// Lock implements the api.ChargerLock interface
+// The cable remains locked when the vehicle is unplugged until unlocked.
+func (c *OCPP) Lock() error {
+	if err := c.configure(ocpp.KeyUnlockConnectorOnEVSideDisconnect, "false"); err != nil {
+		return err
+	}
+
+	c.socketLocked = true
+
+	return nil
+}
+
+// Unlock implements the api.ChargerLock interface
+func (c *OCPP) Unlock() error {
+	if err := c.unlockConnector(); err != nil {
+		return err
+	}
+
+	// restore automatic unlocking when the vehicle is unplugged
+	if c.socketLocked {
+		if err := c.configure(ocpp.KeyUnlockConnectorOnEVSideDisconnect, "true"); err != nil {
+			return err
+		}
+
+		c.socketLocked = false
+	}
+
+	return nil
+}
+
+// unlockConnector releases the connector's lock
+func (c *OCPP) unlockConnector() error {
+	rc := make(chan error, 1)
+	err := ocpp.Instance().UnlockConnector(c.conn.ChargePoint().ID(), func(resp *core.UnlockConnectorConfirmation, err error) {
+		if err == nil && resp != nil && resp.Status != core.UnlockStatusUnlocked {
+			err = errors.New(string(resp.Status))
+		}
+
+		rc <- err
+	}, c.conn.ID())
+
+	return c.wait(err, rc)
+}
+
+var _ api.SignedMeter = (*OCPP)(nil)
+
+// SignedMeterValues implements the api.SignedMeter interface
+func (c *OCPP) SignedMeterValues() (string, string, error) {
+	return c.conn.SignedMeterValues()
+}
+
+// Phases1p3p implements the api.PhaseSwitcher interface.
+// Phases are only switched while the vehicle is not charging, otherwise the loadpoint pauses charging and retries.
 func (c *OCPP) phases1p3p(phases int) error {
+	if status, err := c.Status(); err != nil {
+		return err
+	} else if status == api.StatusC {
+		return api.ErrMustRetry
+	}
+
 	c.phases = phases
 
-	// NOTE: this will currently _never_ do anything since
-	// loadpoint disabled the charger before switching so
-	// updatePeriod will short-circuit
+	// NOTE: charging is paused while switching, the phases
+	// are applied with the charging profile when enabled
 	return c.updatePeriod(c.current)
 }
 
@@ -31,6 +31,8 @@ type Connector struct {
 
 	txnCount int // change initial value to the last known global transaction. Needs persistence
 	txnId    int
+
+	signedStart, signedStop string // signed meter data of current or last transaction
 }
 
 func NewConnector(log *util.Logger, id int, cp *CP, timeout time.Duration) (*Connector, error) {
@@ -206,6 +208,20 @@ func (conn *Connector) TotalEnergy() (float64, error) {
 	return 0, api.ErrNotAvailable
 }
 
+var _ api.SignedMeter = (*Connector)(nil)
+
+// SignedMeterValues implements the api.SignedMeter interface
+func (conn *Connector) SignedMeterValues() (string, string, error) {
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	if conn.signedStart == "" && conn.signedStop == "" {
+		return "", "", api.ErrNotAvailable
+	}
+
+	return conn.signedStart, conn.signedStop, nil
+}
+
 func scale(f float64, scale types.UnitOfMeasure) float64 {
 	switch {
 	case strings.HasPrefix(string(scale), "k"):
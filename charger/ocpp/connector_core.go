@@ -47,6 +47,18 @@ func getSampleKey(s types.SampledValue) types.Measurand {
 	return s.Measurand
 }
 
+// updateSignedData records signed meter data by reading context.
+// Must only be called while holding lock.
+func (conn *Connector) updateSignedData(sample types.SampledValue) {
+	if sample.Context == types.ReadingContextTransactionBegin || conn.signedStart == "" {
+		conn.signedStart = sample.Value
+	}
+
+	if sample.Context != types.ReadingContextTransactionBegin {
+		conn.signedStop = sample.Value
+	}
+}
+
 func (conn *Connector) MeterValues(request *core.MeterValuesRequest) (*core.MeterValuesConfirmation, error) {
 	conn.mu.Lock()
 	defer conn.mu.Unlock()
@@ -60,6 +72,11 @@ func (conn *Connector) MeterValues(request *core.MeterValuesRequest) (*core.Mete
 		// ignore old meter value requests
 		if meterValue.Timestamp.Time.After(conn.meterUpdated) {
 			for _, sample := range meterValue.SampledValue {
+				if sample.Format == types.ValueFormatSignedData {
+					conn.updateSignedData(sample)
+					continue
+				}
+
 				conn.measurements[getSampleKey(sample)] = sample
 				conn.meterUpdated = conn.clock.Now()
 			}
@@ -87,6 +104,8 @@ func (conn *Connector) StartTransaction(request *core.StartTransactionRequest) (
 	conn.txnCount++
 	conn.txnId = conn.txnCount
 
+	conn.signedStart, conn.signedStop = "", ""
+
 	res := &core.StartTransactionConfirmation{
 		IdTagInfo: &types.IdTagInfo{
 			Status: types.AuthorizationStatusAccepted,
@@ -134,6 +153,14 @@ func (conn *Connector) StopTransaction(request *core.StopTransactionRequest) (*c
 
 	conn.txnId = 0
 
+	for _, meterValue := range request.TransactionData {
+		for _, sample := range meterValue.SampledValue {
+			if sample.Format == types.ValueFormatSignedData {
+				conn.updateSignedData(sample)
+			}
+		}
+	}
+
 	res := &core.StopTransactionConfirmation{
 		IdTagInfo: &types.IdTagInfo{
 			Status: types.AuthorizationStatusAccepted, // accept
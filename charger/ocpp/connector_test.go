@@ -0,0 +1,70 @@
+package ocpp
+
+import (
+	"testing"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/util"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignedMeterValues(t *testing.T) {
+	clock := clock.NewMock()
+	conn := &Connector{
+		log:          util.NewLogger("foo"),
+		clock:        clock,
+		measurements: make(map[types.Measurand]types.SampledValue),
+	}
+
+	_, _, err := conn.SignedMeterValues()
+	require.Error(t, err)
+
+	_, err = conn.StartTransaction(&core.StartTransactionRequest{Timestamp: types.NewDateTime(clock.Now())})
+	require.NoError(t, err)
+
+	signed := func(context types.ReadingContext, value string) types.SampledValue {
+		return types.SampledValue{
+			Value:     value,
+			Context:   context,
+			Format:    types.ValueFormatSignedData,
+			Measurand: types.MeasurandEnergyActiveImportRegister,
+		}
+	}
+
+	clock.Add(1)
+	_, err = conn.MeterValues(&core.MeterValuesRequest{
+		MeterValue: []types.MeterValue{{
+			Timestamp: types.NewDateTime(clock.Now()),
+			SampledValue: []types.SampledValue{
+				{Value: "1000", Measurand: types.MeasurandEnergyActiveImportRegister, Unit: types.UnitOfMeasureWh},
+				signed(types.ReadingContextTransactionBegin, "OCMF|begin"),
+			},
+		}},
+	})
+	require.NoError(t, err)
+
+	// signed data must not replace raw measurements
+	assert.Equal(t, "1000", conn.measurements[types.MeasurandEnergyActiveImportRegister].Value)
+
+	start, stop, err := conn.SignedMeterValues()
+	require.NoError(t, err)
+	assert.Equal(t, "OCMF|begin", start)
+	assert.Equal(t, "", stop)
+
+	_, err = conn.StopTransaction(&core.StopTransactionRequest{
+		Timestamp: types.NewDateTime(clock.Now()),
+		TransactionData: []types.MeterValue{{
+			Timestamp:    types.NewDateTime(clock.Now()),
+			SampledValue: []types.SampledValue{signed(types.ReadingContextTransactionEnd, "OCMF|end")},
+		}},
+	})
+	require.NoError(t, err)
+
+	start, stop, err = conn.SignedMeterValues()
+	require.NoError(t, err)
+	assert.Equal(t, "OCMF|begin", start)
+	assert.Equal(t, "OCMF|end", stop)
+}
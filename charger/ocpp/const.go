@@ -2,7 +2,8 @@ package ocpp
 
 const (
 	// Core profile keys
-	KeyNumberOfConnectors = "NumberOfConnectors"
+	KeyNumberOfConnectors                = "NumberOfConnectors"
+	KeyUnlockConnectorOnEVSideDisconnect = "UnlockConnectorOnEVSideDisconnect"
 
 	// Meter profile keys
 	KeyMeterValuesSampledData   = "MeterValuesSampledData"
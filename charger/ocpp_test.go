@@ -122,6 +122,15 @@ func (suite *ocppTestSuite) TestConnect() {
 		suite.Equal(1.2, f)
 	}
 
+	// socket lock
+	{
+		suite.Require().NoError(c1.Lock())
+		suite.True(c1.socketLocked)
+
+		suite.Require().NoError(c1.Unlock())
+		suite.False(c1.socketLocked)
+	}
+
 	// takeover
 	{
 		expectedTxn := 99
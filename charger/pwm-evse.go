@@ -0,0 +1,211 @@
+package charger
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/charger/pwm"
+	"github.com/evcc-io/evcc/util"
+)
+
+// pwmEvseRampDown is the time the vehicle is given to stop charging before the relay opens
+const pwmEvseRampDown = 3 * time.Second
+
+// PwmEvse is a DIY IEC 61851 EVSE driven by the host's pwm output (control pilot),
+// gpio output (contactor relay) and adc input (control pilot voltage).
+// The adc is expected to measure the high level of the control pilot signal, e.g. using a peak detector.
+// The driver does not replace hardware safety measures like residual current and welded contact detection.
+type PwmEvse struct {
+	log        *util.Logger
+	mu         sync.Mutex
+	cp         *pwm.Output
+	relay      *pwm.GPIO
+	adc        *pwm.ADC
+	status     api.ChargeStatus
+	enabled    bool
+	current    float64
+	closed     bool
+	disabledAt time.Time
+}
+
+func init() {
+	registry.Add("pwm-evse", NewPwmEvseFromConfig)
+}
+
+// NewPwmEvseFromConfig creates a pwm evse charger from generic config
+func NewPwmEvseFromConfig(other map[string]interface{}) (api.Charger, error) {
+	cc := struct {
+		Pwm      string  // pwm chip
+		Channel  int     // pwm channel
+		Gpio     string  // gpio root
+		Relay    int     // relay gpio
+		Adc      string  // raw adc value
+		Scale    float64 // V per raw adc count
+		Offset   float64 // V
+		Interval time.Duration
+	}{
+		Pwm:      "/sys/class/pwm/pwmchip0",
+		Gpio:     "/sys/class/gpio",
+		Interval: 100 * time.Millisecond,
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.Relay == 0 || cc.Adc == "" || cc.Scale == 0 {
+		return nil, errors.New("missing relay, adc or scale")
+	}
+
+	cp, err := pwm.NewOutput(cc.Pwm, cc.Channel)
+	if err != nil {
+		return nil, err
+	}
+
+	relay, err := pwm.NewGPIO(cc.Gpio, cc.Relay)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPwmEvse(cp, relay, pwm.NewADC(cc.Adc, cc.Scale, cc.Offset), cc.Interval)
+}
+
+// NewPwmEvse creates a pwm evse charger
+func NewPwmEvse(cp *pwm.Output, relay *pwm.GPIO, adc *pwm.ADC, interval time.Duration) (*PwmEvse, error) {
+	c := &PwmEvse{
+		log:     util.NewLogger("pwm-evse"),
+		cp:      cp,
+		relay:   relay,
+		adc:     adc,
+		status:  api.StatusNone,
+		current: 6,
+	}
+
+	// steady +12V: charging not allowed
+	if err := c.cp.Duty(1); err != nil {
+		return nil, err
+	}
+
+	if err := c.relay.Set(false); err != nil {
+		return nil, err
+	}
+
+	go func() {
+		for range time.Tick(interval) {
+			c.update()
+		}
+	}()
+
+	return c, nil
+}
+
+// update runs the state machine, closing the relay only while enabled and the vehicle requests charging
+func (c *PwmEvse) update() {
+	v, err := c.adc.Voltage()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	status := api.StatusNone
+	if err == nil {
+		status = pwm.Status(v)
+	} else {
+		c.log.ERROR.Println(err)
+	}
+
+	if status != c.status {
+		c.log.DEBUG.Printf("status: %s (%.1fV)", status, v)
+		c.status = status
+	}
+
+	closed := c.enabled && status == api.StatusC
+
+	// give the vehicle time to stop charging after disabling
+	if !closed && c.closed && !c.enabled && status == api.StatusC && time.Since(c.disabledAt) < pwmEvseRampDown {
+		closed = true
+	}
+
+	if closed != c.closed {
+		if err := c.relay.Set(closed); err != nil {
+			c.log.ERROR.Println("relay:", err)
+			return
+		}
+
+		c.log.DEBUG.Printf("relay closed: %t", closed)
+		c.closed = closed
+	}
+}
+
+// Status implements the api.Charger interface
+func (c *PwmEvse) Status() (api.ChargeStatus, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch c.status {
+	case api.StatusNone:
+		return c.status, errors.New("unknown status")
+	case api.StatusD:
+		// ventilation is not supported
+		return c.status, errors.New("vehicle requires ventilation")
+	case api.StatusE, api.StatusF:
+		return c.status, errors.New("control pilot error")
+	}
+
+	return c.status, nil
+}
+
+// Enabled implements the api.Charger interface
+func (c *PwmEvse) Enabled() (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.enabled, nil
+}
+
+// Enable implements the api.Charger interface
+func (c *PwmEvse) Enable(enable bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	duty := 1.0
+	if enable {
+		duty = pwm.DutyCycle(c.current)
+	}
+
+	if err := c.cp.Duty(duty); err != nil {
+		return err
+	}
+
+	if c.enabled && !enable {
+		c.disabledAt = time.Now()
+	}
+
+	c.enabled = enable
+
+	return nil
+}
+
+// MaxCurrent implements the api.Charger interface
+func (c *PwmEvse) MaxCurrent(current int64) error {
+	return c.MaxCurrentMillis(float64(current))
+}
+
+var _ api.ChargerEx = (*PwmEvse)(nil)
+
+// MaxCurrentMillis implements the api.ChargerEx interface
+func (c *PwmEvse) MaxCurrentMillis(current float64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.enabled {
+		if err := c.cp.Duty(pwm.DutyCycle(current)); err != nil {
+			return err
+		}
+	}
+
+	c.current = current
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package pwm
+
+import (
+	"math"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+// Status returns the IEC 61851 vehicle state from the high level of the control pilot voltage
+func Status(voltage float64) api.ChargeStatus {
+	switch {
+	case voltage >= 10.5:
+		return api.StatusA // +12V no vehicle
+	case voltage >= 7.5:
+		return api.StatusB // +9V vehicle connected
+	case voltage >= 4.5:
+		return api.StatusC // +6V charging
+	case voltage >= 1.5:
+		return api.StatusD // +3V charging with ventilation
+	case voltage > -1.5:
+		return api.StatusE // 0V short circuit or no power
+	default:
+		return api.StatusF // -12V error
+	}
+}
+
+// DutyCycle returns the IEC 61851 control pilot duty cycle (0..1) signalling the max current
+func DutyCycle(current float64) float64 {
+	current = min(max(current, 6), 80)
+
+	if current <= 51 {
+		return current / 0.6 / 100
+	}
+
+	return (current/2.5 + 64) / 100
+}
+
+// Current returns the max current signalled by the control pilot duty cycle
+func Current(duty float64) float64 {
+	duty *= 100
+
+	if duty <= 85 {
+		return math.Round(duty*0.6*10) / 10
+	}
+
+	return math.Round((duty-64)*2.5*10) / 10
+}
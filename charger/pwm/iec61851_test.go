@@ -0,0 +1,58 @@
+package pwm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatus(t *testing.T) {
+	for v, s := range map[float64]api.ChargeStatus{
+		11.8: api.StatusA,
+		9.1:  api.StatusB,
+		5.9:  api.StatusC,
+		3.2:  api.StatusD,
+		0:    api.StatusE,
+		-12:  api.StatusF,
+	} {
+		assert.Equal(t, s, Status(v), v)
+	}
+}
+
+func TestDutyCycle(t *testing.T) {
+	assert.InDelta(t, 0.1, DutyCycle(6), 1e-6)
+	assert.InDelta(t, 0.1, DutyCycle(0), 1e-6, "min current")
+	assert.InDelta(t, 0.2667, DutyCycle(16), 1e-4)
+	assert.InDelta(t, 0.9, DutyCycle(65), 1e-6)
+	assert.InDelta(t, 0.96, DutyCycle(100), 1e-6, "max current")
+
+	for _, c := range []float64{6, 10, 16, 32, 63} {
+		assert.InDelta(t, c, Current(DutyCycle(c)), 0.1)
+	}
+}
+
+func TestSysfs(t *testing.T) {
+	dir := t.TempDir()
+
+	chip := filepath.Join(dir, "pwmchip0")
+	require.NoError(t, os.MkdirAll(filepath.Join(chip, "pwm0"), 0o755))
+
+	o, err := NewOutput(chip, 0)
+	require.NoError(t, err)
+	require.NoError(t, o.Duty(0.5))
+
+	b, err := os.ReadFile(filepath.Join(chip, "pwm0", "duty_cycle"))
+	require.NoError(t, err)
+	assert.Equal(t, "500000", string(b))
+
+	adc := filepath.Join(dir, "in_voltage0_raw")
+	require.NoError(t, os.WriteFile(adc, []byte("3000\n"), 0o644))
+
+	v, err := NewADC(adc, 0.008, -12).Voltage()
+	require.NoError(t, err)
+	assert.InDelta(t, 12, v, 1e-6)
+}
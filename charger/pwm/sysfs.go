@@ -0,0 +1,116 @@
+package pwm
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Period is the 1kHz control pilot period
+const Period = time.Millisecond
+
+func write(path string, value any) error {
+	return os.WriteFile(path, []byte(fmt.Sprint(value)), 0o644)
+}
+
+// exportIfMissing exports a sysfs channel unless already present
+func exportIfMissing(dir, export string, id int) error {
+	if _, err := os.Stat(dir); err == nil {
+		return nil
+	}
+
+	if err := write(export, id); err != nil {
+		return err
+	}
+
+	// wait for udev to apply permissions
+	for i := 0; i < 10; i++ {
+		if _, err := os.Stat(dir); err == nil {
+			return nil
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	return fmt.Errorf("export failed: %s", dir)
+}
+
+// Output is a sysfs pwm channel
+type Output struct {
+	dir string
+}
+
+// NewOutput exports the channel of the pwm chip, e.g. /sys/class/pwm/pwmchip0
+func NewOutput(chip string, channel int) (*Output, error) {
+	dir := filepath.Join(chip, fmt.Sprintf("pwm%d", channel))
+	if err := exportIfMissing(dir, filepath.Join(chip, "export"), channel); err != nil {
+		return nil, err
+	}
+
+	o := &Output{dir: dir}
+
+	if err := write(filepath.Join(dir, "period"), Period.Nanoseconds()); err != nil {
+		return nil, err
+	}
+
+	return o, write(filepath.Join(dir, "enable"), 1)
+}
+
+// Duty sets the duty cycle (0..1)
+func (o *Output) Duty(duty float64) error {
+	return write(filepath.Join(o.dir, "duty_cycle"), int64(duty*float64(Period.Nanoseconds())))
+}
+
+// GPIO is a sysfs gpio output
+type GPIO struct {
+	dir string
+}
+
+// NewGPIO exports the gpio as output, e.g. /sys/class/gpio and 17
+func NewGPIO(root string, gpio int) (*GPIO, error) {
+	dir := filepath.Join(root, fmt.Sprintf("gpio%d", gpio))
+	if err := exportIfMissing(dir, filepath.Join(root, "export"), gpio); err != nil {
+		return nil, err
+	}
+
+	// direction low initializes the output as off
+	return &GPIO{dir: dir}, write(filepath.Join(dir, "direction"), "low")
+}
+
+// Set sets the output
+func (g *GPIO) Set(on bool) error {
+	var v int
+	if on {
+		v = 1
+	}
+	return write(filepath.Join(g.dir, "value"), v)
+}
+
+// ADC is a sysfs iio voltage input
+type ADC struct {
+	path          string
+	scale, offset float64
+}
+
+// NewADC creates an input reading the raw value, e.g. /sys/bus/iio/devices/iio:device0/in_voltage0_raw,
+// and converting it to the control pilot voltage
+func NewADC(path string, scale, offset float64) *ADC {
+	return &ADC{path: path, scale: scale, offset: offset}
+}
+
+// Voltage returns the converted voltage
+func (a *ADC) Voltage() (float64, error) {
+	b, err := os.ReadFile(a.path)
+	if err != nil {
+		return 0, err
+	}
+
+	raw, err := strconv.ParseFloat(strings.TrimSpace(string(b)), 64)
+	if err != nil {
+		return 0, err
+	}
+
+	return raw*a.scale + a.offset, nil
+}
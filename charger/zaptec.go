@@ -21,6 +21,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
 	"sort"
 	"time"
@@ -47,6 +48,10 @@ type Zaptec struct {
 	id       string
 	enabled  bool
 	priority bool
+
+	loadBalancing  bool
+	installation   string
+	circuitCurrent float64
 }
 
 func init() {
@@ -59,6 +64,7 @@ func NewZaptecFromConfig(other map[string]interface{}) (api.Charger, error) {
 		User, Password string
 		Id             string
 		Priority       bool
+		LoadBalancing  bool
 		Cache          time.Duration
 	}{
 		Cache: time.Second,
@@ -72,11 +78,11 @@ func NewZaptecFromConfig(other map[string]interface{}) (api.Charger, error) {
 		return nil, api.ErrMissingCredentials
 	}
 
-	return NewZaptec(cc.User, cc.Password, cc.Id, cc.Priority, cc.Cache)
+	return NewZaptec(cc.User, cc.Password, cc.Id, cc.Priority, cc.LoadBalancing, cc.Cache)
 }
 
 // NewZaptec creates Zaptec charger
-func NewZaptec(user, password, id string, priority bool, cache time.Duration) (api.Charger, error) {
+func NewZaptec(user, password, id string, priority, loadBalancing bool, cache time.Duration) (api.Charger, error) {
 	log := util.NewLogger("zaptec").Redact(user, password)
 
 	if !sponsor.IsAuthorized() {
@@ -88,6 +94,9 @@ func NewZaptec(user, password, id string, priority bool, cache time.Duration) (a
 		log:      log,
 		id:       id,
 		priority: priority,
+
+		loadBalancing:  loadBalancing,
+		circuitCurrent: -1,
 	}
 
 	// setup cached values
@@ -229,6 +238,52 @@ func (c *Zaptec) MaxCurrent(current int64) error {
 	return c.chargerUpdate(data)
 }
 
+var _ api.CircuitCurrentLimiter = (*Zaptec)(nil)
+
+// SetCircuitCurrent implements the api.CircuitCurrentLimiter interface.
+// It limits the available current of the charger's installation used by Zaptec's own load balancing.
+func (c *Zaptec) SetCircuitCurrent(current float64) error {
+	if !c.loadBalancing {
+		return api.ErrNotAvailable
+	}
+
+	current = math.Floor(current)
+	if current == c.circuitCurrent {
+		return nil
+	}
+
+	if c.installation == "" {
+		res, err := c.statusG.Get()
+		if err != nil {
+			return err
+		}
+
+		o := res.ObservationByID(zaptec.InstallationId)
+		if o == nil || o.ValueAsString == "" {
+			return errors.New("unknown installation")
+		}
+
+		c.installation = o.ValueAsString
+	}
+
+	c.log.DEBUG.Printf("installation available current: %.0fA", current)
+
+	data := zaptec.InstallationUpdate{
+		AvailableCurrent: &current,
+	}
+
+	uri := fmt.Sprintf("%s/api/installation/%s/update", zaptec.ApiURL, c.installation)
+
+	req, err := request.New(http.MethodPost, uri, request.MarshalJSON(data), request.JSONEncoding)
+	if err == nil {
+		if _, err = c.DoBody(req); err == nil {
+			c.circuitCurrent = current
+		}
+	}
+
+	return err
+}
+
 var _ api.Meter = (*Zaptec)(nil)
 
 // CurrentPower implements the api.Meter interface
@@ -69,6 +69,10 @@ func (o *Observation) Float64() (float64, error) {
 	return strconv.ParseFloat(o.ValueAsString, 64)
 }
 
+type InstallationUpdate struct {
+	AvailableCurrent *float64 `json:"availableCurrent,omitempty"`
+}
+
 type Update struct {
 	MaxChargeCurrent     *int `json:"maxChargeCurrent,omitempty"`
 	MaxChargePhases      *int `json:"maxChargePhases,omitempty"`
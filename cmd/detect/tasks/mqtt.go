@@ -1,12 +1,15 @@
 package tasks
 
 import (
+	"context"
 	"errors"
 	"net"
+	"net/url"
 	"strconv"
 	"time"
 
-	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/eclipse/paho.golang/autopaho"
+	"github.com/eclipse/paho.golang/paho"
 	"github.com/evcc-io/evcc/util"
 )
 
@@ -37,39 +40,62 @@ type MqttHandler struct {
 }
 
 func (h *MqttHandler) Test(log *util.Logger, in ResultDetails) []ResultDetails {
-	addr := net.JoinHostPort(in.IP, strconv.Itoa(h.Port))
+	u := &url.URL{Scheme: "mqtt", Host: net.JoinHostPort(in.IP, strconv.Itoa(h.Port))}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	recv := make(chan bool, 1)
+
+	cm, err := autopaho.NewConnection(ctx, autopaho.ClientConfig{
+		ServerUrls:                    []*url.URL{u},
+		KeepAlive:                     30,
+		CleanStartOnInitialConnection: true,
+		ConnectTimeout:                timeout,
+		ClientConfig: paho.ClientConfig{
+			OnPublishReceived: []func(paho.PublishReceived) (bool, error){
+				func(paho.PublishReceived) (bool, error) {
+					select {
+					case recv <- true:
+					default:
+					}
+					return true, nil
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil
+	}
 
-	opt := mqtt.NewClientOptions()
-	opt.AddBroker(addr)
-	opt.SetConnectTimeout(timeout)
+	defer func() {
+		dctx, dcancel := context.WithTimeout(context.Background(), time.Second)
+		defer dcancel()
+		_ = cm.Disconnect(dctx)
+	}()
 
-	client := mqtt.NewClient(opt)
+	cctx, ccancel := context.WithTimeout(ctx, timeout)
+	defer ccancel()
 
-	var ok bool
-	token := client.Connect()
-	if token.Wait() {
-		ok = token.Error() == nil
+	if err := cm.AwaitConnection(cctx); err != nil || h.Topic == "" {
+		return nil
 	}
 
-	if ok && h.Topic != "" {
-		recv := make(chan bool, 1)
-		_ = client.Subscribe(h.Topic, 1, func(mqtt.Client, mqtt.Message) {
-			recv <- true
-		})
-
-		timer := time.NewTimer(timeout)
-
-		for {
-			select {
-			case <-recv:
-				out := in.Clone()
-				out.Topic = h.Topic
-				return []ResultDetails{out}
-			case <-timer.C:
-				return nil
-			}
-		}
+	sctx, scancel := context.WithTimeout(ctx, timeout)
+	defer scancel()
+
+	if _, err := cm.Subscribe(sctx, &paho.Subscribe{
+		Subscriptions: []paho.SubscribeOptions{{Topic: h.Topic, QoS: 1}},
+	}); err != nil {
+		return nil
 	}
 
-	return nil
+	select {
+	case <-recv:
+		out := in.Clone()
+		out.Topic = h.Topic
+		return []ResultDetails{out}
+	case <-time.After(timeout):
+		return nil
+	}
 }
@@ -1,9 +1,9 @@
 package cmd
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
-	"net/http"
 	_ "net/http/pprof" // pprof handler
 	"os"
 	"os/signal"
@@ -66,6 +66,9 @@ func init() {
 
 	rootCmd.Flags().Bool("profile", false, "Expose pprof profiles")
 	bind(rootCmd, "profile")
+
+	rootCmd.Flags().String("profile-token", "", "Token required for accessing pprof profiles remotely, profiles are only available from localhost without token")
+	bind(rootCmd, "profileToken", "profile-token")
 }
 
 // initConfig reads in config file and ENV variables if set
@@ -115,6 +118,11 @@ func runRoot(cmd *cobra.Command, args []string) {
 		err = cfgErr
 	}
 
+	// low memory mode must be applied before creating the web server
+	if err == nil {
+		err = configureLowMemory(conf.LowMemory)
+	}
+
 	// network config
 	if viper.GetString("uri") != "" {
 		log.WARN.Println("`uri` is deprecated and will be ignored. Use `network` instead.")
@@ -140,7 +148,11 @@ func runRoot(cmd *cobra.Command, args []string) {
 
 	// pprof
 	if viper.GetBool("profile") {
-		httpd.Router().PathPrefix("/debug/").Handler(http.DefaultServeMux)
+		token := viper.GetString("profileToken")
+		if token == "" {
+			log.WARN.Println("profile: no profile token configured, profiles are only available from localhost")
+		}
+		httpd.RegisterProfileHandlers(token)
 	}
 
 	// publish to UI
@@ -201,20 +213,50 @@ func runRoot(cmd *cobra.Command, args []string) {
 		}
 	}
 
+	// mirror states to physical indicators
+	if err == nil && len(conf.Indicators) > 0 {
+		err = configureIndicators(conf.Indicators, tee)
+	}
+
 	// announce on mDNS
 	if err == nil && strings.HasSuffix(conf.Network.Host, ".local") {
 		err = configureMDNS(conf.Network)
 	}
 
+	// remote access tunnel
+	if err == nil && conf.Tunnel.Server != "" {
+		err = configureTunnel(conf.Tunnel, httpd)
+	}
+
 	// start HEMS server
 	if err == nil && conf.HEMS.Type != "" {
 		err = configureHEMS(conf.HEMS, site, httpd)
 	}
 
+	// expose loadpoints to roaming platforms
+	if err == nil && conf.OCPI.Token != "" {
+		err = configureOCPI(conf.OCPI, site, httpd)
+	}
+
+	// expose loadpoints to Apple Home
+	if err == nil && conf.HomeKit != nil {
+		err = configureHomeKit(*conf.HomeKit, site, tee)
+	}
+
+	// derive charge plans from calendars
+	if err == nil && len(conf.Calendars) > 0 {
+		err = configureCalendars(conf.Calendars)
+	}
+
 	// setup messaging
 	var pushChan chan push.Event
 	if err == nil {
-		pushChan, err = configureMessengers(conf.Messaging, site.Vehicles(), valueChan, cache)
+		pushChan, err = configureMessengers(conf.Messaging, site, valueChan, cache)
+	}
+
+	// setup alert rules
+	if err == nil && len(conf.Messaging.Alerts) > 0 {
+		err = configureAlerts(conf.Messaging.Alerts, site, cache, pushChan)
 	}
 
 	// run shutdown functions on stop
@@ -249,6 +291,7 @@ func runRoot(cmd *cobra.Command, args []string) {
 	// show main ui
 	if err == nil {
 		httpd.RegisterSiteHandlers(site, cache)
+		configureDashboard(conf.Dashboard, httpd, socketHub, cache)
 		httpd.RegisterShutdownHandler(func() {
 			log.FATAL.Println("evcc was stopped by user. OS should restart the service. Or restart manually.")
 			once.Do(func() { close(stopC) }) // signal loop to end
@@ -280,6 +323,9 @@ func runRoot(cmd *cobra.Command, args []string) {
 		go func() {
 			site.Run(stopC, conf.Interval)
 		}()
+
+		// systemd watchdog
+		go server.SystemdWatchdog(site)
 	} else {
 		httpd.RegisterShutdownHandler(func() {
 			log.FATAL.Println("evcc was stopped. OS should restart the service. Or restart manually.")
@@ -303,5 +349,11 @@ func runRoot(cmd *cobra.Command, args []string) {
 	// uds health check listener
 	go server.HealthListener(site)
 
+	if conf.Network.TLS.Enabled() {
+		log.INFO.Printf("starting https at :%d", cmp.Or(conf.Network.TLS.Port, 443))
+		log.FATAL.Println(wrapErrors(httpd.ListenAndServeHTTPS(conf.Network.TLS)))
+		return
+	}
+
 	log.FATAL.Println(wrapErrors(httpd.ListenAndServe()))
 }
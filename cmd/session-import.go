@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+
+	"github.com/evcc-io/evcc/core/session"
+	"github.com/evcc-io/evcc/server/db"
+	"github.com/spf13/cobra"
+)
+
+// sessionImportCmd represents the session import command
+var sessionImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import charging sessions from csv export (generic, goe, easee)",
+	Run:   runSessionImport,
+	Args:  cobra.ExactArgs(1),
+}
+
+func init() {
+	sessionCmd.AddCommand(sessionImportCmd)
+	sessionImportCmd.Flags().String("format", "generic", "Csv format (generic, goe, easee)")
+	sessionImportCmd.Flags().String("loadpoint", "", "Loadpoint title for sessions without loadpoint")
+}
+
+func runSessionImport(cmd *cobra.Command, args []string) {
+	// load config
+	if err := loadConfigFile(&conf); err != nil {
+		log.FATAL.Fatal(err)
+	}
+
+	// setup environment
+	if err := configureEnvironment(cmd, conf); err != nil {
+		log.FATAL.Fatal(err)
+	}
+
+	if db.Instance == nil {
+		log.FATAL.Fatal(errors.New("database offline"))
+	}
+
+	f, err := os.Open(args[0])
+	if err != nil {
+		log.FATAL.Fatal(err)
+	}
+	defer f.Close()
+
+	format, _ := cmd.Flags().GetString("format")
+	loadpoint, _ := cmd.Flags().GetString("loadpoint")
+
+	sessions, err := session.Import(f, format, loadpoint)
+	if err != nil {
+		log.FATAL.Fatal(err)
+	}
+
+	added, err := session.Store(db.Instance, sessions)
+	if err != nil {
+		log.FATAL.Fatal(err)
+	}
+
+	log.INFO.Printf("imported %d of %d sessions", added, len(sessions))
+
+	// wait for shutdown
+	<-shutdownDoneC()
+}
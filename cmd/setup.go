@@ -3,34 +3,51 @@ package cmd
 import (
 	"cmp"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"net"
 	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
 	"regexp"
+	"runtime/debug"
 	"slices"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
-	paho "github.com/eclipse/paho.mqtt.golang"
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/calendar"
 	"github.com/evcc-io/evcc/charger"
 	"github.com/evcc-io/evcc/charger/eebus"
 	"github.com/evcc-io/evcc/cmd/shutdown"
 	"github.com/evcc-io/evcc/core"
+	"github.com/evcc-io/evcc/core/alert"
+	"github.com/evcc-io/evcc/core/audit"
+	"github.com/evcc-io/evcc/core/history"
+	"github.com/evcc-io/evcc/core/session"
 	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/core/user"
+	corevehicle "github.com/evcc-io/evcc/core/vehicle"
 	"github.com/evcc-io/evcc/hems"
+	"github.com/evcc-io/evcc/indicator"
 	"github.com/evcc-io/evcc/meter"
+	"github.com/evcc-io/evcc/provider"
 	"github.com/evcc-io/evcc/provider/golang"
 	"github.com/evcc-io/evcc/provider/javascript"
 	"github.com/evcc-io/evcc/provider/mqtt"
 	"github.com/evcc-io/evcc/push"
 	"github.com/evcc-io/evcc/server"
+	"github.com/evcc-io/evcc/server/assets"
 	"github.com/evcc-io/evcc/server/db"
 	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/evcc-io/evcc/server/homekit"
 	"github.com/evcc-io/evcc/server/oauth2redirect"
+	"github.com/evcc-io/evcc/server/ocpi"
 	"github.com/evcc-io/evcc/tariff"
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/config"
@@ -78,25 +95,41 @@ type globalConfig struct {
 	Telemetry    bool
 	Metrics      bool
 	Profile      bool
+	ProfileToken string
+	LowMemory    lowMemoryConfig
 	Levels       map[string]string
 	Interval     time.Duration
 	Database     dbConfig
 	Mqtt         mqttConfig
+	MqttBrokers  []mqtt.NamedConfig
 	ModbusProxy  []proxyConfig
 	Javascript   []javascriptConfig
 	Go           []goConfig
 	Influx       server.InfluxConfig
 	EEBus        map[string]interface{}
 	HEMS         config.Typed
+	OCPI         ocpi.Config
+	Dashboard    server.PublicConfig
+	Tunnel       server.TunnelConfig
+	HomeKit      *homekit.Config
 	Messaging    messagingConfig
 	Meters       []config.Named
 	Chargers     []config.Named
 	Vehicles     []config.Named
+	Users        []user.User
 	Tariffs      tariffConfig
+	Calendars    []calendarConfig
+	Indicators   []indicator.Config
 	Site         map[string]interface{}
 	Loadpoints   []map[string]interface{}
 }
 
+type lowMemoryConfig struct {
+	Enabled     bool
+	Assets      string // directory containing ui dist and i18n, served instead of embedded assets
+	MemoryLimit int    // soft memory limit (MB)
+}
+
 type mqttConfig struct {
 	mqtt.Config `mapstructure:",squash"`
 	Topic       string
@@ -119,13 +152,23 @@ type proxyConfig struct {
 }
 
 type dbConfig struct {
-	Type string
-	Dsn  string
+	Type        string
+	Dsn         string
+	JournalMode string // sqlite journal mode, defaults to WAL
+	Retention   retentionConfig
+}
+
+type retentionConfig struct {
+	Sessions   time.Duration // 0 keeps sessions forever
+	History    time.Duration // power flow snapshots
+	Downsample time.Duration // age after which snapshots are downsampled to hourly resolution
+	Audit      time.Duration
 }
 
 type messagingConfig struct {
 	Events   map[string]push.EventTemplateConfig
 	Services []config.Typed
+	Alerts   []alert.Rule
 }
 
 type tariffConfig struct {
@@ -134,12 +177,23 @@ type tariffConfig struct {
 	FeedIn   config.Typed
 	Co2      config.Typed
 	Planner  config.Typed
+	Solar    config.Typed
+}
+
+type calendarConfig struct {
+	Vehicle  string        // vehicle reference
+	Pattern  string        // event title regex
+	Soc      int           // plan soc
+	Lead     time.Duration // time before event start
+	Interval time.Duration
+	Calendar config.Typed
 }
 
 type networkConfig struct {
 	Schema string
 	Host   string
 	Port   int
+	TLS    server.TLSConfig
 }
 
 func (c networkConfig) HostPort() string {
@@ -162,6 +216,10 @@ func loadConfigFile(conf *globalConfig) error {
 
 	log.INFO.Println("using config file:", cfgFile)
 
+	if err == nil {
+		err = resolveConfigSecrets()
+	}
+
 	if err == nil {
 		if err = viper.UnmarshalExact(conf); err != nil {
 			err = fmt.Errorf("failed parsing config file: %w", err)
@@ -176,6 +234,38 @@ func loadConfigFile(conf *globalConfig) error {
 	return err
 }
 
+// resolveConfigSecrets replaces environment and file references in the config file
+func resolveConfigSecrets() error {
+	for _, key := range viper.AllKeys() {
+		val := viper.Get(key)
+
+		res, err := util.ResolveSecrets(val)
+		if err != nil {
+			return fmt.Errorf("failed parsing config file: %s: %w", key, err)
+		}
+
+		if !reflect.DeepEqual(val, res) {
+			viper.Set(key, res)
+		}
+	}
+
+	return nil
+}
+
+// configurableNamed returns the named database configuration with environment and file references resolved.
+// References of yaml configurations are resolved when reading the config file.
+func configurableNamed(conf config.Config) (config.Named, error) {
+	cc := conf.Named()
+
+	other, err := util.ResolveSecretsMap(cc.Other)
+	if err != nil {
+		return cc, fmt.Errorf("cannot create %s '%s': %w", conf.Class, cc.Name, err)
+	}
+	cc.Other = other
+
+	return cc, nil
+}
+
 func configureMeters(static []config.Named, names ...string) error {
 	for i, cc := range static {
 		if cc.Name == "" {
@@ -203,7 +293,10 @@ func configureMeters(static []config.Named, names ...string) error {
 	}
 
 	for _, conf := range configurable {
-		cc := conf.Named()
+		cc, err := configurableNamed(conf)
+		if err != nil {
+			return err
+		}
 
 		if len(names) > 0 && !slices.Contains(names, cc.Name) {
 			return nil
@@ -252,7 +345,10 @@ func configureChargers(static []config.Named, names ...string) error {
 
 	for _, conf := range configurable {
 		g.Go(func() error {
-			cc := conf.Named()
+			cc, err := configurableNamed(conf)
+			if err != nil {
+				return err
+			}
 
 			if len(names) > 0 && !slices.Contains(names, cc.Name) {
 				return nil
@@ -337,7 +433,10 @@ func configureVehicles(static []config.Named, names ...string) error {
 
 	for _, conf := range configurable {
 		g.Go(func() error {
-			cc := conf.Named()
+			cc, err := configurableNamed(conf)
+			if err != nil {
+				return err
+			}
 
 			if len(names) > 0 && !slices.Contains(names, cc.Name) {
 				return nil
@@ -414,6 +513,11 @@ func configureEnvironment(cmd *cobra.Command, conf globalConfig) (err error) {
 		err = configureMQTT(conf.Mqtt)
 	}
 
+	// setup named mqtt brokers
+	if err == nil {
+		err = configureMQTTBrokers(conf.MqttBrokers)
+	}
+
 	// setup javascript VMs
 	if err == nil {
 		err = configureJavascript(conf.Javascript)
@@ -429,22 +533,102 @@ func configureEnvironment(cmd *cobra.Command, conf globalConfig) (err error) {
 		err = configureEEBus(conf.EEBus)
 	}
 
-	// setup config database
+	// setup config database, continue with yaml configuration if unavailable
 	if err == nil {
-		err = config.Init(db.Instance)
+		if err := config.Init(db.Instance); err != nil && db.Instance != nil {
+			log.ERROR.Println("config database:", err)
+			db.SetError(err)
+		} else if err != nil {
+			return err
+		}
 	}
 
 	return
 }
 
-// configureDatabase configures session database
+// configureLowMemory trims caches and history buffers for devices with little memory
+func configureLowMemory(conf lowMemoryConfig) error {
+	if conf.Assets != "" {
+		dist := filepath.Join(conf.Assets, "dist")
+		if _, err := os.Stat(filepath.Join(dist, "index.html")); err != nil {
+			return fmt.Errorf("assets: %w", err)
+		}
+
+		log.INFO.Println("serving ui assets from", conf.Assets)
+		assets.Web = os.DirFS(dist)
+		assets.I18n = os.DirFS(filepath.Join(conf.Assets, "i18n"))
+	}
+
+	if !conf.Enabled {
+		return nil
+	}
+
+	log.INFO.Println("low memory mode enabled")
+
+	// runtime settings from environment take precedence
+	if os.Getenv("GOGC") == "" {
+		debug.SetGCPercent(50)
+	}
+	if conf.MemoryLimit > 0 && os.Getenv("GOMEMLIMIT") == "" {
+		debug.SetMemoryLimit(int64(conf.MemoryLimit) << 20)
+	}
+
+	provider.ResponseCache = false
+	history.Retention = 12 * time.Hour
+	db.CacheSize = 512
+
+	return nil
+}
+
+// configureDatabase configures session database.
+// If the database is unavailable, e.g. locked, settings are kept in memory and initialization is retried in the background.
 func configureDatabase(conf dbConfig) error {
+	if conf.JournalMode != "" {
+		db.JournalMode = strings.ToUpper(conf.JournalMode)
+	}
+
 	if err := db.NewInstance(conf.Type, conf.Dsn); err != nil {
 		return err
 	}
 
-	if err := settings.Init(); err != nil {
-		return err
+	if r := conf.Retention; r.Downsample > 0 && r.History > 0 && r.Downsample >= r.History {
+		return fmt.Errorf("retention: downsampling age %v must be below history retention %v", r.Downsample, r.History)
+	}
+
+	session.Retention = conf.Retention.Sessions
+	history.Downsample = conf.Retention.Downsample
+	if conf.Retention.History > 0 {
+		history.Retention = conf.Retention.History
+	}
+	if conf.Retention.Audit > 0 {
+		audit.Retention = conf.Retention.Audit
+	}
+
+	initDatabase := func() error {
+		store, err := settings.NewDBStore(db.Instance)
+		if err == nil {
+			err = settings.Init(store)
+		}
+		if err != nil {
+			return err
+		}
+		return audit.Init(db.Instance)
+	}
+
+	if err := initDatabase(); err != nil {
+		log.ERROR.Println("database unavailable, continuing without persistence:", err)
+		db.SetError(err)
+
+		go func() {
+			for range time.Tick(time.Minute) {
+				err := initDatabase()
+				db.SetError(err)
+				if err == nil {
+					log.INFO.Println("database available")
+					return
+				}
+			}
+		}()
 	}
 
 	persistSettings := func() {
@@ -463,9 +647,34 @@ func configureDatabase(conf dbConfig) error {
 		}
 	}()
 
+	// apply retention policies and reclaim free space on startup and daily
+	go func() {
+		maintainDatabase(time.Now())
+		for range time.Tick(24 * time.Hour) {
+			maintainDatabase(time.Now())
+		}
+	}()
+
 	return nil
 }
 
+// maintainDatabase prunes and downsamples stored data according to the retention policies
+func maintainDatabase(now time.Time) {
+	for name, fun := range map[string]func() error{
+		"sessions": func() error { return session.Prune(db.Instance, now) },
+		"history":  func() error { return history.Maintain(db.Instance, now) },
+		"audit":    func() error { return audit.Prune(now) },
+	} {
+		if err := fun(); err != nil {
+			log.ERROR.Printf("retention: %s: %v", name, err)
+		}
+	}
+
+	if err := db.Vacuum(db.Instance); err != nil {
+		log.ERROR.Println("vacuum:", err)
+	}
+}
+
 // configureInflux configures influx database
 func configureInflux(conf server.InfluxConfig, site site.API, in <-chan util.Param) {
 	influx := server.NewInfluxClient(
@@ -488,16 +697,15 @@ func configureInflux(conf server.InfluxConfig, site site.API, in <-chan util.Par
 func configureMQTT(conf mqttConfig) error {
 	log := util.NewLogger("mqtt")
 
-	instance, err := mqtt.RegisteredClient(log, conf.Broker, conf.User, conf.Password, conf.ClientID, 1, conf.Insecure, func(options *paho.ClientOptions) {
-		topic := fmt.Sprintf("%s/status", strings.Trim(conf.Topic, "/"))
-		options.SetWill(topic, "offline", 1, true)
+	topic := fmt.Sprintf("%s/status", strings.Trim(conf.Topic, "/"))
 
-		oc := options.OnConnect
-		options.SetOnConnectHandler(func(client paho.Client) {
-			oc(client)                                   // original handler
-			_ = client.Publish(topic, 1, true, "online") // alive - not logged
-		})
-	})
+	opts, err := conf.Options()
+	if err != nil {
+		return fmt.Errorf("failed configuring mqtt: %w", err)
+	}
+
+	instance, err := mqtt.RegisteredClient(log, conf.Broker, conf.User, conf.Password, conf.ClientID, 1, conf.Insecure,
+		append(opts, mqtt.WithStatus(topic))...)
 	if err != nil {
 		return fmt.Errorf("failed configuring mqtt: %w", err)
 	}
@@ -506,6 +714,19 @@ func configureMQTT(conf mqttConfig) error {
 	return nil
 }
 
+// setup named mqtt brokers
+func configureMQTTBrokers(conf []mqtt.NamedConfig) error {
+	for _, cc := range conf {
+		log := util.NewLogger("mqtt-" + cc.Name)
+
+		if err := mqtt.RegisterNamed(log, cc); err != nil {
+			return fmt.Errorf("failed configuring mqtt broker %s: %w", cc.Name, err)
+		}
+	}
+
+	return nil
+}
+
 // setup javascript
 func configureJavascript(conf []javascriptConfig) error {
 	for _, cc := range conf {
@@ -538,6 +759,132 @@ func configureHEMS(conf config.Typed, site *core.Site, httpd *server.HTTPd) erro
 	return nil
 }
 
+// setup read-only dashboard, optionally on a separate port
+func configureDashboard(conf server.PublicConfig, httpd *server.HTTPd, hub *server.SocketHub, cache *util.Cache) {
+	if conf.Port == 0 {
+		httpd.RegisterPublicHandlers(hub, cache, conf)
+		return
+	}
+
+	public := server.NewPublicHTTPd(fmt.Sprintf(":%d", conf.Port), hub, cache, conf)
+
+	go func() {
+		log.ERROR.Println("dashboard:", public.ListenAndServe())
+	}()
+}
+
+// setup remote access tunnel. The tunnel serves only the read-only dashboard which requires the dashboard token.
+func configureTunnel(conf server.TunnelConfig, httpd *server.HTTPd) error {
+	if conf.Password == "" {
+		return errors.New("failed configuring tunnel: password required")
+	}
+
+	listener, err := server.NewTunnelListener(conf)
+	if err != nil {
+		return fmt.Errorf("failed configuring tunnel: %w", err)
+	}
+
+	srv := server.NewTunnelHTTPd(httpd, conf.Password)
+
+	go func() {
+		if err := srv.Serve(listener); !errors.Is(err, http.ErrServerClosed) && !errors.Is(err, net.ErrClosed) {
+			log.ERROR.Println("tunnel:", err)
+		}
+	}()
+
+	shutdown.Register(func() { listener.Close() })
+
+	return nil
+}
+
+// setup OCPI
+func configureOCPI(conf ocpi.Config, site *core.Site, httpd *server.HTTPd) error {
+	sessions := func() (session.Sessions, error) {
+		if db.Instance == nil {
+			return nil, errors.New("database offline")
+		}
+
+		var res session.Sessions
+		txn := db.Instance.Where("charged_kwh>=0.05").Find(&res)
+
+		return res, txn.Error
+	}
+
+	o, err := ocpi.New(conf, site.Loadpoints(), sessions)
+	if err != nil {
+		return fmt.Errorf("failed configuring ocpi: %w", err)
+	}
+
+	o.Register(httpd.Router())
+
+	return nil
+}
+
+// setup calendar departures
+func configureCalendars(conf []calendarConfig) error {
+	for i, cc := range conf {
+		cal, err := calendar.NewFromConfig(cc.Calendar.Type, cc.Calendar.Other)
+		if err != nil {
+			return fmt.Errorf("calendar %d: %w", i+1, err)
+		}
+
+		dev, err := config.Vehicles().ByName(cc.Vehicle)
+		if err != nil {
+			return fmt.Errorf("calendar %d: %w", i+1, err)
+		}
+
+		re, err := regexp.Compile(cc.Pattern)
+		if err != nil {
+			return fmt.Errorf("calendar %d: invalid pattern: %w", i+1, err)
+		}
+
+		if cc.Soc <= 0 || cc.Soc > 100 {
+			return fmt.Errorf("calendar %d: invalid soc: %d", i+1, cc.Soc)
+		}
+
+		if cc.Interval == 0 {
+			cc.Interval = 15 * time.Minute
+		}
+
+		log := util.NewLogger("calendar")
+		dep := calendar.NewDeparture(log, cal, corevehicle.Adapter(log, dev), re, cc.Soc, cc.Lead)
+
+		go dep.Run(cc.Interval)
+	}
+
+	return nil
+}
+
+// setup indicators
+func configureIndicators(conf []indicator.Config, tee util.TeeAttacher) error {
+	for i, cc := range conf {
+		ind, err := indicator.NewFromConfig(cc)
+		if err != nil {
+			return fmt.Errorf("indicator %d: %w", i+1, err)
+		}
+
+		go ind.Run(tee.Attach())
+	}
+
+	return nil
+}
+
+// setup HomeKit bridge
+func configureHomeKit(conf homekit.Config, site *core.Site, tee util.TeeAttacher) error {
+	hk, err := homekit.New(conf, site.Loadpoints(), server.Version)
+	if err != nil {
+		return fmt.Errorf("failed configuring homekit: %w", err)
+	}
+
+	go hk.Run(tee.Attach())
+
+	go func() {
+		log.ERROR.Println("homekit:", hk.ListenAndServe())
+	}()
+
+	return nil
+}
+
 // setup MDNS
 func configureMDNS(conf networkConfig) error {
 	zc, err := zeroconf.Register("evcc", "_http._tcp", "local.", conf.Port, []string{"path=/"}, nil)
@@ -564,20 +911,39 @@ func configureEEBus(conf map[string]interface{}) error {
 }
 
 // setup messaging
-func configureMessengers(conf messagingConfig, vehicles push.Vehicles, valueChan chan util.Param, cache *util.Cache) (chan push.Event, error) {
+func configureMessengers(conf messagingConfig, site site.API, valueChan chan util.Param, cache *util.Cache) (chan push.Event, error) {
 	messageChan := make(chan push.Event, 1)
 
-	messageHub, err := push.NewHub(conf.Events, vehicles, cache)
+	// alert rules are delivered as events
+	events := maps.Clone(conf.Events)
+	if events == nil {
+		events = make(map[string]push.EventTemplateConfig)
+	}
+	for _, r := range conf.Alerts {
+		events[r.Event()] = r.Template()
+	}
+
+	messageHub, err := push.NewHub(events, site.Vehicles(), cache)
 	if err != nil {
 		return messageChan, fmt.Errorf("failed configuring push services: %w", err)
 	}
 
 	for _, service := range conf.Services {
-		impl, err := push.NewFromConfig(service.Type, service.Other)
+		// message language is handled by the hub
+		other := maps.Clone(service.Other)
+		lang, _ := other["language"].(string)
+		delete(other, "language")
+
+		impl, err := push.NewFromConfig(service.Type, other)
 		if err != nil {
 			return messageChan, fmt.Errorf("failed configuring push service %s: %w", service.Type, err)
 		}
-		messageHub.Add(impl)
+
+		if c, ok := impl.(push.Controller); ok {
+			c.Control(site)
+		}
+
+		messageHub.Add(impl, lang)
 	}
 
 	go messageHub.Run(messageChan, valueChan)
@@ -585,6 +951,18 @@ func configureMessengers(conf messagingConfig, vehicles push.Vehicles, valueChan
 	return messageChan, nil
 }
 
+// configureAlerts evaluates alert rules against published values and device health
+func configureAlerts(rules []alert.Rule, site site.API, cache *util.Cache, pushChan chan<- push.Event) error {
+	engine, err := alert.New(rules, cache, site.HealthStatus, pushChan)
+	if err != nil {
+		return fmt.Errorf("failed configuring alerts: %w", err)
+	}
+
+	go engine.Run(time.Minute)
+
+	return nil
+}
+
 func configureTariff(name string, conf config.Typed, t *api.Tariff, wg *sync.WaitGroup) {
 	defer wg.Done()
 
@@ -595,10 +973,33 @@ func configureTariff(name string, conf config.Typed, t *api.Tariff, wg *sync.Wai
 	res, err := tariff.NewFromConfig(conf.Type, conf.Other)
 	if err != nil {
 		log.ERROR.Printf("failed configuring %s tariff: %v", name, err)
-		return
+
+		// tariff keeps updating in background, use cached rates meanwhile
+		if res == nil {
+			return
+		}
 	}
 
-	*t = res
+	*t = tariff.NewCached(name, res)
+}
+
+// touTariffConfig returns the time-of-use tariff configured in the ui unless the tariff is configured in yaml
+func touTariffConfig(name string, conf config.Typed) config.Typed {
+	if conf.Type != "" {
+		return conf
+	}
+
+	var tou tariff.TouConfig
+	if err := settings.Json(tariff.TouSettingsKey(name), &tou); err != nil {
+		return conf
+	}
+
+	var other map[string]any
+	if b, err := json.Marshal(tou); err != nil || json.Unmarshal(b, &other) != nil {
+		return conf
+	}
+
+	return config.Typed{Type: "tou", Other: other}
 }
 
 func configureTariffs(conf tariffConfig) (*tariff.Tariffs, error) {
@@ -611,12 +1012,13 @@ func configureTariffs(conf tariffConfig) (*tariff.Tariffs, error) {
 	}
 
 	var wg sync.WaitGroup
-	wg.Add(4)
+	wg.Add(5)
 
-	go configureTariff("grid", conf.Grid, &tariffs.Grid, &wg)
-	go configureTariff("feedin", conf.FeedIn, &tariffs.FeedIn, &wg)
+	go configureTariff("grid", touTariffConfig("grid", conf.Grid), &tariffs.Grid, &wg)
+	go configureTariff("feedin", touTariffConfig("feedin", conf.FeedIn), &tariffs.FeedIn, &wg)
 	go configureTariff("co2", conf.Co2, &tariffs.Co2, &wg)
 	go configureTariff("planner", conf.Planner, &tariffs.Planner, &wg)
+	go configureTariff("solar", conf.Solar, &tariffs.Solar, &wg)
 
 	wg.Wait()
 
@@ -638,6 +1040,10 @@ func configureSiteAndLoadpoints(conf globalConfig) (*core.Site, error) {
 		return nil, err
 	}
 
+	if err := user.Configure(conf.Users); err != nil {
+		return nil, fmt.Errorf("failed configuring users: %w", err)
+	}
+
 	loadpoints, err := configureLoadpoints(conf)
 	if err != nil {
 		return nil, fmt.Errorf("failed configuring loadpoints: %w", err)
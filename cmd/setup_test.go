@@ -7,6 +7,7 @@ import (
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core"
 	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/config"
 	"github.com/spf13/viper"
 )
 
@@ -35,3 +36,31 @@ func TestYamlOff(t *testing.T) {
 		t.Errorf("expected `off`, got %s", lp.Mode_)
 	}
 }
+
+func TestConfigurableNamedSecrets(t *testing.T) {
+	// resolved value containing a reference is not expanded again
+	t.Setenv("EVCC_TEST_PASSWORD", "${env:EVCC_TEST_OTHER}")
+	t.Setenv("EVCC_TEST_OTHER", "other")
+
+	conf := config.Config{
+		ID:   1,
+		Type: "template",
+		Details: []config.ConfigDetail{
+			{Key: "password", Value: "${env:EVCC_TEST_PASSWORD}"},
+		},
+	}
+
+	cc, err := configurableNamed(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res := cc.Other["password"]; res != "${env:EVCC_TEST_OTHER}" {
+		t.Errorf("unexpected password: %v", res)
+	}
+
+	// stored configuration keeps the reference
+	if res := conf.Named().Other["password"]; res != "${env:EVCC_TEST_PASSWORD}" {
+		t.Errorf("unexpected stored password: %v", res)
+	}
+}
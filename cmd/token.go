@@ -1,14 +1,18 @@
 package cmd
 
 import (
+	"bytes"
 	"fmt"
+	"os"
 	"slices"
 	"strings"
 
+	"github.com/evcc-io/evcc/server/db/settings"
 	"github.com/evcc-io/evcc/util/config"
 	"github.com/samber/lo"
 	"github.com/spf13/cobra"
 	"golang.org/x/oauth2"
+	"gopkg.in/yaml.v3"
 )
 
 // tokenCmd represents the vehicle command
@@ -18,8 +22,14 @@ var tokenCmd = &cobra.Command{
 	Run:   runToken,
 }
 
+const (
+	tokenStoreSettings = "settings"
+	tokenStoreYaml     = "yaml"
+)
+
 func init() {
 	rootCmd.AddCommand(tokenCmd)
+	tokenCmd.Flags().String("store", "", "Store tokens in settings database or yaml config file instead of printing [settings, yaml]")
 }
 
 func runToken(cmd *cobra.Command, args []string) {
@@ -48,14 +58,17 @@ func runToken(cmd *cobra.Command, args []string) {
 		log.FATAL.Fatalf("vehicle not found, have %v", vehicles)
 	}
 
-	var token *oauth2.Token
-	var err error
+	var (
+		token *oauth2.Token
+		key   string // settings key, empty if not supported
+		err   error
+	)
 
 	switch strings.ToLower(vehicleConf.Type) {
 	case "mercedes":
 		token, err = mercedesToken()
 	case "tronity":
-		token, err = tronityToken(conf, vehicleConf)
+		token, key, err = tronityToken(conf, vehicleConf)
 	default:
 		log.FATAL.Fatalf("vehicle type '%s' does not support token authentication", vehicleConf.Type)
 	}
@@ -64,6 +77,44 @@ func runToken(cmd *cobra.Command, args []string) {
 		log.FATAL.Fatal(err)
 	}
 
+	switch store, _ := cmd.Flags().GetString("store"); store {
+	case tokenStoreSettings:
+		if key == "" {
+			log.FATAL.Fatalf("vehicle type '%s' does not support storing tokens in settings", vehicleConf.Type)
+		}
+
+		if err := configureDatabase(conf.Database); err != nil {
+			log.FATAL.Fatal(err)
+		}
+
+		if err := settings.SetJson(key, token); err != nil {
+			log.FATAL.Fatal(err)
+		}
+
+		if err := settings.Persist(); err != nil {
+			log.FATAL.Fatal(err)
+		}
+
+		fmt.Println()
+		fmt.Println("Tokens stored in settings database")
+
+	case tokenStoreYaml:
+		if err := updateConfigTokens(cfgFile, vehicleConf.Name, token); err != nil {
+			log.FATAL.Fatal(err)
+		}
+
+		fmt.Println()
+		fmt.Println("Tokens added to vehicle config:", cfgFile)
+
+	case "":
+		printTokens(token)
+
+	default:
+		log.FATAL.Fatalf("invalid token store: %s", store)
+	}
+}
+
+func printTokens(token *oauth2.Token) {
 	fmt.Println()
 	fmt.Println("Add the following tokens to the vehicle config:")
 	fmt.Println()
@@ -71,3 +122,80 @@ func runToken(cmd *cobra.Command, args []string) {
 	fmt.Println("      access:", token.AccessToken)
 	fmt.Println("      refresh:", token.RefreshToken)
 }
+
+// updateConfigTokens adds the tokens to the named vehicle in the yaml config file, preserving comments
+func updateConfigTokens(file, name string, token *oauth2.Token) error {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return err
+	}
+
+	vehicle := yamlVehicle(&doc, name)
+	if vehicle == nil {
+		return fmt.Errorf("vehicle not found: %s", name)
+	}
+
+	var tokens yaml.Node
+	if err := tokens.Encode(map[string]string{
+		"access":  token.AccessToken,
+		"refresh": token.RefreshToken,
+	}); err != nil {
+		return err
+	}
+
+	if val := yamlValue(vehicle, "tokens"); val != nil {
+		*val = tokens
+	} else {
+		vehicle.Content = append(vehicle.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "tokens"}, &tokens)
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+
+	if err := enc.Encode(&doc); err != nil {
+		return err
+	}
+
+	return os.WriteFile(file, buf.Bytes(), 0o644)
+}
+
+// yamlValue returns the value node of the mapping key
+func yamlValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if strings.EqualFold(node.Content[i].Value, key) {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+// yamlVehicle returns the vehicle mapping node by name
+func yamlVehicle(doc *yaml.Node, name string) *yaml.Node {
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil
+	}
+
+	vehicles := yamlValue(doc.Content[0], "vehicles")
+	if vehicles == nil || vehicles.Kind != yaml.SequenceNode {
+		return nil
+	}
+
+	for _, v := range vehicles.Content {
+		if n := yamlValue(v, "name"); n != nil && strings.EqualFold(n.Value, name) {
+			return v
+		}
+	}
+
+	return nil
+}
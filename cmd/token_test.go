@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func TestUpdateConfigTokens(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "evcc.yaml")
+
+	require.NoError(t, os.WriteFile(file, []byte(`# config
+vehicles:
+  - name: car
+    type: tronity # cloud
+    tokens:
+      access: old
+  - name: other
+    type: tronity
+`), 0o644))
+
+	require.NoError(t, updateConfigTokens(file, "car", &oauth2.Token{AccessToken: "access", RefreshToken: "refresh"}))
+	require.NoError(t, updateConfigTokens(file, "other", &oauth2.Token{AccessToken: "access2", RefreshToken: "refresh2"}))
+	assert.Error(t, updateConfigTokens(file, "missing", new(oauth2.Token)))
+
+	b, err := os.ReadFile(file)
+	require.NoError(t, err)
+
+	assert.Equal(t, `# config
+vehicles:
+  - name: car
+    type: tronity # cloud
+    tokens:
+      access: access
+      refresh: refresh
+  - name: other
+    type: tronity
+    tokens:
+      access: access2
+      refresh: refresh2
+`, string(b))
+}
@@ -1,12 +1,13 @@
 package cmd
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/evcc-io/evcc/api"
@@ -19,7 +20,7 @@ import (
 	"golang.org/x/oauth2"
 )
 
-func tokenExchangeHandler(oc *oauth2.Config, state string, resC chan *oauth2.Token) func(http.ResponseWriter, *http.Request) {
+func tokenExchangeHandler(oc *oauth2.Config, state string, resC chan<- *oauth2.Token, opts ...oauth2.AuthCodeOption) func(http.ResponseWriter, *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if remote := r.URL.Query().Get("state"); state != remote {
 			w.WriteHeader(http.StatusBadRequest)
@@ -30,9 +31,7 @@ func tokenExchangeHandler(oc *oauth2.Config, state string, resC chan *oauth2.Tok
 		code := r.URL.Query().Get("code")
 
 		ctx := context.Background()
-		token, err := oc.Exchange(ctx, code,
-			oauth2.SetAuthURLParam("grant_type", "code"), // app
-		)
+		token, err := oc.Exchange(ctx, code, opts...)
 		if err != nil {
 			w.WriteHeader(http.StatusBadRequest)
 			fmt.Fprintln(w, err)
@@ -45,52 +44,59 @@ func tokenExchangeHandler(oc *oauth2.Config, state string, resC chan *oauth2.Tok
 	}
 }
 
-func tronityAuthorize(addr string, oc *oauth2.Config) (*oauth2.Token, error) {
+// codeFlowAuthorize performs the oauth2 code flow end-to-end. It opens the login page in the browser and
+// serves the redirect uri for exchanging the code.
+func codeFlowAuthorize(oc *oauth2.Config, authURL func(string) string, opts ...oauth2.AuthCodeOption) (*oauth2.Token, error) {
+	redirect, err := url.Parse(oc.RedirectURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redirect uri: %w", err)
+	}
+
 	state := lo.RandomString(16, lo.AlphanumericCharset)
 
-	uri := oc.AuthCodeURL(state, oauth2.AccessTypeOffline)
-	uri = strings.ReplaceAll(uri, "scope=", "scopes=")
+	uri := authURL(state)
+	fmt.Println("Opening login page, if the browser does not open, please visit:")
+	fmt.Println()
+	fmt.Println("    " + uri)
+	fmt.Println()
 
 	if err := open.Start(uri); err != nil {
-		return nil, err
+		log.WARN.Println("browser:", err)
 	}
 
-	resC := make(chan *oauth2.Token)
-	handler := tokenExchangeHandler(oc, state, resC)
-	defer close(resC)
+	resC := make(chan *oauth2.Token, 1)
 
 	// handle request
 	mux := &http.ServeMux{}
-	mux.HandleFunc("/auth/tronity", handler)
+	mux.HandleFunc(cmp.Or(redirect.Path, "/"), tokenExchangeHandler(oc, state, resC, opts...))
 
-	wg := new(sync.WaitGroup)
 	s := &http.Server{
-		Addr:    addr,
+		Addr:    ":" + cmp.Or(redirect.Port(), "80"),
 		Handler: mux,
 	}
 
+	errC := make(chan error, 1)
+
 	// start server
-	wg.Add(1)
 	go func() {
 		if err := s.ListenAndServe(); err != http.ErrServerClosed {
-			log.FATAL.Fatal(err)
+			errC <- err
 		}
-		wg.Done()
 	}()
 
 	// close on exit
-	defer func() {
-		_ = s.Close()
-		wg.Wait()
-		close(resC)
-	}()
+	defer s.Close()
 
-	t := time.NewTimer(time.Minute)
+	t := time.NewTimer(5 * time.Minute)
+	defer t.Stop()
 
 	select {
 	case <-t.C:
 		return nil, api.ErrTimeout
 
+	case err := <-errC:
+		return nil, err
+
 	case token := <-resC:
 		if token == nil {
 			return nil, errors.New("token not received")
@@ -100,29 +106,36 @@ func tronityAuthorize(addr string, oc *oauth2.Config) (*oauth2.Token, error) {
 	}
 }
 
-func tronityToken(conf globalConfig, vehicleConf config.Named) (*oauth2.Token, error) {
+// tronityToken performs the Tronity code flow and returns the token and its settings key
+func tronityToken(conf globalConfig, vehicleConf config.Named) (*oauth2.Token, string, error) {
 	var cc struct {
 		Credentials vehicle.ClientCredentials
+		VIN         string
 		RedirectURI string
 		Other       map[string]interface{} `mapstructure:",remain"`
 	}
 
 	if err := util.DecodeOther(vehicleConf.Other, &cc); err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	if err := cc.Credentials.Error(); err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	oc, err := tronity.OAuth2Config(cc.Credentials.ID, cc.Credentials.Secret)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	if oc.RedirectURL = cc.RedirectURI; oc.RedirectURL == "" {
 		oc.RedirectURL = fmt.Sprintf("%s/auth/tronity", conf.Network.URI())
 	}
 
-	return tronityAuthorize(conf.Network.HostPort(), oc)
+	token, err := codeFlowAuthorize(oc, func(state string) string {
+		uri := oc.AuthCodeURL(state, oauth2.AccessTypeOffline)
+		return strings.ReplaceAll(uri, "scope=", "scopes=")
+	}, oauth2.SetAuthURLParam("grant_type", "code"))
+
+	return token, tronity.SettingsKey(cc.VIN, cc.Credentials.ID), err
 }
@@ -0,0 +1,227 @@
+package alert
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/push"
+	"github.com/evcc-io/evcc/util"
+)
+
+// EventPrefix is the push event prefix of alert rules
+const EventPrefix = "alert."
+
+// Rule is an alert rule. All configured conditions must be met for the alert to fire.
+type Rule struct {
+	Name      string
+	Key       string        // published value, e.g. gridPower or vehicleSoc
+	Loadpoint int           // loadpoint (1-based) of loadpoint values
+	Above     *float64      // value above
+	Below     *float64      // value below
+	Offline   string        // device reported stale by self-monitoring
+	For       time.Duration // condition must hold this long
+	At        string        // time of day (HH:MM) the condition is checked once, e.g. for departure
+	Repeat    time.Duration // resend interval while active, 0 sends once
+	Title     string        // push message title template
+	Msg       string        // push message template
+}
+
+// Event returns the push event name of the rule
+func (r Rule) Event() string {
+	return EventPrefix + r.Name
+}
+
+// Template returns the push message template of the rule
+func (r Rule) Template() push.EventTemplateConfig {
+	res := push.EventTemplateConfig{Title: r.Title, Msg: r.Msg}
+	if res.Title == "" {
+		res.Title = "Alert: " + r.Name
+	}
+	if res.Msg == "" {
+		res.Msg = r.Name
+	}
+	return res
+}
+
+type rule struct {
+	Rule
+	at      time.Duration // time of day
+	checked string        // day of last time of day check
+	since   time.Time     // condition start
+	sent    time.Time     // last alert
+}
+
+// Values provides published values
+type Values interface {
+	Get(key string) util.Param
+}
+
+// Engine evaluates the alert rules and sends push events
+type Engine struct {
+	log    *util.Logger
+	clock  clock.Clock
+	rules  []*rule
+	values Values
+	health func() site.Health
+	events chan<- push.Event
+}
+
+// New validates the rules and creates the alert engine
+func New(rules []Rule, values Values, health func() site.Health, events chan<- push.Event) (*Engine, error) {
+	e := &Engine{
+		log:    util.NewLogger("alert"),
+		clock:  clock.New(),
+		values: values,
+		health: health,
+		events: events,
+	}
+
+	for _, r := range rules {
+		if r.Name == "" {
+			return nil, errors.New("missing name")
+		}
+
+		if r.Key == "" && r.Offline == "" {
+			return nil, fmt.Errorf("%s: missing key or offline device", r.Name)
+		}
+
+		if r.Key != "" && r.Above == nil && r.Below == nil {
+			return nil, fmt.Errorf("%s: missing above or below", r.Name)
+		}
+
+		rr := &rule{Rule: r}
+
+		if r.At != "" {
+			t, err := time.Parse("15:04", r.At)
+			if err != nil {
+				return nil, fmt.Errorf("%s: invalid time: %s", r.Name, r.At)
+			}
+			rr.at = time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+		}
+
+		e.rules = append(e.rules, rr)
+	}
+
+	return e, nil
+}
+
+// Run evaluates the rules periodically
+func (e *Engine) Run(interval time.Duration) {
+	for range e.clock.Tick(interval) {
+		e.Evaluate()
+	}
+}
+
+// Evaluate evaluates all rules once and sends due alerts
+func (e *Engine) Evaluate() {
+	now := e.clock.Now()
+
+	var health site.Health
+	if e.health != nil {
+		health = e.health()
+	}
+
+	for _, r := range e.rules {
+		if e.evaluate(r, now, health) {
+			e.log.INFO.Printf("%s: alert", r.Name)
+
+			var lp *int
+			if r.Loadpoint > 0 {
+				id := r.Loadpoint - 1
+				lp = &id
+			}
+
+			e.events <- push.Event{Loadpoint: lp, Event: r.Event()}
+		}
+	}
+}
+
+// evaluate updates the rule state and returns true if the alert is due
+func (e *Engine) evaluate(r *rule, now time.Time, health site.Health) bool {
+	// time of day rules are checked once per day
+	if r.At != "" {
+		day := now.Format(time.DateOnly)
+		midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		if r.checked == day || now.Before(midnight.Add(r.at)) {
+			return false
+		}
+
+		r.checked = day
+		return e.condition(r, health)
+	}
+
+	if !e.condition(r, health) {
+		if !r.since.IsZero() {
+			e.log.DEBUG.Printf("%s: cleared", r.Name)
+		}
+
+		r.since = time.Time{}
+		r.sent = time.Time{}
+		return false
+	}
+
+	if r.since.IsZero() {
+		r.since = now
+	}
+
+	if now.Sub(r.since) < r.For {
+		return false
+	}
+
+	if !r.sent.IsZero() && (r.Repeat == 0 || now.Sub(r.sent) < r.Repeat) {
+		return false
+	}
+
+	r.sent = now
+	return true
+}
+
+// condition returns true if all of the rule's conditions are met
+func (e *Engine) condition(r *rule, health site.Health) bool {
+	if r.Offline != "" {
+		if dev, ok := health.Devices[r.Offline]; !ok || !dev.Stale {
+			return false
+		}
+	}
+
+	if r.Key == "" {
+		return true
+	}
+
+	key := r.Key
+	if r.Loadpoint > 0 {
+		key = strconv.Itoa(r.Loadpoint-1) + "." + key
+	}
+
+	val, ok := floatValue(e.values.Get(key).Val)
+	if !ok {
+		return false
+	}
+
+	return (r.Above == nil || val > *r.Above) && (r.Below == nil || val < *r.Below)
+}
+
+// floatValue converts published values to float
+func floatValue(v any) (float64, bool) {
+	switch val := v.(type) {
+	case float64:
+		return val, true
+	case int:
+		return float64(val), true
+	case int64:
+		return float64(val), true
+	case time.Duration:
+		return val.Seconds(), true
+	case bool:
+		if val {
+			return 1, true
+		}
+		return 0, true
+	default:
+		return 0, false
+	}
+}
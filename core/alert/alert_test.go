@@ -0,0 +1,88 @@
+package alert
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/push"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertRules(t *testing.T) {
+	f := func(f float64) *float64 { return &f }
+
+	lp := 0
+	cache := util.NewCache()
+	cache.Add("gridPower", util.Param{Key: "gridPower", Val: 6000.0})
+	cache.Add("0.vehicleSoc", util.Param{Loadpoint: &lp, Key: "vehicleSoc", Val: 40.0})
+
+	var health site.Health
+	events := make(chan push.Event, 10)
+
+	e, err := New([]Rule{
+		{Name: "import", Key: "gridPower", Above: f(5000), For: 10 * time.Minute},
+		{Name: "soc", Key: "vehicleSoc", Loadpoint: 1, Below: f(50), At: "06:00"},
+		{Name: "offline", Offline: "charger", Repeat: time.Hour},
+	}, cache, func() site.Health { return health }, events)
+	require.NoError(t, err)
+
+	mock := clock.NewMock()
+	mock.Set(time.Date(2026, 10, 15, 5, 0, 0, 0, time.Local))
+	e.clock = mock
+
+	received := func() []string {
+		var res []string
+		for {
+			select {
+			case ev := <-events:
+				res = append(res, ev.Event)
+			default:
+				return res
+			}
+		}
+	}
+
+	e.Evaluate()
+	assert.Empty(t, received())
+
+	// grid import held long enough
+	mock.Add(10 * time.Minute)
+	e.Evaluate()
+	assert.Equal(t, []string{"alert.import"}, received())
+
+	// sent once
+	mock.Add(10 * time.Minute)
+	e.Evaluate()
+	assert.Empty(t, received())
+
+	// soc checked at time of day
+	mock.Set(time.Date(2026, 10, 15, 6, 1, 0, 0, time.Local))
+	cache.Add("gridPower", util.Param{Key: "gridPower", Val: 1000.0})
+	health.Devices = map[string]site.DeviceHealth{"charger": {Stale: true}}
+	e.Evaluate()
+	assert.Equal(t, []string{"alert.soc", "alert.offline"}, received())
+
+	// offline repeated
+	mock.Add(30 * time.Minute)
+	e.Evaluate()
+	assert.Empty(t, received())
+
+	mock.Add(30 * time.Minute)
+	e.Evaluate()
+	assert.Equal(t, []string{"alert.offline"}, received())
+
+	// invalid rules
+	for _, r := range []Rule{
+		{Key: "gridPower", Above: f(0)},
+		{Name: "missing"},
+		{Name: "threshold", Key: "gridPower"},
+		{Name: "time", Offline: "charger", At: "25:00"},
+	} {
+		_, err := New([]Rule{r}, cache, nil, events)
+		assert.Error(t, err, r)
+	}
+}
@@ -0,0 +1,111 @@
+package audit
+
+import (
+	"sync"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+	"gorm.io/gorm"
+)
+
+var Retention = 90 * 24 * time.Hour // audit log retention
+
+// sources of control actions
+const (
+	SourceUI     = "ui"
+	SourceAPI    = "api"
+	SourceMqtt   = "mqtt"
+	SourceSignal = "signal" // external grid signals
+)
+
+// Entry is a control action
+type Entry struct {
+	ID      uint      `json:"id" gorm:"primarykey"`
+	Created time.Time `json:"created" gorm:"index"`
+	Source  string    `json:"source" gorm:"index"`
+	Client  string    `json:"client"` // remote address
+	Action  string    `json:"action"` // api path or mqtt topic
+	Value   string    `json:"value,omitempty"`
+	Error   string    `json:"error,omitempty"`
+}
+
+var (
+	mu     sync.Mutex
+	log    = util.NewLogger("audit")
+	db     *gorm.DB
+	pruned time.Time
+)
+
+// Init creates the audit log storage
+func Init(instance *gorm.DB) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	err := instance.AutoMigrate(new(Entry))
+	if err == nil {
+		db = instance
+	}
+
+	return err
+}
+
+// Record adds a control action to the audit log
+func Record(e Entry) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if e.Created.IsZero() {
+		e.Created = time.Now()
+	}
+
+	log.DEBUG.Printf("%s %s: %s %s", e.Source, e.Client, e.Action, e.Value)
+
+	if db == nil {
+		return
+	}
+
+	if err := db.Create(&e).Error; err != nil {
+		log.ERROR.Printf("persist: %v", err)
+	}
+
+	if e.Created.Sub(pruned) < time.Hour {
+		return
+	}
+	pruned = e.Created
+
+	if err := db.Where("created < ?", e.Created.Add(-Retention)).Delete(new(Entry)).Error; err != nil {
+		log.ERROR.Printf("prune: %v", err)
+	}
+}
+
+// Prune deletes entries older than the retention period
+func Prune(now time.Time) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if db == nil {
+		return nil
+	}
+
+	return db.Where("created < ?", now.Add(-Retention)).Delete(new(Entry)).Error
+}
+
+// Entries returns the most recent control actions since from, optionally filtered by source
+func Entries(from time.Time, source string, limit int) ([]Entry, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	res := make([]Entry, 0)
+	if db == nil {
+		return res, nil
+	}
+
+	tx := db.Where("created >= ?", from)
+	if source != "" {
+		tx = tx.Where("source = ?", source)
+	}
+
+	tx = tx.Order("created desc").Limit(limit).Find(&res)
+
+	return res, tx.Error
+}
@@ -0,0 +1,36 @@
+package audit
+
+import (
+	"testing"
+	"time"
+
+	serverdb "github.com/evcc-io/evcc/server/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAudit(t *testing.T) {
+	instance, err := serverdb.New("sqlite", ":memory:")
+	require.NoError(t, err)
+	require.NoError(t, Init(instance))
+
+	now := time.Now()
+
+	Record(Entry{Created: now.Add(-Retention - time.Hour), Source: SourceUI, Action: "POST /api/loadpoints/1/mode/now"})
+	Record(Entry{Created: now.Add(-time.Hour), Source: SourceMqtt, Action: "/loadpoints/1/mode", Value: "now"})
+	Record(Entry{Created: now, Source: SourceUI, Action: "POST /api/loadpoints/1/mode/pv"})
+
+	res, err := Entries(now.Add(-2*Retention), "", 10)
+	require.NoError(t, err)
+	require.Len(t, res, 2, "expired entry not pruned")
+	assert.Equal(t, "POST /api/loadpoints/1/mode/pv", res[0].Action)
+
+	res, err = Entries(now.Add(-2*Retention), SourceMqtt, 10)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.Equal(t, "now", res[0].Value)
+
+	res, err = Entries(now.Add(-2*Retention), "", 1)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+}
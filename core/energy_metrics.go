@@ -1,18 +1,37 @@
 package core
 
+import (
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/core/session"
+)
+
 // EnergyMetrics calculates stats about the charged energy and gives you details about price or co2s
 type EnergyMetrics struct {
 	totalKWh          float64  // Total amount of energy used (kWh)
 	solarKWh          float64  // Self-produced energy (kWh)
+	minSocKWh         float64  // Energy charged for reaching the vehicle's min soc (kWh)
 	price             *float64 // Total cost (Currency)
 	co2               *float64 // Amount of emitted CO2 (gCO2eq)
+	reference         *float64 // Total cost at reference grid price (Currency)
 	currentGreenShare float64  // Current share of solar energy of site (0-1)
 	currentPrice      *float64 // Current price per kWh
 	currentCo2        *float64 // Current co2 emissions
+	currentReference  *float64 // Current reference grid price per kWh
+	currentMinSoc     bool     // Min soc charging active
+	slots             []energySlot
+	clock             clock.Clock
+}
+
+// energySlot accumulates energy, cost and emissions of a tariff slot
+type energySlot struct {
+	session.TariffSlot
+	co2 *float64 // Amount of emitted CO2 (gCO2eq)
 }
 
 func NewEnergyMetrics() *EnergyMetrics {
-	em := &EnergyMetrics{}
+	em := &EnergyMetrics{
+		clock: clock.New(),
+	}
 	em.Reset()
 
 	return em
@@ -25,6 +44,16 @@ func (em *EnergyMetrics) SetEnvironment(greenShare float64, effPrice, effCo2 *fl
 	em.currentCo2 = effCo2
 }
 
+// SetReferencePrice sets the grid price charged energy is compared against for savings
+func (em *EnergyMetrics) SetReferencePrice(price *float64) {
+	em.currentReference = price
+}
+
+// SetMinSoc marks energy charged from now on as min soc energy
+func (em *EnergyMetrics) SetMinSoc(active bool) {
+	em.currentMinSoc = active
+}
+
 // Update sets the a new value for the total amount of charged energy and updated metrics based on environment values.
 // It returns the added total and green energy.
 func (em *EnergyMetrics) Update(chargedKWh float64) (float64, float64) {
@@ -36,6 +65,9 @@ func (em *EnergyMetrics) Update(chargedKWh float64) (float64, float64) {
 	em.totalKWh = chargedKWh
 	addedGreen := added * em.currentGreenShare
 	em.solarKWh += addedGreen
+	if em.currentMinSoc {
+		em.minSocKWh += added
+	}
 	// optional values
 	if em.currentPrice != nil {
 		addedPrice := *em.currentPrice * added
@@ -53,15 +85,54 @@ func (em *EnergyMetrics) Update(chargedKWh float64) (float64, float64) {
 		}
 		em.co2 = &newCo2
 	}
+	if em.currentReference != nil {
+		reference := *em.currentReference * added
+		if em.reference != nil {
+			reference += *em.reference
+		}
+		em.reference = &reference
+	}
+	em.updateSlot(added)
 	return added, addedGreen
 }
 
+// updateSlot adds energy, cost and emissions at current price and co2 to the current tariff slot
+func (em *EnergyMetrics) updateSlot(added float64) {
+	start := em.clock.Now().Truncate(session.SlotDuration)
+
+	if n := len(em.slots); n == 0 || !em.slots[n-1].Start.Equal(start) {
+		em.slots = append(em.slots, energySlot{TariffSlot: session.TariffSlot{Start: start}})
+	}
+
+	slot := &em.slots[len(em.slots)-1]
+	slot.Energy += added
+
+	if em.currentPrice != nil {
+		price := *em.currentPrice * added
+		if slot.Price != nil {
+			price += *slot.Price
+		}
+		slot.Price = &price
+	}
+
+	if em.currentCo2 != nil {
+		co2 := *em.currentCo2 * added
+		if slot.co2 != nil {
+			co2 += *slot.co2
+		}
+		slot.co2 = &co2
+	}
+}
+
 // Reset sets all calculations to initial values
 func (em *EnergyMetrics) Reset() {
 	em.totalKWh = 0
 	em.solarKWh = 0
+	em.minSocKWh = 0
 	em.price = nil
 	em.co2 = nil
+	em.reference = nil
+	em.slots = nil
 }
 
 // TotalWh returns the total energy in Wh
@@ -69,6 +140,11 @@ func (em *EnergyMetrics) TotalWh() float64 {
 	return em.totalKWh * 1e3
 }
 
+// MinSocWh returns the energy charged for reaching the vehicle's min soc in Wh
+func (em *EnergyMetrics) MinSocWh() float64 {
+	return em.minSocKWh * 1e3
+}
+
 // SolarPercentage returns the share of self-produced energy in percent
 func (em *EnergyMetrics) SolarPercentage() float64 {
 	if em.totalKWh == 0 {
@@ -94,6 +170,15 @@ func (em *EnergyMetrics) PricePerKWh() *float64 {
 	return &price
 }
 
+// ReferencePricePerKWh returns the average reference grid price while charging
+func (em *EnergyMetrics) ReferencePricePerKWh() *float64 {
+	if em.totalKWh == 0 || em.reference == nil {
+		return nil
+	}
+	price := *em.reference / em.totalKWh
+	return &price
+}
+
 // Co2PerKWh returns the average co2 emissions per kWh
 func (em *EnergyMetrics) Co2PerKWh() *float64 {
 	if em.totalKWh == 0 || em.co2 == nil {
@@ -103,10 +188,36 @@ func (em *EnergyMetrics) Co2PerKWh() *float64 {
 	return &co2
 }
 
+// TariffSlots returns the charged energy with average price and emissions per tariff slot
+func (em *EnergyMetrics) TariffSlots() []session.TariffSlot {
+	res := make([]session.TariffSlot, 0, len(em.slots))
+
+	for _, s := range em.slots {
+		slot := s.TariffSlot
+
+		if s.Price != nil {
+			price := *s.Price
+			pricePerKWh := price / s.Energy
+			slot.Price = &price
+			slot.PricePerKWh = &pricePerKWh
+		}
+
+		if s.co2 != nil {
+			co2PerKWh := *s.co2 / s.Energy
+			slot.Co2PerKWh = &co2PerKWh
+		}
+
+		res = append(res, slot)
+	}
+
+	return res
+}
+
 // Publish publishes metrics with a given prefix
 func (em *EnergyMetrics) Publish(prefix string, p publisher) {
 	p.publish(prefix+"Energy", em.TotalWh())
 	p.publish(prefix+"SolarPercentage", em.SolarPercentage())
+	p.publish(prefix+"MinSocEnergy", em.MinSocWh())
 	p.publish(prefix+"PricePerKWh", em.PricePerKWh())
 	p.publish(prefix+"Price", em.Price())
 	p.publish(prefix+"Co2PerKWh", em.Co2PerKWh())
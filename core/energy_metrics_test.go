@@ -2,6 +2,9 @@ package core
 
 import (
 	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
 )
 
 func isEqualFloat64(a, b *float64) bool {
@@ -153,3 +156,63 @@ func TestEnergyMetrics(t *testing.T) {
 		t.Errorf("Metrics not properly reset %+v", s)
 	}
 }
+
+func TestEnergyMetricsMinSoc(t *testing.T) {
+	s := NewEnergyMetrics()
+
+	s.SetMinSoc(true)
+	s.Update(1)
+	s.Update(2)
+	s.SetMinSoc(false)
+	s.Update(5)
+
+	if s.MinSocWh() != 2000 {
+		t.Errorf("MinSocWh was incorrect, got: %.3f, want: %.3f.", s.MinSocWh(), 2000.0)
+	}
+	if s.TotalWh() != 5000 {
+		t.Errorf("TotalWh was incorrect, got: %.3f, want: %.3f.", s.TotalWh(), 5000.0)
+	}
+
+	s.Reset()
+	if s.MinSocWh() != 0 {
+		t.Errorf("MinSocWh not properly reset %+v", s)
+	}
+}
+
+func TestEnergyMetricsTariffSlots(t *testing.T) {
+	f := func(f float64) *float64 { return &f }
+
+	clock := clock.NewMock()
+	clock.Set(time.Date(2024, 1, 1, 10, 10, 0, 0, time.UTC))
+
+	s := NewEnergyMetrics()
+	s.clock = clock
+
+	s.SetEnvironment(0, f(0.3), nil)
+	s.Update(1)
+	clock.Add(5 * time.Minute)
+	s.SetEnvironment(0, f(0.2), f(100))
+	s.Update(2)
+	s.SetEnvironment(0, nil, f(200))
+	s.Update(4)
+
+	slots := s.TariffSlots()
+	if len(slots) != 2 {
+		t.Fatalf("expected 2 slots, got %d", len(slots))
+	}
+
+	if !slots[0].Start.Equal(time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)) || slots[0].Energy != 1 ||
+		!isEqualFloat64(slots[0].PricePerKWh, f(0.3)) || slots[0].Co2PerKWh != nil {
+		t.Errorf("unexpected first slot %+v", slots[0])
+	}
+
+	if !slots[1].Start.Equal(time.Date(2024, 1, 1, 10, 15, 0, 0, time.UTC)) || slots[1].Energy != 3 ||
+		!isEqualFloat64(slots[1].Price, f(0.2)) || !isEqualFloat64(slots[1].Co2PerKWh, f(500.0/3)) {
+		t.Errorf("unexpected second slot %+v", slots[1])
+	}
+
+	s.Reset()
+	if len(s.TariffSlots()) != 0 {
+		t.Errorf("TariffSlots not properly reset")
+	}
+}
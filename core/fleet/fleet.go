@@ -0,0 +1,270 @@
+package fleet
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+)
+
+// Reservation claims a loadpoint for a vehicle during a time window
+type Reservation struct {
+	ID        int       `json:"id"`
+	Loadpoint int       `json:"loadpoint"` // loadpoint number starting at 1
+	Vehicle   string    `json:"vehicle"`
+	From      time.Time `json:"from"`
+	To        time.Time `json:"to"`
+}
+
+// Active returns true if the reservation applies at the given time
+func (r Reservation) Active(ts time.Time) bool {
+	return !ts.Before(r.From) && ts.Before(r.To)
+}
+
+// Request is a vehicle waiting for a free loadpoint
+type Request struct {
+	Vehicle   string     `json:"vehicle"`
+	Departure *time.Time `json:"departure,omitempty"`
+	Energy    float64    `json:"energy,omitempty"` // energy required until departure (kWh)
+	Created   time.Time  `json:"created"`
+}
+
+// Assignment is a free loadpoint offered to a vehicle
+type Assignment struct {
+	Loadpoint int    `json:"loadpoint"`
+	Vehicle   string `json:"vehicle"`
+	Reserved  bool   `json:"reserved,omitempty"`
+}
+
+// State is the persisted fleet state
+type State struct {
+	Reservations []Reservation `json:"reservations"`
+	Queue        []Request     `json:"queue"`
+}
+
+// Fleet sequences charging among more vehicles than loadpoints
+type Fleet struct {
+	mu           sync.Mutex
+	clock        clock.Clock
+	nextID       int
+	reservations []Reservation
+	queue        []Request
+}
+
+// New creates a fleet
+func New() *Fleet {
+	return &Fleet{
+		clock:  clock.New(),
+		nextID: 1,
+	}
+}
+
+// Restore restores persisted state
+func (f *Fleet) Restore(state State) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.reservations = state.Reservations
+	f.queue = state.Queue
+
+	for _, r := range f.reservations {
+		f.nextID = max(f.nextID, r.ID+1)
+	}
+}
+
+// State returns the current state
+func (f *Fleet) State() State {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.prune()
+
+	return State{
+		Reservations: slices.Clone(f.reservations),
+		Queue:        f.sortedQueue(),
+	}
+}
+
+// prune removes expired reservations
+func (f *Fleet) prune() {
+	now := f.clock.Now()
+	f.reservations = slices.DeleteFunc(f.reservations, func(r Reservation) bool {
+		return !r.To.After(now)
+	})
+}
+
+// Reserve adds a reservation
+func (f *Fleet) Reserve(r Reservation) (Reservation, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Loadpoint < 1 || r.Vehicle == "" {
+		return r, errors.New("missing loadpoint or vehicle")
+	}
+
+	if !r.To.After(r.From) || !r.To.After(f.clock.Now()) {
+		return r, errors.New("invalid time window")
+	}
+
+	f.prune()
+
+	for _, o := range f.reservations {
+		if r.From.Before(o.To) && o.From.Before(r.To) && (o.Loadpoint == r.Loadpoint || o.Vehicle == r.Vehicle) {
+			return r, fmt.Errorf("overlaps reservation %d", o.ID)
+		}
+	}
+
+	r.ID = f.nextID
+	f.nextID++
+
+	f.reservations = append(f.reservations, r)
+	slices.SortFunc(f.reservations, func(a, b Reservation) int {
+		return a.From.Compare(b.From)
+	})
+
+	return r, nil
+}
+
+// Cancel removes a reservation
+func (f *Fleet) Cancel(id int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	idx := slices.IndexFunc(f.reservations, func(r Reservation) bool {
+		return r.ID == id
+	})
+	if idx < 0 {
+		return fmt.Errorf("reservation not found: %d", id)
+	}
+
+	f.reservations = slices.Delete(f.reservations, idx, idx+1)
+
+	return nil
+}
+
+// Enqueue adds or updates a vehicle's request for a loadpoint
+func (f *Fleet) Enqueue(r Request) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Vehicle == "" {
+		return errors.New("missing vehicle")
+	}
+
+	r.Created = f.clock.Now()
+
+	if idx := slices.IndexFunc(f.queue, func(o Request) bool { return o.Vehicle == r.Vehicle }); idx >= 0 {
+		r.Created = f.queue[idx].Created
+		f.queue[idx] = r
+		return nil
+	}
+
+	f.queue = append(f.queue, r)
+
+	return nil
+}
+
+// Dequeue removes a vehicle from the queue and returns its request if it was queued
+func (f *Fleet) Dequeue(vehicle string) (Request, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	idx := slices.IndexFunc(f.queue, func(r Request) bool {
+		return r.Vehicle == vehicle
+	})
+	if idx < 0 {
+		return Request{}, false
+	}
+
+	res := f.queue[idx]
+	f.queue = slices.Delete(f.queue, idx, idx+1)
+
+	return res, true
+}
+
+// Reservation returns the loadpoint's active reservation
+func (f *Fleet) Reservation(loadpoint int) (Reservation, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := f.clock.Now()
+
+	for _, r := range f.reservations {
+		if r.Loadpoint == loadpoint && r.Active(now) {
+			return r, true
+		}
+	}
+
+	return Reservation{}, false
+}
+
+// sortedQueue returns the queue ordered by earliest departure, then largest energy deficit, then waiting time
+func (f *Fleet) sortedQueue() []Request {
+	res := slices.Clone(f.queue)
+
+	slices.SortStableFunc(res, func(a, b Request) int {
+		switch {
+		case (a.Departure == nil) != (b.Departure == nil):
+			if a.Departure == nil {
+				return 1
+			}
+			return -1
+		case a.Departure != nil && !a.Departure.Equal(*b.Departure):
+			return a.Departure.Compare(*b.Departure)
+		case a.Energy != b.Energy:
+			return cmp.Compare(b.Energy, a.Energy)
+		default:
+			return a.Created.Compare(b.Created)
+		}
+	})
+
+	return res
+}
+
+// Assign distributes free loadpoints. Loadpoints with an active reservation are held for the reserved vehicle,
+// remaining loadpoints are offered to queued vehicles in queue order.
+func (f *Fleet) Assign(free []int) []Assignment {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := f.clock.Now()
+	f.prune()
+
+	var res []Assignment
+	reserved := make(map[string]bool)
+
+	for _, r := range f.reservations {
+		if r.Active(now) {
+			reserved[r.Vehicle] = true
+
+			if slices.Contains(free, r.Loadpoint) {
+				res = append(res, Assignment{Loadpoint: r.Loadpoint, Vehicle: r.Vehicle, Reserved: true})
+			}
+		}
+	}
+
+	queue := slices.DeleteFunc(f.sortedQueue(), func(r Request) bool {
+		return reserved[r.Vehicle]
+	})
+
+	for _, lp := range free {
+		if len(queue) == 0 {
+			break
+		}
+
+		if slices.ContainsFunc(f.reservations, func(r Reservation) bool {
+			return r.Loadpoint == lp && r.Active(now)
+		}) {
+			continue
+		}
+
+		res = append(res, Assignment{Loadpoint: lp, Vehicle: queue[0].Vehicle})
+		queue = queue[1:]
+	}
+
+	return res
+}
@@ -0,0 +1,121 @@
+package fleet
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReserve(t *testing.T) {
+	clck := clock.NewMock()
+	f := New()
+	f.clock = clck
+
+	now := clck.Now()
+
+	r, err := f.Reserve(Reservation{Loadpoint: 1, Vehicle: "a", From: now, To: now.Add(time.Hour)})
+	require.NoError(t, err)
+	assert.Equal(t, 1, r.ID)
+
+	// overlapping loadpoint
+	_, err = f.Reserve(Reservation{Loadpoint: 1, Vehicle: "b", From: now.Add(30 * time.Minute), To: now.Add(2 * time.Hour)})
+	assert.Error(t, err)
+
+	// overlapping vehicle
+	_, err = f.Reserve(Reservation{Loadpoint: 2, Vehicle: "a", From: now.Add(30 * time.Minute), To: now.Add(2 * time.Hour)})
+	assert.Error(t, err)
+
+	// adjacent
+	_, err = f.Reserve(Reservation{Loadpoint: 1, Vehicle: "b", From: now.Add(time.Hour), To: now.Add(2 * time.Hour)})
+	assert.NoError(t, err)
+
+	// expired reservations are removed
+	clck.Add(90 * time.Minute)
+	assert.Len(t, f.State().Reservations, 1)
+
+	assert.NoError(t, f.Cancel(2))
+	assert.Error(t, f.Cancel(2))
+}
+
+func TestQueueOrder(t *testing.T) {
+	clck := clock.NewMock()
+	f := New()
+	f.clock = clck
+
+	now := clck.Now()
+	at := func(d time.Duration) *time.Time {
+		ts := now.Add(d)
+		return &ts
+	}
+
+	require.NoError(t, f.Enqueue(Request{Vehicle: "none"}))
+	clck.Add(time.Minute)
+	require.NoError(t, f.Enqueue(Request{Vehicle: "late", Departure: at(8 * time.Hour)}))
+	require.NoError(t, f.Enqueue(Request{Vehicle: "small", Departure: at(4 * time.Hour), Energy: 10}))
+	require.NoError(t, f.Enqueue(Request{Vehicle: "large", Departure: at(4 * time.Hour), Energy: 30}))
+
+	var order []string
+	for _, r := range f.State().Queue {
+		order = append(order, r.Vehicle)
+	}
+	assert.Equal(t, []string{"large", "small", "late", "none"}, order)
+
+	r, ok := f.Dequeue("small")
+	assert.True(t, ok)
+	assert.Equal(t, 10.0, r.Energy)
+
+	_, ok = f.Dequeue("small")
+	assert.False(t, ok)
+}
+
+func TestRequestJSON(t *testing.T) {
+	b, err := json.Marshal(Request{Vehicle: "a"})
+	require.NoError(t, err)
+	assert.NotContains(t, string(b), "departure")
+}
+
+func TestAssign(t *testing.T) {
+	clck := clock.NewMock()
+	f := New()
+	f.clock = clck
+
+	now := clck.Now()
+
+	_, err := f.Reserve(Reservation{Loadpoint: 1, Vehicle: "a", From: now, To: now.Add(time.Hour)})
+	require.NoError(t, err)
+
+	require.NoError(t, f.Enqueue(Request{Vehicle: "a"}))
+	departure := now.Add(time.Hour)
+	require.NoError(t, f.Enqueue(Request{Vehicle: "b", Departure: &departure}))
+	require.NoError(t, f.Enqueue(Request{Vehicle: "c"}))
+
+	// reserved loadpoint held for reserved vehicle, queue served in order
+	assert.Equal(t, []Assignment{
+		{Loadpoint: 1, Vehicle: "a", Reserved: true},
+		{Loadpoint: 2, Vehicle: "b"},
+		{Loadpoint: 3, Vehicle: "c"},
+	}, f.Assign([]int{1, 2, 3}))
+
+	// reserved loadpoint not available to queue
+	assert.Equal(t, []Assignment{
+		{Loadpoint: 2, Vehicle: "b"},
+	}, f.Assign([]int{2}))
+
+	r, ok := f.Reservation(1)
+	assert.True(t, ok)
+	assert.Equal(t, "a", r.Vehicle)
+
+	// reservation expired
+	clck.Add(time.Hour)
+
+	_, ok = f.Reservation(1)
+	assert.False(t, ok)
+
+	assert.Equal(t, []Assignment{
+		{Loadpoint: 1, Vehicle: "b"},
+	}, f.Assign([]int{1}))
+}
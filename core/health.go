@@ -3,6 +3,8 @@ package core
 import (
 	"sync"
 	"time"
+
+	"github.com/evcc-io/evcc/core/site"
 )
 
 // Health is a health checker that needs regular updates to stay healthy
@@ -10,11 +12,30 @@ type Health struct {
 	mux     sync.Mutex
 	updated time.Time
 	timeout time.Duration
+	latency time.Duration
+	total   time.Duration
+	max     time.Duration
+	loops   int64
+	errors  int64
+	devices map[string]*deviceHealth
+}
+
+type deviceHealth struct {
+	updated   time.Time
+	errors    int64
+	lastError string
+	reads     int64
+	duration  time.Duration
+	total     time.Duration
+	max       time.Duration
 }
 
 // NewHealth creates new health checker
 func NewHealth(timeout time.Duration) *Health {
-	return &Health{timeout: timeout}
+	return &Health{
+		timeout: timeout,
+		devices: make(map[string]*deviceHealth),
+	}
 }
 
 // Healthy returns health status based on last update timestamp
@@ -40,3 +61,91 @@ func (health *Health) Update() {
 
 	health.updated = time.Now()
 }
+
+// Loop records the duration and result of a control loop
+func (health *Health) Loop(latency time.Duration, err error) {
+	if health == nil {
+		return
+	}
+
+	health.mux.Lock()
+	defer health.mux.Unlock()
+
+	health.latency = latency
+	health.total += latency
+	health.max = max(health.max, latency)
+	health.loops++
+	if err != nil {
+		health.errors++
+	}
+}
+
+// Device records the result and duration of reading a device
+func (health *Health) Device(name string, duration time.Duration, err error) {
+	if health == nil || name == "" {
+		return
+	}
+
+	health.mux.Lock()
+	defer health.mux.Unlock()
+
+	dev, ok := health.devices[name]
+	if !ok {
+		dev = new(deviceHealth)
+		health.devices[name] = dev
+	}
+
+	dev.reads++
+	dev.duration = duration
+	dev.total += duration
+	dev.max = max(dev.max, duration)
+
+	if err != nil {
+		dev.errors++
+		dev.lastError = err.Error()
+		return
+	}
+
+	dev.updated = time.Now()
+}
+
+// Status returns the health status of the control loop and its devices
+func (health *Health) Status() site.Health {
+	if health == nil {
+		return site.Health{}
+	}
+
+	health.mux.Lock()
+	defer health.mux.Unlock()
+
+	res := site.Health{
+		Healthy:    time.Since(health.updated) < health.timeout,
+		Updated:    health.updated,
+		Latency:    health.latency.Seconds(),
+		MaxLatency: health.max.Seconds(),
+		Loops:      health.loops,
+		Errors:     health.errors,
+		Devices:    make(map[string]site.DeviceHealth, len(health.devices)),
+	}
+
+	if health.loops > 0 {
+		res.AvgLatency = (health.total / time.Duration(health.loops)).Seconds()
+	}
+
+	for name, dev := range health.devices {
+		age := time.Since(dev.updated)
+
+		res.Devices[name] = site.DeviceHealth{
+			Updated:     dev.updated,
+			Age:         age.Seconds(),
+			Stale:       age >= health.timeout,
+			Errors:      dev.errors,
+			LastError:   dev.lastError,
+			Duration:    dev.duration.Seconds(),
+			AvgDuration: (dev.total / time.Duration(dev.reads)).Seconds(),
+			MaxDuration: dev.max.Seconds(),
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,43 @@
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthStatus(t *testing.T) {
+	var nilHealth *Health
+	assert.False(t, nilHealth.Status().Healthy)
+
+	h := NewHealth(time.Minute)
+	h.Device("grid", 100*time.Millisecond, nil)
+	h.Device("grid", 300*time.Millisecond, nil)
+	h.Device("charger", 5*time.Second, errors.New("timeout"))
+	h.Device("", 0, nil)
+	h.Update()
+	h.Loop(time.Second, nil)
+	h.Loop(3*time.Second, errors.New("grid meter: timeout"))
+
+	res := h.Status()
+	assert.True(t, res.Healthy)
+	assert.Equal(t, 3.0, res.Latency)
+	assert.Equal(t, 2.0, res.AvgLatency)
+	assert.Equal(t, 3.0, res.MaxLatency)
+	assert.Equal(t, int64(2), res.Loops)
+	assert.Equal(t, int64(1), res.Errors)
+	assert.Len(t, res.Devices, 2)
+
+	assert.False(t, res.Devices["grid"].Stale)
+	assert.Equal(t, int64(0), res.Devices["grid"].Errors)
+	assert.InDelta(t, 0.3, res.Devices["grid"].Duration, 1e-9)
+	assert.InDelta(t, 0.2, res.Devices["grid"].AvgDuration, 1e-9)
+	assert.InDelta(t, 0.3, res.Devices["grid"].MaxDuration, 1e-9)
+
+	// never read successfully
+	assert.True(t, res.Devices["charger"].Stale)
+	assert.Equal(t, int64(1), res.Devices["charger"].Errors)
+	assert.Equal(t, "timeout", res.Devices["charger"].LastError)
+}
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"math"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
@@ -22,12 +23,24 @@ func bo() *backoff.ExponentialBackOff {
 	return bo
 }
 
-// powerToCurrent is a helper function to convert power to per-phase current
-func powerToCurrent(power float64, phases int) float64 {
-	if Voltage == 0 {
-		panic("Voltage is not set")
+// averageVoltage returns the average of plausible phase voltages or 0 if none.
+// Voltages deviating more than 15% from the nominal voltage are ignored, e.g. unconnected phases.
+func averageVoltage(voltages []float64) float64 {
+	var sum float64
+	var n int
+
+	for _, u := range voltages {
+		if math.Abs(u-Voltage) <= 0.15*Voltage {
+			sum += u
+			n++
+		}
+	}
+
+	if n == 0 {
+		return 0
 	}
-	return power / (float64(phases) * Voltage)
+
+	return sum / float64(n)
 }
 
 // sitePower returns the available delta power that the charger might additionally consume
@@ -43,3 +56,18 @@ func sitePower(log *util.Logger, maxGrid, grid, battery, residual float64) float
 
 	return grid + battery + residual
 }
+
+// powerLimitStop is the smallest site power limit. Since a limit of 0 means unlimited,
+// it is used to stop charging. Being below any charger's minimum power it disables the charger.
+const powerLimitStop = 1.0
+
+// minPowerLimit returns the smallest of the given power limits, 0 for unlimited
+func minPowerLimit(limits ...float64) float64 {
+	var res float64
+	for _, limit := range limits {
+		if limit > 0 && (res == 0 || limit < res) {
+			res = limit
+		}
+	}
+	return res
+}
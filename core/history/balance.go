@@ -0,0 +1,145 @@
+package history
+
+import (
+	"time"
+)
+
+// energy flow nodes
+const (
+	PV      = "pv"
+	Grid    = "grid"
+	Battery = "battery"
+	Home    = "home"
+	Vehicle = "vehicle"
+)
+
+// Link is the energy transferred from source to target
+type Link struct {
+	Source string  `json:"source"`
+	Target string  `json:"target"`
+	Energy float64 `json:"energy"` // kWh
+}
+
+// Balance is the energy balance of a time bucket suitable for Sankey diagrams
+type Balance struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	Links []Link    `json:"links"`
+}
+
+// allocation orders
+var (
+	sources = []string{PV, Battery, Grid}
+	targets = []string{Home, Vehicle, Battery, Grid}
+)
+
+// allocate distributes the flow's sources to its targets.
+// PV is consumed first, then battery discharge and finally grid import. Targets are served in order home, vehicle, battery and grid export.
+func allocate(f Flow) map[[2]string]float64 {
+	var vehicle float64
+	for _, p := range f.Loadpoints {
+		vehicle += max(p, 0)
+	}
+
+	supply := map[string]float64{
+		PV:      max(f.PV, 0),
+		Battery: f.Discharge(),
+		Grid:    f.Import(),
+	}
+
+	demand := map[string]float64{
+		Home:    max(f.Home, 0),
+		Vehicle: vehicle,
+		Battery: f.Charge(),
+		Grid:    f.Export(),
+	}
+
+	res := make(map[[2]string]float64)
+
+	for _, s := range sources {
+		for _, t := range targets {
+			if s == t {
+				continue
+			}
+
+			p := min(supply[s], demand[t])
+			if p <= 0 {
+				continue
+			}
+
+			res[[2]string{s, t}] += p
+			supply[s] -= p
+			demand[t] -= p
+		}
+	}
+
+	return res
+}
+
+// Balances integrates the power flows into energy balances per bucket, a zero bucket returns a single balance.
+// Each snapshot's power is held until the next snapshot for at most twice its resolution, the last snapshot for its resolution.
+func Balances(flows []Flow, bucket time.Duration) []Balance {
+	var (
+		res     = make([]Balance, 0)
+		current map[[2]string]float64
+		start   time.Time
+	)
+
+	bucketStart := func(ts time.Time) time.Time {
+		if bucket == 0 {
+			return flows[0].Created
+		}
+		return ts.Truncate(bucket)
+	}
+
+	flush := func(end time.Time) {
+		if current == nil {
+			return
+		}
+
+		if bucket > 0 {
+			end = start.Add(bucket)
+		}
+
+		b := Balance{Start: start, End: end, Links: make([]Link, 0, len(current))}
+
+		for _, s := range sources {
+			for _, t := range targets {
+				if e, ok := current[[2]string{s, t}]; ok {
+					b.Links = append(b.Links, Link{Source: s, Target: t, Energy: e})
+				}
+			}
+		}
+
+		res = append(res, b)
+		current = nil
+	}
+
+	for i, f := range flows {
+		span := f.Resolution()
+		end := f.Created.Add(span)
+		if i+1 < len(flows) {
+			end = f.Created.Add(2 * span)
+			if next := flows[i+1].Created; next.Before(end) {
+				end = next
+			}
+		}
+
+		if bs := bucketStart(f.Created); current == nil || !bs.Equal(start) {
+			flush(f.Created)
+			start = bs
+			current = make(map[[2]string]float64)
+		}
+
+		hours := end.Sub(f.Created).Hours()
+		for k, p := range allocate(f) {
+			current[k] += p * hours / 1e3
+		}
+
+		if i == len(flows)-1 {
+			flush(end)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,63 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllocate(t *testing.T) {
+	// 5kW pv serve 1kW home and 3kW vehicle, 1kW charges the battery
+	assert.Equal(t, map[[2]string]float64{
+		{PV, Home}:    1000,
+		{PV, Vehicle}: 3000,
+		{PV, Battery}: 1000,
+	}, allocate(Flow{PV: 5000, Battery: -1000, Home: 1000, Loadpoints: []float64{3000}}))
+
+	// battery and grid cover the vehicle at night
+	assert.Equal(t, map[[2]string]float64{
+		{Battery, Home}:    500,
+		{Battery, Vehicle}: 1500,
+		{Grid, Vehicle}:    9000,
+	}, allocate(Flow{Grid: 9000, Battery: 2000, Home: 500, Loadpoints: []float64{10500}}))
+
+	// export
+	assert.Equal(t, map[[2]string]float64{
+		{PV, Home}: 500,
+		{PV, Grid}: 2500,
+	}, allocate(Flow{PV: 3000, Grid: -2500, Home: 500}))
+}
+
+func TestBalances(t *testing.T) {
+	start := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+
+	var flows []Flow
+	for ts := start; ts.Before(start.Add(2 * time.Hour)); ts = ts.Add(Interval) {
+		flows = append(flows, Flow{Created: ts, PV: 4000, Grid: -1000, Home: 1000, Loadpoints: []float64{2000}})
+	}
+
+	res := Balances(flows, time.Hour)
+	require.Len(t, res, 2)
+
+	assert.Equal(t, start, res[0].Start)
+	assert.Equal(t, start.Add(time.Hour), res[0].End)
+	require.Len(t, res[0].Links, 3)
+	for i, l := range []Link{
+		{Source: PV, Target: Home, Energy: 1},
+		{Source: PV, Target: Vehicle, Energy: 2},
+		{Source: PV, Target: Grid, Energy: 1},
+	} {
+		assert.Equal(t, l.Source, res[0].Links[i].Source)
+		assert.Equal(t, l.Target, res[0].Links[i].Target)
+		assert.InDelta(t, l.Energy, res[0].Links[i].Energy, 1e-9)
+	}
+
+	// single bucket
+	res = Balances(flows, 0)
+	require.Len(t, res, 1)
+	assert.InDelta(t, 4.0, res[0].Links[1].Energy, 1e-9)
+
+	assert.Empty(t, Balances(nil, time.Hour))
+}
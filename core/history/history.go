@@ -0,0 +1,190 @@
+package history
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+	"gorm.io/gorm"
+)
+
+const Interval = 30 * time.Second // snapshot resolution
+
+var (
+	// Retention is the snapshot retention
+	Retention = 48 * time.Hour
+
+	// Downsample is the age after which snapshots are downsampled to hourly resolution, 0 to disable
+	Downsample time.Duration
+)
+
+// Flow is a snapshot of the site's power flows
+type Flow struct {
+	ID         uint               `json:"-" gorm:"primarykey"`
+	Created    time.Time          `json:"created" gorm:"index"`
+	Grid       float64            `json:"grid"`
+	PV         float64            `json:"pv"`
+	Battery    float64            `json:"battery"`
+	Home       float64            `json:"home"`
+	Loadpoints []float64          `json:"loadpoints" gorm:"serializer:json"`
+	Consumers  map[string]float64 `json:"consumers,omitempty" gorm:"serializer:json"`
+	Duration   time.Duration      `json:"duration,omitempty"` // downsampled snapshots only
+
+	// downsampled snapshots keep both directions, Grid and Battery then hold import and discharge only
+	GridExport    float64 `json:"gridExport,omitempty"`
+	BatteryCharge float64 `json:"batteryCharge,omitempty"`
+}
+
+// Import returns the grid import power
+func (f Flow) Import() float64 {
+	return max(f.Grid, 0)
+}
+
+// Export returns the grid export power
+func (f Flow) Export() float64 {
+	return max(-f.Grid, 0) + f.GridExport
+}
+
+// Charge returns the battery charge power
+func (f Flow) Charge() float64 {
+	return max(-f.Battery, 0) + f.BatteryCharge
+}
+
+// Discharge returns the battery discharge power
+func (f Flow) Discharge() float64 {
+	return max(f.Battery, 0)
+}
+
+// Resolution returns the time span represented by the snapshot
+func (f Flow) Resolution() time.Duration {
+	if f.Duration > 0 {
+		return f.Duration
+	}
+	return Interval
+}
+
+// DB is a SQL database storage service for power flows
+type DB struct {
+	log     *util.Logger
+	db      *gorm.DB
+	updated time.Time
+	pruned  time.Time
+}
+
+// NewStore creates a power flow history store
+func NewStore(db *gorm.DB) (*DB, error) {
+	err := db.AutoMigrate(new(Flow))
+
+	historydb := &DB{
+		log: util.NewLogger("db"),
+		db:  db,
+	}
+
+	return historydb, err
+}
+
+// Add persists the flow snapshot if the last snapshot is older than the history interval
+func (s *DB) Add(flow Flow) {
+	if flow.Created.Sub(s.updated) < Interval {
+		return
+	}
+	s.updated = flow.Created
+
+	if err := s.db.Create(&flow).Error; err != nil {
+		s.log.ERROR.Printf("persist: %v", err)
+	}
+
+	if flow.Created.Sub(s.pruned) < time.Hour {
+		return
+	}
+	s.pruned = flow.Created
+
+	if err := s.db.Where("created < ?", flow.Created.Add(-Retention)).Delete(new(Flow)).Error; err != nil {
+		s.log.ERROR.Printf("prune: %v", err)
+	}
+}
+
+// Maintain prunes expired snapshots and downsamples snapshots older than the downsampling age to hourly averages.
+// Averages preserve the integrated energy per direction, i.e. missing snapshots count as zero power and
+// grid import and export or battery charge and discharge do not cancel out.
+func Maintain(db *gorm.DB, now time.Time) error {
+	if err := db.Where("created < ?", now.Add(-Retention)).Delete(new(Flow)).Error; err != nil {
+		return err
+	}
+
+	if Downsample <= 0 {
+		return nil
+	}
+
+	var flows []Flow
+	before := now.Add(-Downsample).Truncate(time.Hour)
+	if err := db.Where("created < ? AND duration = 0", before).Order("created").Find(&flows).Error; err != nil {
+		return err
+	}
+
+	for len(flows) > 0 {
+		hour := flows[0].Created.Truncate(time.Hour)
+
+		n := 1
+		for n < len(flows) && flows[n].Created.Truncate(time.Hour).Equal(hour) {
+			n++
+		}
+
+		if err := downsample(db, hour, flows[:n]); err != nil {
+			return err
+		}
+
+		flows = flows[n:]
+	}
+
+	return nil
+}
+
+// downsample replaces the hour's snapshots by a single snapshot
+func downsample(db *gorm.DB, hour time.Time, flows []Flow) error {
+	scale := Interval.Hours()
+
+	res := Flow{
+		Created:  hour,
+		Duration: time.Hour,
+	}
+
+	ids := make([]uint, 0, len(flows))
+	for _, f := range flows {
+		ids = append(ids, f.ID)
+
+		res.Grid += f.Import() * scale
+		res.GridExport += f.Export() * scale
+		res.PV += f.PV * scale
+		res.Battery += f.Discharge() * scale
+		res.BatteryCharge += f.Charge() * scale
+		res.Home += f.Home * scale
+
+		for i, p := range f.Loadpoints {
+			if i >= len(res.Loadpoints) {
+				res.Loadpoints = append(res.Loadpoints, 0)
+			}
+			res.Loadpoints[i] += p * scale
+		}
+
+		for k, p := range f.Consumers {
+			if res.Consumers == nil {
+				res.Consumers = make(map[string]float64)
+			}
+			res.Consumers[k] += p * scale
+		}
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&res).Error; err != nil {
+			return err
+		}
+		return tx.Delete(new(Flow), ids).Error
+	})
+}
+
+// Flows returns the power flow snapshots since from
+func Flows(db *gorm.DB, from time.Time) ([]Flow, error) {
+	res := make([]Flow, 0)
+	tx := db.Where("created >= ?", from).Order("created").Find(&res)
+	return res, tx.Error
+}
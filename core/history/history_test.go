@@ -0,0 +1,136 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	serverdb "github.com/evcc-io/evcc/server/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistory(t *testing.T) {
+	db, err := serverdb.New("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	s, err := NewStore(db)
+	require.NoError(t, err)
+
+	now := time.Now().Truncate(time.Second)
+	start := now.Add(-Retention - time.Hour)
+
+	// expired snapshot
+	s.Add(Flow{Created: start, Grid: 1})
+
+	// throttled to interval
+	s.Add(Flow{Created: now, Grid: 2, Loadpoints: []float64{1000, 0}})
+	s.Add(Flow{Created: now.Add(Interval / 2), Grid: 3})
+	s.Add(Flow{Created: now.Add(Interval), Grid: 4})
+
+	res, err := Flows(db, start)
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+
+	assert.Equal(t, 2.0, res[0].Grid)
+	assert.Equal(t, []float64{1000, 0}, res[0].Loadpoints)
+	assert.Equal(t, 4.0, res[1].Grid)
+
+	res, err = Flows(db, now.Add(time.Second))
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+}
+
+func TestMaintain(t *testing.T) {
+	db, err := serverdb.New("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	s, err := NewStore(db)
+	require.NoError(t, err)
+
+	defer func(r, d time.Duration) {
+		Retention, Downsample = r, d
+	}(Retention, Downsample)
+
+	Retention = 10 * 24 * time.Hour
+	Downsample = 24 * time.Hour
+
+	now := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+	hour := now.Add(-48 * time.Hour)
+
+	// expired
+	s.Add(Flow{Created: now.Add(-Retention - time.Hour), Grid: 1})
+
+	// half an hour of 1kW pv
+	for ts := hour; ts.Before(hour.Add(30 * time.Minute)); ts = ts.Add(Interval) {
+		s.Add(Flow{Created: ts, PV: 1000, Loadpoints: []float64{2000}})
+	}
+
+	// recent
+	s.Add(Flow{Created: now, PV: 3000})
+
+	require.NoError(t, Maintain(db, now))
+
+	res, err := Flows(db, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+
+	assert.Equal(t, hour, res[0].Created.UTC())
+	assert.Equal(t, time.Hour, res[0].Resolution())
+	assert.InDelta(t, 500, res[0].PV, 1e-6)
+	assert.InDelta(t, 1000, res[0].Loadpoints[0], 1e-6)
+
+	assert.Equal(t, Interval, res[1].Resolution())
+	assert.Equal(t, 3000.0, res[1].PV)
+
+	// energy is preserved
+	b := Balances(res[:1], 0)
+	require.Len(t, b, 1)
+	require.Len(t, b[0].Links, 1)
+	assert.InDelta(t, 0.5, b[0].Links[0].Energy, 1e-6)
+}
+
+func TestDownsampleDirections(t *testing.T) {
+	db, err := serverdb.New("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	s, err := NewStore(db)
+	require.NoError(t, err)
+
+	defer func(d time.Duration) {
+		Downsample = d
+	}(Downsample)
+
+	Downsample = time.Hour
+
+	now := time.Date(2024, 6, 10, 12, 0, 0, 0, time.UTC)
+	hour := now.Add(-3 * time.Hour)
+
+	// import and discharge in the first half, export and charge in the second half of the hour
+	for ts := hour; ts.Before(hour.Add(time.Hour)); ts = ts.Add(Interval) {
+		sign := 1.0
+		if ts.Sub(hour) >= 30*time.Minute {
+			sign = -1
+		}
+		s.Add(Flow{Created: ts, Grid: sign * 2000, Battery: sign * 1000})
+	}
+
+	require.NoError(t, Maintain(db, now))
+
+	res, err := Flows(db, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+
+	f := res[0]
+	assert.InDelta(t, 1000, f.Import(), 1e-6)
+	assert.InDelta(t, 1000, f.Export(), 1e-6)
+	assert.InDelta(t, 500, f.Discharge(), 1e-6)
+	assert.InDelta(t, 500, f.Charge(), 1e-6)
+
+	// balances account for both directions
+	energy := make(map[[2]string]float64)
+	for _, l := range Balances(res, 0)[0].Links {
+		energy[[2]string{l.Source, l.Target}] = l.Energy
+	}
+	assert.InDelta(t, 0.5, energy[[2]string{Battery, Grid}], 1e-6)
+	assert.InDelta(t, 0.5, energy[[2]string{Grid, Battery}], 1e-6)
+}
@@ -12,15 +12,21 @@ const (
 	LimitEnergy      = "limitEnergy" // limit energy
 	EnableThreshold  = "enableThreshold"
 	DisableThreshold = "disableThreshold"
+	EnableDelay      = "enableDelay"
+	DisableDelay     = "disableDelay"
+	RampRate         = "rampRate"   // max charge current change per minute
+	PowerLimit       = "powerLimit" // site imposed charge power limit
 
 	PhasesConfigured = "phasesConfigured" // configured phases (1/3, 0 for auto on 1p3p chargers, nil for plain chargers)
 	PhasesEnabled    = "phasesEnabled"    // enabled phases (1/3)
 	PhasesActive     = "phasesActive"     // active phases as used by vehicle (1/2/3)
+	PhaseReason      = "phaseReason"      // automatic phase switching decision rationale
 
 	ChargerIcon           = "chargerIcon"           // charger icon for ui
 	ChargerFeature        = "chargerFeature"        // charger feature
 	ChargerPhysicalPhases = "chargerPhysicalPhases" // charger phases
 	ChargerPhases1p3p     = "chargerPhases1p3p"     // phase switcher (1p3p chargers)
+	ChargerSocketLock     = "chargerSocketLock"     // socket lock can be released
 
 	// loadpoint status
 	Enabled   = "enabled"   // loadpoint enabled
@@ -28,8 +34,10 @@ const (
 	Charging  = "charging"  // charging
 
 	// smart charging
-	SmartCostActive = "smartCostActive" // smart cost active
-	SmartCostLimit  = "smartCostLimit"  // smart cost limit
+	SmartCostActive     = "smartCostActive"     // smart cost active
+	SmartCostLimit      = "smartCostLimit"      // smart cost limit
+	SmartCostPercentile = "smartCostPercentile" // smart cost percentile of the coming day
+	StrictPV            = "strictPV"            // pv mode never charges from grid
 
 	// effective values
 	EffectivePriority   = "effectivePriority"   // effective priority
@@ -57,6 +65,10 @@ const (
 	PlanTime           = "planTime"           // charge plan finish time goal
 	PlanEnergy         = "planEnergy"         // charge plan energy goal
 	PlanSoc            = "planSoc"            // charge plan soc goal
+	PlanPrecondition   = "planPrecondition"   // battery preconditioning duration before plan time
+	ChargeCurve        = "chargeCurve"        // learned vehicle charge curve
+	TripLimitSoc       = "tripLimitSoc"       // vehicle limit soc for the next session only
+	ManualSoc          = "manualSoc"          // soc of vehicles without api
 	PlanActive         = "planActive"         // charge plan has determined current slot to be an active slot
 	PlanProjectedStart = "planProjectedStart" // charge plan start time (earliest slot)
 	PlanOverrun        = "planOverrun"        // charge plan goal not reachable in time
@@ -64,6 +76,17 @@ const (
 	// remote control
 	RemoteDisabled       = "remoteDisabled"       // remote disabled
 	RemoteDisabledSource = "remoteDisabledSource" // remote disabled source
+	RemotePower          = "remotePower"          // remote recommended charge power
+	ExternalPower        = "externalPower"        // external charge power setpoint
+	ExternalCurrent      = "externalCurrent"      // external charge current setpoint
+	ExternalExpired      = "externalExpired"      // external setpoint expired
+	GuestSession         = "guestSession"         // guest session caps
+	Boost                = "boost"                // one-shot full power charge
+	StandbySuppressed    = "standbySuppressed"    // charger disabled to suppress vehicle standby consumption
+	Lockout              = "lockout"              // charging locked out by schedule
+	ChargerFault         = "chargerFault"         // charger fault not recovered
+	ChargerTemperature   = "chargerTemperature"   // derating temperature
+	DeratingCurrent      = "deratingCurrent"      // temperature dependent max current
 
 	// vehicle
 	VehicleName            = "vehicleName"            // vehicle name
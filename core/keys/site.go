@@ -3,20 +3,37 @@ package keys
 const (
 	Aux                   = "aux"
 	AuxPower              = "auxPower"
+	Away                  = "away"
+	CircuitCapped         = "circuitCapped"
+	Circuits              = "circuits"
+	Consumers             = "consumers"
 	Currency              = "currency"
+	DatabaseError         = "databaseError"
+	DemandResponse        = "demandResponse"
+	DemandResponseLimit   = "demandResponseLimit"
+	ExportLimited         = "exportLimited"
+	Fleet                 = "fleet"
 	GreenShareHome        = "greenShareHome"
 	GreenShareLoadpoints  = "greenShareLoadpoints"
 	GridConfigured        = "gridConfigured"
 	GridCurrents          = "gridCurrents"
+	GridCurtailed         = "gridCurtailed"
 	GridEnergy            = "gridEnergy"
 	GridPower             = "gridPower"
 	GridPowers            = "gridPowers"
 	HomePower             = "homePower"
+	OffGrid               = "offGrid"
+	PeakDemand            = "peakDemand"
+	PeakDemandLimit       = "peakDemandLimit"
+	PeakDemands           = "peakDemands"
 	PrioritySoc           = "prioritySoc"
 	Pv                    = "pv"
 	PvConfigured          = "pvConfigured"
 	PvEnergy              = "pvEnergy"
+	PvCurtailment         = "pvCurtailment"
+	PvForecastTrend       = "pvForecastTrend"
 	PvPower               = "pvPower"
+	ReferencePrice        = "referencePrice"
 	ResidualPower         = "residualPower"
 	SiteTitle             = "siteTitle"
 	SmartCostType         = "smartCostType"
@@ -48,5 +65,6 @@ const (
 	BatteryEnergy     = "batteryEnergy"
 	BatteryMode       = "batteryMode"
 	BatteryPower      = "batteryPower"
+	BatteryPriority   = "batteryPriority"
 	BatterySoc        = "batterySoc"
 )
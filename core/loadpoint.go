@@ -1,6 +1,7 @@
 package core
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math"
@@ -12,7 +13,6 @@ import (
 
 	evbus "github.com/asaskevich/EventBus"
 	"github.com/benbjohnson/clock"
-	"github.com/cenkalti/backoff/v4"
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/coordinator"
 	"github.com/evcc-io/evcc/core/keys"
@@ -24,6 +24,7 @@ import (
 	"github.com/evcc-io/evcc/core/wrapper"
 	"github.com/evcc-io/evcc/provider"
 	"github.com/evcc-io/evcc/push"
+	"github.com/evcc-io/evcc/tariff/fixed"
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/config"
 	"github.com/evcc-io/evcc/util/telemetry"
@@ -38,6 +39,7 @@ const (
 	evVehicleDisconnect   = "disconnect" // vehicle disconnected
 	evVehicleSoc          = "soc"        // vehicle soc progress
 	evVehicleUnidentified = "guest"      // vehicle unidentified
+	evChargerFault        = "fault"      // charger fault not recovered
 
 	pvTimer   = "pv"
 	pvEnable  = "enable"
@@ -54,6 +56,7 @@ const (
 
 	chargerSwitchDuration = 60 * time.Second // allow out of sync during this timespan
 	phaseSwitchDuration   = 60 * time.Second // allow out of sync and do not measure phases during this timespan
+	remotePowerTimeout    = 5 * time.Minute  // remote power recommendation expires unless refreshed
 )
 
 // elapsed is the time an expired timer will be set to
@@ -86,6 +89,12 @@ type ThresholdConfig struct {
 	Threshold float64
 }
 
+// PhaseSwitchingConfig defines automatic 1p3p switching behavior
+type PhaseSwitchingConfig struct {
+	MinDwell  time.Duration `mapstructure:"minDwell"`  // minimum time between automatic phase switches
+	Lookahead time.Duration `mapstructure:"lookahead"` // solar forecast horizon for surplus trend
+}
+
 // Task is the task type
 type Task = func()
 
@@ -112,6 +121,15 @@ type Loadpoint struct {
 	MeterRef        string `mapstructure:"meter"`    // Charge meter reference
 	Soc             SocConfig
 	Enable, Disable ThresholdConfig
+	RampRate        float64              `mapstructure:"rampRate"`       // Max charge current change in A/min, 0 for unlimited
+	PhaseSwitching  PhaseSwitchingConfig `mapstructure:"phaseSwitching"` // Automatic 1p3p switching
+	UnlockAtLimit   bool                 `mapstructure:"unlockAtLimit"`  // Release socket lock when limit soc is reached
+	External        ExternalConfig       `mapstructure:"external"`       // External mode setpoint watchdog
+	Lockout         LockoutConfig        `mapstructure:"lockout"`        // Scheduled charging lockout
+	Supervision     SupervisionConfig    `mapstructure:"supervision"`    // Charger fault detection and recovery
+	Derating        DeratingConfig       `mapstructure:"derating"`       // Temperature dependent max current
+	Calibration     CalibrationConfig    `mapstructure:"calibration"`    // Charge power and energy correction
+	Standby         StandbyConfig        `mapstructure:"standby"`        // Disable charger after charging completed
 
 	// TODO deprecated
 	GuardDuration_    time.Duration `mapstructure:"guardduration"` // charger enable/disable minimum holding time
@@ -119,29 +137,41 @@ type Loadpoint struct {
 	MinCurrent_       float64       `mapstructure:"minCurrent"`
 	MaxCurrent_       float64       `mapstructure:"maxCurrent"`
 
-	minCurrent       float64 // PV mode: start current	Min+PV mode: min current
-	maxCurrent       float64 // Max allowed current. Physically ensured by the charger
-	configuredPhases int     // Charger configured phase mode 0/1/3
-	limitSoc         int     // Session limit for soc
-	limitEnergy      float64 // Session limit for energy
-	smartCostLimit   float64 // always charge if cost is below this value
+	minCurrent          float64 // PV mode: start current	Min+PV mode: min current
+	maxCurrent          float64 // Max allowed current. Physically ensured by the charger
+	configuredPhases    int     // Charger configured phase mode 0/1/3
+	limitSoc            int     // Session limit for soc
+	limitEnergy         float64 // Session limit for energy
+	smartCostLimit      float64 // always charge if cost is below this value
+	smartCostPercentile float64 // always charge if cost is within the cheapest percentile of the coming day
+	strictPV            bool    // pv mode pauses instead of charging from grid
+	powerLimit          float64 // site imposed charge power limit, 0 for unlimited
 
 	mode                api.ChargeMode
 	enabled             bool      // Charger enabled state
 	phases              int       // Charger enabled phases, guarded by mutex
 	measuredPhases      int       // Charger physically measured phases
 	chargeCurrent       float64   // Charger current limit
+	chargeCurrentSet    time.Time // Charger current limit updated timestamp
 	socUpdated          time.Time // Soc updated timestamp (poll: connected)
 	vehicleDetect       time.Time // Vehicle connected timestamp
 	chargerSwitched     time.Time // Charger enabled/disabled timestamp
 	phasesSwitched      time.Time // Phase switch timestamp
+	phaseSwitchPending  int       // Phases to switch to once charging has paused, 0 for none
 	vehicleDetectTicker *clock.Ticker
 	vehicleIdentifier   string
 
 	charger          api.Charger
 	chargeTimer      api.ChargeTimer
 	chargeRater      api.ChargeRater
-	chargedAtStartup float64 // session energy at startup
+	chargedAtStartup float64                // session energy at startup
+	chargePowerP     *poller[float64]       // charge meter power reading
+	health           *Health                // site health checker
+	vehicleAway      func(api.Vehicle) bool // vehicle known to be away from home
+	interval         time.Duration          // control loop interval
+
+	chargerStatusG  func() (api.ChargeStatus, error) // charger status, cancelled at the control loop interval
+	chargerEnabledG func() (bool, error)             // charger enabled state, cancelled at the control loop interval
 
 	chargeMeter    api.Meter   // Charger usage meter
 	vehicle        api.Vehicle // Currently active vehicle
@@ -150,33 +180,64 @@ type Loadpoint struct {
 	socEstimator   *soc.Estimator
 
 	// charge planning
-	planner     *planner.Planner
-	planTime    time.Time // time goal
-	planEnergy  float64   // Plan charge energy in kWh (dumb vehicles)
-	planSlotEnd time.Time // current plan slot end time
-	planActive  bool      // charge plan exists and has a currently active slot
+	planner       *planner.Planner
+	solarForecast api.Tariff // pv forecast for 1p3p switch decisions
+	planTime      time.Time  // time goal
+	planEnergy    float64    // Plan charge energy in kWh (dumb vehicles)
+	planSlotEnd   time.Time  // current plan slot end time
+	planActive    bool       // charge plan exists and has a currently active slot
 
 	// cached state
-	status         api.ChargeStatus       // Charger status
-	remoteDemand   loadpoint.RemoteDemand // External status demand
-	chargePower    float64                // Charging power
-	chargeCurrents []float64              // Phase currents
-	connectedTime  time.Time              // Time when vehicle was connected
-	pvTimer        time.Time              // PV enabled/disable timer
-	phaseTimer     time.Time              // 1p3p switch timer
-	wakeUpTimer    *Timer                 // Vehicle wake-up timeout
+	status          api.ChargeStatus        // Charger status
+	remoteDemand    loadpoint.RemoteDemand  // External status demand
+	remotePower     float64                 // External recommended charge power
+	remotePowerAt   time.Time               // Time of last remote power recommendation
+	externalPower   float64                 // External charge power setpoint
+	externalCurrent float64                 // External charge current setpoint
+	externalUpdated time.Time               // Time of last external setpoint
+	externalExpired bool                    // External setpoint expired, fallback mode active
+	guest           *loadpoint.GuestSession // Guest session for the current or next vehicle
+	boost           *loadpoint.Boost        // One-shot full power charge regardless of mode
+	boostEnergy     float64                 // Charged energy at boost start (Wh)
+	lockout         fixed.Zones             // Charging lockout windows
+	relayS          func(bool) error        // Smart relay powering the charger
+	temperatureG    func() (float64, error) // Derating temperature
+	standbySince    time.Time               // Vehicle idle at enabled charger since
+	standbyActive   bool                    // Charger disabled to suppress standby consumption
+	standbyTargets  standbyTargets          // Charging targets when the vehicle became idle
+	derating        int                     // Number of active derating rules
+	preconditioned  time.Time               // Plan time battery preconditioning was started for
+	stallStart      time.Time               // Start of charging stall
+	statusFlaps     []time.Time             // Recent charger status changes
+	recoveryAction  int                     // Next recovery action
+	recoveryActed   time.Time               // Time of last recovery action
+	recoveryRestore string                  // Recovery action waiting to re-enable charger or relay
+	chargerFault    bool                    // Charger fault persists after recovery actions
+	chargePower     float64                 // Charging power
+	chargeCurrents  []float64               // Phase currents
+	chargeVoltage   float64                 // Measured average phase voltage, 0 if unknown
+	connectedTime   time.Time               // Time when vehicle was connected
+	pvTimer         time.Time               // PV enabled/disable timer
+	phaseTimer      time.Time               // 1p3p switch timer
+	phaseReason     string                  // 1p3p switch decision rationale
+	socketUnlocked  bool                    // socket lock released during current session
+	wakeUpTimer     *Timer                  // Vehicle wake-up timeout
 
 	// charge progress
-	vehicleSoc              float64        // Vehicle Soc
-	chargeDuration          time.Duration  // Charge duration
-	sessionEnergy           *EnergyMetrics // Stats for charged energy by session
-	chargeRemainingDuration time.Duration  // Remaining charge duration
-	chargeRemainingEnergy   float64        // Remaining charge energy in Wh
-	progress                *Progress      // Step-wise progress indicator
+	vehicleSoc              float64         // Vehicle Soc
+	chargeDuration          time.Duration   // Charge duration
+	planPowerRatio          float64         // Observed to nominal charge power while charging for plan, 0 if unknown
+	planPowerStart          time.Time       // Start of charging at full power for plan
+	chargeCurveSamples      map[int]float64 // Max charge power by soc bucket for learning the charge curve
+	sessionEnergy           *EnergyMetrics  // Stats for charged energy by session
+	chargeRemainingDuration time.Duration   // Remaining charge duration
+	chargeRemainingEnergy   float64         // Remaining charge energy in Wh
+	progress                *Progress       // Step-wise progress indicator
 
 	// session log
-	db      *session.DB
-	session *session.Session
+	db          session.Backend
+	session     *session.Session
+	gridCharged bool // session charged outside strict pv mode
 
 	settings *Settings
 
@@ -255,6 +316,34 @@ func NewLoadpointFromConfig(log *util.Logger, settings *Settings, other map[stri
 		}
 	}
 
+	if lp.External.Fallback == api.ModeExternal || lp.External.Timeout <= 0 {
+		return nil, fmt.Errorf("invalid external mode fallback: %s after %v", lp.External.Fallback, lp.External.Timeout)
+	}
+
+	if err := lp.configureLockout(); err != nil {
+		return nil, err
+	}
+
+	if err := lp.configureSupervision(); err != nil {
+		return nil, err
+	}
+
+	if err := lp.configureDerating(); err != nil {
+		return nil, err
+	}
+
+	if err := lp.configureCalibration(); err != nil {
+		return nil, err
+	}
+
+	if err := lp.configureStandby(); err != nil {
+		return nil, err
+	}
+
+	if lp.RampRate < 0 {
+		return nil, fmt.Errorf("invalid ramp rate: %.3gA/min", lp.RampRate)
+	}
+
 	// validate thresholds
 	if lp.Enable.Threshold > lp.Disable.Threshold {
 		lp.log.WARN.Printf("PV mode enable threshold (%.0fW) is larger than disable threshold (%.0fW)", lp.Enable.Threshold, lp.Disable.Threshold)
@@ -290,12 +379,15 @@ func NewLoadpoint(log *util.Logger, settings *Settings) *Loadpoint {
 				Mode:     pollCharging,
 			},
 		},
-		Enable:        ThresholdConfig{Delay: time.Minute, Threshold: 0},     // t, W
-		Disable:       ThresholdConfig{Delay: 3 * time.Minute, Threshold: 0}, // t, W
-		sessionEnergy: NewEnergyMetrics(),
-		progress:      NewProgress(0, 10),     // soc progress indicator
-		coordinator:   coordinator.NewDummy(), // dummy vehicle coordinator
-		tasks:         util.NewQueue[Task](),  // task queue
+		Enable:         ThresholdConfig{Delay: time.Minute, Threshold: 0},     // t, W
+		Disable:        ThresholdConfig{Delay: 3 * time.Minute, Threshold: 0}, // t, W
+		PhaseSwitching: PhaseSwitchingConfig{Lookahead: time.Hour},
+		External:       ExternalConfig{Timeout: time.Minute, Fallback: api.ModePV},
+		Supervision:    SupervisionConfig{Window: 10 * time.Minute, Retry: 5 * time.Minute},
+		sessionEnergy:  NewEnergyMetrics(),
+		progress:       NewProgress(0, 10),     // soc progress indicator
+		coordinator:    coordinator.NewDummy(), // dummy vehicle coordinator
+		tasks:          util.NewQueue[Task](),  // task queue
 	}
 
 	return lp
@@ -328,6 +420,12 @@ func (lp *Loadpoint) restoreSettings() {
 	if v, err := lp.settings.Float(keys.SmartCostLimit); err == nil {
 		lp.SetSmartCostLimit(v)
 	}
+	if v, err := lp.settings.Float(keys.SmartCostPercentile); err == nil {
+		lp.SetSmartCostPercentile(v)
+	}
+	if v, err := lp.settings.Bool(keys.StrictPV); err == nil {
+		lp.SetStrictPV(v)
+	}
 	t, err1 := lp.settings.Time(keys.PlanTime)
 	v, err2 := lp.settings.Float(keys.PlanEnergy)
 	if err1 == nil && err2 == nil {
@@ -335,6 +433,11 @@ func (lp *Loadpoint) restoreSettings() {
 	}
 }
 
+// RequestUpdate implements loadpoint.API
+func (lp *Loadpoint) RequestUpdate() {
+	lp.requestUpdate()
+}
+
 // requestUpdate requests site to update this loadpoint
 func (lp *Loadpoint) requestUpdate() {
 	select {
@@ -365,10 +468,10 @@ func (lp *Loadpoint) configureChargerType(charger api.Charger) {
 	// measurement are obtained from separate charge meter if defined
 	// (https://github.com/evcc-io/evcc/issues/2469)
 	if rt, ok := charger.(api.ChargeRater); ok && integrated {
-		lp.chargeRater = rt
+		lp.chargeRater = &calibratedRater{rt, &lp.Calibration}
 
 		// when restarting in the middle of charging session, use this as negative offset
-		if f, err := rt.ChargedEnergy(); err == nil {
+		if f, err := lp.chargeRater.ChargedEnergy(); err == nil {
 			lp.chargedAtStartup = f
 		}
 	} else {
@@ -378,6 +481,11 @@ func (lp *Loadpoint) configureChargerType(charger api.Charger) {
 		_ = lp.bus.Subscribe(evChargeStart, func() { rt.StartCharge(true) })
 		_ = lp.bus.Subscribe(evChargeStop, rt.StopCharge)
 		lp.chargeRater = rt
+
+		// energy integrated from the calibrated charge power is already calibrated
+		if _, ok := lp.chargeMeter.(api.MeterEnergy); ok {
+			lp.chargeRater = &calibratedRater{rt, &lp.Calibration}
+		}
 	}
 
 	// ensure charge timer exists
@@ -440,6 +548,9 @@ func (lp *Loadpoint) evChargeStopHandler() {
 	provider.ResetCached()
 	lp.socUpdated = time.Time{}
 
+	// keep charged soc for vehicles without api
+	lp.updateManualSoc()
+
 	// reset pv enable/disable timer
 	// https://github.com/evcc-io/evcc/issues/2289
 	if !lp.pvTimer.Equal(elapsed) {
@@ -456,7 +567,7 @@ func (lp *Loadpoint) evVehicleConnectHandler() {
 	// energy
 	lp.sessionEnergy.Reset()
 	lp.sessionEnergy.Publish("session", lp)
-	lp.publish(keys.ChargedEnergy, lp.getChargedEnergy())
+	lp.publish(keys.ChargedEnergy, lp.GetChargedEnergy())
 
 	// duration
 	lp.connectedTime = lp.clock.Now()
@@ -478,6 +589,9 @@ func (lp *Loadpoint) evVehicleConnectHandler() {
 	// immediately allow pv mode activity
 	lp.elapsePVTimer()
 
+	// socket locks when plugged
+	lp.socketUnlocked = false
+
 	// create charging session
 	lp.createSession()
 }
@@ -486,15 +600,28 @@ func (lp *Loadpoint) evVehicleConnectHandler() {
 func (lp *Loadpoint) evVehicleDisconnectHandler() {
 	lp.log.INFO.Println("car disconnected")
 
+	// signed meter data of the transaction end may arrive after charging stopped
+	lp.updateSession(lp.updateSignedMeterValues)
+
 	// session is persisted during evChargeStopHandler which runs before
 	lp.clearSession()
 
 	// phases are unknown when vehicle disconnects
 	lp.resetMeasuredPhases()
 
+	// charge power depends on vehicle
+	lp.resetPlanPower()
+
+	// guest sessions are valid for a single session
+	lp.Lock()
+	lp.stopGuestSession()
+	lp.stopBoost()
+	lp.Unlock()
+	lp.learnChargeCurve()
+
 	// energy and duration
 	lp.sessionEnergy.Publish("session", lp)
-	lp.publish(keys.ChargedEnergy, lp.getChargedEnergy())
+	lp.publish(keys.ChargedEnergy, lp.GetChargedEnergy())
 	lp.publish(keys.ConnectedDuration, lp.clock.Since(lp.connectedTime).Round(time.Second))
 
 	// forget startup energy offset
@@ -507,6 +634,13 @@ func (lp *Loadpoint) evVehicleDisconnectHandler() {
 	// set default mode on disconnect
 	lp.defaultMode()
 
+	// trip limit is valid for a single session
+	if v := lp.GetVehicle(); v != nil {
+		if settings := vehicle.Settings(lp.log, v); settings.GetTripLimitSoc() > 0 {
+			settings.SetTripLimitSoc(0)
+		}
+	}
+
 	// set default vehicle (may be nil)
 	lp.setActiveVehicle(lp.defaultVehicle)
 
@@ -543,7 +677,7 @@ func (lp *Loadpoint) evChargeCurrentHandler(current float64) {
 // If physical charge meter is present this handler is not used.
 // The actual value is published by the evChargeCurrentHandler
 func (lp *Loadpoint) evChargeCurrentWrappedMeterHandler(current float64) {
-	power := current * float64(lp.ActivePhases()) * Voltage
+	power := current * float64(lp.ActivePhases()) * lp.voltage()
 
 	// if disabled we cannot be charging
 	if !lp.enabled || !lp.charging() {
@@ -591,6 +725,9 @@ func (lp *Loadpoint) Prepare(uiChan chan<- util.Param, pushChan chan<- push.Even
 
 	lp.publish(keys.EnableThreshold, lp.Enable.Threshold)
 	lp.publish(keys.DisableThreshold, lp.Disable.Threshold)
+	lp.publish(keys.EnableDelay, lp.Enable.Delay)
+	lp.publish(keys.DisableDelay, lp.Disable.Delay)
+	lp.publish(keys.RampRate, lp.RampRate)
 
 	lp.publish(keys.PhasesConfigured, lp.configuredPhases)
 	lp.publish(keys.ChargerPhases1p3p, lp.hasPhaseSwitching())
@@ -610,6 +747,10 @@ func (lp *Loadpoint) Prepare(uiChan chan<- util.Param, pushChan chan<- push.Even
 		lp.publishChargerFeature(f)
 	}
 
+	// charger socket lock
+	_, ok := lp.charger.(api.ChargerLock)
+	lp.publish(keys.ChargerSocketLock, ok)
+
 	// charger icon
 	if c, ok := lp.charger.(api.IconDescriber); ok {
 		lp.publish(keys.ChargerIcon, c.Icon())
@@ -651,9 +792,39 @@ func (lp *Loadpoint) Prepare(uiChan chan<- util.Param, pushChan chan<- push.Even
 	}
 }
 
+// setInterval sets the control loop interval and cancels charger and vehicle readings exceeding it
+func (lp *Loadpoint) setInterval(interval time.Duration) {
+	lp.vmu.Lock()
+	defer lp.vmu.Unlock()
+
+	lp.interval = interval
+	lp.chargerStatusG = util.WithTimeout(lp.charger.Status, interval)
+	lp.chargerEnabledG = util.WithTimeout(lp.charger.Enabled, interval)
+
+	if lp.socEstimator != nil {
+		lp.socEstimator.SetTimeout(interval)
+	}
+}
+
+// chargerStatus returns the charger status, cancelled at the control loop interval
+func (lp *Loadpoint) chargerStatus() (api.ChargeStatus, error) {
+	if lp.chargerStatusG != nil {
+		return lp.chargerStatusG()
+	}
+	return lp.charger.Status()
+}
+
+// chargerEnabled returns the charger enabled state, cancelled at the control loop interval
+func (lp *Loadpoint) chargerEnabled() (bool, error) {
+	if lp.chargerEnabledG != nil {
+		return lp.chargerEnabledG()
+	}
+	return lp.charger.Enabled()
+}
+
 // syncCharger updates charger status and synchronizes it with expectations
 func (lp *Loadpoint) syncCharger() error {
-	enabled, err := lp.charger.Enabled()
+	enabled, err := lp.chargerEnabled()
 	if err != nil {
 		return err
 	}
@@ -707,17 +878,39 @@ func (lp *Loadpoint) syncCharger() error {
 	return nil
 }
 
+// hasFineCurrentControl returns true if the charger accepts fractional currents
+func (lp *Loadpoint) hasFineCurrentControl() bool {
+	switch lp.charger.(type) {
+	case api.ChargerPowerController, api.ChargerEx:
+		return true
+	default:
+		return false
+	}
+}
+
 // setLimit applies charger current limits and enables/disables accordingly
 func (lp *Loadpoint) setLimit(chargeCurrent float64, force bool) error {
+	// site imposed power limit
+	if limit := lp.GetPowerLimit(); limit > 0 && chargeCurrent > 0 {
+		if maxCurrent := lp.powerToCurrent(limit, lp.ActivePhases()); chargeCurrent > maxCurrent {
+			lp.log.DEBUG.Printf("power limit: %.0fW (%.3gA)", limit, maxCurrent)
+			chargeCurrent = maxCurrent
+		}
+	}
+
 	// full amps only?
-	if _, ok := lp.charger.(api.ChargerEx); !ok || lp.vehicleHasFeature(api.CoarseCurrent) {
+	if !lp.hasFineCurrentControl() || lp.vehicleHasFeature(api.CoarseCurrent) {
 		chargeCurrent = math.Trunc(chargeCurrent)
 	}
 
 	// set current
 	if chargeCurrent != lp.chargeCurrent && chargeCurrent >= lp.effectiveMinCurrent() {
 		var err error
-		if charger, ok := lp.charger.(api.ChargerEx); ok {
+		if charger, ok := lp.charger.(api.ChargerPowerController); ok {
+			power := chargeCurrent * lp.voltage() * float64(lp.ActivePhases())
+			lp.log.DEBUG.Printf("max charge power: %.0fW", power)
+			err = charger.MaxPower(power)
+		} else if charger, ok := lp.charger.(api.ChargerEx); ok {
 			err = charger.MaxCurrentMillis(chargeCurrent)
 		} else {
 			err = lp.charger.MaxCurrent(int64(chargeCurrent))
@@ -725,7 +918,7 @@ func (lp *Loadpoint) setLimit(chargeCurrent float64, force bool) error {
 
 		if err != nil {
 			v := lp.GetVehicle()
-			if vv, ok := v.(api.Resurrector); ok && errors.Is(err, api.ErrAsleep) {
+			if vv, ok := v.(api.Resurrector); ok && errors.Is(err, api.ErrAsleep) && !lp.isVehicleAway(v) {
 				// https://github.com/evcc-io/evcc/issues/8254
 				// wakeup vehicle
 				lp.log.DEBUG.Printf("max charge current: waking up vehicle")
@@ -739,6 +932,7 @@ func (lp *Loadpoint) setLimit(chargeCurrent float64, force bool) error {
 
 		lp.log.DEBUG.Printf("max charge current: %.3gA", chargeCurrent)
 		lp.chargeCurrent = chargeCurrent
+		lp.chargeCurrentSet = lp.clock.Now()
 		lp.bus.Publish(evChargeCurrent, chargeCurrent)
 	}
 
@@ -746,7 +940,7 @@ func (lp *Loadpoint) setLimit(chargeCurrent float64, force bool) error {
 	if enabled := chargeCurrent >= lp.effectiveMinCurrent(); enabled != lp.enabled {
 		if err := lp.charger.Enable(enabled); err != nil {
 			v := lp.GetVehicle()
-			if vv, ok := v.(api.Resurrector); enabled && ok && errors.Is(err, api.ErrAsleep) {
+			if vv, ok := v.(api.Resurrector); enabled && ok && errors.Is(err, api.ErrAsleep) && !lp.isVehicleAway(v) {
 				// https://github.com/evcc-io/evcc/issues/8254
 				// wakeup vehicle
 				lp.log.DEBUG.Printf("charger %s: waking up vehicle", status[enabled])
@@ -808,7 +1002,7 @@ func (lp *Loadpoint) vehicleHasSoc() bool {
 // remainingLimitEnergy returns missing energy amount in kWh if vehicle has a valid energy target
 func (lp *Loadpoint) remainingLimitEnergy() (float64, bool) {
 	limit := lp.GetLimitEnergy()
-	return max(0, limit-lp.getChargedEnergy()/1e3),
+	return max(0, limit-lp.GetChargedEnergy()/1e3),
 		limit > 0 && !lp.socBasedPlanning()
 }
 
@@ -846,13 +1040,13 @@ func (lp *Loadpoint) minSocNotReached() bool {
 	}
 
 	minEnergy := v.Capacity() * float64(minSoc) / 100 / soc.ChargeEfficiency
-	return minEnergy > 0 && lp.getChargedEnergy() < minEnergy
+	return minEnergy > 0 && lp.GetChargedEnergy() < minEnergy
 }
 
-// disableUnlessClimater disables the charger unless climate is active
-func (lp *Loadpoint) disableUnlessClimater() error {
+// disableUnlessClimater disables the charger unless climate is active and may be powered from grid
+func (lp *Loadpoint) disableUnlessClimater(strictPV bool) error {
 	var current float64 // zero disables
-	if lp.vehicleClimateActive() {
+	if !strictPV && lp.vehicleClimateActive() {
 		current = lp.effectiveMinCurrent()
 	}
 
@@ -867,6 +1061,18 @@ func (lp *Loadpoint) remoteControlled(demand loadpoint.RemoteDemand) bool {
 	return lp.remoteDemand == demand
 }
 
+// remoteRecommendedPower returns the external recommended charge power unless expired
+func (lp *Loadpoint) remoteRecommendedPower() float64 {
+	lp.RLock()
+	defer lp.RUnlock()
+
+	if lp.remoteDemand != loadpoint.RemoteEnable || lp.clock.Since(lp.remotePowerAt) > remotePowerTimeout {
+		return 0
+	}
+
+	return lp.remotePower
+}
+
 // statusEvents converts the observed charger status change into a logical sequence of events
 func statusEvents(prevStatus, status api.ChargeStatus) []string {
 	res := make([]string, 0, 2)
@@ -896,7 +1102,9 @@ func statusEvents(prevStatus, status api.ChargeStatus) []string {
 
 // updateChargerStatus updates charger status and detects car connected/disconnected events
 func (lp *Loadpoint) updateChargerStatus() error {
-	status, err := lp.charger.Status()
+	start := time.Now()
+	status, err := lp.chargerStatus()
+	lp.health.Device(lp.ChargerRef, time.Since(start), err)
 	if err != nil {
 		return err
 	}
@@ -906,6 +1114,10 @@ func (lp *Loadpoint) updateChargerStatus() error {
 	if prevStatus := lp.GetStatus(); status != prevStatus {
 		lp.setStatus(status)
 
+		if prevStatus != api.StatusNone {
+			lp.recordStatusFlap()
+		}
+
 		for _, ev := range statusEvents(prevStatus, status) {
 			lp.bus.Publish(ev)
 
@@ -1009,6 +1221,16 @@ func (lp *Loadpoint) scalePhases(phases int) error {
 	if lp.GetPhases() != phases {
 		// switch phases
 		if err := cp.Phases1p3p(phases); err != nil {
+			// charger requires charging to pause, disable and retry with the next cycles
+			if errors.Is(err, api.ErrMustRetry) {
+				if lp.phaseSwitchPending != phases {
+					lp.log.DEBUG.Printf("switch phases: pausing charging for switching to %dp", phases)
+				}
+				lp.phaseSwitchPending = phases
+				return lp.setLimit(0, true)
+			}
+
+			lp.phaseSwitchPending = 0
 			return fmt.Errorf("switch phases: %w", err)
 		}
 
@@ -1021,13 +1243,15 @@ func (lp *Loadpoint) scalePhases(phases int) error {
 		lp.setPhases(phases)
 	}
 
+	lp.phaseSwitchPending = 0
+
 	return nil
 }
 
 // fastCharging scales to 3p if available and sets maximum current
 func (lp *Loadpoint) fastCharging() error {
 	err := lp.scalePhasesIfAvailable(3)
-	if err == nil {
+	if err == nil && lp.phaseSwitchPending == 0 {
 		err = lp.setLimit(lp.effectiveMaxCurrent(), true)
 	}
 	return err
@@ -1049,63 +1273,115 @@ func (lp *Loadpoint) pvScalePhases(sitePower, minCurrent, maxCurrent float64) bo
 		lp.resetMeasuredPhases()
 	}
 
-	var waiting bool
+	var (
+		waiting bool
+		reason  string
+	)
+
+	// expose decision rationale
+	defer func() {
+		lp.setPhaseReason(reason)
+	}()
+
 	activePhases := lp.ActivePhases()
 	availablePower := lp.chargePower - sitePower
 	scalable := (sitePower > 0 || !lp.enabled) && activePhases > 1 && lp.configuredPhases < 3
 
+	// switching costs only apply when interrupting an active charging session
+	charging := lp.charging()
+	dwelling := charging && lp.phaseSwitchDwelling()
+	penalty := lp.phaseSwitchPenalty()
+	trend := lp.solarTrend()
+
 	// scale down phases
-	if targetCurrent := powerToCurrent(availablePower, activePhases); targetCurrent < minCurrent && scalable {
-		lp.log.DEBUG.Printf("available power %.0fW < %.0fW min %dp threshold", availablePower, float64(activePhases)*Voltage*minCurrent, activePhases)
+	if targetCurrent := lp.powerToCurrent(availablePower, activePhases); targetCurrent < minCurrent && scalable {
+		lp.log.DEBUG.Printf("available power %.0fW < %.0fW min %dp threshold", availablePower, float64(activePhases)*lp.voltage()*minCurrent, activePhases)
 
-		if !lp.charging() { // scale immediately if not charging
-			lp.phaseTimer = elapsed
-		}
+		delay := lp.Disable.Delay
 
-		if lp.phaseTimer.IsZero() {
-			lp.log.DEBUG.Printf("start phase %s timer", phaseScale1p)
-			lp.phaseTimer = lp.clock.Now()
+		switch {
+		case dwelling:
+			lp.log.DEBUG.Printf("phase %s suppressed: minimum dwell time %v not reached", phaseScale1p, lp.PhaseSwitching.MinDwell)
+			reason = phaseReasonDwell
+
+		case charging && penalty > 0 && lp.powerToCurrent(availablePower+trend, activePhases) >= minCurrent:
+			// surplus is expected to recover, switching is only worth it if the deficit persists
+			lp.log.DEBUG.Printf("phase %s delayed by %v vehicle penalty: %.0fW forecast surplus", phaseScale1p, penalty, availablePower+trend)
+			reason = phaseReasonPenalty
+			delay += penalty
 		}
 
-		lp.publishTimer(phaseTimer, lp.Disable.Delay, phaseScale1p)
+		if reason != phaseReasonDwell {
+			if !charging { // scale immediately if not charging
+				lp.phaseTimer = elapsed
+			}
 
-		if elapsed := lp.clock.Since(lp.phaseTimer); elapsed >= lp.Disable.Delay {
-			if err := lp.scalePhases(1); err != nil {
-				lp.log.ERROR.Println(err)
+			if lp.phaseTimer.IsZero() {
+				lp.log.DEBUG.Printf("start phase %s timer", phaseScale1p)
+				lp.phaseTimer = lp.clock.Now()
+			}
+
+			lp.publishTimer(phaseTimer, delay, phaseScale1p)
+
+			if elapsed := lp.clock.Since(lp.phaseTimer); elapsed >= delay {
+				if err := lp.scalePhases(1); err != nil {
+					lp.log.ERROR.Println(err)
+				}
+				return true
 			}
-			return true
-		}
 
-		waiting = true
+			waiting = true
+		}
 	}
 
 	maxPhases := lp.maxActivePhases()
-	target1pCurrent := powerToCurrent(availablePower, 1)
+	target1pCurrent := lp.powerToCurrent(availablePower, 1)
 	scalable = maxPhases > 1 && phases < maxPhases && target1pCurrent > maxCurrent
 
 	// scale up phases
-	if targetCurrent := powerToCurrent(availablePower, maxPhases); targetCurrent >= minCurrent && scalable {
-		lp.log.DEBUG.Printf("available power %.0fW > %.0fW min %dp threshold", availablePower, 3*Voltage*minCurrent, maxPhases)
-
-		if !lp.charging() { // scale immediately if not charging
-			lp.phaseTimer = elapsed
+	if targetCurrent := lp.powerToCurrent(availablePower, maxPhases); targetCurrent >= minCurrent && scalable {
+		lp.log.DEBUG.Printf("available power %.0fW > %.0fW min %dp threshold", availablePower, 3*lp.voltage()*minCurrent, maxPhases)
+
+		delay := lp.Enable.Delay
+
+		switch {
+		case dwelling:
+			lp.log.DEBUG.Printf("phase %s suppressed: minimum dwell time %v not reached", phaseScale3p, lp.PhaseSwitching.MinDwell)
+			reason = phaseReasonDwell
+
+		case trend < 0 && lp.powerToCurrent(availablePower+trend, maxPhases) < minCurrent:
+			// surplus is expected to vanish before the switch pays off
+			lp.log.DEBUG.Printf("phase %s suppressed: %.0fW forecast surplus", phaseScale3p, availablePower+trend)
+			reason = phaseReasonForecast
+
+		case charging && penalty > 0 && trend <= 0:
+			// no rising surplus expected, require surplus to persist for the vehicle's interruption
+			lp.log.DEBUG.Printf("phase %s delayed by %v vehicle penalty", phaseScale3p, penalty)
+			reason = phaseReasonPenalty
+			delay += penalty
 		}
 
-		if lp.phaseTimer.IsZero() {
-			lp.log.DEBUG.Printf("start phase %s timer", phaseScale3p)
-			lp.phaseTimer = lp.clock.Now()
-		}
+		if reason != phaseReasonDwell && reason != phaseReasonForecast {
+			if !charging { // scale immediately if not charging
+				lp.phaseTimer = elapsed
+			}
 
-		lp.publishTimer(phaseTimer, lp.Enable.Delay, phaseScale3p)
+			if lp.phaseTimer.IsZero() {
+				lp.log.DEBUG.Printf("start phase %s timer", phaseScale3p)
+				lp.phaseTimer = lp.clock.Now()
+			}
+
+			lp.publishTimer(phaseTimer, delay, phaseScale3p)
 
-		if elapsed := lp.clock.Since(lp.phaseTimer); elapsed >= lp.Enable.Delay {
-			if err := lp.scalePhases(3); err != nil {
-				lp.log.ERROR.Println(err)
+			if elapsed := lp.clock.Since(lp.phaseTimer); elapsed >= delay {
+				if err := lp.scalePhases(3); err != nil {
+					lp.log.ERROR.Println(err)
+				}
+				return true
 			}
-			return true
-		}
 
-		waiting = true
+			waiting = true
+		}
 	}
 
 	// reset timer to disabled state
@@ -1139,7 +1415,7 @@ func (lp *Loadpoint) publishTimer(name string, delay time.Duration, action strin
 }
 
 // pvMaxCurrent calculates the maximum target current for PV mode
-func (lp *Loadpoint) pvMaxCurrent(mode api.ChargeMode, sitePower float64, batteryBuffered, batteryStart bool) float64 {
+func (lp *Loadpoint) pvMaxCurrent(mode api.ChargeMode, sitePower float64, batteryBuffered, batteryStart, strictPV bool) float64 {
 	// read only once to simplify testing
 	minCurrent := lp.effectiveMinCurrent()
 	maxCurrent := lp.effectiveMaxCurrent()
@@ -1147,12 +1423,17 @@ func (lp *Loadpoint) pvMaxCurrent(mode api.ChargeMode, sitePower float64, batter
 	// switch phases up/down
 	if lp.hasPhaseSwitching() {
 		_ = lp.pvScalePhases(sitePower, minCurrent, maxCurrent)
+
+		// keep charger disabled until charging paused for switching
+		if lp.phaseSwitchPending != 0 {
+			return 0
+		}
 	}
 
 	// calculate target charge current from delta power and actual current
 	effectiveCurrent := lp.effectiveCurrent()
 	activePhases := lp.ActivePhases()
-	deltaCurrent := powerToCurrent(-sitePower, activePhases)
+	deltaCurrent := lp.powerToCurrent(-sitePower, activePhases)
 	targetCurrent := max(effectiveCurrent+deltaCurrent, 0)
 
 	lp.log.DEBUG.Printf("pv charge current: %.3gA = %.3gA + %.3gA (%.0fW @ %dp)", targetCurrent, effectiveCurrent, deltaCurrent, sitePower, activePhases)
@@ -1162,12 +1443,19 @@ func (lp *Loadpoint) pvMaxCurrent(mode api.ChargeMode, sitePower float64, batter
 		return minCurrent
 	}
 
+	// strict pv mode pauses immediately instead of drawing min current from grid
+	if strictPV && lp.enabled && targetCurrent < minCurrent {
+		lp.log.DEBUG.Printf("strict pv: insufficient surplus for %.3gA min current", minCurrent)
+		lp.resetPVTimer()
+		return 0
+	}
+
 	if mode == api.ModePV && lp.enabled && targetCurrent < minCurrent {
 		projectedSitePower := sitePower
 		if !lp.phaseTimer.IsZero() {
 			// calculate site power after a phase switch from activePhases phases -> 1 phase
 			// notes: activePhases can be 1, 2 or 3 and phaseTimer can only be active if lp current is already at minCurrent
-			projectedSitePower -= Voltage * minCurrent * float64(activePhases-1)
+			projectedSitePower -= lp.voltage() * minCurrent * float64(activePhases-1)
 		}
 		// kick off disable sequence
 		if projectedSitePower >= lp.Disable.Threshold {
@@ -1202,7 +1490,7 @@ func (lp *Loadpoint) pvMaxCurrent(mode api.ChargeMode, sitePower float64, batter
 	if mode == api.ModePV && !lp.enabled {
 		// kick off enable sequence
 		if (lp.Enable.Threshold == 0 && targetCurrent >= minCurrent) ||
-			(lp.Enable.Threshold != 0 && sitePower <= lp.Enable.Threshold) {
+			(lp.Enable.Threshold != 0 && sitePower <= lp.Enable.Threshold && (!strictPV || targetCurrent >= minCurrent)) {
 			lp.log.DEBUG.Printf("site power %.0fW <= %.0fW enable threshold", sitePower, lp.Enable.Threshold)
 
 			if lp.pvTimer.IsZero() {
@@ -1240,39 +1528,69 @@ func (lp *Loadpoint) pvMaxCurrent(mode api.ChargeMode, sitePower float64, batter
 	return targetCurrent
 }
 
+// rampCurrent limits charge current changes of an enabled charger to the configured ramp rate
+func (lp *Loadpoint) rampCurrent(targetCurrent float64) float64 {
+	rate := lp.GetRampRate()
+	if rate == 0 || !lp.enabled || targetCurrent < lp.effectiveMinCurrent() || lp.chargeCurrentSet.IsZero() {
+		return targetCurrent
+	}
+
+	maxDelta := rate * lp.clock.Since(lp.chargeCurrentSet).Minutes()
+	if delta := targetCurrent - lp.chargeCurrent; math.Abs(delta) > maxDelta {
+		rampedCurrent := lp.chargeCurrent + math.Copysign(maxDelta, delta)
+		lp.log.DEBUG.Printf("ramp charge current: %.3gA (target %.3gA @ %.3gA/min)", rampedCurrent, targetCurrent, rate)
+		return rampedCurrent
+	}
+
+	return targetCurrent
+}
+
 // UpdateChargePower updates charge meter power
 func (lp *Loadpoint) UpdateChargePower() {
-	bo := backoff.NewExponentialBackOff()
-	bo.MaxElapsedTime = time.Second
+	if lp.chargePowerP == nil {
+		lp.chargePowerP = newPoller(func(ctx context.Context) (float64, error) {
+			start := time.Now()
+			res, err := currentPower(ctx, lp.chargeMeter)
+			lp.health.Device(lp.chargeMeterRef(), time.Since(start), err)
+			return res, err
+		}, lp.interval)
+	}
 
-	if err := backoff.Retry(func() error {
-		value, err := lp.chargeMeter.CurrentPower()
-		if err != nil {
-			return err
-		}
+	value, err := lp.chargePowerP.Get()
+	if err != nil {
+		lp.log.ERROR.Printf("charge meter: %v", err)
+		return
+	}
 
-		lp.Lock()
-		lp.chargePower = value // update value if no error
-		lp.Unlock()
+	value = lp.Calibration.power(value)
 
-		lp.log.DEBUG.Printf("charge power: %.0fW", value)
-		lp.publish(keys.ChargePower, value)
+	lp.Lock()
+	lp.chargePower = value // update value if no error
+	lp.Unlock()
 
-		// https://github.com/evcc-io/evcc/issues/2153
-		// https://github.com/evcc-io/evcc/issues/6986
-		if lp.chargePower < -20 {
-			lp.log.WARN.Printf("charge power must not be negative: %.0f", lp.chargePower)
-		}
+	lp.log.DEBUG.Printf("charge power: %.0fW", value)
+	lp.publish(keys.ChargePower, value)
 
-		return nil
-	}, bo); err != nil {
-		lp.log.ERROR.Printf("charge meter: %v", err)
+	// https://github.com/evcc-io/evcc/issues/2153
+	// https://github.com/evcc-io/evcc/issues/6986
+	if value < -20 {
+		lp.log.WARN.Printf("charge power must not be negative: %.0f", value)
+	}
+}
+
+// chargeMeterRef returns the charge meter reference, or the charger reference if the charger measures power itself
+func (lp *Loadpoint) chargeMeterRef() string {
+	if lp.MeterRef != "" {
+		return lp.MeterRef
 	}
+	return lp.ChargerRef
 }
 
 // updateChargeCurrents uses PhaseCurrents interface to count phases with current >=1A
 func (lp *Loadpoint) updateChargeCurrents() {
+	lp.Lock()
 	lp.chargeCurrents = nil
+	lp.Unlock()
 
 	phaseMeter, ok := lp.chargeMeter.(api.PhaseCurrents)
 	if !ok {
@@ -1285,13 +1603,17 @@ func (lp *Loadpoint) updateChargeCurrents() {
 		return
 	}
 
-	lp.chargeCurrents = []float64{i1, i2, i3}
-	lp.log.DEBUG.Printf("charge currents: %.3gA", lp.chargeCurrents)
-	lp.publish(keys.ChargeCurrents, lp.chargeCurrents)
+	chargeCurrents := []float64{i1, i2, i3}
+	lp.log.DEBUG.Printf("charge currents: %.3gA", chargeCurrents)
+	lp.publish(keys.ChargeCurrents, chargeCurrents)
+
+	lp.Lock()
+	lp.chargeCurrents = chargeCurrents
+	lp.Unlock()
 
 	if lp.charging() && lp.phaseSwitchCompleted() {
 		var phases int
-		for _, i := range lp.chargeCurrents {
+		for _, i := range chargeCurrents {
 			if i > minActiveCurrent {
 				phases++
 			}
@@ -1308,12 +1630,8 @@ func (lp *Loadpoint) updateChargeCurrents() {
 	}
 }
 
-// updateChargeVoltages uses PhaseVoltages interface to count phases with nominal grid voltage
+// updateChargeVoltages uses PhaseVoltages interface to measure the charge voltage and count phases with nominal grid voltage
 func (lp *Loadpoint) updateChargeVoltages() {
-	if lp.hasPhaseSwitching() {
-		return // we don't need the voltages
-	}
-
 	phaseMeter, ok := lp.chargeMeter.(api.PhaseVoltages)
 	if !ok {
 		return // don't guess
@@ -1322,6 +1640,11 @@ func (lp *Loadpoint) updateChargeVoltages() {
 	u1, u2, u3, err := phaseMeter.Voltages()
 	if err != nil {
 		lp.log.ERROR.Printf("charge meter: %v", err)
+
+		lp.Lock()
+		lp.chargeVoltage = 0
+		lp.Unlock()
+
 		return
 	}
 
@@ -1329,6 +1652,14 @@ func (lp *Loadpoint) updateChargeVoltages() {
 	lp.log.DEBUG.Printf("charge voltages: %.3gV", chargeVoltages)
 	lp.publish(keys.ChargeVoltages, chargeVoltages)
 
+	lp.Lock()
+	lp.chargeVoltage = averageVoltage(chargeVoltages)
+	lp.Unlock()
+
+	if lp.hasPhaseSwitching() {
+		return // phases are known
+	}
+
 	// Quine-McCluskey for (¬L1∧L2∧¬L3) ∨ (L1∧L2∧¬L3) ∨ (¬L1∧¬L2∧L3) ∨ (L1∧¬L2∧L3) ∨ (¬L1∧L2∧L3) -> ¬L1 ∧ L3 ∨ L2 ∧ ¬L3 ∨ ¬L2 ∧ L3
 	if !(u1 >= minActiveVoltage) && (u3 >= minActiveVoltage) || (u2 >= minActiveVoltage) && !(u3 >= minActiveVoltage) || !(u2 >= minActiveVoltage) && (u3 >= minActiveVoltage) {
 		lp.log.WARN.Printf("invalid phase wiring between charge meter and charger")
@@ -1373,7 +1704,7 @@ func (lp *Loadpoint) publishChargeProgress() {
 	lp.sessionEnergy.Publish("session", lp)
 
 	// TODO deprecated: use sessionEnergy instead
-	lp.publish(keys.ChargedEnergy, lp.getChargedEnergy())
+	lp.publish(keys.ChargedEnergy, lp.GetChargedEnergy())
 	lp.publish(keys.ChargeDuration, lp.chargeDuration)
 	if _, ok := lp.chargeMeter.(api.MeterEnergy); ok {
 		lp.publish(keys.ChargeTotalImport, lp.chargeMeterTotal())
@@ -1399,7 +1730,7 @@ func (lp *Loadpoint) publishSocAndRange() {
 	if err == nil || lp.chargerHasFeature(api.IntegratedDevice) || lp.vehicleSocPollAllowed() {
 		lp.socUpdated = lp.clock.Now()
 
-		f, err := lp.socEstimator.Soc(lp.getChargedEnergy())
+		f, err := lp.socEstimator.Soc(lp.GetChargedEnergy())
 		if err != nil {
 			if errors.Is(err, api.ErrMustRetry) {
 				lp.socUpdated = time.Time{}
@@ -1414,6 +1745,11 @@ func (lp *Loadpoint) publishSocAndRange() {
 		lp.log.DEBUG.Printf("vehicle soc: %.0f%%", lp.vehicleSoc)
 		lp.publish(keys.VehicleSoc, lp.vehicleSoc)
 
+		// first soc of the session for battery health estimation
+		if lp.session != nil && lp.session.SocStart == nil && lp.vehicleHasSoc() {
+			lp.session.SocStart = &f
+		}
+
 		// vehicle target soc
 		// TODO take vehicle api limits into account
 		targetSoc := 100
@@ -1506,6 +1842,7 @@ func (lp *Loadpoint) Update(sitePower float64, autoCharge, batteryBuffered, batt
 	// read and publish meters first- charge power has already been updated by the site
 	lp.updateChargeVoltages()
 	lp.updateChargeCurrents()
+	lp.updateDerating()
 
 	lp.sessionEnergy.SetEnvironment(greenShare, effPrice, effCo2)
 
@@ -1556,8 +1893,30 @@ func (lp *Loadpoint) Update(sitePower float64, autoCharge, batteryBuffered, batt
 	mode := lp.GetMode()
 	lp.publish(keys.Mode, mode)
 
+	// external setpoints are guarded by watchdog
+	mode = lp.effectiveExternalMode(mode)
+
+	// guest sessions ignore mode, vehicle and plan settings
+	guest := lp.guestSessionActive()
+
+	// boost charges regardless of mode and returns to the mode when completed
+	boost := lp.boostActive()
+
 	// update and publish plan without being short-circuited by modes etc.
+	lp.updatePlanPower()
+	lp.updateChargeCurve()
 	plannerActive := lp.plannerActive()
+	lp.updatePrecondition()
+
+	// min soc guarantee applies to all modes except off
+	minSocActive := lp.minSocNotReached()
+	var minSocCharging bool
+
+	// strict pv mode never charges from grid, neither for min soc, plan nor cheap tariffs
+	strictPV := mode == api.ModePV && !guest && lp.GetStrictPV()
+	if strictPV {
+		minSocActive, plannerActive, autoCharge = false, false, false
+	}
 
 	// execute loading strategy
 	switch {
@@ -1565,35 +1924,61 @@ func (lp *Loadpoint) Update(sitePower float64, autoCharge, batteryBuffered, batt
 		// always disable charger if not connected
 		// https://github.com/evcc-io/evcc/issues/105
 		err = lp.setLimit(0, false)
+		lp.phaseSwitchPending = 0
 
 	case lp.scalePhasesRequired():
 		err = lp.scalePhases(lp.configuredPhases)
 
+	case lp.phaseSwitchPending != 0:
+		err = lp.scalePhases(lp.phaseSwitchPending)
+
+	case lp.lockoutActive():
+		lp.log.DEBUG.Println("charging locked out")
+		err = lp.setLimit(0, true)
+
 	case lp.remoteControlled(loadpoint.RemoteHardDisable):
 		remoteDisabled = loadpoint.RemoteHardDisable
 		fallthrough
 
-	case mode == api.ModeOff:
+	case mode == api.ModeOff && !guest && !boost:
 		err = lp.setLimit(0, true)
 
+	case guest:
+		err = lp.guestCharging()
+
+	case boost:
+		err = lp.fastCharging()
+		lp.resetPhaseTimer()
+		lp.elapsePVTimer() // let PV mode disable immediately afterwards
+
 	// minimum or target charging
-	case lp.minSocNotReached() || plannerActive:
+	case minSocActive || plannerActive:
+		minSocCharging = minSocActive
 		err = lp.fastCharging()
 		lp.resetPhaseTimer()
 		lp.elapsePVTimer() // let PV mode disable immediately afterwards
 
 	case lp.limitEnergyReached():
-		lp.log.DEBUG.Printf("limitEnergy reached: %.0fkWh > %0.1fkWh", lp.getChargedEnergy()/1e3, lp.limitEnergy)
-		err = lp.disableUnlessClimater()
+		lp.log.DEBUG.Printf("limitEnergy reached: %.0fkWh > %0.1fkWh", lp.GetChargedEnergy()/1e3, lp.limitEnergy)
+		err = lp.disableUnlessClimater(strictPV)
 
 	case lp.limitSocReached():
 		lp.log.DEBUG.Printf("limitSoc reached: %.1f%% > %d%%", lp.vehicleSoc, lp.effectiveLimitSoc())
-		err = lp.disableUnlessClimater()
+		err = lp.disableUnlessClimater(strictPV)
+		if err == nil {
+			lp.unlockAtLimit()
+		}
+
+	case lp.standbySuppressed():
+		err = lp.disableUnlessClimater(strictPV)
 
 	// immediate charging- must be placed after limits are evaluated
 	case mode == api.ModeNow:
 		err = lp.fastCharging()
 
+	case mode == api.ModeExternal:
+		err = lp.externalCharging()
+
 	case mode == api.ModeMinPV || mode == api.ModePV:
 		// cheap tariff
 		if autoCharge && lp.EffectivePlanTime().IsZero() {
@@ -1603,10 +1988,18 @@ func (lp *Loadpoint) Update(sitePower float64, autoCharge, batteryBuffered, batt
 			break
 		}
 
-		targetCurrent := lp.pvMaxCurrent(mode, sitePower, batteryBuffered, batteryStart)
+		var targetCurrent float64
+		if power := lp.remoteRecommendedPower(); power > 0 {
+			// follow external energy manager instead of competing for the same surplus
+			targetCurrent = min(max(lp.powerToCurrent(power, lp.ActivePhases()), lp.effectiveMinCurrent()), lp.effectiveMaxCurrent())
+			lp.log.DEBUG.Printf("remote charge current: %.3gA (%.0fW)", targetCurrent, power)
+		} else {
+			targetCurrent = lp.pvMaxCurrent(mode, sitePower, batteryBuffered, batteryStart, strictPV)
+		}
+		targetCurrent = lp.rampCurrent(targetCurrent)
 
 		var required bool // false
-		if targetCurrent == 0 && lp.vehicleClimateActive() {
+		if targetCurrent == 0 && !strictPV && lp.vehicleClimateActive() {
 			targetCurrent = lp.effectiveMinCurrent()
 			required = true
 		}
@@ -1621,6 +2014,14 @@ func (lp *Loadpoint) Update(sitePower float64, autoCharge, batteryBuffered, batt
 		err = lp.setLimit(targetCurrent, required)
 	}
 
+	// account energy charged for reaching min soc separately
+	lp.sessionEnergy.SetMinSoc(minSocCharging)
+
+	// sessions charged exclusively in strict pv mode are solar only
+	if lp.charging() && !strictPV {
+		lp.gridCharged = true
+	}
+
 	// Wake-up checks
 	if lp.enabled && lp.status == api.StatusB &&
 		// TODO take vehicle api limits into account
@@ -1628,6 +2029,9 @@ func (lp *Loadpoint) Update(sitePower float64, autoCharge, batteryBuffered, batt
 		lp.wakeUpVehicle()
 	}
 
+	// detect charger faults and recover
+	lp.supervise(mode)
+
 	// effective disabled status
 	if remoteDisabled != loadpoint.RemoteEnable {
 		lp.publish(keys.RemoteDisabled, remoteDisabled)
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/planner"
 )
 
 //go:generate mockgen -package loadpoint -destination mock.go -mock_names API=MockAPI github.com/evcc-io/evcc/core/loadpoint API
@@ -21,6 +22,8 @@ type API interface {
 
 	// GetStatus returns the charging status
 	GetStatus() api.ChargeStatus
+	// RequestUpdate requests an immediate update, e.g. after the charger pushed a status change
+	RequestUpdate()
 
 	//
 	// settings
@@ -92,6 +95,8 @@ type API interface {
 	SocBasedPlanning() bool
 	// GetPlan creates a charging plan
 	GetPlan(targetTime time.Time, requiredDuration time.Duration) (api.Rates, error)
+	// GetPlanExplanation creates a charging plan and explains the planner's reasoning
+	GetPlanExplanation(targetTime time.Time, requiredDuration time.Duration) (planner.Explanation, error)
 
 	// GetEnableThreshold gets the loadpoint enable threshold
 	GetEnableThreshold() float64
@@ -101,9 +106,47 @@ type API interface {
 	GetDisableThreshold() float64
 	// SetDisableThreshold sets loadpoint disable threshold
 	SetDisableThreshold(threshold float64)
+	// GetEnableDelay gets the loadpoint enable delay
+	GetEnableDelay() time.Duration
+	// SetEnableDelay sets loadpoint enable delay
+	SetEnableDelay(delay time.Duration)
+	// GetDisableDelay gets the loadpoint disable delay
+	GetDisableDelay() time.Duration
+	// SetDisableDelay sets loadpoint disable delay
+	SetDisableDelay(delay time.Duration)
+	// GetRampRate gets the loadpoint max charge current change per minute
+	GetRampRate() float64
+	// SetRampRate sets the loadpoint max charge current change per minute
+	SetRampRate(rate float64) error
 
 	// RemoteControl sets remote status demand
 	RemoteControl(string, RemoteDemand)
+	// RemotePower sets the remote recommended charge power, 0 to use own surplus calculation
+	RemotePower(string, float64)
+	// GetGuestSession returns the active guest session or nil
+	GetGuestSession() *GuestSession
+	// StartGuestSession enables charging for the current or next session up to the energy (kWh) or cost cap
+	StartGuestSession(energy, cost float64) error
+	// StopGuestSession ends the guest session
+	StopGuestSession()
+	// GetBoost returns the active boost or nil
+	GetBoost() *Boost
+	// StartBoost charges the connected vehicle at full power for the energy (kWh) or duration (minutes), then returns to the current mode
+	StartBoost(energy float64, minutes int) error
+	// StopBoost ends the boost
+	StopBoost()
+	// GetExternalPower returns the external charge power setpoint
+	GetExternalPower() float64
+	// SetExternalPower sets the external charge power setpoint for external mode
+	SetExternalPower(float64)
+	// GetExternalCurrent returns the external charge current setpoint
+	GetExternalCurrent() float64
+	// SetExternalCurrent sets the external charge current setpoint for external mode
+	SetExternalCurrent(float64)
+	// LockSocket locks the charger's socket
+	LockSocket() error
+	// UnlockSocket releases the charger's socket lock
+	UnlockSocket() error
 
 	//
 	// smart grid charging
@@ -113,6 +156,14 @@ type API interface {
 	GetSmartCostLimit() float64
 	// SetSmartCostLimit sets the smart cost limit
 	SetSmartCostLimit(limit float64)
+	// GetSmartCostPercentile gets the share of cheapest rates of the coming day used for smart charging
+	GetSmartCostPercentile() float64
+	// SetSmartCostPercentile sets the share of cheapest rates of the coming day used for smart charging
+	SetSmartCostPercentile(percentile float64)
+	// GetStrictPV returns true if pv mode never charges from grid
+	GetStrictPV() bool
+	// SetStrictPV sets strict pv mode, pausing instead of charging from grid
+	SetStrictPV(bool)
 
 	//
 	// power and energy
@@ -122,8 +173,18 @@ type API interface {
 	HasChargeMeter() bool
 	// GetChargePower returns the current charging power
 	GetChargePower() float64
+	// GetVoltage returns the phase voltage used for converting power and current
+	GetVoltage() float64
+	// GetChargeCurrents returns the measured phase currents, nil if unknown
+	GetChargeCurrents() []float64
 	// GetChargePowerFlexibility returns the flexible amount of current charging power
 	GetChargePowerFlexibility() float64
+	// GetChargedEnergy returns the session charged energy in Wh
+	GetChargedEnergy() float64
+	// GetPowerLimit returns the site imposed charge power limit
+	GetPowerLimit() float64
+	// SetPowerLimit sets the site imposed charge power limit, 0 for unlimited
+	SetPowerLimit(power float64)
 
 	//
 	// charge progress
@@ -146,4 +207,8 @@ type API interface {
 	SetVehicle(vehicle api.Vehicle)
 	// StartVehicleDetection allows triggering vehicle detection for debugging purposes
 	StartVehicleDetection()
+	// GetVehicleSoc returns the vehicle soc
+	GetVehicleSoc() float64
+	// SetVehicleSoc sets the soc of a connected vehicle without api
+	SetVehicleSoc(soc float64) error
 }
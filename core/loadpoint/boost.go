@@ -0,0 +1,10 @@
+package loadpoint
+
+import "time"
+
+// Boost charges at full power regardless of mode until the energy or duration is reached
+type Boost struct {
+	Energy  float64   `json:"energy,omitempty"`  // energy in kWh, 0 for none
+	Minutes int       `json:"minutes,omitempty"` // duration in minutes, 0 for none
+	Started time.Time `json:"started"`
+}
@@ -0,0 +1,7 @@
+package loadpoint
+
+// GuestSession enables charging for a single session regardless of vehicle and plan settings
+type GuestSession struct {
+	Energy float64 `json:"energy,omitempty"` // energy cap in kWh, 0 for none
+	Cost   float64 `json:"cost,omitempty"`   // cost cap in tariff currency, 0 for none
+}
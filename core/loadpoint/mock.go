@@ -14,6 +14,7 @@ import (
 	time "time"
 
 	api "github.com/evcc-io/evcc/api"
+	planner "github.com/evcc-io/evcc/core/planner"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -110,6 +111,34 @@ func (mr *MockAPIMockRecorder) EffectivePriority() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EffectivePriority", reflect.TypeOf((*MockAPI)(nil).EffectivePriority))
 }
 
+// GetBoost mocks base method.
+func (m *MockAPI) GetBoost() *Boost {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoost")
+	ret0, _ := ret[0].(*Boost)
+	return ret0
+}
+
+// GetBoost indicates an expected call of GetBoost.
+func (mr *MockAPIMockRecorder) GetBoost() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoost", reflect.TypeOf((*MockAPI)(nil).GetBoost))
+}
+
+// GetChargeCurrents mocks base method.
+func (m *MockAPI) GetChargeCurrents() []float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChargeCurrents")
+	ret0, _ := ret[0].([]float64)
+	return ret0
+}
+
+// GetChargeCurrents indicates an expected call of GetChargeCurrents.
+func (mr *MockAPIMockRecorder) GetChargeCurrents() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChargeCurrents", reflect.TypeOf((*MockAPI)(nil).GetChargeCurrents))
+}
+
 // GetChargePower mocks base method.
 func (m *MockAPI) GetChargePower() float64 {
 	m.ctrl.T.Helper()
@@ -138,6 +167,34 @@ func (mr *MockAPIMockRecorder) GetChargePowerFlexibility() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChargePowerFlexibility", reflect.TypeOf((*MockAPI)(nil).GetChargePowerFlexibility))
 }
 
+// GetChargedEnergy mocks base method.
+func (m *MockAPI) GetChargedEnergy() float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChargedEnergy")
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// GetChargedEnergy indicates an expected call of GetChargedEnergy.
+func (mr *MockAPIMockRecorder) GetChargedEnergy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChargedEnergy", reflect.TypeOf((*MockAPI)(nil).GetChargedEnergy))
+}
+
+// GetDisableDelay mocks base method.
+func (m *MockAPI) GetDisableDelay() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDisableDelay")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetDisableDelay indicates an expected call of GetDisableDelay.
+func (mr *MockAPIMockRecorder) GetDisableDelay() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDisableDelay", reflect.TypeOf((*MockAPI)(nil).GetDisableDelay))
+}
+
 // GetDisableThreshold mocks base method.
 func (m *MockAPI) GetDisableThreshold() float64 {
 	m.ctrl.T.Helper()
@@ -152,6 +209,20 @@ func (mr *MockAPIMockRecorder) GetDisableThreshold() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDisableThreshold", reflect.TypeOf((*MockAPI)(nil).GetDisableThreshold))
 }
 
+// GetEnableDelay mocks base method.
+func (m *MockAPI) GetEnableDelay() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnableDelay")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetEnableDelay indicates an expected call of GetEnableDelay.
+func (mr *MockAPIMockRecorder) GetEnableDelay() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnableDelay", reflect.TypeOf((*MockAPI)(nil).GetEnableDelay))
+}
+
 // GetEnableThreshold mocks base method.
 func (m *MockAPI) GetEnableThreshold() float64 {
 	m.ctrl.T.Helper()
@@ -166,6 +237,48 @@ func (mr *MockAPIMockRecorder) GetEnableThreshold() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnableThreshold", reflect.TypeOf((*MockAPI)(nil).GetEnableThreshold))
 }
 
+// GetExternalCurrent mocks base method.
+func (m *MockAPI) GetExternalCurrent() float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExternalCurrent")
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// GetExternalCurrent indicates an expected call of GetExternalCurrent.
+func (mr *MockAPIMockRecorder) GetExternalCurrent() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExternalCurrent", reflect.TypeOf((*MockAPI)(nil).GetExternalCurrent))
+}
+
+// GetExternalPower mocks base method.
+func (m *MockAPI) GetExternalPower() float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExternalPower")
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// GetExternalPower indicates an expected call of GetExternalPower.
+func (mr *MockAPIMockRecorder) GetExternalPower() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExternalPower", reflect.TypeOf((*MockAPI)(nil).GetExternalPower))
+}
+
+// GetGuestSession mocks base method.
+func (m *MockAPI) GetGuestSession() *GuestSession {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGuestSession")
+	ret0, _ := ret[0].(*GuestSession)
+	return ret0
+}
+
+// GetGuestSession indicates an expected call of GetGuestSession.
+func (mr *MockAPIMockRecorder) GetGuestSession() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGuestSession", reflect.TypeOf((*MockAPI)(nil).GetGuestSession))
+}
+
 // GetLimitEnergy mocks base method.
 func (m *MockAPI) GetLimitEnergy() float64 {
 	m.ctrl.T.Helper()
@@ -280,6 +393,21 @@ func (mr *MockAPIMockRecorder) GetPlanEnergy() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlanEnergy", reflect.TypeOf((*MockAPI)(nil).GetPlanEnergy))
 }
 
+// GetPlanExplanation mocks base method.
+func (m *MockAPI) GetPlanExplanation(arg0 time.Time, arg1 time.Duration) (planner.Explanation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlanExplanation", arg0, arg1)
+	ret0, _ := ret[0].(planner.Explanation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlanExplanation indicates an expected call of GetPlanExplanation.
+func (mr *MockAPIMockRecorder) GetPlanExplanation(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlanExplanation", reflect.TypeOf((*MockAPI)(nil).GetPlanExplanation), arg0, arg1)
+}
+
 // GetPlanGoal mocks base method.
 func (m *MockAPI) GetPlanGoal() (float64, bool) {
 	m.ctrl.T.Helper()
@@ -309,6 +437,20 @@ func (mr *MockAPIMockRecorder) GetPlanRequiredDuration(arg0, arg1 any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlanRequiredDuration", reflect.TypeOf((*MockAPI)(nil).GetPlanRequiredDuration), arg0, arg1)
 }
 
+// GetPowerLimit mocks base method.
+func (m *MockAPI) GetPowerLimit() float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPowerLimit")
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// GetPowerLimit indicates an expected call of GetPowerLimit.
+func (mr *MockAPIMockRecorder) GetPowerLimit() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPowerLimit", reflect.TypeOf((*MockAPI)(nil).GetPowerLimit))
+}
+
 // GetPriority mocks base method.
 func (m *MockAPI) GetPriority() int {
 	m.ctrl.T.Helper()
@@ -323,6 +465,20 @@ func (mr *MockAPIMockRecorder) GetPriority() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPriority", reflect.TypeOf((*MockAPI)(nil).GetPriority))
 }
 
+// GetRampRate mocks base method.
+func (m *MockAPI) GetRampRate() float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRampRate")
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// GetRampRate indicates an expected call of GetRampRate.
+func (mr *MockAPIMockRecorder) GetRampRate() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRampRate", reflect.TypeOf((*MockAPI)(nil).GetRampRate))
+}
+
 // GetRemainingDuration mocks base method.
 func (m *MockAPI) GetRemainingDuration() time.Duration {
 	m.ctrl.T.Helper()
@@ -365,6 +521,20 @@ func (mr *MockAPIMockRecorder) GetSmartCostLimit() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSmartCostLimit", reflect.TypeOf((*MockAPI)(nil).GetSmartCostLimit))
 }
 
+// GetSmartCostPercentile mocks base method.
+func (m *MockAPI) GetSmartCostPercentile() float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSmartCostPercentile")
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// GetSmartCostPercentile indicates an expected call of GetSmartCostPercentile.
+func (mr *MockAPIMockRecorder) GetSmartCostPercentile() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSmartCostPercentile", reflect.TypeOf((*MockAPI)(nil).GetSmartCostPercentile))
+}
+
 // GetStatus mocks base method.
 func (m *MockAPI) GetStatus() api.ChargeStatus {
 	m.ctrl.T.Helper()
@@ -379,6 +549,20 @@ func (mr *MockAPIMockRecorder) GetStatus() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatus", reflect.TypeOf((*MockAPI)(nil).GetStatus))
 }
 
+// GetStrictPV mocks base method.
+func (m *MockAPI) GetStrictPV() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStrictPV")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// GetStrictPV indicates an expected call of GetStrictPV.
+func (mr *MockAPIMockRecorder) GetStrictPV() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStrictPV", reflect.TypeOf((*MockAPI)(nil).GetStrictPV))
+}
+
 // GetVehicle mocks base method.
 func (m *MockAPI) GetVehicle() api.Vehicle {
 	m.ctrl.T.Helper()
@@ -393,6 +577,34 @@ func (mr *MockAPIMockRecorder) GetVehicle() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVehicle", reflect.TypeOf((*MockAPI)(nil).GetVehicle))
 }
 
+// GetVehicleSoc mocks base method.
+func (m *MockAPI) GetVehicleSoc() float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVehicleSoc")
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// GetVehicleSoc indicates an expected call of GetVehicleSoc.
+func (mr *MockAPIMockRecorder) GetVehicleSoc() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVehicleSoc", reflect.TypeOf((*MockAPI)(nil).GetVehicleSoc))
+}
+
+// GetVoltage mocks base method.
+func (m *MockAPI) GetVoltage() float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVoltage")
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// GetVoltage indicates an expected call of GetVoltage.
+func (mr *MockAPIMockRecorder) GetVoltage() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVoltage", reflect.TypeOf((*MockAPI)(nil).GetVoltage))
+}
+
 // HasChargeMeter mocks base method.
 func (m *MockAPI) HasChargeMeter() bool {
 	m.ctrl.T.Helper()
@@ -421,6 +633,20 @@ func (mr *MockAPIMockRecorder) IsFastChargingActive() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsFastChargingActive", reflect.TypeOf((*MockAPI)(nil).IsFastChargingActive))
 }
 
+// LockSocket mocks base method.
+func (m *MockAPI) LockSocket() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockSocket")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockSocket indicates an expected call of LockSocket.
+func (mr *MockAPIMockRecorder) LockSocket() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockSocket", reflect.TypeOf((*MockAPI)(nil).LockSocket))
+}
+
 // PublishEffectiveValues mocks base method.
 func (m *MockAPI) PublishEffectiveValues() {
 	m.ctrl.T.Helper()
@@ -445,6 +671,42 @@ func (mr *MockAPIMockRecorder) RemoteControl(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoteControl", reflect.TypeOf((*MockAPI)(nil).RemoteControl), arg0, arg1)
 }
 
+// RemotePower mocks base method.
+func (m *MockAPI) RemotePower(arg0 string, arg1 float64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RemotePower", arg0, arg1)
+}
+
+// RemotePower indicates an expected call of RemotePower.
+func (mr *MockAPIMockRecorder) RemotePower(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemotePower", reflect.TypeOf((*MockAPI)(nil).RemotePower), arg0, arg1)
+}
+
+// RequestUpdate mocks base method.
+func (m *MockAPI) RequestUpdate() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RequestUpdate")
+}
+
+// RequestUpdate indicates an expected call of RequestUpdate.
+func (mr *MockAPIMockRecorder) RequestUpdate() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestUpdate", reflect.TypeOf((*MockAPI)(nil).RequestUpdate))
+}
+
+// SetDisableDelay mocks base method.
+func (m *MockAPI) SetDisableDelay(arg0 time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetDisableDelay", arg0)
+}
+
+// SetDisableDelay indicates an expected call of SetDisableDelay.
+func (mr *MockAPIMockRecorder) SetDisableDelay(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDisableDelay", reflect.TypeOf((*MockAPI)(nil).SetDisableDelay), arg0)
+}
+
 // SetDisableThreshold mocks base method.
 func (m *MockAPI) SetDisableThreshold(arg0 float64) {
 	m.ctrl.T.Helper()
@@ -457,6 +719,18 @@ func (mr *MockAPIMockRecorder) SetDisableThreshold(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDisableThreshold", reflect.TypeOf((*MockAPI)(nil).SetDisableThreshold), arg0)
 }
 
+// SetEnableDelay mocks base method.
+func (m *MockAPI) SetEnableDelay(arg0 time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetEnableDelay", arg0)
+}
+
+// SetEnableDelay indicates an expected call of SetEnableDelay.
+func (mr *MockAPIMockRecorder) SetEnableDelay(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEnableDelay", reflect.TypeOf((*MockAPI)(nil).SetEnableDelay), arg0)
+}
+
 // SetEnableThreshold mocks base method.
 func (m *MockAPI) SetEnableThreshold(arg0 float64) {
 	m.ctrl.T.Helper()
@@ -469,6 +743,30 @@ func (mr *MockAPIMockRecorder) SetEnableThreshold(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEnableThreshold", reflect.TypeOf((*MockAPI)(nil).SetEnableThreshold), arg0)
 }
 
+// SetExternalCurrent mocks base method.
+func (m *MockAPI) SetExternalCurrent(arg0 float64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetExternalCurrent", arg0)
+}
+
+// SetExternalCurrent indicates an expected call of SetExternalCurrent.
+func (mr *MockAPIMockRecorder) SetExternalCurrent(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetExternalCurrent", reflect.TypeOf((*MockAPI)(nil).SetExternalCurrent), arg0)
+}
+
+// SetExternalPower mocks base method.
+func (m *MockAPI) SetExternalPower(arg0 float64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetExternalPower", arg0)
+}
+
+// SetExternalPower indicates an expected call of SetExternalPower.
+func (mr *MockAPIMockRecorder) SetExternalPower(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetExternalPower", reflect.TypeOf((*MockAPI)(nil).SetExternalPower), arg0)
+}
+
 // SetLimitEnergy mocks base method.
 func (m *MockAPI) SetLimitEnergy(arg0 float64) {
 	m.ctrl.T.Helper()
@@ -561,6 +859,18 @@ func (mr *MockAPIMockRecorder) SetPlanEnergy(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPlanEnergy", reflect.TypeOf((*MockAPI)(nil).SetPlanEnergy), arg0, arg1)
 }
 
+// SetPowerLimit mocks base method.
+func (m *MockAPI) SetPowerLimit(arg0 float64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetPowerLimit", arg0)
+}
+
+// SetPowerLimit indicates an expected call of SetPowerLimit.
+func (mr *MockAPIMockRecorder) SetPowerLimit(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPowerLimit", reflect.TypeOf((*MockAPI)(nil).SetPowerLimit), arg0)
+}
+
 // SetPriority mocks base method.
 func (m *MockAPI) SetPriority(arg0 int) {
 	m.ctrl.T.Helper()
@@ -573,6 +883,20 @@ func (mr *MockAPIMockRecorder) SetPriority(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPriority", reflect.TypeOf((*MockAPI)(nil).SetPriority), arg0)
 }
 
+// SetRampRate mocks base method.
+func (m *MockAPI) SetRampRate(arg0 float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRampRate", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRampRate indicates an expected call of SetRampRate.
+func (mr *MockAPIMockRecorder) SetRampRate(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRampRate", reflect.TypeOf((*MockAPI)(nil).SetRampRate), arg0)
+}
+
 // SetSmartCostLimit mocks base method.
 func (m *MockAPI) SetSmartCostLimit(arg0 float64) {
 	m.ctrl.T.Helper()
@@ -585,6 +909,30 @@ func (mr *MockAPIMockRecorder) SetSmartCostLimit(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSmartCostLimit", reflect.TypeOf((*MockAPI)(nil).SetSmartCostLimit), arg0)
 }
 
+// SetSmartCostPercentile mocks base method.
+func (m *MockAPI) SetSmartCostPercentile(arg0 float64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSmartCostPercentile", arg0)
+}
+
+// SetSmartCostPercentile indicates an expected call of SetSmartCostPercentile.
+func (mr *MockAPIMockRecorder) SetSmartCostPercentile(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSmartCostPercentile", reflect.TypeOf((*MockAPI)(nil).SetSmartCostPercentile), arg0)
+}
+
+// SetStrictPV mocks base method.
+func (m *MockAPI) SetStrictPV(arg0 bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetStrictPV", arg0)
+}
+
+// SetStrictPV indicates an expected call of SetStrictPV.
+func (mr *MockAPIMockRecorder) SetStrictPV(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStrictPV", reflect.TypeOf((*MockAPI)(nil).SetStrictPV), arg0)
+}
+
 // SetVehicle mocks base method.
 func (m *MockAPI) SetVehicle(arg0 api.Vehicle) {
 	m.ctrl.T.Helper()
@@ -597,6 +945,20 @@ func (mr *MockAPIMockRecorder) SetVehicle(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVehicle", reflect.TypeOf((*MockAPI)(nil).SetVehicle), arg0)
 }
 
+// SetVehicleSoc mocks base method.
+func (m *MockAPI) SetVehicleSoc(arg0 float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetVehicleSoc", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetVehicleSoc indicates an expected call of SetVehicleSoc.
+func (mr *MockAPIMockRecorder) SetVehicleSoc(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVehicleSoc", reflect.TypeOf((*MockAPI)(nil).SetVehicleSoc), arg0)
+}
+
 // SocBasedPlanning mocks base method.
 func (m *MockAPI) SocBasedPlanning() bool {
 	m.ctrl.T.Helper()
@@ -611,6 +973,34 @@ func (mr *MockAPIMockRecorder) SocBasedPlanning() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SocBasedPlanning", reflect.TypeOf((*MockAPI)(nil).SocBasedPlanning))
 }
 
+// StartBoost mocks base method.
+func (m *MockAPI) StartBoost(arg0 float64, arg1 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartBoost", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartBoost indicates an expected call of StartBoost.
+func (mr *MockAPIMockRecorder) StartBoost(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartBoost", reflect.TypeOf((*MockAPI)(nil).StartBoost), arg0, arg1)
+}
+
+// StartGuestSession mocks base method.
+func (m *MockAPI) StartGuestSession(arg0, arg1 float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartGuestSession", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartGuestSession indicates an expected call of StartGuestSession.
+func (mr *MockAPIMockRecorder) StartGuestSession(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartGuestSession", reflect.TypeOf((*MockAPI)(nil).StartGuestSession), arg0, arg1)
+}
+
 // StartVehicleDetection mocks base method.
 func (m *MockAPI) StartVehicleDetection() {
 	m.ctrl.T.Helper()
@@ -623,6 +1013,30 @@ func (mr *MockAPIMockRecorder) StartVehicleDetection() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartVehicleDetection", reflect.TypeOf((*MockAPI)(nil).StartVehicleDetection))
 }
 
+// StopBoost mocks base method.
+func (m *MockAPI) StopBoost() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "StopBoost")
+}
+
+// StopBoost indicates an expected call of StopBoost.
+func (mr *MockAPIMockRecorder) StopBoost() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopBoost", reflect.TypeOf((*MockAPI)(nil).StopBoost))
+}
+
+// StopGuestSession mocks base method.
+func (m *MockAPI) StopGuestSession() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "StopGuestSession")
+}
+
+// StopGuestSession indicates an expected call of StopGuestSession.
+func (mr *MockAPIMockRecorder) StopGuestSession() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopGuestSession", reflect.TypeOf((*MockAPI)(nil).StopGuestSession))
+}
+
 // Title mocks base method.
 func (m *MockAPI) Title() string {
 	m.ctrl.T.Helper()
@@ -636,3 +1050,17 @@ func (mr *MockAPIMockRecorder) Title() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Title", reflect.TypeOf((*MockAPI)(nil).Title))
 }
+
+// UnlockSocket mocks base method.
+func (m *MockAPI) UnlockSocket() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockSocket")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlockSocket indicates an expected call of UnlockSocket.
+func (mr *MockAPIMockRecorder) UnlockSocket() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockSocket", reflect.TypeOf((*MockAPI)(nil).UnlockSocket))
+}
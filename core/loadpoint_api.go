@@ -3,6 +3,7 @@ package core
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/evcc-io/evcc/api"
@@ -71,8 +72,8 @@ func (lp *Loadpoint) SetMode(mode api.ChargeMode) {
 	}
 }
 
-// getChargedEnergy returns session charge energy in Wh
-func (lp *Loadpoint) getChargedEnergy() float64 {
+// GetChargedEnergy returns session charge energy in Wh
+func (lp *Loadpoint) GetChargedEnergy() float64 {
 	lp.RLock()
 	defer lp.RUnlock()
 	return lp.sessionEnergy.TotalWh()
@@ -276,6 +277,72 @@ func (lp *Loadpoint) SetDisableThreshold(threshold float64) {
 	}
 }
 
+// GetEnableDelay gets the loadpoint enable delay
+func (lp *Loadpoint) GetEnableDelay() time.Duration {
+	lp.RLock()
+	defer lp.RUnlock()
+	return lp.Enable.Delay
+}
+
+// SetEnableDelay sets loadpoint enable delay
+func (lp *Loadpoint) SetEnableDelay(delay time.Duration) {
+	lp.Lock()
+	defer lp.Unlock()
+
+	lp.log.DEBUG.Println("set enable delay:", delay)
+
+	if lp.Enable.Delay != delay {
+		lp.Enable.Delay = delay
+		lp.publish(keys.EnableDelay, delay)
+	}
+}
+
+// GetDisableDelay gets the loadpoint disable delay
+func (lp *Loadpoint) GetDisableDelay() time.Duration {
+	lp.RLock()
+	defer lp.RUnlock()
+	return lp.Disable.Delay
+}
+
+// SetDisableDelay sets loadpoint disable delay
+func (lp *Loadpoint) SetDisableDelay(delay time.Duration) {
+	lp.Lock()
+	defer lp.Unlock()
+
+	lp.log.DEBUG.Println("set disable delay:", delay)
+
+	if lp.Disable.Delay != delay {
+		lp.Disable.Delay = delay
+		lp.publish(keys.DisableDelay, delay)
+	}
+}
+
+// GetRampRate gets the loadpoint max charge current change per minute
+func (lp *Loadpoint) GetRampRate() float64 {
+	lp.RLock()
+	defer lp.RUnlock()
+	return lp.RampRate
+}
+
+// SetRampRate sets the loadpoint max charge current change per minute
+func (lp *Loadpoint) SetRampRate(rate float64) error {
+	lp.Lock()
+	defer lp.Unlock()
+
+	if rate < 0 {
+		return errors.New("ramp rate must not be negative")
+	}
+
+	lp.log.DEBUG.Println("set ramp rate:", rate)
+
+	if lp.RampRate != rate {
+		lp.RampRate = rate
+		lp.publish(keys.RampRate, rate)
+	}
+
+	return nil
+}
+
 // RemoteControl sets remote status demand
 func (lp *Loadpoint) RemoteControl(source string, demand loadpoint.RemoteDemand) {
 	lp.Lock()
@@ -294,6 +361,86 @@ func (lp *Loadpoint) RemoteControl(source string, demand loadpoint.RemoteDemand)
 	}
 }
 
+// RemotePower sets the remote recommended charge power, 0 to use own surplus calculation
+func (lp *Loadpoint) RemotePower(source string, power float64) {
+	lp.Lock()
+	defer lp.Unlock()
+
+	lp.remotePowerAt = lp.clock.Now()
+
+	if lp.remotePower != power {
+		lp.log.DEBUG.Printf("remote power (%s): %.0fW", source, power)
+		lp.remotePower = power
+
+		lp.publish(keys.RemotePower, power)
+
+		lp.requestUpdate()
+	}
+}
+
+// GetPowerLimit returns the site imposed charge power limit
+func (lp *Loadpoint) GetPowerLimit() float64 {
+	lp.RLock()
+	defer lp.RUnlock()
+	return lp.powerLimit
+}
+
+// SetPowerLimit sets the site imposed charge power limit, 0 for unlimited
+func (lp *Loadpoint) SetPowerLimit(power float64) {
+	lp.Lock()
+	defer lp.Unlock()
+
+	if lp.powerLimit != power {
+		lp.log.DEBUG.Println("set power limit:", power)
+		lp.powerLimit = power
+		lp.publish(keys.PowerLimit, power)
+	}
+}
+
+// LockSocket locks the charger's socket
+func (lp *Loadpoint) LockSocket() error {
+	c, ok := lp.charger.(api.ChargerLock)
+	if !ok {
+		return api.ErrNotAvailable
+	}
+
+	lp.log.DEBUG.Println("lock socket")
+
+	if err := c.Lock(); err != nil {
+		return fmt.Errorf("lock socket: %w", err)
+	}
+
+	lp.Lock()
+	lp.socketUnlocked = false
+	lp.Unlock()
+
+	return nil
+}
+
+// UnlockSocket releases the charger's socket lock
+func (lp *Loadpoint) UnlockSocket() error {
+	c, ok := lp.charger.(api.ChargerLock)
+	if !ok {
+		return api.ErrNotAvailable
+	}
+
+	if lp.GetStatus() == api.StatusC {
+		return errors.New("cannot unlock while charging")
+	}
+
+	lp.log.DEBUG.Println("unlock socket")
+
+	if err := c.Unlock(); err != nil {
+		return fmt.Errorf("unlock socket: %w", err)
+	}
+
+	lp.Lock()
+	lp.socketUnlocked = true
+	lp.Unlock()
+
+	return nil
+}
+
 // HasChargeMeter determines if a physical charge meter is attached
 func (lp *Loadpoint) HasChargeMeter() bool {
 	_, isWrapped := lp.chargeMeter.(*wrapper.ChargeMeter)
@@ -307,11 +454,23 @@ func (lp *Loadpoint) GetChargePower() float64 {
 	return lp.chargePower
 }
 
+// GetVoltage returns the phase voltage used for converting power and current
+func (lp *Loadpoint) GetVoltage() float64 {
+	return lp.voltage()
+}
+
+// GetChargeCurrents returns the measured phase currents, nil if unknown
+func (lp *Loadpoint) GetChargeCurrents() []float64 {
+	lp.RLock()
+	defer lp.RUnlock()
+	return slices.Clone(lp.chargeCurrents)
+}
+
 // GetChargePowerFlexibility returns the flexible amount of current charging power
 func (lp *Loadpoint) GetChargePowerFlexibility() float64 {
 	// no locking
 	mode := lp.GetMode()
-	if mode == api.ModeNow || !lp.charging() || lp.minSocNotReached() {
+	if mode == api.ModeNow || mode == api.ModeExternal || !lp.charging() || lp.minSocNotReached() {
 		return 0
 	}
 
@@ -387,12 +546,12 @@ func (lp *Loadpoint) SetMaxCurrent(current float64) error {
 
 // GetMinPower returns the min loadpoint power for a single phase
 func (lp *Loadpoint) GetMinPower() float64 {
-	return Voltage * lp.effectiveMinCurrent()
+	return lp.voltage() * lp.effectiveMinCurrent()
 }
 
 // GetMaxPower returns the max loadpoint power taking vehicle capabilities and phase scaling into account
 func (lp *Loadpoint) GetMaxPower() float64 {
-	return Voltage * lp.effectiveMaxCurrent() * float64(lp.maxActivePhases())
+	return lp.voltage() * lp.effectiveMaxCurrent() * float64(lp.maxActivePhases())
 }
 
 // IsFastChargingActive indicates if fast charging with maximum power is active
@@ -498,3 +657,45 @@ func (lp *Loadpoint) SetSmartCostLimit(val float64) {
 		lp.publish(keys.SmartCostLimit, lp.smartCostLimit)
 	}
 }
+
+// GetStrictPV returns true if pv mode never charges from grid
+func (lp *Loadpoint) GetStrictPV() bool {
+	lp.RLock()
+	defer lp.RUnlock()
+	return lp.strictPV
+}
+
+// SetStrictPV sets strict pv mode
+func (lp *Loadpoint) SetStrictPV(val bool) {
+	lp.Lock()
+	defer lp.Unlock()
+
+	lp.log.DEBUG.Println("set strict pv:", val)
+
+	if lp.strictPV != val {
+		lp.strictPV = val
+		lp.settings.SetBool(keys.StrictPV, lp.strictPV)
+		lp.publish(keys.StrictPV, lp.strictPV)
+	}
+}
+
+// GetSmartCostPercentile gets the smart cost percentile
+func (lp *Loadpoint) GetSmartCostPercentile() float64 {
+	lp.RLock()
+	defer lp.RUnlock()
+	return lp.smartCostPercentile
+}
+
+// SetSmartCostPercentile sets the smart cost percentile
+func (lp *Loadpoint) SetSmartCostPercentile(val float64) {
+	lp.Lock()
+	defer lp.Unlock()
+
+	lp.log.DEBUG.Println("set smart cost percentile:", val)
+
+	if lp.smartCostPercentile != val {
+		lp.smartCostPercentile = val
+		lp.settings.SetFloat(keys.SmartCostPercentile, lp.smartCostPercentile)
+		lp.publish(keys.SmartCostPercentile, lp.smartCostPercentile)
+	}
+}
@@ -0,0 +1,92 @@
+package core
+
+import (
+	"errors"
+	"time"
+
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/loadpoint"
+)
+
+// GetBoost returns the active boost or nil
+func (lp *Loadpoint) GetBoost() *loadpoint.Boost {
+	lp.RLock()
+	defer lp.RUnlock()
+
+	if lp.boost == nil {
+		return nil
+	}
+
+	res := *lp.boost
+	return &res
+}
+
+// StartBoost charges the connected vehicle at full power for the energy (kWh) or duration (minutes), then returns to the current mode
+func (lp *Loadpoint) StartBoost(energy float64, minutes int) error {
+	if energy <= 0 && minutes <= 0 {
+		return errors.New("boost requires energy or duration")
+	}
+
+	if !lp.connected() {
+		return errors.New("vehicle not connected")
+	}
+
+	chargedEnergy := lp.GetChargedEnergy()
+
+	lp.Lock()
+	defer lp.Unlock()
+
+	lp.log.DEBUG.Printf("start boost: %.3gkWh, %dmin", energy, minutes)
+
+	lp.boost = &loadpoint.Boost{Energy: max(energy, 0), Minutes: max(minutes, 0), Started: lp.clock.Now()}
+	lp.boostEnergy = chargedEnergy
+	lp.publish(keys.Boost, *lp.boost)
+
+	lp.requestUpdate()
+
+	return nil
+}
+
+// StopBoost ends the boost
+func (lp *Loadpoint) StopBoost() {
+	lp.Lock()
+	defer lp.Unlock()
+
+	lp.stopBoost()
+	lp.requestUpdate()
+}
+
+// stopBoost ends the boost (no mutex)
+func (lp *Loadpoint) stopBoost() {
+	if lp.boost != nil {
+		lp.log.DEBUG.Println("stop boost")
+	}
+
+	lp.boost = nil
+	lp.publish(keys.Boost, nil)
+}
+
+// boostActive returns true if a boost is active. The boost is ended once its energy or duration is reached.
+func (lp *Loadpoint) boostActive() bool {
+	boost := lp.GetBoost()
+	if boost == nil {
+		return false
+	}
+
+	lp.RLock()
+	charged := lp.sessionEnergy.TotalWh() - lp.boostEnergy
+	lp.RUnlock()
+
+	reached := boost.Energy > 0 && charged/1e3 >= boost.Energy ||
+		boost.Minutes > 0 && lp.clock.Since(boost.Started) >= time.Duration(boost.Minutes)*time.Minute
+
+	if reached {
+		lp.log.DEBUG.Printf("boost completed: %.3gkWh", charged/1e3)
+
+		lp.Lock()
+		lp.stopBoost()
+		lp.Unlock()
+	}
+
+	return !reached
+}
@@ -0,0 +1,55 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	evbus "github.com/asaskevich/EventBus"
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoost(t *testing.T) {
+	clock := clock.NewMock()
+
+	lp := &Loadpoint{
+		log:           util.NewLogger("foo"),
+		clock:         clock,
+		bus:           evbus.New(),
+		sessionEnergy: NewEnergyMetrics(),
+	}
+
+	require.Error(t, lp.StartBoost(0, 0))
+
+	// requires connected vehicle
+	require.Error(t, lp.StartBoost(5, 0))
+	lp.status = api.StatusB
+
+	lp.sessionEnergy.Update(2)
+	require.NoError(t, lp.StartBoost(5, 0))
+	assert.Equal(t, &loadpoint.Boost{Energy: 5, Started: clock.Now()}, lp.GetBoost())
+	assert.True(t, lp.boostActive())
+
+	// energy counts from boost start
+	lp.sessionEnergy.Update(6)
+	assert.True(t, lp.boostActive())
+
+	lp.sessionEnergy.Update(7)
+	assert.False(t, lp.boostActive())
+	assert.Nil(t, lp.GetBoost())
+
+	// duration
+	require.NoError(t, lp.StartBoost(0, 30))
+	clock.Add(29 * time.Minute)
+	assert.True(t, lp.boostActive())
+	clock.Add(time.Minute)
+	assert.False(t, lp.boostActive())
+
+	require.NoError(t, lp.StartBoost(10, 0))
+	lp.StopBoost()
+	assert.Nil(t, lp.GetBoost())
+}
@@ -0,0 +1,61 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+// CalibrationConfig corrects charger measurements deviating systematically from a reference meter
+type CalibrationConfig struct {
+	PowerFactor  float64 `mapstructure:"powerFactor"`  // charge power correction factor, 0 for none
+	PowerOffset  float64 `mapstructure:"powerOffset"`  // charge power offset while consuming (W)
+	EnergyFactor float64 `mapstructure:"energyFactor"` // charged energy correction factor, 0 for none
+}
+
+// configureCalibration validates the calibration
+func (lp *Loadpoint) configureCalibration() error {
+	conf := lp.Calibration
+
+	if conf.PowerFactor < 0 {
+		return fmt.Errorf("calibration: invalid power factor: %.3g", conf.PowerFactor)
+	}
+
+	if conf.EnergyFactor < 0 {
+		return fmt.Errorf("calibration: invalid energy factor: %.3g", conf.EnergyFactor)
+	}
+
+	return nil
+}
+
+// power returns the calibrated charge power. Idle power is not corrected.
+func (c CalibrationConfig) power(p float64) float64 {
+	if p <= 0 || c.PowerFactor == 0 && c.PowerOffset == 0 {
+		return p
+	}
+
+	if c.PowerFactor > 0 {
+		p *= c.PowerFactor
+	}
+
+	return max(p+c.PowerOffset, 0)
+}
+
+// energy returns the calibrated energy
+func (c CalibrationConfig) energy(e float64) float64 {
+	if c.EnergyFactor > 0 {
+		return e * c.EnergyFactor
+	}
+	return e
+}
+
+// calibratedRater applies the energy calibration to the charged energy
+type calibratedRater struct {
+	api.ChargeRater
+	calibration *CalibrationConfig
+}
+
+func (r *calibratedRater) ChargedEnergy() (float64, error) {
+	f, err := r.ChargeRater.ChargedEnergy()
+	return r.calibration.energy(f), err
+}
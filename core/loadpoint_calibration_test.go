@@ -0,0 +1,45 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCalibrationPower(t *testing.T) {
+	tc := []struct {
+		cal       CalibrationConfig
+		in, power float64
+	}{
+		{CalibrationConfig{}, 1000, 1000},
+		{CalibrationConfig{PowerFactor: 1.1}, 1000, 1100},
+		{CalibrationConfig{PowerOffset: 50}, 1000, 1050},
+		{CalibrationConfig{PowerFactor: 0.9, PowerOffset: -50}, 1000, 850},
+		{CalibrationConfig{PowerFactor: 1.1, PowerOffset: 50}, 0, 0}, // idle
+		{CalibrationConfig{PowerOffset: -50}, 20, 0},
+	}
+
+	for _, tc := range tc {
+		assert.InDelta(t, tc.power, tc.cal.power(tc.in), 1e-9, "%+v", tc)
+	}
+}
+
+func TestCalibrationChargedEnergy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	rt := api.NewMockChargeRater(ctrl)
+	rt.EXPECT().ChargedEnergy().Return(10.0, nil)
+
+	cal := CalibrationConfig{EnergyFactor: 1.05}
+	r := &calibratedRater{rt, &cal}
+
+	f, err := r.ChargedEnergy()
+	assert.NoError(t, err)
+	assert.InDelta(t, 10.5, f, 1e-9)
+
+	lp := NewLoadpoint(nil, nil)
+	lp.Calibration = CalibrationConfig{EnergyFactor: -1}
+	assert.Error(t, lp.configureCalibration())
+}
@@ -26,6 +26,26 @@ func (lp *Loadpoint) publishChargerFeature(f api.Feature) {
 	lp.publish(keys.ChargerFeature+f.String(), ok)
 }
 
+// unlockAtLimit releases the socket lock once per session if configured
+func (lp *Loadpoint) unlockAtLimit() {
+	if !lp.UnlockAtLimit || lp.GetStatus() != api.StatusB {
+		return
+	}
+
+	lp.Lock()
+	unlocked := lp.socketUnlocked
+	lp.socketUnlocked = true // don't retry on failure
+	lp.Unlock()
+
+	if unlocked {
+		return
+	}
+
+	if err := lp.UnlockSocket(); err != nil {
+		lp.log.ERROR.Println(err)
+	}
+}
+
 // chargerSoc returns charger soc if available
 func (lp *Loadpoint) chargerSoc() (float64, error) {
 	if c, ok := lp.charger.(api.Battery); ok {
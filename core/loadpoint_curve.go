@@ -0,0 +1,74 @@
+package core
+
+import (
+	"slices"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/vehicle"
+)
+
+const (
+	chargeCurveStep      = 5   // soc bucket width of the learned charge curve in %
+	chargeCurveSmoothing = 0.5 // weight of the latest session when merging the learned charge curve
+)
+
+// updateChargeCurve records the charge power over soc while charging at full current
+func (lp *Loadpoint) updateChargeCurve() {
+	if !lp.charging() || lp.vehicleSoc <= 0 || lp.chargeCurrent < lp.effectiveMaxCurrent() || lp.GetVehicle() == nil {
+		return
+	}
+
+	lp.Lock()
+	defer lp.Unlock()
+
+	if lp.chargeCurveSamples == nil {
+		lp.chargeCurveSamples = make(map[int]float64)
+	}
+
+	bucket := int(lp.vehicleSoc) / chargeCurveStep * chargeCurveStep
+	lp.chargeCurveSamples[bucket] = max(lp.chargeCurveSamples[bucket], lp.chargePower)
+}
+
+// learnChargeCurve merges the session's charge power samples into the vehicle's learned charge curve.
+// Vehicles with configured charge curve are not learned.
+func (lp *Loadpoint) learnChargeCurve() {
+	lp.Lock()
+	samples := lp.chargeCurveSamples
+	lp.chargeCurveSamples = nil
+	lp.Unlock()
+
+	v := lp.GetVehicle()
+	if v == nil || len(samples) == 0 {
+		return
+	}
+
+	if cd, ok := v.(api.ChargeCurveDescriber); ok && len(cd.ChargeCurve()) > 0 {
+		return
+	}
+
+	settings := vehicle.Settings(lp.log, v)
+	curve := mergeChargeCurve(settings.GetLearnedChargeCurve(), samples)
+	settings.SetLearnedChargeCurve(curve)
+
+	lp.log.DEBUG.Printf("learned charge curve: %v", curve)
+}
+
+// mergeChargeCurve merges soc bucket samples into the charge curve
+func mergeChargeCurve(curve api.ChargeCurve, samples map[int]float64) api.ChargeCurve {
+	res := slices.Clone(curve)
+
+	for bucket, power := range samples {
+		soc := float64(bucket) + chargeCurveStep/2.0
+
+		if idx := slices.IndexFunc(res, func(p api.ChargeCurvePoint) bool { return p.Soc == soc }); idx >= 0 {
+			res[idx].Power = chargeCurveSmoothing*power + (1-chargeCurveSmoothing)*res[idx].Power
+			continue
+		}
+
+		res = append(res, api.ChargeCurvePoint{Soc: soc, Power: power})
+	}
+
+	res.Sort()
+
+	return res
+}
@@ -0,0 +1,16 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeChargeCurve(t *testing.T) {
+	curve := mergeChargeCurve(nil, map[int]float64{50: 11000, 80: 7000})
+	assert.Equal(t, api.ChargeCurve{{Soc: 52.5, Power: 11000}, {Soc: 82.5, Power: 7000}}, curve)
+
+	curve = mergeChargeCurve(curve, map[int]float64{80: 5000, 90: 3000})
+	assert.Equal(t, api.ChargeCurve{{Soc: 52.5, Power: 11000}, {Soc: 82.5, Power: 6000}, {Soc: 92.5, Power: 3000}}, curve)
+}
@@ -0,0 +1,128 @@
+package core
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/provider"
+)
+
+// DeratingConfig reduces the maximum charge current depending on temperature
+type DeratingConfig struct {
+	Temperature *provider.Config `mapstructure:"temperature"` // temperature plugin (°C), defaults to the charger's sensor
+	Hysteresis  float64          `mapstructure:"hysteresis"`  // temperature drop below a rule's threshold before it is released (K)
+	Rules       []DeratingRule   `mapstructure:"rules"`       // derating steps
+}
+
+// DeratingRule limits the charge current above a temperature
+type DeratingRule struct {
+	Above      float64 `mapstructure:"above"`      // temperature threshold (°C)
+	MaxCurrent float64 `mapstructure:"maxCurrent"` // max charge current (A)
+}
+
+// configureDerating validates the derating rules and creates the temperature getter
+func (lp *Loadpoint) configureDerating() error {
+	conf := &lp.Derating
+	if len(conf.Rules) == 0 {
+		return nil
+	}
+
+	for _, r := range conf.Rules {
+		if r.MaxCurrent < 0 {
+			return fmt.Errorf("derating: invalid max current: %.3gA", r.MaxCurrent)
+		}
+	}
+
+	slices.SortFunc(conf.Rules, func(a, b DeratingRule) int {
+		return cmp.Compare(a.Above, b.Above)
+	})
+
+	if conf.Hysteresis == 0 {
+		conf.Hysteresis = 2
+	}
+
+	if conf.Temperature != nil {
+		temperatureG, err := provider.NewFloatGetterFromConfig(*conf.Temperature)
+		if err != nil {
+			return fmt.Errorf("derating: %w", err)
+		}
+		lp.temperatureG = temperatureG
+		return nil
+	}
+
+	if c, ok := lp.charger.(api.ChargerTemperature); ok {
+		lp.temperatureG = c.Temperature
+		return nil
+	}
+
+	return errors.New("derating: missing temperature")
+}
+
+// deratingLevel returns the number of applicable rules considering hysteresis, 0 if none applies
+func (lp *Loadpoint) deratingLevel(temp float64, level int) int {
+	var res int
+
+	for i, r := range lp.Derating.Rules {
+		threshold := r.Above
+		if i < level {
+			// keep active rules until temperature dropped below hysteresis
+			threshold -= lp.Derating.Hysteresis
+		}
+
+		if temp >= threshold {
+			res = i + 1
+		}
+	}
+
+	return res
+}
+
+// updateDerating reads the temperature and applies the derating rules
+func (lp *Loadpoint) updateDerating() {
+	if lp.temperatureG == nil {
+		return
+	}
+
+	temp, err := lp.temperatureG()
+	if err != nil {
+		lp.log.ERROR.Println("derating:", err)
+		return
+	}
+
+	lp.publish(keys.ChargerTemperature, temp)
+
+	lp.Lock()
+	defer lp.Unlock()
+
+	level := lp.deratingLevel(temp, lp.derating)
+	if level == lp.derating {
+		return
+	}
+
+	lp.derating = level
+
+	if level > 0 {
+		current := lp.Derating.Rules[level-1].MaxCurrent
+		lp.log.WARN.Printf("derating: %.1f°C, max current %.3gA", temp, current)
+		lp.publish(keys.DeratingCurrent, current)
+	} else {
+		lp.log.INFO.Printf("derating: %.1f°C, released", temp)
+		lp.publish(keys.DeratingCurrent, nil)
+	}
+}
+
+// deratingCurrent returns the temperature dependent max current if derated
+func (lp *Loadpoint) deratingCurrent() (float64, bool) {
+	lp.RLock()
+	defer lp.RUnlock()
+
+	if lp.derating == 0 {
+		return 0, false
+	}
+
+	return lp.Derating.Rules[lp.derating-1].MaxCurrent, true
+}
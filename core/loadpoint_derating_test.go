@@ -0,0 +1,74 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeratingLevel(t *testing.T) {
+	lp := &Loadpoint{
+		Derating: DeratingConfig{
+			Hysteresis: 2,
+			Rules: []DeratingRule{
+				{Above: 40, MaxCurrent: 10},
+				{Above: 50, MaxCurrent: 6},
+			},
+		},
+	}
+
+	for _, tc := range []struct {
+		temp          float64
+		active, level int
+	}{
+		{30, 0, 0},
+		{40, 0, 1},
+		{39, 1, 1}, // hysteresis
+		{37.9, 1, 0},
+		{55, 0, 2},
+		{49, 2, 2}, // hysteresis
+		{47, 2, 1}, // back to first rule
+		{45, 2, 1},
+		{20, 2, 0},
+	} {
+		assert.Equal(t, tc.level, lp.deratingLevel(tc.temp, tc.active), "%.1f°C active %d", tc.temp, tc.active)
+	}
+}
+
+func TestDeratingCurrent(t *testing.T) {
+	temp := 20.0
+
+	lp := &Loadpoint{
+		log: util.NewLogger("foo"),
+		temperatureG: func() (float64, error) {
+			return temp, nil
+		},
+		Derating: DeratingConfig{
+			Hysteresis: 2,
+			Rules: []DeratingRule{
+				{Above: 40, MaxCurrent: 10},
+			},
+		},
+	}
+
+	lp.updateDerating()
+	_, ok := lp.deratingCurrent()
+	assert.False(t, ok)
+
+	temp = 41
+	lp.updateDerating()
+	current, ok := lp.deratingCurrent()
+	assert.True(t, ok)
+	assert.Equal(t, 10.0, current)
+
+	temp = 39
+	lp.updateDerating()
+	_, ok = lp.deratingCurrent()
+	assert.True(t, ok)
+
+	temp = 37
+	lp.updateDerating()
+	_, ok = lp.deratingCurrent()
+	assert.False(t, ok)
+}
@@ -99,6 +99,10 @@ func (lp *Loadpoint) effectiveMaxCurrent() float64 {
 		}
 	}
 
+	if res, ok := lp.deratingCurrent(); ok {
+		maxCurrent = min(maxCurrent, res)
+	}
+
 	return maxCurrent
 }
 
@@ -113,7 +117,14 @@ func (lp *Loadpoint) effectiveLimitSoc() int {
 	}
 
 	if v := lp.GetVehicle(); v != nil {
-		if soc := vehicle.Settings(lp.log, v).GetLimitSoc(); soc > 0 {
+		settings := vehicle.Settings(lp.log, v)
+
+		// trip limit overrides the daily limit for a single session
+		if soc := settings.GetTripLimitSoc(); soc > 0 {
+			return soc
+		}
+
+		if soc := settings.GetLimitSoc(); soc > 0 {
 			return soc
 		}
 	}
@@ -122,13 +133,34 @@ func (lp *Loadpoint) effectiveLimitSoc() int {
 	return 100
 }
 
+// voltage returns the measured phase voltage or the nominal voltage if not available
+func (lp *Loadpoint) voltage() float64 {
+	lp.RLock()
+	defer lp.RUnlock()
+
+	if lp.chargeVoltage > 0 {
+		return lp.chargeVoltage
+	}
+
+	if Voltage == 0 {
+		panic("Voltage is not set")
+	}
+
+	return Voltage
+}
+
+// powerToCurrent converts power to per-phase current
+func (lp *Loadpoint) powerToCurrent(power float64, phases int) float64 {
+	return power / (float64(phases) * lp.voltage())
+}
+
 // EffectiveMinPower returns the effective min power for a single phase
 func (lp *Loadpoint) EffectiveMinPower() float64 {
 	// TODO check if 1p available
-	return Voltage * lp.effectiveMinCurrent()
+	return lp.voltage() * lp.effectiveMinCurrent()
 }
 
 // EffectiveMaxPower returns the effective max power taking vehicle capabilities and phase scaling into account
 func (lp *Loadpoint) EffectiveMaxPower() float64 {
-	return Voltage * lp.effectiveMaxCurrent() * float64(lp.maxActivePhases())
+	return lp.voltage() * lp.effectiveMaxCurrent() * float64(lp.maxActivePhases())
 }
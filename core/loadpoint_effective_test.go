@@ -4,7 +4,9 @@ import (
 	"testing"
 
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/vehicle"
 	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/config"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
@@ -64,3 +66,33 @@ func TestEffectiveMinMaxCurrent(t *testing.T) {
 		assert.Equal(t, tc.effectiveMax, lp.effectiveMaxCurrent())
 	}
 }
+
+func TestEffectiveTripLimitSoc(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	v := api.NewMockVehicle(ctrl)
+
+	dev := config.NewStaticDevice(config.Named{Name: "trip"}, api.Vehicle(v))
+	assert.NoError(t, config.Vehicles().Add(dev))
+	defer config.Vehicles().Delete("trip")
+
+	lp := NewLoadpoint(util.NewLogger("foo"), nil)
+	lp.vehicle = v
+
+	s := vehicle.Settings(lp.log, v)
+	s.SetLimitSoc(80)
+	assert.Equal(t, 80, lp.effectiveLimitSoc())
+
+	// trip limit overrides daily limit
+	s.SetTripLimitSoc(100)
+	assert.Equal(t, 100, lp.effectiveLimitSoc())
+
+	// session limit overrides both
+	lp.limitSoc = 90
+	assert.Equal(t, 90, lp.effectiveLimitSoc())
+	lp.limitSoc = 0
+
+	// trip limit reverts after the session
+	s.SetTripLimitSoc(0)
+	assert.Equal(t, 80, lp.effectiveLimitSoc())
+}
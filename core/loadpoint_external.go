@@ -0,0 +1,121 @@
+package core
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+)
+
+// ExternalConfig configures the external mode where a third-party optimizer controls charge power
+type ExternalConfig struct {
+	Timeout  time.Duration  `mapstructure:"timeout"`  // setpoints expire unless refreshed
+	Fallback api.ChargeMode `mapstructure:"fallback"` // mode used while setpoints are expired
+}
+
+// GetExternalPower returns the external charge power setpoint
+func (lp *Loadpoint) GetExternalPower() float64 {
+	lp.RLock()
+	defer lp.RUnlock()
+	return lp.externalPower
+}
+
+// SetExternalPower sets the external charge power setpoint and refreshes the watchdog
+func (lp *Loadpoint) SetExternalPower(power float64) {
+	lp.Lock()
+	defer lp.Unlock()
+
+	lp.log.DEBUG.Printf("set external power: %.0fW", power)
+
+	lp.setExternalSetpoint(max(power, 0), 0)
+}
+
+// GetExternalCurrent returns the external charge current setpoint
+func (lp *Loadpoint) GetExternalCurrent() float64 {
+	lp.RLock()
+	defer lp.RUnlock()
+	return lp.externalCurrent
+}
+
+// SetExternalCurrent sets the external charge current setpoint and refreshes the watchdog
+func (lp *Loadpoint) SetExternalCurrent(current float64) {
+	lp.Lock()
+	defer lp.Unlock()
+
+	lp.log.DEBUG.Printf("set external current: %.3gA", current)
+
+	lp.setExternalSetpoint(0, max(current, 0))
+}
+
+// setExternalSetpoint replaces the external setpoint (no mutex)
+func (lp *Loadpoint) setExternalSetpoint(power, current float64) {
+	lp.externalUpdated = lp.clock.Now()
+
+	if lp.externalPower != power || lp.externalCurrent != current {
+		lp.externalPower = power
+		lp.externalCurrent = current
+
+		lp.publish(keys.ExternalPower, power)
+		lp.publish(keys.ExternalCurrent, current)
+	}
+
+	if lp.externalExpired {
+		lp.externalExpired = false
+		lp.publish(keys.ExternalExpired, false)
+	}
+
+	lp.requestUpdate()
+}
+
+// effectiveExternalMode returns the fallback mode while in external mode without recent setpoints
+func (lp *Loadpoint) effectiveExternalMode(mode api.ChargeMode) api.ChargeMode {
+	if mode != api.ModeExternal {
+		return mode
+	}
+
+	lp.Lock()
+	defer lp.Unlock()
+
+	expired := lp.clock.Since(lp.externalUpdated) > lp.External.Timeout
+
+	if expired != lp.externalExpired {
+		if expired {
+			lp.log.WARN.Printf("external setpoint expired, using %s mode", lp.External.Fallback)
+		}
+
+		lp.externalExpired = expired
+		lp.publish(keys.ExternalExpired, expired)
+	}
+
+	if expired {
+		return lp.External.Fallback
+	}
+
+	return mode
+}
+
+// externalCharging follows the external power or current setpoint within the loadpoint's limits
+func (lp *Loadpoint) externalCharging() error {
+	power, current := lp.GetExternalPower(), lp.GetExternalCurrent()
+
+	minCurrent := lp.effectiveMinCurrent()
+	maxCurrent := lp.effectiveMaxCurrent()
+
+	if power > 0 {
+		// available power is charge power minus site power
+		if lp.hasPhaseSwitching() {
+			_ = lp.pvScalePhases(lp.chargePower-power, minCurrent, maxCurrent)
+		}
+
+		current = lp.powerToCurrent(power, lp.ActivePhases())
+	}
+
+	if current <= 0 {
+		return lp.setLimit(0, true)
+	}
+
+	current = min(max(current, minCurrent), maxCurrent)
+	lp.log.DEBUG.Printf("external charge current: %.3gA", current)
+
+	return lp.setLimit(current, true)
+}
@@ -0,0 +1,93 @@
+package core
+
+import (
+	"errors"
+
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/loadpoint"
+)
+
+// GetGuestSession returns the active guest session or nil
+func (lp *Loadpoint) GetGuestSession() *loadpoint.GuestSession {
+	lp.RLock()
+	defer lp.RUnlock()
+
+	if lp.guest == nil {
+		return nil
+	}
+
+	res := *lp.guest
+	return &res
+}
+
+// StartGuestSession enables charging for the current or next session up to the energy (kWh) or cost cap
+func (lp *Loadpoint) StartGuestSession(energy, cost float64) error {
+	if energy <= 0 && cost <= 0 {
+		return errors.New("guest session requires energy or cost cap")
+	}
+
+	lp.Lock()
+	defer lp.Unlock()
+
+	lp.log.DEBUG.Printf("start guest session: %.3gkWh, %.3g cost", energy, cost)
+
+	lp.guest = &loadpoint.GuestSession{Energy: max(energy, 0), Cost: max(cost, 0)}
+	lp.publish(keys.GuestSession, *lp.guest)
+
+	lp.requestUpdate()
+
+	return nil
+}
+
+// StopGuestSession ends the guest session
+func (lp *Loadpoint) StopGuestSession() {
+	lp.Lock()
+	defer lp.Unlock()
+
+	lp.stopGuestSession()
+	lp.requestUpdate()
+}
+
+// stopGuestSession ends the guest session (no mutex)
+func (lp *Loadpoint) stopGuestSession() {
+	if lp.guest != nil {
+		lp.log.DEBUG.Println("stop guest session")
+	}
+
+	lp.guest = nil
+	lp.publish(keys.GuestSession, nil)
+}
+
+// guestSessionActive returns true if a guest session is active
+func (lp *Loadpoint) guestSessionActive() bool {
+	return lp.GetGuestSession() != nil
+}
+
+// guestCapReached returns true if the guest session's energy or cost cap is reached
+func (lp *Loadpoint) guestCapReached() bool {
+	guest := lp.GetGuestSession()
+	if guest == nil {
+		return false
+	}
+
+	if guest.Energy > 0 && lp.GetChargedEnergy()/1e3 >= guest.Energy {
+		lp.log.DEBUG.Printf("guest energy cap reached: %.3gkWh", guest.Energy)
+		return true
+	}
+
+	if price := lp.sessionEnergy.Price(); guest.Cost > 0 && price != nil && *price >= guest.Cost {
+		lp.log.DEBUG.Printf("guest cost cap reached: %.3g", guest.Cost)
+		return true
+	}
+
+	return false
+}
+
+// guestCharging charges at full power until the guest session cap is reached
+func (lp *Loadpoint) guestCharging() error {
+	if lp.guestCapReached() {
+		return lp.setLimit(0, true)
+	}
+
+	return lp.fastCharging()
+}
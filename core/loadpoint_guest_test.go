@@ -0,0 +1,51 @@
+package core
+
+import (
+	"testing"
+
+	evbus "github.com/asaskevich/EventBus"
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGuestSession(t *testing.T) {
+	Voltage = 230 // V
+	ctrl := gomock.NewController(t)
+	charger := api.NewMockCharger(ctrl)
+
+	lp := &Loadpoint{
+		log:           util.NewLogger("foo"),
+		clock:         clock.NewMock(),
+		bus:           evbus.New(),
+		charger:       charger,
+		wakeUpTimer:   NewTimer(),
+		sessionEnergy: NewEnergyMetrics(),
+		minCurrent:    minA,
+		maxCurrent:    maxA,
+		phases:        3,
+	}
+
+	require.Error(t, lp.StartGuestSession(0, 0))
+	assert.False(t, lp.guestSessionActive())
+
+	require.NoError(t, lp.StartGuestSession(10, 0))
+	assert.Equal(t, &loadpoint.GuestSession{Energy: 10}, lp.GetGuestSession())
+
+	// charging below cap
+	charger.EXPECT().MaxCurrent(int64(maxA)).Return(nil)
+	charger.EXPECT().Enable(true).Return(nil)
+	assert.NoError(t, lp.guestCharging())
+
+	// cap reached
+	lp.sessionEnergy.Update(10)
+	charger.EXPECT().Enable(false).Return(nil)
+	assert.NoError(t, lp.guestCharging())
+
+	lp.StopGuestSession()
+	assert.Nil(t, lp.GetGuestSession())
+}
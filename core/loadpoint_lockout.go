@@ -0,0 +1,87 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/user"
+	"github.com/evcc-io/evcc/core/vehicle"
+	"github.com/evcc-io/evcc/tariff/fixed"
+)
+
+// LockoutConfig disables charging during scheduled windows regardless of mode
+type LockoutConfig struct {
+	Windows  []LockoutWindow `mapstructure:"windows"`
+	Override string          `mapstructure:"override"` // user role allowed to charge during lockout
+}
+
+// LockoutWindow is a recurring lockout schedule
+type LockoutWindow struct {
+	Days  string `mapstructure:"days"`  // e.g. Mon-Fri, all days if empty
+	Hours string `mapstructure:"hours"` // e.g. 6-22 or 0-6,22-0, all day if empty
+}
+
+// configureLockout parses the lockout windows
+func (lp *Loadpoint) configureLockout() error {
+	for i, w := range lp.Lockout.Windows {
+		days, err := fixed.ParseDays(w.Days)
+		if err != nil {
+			return fmt.Errorf("lockout window %d: %w", i+1, err)
+		}
+
+		if w.Hours == "" {
+			lp.lockout = append(lp.lockout, fixed.Zone{Days: days})
+			continue
+		}
+
+		hours, err := fixed.ParseTimeRanges(w.Hours)
+		if err != nil {
+			return fmt.Errorf("lockout window %d: %w", i+1, err)
+		}
+
+		for _, h := range hours {
+			lp.lockout = append(lp.lockout, fixed.Zone{Days: days, Hours: h})
+		}
+	}
+
+	return nil
+}
+
+// lockoutWindow returns true if charging is locked out at the given time
+func (lp *Loadpoint) lockoutWindow(now time.Time) bool {
+	hm := fixed.HourMin{Hour: now.Hour(), Min: now.Minute()}
+
+	for _, z := range lp.lockout.ForDay(fixed.Day(now.Weekday())) {
+		if z.Hours.IsNil() || z.Hours.Contains(hm) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// lockoutOverride returns true if the user identified by charger identifier or vehicle may charge during lockout
+func (lp *Loadpoint) lockoutOverride() bool {
+	if lp.Lockout.Override == "" {
+		return false
+	}
+
+	var name string
+	if v := lp.GetVehicle(); v != nil {
+		name = vehicle.Settings(lp.log, v).Name()
+	}
+
+	lp.RLock()
+	identifier := lp.vehicleIdentifier
+	lp.RUnlock()
+
+	return user.HasRole(user.Attribute(identifier, name), lp.Lockout.Override)
+}
+
+// lockoutActive returns true if charging is locked out and not overridden
+func (lp *Loadpoint) lockoutActive() bool {
+	res := lp.lockoutWindow(lp.clock.Now()) && !lp.lockoutOverride()
+	lp.publish(keys.Lockout, res)
+	return res
+}
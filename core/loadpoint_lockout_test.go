@@ -0,0 +1,51 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	evbus "github.com/asaskevich/EventBus"
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/core/user"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockout(t *testing.T) {
+	clck := clock.NewMock()
+
+	lp := &Loadpoint{
+		log:   util.NewLogger("foo"),
+		clock: clck,
+		bus:   evbus.New(),
+	}
+
+	lp.Lockout = LockoutConfig{
+		Windows: []LockoutWindow{
+			{Days: "Mon-Fri", Hours: "0-6,22-0"},
+			{Days: "Sun"},
+		},
+		Override: "admin",
+	}
+	require.NoError(t, lp.configureLockout())
+
+	// monday
+	assert.True(t, lp.lockoutWindow(time.Date(2024, 1, 1, 5, 59, 0, 0, time.Local)))
+	assert.False(t, lp.lockoutWindow(time.Date(2024, 1, 1, 6, 0, 0, 0, time.Local)))
+	assert.True(t, lp.lockoutWindow(time.Date(2024, 1, 1, 23, 0, 0, 0, time.Local)))
+	// saturday
+	assert.False(t, lp.lockoutWindow(time.Date(2024, 1, 6, 23, 0, 0, 0, time.Local)))
+	// sunday
+	assert.True(t, lp.lockoutWindow(time.Date(2024, 1, 7, 12, 0, 0, 0, time.Local)))
+
+	// override by user role
+	clck.Set(time.Date(2024, 1, 7, 12, 0, 0, 0, time.Local))
+	assert.True(t, lp.lockoutActive())
+
+	require.NoError(t, user.Configure([]user.User{{Name: "alice", Identifiers: []string{"tag"}, Role: "admin"}}))
+	t.Cleanup(func() { _ = user.Configure(nil) })
+
+	lp.vehicleIdentifier = "tag"
+	assert.False(t, lp.lockoutActive())
+}
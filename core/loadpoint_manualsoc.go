@@ -0,0 +1,56 @@
+package core
+
+import (
+	"errors"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/vehicle"
+)
+
+// GetVehicleSoc returns the vehicle soc
+func (lp *Loadpoint) GetVehicleSoc() float64 {
+	lp.RLock()
+	defer lp.RUnlock()
+	return lp.vehicleSoc
+}
+
+// SetVehicleSoc sets the soc of a connected vehicle without api, e.g. the manual vehicle
+func (lp *Loadpoint) SetVehicleSoc(soc float64) error {
+	v := lp.GetVehicle()
+	if v == nil {
+		return errors.New("no vehicle")
+	}
+
+	if err := vehicle.Settings(lp.log, v).SetManualSoc(soc); err != nil {
+		return err
+	}
+
+	lp.log.DEBUG.Printf("set vehicle soc: %.0f%%", soc)
+
+	lp.addTask(lp.restartSocEstimation)
+	lp.requestUpdate()
+
+	return nil
+}
+
+// restartSocEstimation restarts the soc estimation from the entered soc and refreshes the soc
+func (lp *Loadpoint) restartSocEstimation() {
+	if lp.socEstimator != nil {
+		lp.socEstimator.Reset()
+	}
+	lp.socUpdated = time.Time{}
+}
+
+// updateManualSoc advances the soc of vehicles without api to the soc estimated from the charged energy.
+// Updating while charging would make the estimator resample and lose the energy charged in between.
+func (lp *Loadpoint) updateManualSoc() {
+	v := lp.GetVehicle()
+	if _, ok := v.(api.SocSetter); !ok || lp.vehicleSoc <= 0 {
+		return
+	}
+
+	if err := vehicle.Settings(lp.log, v).SetManualSoc(lp.vehicleSoc); err != nil {
+		lp.log.ERROR.Printf("vehicle soc: %v", err)
+	}
+}
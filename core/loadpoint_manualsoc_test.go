@@ -0,0 +1,74 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/soc"
+	"github.com/evcc-io/evcc/core/vehicle"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/config"
+	evvehicle "github.com/evcc-io/evcc/vehicle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestManualSoc(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	v, err := evvehicle.NewManualFromConfig(map[string]any{"capacity": 9})
+	require.NoError(t, err)
+
+	dev := config.NewStaticDevice(config.Named{Name: "manual"}, v)
+	require.NoError(t, config.Vehicles().Add(dev))
+	defer config.Vehicles().Delete("manual")
+
+	lp := NewLoadpoint(util.NewLogger("foo"), nil)
+	lp.vehicle = v
+	lp.socEstimator = soc.NewEstimator(lp.log, api.NewMockCharger(ctrl), v, true)
+
+	// soc unknown until entered
+	_, err = v.Soc()
+	require.ErrorIs(t, err, api.ErrMustRetry)
+
+	// empty battery is a valid soc
+	require.NoError(t, v.(api.SocSetter).SetSoc(0))
+	res, err := v.Soc()
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, res)
+
+	require.NoError(t, lp.SetVehicleSoc(40))
+	res, ok := vehicle.Settings(lp.log, v).GetManualSoc()
+	assert.True(t, ok)
+	assert.Equal(t, 40.0, res)
+
+	f, err := lp.socEstimator.Soc(0)
+	require.NoError(t, err)
+	assert.Equal(t, 40.0, f)
+
+	// 1 kWh at 90% efficiency adds 10% each
+	for _, e := range []float64{1000, 2000} {
+		lp.vehicleSoc, err = lp.socEstimator.Soc(e)
+		require.NoError(t, err)
+	}
+	assert.InDelta(t, 60, lp.vehicleSoc, 1e-6)
+
+	// vehicle keeps the charged soc after charging
+	lp.updateManualSoc()
+
+	res, err = v.Soc()
+	require.NoError(t, err)
+	assert.InDelta(t, 60, res, 1e-6)
+	res, _ = vehicle.Settings(lp.log, v).GetManualSoc()
+	assert.InDelta(t, 60, res, 1e-6)
+
+	// no energy lost when resuming
+	f, err = lp.socEstimator.Soc(2000)
+	require.NoError(t, err)
+	assert.InDelta(t, 60, f, 1e-6)
+
+	f, err = lp.socEstimator.Soc(3000)
+	require.NoError(t, err)
+	assert.InDelta(t, 70, f, 1e-6)
+}
@@ -1,6 +1,8 @@
 package core
 
 import (
+	"time"
+
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/keys"
 )
@@ -114,3 +116,75 @@ func (lp *Loadpoint) hasPhaseSwitching() bool {
 	_, ok := lp.charger.(api.PhaseSwitcher)
 	return ok
 }
+
+// phase switch decision rationale
+const (
+	phaseReasonDwell    = "dwell"    // minimum time since last switch not reached
+	phaseReasonForecast = "forecast" // forecast surplus does not justify switching
+	phaseReasonPenalty  = "penalty"  // switch delayed by vehicle phase switch penalty
+)
+
+// setPhaseReason publishes the phase switch decision rationale
+func (lp *Loadpoint) setPhaseReason(reason string) {
+	if lp.phaseReason != reason {
+		lp.phaseReason = reason
+		lp.publish(keys.PhaseReason, reason)
+	}
+}
+
+// phaseSwitchDwelling returns true if the minimum dwell time since the last phase switch has not elapsed
+func (lp *Loadpoint) phaseSwitchDwelling() bool {
+	return lp.PhaseSwitching.MinDwell > 0 && !lp.phasesSwitched.IsZero() &&
+		lp.clock.Since(lp.phasesSwitched) < lp.PhaseSwitching.MinDwell
+}
+
+// phaseSwitchPenalty returns the time the vehicle needs to resume charging after a phase switch
+func (lp *Loadpoint) phaseSwitchPenalty() time.Duration {
+	if v, ok := lp.GetVehicle().(api.PhaseSwitchPenaltyDescriber); ok {
+		return v.PhaseSwitchPenalty()
+	}
+	return 0
+}
+
+// solarTrend returns the difference between the average forecast pv power over the
+// lookahead horizon and the current forecast pv power. Returns 0 if no forecast is available.
+func (lp *Loadpoint) solarTrend() float64 {
+	if lp.solarForecast == nil || lp.PhaseSwitching.Lookahead <= 0 {
+		return 0
+	}
+
+	rr, err := lp.solarForecast.Rates()
+	if err != nil {
+		return 0
+	}
+
+	now := lp.clock.Now()
+	current, err := rr.Current(now)
+	if err != nil {
+		return 0
+	}
+
+	end := now.Add(lp.PhaseSwitching.Lookahead)
+
+	var energy, duration float64
+	for _, r := range rr {
+		from, to := r.Start, r.End
+		if from.Before(now) {
+			from = now
+		}
+		if to.After(end) {
+			to = end
+		}
+
+		if d := to.Sub(from).Hours(); d > 0 {
+			energy += r.Price * d
+			duration += d
+		}
+	}
+
+	if duration == 0 {
+		return 0
+	}
+
+	return energy/duration - current.Price
+}
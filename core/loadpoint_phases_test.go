@@ -9,6 +9,7 @@ import (
 	"github.com/benbjohnson/clock"
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
 
@@ -446,3 +447,176 @@ func TestScalePhasesIfAvailable(t *testing.T) {
 		ctrl.Finish()
 	}
 }
+
+type phaseSwitchPenaltyVehicle struct {
+	*api.MockVehicle
+	penalty time.Duration
+}
+
+func (v *phaseSwitchPenaltyVehicle) PhaseSwitchPenalty() time.Duration {
+	return v.penalty
+}
+
+func TestPvScalePhasesDecision(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	charger := &struct {
+		*api.MockCharger
+		*api.MockPhaseSwitcher
+	}{
+		api.NewMockCharger(ctrl),
+		api.NewMockPhaseSwitcher(ctrl),
+	}
+
+	dt := time.Minute
+	dwell := 10 * time.Minute
+	penalty := 5 * time.Minute
+	Voltage = 230 // V
+
+	// forecast with current and next slot pv power
+	forecast := func(lp *Loadpoint, current, next float64) {
+		now := lp.clock.Now()
+		tariff := api.NewMockTariff(ctrl)
+		tariff.EXPECT().Rates().Return(api.Rates{
+			{Start: now.Add(-30 * time.Minute), End: now.Add(30 * time.Minute), Price: current},
+			{Start: now.Add(30 * time.Minute), End: now.Add(90 * time.Minute), Price: next},
+		}, nil).AnyTimes()
+		lp.solarForecast = tariff
+	}
+
+	vehicle := func(lp *Loadpoint) {
+		v := api.NewMockVehicle(ctrl)
+		v.EXPECT().Phases().Return(0).AnyTimes()
+		lp.vehicle = &phaseSwitchPenaltyVehicle{v, penalty}
+	}
+
+	tc := []struct {
+		desc                   string
+		phases, measuredPhases int
+		sitePower              float64
+		toPhases               int
+		res                    bool
+		reason                 string
+		prepare                func(lp *Loadpoint)
+	}{
+		{"3/3->1, dwell time not reached", 3, 3, 0.1, 3, false, phaseReasonDwell, func(lp *Loadpoint) {
+			lp.phaseTimer = elapsed
+			lp.phasesSwitched = lp.clock.Now().Add(-dwell / 2)
+		}},
+		{"3/3->1, dwell time reached", 3, 3, 0.1, 1, true, "", func(lp *Loadpoint) {
+			lp.phaseTimer = elapsed
+			lp.phasesSwitched = lp.clock.Now().Add(-dwell)
+		}},
+		{"3/3->1, penalty with recovering forecast", 3, 3, 0.1, 3, false, phaseReasonPenalty, func(lp *Loadpoint) {
+			vehicle(lp)
+			forecast(lp, 0, 20e3)
+			lp.phaseTimer = lp.clock.Now().Add(-dt)
+		}},
+		{"3/3->1, penalty with recovering forecast elapsed", 3, 3, 0.1, 1, true, phaseReasonPenalty, func(lp *Loadpoint) {
+			vehicle(lp)
+			forecast(lp, 0, 20e3)
+			lp.phaseTimer = lp.clock.Now().Add(-dt - penalty)
+		}},
+		{"3/3->1, penalty without recovery", 3, 3, 0.1, 1, true, "", func(lp *Loadpoint) {
+			vehicle(lp)
+			lp.phaseTimer = lp.clock.Now().Add(-dt)
+		}},
+		{"1/1->3, falling forecast", 1, 1, -3 * Voltage * minA, 1, false, phaseReasonForecast, func(lp *Loadpoint) {
+			forecast(lp, 10e3, 0)
+			lp.phaseTimer = elapsed
+		}},
+		{"1/1->3, penalty", 1, 1, -3 * Voltage * minA, 1, false, phaseReasonPenalty, func(lp *Loadpoint) {
+			vehicle(lp)
+			lp.phaseTimer = lp.clock.Now().Add(-dt)
+		}},
+		{"1/1->3, rising forecast waives penalty", 1, 1, -3 * Voltage * minA, 3, true, "", func(lp *Loadpoint) {
+			vehicle(lp)
+			forecast(lp, 0, 20e3)
+			lp.phaseTimer = lp.clock.Now().Add(-dt)
+		}},
+	}
+
+	for _, tc := range tc {
+		t.Log(tc.desc)
+
+		clock := clock.NewMock()
+		clock.Add(time.Hour) // avoid time.IsZero
+
+		lp := &Loadpoint{
+			log:            util.NewLogger("foo"),
+			clock:          clock,
+			charger:        charger,
+			minCurrent:     minA,
+			maxCurrent:     maxA,
+			phases:         tc.phases,
+			measuredPhases: tc.measuredPhases,
+			status:         api.StatusC,
+			Enable: ThresholdConfig{
+				Delay: dt,
+			},
+			Disable: ThresholdConfig{
+				Delay: dt,
+			},
+			PhaseSwitching: PhaseSwitchingConfig{
+				MinDwell:  dwell,
+				Lookahead: time.Hour,
+			},
+		}
+
+		tc.prepare(lp)
+
+		if tc.res {
+			charger.MockPhaseSwitcher.EXPECT().Phases1p3p(tc.toPhases).Return(nil)
+		}
+
+		res := lp.pvScalePhases(tc.sitePower, minA, maxA)
+
+		switch {
+		case tc.res != res:
+			t.Errorf("expected %v, got %v", tc.res, res)
+		case lp.phases != tc.toPhases:
+			t.Errorf("expected %dp, got %dp", tc.toPhases, lp.phases)
+		case lp.phaseReason != tc.reason:
+			t.Errorf("expected reason %q, got %q", tc.reason, lp.phaseReason)
+		}
+	}
+}
+
+func TestScalePhasesPausesCharging(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	charger := api.NewMockCharger(ctrl)
+	phaseCharger := api.NewMockPhaseSwitcher(ctrl)
+
+	lp := &Loadpoint{
+		log:   util.NewLogger("foo"),
+		clock: clock.NewMock(),
+		bus:   evbus.New(),
+		charger: struct {
+			*api.MockCharger
+			*api.MockPhaseSwitcher
+		}{
+			charger,
+			phaseCharger,
+		},
+		minCurrent:  minA,
+		maxCurrent:  maxA,
+		phases:      3,
+		enabled:     true,
+		wakeUpTimer: NewTimer(),
+	}
+
+	// vehicle still charging, charger is disabled and switching retried
+	phaseCharger.EXPECT().Phases1p3p(1).Return(api.ErrMustRetry)
+	charger.EXPECT().Enable(false).Return(nil)
+
+	require.NoError(t, lp.scalePhases(1))
+	require.Equal(t, 1, lp.phaseSwitchPending)
+	require.Equal(t, 3, lp.GetPhases())
+	require.False(t, lp.enabled)
+
+	// charging paused
+	phaseCharger.EXPECT().Phases1p3p(1).Return(nil)
+
+	require.NoError(t, lp.scalePhases(lp.phaseSwitchPending))
+	require.Zero(t, lp.phaseSwitchPending)
+	require.Equal(t, 1, lp.GetPhases())
+}
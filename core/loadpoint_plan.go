@@ -13,6 +13,10 @@ import (
 const (
 	smallSlotDuration = 10 * time.Minute // small planner slot duration we might ignore
 	smallGapDuration  = 60 * time.Minute // small gap duration between planner slots we might ignore
+
+	planPowerSettleTime = 2 * time.Minute // time after charging at full power before observing charge power
+	planPowerSmoothing  = 0.2             // weight of the latest observed charge power
+	planPowerMinRatio   = 0.2             // lower bound of observed to nominal charge power
 )
 
 // TODO planActive is not guarded by mutex
@@ -39,14 +43,19 @@ func (lp *Loadpoint) deletePlan() {
 
 // remainingPlanEnergy returns missing energy amount in kWh
 func (lp *Loadpoint) remainingPlanEnergy(planEnergy float64) float64 {
-	return max(0, planEnergy-lp.getChargedEnergy()/1e3)
+	return max(0, planEnergy-lp.GetChargedEnergy()/1e3)
 }
 
-// GetPlanRequiredDuration is the estimated total charging duration
+// GetPlanRequiredDuration is the estimated total charging duration.
+// Nominal power is reduced by the observed charge power ratio, e.g. due to tapering or temperature derating.
 func (lp *Loadpoint) GetPlanRequiredDuration(goal, maxPower float64) time.Duration {
 	lp.RLock()
 	defer lp.RUnlock()
 
+	if lp.planPowerRatio > 0 {
+		maxPower *= lp.planPowerRatio
+	}
+
 	if lp.socBasedPlanning() {
 		if lp.socEstimator == nil {
 			return 0
@@ -58,6 +67,50 @@ func (lp *Loadpoint) GetPlanRequiredDuration(goal, maxPower float64) time.Durati
 	return time.Duration(energy * 1e3 / maxPower * float64(time.Hour))
 }
 
+// resetPlanPower forgets the observed charge power
+func (lp *Loadpoint) resetPlanPower() {
+	lp.Lock()
+	defer lp.Unlock()
+
+	lp.planPowerRatio = 0
+	lp.planPowerStart = time.Time{}
+}
+
+// updatePlanPower observes the ratio of actual to nominal charge power while charging at full current for the plan
+func (lp *Loadpoint) updatePlanPower() {
+	nominal := lp.chargeCurrent * float64(lp.ActivePhases()) * lp.voltage()
+
+	// charge current not limited by evcc
+	if !lp.planActive || !lp.charging() || lp.chargeCurrent < lp.effectiveMaxCurrent() || nominal <= 0 {
+		lp.Lock()
+		lp.planPowerStart = time.Time{}
+		lp.Unlock()
+		return
+	}
+
+	lp.Lock()
+	defer lp.Unlock()
+
+	if lp.planPowerStart.IsZero() {
+		lp.planPowerStart = lp.clock.Now()
+	}
+
+	if lp.clock.Since(lp.planPowerStart) < planPowerSettleTime {
+		return
+	}
+
+	ratio := min(max(lp.chargePower/nominal, planPowerMinRatio), 1)
+	if lp.planPowerRatio > 0 {
+		ratio = planPowerSmoothing*ratio + (1-planPowerSmoothing)*lp.planPowerRatio
+	}
+
+	if ratio < 1 {
+		lp.log.DEBUG.Printf("plan: observed %.0f%% of nominal charge power", 100*ratio)
+	}
+
+	lp.planPowerRatio = ratio
+}
+
 // GetPlanGoal returns the plan goal and if the goal is soc based
 func (lp *Loadpoint) GetPlanGoal() (float64, bool) {
 	lp.RLock()
@@ -81,6 +134,15 @@ func (lp *Loadpoint) GetPlan(targetTime time.Time, requiredDuration time.Duratio
 	return lp.planner.Plan(requiredDuration, targetTime)
 }
 
+// GetPlanExplanation creates a charging plan for given time and duration and explains the planner's reasoning
+func (lp *Loadpoint) GetPlanExplanation(targetTime time.Time, requiredDuration time.Duration) (planner.Explanation, error) {
+	if lp.planner == nil || targetTime.IsZero() {
+		return planner.Explanation{Strategy: planner.StrategyNone}, nil
+	}
+
+	return lp.planner.Explain(requiredDuration, targetTime)
+}
+
 // plannerActive checks if the charging plan has a currently active slot
 func (lp *Loadpoint) plannerActive() (active bool) {
 	defer func() {
@@ -0,0 +1,53 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanPowerFeedback(t *testing.T) {
+	Voltage = 230
+	clck := clock.NewMock()
+
+	lp := &Loadpoint{
+		log:            util.NewLogger("foo"),
+		clock:          clck,
+		maxCurrent:     16,
+		phases:         3,
+		measuredPhases: 3,
+		chargeCurrent:  16,
+		status:         api.StatusC,
+		planActive:     true,
+		sessionEnergy:  NewEnergyMetrics(),
+	}
+
+	nominal := 16 * 3 * Voltage
+	lp.chargePower = nominal / 2
+
+	// settle time after charging at full power
+	lp.updatePlanPower()
+	assert.Equal(t, time.Hour, lp.GetPlanRequiredDuration(nominal/1e3, nominal))
+
+	clck.Add(planPowerSettleTime)
+	lp.updatePlanPower()
+	assert.Equal(t, 2*time.Hour, lp.GetPlanRequiredDuration(nominal/1e3, nominal))
+
+	// smoothed recovery
+	lp.chargePower = nominal
+	lp.updatePlanPower()
+	assert.InDelta(t, 0.6, lp.planPowerRatio, 1e-6)
+
+	// current limited by evcc is ignored
+	lp.chargeCurrent = 8
+	lp.chargePower = nominal / 4
+	lp.updatePlanPower()
+	assert.InDelta(t, 0.6, lp.planPowerRatio, 1e-6)
+
+	lp.resetPlanPower()
+	assert.Equal(t, time.Hour, lp.GetPlanRequiredDuration(nominal/1e3, nominal))
+}
@@ -0,0 +1,43 @@
+package core
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/vehicle"
+)
+
+// updatePrecondition starts battery preconditioning shortly before the vehicle's plan time
+func (lp *Loadpoint) updatePrecondition() {
+	if v := lp.GetVehicle(); v != nil {
+		lp.precondition(vehicle.Settings(lp.log, v), lp.clock.Now())
+	}
+}
+
+// precondition triggers preconditioning once per plan if within the configured duration before plan time
+func (lp *Loadpoint) precondition(v vehicle.API, now time.Time) {
+	pc, ok := v.Instance().(api.VehiclePreconditioner)
+	if !ok {
+		return
+	}
+
+	duration := v.GetPlanPrecondition()
+	planTime, _ := v.GetPlanSoc()
+
+	if duration <= 0 || planTime.IsZero() || planTime.Equal(lp.preconditioned) {
+		return
+	}
+
+	if now.Before(planTime.Add(-duration)) || !now.Before(planTime) {
+		return
+	}
+
+	lp.log.INFO.Printf("precondition: %s for departure at %v", v.Name(), planTime.Round(time.Second).Local())
+
+	if err := pc.Precondition(); err != nil {
+		lp.log.ERROR.Println("precondition:", err)
+		return
+	}
+
+	lp.preconditioned = planTime
+}
@@ -0,0 +1,60 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/vehicle"
+	"github.com/evcc-io/evcc/util"
+	"go.uber.org/mock/gomock"
+)
+
+type preconditionVehicle struct {
+	*api.MockVehicle
+	precondition func() error
+}
+
+func (v *preconditionVehicle) Precondition() error {
+	return v.precondition()
+}
+
+func TestPrecondition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	var count int
+	v := &preconditionVehicle{
+		MockVehicle: api.NewMockVehicle(ctrl),
+		precondition: func() error {
+			count++
+			return nil
+		},
+	}
+
+	planTime := time.Date(2024, 1, 2, 7, 0, 0, 0, time.Local)
+
+	vs := vehicle.NewMockAPI(ctrl)
+	vs.EXPECT().Name().Return("car").AnyTimes()
+	vs.EXPECT().Instance().Return(v).AnyTimes()
+	vs.EXPECT().GetPlanSoc().Return(planTime, 80).AnyTimes()
+	vs.EXPECT().GetPlanPrecondition().Return(30 * time.Minute).AnyTimes()
+
+	lp := &Loadpoint{
+		log: util.NewLogger("foo"),
+	}
+
+	for _, tc := range []struct {
+		now   time.Time
+		count int
+	}{
+		{planTime.Add(-time.Hour), 0},
+		{planTime.Add(-30 * time.Minute), 1},
+		{planTime.Add(-10 * time.Minute), 1}, // once per plan
+		{planTime.Add(time.Minute), 1},
+	} {
+		lp.precondition(vs, tc.now)
+		if count != tc.count {
+			t.Errorf("%v: expected %d preconditionings, got %d", tc.now, tc.count, count)
+		}
+	}
+}
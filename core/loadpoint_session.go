@@ -1,8 +1,12 @@
 package core
 
 import (
+	"errors"
+
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/session"
+	"github.com/evcc-io/evcc/core/user"
+	"github.com/evcc-io/evcc/core/vehicle"
 )
 
 func (lp *Loadpoint) chargeMeterTotal() float64 {
@@ -17,11 +21,31 @@ func (lp *Loadpoint) chargeMeterTotal() float64 {
 		return 0
 	}
 
+	f = lp.Calibration.energy(f)
 	lp.log.DEBUG.Printf("charge total import: %.3fkWh", f)
 
 	return f
 }
 
+// updateSignedMeterValues copies the charger's signed meter data sets to the session
+func (lp *Loadpoint) updateSignedMeterValues(s *session.Session) {
+	c, ok := lp.charger.(api.SignedMeter)
+	if !ok {
+		return
+	}
+
+	start, stop, err := c.SignedMeterValues()
+	if err != nil {
+		if !errors.Is(err, api.ErrNotAvailable) {
+			lp.log.ERROR.Printf("signed meter values: %v", err)
+		}
+		return
+	}
+
+	s.SignedStart = start
+	s.SignedStop = stop
+}
+
 // createSession creates a charging session. The created timestamp is empty until set by evChargeStartHandler.
 // The session is not persisted yet. That will only happen when stopSession is called.
 func (lp *Loadpoint) createSession() {
@@ -31,6 +55,7 @@ func (lp *Loadpoint) createSession() {
 	}
 
 	lp.session = lp.db.New(lp.chargeMeterTotal())
+	lp.gridCharged = false
 
 	if vehicle := lp.GetVehicle(); vehicle != nil {
 		lp.session.Vehicle = vehicle.Title()
@@ -41,6 +66,18 @@ func (lp *Loadpoint) createSession() {
 			lp.session.Identifier = id
 		}
 	}
+
+	lp.updateSessionUser(lp.session)
+}
+
+// updateSessionUser attributes the session to the user owning the identifier or vehicle
+func (lp *Loadpoint) updateSessionUser(s *session.Session) {
+	var name string
+	if v := lp.GetVehicle(); v != nil {
+		name = vehicle.Settings(lp.log, v).Name()
+	}
+
+	s.User = user.Attribute(s.Identifier, name)
 }
 
 // stopSession ends a charging session segment and persists the session.
@@ -58,21 +95,31 @@ func (lp *Loadpoint) stopSession() {
 	}
 
 	s.Finished = lp.clock.Now()
+	s.Guest = lp.GetGuestSession() != nil
 	if meterStop := lp.chargeMeterTotal(); meterStop > 0 {
 		s.MeterStop = &meterStop
 	}
 
-	if chargedEnergy := lp.getChargedEnergy() / 1e3; chargedEnergy > s.ChargedEnergy {
+	if chargedEnergy := lp.GetChargedEnergy() / 1e3; chargedEnergy > s.ChargedEnergy {
 		lp.sessionEnergy.Update(chargedEnergy)
 	}
 
 	solarPerc := lp.sessionEnergy.SolarPercentage()
 	s.SolarPercentage = &solarPerc
+	s.SolarOnly = !lp.gridCharged
 	s.Price = lp.sessionEnergy.Price()
 	s.PricePerKWh = lp.sessionEnergy.PricePerKWh()
+	s.ReferencePrice = lp.sessionEnergy.ReferencePricePerKWh()
 	s.Co2PerKWh = lp.sessionEnergy.Co2PerKWh()
+	s.TariffSlots = lp.sessionEnergy.TariffSlots()
 	s.ChargedEnergy = lp.sessionEnergy.TotalWh() / 1e3
+	s.MinSocEnergy = lp.sessionEnergy.MinSocWh() / 1e3
 	s.ChargeDuration = &lp.chargeDuration
+	if lp.vehicleHasSoc() && lp.vehicleSoc > 0 {
+		socEnd := lp.vehicleSoc
+		s.SocEnd = &socEnd
+	}
+	lp.updateSignedMeterValues(s)
 
 	lp.db.Persist(s)
 }
@@ -104,3 +151,8 @@ func (lp *Loadpoint) clearSession() {
 
 	lp.session = nil
 }
+
+// setReferencePrice sets the grid price the session's charged energy is compared against for savings
+func (lp *Loadpoint) setReferencePrice(price *float64) {
+	lp.sessionEnergy.SetReferencePrice(price)
+}
@@ -61,11 +61,11 @@ func TestSession(t *testing.T) {
 	// stop charging
 	clock.Add(time.Hour)
 	lp.sessionEnergy.Update(1.23)
-	me.EXPECT().TotalEnergy().Return(1.0+lp.getChargedEnergy()/1e3, nil) // match chargedEnergy
+	me.EXPECT().TotalEnergy().Return(1.0+lp.GetChargedEnergy()/1e3, nil) // match chargedEnergy
 
 	lp.stopSession()
 	assert.NotNil(t, lp.session)
-	assert.Equal(t, lp.getChargedEnergy()/1e3, lp.session.ChargedEnergy)
+	assert.Equal(t, lp.GetChargedEnergy()/1e3, lp.session.ChargedEnergy)
 	assert.Equal(t, clock.Now(), lp.session.Finished)
 
 	s, err := db.Sessions()
@@ -75,7 +75,7 @@ func TestSession(t *testing.T) {
 
 	// stop charging - 2nd leg
 	clock.Add(time.Hour)
-	lp.sessionEnergy.Update(lp.getChargedEnergy() * 2)
+	lp.sessionEnergy.Update(lp.GetChargedEnergy() * 2)
 	me.EXPECT().TotalEnergy().Return(3.0, nil) // doesn't match chargedEnergy
 
 	lp.stopSession()
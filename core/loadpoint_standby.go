@@ -0,0 +1,144 @@
+package core
+
+import (
+	"errors"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/vehicle"
+)
+
+// StandbyConfig disables the charger once the vehicle stopped charging to avoid standby consumption
+type StandbyConfig struct {
+	Delay     time.Duration `mapstructure:"delay"`     // idle duration after charging before the charger is disabled, 0 disables suppression
+	Interval  time.Duration `mapstructure:"interval"`  // period of re-enable windows for battery balancing, 0 for none
+	Window    time.Duration `mapstructure:"window"`    // duration of re-enable windows
+	Departure time.Duration `mapstructure:"departure"` // re-enable ahead of the plan time, e.g. for preconditioning
+}
+
+// standbyTargets are the user settings determining whether the vehicle should charge.
+// Changing any of them lifts standby suppression.
+type standbyTargets struct {
+	mode        api.ChargeMode
+	limitSoc    int
+	minSoc      int
+	limitEnergy float64
+	planTime    time.Time
+	planSoc     int
+	planEnergy  float64
+}
+
+// currentStandbyTargets returns the current charging targets
+func (lp *Loadpoint) currentStandbyTargets() standbyTargets {
+	res := standbyTargets{
+		mode:        lp.GetMode(),
+		limitSoc:    lp.effectiveLimitSoc(),
+		limitEnergy: lp.GetLimitEnergy(),
+		planTime:    lp.EffectivePlanTime(),
+		planSoc:     lp.EffectivePlanSoc(),
+	}
+
+	_, res.planEnergy = lp.GetPlanEnergy()
+
+	if v := lp.GetVehicle(); v != nil {
+		res.minSoc = vehicle.Settings(lp.log, v).GetMinSoc()
+	}
+
+	return res
+}
+
+// configureStandby validates the standby suppression windows
+func (lp *Loadpoint) configureStandby() error {
+	conf := &lp.Standby
+	if conf.Delay <= 0 {
+		return nil
+	}
+
+	if conf.Interval > 0 && conf.Window == 0 {
+		conf.Window = 15 * time.Minute
+	}
+
+	if conf.Interval > 0 && conf.Window >= conf.Interval {
+		return errors.New("standby: window must be shorter than interval")
+	}
+
+	return nil
+}
+
+// standbySuppressed returns true if the charger should be disabled since the connected vehicle stopped charging.
+// Suppression is lifted during periodic re-enable windows and ahead of the plan time.
+func (lp *Loadpoint) standbySuppressed() bool {
+	conf := lp.Standby
+	if conf.Delay <= 0 {
+		return false
+	}
+
+	// vehicle charging or nothing charged yet
+	if lp.GetStatus() != api.StatusB || lp.GetChargedEnergy() == 0 {
+		lp.setStandby(time.Time{}, false)
+		return false
+	}
+
+	targets := lp.currentStandbyTargets()
+
+	lp.RLock()
+	since, enabled, prev := lp.standbySince, lp.enabled, lp.standbyTargets
+	lp.RUnlock()
+
+	// user changed mode, limits or plan, give the vehicle a chance to charge again
+	if !since.IsZero() && targets != prev {
+		lp.log.DEBUG.Println("standby: settings changed")
+		since = time.Time{}
+	}
+
+	// vehicle stopped drawing power although charger is enabled
+	if since.IsZero() {
+		if enabled {
+			lp.setStandby(lp.clock.Now(), false)
+		} else {
+			lp.setStandby(time.Time{}, false)
+		}
+
+		lp.Lock()
+		lp.standbyTargets = targets
+		lp.Unlock()
+
+		return false
+	}
+
+	elapsed := lp.clock.Since(since) - conf.Delay
+
+	suppressed := elapsed >= 0
+	if suppressed && conf.Interval > 0 && elapsed%conf.Interval >= conf.Interval-conf.Window {
+		lp.log.DEBUG.Println("standby: balancing window")
+		suppressed = false
+	}
+
+	if planTime := lp.EffectivePlanTime(); suppressed && conf.Departure > 0 && !planTime.IsZero() && lp.clock.Until(planTime) <= conf.Departure {
+		lp.log.DEBUG.Println("standby: departure window")
+		suppressed = false
+	}
+
+	lp.setStandby(since, suppressed)
+
+	return suppressed
+}
+
+// setStandby updates and publishes standby suppression status
+func (lp *Loadpoint) setStandby(since time.Time, suppressed bool) {
+	lp.Lock()
+	defer lp.Unlock()
+
+	if suppressed != lp.standbyActive {
+		if suppressed {
+			lp.log.DEBUG.Println("standby: disable charger")
+		} else {
+			lp.log.DEBUG.Println("standby: enable charger")
+		}
+	}
+
+	lp.standbySince = since
+	lp.standbyActive = suppressed
+	lp.publish(keys.StandbySuppressed, suppressed)
+}
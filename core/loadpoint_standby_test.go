@@ -0,0 +1,114 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	evbus "github.com/asaskevich/EventBus"
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStandbySuppression(t *testing.T) {
+	clock := clock.NewMock()
+
+	lp := &Loadpoint{
+		log:           util.NewLogger("foo"),
+		clock:         clock,
+		bus:           evbus.New(),
+		sessionEnergy: NewEnergyMetrics(),
+		status:        api.StatusC,
+		enabled:       true,
+		Standby: StandbyConfig{
+			Delay:    30 * time.Minute,
+			Interval: 6 * time.Hour,
+		},
+	}
+
+	require.NoError(t, lp.configureStandby())
+	assert.Equal(t, 15*time.Minute, lp.Standby.Window)
+
+	// charging
+	lp.sessionEnergy.Update(10)
+	assert.False(t, lp.standbySuppressed())
+
+	// vehicle completed charging
+	lp.status = api.StatusB
+	assert.False(t, lp.standbySuppressed())
+
+	clock.Add(29 * time.Minute)
+	assert.False(t, lp.standbySuppressed())
+
+	clock.Add(time.Minute)
+	assert.True(t, lp.standbySuppressed())
+
+	// charger disabled by suppression
+	lp.enabled = false
+	clock.Add(5*time.Hour + 44*time.Minute)
+	assert.True(t, lp.standbySuppressed())
+
+	// balancing window
+	clock.Add(time.Minute)
+	assert.False(t, lp.standbySuppressed())
+
+	clock.Add(15 * time.Minute)
+	assert.True(t, lp.standbySuppressed())
+
+	// vehicle resumes charging
+	lp.status = api.StatusC
+	assert.False(t, lp.standbySuppressed())
+	assert.True(t, lp.standbySince.IsZero())
+}
+
+func TestStandbyUserChange(t *testing.T) {
+	clock := clock.NewMock()
+
+	lp := &Loadpoint{
+		log:           util.NewLogger("foo"),
+		clock:         clock,
+		bus:           evbus.New(),
+		sessionEnergy: NewEnergyMetrics(),
+		status:        api.StatusB,
+		enabled:       true,
+		mode:          api.ModePV,
+		Standby: StandbyConfig{
+			Delay: 30 * time.Minute,
+		},
+	}
+
+	lp.sessionEnergy.Update(10)
+	assert.False(t, lp.standbySuppressed())
+
+	clock.Add(30 * time.Minute)
+	assert.True(t, lp.standbySuppressed())
+
+	// charger disabled by suppression
+	lp.enabled = false
+	clock.Add(time.Hour)
+	assert.True(t, lp.standbySuppressed())
+
+	// user changes mode, charger is re-enabled
+	lp.mode = api.ModeNow
+	assert.False(t, lp.standbySuppressed())
+	assert.True(t, lp.standbySince.IsZero())
+
+	// vehicle does not charge, suppressed again after delay
+	lp.enabled = true
+	assert.False(t, lp.standbySuppressed())
+	clock.Add(30 * time.Minute)
+	assert.True(t, lp.standbySuppressed())
+
+	// user raises limit soc
+	lp.enabled = false
+	lp.limitSoc = 90
+	assert.False(t, lp.standbySuppressed())
+	assert.True(t, lp.standbySince.IsZero())
+}
+
+func TestStandbyConfig(t *testing.T) {
+	lp := &Loadpoint{Standby: StandbyConfig{Delay: time.Minute, Interval: time.Hour, Window: time.Hour}}
+	assert.Error(t, lp.configureStandby())
+}
@@ -0,0 +1,244 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/provider"
+)
+
+const (
+	supervisionWakeUp = "wakeup" // wake up vehicle or charger
+	supervisionToggle = "toggle" // disable and re-enable charger
+	supervisionRelay  = "relay"  // power-cycle charger using smart relay
+
+	supervisionStallPower  = 50               // charge power below which a charging session is treated as stalled (W)
+	supervisionToggleDelay = 10 * time.Second // charger disabled time when toggling the charger
+	supervisionRelayDelay  = 10 * time.Second // smart relay off time when power-cycling the charger
+)
+
+// SupervisionConfig detects stuck charging sessions and executes recovery actions
+type SupervisionConfig struct {
+	Stall   time.Duration    `mapstructure:"stall"`   // fault if connected in fast mode without charge power for this duration, disabled if zero
+	Flaps   int              `mapstructure:"flaps"`   // fault if charger status changes this often within window, disabled if zero
+	Window  time.Duration    `mapstructure:"window"`  // status flap detection window
+	Retry   time.Duration    `mapstructure:"retry"`   // wait time for each recovery action to take effect
+	Actions []string         `mapstructure:"actions"` // recovery actions in order: wakeup, toggle, relay
+	Relay   *provider.Config `mapstructure:"relay"`   // smart relay powering the charger, required for relay action
+}
+
+// configureSupervision validates the supervision config and creates the smart relay
+func (lp *Loadpoint) configureSupervision() error {
+	if lp.Supervision.Stall <= 0 && lp.Supervision.Flaps <= 0 {
+		return nil
+	}
+
+	if len(lp.Supervision.Actions) == 0 {
+		lp.Supervision.Actions = []string{supervisionWakeUp, supervisionToggle}
+	}
+
+	for _, action := range lp.Supervision.Actions {
+		if !slices.Contains([]string{supervisionWakeUp, supervisionToggle, supervisionRelay}, action) {
+			return fmt.Errorf("supervision: invalid action: %s", action)
+		}
+	}
+
+	if slices.Contains(lp.Supervision.Actions, supervisionRelay) {
+		if lp.Supervision.Relay == nil {
+			return errors.New("supervision: relay action requires relay")
+		}
+
+		relayS, err := provider.NewBoolSetterFromConfig("relay", *lp.Supervision.Relay)
+		if err != nil {
+			return fmt.Errorf("supervision: %w", err)
+		}
+
+		lp.relayS = relayS
+	}
+
+	return nil
+}
+
+// supervisionEnabled returns true if fault detection is configured
+func (lp *Loadpoint) supervisionEnabled() bool {
+	return lp.Supervision.Stall > 0 || lp.Supervision.Flaps > 0
+}
+
+// recordStatusFlap records a charger status change for flap detection
+func (lp *Loadpoint) recordStatusFlap() {
+	if lp.Supervision.Flaps <= 0 {
+		return
+	}
+
+	now := lp.clock.Now()
+	lp.statusFlaps = append(slices.DeleteFunc(lp.statusFlaps, func(ts time.Time) bool {
+		return now.Sub(ts) > lp.Supervision.Window
+	}), now)
+}
+
+// statusFlapping returns true if the charger status changed too often within the flap window
+func (lp *Loadpoint) statusFlapping(now time.Time) bool {
+	if lp.Supervision.Flaps <= 0 {
+		return false
+	}
+
+	var flaps int
+	for _, ts := range lp.statusFlaps {
+		if now.Sub(ts) <= lp.Supervision.Window {
+			flaps++
+		}
+	}
+
+	return flaps >= lp.Supervision.Flaps
+}
+
+// chargerStalled returns true if the vehicle is connected in fast mode but has not been charging for the stall duration
+func (lp *Loadpoint) chargerStalled(mode api.ChargeMode, now time.Time) bool {
+	stalled := lp.Supervision.Stall > 0 && mode == api.ModeNow && lp.connected() && lp.enabled &&
+		lp.chargePower < supervisionStallPower && !lp.limitSocReached() && !lp.limitEnergyReached()
+
+	if !stalled {
+		lp.stallStart = time.Time{}
+		return false
+	}
+
+	if lp.stallStart.IsZero() {
+		lp.stallStart = now
+	}
+
+	return now.Sub(lp.stallStart) >= lp.Supervision.Stall
+}
+
+// supervise detects charger faults and executes the configured recovery actions one at a time.
+// If the fault persists after all actions, it is reported.
+func (lp *Loadpoint) supervise(mode api.ChargeMode) {
+	if !lp.supervisionEnabled() {
+		return
+	}
+
+	now := lp.clock.Now()
+
+	// charger or relay still waiting to be switched back on
+	if lp.recoveryRestore != "" {
+		lp.restoreRecovery(now)
+		return
+	}
+
+	if !lp.chargerStalled(mode, now) && !lp.statusFlapping(now) {
+		if lp.recoveryAction > 0 {
+			lp.log.INFO.Println("charger fault: recovered")
+			lp.recoveryAction = 0
+			lp.recoveryActed = time.Time{}
+		}
+
+		if lp.chargerFault {
+			lp.chargerFault = false
+			lp.publish(keys.ChargerFault, false)
+		}
+
+		return
+	}
+
+	// wait for previous action to take effect, or fault already reported
+	if lp.chargerFault || now.Sub(lp.recoveryActed) < lp.Supervision.Retry {
+		return
+	}
+
+	if lp.recoveryAction >= len(lp.Supervision.Actions) {
+		lp.log.ERROR.Println("charger fault: recovery failed")
+		lp.chargerFault = true
+		lp.publish(keys.ChargerFault, true)
+		lp.pushEvent(evChargerFault)
+		return
+	}
+
+	action := lp.Supervision.Actions[lp.recoveryAction]
+	lp.recoveryAction++
+	lp.recoveryActed = now
+
+	lp.log.WARN.Printf("charger fault: recovery action %s", action)
+
+	if err := lp.executeRecovery(action); err != nil {
+		lp.log.ERROR.Printf("charger fault: %s: %v", action, err)
+	}
+}
+
+// restoreRecovery switches the charger or relay back on once the recovery action's off time has elapsed.
+// Failures are retried each cycle and reported as charger fault until successful.
+func (lp *Loadpoint) restoreRecovery(now time.Time) {
+	action := lp.recoveryRestore
+
+	delay := supervisionToggleDelay
+	if action == supervisionRelay {
+		delay = supervisionRelayDelay
+	}
+
+	if now.Sub(lp.recoveryActed) < delay {
+		return
+	}
+
+	var err error
+	if action == supervisionRelay {
+		err = lp.relayS(true)
+	} else {
+		err = lp.charger.Enable(lp.enabled)
+	}
+
+	if err != nil {
+		lp.log.ERROR.Printf("charger fault: %s: %v", action, err)
+
+		if !lp.chargerFault {
+			lp.chargerFault = true
+			lp.publish(keys.ChargerFault, true)
+			lp.pushEvent(evChargerFault)
+		}
+
+		return
+	}
+
+	lp.recoveryRestore = ""
+	lp.recoveryActed = now
+
+	if lp.chargerFault {
+		lp.chargerFault = false
+		lp.publish(keys.ChargerFault, false)
+	}
+}
+
+// executeRecovery executes a single recovery action. Toggle and relay actions switch off only,
+// switching back on is deferred to restoreRecovery.
+func (lp *Loadpoint) executeRecovery(action string) error {
+	switch action {
+	case supervisionWakeUp:
+		if c, ok := lp.charger.(api.Resurrector); ok {
+			if err := c.WakeUp(); err != nil {
+				return err
+			}
+		}
+		if v, ok := lp.GetVehicle().(api.Resurrector); ok {
+			return v.WakeUp()
+		}
+		return nil
+
+	case supervisionToggle:
+		if err := lp.charger.Enable(false); err != nil {
+			return err
+		}
+		lp.recoveryRestore = action
+		return nil
+
+	case supervisionRelay:
+		if err := lp.relayS(false); err != nil {
+			return err
+		}
+		lp.recoveryRestore = action
+		return nil
+
+	default:
+		return fmt.Errorf("invalid action: %s", action)
+	}
+}
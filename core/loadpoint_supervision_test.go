@@ -0,0 +1,186 @@
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	evbus "github.com/asaskevich/EventBus"
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/push"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSupervisionStall(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	clck := clock.NewMock()
+	charger := api.NewMockCharger(ctrl)
+	pushChan := make(chan push.Event, 1)
+
+	relay := make(chan bool, 2)
+
+	lp := &Loadpoint{
+		log:           util.NewLogger("foo"),
+		clock:         clck,
+		bus:           evbus.New(),
+		charger:       charger,
+		pushChan:      pushChan,
+		sessionEnergy: NewEnergyMetrics(),
+		status:        api.StatusB,
+		enabled:       true,
+		relayS: func(b bool) error {
+			relay <- b
+			return nil
+		},
+		Supervision: SupervisionConfig{
+			Stall:   10 * time.Minute,
+			Retry:   5 * time.Minute,
+			Actions: []string{supervisionToggle, supervisionRelay},
+		},
+	}
+
+	// stall starts
+	lp.supervise(api.ModeNow)
+	assert.Equal(t, 0, lp.recoveryAction)
+
+	// not in fast mode
+	clck.Add(10 * time.Minute)
+	lp.supervise(api.ModePV)
+	assert.True(t, lp.stallStart.IsZero())
+
+	lp.supervise(api.ModeNow)
+	clck.Add(10 * time.Minute)
+
+	// toggle
+	charger.EXPECT().Enable(false).Return(nil)
+	lp.supervise(api.ModeNow)
+	assert.Equal(t, 1, lp.recoveryAction)
+	ctrl.Finish()
+
+	// charger stays disabled for toggle delay
+	lp.supervise(api.ModeNow)
+	clck.Add(supervisionToggleDelay)
+	charger.EXPECT().Enable(true).Return(nil)
+	lp.supervise(api.ModeNow)
+	ctrl.Finish()
+
+	// waiting for action to take effect
+	clck.Add(time.Minute)
+	lp.supervise(api.ModeNow)
+	assert.Equal(t, 1, lp.recoveryAction)
+
+	// power-cycle
+	clck.Add(4 * time.Minute)
+	lp.supervise(api.ModeNow)
+	assert.False(t, <-relay)
+	lp.supervise(api.ModeNow)
+	assert.Len(t, relay, 0)
+	clck.Add(supervisionRelayDelay)
+	lp.supervise(api.ModeNow)
+	assert.True(t, <-relay)
+
+	// recovery failed
+	clck.Add(5 * time.Minute)
+	lp.supervise(api.ModeNow)
+	assert.True(t, lp.chargerFault)
+	assert.Equal(t, push.Event{Event: evChargerFault}, <-pushChan)
+
+	// fault is reported once
+	clck.Add(5 * time.Minute)
+	lp.supervise(api.ModeNow)
+	assert.Len(t, pushChan, 0)
+
+	// charging resumed
+	lp.chargePower = 11e3
+	lp.supervise(api.ModeNow)
+	assert.False(t, lp.chargerFault)
+	assert.Equal(t, 0, lp.recoveryAction)
+}
+
+func TestSupervisionRelayFailure(t *testing.T) {
+	clck := clock.NewMock()
+	pushChan := make(chan push.Event, 1)
+
+	var relayErr error
+
+	lp := &Loadpoint{
+		log:           util.NewLogger("foo"),
+		clock:         clck,
+		bus:           evbus.New(),
+		pushChan:      pushChan,
+		sessionEnergy: NewEnergyMetrics(),
+		status:        api.StatusB,
+		enabled:       true,
+		relayS: func(b bool) error {
+			if b {
+				return relayErr
+			}
+			return nil
+		},
+		Supervision: SupervisionConfig{
+			Stall:   10 * time.Minute,
+			Retry:   5 * time.Minute,
+			Actions: []string{supervisionRelay},
+		},
+	}
+
+	lp.supervise(api.ModeNow)
+	clck.Add(10 * time.Minute)
+
+	// power-cycle
+	lp.supervise(api.ModeNow)
+	assert.Equal(t, supervisionRelay, lp.recoveryRestore)
+
+	// relay fails to switch on
+	relayErr = errors.New("relay")
+	clck.Add(supervisionRelayDelay)
+	lp.supervise(api.ModeNow)
+	assert.True(t, lp.chargerFault)
+	assert.Equal(t, push.Event{Event: evChargerFault}, <-pushChan)
+
+	// retried, fault reported once
+	clck.Add(time.Minute)
+	lp.supervise(api.ModeNow)
+	assert.Equal(t, supervisionRelay, lp.recoveryRestore)
+	assert.Len(t, pushChan, 0)
+
+	// relay switched on
+	relayErr = nil
+	clck.Add(time.Minute)
+	lp.supervise(api.ModeNow)
+	assert.Empty(t, lp.recoveryRestore)
+	assert.False(t, lp.chargerFault)
+}
+
+func TestSupervisionFlaps(t *testing.T) {
+	clck := clock.NewMock()
+
+	lp := &Loadpoint{
+		log:   util.NewLogger("foo"),
+		clock: clck,
+		Supervision: SupervisionConfig{
+			Flaps:  3,
+			Window: 10 * time.Minute,
+		},
+	}
+
+	for range 2 {
+		lp.recordStatusFlap()
+		clck.Add(time.Minute)
+	}
+	assert.False(t, lp.statusFlapping(clck.Now()))
+
+	lp.recordStatusFlap()
+	assert.True(t, lp.statusFlapping(clck.Now()))
+
+	// oldest status change leaves the window
+	clck.Add(9 * time.Minute)
+	assert.False(t, lp.statusFlapping(clck.Now()))
+	assert.Len(t, lp.statusFlaps, 3)
+
+	lp.recordStatusFlap()
+	assert.Len(t, lp.statusFlaps, 3)
+}
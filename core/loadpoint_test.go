@@ -1,12 +1,14 @@
 package core
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	evbus "github.com/asaskevich/EventBus"
 	"github.com/benbjohnson/clock"
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
 	"github.com/evcc-io/evcc/core/soc"
 	"github.com/evcc-io/evcc/push"
 	"github.com/evcc-io/evcc/util"
@@ -338,7 +340,7 @@ func TestPVHysteresis(t *testing.T) {
 				// charger.EXPECT().Enabled().Return(tc.enabled, nil)
 
 				lp.enabled = tc.enabled
-				current := lp.pvMaxCurrent(api.ModePV, se.site, false, false)
+				current := lp.pvMaxCurrent(api.ModePV, se.site, false, false, false)
 
 				if current != se.current {
 					t.Errorf("step %d: wanted %.1f, got %.1f", step, se.current, current)
@@ -350,6 +352,203 @@ func TestPVHysteresis(t *testing.T) {
 	}
 }
 
+func TestRampCurrent(t *testing.T) {
+	clck := clock.NewMock()
+	ctrl := gomock.NewController(t)
+
+	lp := &Loadpoint{
+		log:              util.NewLogger("foo"),
+		clock:            clck,
+		charger:          api.NewMockCharger(ctrl),
+		minCurrent:       minA,
+		maxCurrent:       maxA,
+		enabled:          true,
+		chargeCurrent:    minA,
+		chargeCurrentSet: clck.Now(),
+		RampRate:         2,
+	}
+
+	tc := []struct {
+		delay           time.Duration
+		target, current float64
+	}{
+		{0, maxA, minA},
+		{time.Minute, maxA, minA + 2},
+		{2 * time.Minute, maxA, minA + 4},
+		{10 * time.Minute, maxA, maxA},
+		{time.Minute, 0, 0},       // disabling is not ramped
+		{time.Minute, minA, minA}, // within rate
+	}
+
+	for _, tc := range tc {
+		clck.Set(lp.chargeCurrentSet.Add(tc.delay))
+		assert.Equal(t, tc.current, lp.rampCurrent(tc.target), tc)
+	}
+}
+
+type lockCharger struct {
+	*api.MockCharger
+	unlocked int
+}
+
+func (c *lockCharger) Lock() error {
+	c.unlocked--
+	return nil
+}
+
+func (c *lockCharger) Unlock() error {
+	c.unlocked++
+	return nil
+}
+
+func TestUnlockAtLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	charger := &lockCharger{MockCharger: api.NewMockCharger(ctrl)}
+
+	lp := &Loadpoint{
+		log:           util.NewLogger("foo"),
+		charger:       charger,
+		status:        api.StatusC,
+		UnlockAtLimit: true,
+	}
+
+	// not while charging
+	lp.unlockAtLimit()
+	assert.Equal(t, 0, charger.unlocked)
+	assert.Error(t, lp.UnlockSocket())
+
+	// once per session
+	lp.status = api.StatusB
+	lp.unlockAtLimit()
+	lp.unlockAtLimit()
+	assert.Equal(t, 1, charger.unlocked)
+
+	// manual unlock always possible
+	assert.NoError(t, lp.UnlockSocket())
+	assert.Equal(t, 2, charger.unlocked)
+
+	// lock re-arms unlock at limit
+	assert.NoError(t, lp.LockSocket())
+	assert.Equal(t, 1, charger.unlocked)
+	assert.False(t, lp.socketUnlocked)
+	lp.unlockAtLimit()
+	assert.Equal(t, 2, charger.unlocked)
+}
+
+func TestPowerLimit(t *testing.T) {
+	Voltage = 230 // V
+	ctrl := gomock.NewController(t)
+	charger := api.NewMockCharger(ctrl)
+
+	lp := &Loadpoint{
+		log:         util.NewLogger("foo"),
+		clock:       clock.NewMock(),
+		bus:         evbus.New(),
+		charger:     charger,
+		wakeUpTimer: NewTimer(),
+		minCurrent:  minA,
+		maxCurrent:  maxA,
+		phases:      1,
+		enabled:     true,
+	}
+
+	// capped to limit
+	lp.SetPowerLimit(2300)
+	charger.EXPECT().MaxCurrent(int64(10)).Return(nil)
+	assert.NoError(t, lp.setLimit(maxA, false))
+
+	// disabled below min current
+	lp.SetPowerLimit(1000)
+	charger.EXPECT().Enable(false).Return(nil)
+	assert.NoError(t, lp.setLimit(maxA, false))
+	assert.False(t, lp.enabled)
+
+	// unlimited
+	lp.SetPowerLimit(0)
+	charger.EXPECT().MaxCurrent(int64(maxA)).Return(nil)
+	charger.EXPECT().Enable(true).Return(nil)
+	assert.NoError(t, lp.setLimit(maxA, false))
+}
+
+type powerCharger struct {
+	*api.MockCharger
+	power float64
+}
+
+func (c *powerCharger) MaxPower(power float64) error {
+	c.power = power
+	return nil
+}
+
+func TestPowerController(t *testing.T) {
+	Voltage = 230 // V
+	ctrl := gomock.NewController(t)
+	charger := &powerCharger{MockCharger: api.NewMockCharger(ctrl)}
+
+	lp := &Loadpoint{
+		log:         util.NewLogger("foo"),
+		clock:       clock.NewMock(),
+		bus:         evbus.New(),
+		charger:     charger,
+		wakeUpTimer: NewTimer(),
+		minCurrent:  minA,
+		maxCurrent:  maxA,
+		phases:      3,
+		enabled:     true,
+	}
+
+	// fractional current converted to power setpoint
+	assert.NoError(t, lp.setLimit(10.5, false))
+	assert.Equal(t, 10.5*230*3, charger.power)
+	assert.Equal(t, 10.5, lp.chargeCurrent)
+}
+
+func TestRemotePower(t *testing.T) {
+	clck := clock.NewMock()
+
+	lp := &Loadpoint{
+		log:   util.NewLogger("foo"),
+		clock: clck,
+		bus:   evbus.New(),
+	}
+
+	lp.RemotePower("foo", 2300)
+	assert.Equal(t, 2300.0, lp.remoteRecommendedPower())
+
+	// ignored while remote disabled
+	lp.RemoteControl("foo", loadpoint.RemoteSoftDisable)
+	assert.Equal(t, 0.0, lp.remoteRecommendedPower())
+	lp.RemoteControl("foo", loadpoint.RemoteEnable)
+
+	// expires unless refreshed
+	clck.Add(remotePowerTimeout)
+	lp.RemotePower("foo", 2300)
+	clck.Add(remotePowerTimeout)
+	assert.Equal(t, 2300.0, lp.remoteRecommendedPower())
+	clck.Add(time.Second)
+	assert.Equal(t, 0.0, lp.remoteRecommendedPower())
+}
+
+func TestMeasuredVoltage(t *testing.T) {
+	Voltage = 230
+	lp := &Loadpoint{
+		log: util.NewLogger("foo"),
+		bus: evbus.New(),
+	}
+
+	// nominal voltage
+	assert.Equal(t, 10.0, lp.powerToCurrent(6900, 3))
+
+	// unconnected phase ignored
+	lp.chargeVoltage = averageVoltage([]float64{240, 240, 0})
+	assert.Equal(t, 240.0, lp.GetVoltage())
+	assert.Equal(t, 10.0, lp.powerToCurrent(7200, 3))
+
+	// implausible voltages fall back to nominal voltage
+	lp.chargeVoltage = averageVoltage([]float64{400, 0, 0})
+	assert.Equal(t, 230.0, lp.GetVoltage())
+}
+
 func TestPVHysteresisForStatusOtherThanC(t *testing.T) {
 	const phases = 3
 
@@ -371,7 +570,7 @@ func TestPVHysteresisForStatusOtherThanC(t *testing.T) {
 
 	// maxCurrent will read enabled state in PV mode
 	sitePower := -float64(phases)*minA*Voltage + 1 // 1W below min power
-	current := lp.pvMaxCurrent(api.ModePV, sitePower, false, false)
+	current := lp.pvMaxCurrent(api.ModePV, sitePower, false, false, false)
 
 	if current != 0 {
 		t.Errorf("PV mode could not disable charger as expected. Expected 0, got %.f", current)
@@ -380,6 +579,38 @@ func TestPVHysteresisForStatusOtherThanC(t *testing.T) {
 	ctrl.Finish()
 }
 
+func TestStrictPV(t *testing.T) {
+	const phases = 3
+
+	clck := clock.NewMock()
+
+	Voltage = 100
+	lp := &Loadpoint{
+		log:            util.NewLogger("foo"),
+		clock:          clck,
+		minCurrent:     minA,
+		maxCurrent:     maxA,
+		phases:         phases,
+		measuredPhases: phases,
+		enabled:        true,
+		status:         api.StatusC,
+		chargeCurrent:  minA,
+		Disable:        ThresholdConfig{Delay: time.Minute},
+		Enable:         ThresholdConfig{Threshold: 500},
+	}
+
+	// 1W below min power pauses immediately without disable delay
+	sitePower := 1.0
+	assert.Equal(t, float64(minA), lp.pvMaxCurrent(api.ModePV, sitePower, false, false, false))
+	assert.Equal(t, 0.0, lp.pvMaxCurrent(api.ModePV, sitePower, false, false, true))
+
+	// positive enable threshold does not enable from grid
+	lp.enabled = false
+	lp.chargeCurrent = 0
+	assert.Equal(t, 0.0, lp.pvMaxCurrent(api.ModePV, 400, false, false, true))
+	assert.True(t, lp.pvTimer.IsZero())
+}
+
 func TestDisableAndEnableAtTargetSoc(t *testing.T) {
 	clock := clock.NewMock()
 	ctrl := gomock.NewController(t)
@@ -757,9 +988,83 @@ func TestPVHysteresisAfterPhaseSwitch(t *testing.T) {
 
 		for step, se := range tc.series {
 			clck.Set(start.Add(se.delay))
-			assert.Equal(t, se.current, lp.pvMaxCurrent(api.ModePV, se.site, false, false), step)
+			assert.Equal(t, se.current, lp.pvMaxCurrent(api.ModePV, se.site, false, false, false), step)
 		}
 
 		ctrl.Finish()
 	}
 }
+
+func TestExternalMode(t *testing.T) {
+	Voltage = 230 // V
+	ctrl := gomock.NewController(t)
+	charger := api.NewMockCharger(ctrl)
+	clck := clock.NewMock()
+
+	lp := &Loadpoint{
+		log:         util.NewLogger("foo"),
+		clock:       clck,
+		bus:         evbus.New(),
+		charger:     charger,
+		wakeUpTimer: NewTimer(),
+		minCurrent:  minA,
+		maxCurrent:  maxA,
+		phases:      1,
+		enabled:     true,
+		External:    ExternalConfig{Timeout: time.Minute, Fallback: api.ModePV},
+	}
+
+	// fallback without setpoint
+	assert.Equal(t, api.ModePV, lp.effectiveExternalMode(api.ModeExternal))
+	assert.True(t, lp.externalExpired)
+
+	// power setpoint
+	lp.SetExternalPower(2300)
+	assert.Equal(t, api.ModeExternal, lp.effectiveExternalMode(api.ModeExternal))
+	assert.False(t, lp.externalExpired)
+
+	charger.EXPECT().MaxCurrent(int64(10)).Return(nil)
+	assert.NoError(t, lp.externalCharging())
+
+	// current setpoint replaces power and is limited to max current
+	lp.SetExternalCurrent(32)
+	assert.Equal(t, 0.0, lp.GetExternalPower())
+
+	charger.EXPECT().MaxCurrent(int64(maxA)).Return(nil)
+	assert.NoError(t, lp.externalCharging())
+
+	// zero disables
+	lp.SetExternalCurrent(0)
+	charger.EXPECT().Enable(false).Return(nil)
+	assert.NoError(t, lp.externalCharging())
+
+	// watchdog
+	clck.Add(time.Minute + time.Second)
+	assert.Equal(t, api.ModePV, lp.effectiveExternalMode(api.ModeExternal))
+	assert.Equal(t, api.ModeNow, lp.effectiveExternalMode(api.ModeNow))
+}
+
+func TestChargerReadInterval(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	charger := api.NewMockCharger(ctrl)
+
+	release := make(chan struct{})
+	defer close(release)
+
+	// hanging charger is called once only
+	charger.EXPECT().Status().DoAndReturn(func() (api.ChargeStatus, error) {
+		<-release
+		return api.StatusB, nil
+	}).Times(1)
+
+	lp := &Loadpoint{
+		log:     util.NewLogger("foo"),
+		charger: charger,
+	}
+	lp.setInterval(10 * time.Millisecond)
+
+	for range 3 {
+		_, err := lp.chargerStatus()
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	}
+}
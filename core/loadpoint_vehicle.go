@@ -56,6 +56,13 @@ func (lp *Loadpoint) identifyVehicle() {
 	// vehicle found or removed
 	lp.setVehicleIdentifier(id)
 
+	if id != "" {
+		lp.updateSession(func(session *session.Session) {
+			session.Identifier = id
+			lp.updateSessionUser(session)
+		})
+	}
+
 	if id != "" {
 		lp.log.DEBUG.Println("charger vehicle id:", id)
 
@@ -130,6 +137,8 @@ func (lp *Loadpoint) setActiveVehicle(v api.Vehicle) {
 			estimate = true
 		}
 		lp.socEstimator = soc.NewEstimator(lp.log, lp.charger, v, estimate)
+		lp.socEstimator.SetTimeout(lp.interval)
+		lp.socEstimator.SetChargeCurve(vehicle.Settings(lp.log, v).GetLearnedChargeCurve())
 
 		lp.publish(keys.VehicleName, vehicle.Settings(lp.log, v).Name())
 
@@ -161,6 +170,7 @@ func (lp *Loadpoint) setActiveVehicle(v api.Vehicle) {
 		}
 
 		lp.session.Vehicle = title
+		lp.updateSessionUser(lp.session)
 	})
 }
 
@@ -174,8 +184,8 @@ func (lp *Loadpoint) wakeUpVehicle() {
 			}
 		}
 	} else {
-		// vehicle
-		if vs, ok := lp.GetVehicle().(api.Resurrector); ok {
+		// vehicle, unless known to be away
+		if vs, ok := lp.GetVehicle().(api.Resurrector); ok && !lp.isVehicleAway(lp.GetVehicle()) {
 			lp.log.DEBUG.Printf("wake-up vehicle, attempts left: %d", lp.wakeUpTimer.wakeupAttemptsLeft)
 			if err := vs.WakeUp(); err != nil {
 				lp.log.ERROR.Printf("wake-up vehicle: %v", err)
@@ -184,6 +194,11 @@ func (lp *Loadpoint) wakeUpVehicle() {
 	}
 }
 
+// isVehicleAway returns true if the vehicle is known to be away from home
+func (lp *Loadpoint) isVehicleAway(v api.Vehicle) bool {
+	return lp.vehicleAway != nil && lp.vehicleAway(v)
+}
+
 // unpublishVehicle resets published vehicle data
 func (lp *Loadpoint) unpublishVehicle() {
 	lp.vehicleSoc = 0
@@ -0,0 +1,93 @@
+package planner
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+// Strategy is the planning strategy applied
+type Strategy string
+
+const (
+	StrategyNone        Strategy = "none"        // nothing to plan
+	StrategyLatestStart Strategy = "latestStart" // without prices, start as late as possible
+	StrategyContinuous  Strategy = "continuous"  // not enough time left for optimizing
+	StrategyDeferred    Strategy = "deferred"    // enough time after the available rates
+	StrategyCheapest    Strategy = "cheapest"    // cheapest slots until target time
+)
+
+// Reason explains why a slot was selected or skipped
+type Reason string
+
+const (
+	ReasonSelected    Reason = "selected"    // slot is part of the plan
+	ReasonExpensive   Reason = "expensive"   // slot is more expensive than all selected slots
+	ReasonNotRequired Reason = "notRequired" // equally cheap later slots are sufficient
+	ReasonDeferred    Reason = "deferred"    // charging happens after the available rates
+)
+
+// Slot is a candidate slot between now and target time
+type Slot struct {
+	api.Rate
+	Reason Reason `json:"reason"`
+}
+
+// Explanation describes how the planner created the plan
+type Explanation struct {
+	TargetTime       time.Time     `json:"targetTime"`
+	RequiredDuration time.Duration `json:"-"`
+	Strategy         Strategy      `json:"strategy"`
+	Constraints      []string      `json:"constraints,omitempty"`
+	Slots            []Slot        `json:"slots,omitempty"`
+	Plan             api.Rates     `json:"plan"`
+}
+
+// constrain adds a constraint the planner hit
+func (e *Explanation) constrain(format string, a ...any) {
+	e.Constraints = append(e.Constraints, fmt.Sprintf(format, a...))
+}
+
+// explainSlots returns the candidate slots between now and target time with the reason for (not) using them
+func explainSlots(rates, plan api.Rates, now, targetTime time.Time) []Slot {
+	maxPrice := math.Inf(-1)
+	for _, slot := range plan {
+		maxPrice = max(maxPrice, slot.Price)
+	}
+
+	var res []Slot
+	for _, r := range rates {
+		if !r.End.After(now) || !r.Start.Before(targetTime) {
+			continue
+		}
+
+		slot := Slot{Rate: r}
+
+		switch {
+		case len(plan) == 0:
+			slot.Reason = ReasonDeferred
+		case overlaps(r, plan):
+			slot.Reason = ReasonSelected
+		case r.Price > maxPrice:
+			slot.Reason = ReasonExpensive
+		default:
+			slot.Reason = ReasonNotRequired
+		}
+
+		res = append(res, slot)
+	}
+
+	return res
+}
+
+// overlaps returns true if the rate overlaps any slot of the plan
+func overlaps(r api.Rate, plan api.Rates) bool {
+	for _, slot := range plan {
+		if slot.Start.Before(r.End) && slot.End.After(r.Start) {
+			return true
+		}
+	}
+	return false
+}
@@ -136,15 +136,29 @@ func (t *Planner) continuousPlan(rates api.Rates, start, end time.Time) api.Rate
 	return res
 }
 
+// Plan creates a lowest-cost plan for the required duration until target time
 func (t *Planner) Plan(requiredDuration time.Duration, targetTime time.Time) (api.Rates, error) {
+	exp, err := t.Explain(requiredDuration, targetTime)
+	return exp.Plan, err
+}
+
+// Explain creates a lowest-cost plan for the required duration until target time and explains the planner's reasoning
+func (t *Planner) Explain(requiredDuration time.Duration, targetTime time.Time) (Explanation, error) {
+	exp := Explanation{
+		TargetTime:       targetTime,
+		RequiredDuration: requiredDuration,
+		Strategy:         StrategyNone,
+	}
+
 	if t == nil || requiredDuration <= 0 {
-		return nil, nil
+		return exp, nil
 	}
 
 	latestStart := targetTime.Add(-requiredDuration)
 	if latestStart.Before(t.clock.Now()) {
 		latestStart = t.clock.Now()
 		targetTime = latestStart.Add(requiredDuration)
+		exp.constrain("target time cannot be met, charging until %v", targetTime.Round(time.Second).Local())
 	}
 
 	// simplePlan only considers time, but not cost
@@ -157,19 +171,33 @@ func (t *Planner) Plan(requiredDuration time.Duration, targetTime time.Time) (ap
 
 	// target charging without tariff or late start
 	if t.tariff == nil {
-		return simplePlan, nil
+		exp.Strategy = StrategyLatestStart
+		exp.constrain("no tariff, starting as late as possible")
+		exp.Plan = simplePlan
+		return exp, nil
 	}
 
 	rates, err := t.tariff.Rates()
 
 	// treat like normal target charging if we don't have rates
 	if len(rates) == 0 || err != nil {
-		return simplePlan, err
+		exp.Strategy = StrategyLatestStart
+		exp.constrain("no rates available, starting as late as possible")
+		exp.Plan = simplePlan
+		return exp, err
 	}
 
+	// candidate slots in time order
+	candidates := slices.Clone(rates)
+	candidates.Sort()
+
 	// consume remaining time
 	if t.clock.Until(targetTime) <= requiredDuration {
-		return t.continuousPlan(rates, latestStart, targetTime), nil
+		exp.Strategy = StrategyContinuous
+		exp.constrain("remaining time does not exceed required duration, charging continuously")
+		exp.Plan = t.continuousPlan(rates, latestStart, targetTime)
+		exp.Slots = explainSlots(candidates, exp.Plan, t.clock.Now(), targetTime)
+		return exp, nil
 	}
 
 	// rates are by default sorted by date, oldest to newest
@@ -183,12 +211,16 @@ func (t *Planner) Plan(requiredDuration time.Duration, targetTime time.Time) (ap
 		// there is enough time for charging after end of current rates
 		durationAfterRates := targetTime.Sub(last)
 		if durationAfterRates >= requiredDuration {
-			return nil, nil
+			exp.Strategy = StrategyDeferred
+			exp.constrain("target time beyond available rates, charging is planned once rates for %v become available", last.Round(time.Second).Local())
+			exp.Slots = explainSlots(candidates, nil, t.clock.Now(), targetTime)
+			return exp, nil
 		}
 
 		// need to use some of the available slots
 		t.log.DEBUG.Printf("target time beyond available slots- reducing plan horizon from %v to %v",
 			requiredDuration.Round(time.Second), durationAfterRates.Round(time.Second))
+		exp.constrain("target time beyond available rates, reducing required duration by %v", durationAfterRates.Round(time.Second))
 
 		targetTime = last
 		requiredDuration -= durationAfterRates
@@ -199,5 +231,9 @@ func (t *Planner) Plan(requiredDuration time.Duration, targetTime time.Time) (ap
 	// sort plan by time
 	plan.Sort()
 
-	return plan, nil
+	exp.Strategy = StrategyCheapest
+	exp.Plan = plan
+	exp.Slots = explainSlots(candidates, plan, t.clock.Now(), targetTime)
+
+	return exp, nil
 }
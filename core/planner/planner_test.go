@@ -8,6 +8,7 @@ import (
 	"github.com/benbjohnson/clock"
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/util"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -307,3 +308,41 @@ func TestContinuousPlanOutsideRates(t *testing.T) {
 	// 3-slot plan
 	assert.Len(t, plan, 1)
 }
+
+func TestExplain(t *testing.T) {
+	clock := clock.NewMock()
+	ctrl := gomock.NewController(t)
+
+	trf := api.NewMockTariff(ctrl)
+	trf.EXPECT().Rates().AnyTimes().Return(rates([]float64{20, 10, 10, 80, 40}, clock.Now(), time.Hour), nil)
+
+	p := &Planner{
+		log:    util.NewLogger("foo"),
+		clock:  clock,
+		tariff: trf,
+	}
+
+	exp, err := p.Explain(time.Hour, clock.Now().Add(4*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, StrategyCheapest, exp.Strategy)
+	assert.Empty(t, exp.Constraints)
+
+	// later of equally cheap slots is preferred, last slot is after target time
+	reasons := lo.Map(exp.Slots, func(s Slot, _ int) Reason { return s.Reason })
+	assert.Equal(t, []Reason{ReasonExpensive, ReasonNotRequired, ReasonSelected, ReasonExpensive}, reasons)
+	assert.Equal(t, exp.Plan[0].Start, clock.Now().Add(2*time.Hour))
+
+	// not enough time left
+	exp, err = p.Explain(2*time.Hour, clock.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, StrategyContinuous, exp.Strategy)
+	assert.Len(t, exp.Constraints, 2)
+	assert.Equal(t, 2*time.Hour, Duration(exp.Plan))
+
+	// enough time after known rates
+	exp, err = p.Explain(time.Hour, clock.Now().Add(8*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, StrategyDeferred, exp.Strategy)
+	assert.Empty(t, exp.Plan)
+	assert.Equal(t, ReasonDeferred, exp.Slots[0].Reason)
+}
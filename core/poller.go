@@ -0,0 +1,98 @@
+package core
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+const (
+	pollTimeout = 5 * time.Second // wait time for a device reading before falling back to the last known value
+	pollMaxAge  = time.Minute     // max age of the last known value
+	pollWorkers = 4               // max concurrent device readings
+)
+
+// poller reads a device value in the background. Readings exceeding the timeout continue
+// in the background while the last known value is used, so that a single slow device
+// does not delay the control loop. Readings are cancelled after the deadline.
+type poller[T any] struct {
+	mu       sync.Mutex
+	read     func(context.Context) (T, error)
+	timeout  time.Duration
+	deadline time.Duration
+	maxAge   time.Duration
+	pending  *pollResult[T] // reading in progress
+	val      T              // last known value
+	updated  time.Time      // last known value timestamp
+}
+
+type pollResult[T any] struct {
+	done chan struct{}
+	val  T
+	err  error
+}
+
+// newPoller creates a poller whose readings are cancelled after the deadline, usually the control loop interval
+func newPoller[T any](read func(context.Context) (T, error), deadline time.Duration) *poller[T] {
+	if deadline <= 0 {
+		deadline = pollMaxAge
+	}
+
+	return &poller[T]{
+		read:     read,
+		timeout:  min(pollTimeout, deadline),
+		deadline: deadline,
+		maxAge:   pollMaxAge,
+	}
+}
+
+// Get starts a reading unless one is in progress and waits for its result up to the timeout.
+// On timeout, the last known value is returned unless it has expired.
+func (p *poller[T]) Get() (T, error) {
+	p.mu.Lock()
+	res := p.pending
+	if res == nil {
+		res = &pollResult[T]{done: make(chan struct{})}
+		p.pending = res
+		go p.poll(res)
+	}
+	p.mu.Unlock()
+
+	timer := time.NewTimer(p.timeout)
+	defer timer.Stop()
+
+	select {
+	case <-res.done:
+		return res.val, res.err
+	case <-timer.C:
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.updated.IsZero() || time.Since(p.updated) > p.maxAge {
+		var zero T
+		return zero, api.ErrTimeout
+	}
+
+	return p.val, nil
+}
+
+func (p *poller[T]) poll(res *pollResult[T]) {
+	ctx, cancel := context.WithTimeout(context.Background(), p.deadline)
+	defer cancel()
+
+	res.val, res.err = p.read(ctx)
+
+	p.mu.Lock()
+	if res.err == nil {
+		p.val = res.val
+		p.updated = time.Now()
+	}
+	p.pending = nil
+	p.mu.Unlock()
+
+	close(res.done)
+}
@@ -0,0 +1,83 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoller(t *testing.T) {
+	var reads atomic.Int32
+	block := make(chan struct{})
+	val := 1.0
+
+	p := newPoller(func(context.Context) (float64, error) {
+		reads.Add(1)
+		<-block
+		return val, nil
+	}, 0)
+	p.timeout = 10 * time.Millisecond
+
+	// no last known value
+	_, err := p.Get()
+	assert.ErrorIs(t, err, api.ErrTimeout)
+
+	// reading in progress is not restarted
+	_, err = p.Get()
+	assert.ErrorIs(t, err, api.ErrTimeout)
+	assert.Equal(t, int32(1), reads.Load())
+
+	// reading completes
+	block <- struct{}{}
+	require.Eventually(t, func() bool {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		return p.pending == nil
+	}, time.Second, time.Millisecond)
+
+	// slow reading returns last known value
+	val = 2
+	res, err := p.Get()
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, res)
+
+	block <- struct{}{}
+	require.Eventually(t, func() bool {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		return p.pending == nil && p.val == 2
+	}, time.Second, time.Millisecond)
+
+	// last known value expired
+	p.maxAge = 0
+	_, err = p.Get()
+	assert.ErrorIs(t, err, api.ErrTimeout)
+	close(block)
+}
+
+func TestPollerError(t *testing.T) {
+	p := newPoller(func(context.Context) (float64, error) {
+		return 0, errors.New("foo")
+	}, 0)
+
+	_, err := p.Get()
+	assert.EqualError(t, err, "foo")
+	assert.True(t, p.updated.IsZero())
+}
+
+func TestPollerDeadline(t *testing.T) {
+	p := newPoller(func(ctx context.Context) (float64, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	}, 10*time.Millisecond)
+	p.timeout = time.Second
+
+	_, err := p.Get()
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,10 @@
+package session
+
+// Backend is the session persistence backend used by the loadpoint
+type Backend interface {
+	New(meter float64) *Session
+	Persist(session interface{})
+	ClosePendingSessionsInHistory(chargeMeterTotal float64) error
+}
+
+var _ Backend = (*DB)(nil)
@@ -0,0 +1,42 @@
+package session
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+// AddConsumption sets the distance driven after each session and the resulting consumption in kWh/100km.
+// The distance is the odometer difference to the vehicle's following session, taken from all sessions
+// since filtered sessions may lack their successor. Sessions without odometer readings are skipped.
+func (t Sessions) AddConsumption(all Sessions) {
+	all = slices.Clone(all)
+	slices.SortStableFunc(all, func(a, b Session) int {
+		return cmp.Or(cmp.Compare(a.Vehicle, b.Vehicle), a.Created.Compare(b.Created))
+	})
+
+	distance := make(map[uint]float64)
+	for i := 1; i < len(all); i++ {
+		prev, s := all[i-1], all[i]
+		if s.Vehicle == "" || s.Vehicle != prev.Vehicle || prev.Odometer == nil || s.Odometer == nil {
+			continue
+		}
+
+		if d := *s.Odometer - *prev.Odometer; d > 0 {
+			distance[prev.ID] = d
+		}
+	}
+
+	for i, s := range t {
+		d, ok := distance[s.ID]
+		if !ok {
+			continue
+		}
+
+		d = math.Round(d*10) / 10
+		consumption := math.Round(s.ChargedEnergy/d*1e4) / 100
+
+		t[i].Distance = &d
+		t[i].Consumption = &consumption
+	}
+}
@@ -0,0 +1,43 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsumption(t *testing.T) {
+	odo := func(f float64) *float64 { return &f }
+
+	oct := time.Date(2026, 10, 5, 12, 0, 0, 0, time.Local)
+
+	all := Sessions{
+		{ID: 1, Created: oct, Vehicle: "ev", Odometer: odo(1000), ChargedEnergy: 20},
+		{ID: 2, Created: oct.Add(time.Hour), Vehicle: "other", Odometer: odo(50000), ChargedEnergy: 10},
+		{ID: 3, Created: oct.AddDate(0, 0, 1), Vehicle: "ev", Odometer: odo(1100), ChargedEnergy: 15},
+		{ID: 4, Created: oct.AddDate(0, 0, 2), Vehicle: "ev", ChargedEnergy: 5},
+		{ID: 5, Created: oct.AddDate(0, 0, 3), Vehicle: "ev", Odometer: odo(1300), ChargedEnergy: 30},
+		{ID: 6, Created: oct.AddDate(0, 0, 4), Vehicle: "ev", Odometer: odo(1450), ChargedEnergy: 10},
+	}
+
+	// filtered result lacking the successor sessions
+	res := Sessions{all[0], all[1], all[4]}
+	res.AddConsumption(all)
+
+	require.NotNil(t, res[0].Distance)
+	assert.Equal(t, 100.0, *res[0].Distance)
+	assert.Equal(t, 20.0, *res[0].Consumption)
+
+	assert.Nil(t, res[1].Distance, "no successor")
+	assert.Equal(t, 150.0, *res[2].Distance)
+
+	// session without odometer breaks the chain
+	all.AddConsumption(all)
+	assert.Nil(t, all[2].Distance)
+
+	assert.Equal(t, VehicleReports{
+		{Vehicle: "ev", Month: "2026-10", Distance: 250, ChargedEnergy: 50, Consumption: 20},
+	}, all.VehicleReports())
+}
@@ -1,25 +1,45 @@
 package session
 
 import (
+	"sync"
+	"time"
+
+	serverdb "github.com/evcc-io/evcc/server/db"
 	"github.com/evcc-io/evcc/util"
 	"gorm.io/gorm"
 )
 
+// Retention is the session retention, 0 keeps sessions forever
+var Retention time.Duration
+
+// Prune deletes sessions older than the retention period
+func Prune(db *gorm.DB, now time.Time) error {
+	if Retention <= 0 {
+		return nil
+	}
+	return db.Where("created < ?", now.Add(-Retention)).Delete(new(Session)).Error
+}
+
 // DB is a SQL database storage service
 type DB struct {
 	log  *util.Logger
 	db   *gorm.DB
 	name string
+
+	mu       sync.Mutex
+	migrated bool
+	pending  []interface{} // sessions queued while the database is unavailable
 }
 
-// NewStore creates a session store
+// NewStore creates a session store. If migration fails the store is still returned and migration is retried on next write.
 func NewStore(name string, db *gorm.DB) (*DB, error) {
 	err := db.AutoMigrate(new(Session))
 
 	sessiondb := &DB{
-		log:  util.NewLogger("db"),
-		db:   db,
-		name: name,
+		log:      util.NewLogger("db"),
+		db:       db,
+		name:     name,
+		migrated: err == nil,
 	}
 
 	return sessiondb, err
@@ -38,11 +58,54 @@ func (s *DB) New(meter float64) *Session {
 	return &t
 }
 
-// Persist creates or updates a transaction in the database
+// Persist creates or updates a transaction in the database.
+// Notes and tags are edited by the user and never overwritten by the loadpoint.
+// Failed writes are queued and retried with the next write.
 func (s *DB) Persist(session interface{}) {
-	if err := s.db.Save(session).Error; err != nil {
-		s.log.ERROR.Printf("persist: %v", err)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.queue(session)
+
+	err := s.flush()
+	if err != nil {
+		s.log.ERROR.Printf("persist: %v (%d queued)", err, len(s.pending))
 	}
+
+	serverdb.SetError(err)
+}
+
+// queue adds the session to the pending writes, replacing previous writes of the same session
+func (s *DB) queue(session interface{}) {
+	// only pointers are comparable, sessions passed by value are always queued
+	if _, ok := session.(*Session); ok {
+		for _, q := range s.pending {
+			if q == session {
+				return
+			}
+		}
+	}
+
+	s.pending = append(s.pending, session)
+}
+
+// flush writes the pending sessions in order until the first failure
+func (s *DB) flush() error {
+	if !s.migrated {
+		if err := s.db.AutoMigrate(new(Session)); err != nil {
+			return err
+		}
+		s.migrated = true
+	}
+
+	for len(s.pending) > 0 {
+		if err := s.db.Omit("notes", "tags").Save(s.pending[0]).Error; err != nil {
+			return err
+		}
+		s.pending = s.pending[1:]
+	}
+
+	return nil
 }
 
 // Return sessions
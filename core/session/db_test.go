@@ -0,0 +1,57 @@
+package session
+
+import (
+	"testing"
+
+	serverdb "github.com/evcc-io/evcc/server/db"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPersistKeepsNotes(t *testing.T) {
+	db, err := serverdb.New("sqlite", ":memory:")
+	require.NoError(t, err)
+	s, err := NewStore("lp", db)
+	require.NoError(t, err)
+
+	// notes and tags edited while charging are preserved
+	sess := &Session{Loadpoint: "lp", ChargedEnergy: 1}
+	s.Persist(sess)
+	require.NotZero(t, sess.ID)
+	require.NoError(t, db.Model(&Session{ID: sess.ID}).Updates(map[string]any{"notes": "hi", "tags": `["a"]`}).Error)
+	sess.ChargedEnergy = 2
+	s.Persist(sess)
+	var res Session
+	require.NoError(t, db.First(&res, sess.ID).Error)
+	require.Equal(t, "hi", res.Notes)
+	require.Equal(t, []string{"a"}, res.Tags)
+	require.Equal(t, 2.0, res.ChargedEnergy)
+}
+
+func TestPersistQueuesFailedWrites(t *testing.T) {
+	db, err := serverdb.New("sqlite", ":memory:")
+	require.NoError(t, err)
+	s, err := NewStore("lp", db)
+	require.NoError(t, err)
+
+	// database unavailable
+	require.NoError(t, db.Migrator().DropTable(new(Session)))
+
+	first := &Session{Loadpoint: "lp", ChargedEnergy: 1}
+	s.Persist(first)
+	s.Persist(first)
+	require.Len(t, s.pending, 1)
+	require.Error(t, serverdb.Err())
+
+	// database available again
+	require.NoError(t, db.AutoMigrate(new(Session)))
+
+	second := &Session{Loadpoint: "lp", ChargedEnergy: 2}
+	s.Persist(second)
+	require.Empty(t, s.pending)
+	require.NoError(t, serverdb.Err())
+
+	var res Sessions
+	require.NoError(t, db.Order("id").Find(&res).Error)
+	require.Len(t, res, 2)
+	require.Equal(t, 1.0, res[0].ChargedEnergy)
+}
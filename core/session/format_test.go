@@ -15,3 +15,8 @@ func TestFormatValue(t *testing.T) {
 	assert.Equal(t, "1.234", formatValue(mp, f, 3))
 	assert.Equal(t, "1.234", formatValue(mp, &f, 3))
 }
+
+func TestNormalizeTags(t *testing.T) {
+	assert.Equal(t, []string{"business", "road-trip"}, NormalizeTags([]string{" Business", "road-trip", "business", ""}))
+	assert.Equal(t, "business, guest", formatValue(message.NewPrinter(language.Make("en")), []string{"business", "guest"}, 0))
+}
@@ -0,0 +1,146 @@
+package session
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/evcc-io/evcc/core/soc"
+)
+
+const (
+	healthMinSocDelta = 30 // minimum soc range of a session for a reliable capacity estimate in %
+	healthMinSocEnd   = 80 // minimum soc a session must end at for a reliable capacity estimate in %
+)
+
+// CapacityEstimate is the usable battery capacity estimated from a single session
+type CapacityEstimate struct {
+	ID       uint      `json:"id"`
+	Created  time.Time `json:"created"`
+	SocStart float64   `json:"socStart"`
+	SocEnd   float64   `json:"socEnd"`
+	Energy   float64   `json:"energy"`   // kWh
+	Capacity float64   `json:"capacity"` // kWh
+	Reliable bool      `json:"reliable"`
+}
+
+// VehicleHealth is a vehicle's battery capacity trend
+type VehicleHealth struct {
+	Vehicle     string             `json:"vehicle"`
+	Capacity    float64            `json:"capacity,omitempty"`    // nominal capacity in kWh
+	Estimated   float64            `json:"estimated,omitempty"`   // current usable capacity according to trend in kWh
+	Health      float64            `json:"health,omitempty"`      // estimated capacity relative to nominal capacity in %
+	Degradation float64            `json:"degradation,omitempty"` // capacity loss per year in kWh
+	Estimates   []CapacityEstimate `json:"estimates"`
+}
+
+// capacityEstimate estimates the usable capacity from the session's soc range and charged energy
+func (s Session) capacityEstimate() (CapacityEstimate, bool) {
+	if s.SocStart == nil || s.SocEnd == nil || *s.SocEnd <= *s.SocStart || s.ChargedEnergy <= 0 {
+		return CapacityEstimate{}, false
+	}
+
+	delta := *s.SocEnd - *s.SocStart
+
+	return CapacityEstimate{
+		ID:       s.ID,
+		Created:  s.Created,
+		SocStart: *s.SocStart,
+		SocEnd:   *s.SocEnd,
+		Energy:   s.ChargedEnergy,
+		Capacity: math.Round(s.ChargedEnergy*soc.ChargeEfficiency/delta*1e4) / 100,
+		Reliable: delta >= healthMinSocDelta && *s.SocEnd >= healthMinSocEnd,
+	}, true
+}
+
+// VehicleHealth estimates the usable battery capacity per vehicle from sessions with soc readings.
+// Estimates from sessions with small soc range or not ending near full are flagged unreliable
+// and excluded from the trend. Nominal capacity and health are not set.
+func (t Sessions) VehicleHealth() []VehicleHealth {
+	idx := make(map[string]int)
+	var res []VehicleHealth
+
+	for _, s := range t {
+		if s.Vehicle == "" {
+			continue
+		}
+
+		e, ok := s.capacityEstimate()
+		if !ok {
+			continue
+		}
+
+		i, ok := idx[s.Vehicle]
+		if !ok {
+			i = len(res)
+			idx[s.Vehicle] = i
+			res = append(res, VehicleHealth{Vehicle: s.Vehicle})
+		}
+
+		res[i].Estimates = append(res[i].Estimates, e)
+	}
+
+	for i := range res {
+		h := &res[i]
+
+		slices.SortFunc(h.Estimates, func(a, b CapacityEstimate) int {
+			return a.Created.Compare(b.Created)
+		})
+
+		h.Estimated, h.Degradation = capacityTrend(h.Estimates)
+	}
+
+	slices.SortFunc(res, func(a, b VehicleHealth) int {
+		return cmp.Compare(a.Vehicle, b.Vehicle)
+	})
+
+	return res
+}
+
+// SetCapacity sets the nominal capacity and derives the battery health
+func (h *VehicleHealth) SetCapacity(capacity float64) {
+	h.Capacity = capacity
+	if capacity > 0 && h.Estimated > 0 {
+		h.Health = math.Round(h.Estimated / capacity * 100)
+	}
+}
+
+// capacityTrend fits a linear regression over the reliable estimates and returns the
+// capacity at the latest estimate and the capacity loss per year
+func capacityTrend(estimates []CapacityEstimate) (float64, float64) {
+	const year = float64(365 * 24 * time.Hour)
+
+	var reliable []CapacityEstimate
+	for _, e := range estimates {
+		if e.Reliable {
+			reliable = append(reliable, e)
+		}
+	}
+
+	if len(reliable) == 0 {
+		return 0, 0
+	}
+
+	t0 := reliable[0].Created
+	n := float64(len(reliable))
+
+	var sx, sy, sxx, sxy float64
+	for _, e := range reliable {
+		x := float64(e.Created.Sub(t0)) / year
+		sx += x
+		sy += e.Capacity
+		sxx += x * x
+		sxy += x * e.Capacity
+	}
+
+	var slope float64
+	if d := n*sxx - sx*sx; d > 0 {
+		slope = (n*sxy - sx*sy) / d
+	}
+
+	last := float64(reliable[len(reliable)-1].Created.Sub(t0)) / year
+	estimated := (sy-slope*sx)/n + slope*last
+
+	return math.Round(estimated*10) / 10, math.Round(-slope*10) / 10
+}
@@ -0,0 +1,40 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVehicleHealth(t *testing.T) {
+	f := func(f float64) *float64 { return &f }
+
+	t0 := time.Date(2025, 10, 1, 12, 0, 0, 0, time.Local)
+
+	res := Sessions{
+		// 50kWh * 0.9 / 60% = 75kWh
+		{ID: 1, Created: t0, Vehicle: "ev", SocStart: f(20), SocEnd: f(80), ChargedEnergy: 50},
+		// small soc range
+		{ID: 2, Created: t0.AddDate(0, 3, 0), Vehicle: "ev", SocStart: f(60), SocEnd: f(80), ChargedEnergy: 20},
+		// 40kWh * 0.9 / 50% = 72kWh
+		{ID: 3, Created: t0.AddDate(1, 0, 0), Vehicle: "ev", SocStart: f(50), SocEnd: f(100), ChargedEnergy: 40},
+		{ID: 4, Created: t0, Vehicle: "ev", ChargedEnergy: 10},
+		{ID: 5, Created: t0, SocStart: f(0), SocEnd: f(100), ChargedEnergy: 10},
+	}.VehicleHealth()
+
+	require.Len(t, res, 1)
+
+	h := res[0]
+	require.Len(t, h.Estimates, 3)
+	assert.Equal(t, 75.0, h.Estimates[0].Capacity)
+	assert.False(t, h.Estimates[1].Reliable)
+	assert.Equal(t, 72.0, h.Estimates[2].Capacity)
+
+	assert.Equal(t, 72.0, h.Estimated)
+	assert.InDelta(t, 3.0, h.Degradation, 0.1)
+
+	h.SetCapacity(80)
+	assert.Equal(t, 90.0, h.Health)
+}
@@ -0,0 +1,268 @@
+package session
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ImportFormat describes the columns of a csv session export
+type ImportFormat struct {
+	Created, Finished    []string // session start and end
+	Loadpoint, Vehicle   []string
+	Identifier, User     []string
+	ChargedEnergy        []string // kWh
+	MeterStart, MeterEnd []string // kWh
+	Price                []string
+}
+
+// ImportFormats are the supported csv formats. Column names are matched case-insensitive.
+var ImportFormats = map[string]ImportFormat{
+	// evcc's own export and simple spreadsheets
+	"generic": {
+		Created:       []string{"created", "start", "started"},
+		Finished:      []string{"finished", "end", "stop", "ended"},
+		Loadpoint:     []string{"loadpoint", "charger"},
+		Vehicle:       []string{"vehicle", "car"},
+		Identifier:    []string{"identifier", "rfid", "id"},
+		User:          []string{"user"},
+		ChargedEnergy: []string{"charged energy (kwh)", "chargedenergy", "energy", "kwh"},
+		MeterStart:    []string{"meter start (kwh)", "meterstart"},
+		MeterEnd:      []string{"meter stop (kwh)", "meterstop", "meterend"},
+		Price:         []string{"price", "cost"},
+	},
+	// go-e Charger app and cloud export
+	"goe": {
+		Created:       []string{"start"},
+		Finished:      []string{"end"},
+		Identifier:    []string{"id chip uid", "id chip"},
+		User:          []string{"id chip name"},
+		ChargedEnergy: []string{"energy", "energy (kwh)", "energy [kwh]"},
+		MeterStart:    []string{"eto start", "meter reading start"},
+		MeterEnd:      []string{"eto end", "meter reading end"},
+	},
+	// Easee cloud charging history export
+	"easee": {
+		Created:       []string{"from", "start", "carconnected"},
+		Finished:      []string{"to", "end", "cardisconnected"},
+		Loadpoint:     []string{"charger name", "charger", "charger id"},
+		Identifier:    []string{"authtoken", "auth token", "rfid"},
+		User:          []string{"user", "username"},
+		ChargedEnergy: []string{"energy (kwh)", "kwh", "energy"},
+		Price:         []string{"cost", "price", "total cost"},
+	},
+}
+
+var importTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	"02.01.2006 15:04:05",
+	"02.01.2006 15:04",
+	"01/02/2006 15:04:05",
+	"01/02/2006 15:04",
+}
+
+func parseImportTime(s string) (time.Time, error) {
+	for _, layout := range importTimeLayouts {
+		if ts, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return ts, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time: %s", s)
+}
+
+// parseImportFloat parses numbers with decimal point or comma and optional unit suffix
+func parseImportFloat(s string) (float64, error) {
+	s = strings.TrimSpace(strings.TrimRight(strings.TrimSpace(s), "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ€$ "))
+	if strings.Contains(s, ",") {
+		if strings.Contains(s, ".") {
+			// thousands separator
+			s = strings.ReplaceAll(s, ".", "")
+		}
+		s = strings.ReplaceAll(s, ",", ".")
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+// Import parses sessions from a csv export in the given format.
+// Sessions without loadpoint are assigned to the given loadpoint.
+func Import(r io.Reader, format, loadpoint string) (Sessions, error) {
+	f, ok := ImportFormats[strings.ToLower(format)]
+	if !ok {
+		return nil, fmt.Errorf("invalid format: %s", format)
+	}
+
+	br := bufio.NewReader(r)
+
+	// skip utf-8 bom
+	if b, err := br.Peek(3); err == nil && bytes.Equal(b, []byte{0xEF, 0xBB, 0xBF}) {
+		_, _ = br.Discard(3)
+	}
+
+	// detect separator from header line
+	header, err := br.Peek(4096)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, bufio.ErrBufferFull) {
+		return nil, err
+	}
+	line, _, _ := bytes.Cut(header, []byte("\n"))
+
+	cr := csv.NewReader(br)
+	cr.FieldsPerRecord = -1
+	if bytes.Count(line, []byte(";")) > bytes.Count(line, []byte(",")) {
+		cr.Comma = ';'
+	}
+
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, errors.New("missing header")
+	}
+
+	columns := make(map[string]int)
+	for i, col := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(col))] = i
+	}
+
+	column := func(aliases []string) int {
+		for _, a := range aliases {
+			if i, ok := columns[a]; ok {
+				return i
+			}
+		}
+		return -1
+	}
+
+	created, energy := column(f.Created), column(f.ChargedEnergy)
+	if created < 0 || energy < 0 {
+		return nil, errors.New("missing start or energy column")
+	}
+
+	var (
+		finished   = column(f.Finished)
+		lp         = column(f.Loadpoint)
+		vehicle    = column(f.Vehicle)
+		identifier = column(f.Identifier)
+		user       = column(f.User)
+		meterStart = column(f.MeterStart)
+		meterEnd   = column(f.MeterEnd)
+		price      = column(f.Price)
+	)
+
+	var res Sessions
+
+	for n, rec := range records[1:] {
+		value := func(i int) string {
+			if i < 0 || i >= len(rec) {
+				return ""
+			}
+			return strings.TrimSpace(rec[i])
+		}
+
+		optional := func(i int) (*float64, error) {
+			s := value(i)
+			if s == "" {
+				return nil, nil
+			}
+			f, err := parseImportFloat(s)
+			return &f, err
+		}
+
+		// skip empty and summary lines
+		if value(created) == "" {
+			continue
+		}
+
+		s := Session{
+			Loadpoint:  value(lp),
+			Vehicle:    value(vehicle),
+			Identifier: value(identifier),
+			User:       value(user),
+		}
+
+		if s.Loadpoint == "" {
+			s.Loadpoint = loadpoint
+		}
+
+		var err error
+		if s.Created, err = parseImportTime(value(created)); err != nil {
+			return nil, fmt.Errorf("line %d: %w", n+2, err)
+		}
+
+		if v := value(finished); v != "" {
+			if s.Finished, err = parseImportTime(v); err != nil {
+				return nil, fmt.Errorf("line %d: %w", n+2, err)
+			}
+		}
+
+		if s.ChargedEnergy, err = parseImportFloat(value(energy)); err != nil {
+			return nil, fmt.Errorf("line %d: invalid energy: %w", n+2, err)
+		}
+
+		if s.MeterStart, err = optional(meterStart); err != nil {
+			return nil, fmt.Errorf("line %d: invalid meter: %w", n+2, err)
+		}
+
+		if s.MeterStop, err = optional(meterEnd); err != nil {
+			return nil, fmt.Errorf("line %d: invalid meter: %w", n+2, err)
+		}
+
+		if s.Price, err = optional(price); err != nil {
+			return nil, fmt.Errorf("line %d: invalid price: %w", n+2, err)
+		}
+
+		if s.Price != nil && s.ChargedEnergy > 0 {
+			perKWh := *s.Price / s.ChargedEnergy
+			s.PricePerKWh = &perKWh
+		}
+
+		res = append(res, s)
+	}
+
+	return res, nil
+}
+
+// Store adds the sessions to the database, skipping sessions already present for the same loadpoint and start time.
+// It returns the number of sessions added.
+func Store(db *gorm.DB, sessions Sessions) (int, error) {
+	if err := db.AutoMigrate(new(Session)); err != nil {
+		return 0, err
+	}
+
+	var added int
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for _, s := range sessions {
+			var count int64
+			if err := tx.Model(new(Session)).Where("loadpoint = ? AND created = ?", s.Loadpoint, s.Created).Count(&count).Error; err != nil {
+				return err
+			}
+
+			if count > 0 {
+				continue
+			}
+
+			if err := tx.Create(&s).Error; err != nil {
+				return err
+			}
+
+			added++
+		}
+
+		return nil
+	})
+
+	return added, err
+}
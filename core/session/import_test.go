@@ -0,0 +1,73 @@
+package session
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	serverdb "github.com/evcc-io/evcc/server/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportGoe(t *testing.T) {
+	csv := "\xEF\xBB\xBFSession Number;ID Chip UID;ID Chip Name;Start;End;Energy;Eto start;Eto end\n" +
+		"1;1234;Alice;01.03.2024 18:00:00;01.03.2024 22:30:00;12,5;1000,0;1012,5\n" +
+		";;;;;12,5;;\n"
+
+	res, err := Import(strings.NewReader(csv), "goe", "Garage")
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+
+	s := res[0]
+	assert.Equal(t, "Garage", s.Loadpoint)
+	assert.Equal(t, "1234", s.Identifier)
+	assert.Equal(t, "Alice", s.User)
+	assert.Equal(t, time.Date(2024, 3, 1, 18, 0, 0, 0, time.Local), s.Created)
+	assert.Equal(t, time.Date(2024, 3, 1, 22, 30, 0, 0, time.Local), s.Finished)
+	assert.Equal(t, 12.5, s.ChargedEnergy)
+	assert.Equal(t, 1012.5, *s.MeterStop)
+}
+
+func TestImportEasee(t *testing.T) {
+	csv := "Charger Name,From,To,Energy (kWh),Cost\n" +
+		"Carport,2024-03-01T18:00:00Z,2024-03-01T20:00:00Z,10.0,3.0 EUR\n"
+
+	res, err := Import(strings.NewReader(csv), "easee", "Garage")
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+
+	assert.Equal(t, "Carport", res[0].Loadpoint)
+	assert.Equal(t, 3.0, *res[0].Price)
+	assert.InDelta(t, 0.3, *res[0].PricePerKWh, 1e-6)
+}
+
+func TestImportErrors(t *testing.T) {
+	_, err := Import(strings.NewReader("start,energy\n"), "foo", "")
+	assert.Error(t, err)
+
+	_, err = Import(strings.NewReader("start,vehicle\n"), "generic", "")
+	assert.Error(t, err)
+
+	_, err = Import(strings.NewReader("start,energy\nyesterday,1\n"), "generic", "")
+	assert.Error(t, err)
+}
+
+func TestImportStore(t *testing.T) {
+	db, err := serverdb.New("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	sessions := Sessions{
+		{Loadpoint: "lp", Created: time.Date(2024, 3, 1, 18, 0, 0, 0, time.UTC), ChargedEnergy: 1},
+		{Loadpoint: "lp", Created: time.Date(2024, 3, 2, 18, 0, 0, 0, time.UTC), ChargedEnergy: 2},
+	}
+
+	added, err := Store(db, sessions)
+	require.NoError(t, err)
+	assert.Equal(t, 2, added)
+
+	// repeated import skips existing sessions
+	added, err = Store(db, sessions)
+	require.NoError(t, err)
+	assert.Equal(t, 0, added)
+}
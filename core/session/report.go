@@ -0,0 +1,166 @@
+package session
+
+import (
+	"cmp"
+	"context"
+	"encoding/csv"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+// UserReport is a user's charging summary for one month
+type UserReport struct {
+	User          string  `json:"user"`
+	Month         string  `json:"month"` // YYYY-MM
+	Sessions      int     `json:"sessions"`
+	ChargedEnergy float64 `json:"chargedEnergy"` // kWh
+	Price         float64 `json:"price"`
+}
+
+// UserReports is a list of monthly user summaries
+type UserReports []UserReport
+
+var _ api.CsvWriter = (*UserReports)(nil)
+
+// UserReports aggregates charged energy and cost per user and month. Sessions without user are reported with empty user.
+func (t Sessions) UserReports() UserReports {
+	type key struct{ user, month string }
+
+	idx := make(map[key]int)
+	var res UserReports
+
+	for _, s := range t {
+		k := key{s.User, s.Created.Local().Format("2006-01")}
+
+		i, ok := idx[k]
+		if !ok {
+			i = len(res)
+			idx[k] = i
+			res = append(res, UserReport{User: k.user, Month: k.month})
+		}
+
+		res[i].Sessions++
+		res[i].ChargedEnergy += s.ChargedEnergy
+		if s.Price != nil {
+			res[i].Price += *s.Price
+		}
+	}
+
+	slices.SortFunc(res, func(a, b UserReport) int {
+		return cmp.Or(cmp.Compare(b.Month, a.Month), cmp.Compare(a.User, b.User))
+	})
+
+	return res
+}
+
+// WriteCsv implements the api.CsvWriter interface
+func (t *UserReports) WriteCsv(ctx context.Context, w io.Writer) error {
+	if _, err := w.Write([]byte{0xEF, 0xBB, 0xBF}); err != nil {
+		return err
+	}
+
+	ww := csv.NewWriter(w)
+
+	if err := ww.Write([]string{"User", "Month", "Sessions", "Charged Energy (kWh)", "Price"}); err != nil {
+		return err
+	}
+
+	for _, r := range *t {
+		if err := ww.Write([]string{
+			r.User,
+			r.Month,
+			strconv.Itoa(r.Sessions),
+			strconv.FormatFloat(r.ChargedEnergy, 'f', 3, 64),
+			strconv.FormatFloat(r.Price, 'f', 2, 64),
+		}); err != nil {
+			return err
+		}
+	}
+
+	ww.Flush()
+
+	return ww.Error()
+}
+
+// VehicleReport is a vehicle's driving consumption for one month
+type VehicleReport struct {
+	Vehicle       string  `json:"vehicle"`
+	Month         string  `json:"month"`         // YYYY-MM
+	Distance      float64 `json:"distance"`      // km
+	ChargedEnergy float64 `json:"chargedEnergy"` // kWh
+	Consumption   float64 `json:"consumption"`   // kWh/100km
+}
+
+// VehicleReports is a list of monthly vehicle consumption summaries
+type VehicleReports []VehicleReport
+
+var _ api.CsvWriter = (*VehicleReports)(nil)
+
+// VehicleReports aggregates distance and charged energy per vehicle and month. Only sessions with known distance (see AddConsumption) are included.
+func (t Sessions) VehicleReports() VehicleReports {
+	type key struct{ vehicle, month string }
+
+	idx := make(map[key]int)
+	var res VehicleReports
+
+	for _, s := range t {
+		if s.Distance == nil {
+			continue
+		}
+
+		k := key{s.Vehicle, s.Created.Local().Format("2006-01")}
+
+		i, ok := idx[k]
+		if !ok {
+			i = len(res)
+			idx[k] = i
+			res = append(res, VehicleReport{Vehicle: k.vehicle, Month: k.month})
+		}
+
+		res[i].Distance += *s.Distance
+		res[i].ChargedEnergy += s.ChargedEnergy
+	}
+
+	for i, r := range res {
+		res[i].Consumption = math.Round(r.ChargedEnergy/r.Distance*1e4) / 100
+	}
+
+	slices.SortFunc(res, func(a, b VehicleReport) int {
+		return cmp.Or(cmp.Compare(b.Month, a.Month), cmp.Compare(a.Vehicle, b.Vehicle))
+	})
+
+	return res
+}
+
+// WriteCsv implements the api.CsvWriter interface
+func (t *VehicleReports) WriteCsv(ctx context.Context, w io.Writer) error {
+	if _, err := w.Write([]byte{0xEF, 0xBB, 0xBF}); err != nil {
+		return err
+	}
+
+	ww := csv.NewWriter(w)
+
+	if err := ww.Write([]string{"Vehicle", "Month", "Distance (km)", "Charged Energy (kWh)", "Consumption (kWh/100km)"}); err != nil {
+		return err
+	}
+
+	for _, r := range *t {
+		if err := ww.Write([]string{
+			r.Vehicle,
+			r.Month,
+			strconv.FormatFloat(r.Distance, 'f', 1, 64),
+			strconv.FormatFloat(r.ChargedEnergy, 'f', 3, 64),
+			strconv.FormatFloat(r.Consumption, 'f', 2, 64),
+		}); err != nil {
+			return err
+		}
+	}
+
+	ww.Flush()
+
+	return ww.Error()
+}
@@ -0,0 +1,28 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserReports(t *testing.T) {
+	price := func(f float64) *float64 { return &f }
+
+	oct := time.Date(2026, 10, 5, 12, 0, 0, 0, time.Local)
+	sep := time.Date(2026, 9, 5, 12, 0, 0, 0, time.Local)
+
+	res := Sessions{
+		{Created: oct, User: "bob", ChargedEnergy: 10, Price: price(3)},
+		{Created: oct, User: "alice", ChargedEnergy: 5, Price: price(1)},
+		{Created: oct, User: "bob", ChargedEnergy: 20},
+		{Created: sep, User: "bob", ChargedEnergy: 7, Price: price(2)},
+	}.UserReports()
+
+	assert.Equal(t, UserReports{
+		{User: "alice", Month: "2026-10", Sessions: 1, ChargedEnergy: 5, Price: 1},
+		{User: "bob", Month: "2026-10", Sessions: 2, ChargedEnergy: 30, Price: 3},
+		{User: "bob", Month: "2026-09", Sessions: 1, ChargedEnergy: 7, Price: 2},
+	}, res)
+}
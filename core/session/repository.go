@@ -0,0 +1,97 @@
+package session
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// Filter selects sessions by tag, year and month. Empty fields match all sessions.
+type Filter struct {
+	Tag, Year, Month string
+}
+
+// Repository is the session backend used by the api for querying and editing sessions
+type Repository interface {
+	Find(filter Filter) (Sessions, error)
+	Odometers() (Sessions, error)
+	WithSoc() (Sessions, error)
+	Import(sessions Sessions) (int, error)
+	Update(id string, updates map[string]any) error
+	Delete(id string) error
+}
+
+var _ Repository = (*Repo)(nil)
+
+// Repo is the SQL database session repository
+type Repo struct {
+	db *gorm.DB
+}
+
+// NewRepository creates a session repository
+func NewRepository(db *gorm.DB) *Repo {
+	return &Repo{db: db}
+}
+
+// minEnergy excludes sessions without relevant charged energy
+const minEnergy = "charged_kwh>=0.05"
+
+// Find returns the sessions matching the filter, newest first
+func (r *Repo) Find(filter Filter) (Sessions, error) {
+	var (
+		res  Sessions
+		cond = []string{minEnergy}
+		args []any
+	)
+
+	push := func(field string, val any) {
+		cond = append(cond, field)
+		args = append(args, val)
+	}
+
+	if filter.Tag != "" {
+		push("tags LIKE ?", `%"`+filter.Tag+`"%`)
+	}
+
+	// TODO support other databases than Sqlite
+	if filter.Year != "" {
+		push("STRFTIME('%Y', created) LIKE ?", filter.Year)
+
+		if filter.Month != "" {
+			push("STRFTIME('%m', created) LIKE ?", filter.Month)
+		}
+	}
+
+	err := r.db.Where(strings.Join(cond, " AND "), args...).Order("created DESC").Find(&res).Error
+
+	return res, err
+}
+
+// Odometers returns the odometer readings of all sessions for calculating consumption
+func (r *Repo) Odometers() (Sessions, error) {
+	var res Sessions
+	err := r.db.Select("id", "created", "vehicle", "odometer").Where(minEnergy).Find(&res).Error
+	return res, err
+}
+
+// WithSoc returns all sessions with start and end soc, oldest first
+func (r *Repo) WithSoc() (Sessions, error) {
+	var res Sessions
+	err := r.db.Where("soc_start IS NOT NULL AND soc_end IS NOT NULL").Order("created").Find(&res).Error
+	return res, err
+}
+
+// Import stores imported sessions skipping existing ones
+func (r *Repo) Import(sessions Sessions) (int, error) {
+	return Store(r.db, sessions)
+}
+
+// Update updates the given columns of a session
+func (r *Repo) Update(id string, updates map[string]any) error {
+	return r.db.Table("sessions").Where("id = ?", id).Updates(updates).Error
+}
+
+// Delete removes a session
+func (r *Repo) Delete(id string) error {
+	return r.db.Delete(new(Session), id).Error
+}
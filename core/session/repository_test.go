@@ -0,0 +1,48 @@
+package session
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	serverdb "github.com/evcc-io/evcc/server/db"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository(t *testing.T) {
+	db, err := serverdb.New("sqlite", ":memory:")
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(new(Session)))
+
+	repo := NewRepository(db)
+
+	added, err := repo.Import(Sessions{
+		{Loadpoint: "lp", Created: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), ChargedEnergy: 1, Tags: []string{"work"}},
+		{Loadpoint: "lp", Created: time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC), ChargedEnergy: 2},
+		{Loadpoint: "lp", Created: time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC), ChargedEnergy: 0.01},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, added)
+
+	res, err := repo.Find(Filter{})
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	require.Equal(t, 2.0, res[0].ChargedEnergy)
+
+	res, err = repo.Find(Filter{Year: "2024", Month: "01"})
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+
+	res, err = repo.Find(Filter{Tag: "work"})
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+
+	id := strconv.Itoa(int(res[0].ID))
+	require.NoError(t, repo.Update(id, map[string]any{"notes": "hi"}))
+	require.NoError(t, repo.Delete(strconv.Itoa(int(res[0].ID)+1)))
+
+	res, err = repo.Find(Filter{})
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	require.Equal(t, "hi", res[0].Notes)
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"slices"
 	"strings"
 	"time"
 
@@ -26,15 +27,29 @@ type Session struct {
 	Loadpoint       string         `json:"loadpoint"`
 	Identifier      string         `json:"identifier"`
 	Vehicle         string         `json:"vehicle"`
+	User            string         `json:"user"`
+	Guest           bool           `json:"guest"`
 	Odometer        *float64       `json:"odometer" format:"int"`
+	SocStart        *float64       `json:"socStart" csv:"SoC Start (%)" gorm:"column:soc_start" format:"int"`
+	SocEnd          *float64       `json:"socEnd" csv:"SoC End (%)" gorm:"column:soc_end" format:"int"`
 	MeterStart      *float64       `json:"meterStart" csv:"Meter Start (kWh)" gorm:"column:meter_start_kwh"`
 	MeterStop       *float64       `json:"meterStop" csv:"Meter Stop (kWh)" gorm:"column:meter_end_kwh"`
 	ChargedEnergy   float64        `json:"chargedEnergy" csv:"Charged Energy (kWh)" gorm:"column:charged_kwh"`
+	MinSocEnergy    float64        `json:"minSocEnergy" csv:"Min SoC Energy (kWh)" gorm:"column:min_soc_kwh"`
 	ChargeDuration  *time.Duration `json:"chargeDuration" csv:"Charge Duration" gorm:"column:charge_duration"`
 	SolarPercentage *float64       `json:"solarPercentage" csv:"Solar (%)" gorm:"column:solar_percentage"`
+	SolarOnly       bool           `json:"solarOnly" csv:"Solar Only" gorm:"column:solar_only"`
 	Price           *float64       `json:"price" csv:"Price" gorm:"column:price"`
 	PricePerKWh     *float64       `json:"pricePerKWh" csv:"Price/kWh" gorm:"column:price_per_kwh"`
+	ReferencePrice  *float64       `json:"referencePricePerKWh" csv:"Reference Price/kWh" gorm:"column:reference_price_per_kwh"` // grid price while charging for savings
 	Co2PerKWh       *float64       `json:"co2PerKWh" csv:"CO2/kWh (gCO2eq)" gorm:"column:co2_per_kwh"`
+	SignedStart     string         `json:"signedStart" csv:"Signed Meter Start" gorm:"column:signed_start"`
+	SignedStop      string         `json:"signedStop" csv:"Signed Meter Stop" gorm:"column:signed_stop"`
+	Notes           string         `json:"notes" csv:"Notes" gorm:"column:notes"`
+	Tags            []string       `json:"tags" csv:"Tags" gorm:"column:tags;serializer:json"`
+	TariffSlots     []TariffSlot   `json:"tariffSlots" csv:"-" gorm:"column:tariff_slots;serializer:json"`
+	Distance        *float64       `json:"distance" csv:"Distance (km)" gorm:"-" format:"int"`
+	Consumption     *float64       `json:"consumption" csv:"Consumption (kWh/100km)" gorm:"-"`
 }
 
 // Sessions is a list of sessions
@@ -87,6 +102,8 @@ func formatValue(mp *message.Printer, value any, digits int) string {
 			return ""
 		}
 		return v.Local().Format("2006-01-02 15:04:05")
+	case []string:
+		return strings.Join(v, ", ")
 	default:
 		return fmt.Sprintf("%v", value)
 	}
@@ -151,3 +168,14 @@ func (t *Sessions) WriteCsv(ctx context.Context, w io.Writer) error {
 
 	return ww.Error()
 }
+
+// NormalizeTags returns the unique, lower-case tags
+func NormalizeTags(tags []string) []string {
+	res := make([]string, 0, len(tags))
+	for _, t := range tags {
+		if t = strings.ToLower(strings.TrimSpace(t)); t != "" && !slices.Contains(res, t) {
+			res = append(res, t)
+		}
+	}
+	return res
+}
@@ -0,0 +1,84 @@
+package session
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+// SlotDuration is the duration of a tariff slot. Slots are aligned to the wall clock and subdivide hourly tariffs.
+const SlotDuration = 15 * time.Minute
+
+// TariffSlot is the energy charged during a tariff slot and its effective price and emissions
+type TariffSlot struct {
+	Start       time.Time `json:"start"`
+	Energy      float64   `json:"energy"`                // kWh
+	Price       *float64  `json:"price,omitempty"`       // total cost
+	PricePerKWh *float64  `json:"pricePerKWh,omitempty"` // average price per kWh
+	Co2PerKWh   *float64  `json:"co2PerKWh,omitempty"`   // average emissions (gCO2eq/kWh)
+}
+
+// TariffSlotReport is a session's tariff slot
+type TariffSlotReport struct {
+	Session uint `json:"session"`
+	TariffSlot
+}
+
+// TariffSlotReports is a list of tariff slots of multiple sessions
+type TariffSlotReports []TariffSlotReport
+
+var _ api.CsvWriter = (*TariffSlotReports)(nil)
+
+// TariffSlots returns the tariff slots of all sessions
+func (t Sessions) TariffSlots() TariffSlotReports {
+	res := make(TariffSlotReports, 0)
+
+	for _, s := range t {
+		for _, slot := range s.TariffSlots {
+			res = append(res, TariffSlotReport{Session: s.ID, TariffSlot: slot})
+		}
+	}
+
+	return res
+}
+
+func formatOptional(f *float64, digits int) string {
+	if f == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*f, 'f', digits, 64)
+}
+
+// WriteCsv implements the api.CsvWriter interface
+func (t *TariffSlotReports) WriteCsv(ctx context.Context, w io.Writer) error {
+	if _, err := w.Write([]byte{0xEF, 0xBB, 0xBF}); err != nil {
+		return err
+	}
+
+	ww := csv.NewWriter(w)
+
+	if err := ww.Write([]string{"Session", "Start", "Charged Energy (kWh)", "Price", "Price/kWh", "CO2/kWh (gCO2eq)"}); err != nil {
+		return err
+	}
+
+	for _, r := range *t {
+		if err := ww.Write([]string{
+			strconv.FormatUint(uint64(r.Session), 10),
+			r.Start.Local().Format("2006-01-02 15:04:05"),
+			strconv.FormatFloat(r.Energy, 'f', 3, 64),
+			formatOptional(r.Price, 4),
+			formatOptional(r.PricePerKWh, 4),
+			formatOptional(r.Co2PerKWh, 1),
+		}); err != nil {
+			return err
+		}
+	}
+
+	ww.Flush()
+
+	return ww.Error()
+}
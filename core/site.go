@@ -4,22 +4,25 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"math"
 	"strings"
 	"sync"
 	"testing"
 	"time"
 
-	"github.com/cenkalti/backoff/v4"
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/cmd/shutdown"
 	"github.com/evcc-io/evcc/core/coordinator"
+	"github.com/evcc-io/evcc/core/fleet"
+	"github.com/evcc-io/evcc/core/history"
 	"github.com/evcc-io/evcc/core/keys"
 	"github.com/evcc-io/evcc/core/loadpoint"
 	"github.com/evcc-io/evcc/core/planner"
 	"github.com/evcc-io/evcc/core/prioritizer"
 	"github.com/evcc-io/evcc/core/session"
 	"github.com/evcc-io/evcc/core/soc"
+	"github.com/evcc-io/evcc/core/statistics"
 	"github.com/evcc-io/evcc/core/vehicle"
 	"github.com/evcc-io/evcc/push"
 	"github.com/evcc-io/evcc/server/db"
@@ -37,6 +40,7 @@ const standbyPower = 10 // consider less than 10W as charger in standby
 type updater interface {
 	loadpoint.API
 	Update(availablePower float64, autoCharge, batteryBuffered, batteryStart bool, greenShare float64, effectivePrice, effectiveCo2 *float64)
+	setReferencePrice(price *float64)
 }
 
 // meterMeasurement is used as slice element for publishing structured data
@@ -65,32 +69,87 @@ type Site struct {
 	log *util.Logger
 
 	// configuration
-	Title                             string       `mapstructure:"title"`         // UI title
-	Voltage                           float64      `mapstructure:"voltage"`       // Operating voltage. 230V for Germany.
-	ResidualPower                     float64      `mapstructure:"residualPower"` // PV meter only: household usage. Grid meter: household safety margin
-	Meters                            MetersConfig // Meter references
-	MaxGridSupplyWhileBatteryCharging float64      `mapstructure:"maxGridSupplyWhileBatteryCharging"` // ignore battery charging if AC consumption is above this value
+	Title                             string            `mapstructure:"title"`         // UI title
+	Voltage                           float64           `mapstructure:"voltage"`       // Operating voltage. 230V for Germany.
+	ResidualPower                     float64           `mapstructure:"residualPower"` // PV meter only: household usage. Grid meter: household safety margin
+	Meters                            MetersConfig      // Meter references
+	MaxGridSupplyWhileBatteryCharging float64           `mapstructure:"maxGridSupplyWhileBatteryCharging"` // ignore battery charging if AC consumption is above this value
+	PhaseSurplus                      bool              `mapstructure:"phaseSurplus"`                      // limit pv charging to the surplus of each phase
+	Presence                          PresenceConfig    `mapstructure:"presence"`                          // occupancy detection
+	ExportLimit                       ExportLimitConfig `mapstructure:"exportLimit"`                       // grid feed-in limitation
+	OffGrid                           OffGridConfig     `mapstructure:"offGrid"`                           // generator or island operation
+	PeakShaving                       PeakShavingConfig `mapstructure:"peakShaving"`                       // demand charge avoidance
+	Arbitration                       ArbitrationConfig `mapstructure:"arbitration"`                       // battery vs vehicle pv surplus priority
+	PvForecast                        PvForecastConfig  `mapstructure:"pvForecast"`                        // weather compensated pv surplus
+	GridSignal                        GridSignalConfig  `mapstructure:"gridSignal"`                        // external curtailment signals
+	Circuits                          []CircuitConfig   `mapstructure:"circuits"`                          // circuit limits and monitoring
+	Consumers                         []ConsumerConfig  `mapstructure:"consumers"`                         // named household consumers
 
 	// meters
 	gridMeter     api.Meter   // Grid usage meter
 	pvMeters      []api.Meter // PV generation meters
 	batteryMeters []api.Meter // Battery charging meters
 	auxMeters     []api.Meter // Auxiliary meters
+	consumers     []*consumer // Named household consumers
+	meterPollers  map[api.Meter]*poller[float64]
+	interval      time.Duration              // control loop interval
+	meterPowers   map[api.Meter]powerReading // meter power readings of the current control loop
 
 	// battery settings
 	prioritySoc             float64 // prefer battery up to this Soc
 	bufferSoc               float64 // continue charging on battery above this Soc
 	bufferStartSoc          float64 // start charging on battery above this Soc
 	batteryDischargeControl bool    // prevent battery discharge for fast and planned charging
+	arbitrationPriority     bool    // battery has priority over vehicles based on forecast prices
+
+	// savings
+	referencePrice float64 // reference grid price, grid tariff average if 0
+
+	// presence
+	presenceG       []func() (bool, error)    // presence sources
+	presenceUpdated time.Time                 // last presence update
+	away            bool                      // nobody home
+	homePolicies    map[*Loadpoint]homePolicy // loadpoint settings before leaving
+	arrivals        []*arrival                // vehicles tracked for arrival
+	arrivalUpdated  time.Time                 // last arrival update
+
+	// off-grid
+	offGridG func() (bool, error) // off-grid signal
+	offGrid  bool                 // running from generator or in island mode
+
+	// grid signal
+	gridSignal gridSignal // external curtailment state
+
+	// demand response
+	demandResponse demandResponse // demand response event state
+
+	// circuits
+	circuits []*circuit        // circuit hierarchy
+	pushChan chan<- push.Event // circuit alerts
+
+	// peak shaving
+	peak peakDemand // demand interval tracking
+
+	// fleet
+	fleet            *fleet.Fleet       // reservations and queue
+	fleetAssignments []fleet.Assignment // free loadpoints offered to vehicles
+
+	// export limitation
+	curtailS     func(float64) error // inverter power limit setter
+	curtailLimit float64             // last inverter power limit in %
 
 	loadpoints  []*Loadpoint             // Loadpoints
 	tariffs     *tariff.Tariffs          // Tariffs
 	coordinator *coordinator.Coordinator // Vehicles
 	prioritizer *prioritizer.Prioritizer // Power budgets
 	stats       *Stats                   // Stats
+	history     *history.DB              // Power flow history
+
+	databaseError string // Last database error, empty while persisting
 
 	// cached state
 	gridPower    float64         // Grid power
+	gridCurrents []float64       // Signed grid phase currents, nil if unknown
 	pvPower      float64         // PV power
 	batteryPower float64         // Battery charge power
 	batterySoc   float64         // Battery soc
@@ -127,6 +186,10 @@ func NewSiteFromConfig(
 	site.coordinator = coordinator.New(log, config.Instances(handler.Devices()))
 	handler.Subscribe(site.updateVehicles)
 
+	for _, dev := range handler.Devices() {
+		site.restoreManualSoc(dev)
+	}
+
 	site.prioritizer = prioritizer.New(log)
 	site.stats = NewStats()
 
@@ -139,19 +202,34 @@ func NewSiteFromConfig(
 
 	tariff := site.GetTariff(PlannerTariff)
 
+	// database failures are not fatal, writes fail until the database is available
+	if db.Instance != nil {
+		var err error
+		if site.history, err = history.NewStore(db.Instance); err != nil {
+			site.log.ERROR.Println("history:", err)
+			db.SetError(err)
+		}
+		if site.stats.aggregator, err = statistics.New(db.Instance); err != nil {
+			site.log.ERROR.Println("statistics:", err)
+			db.SetError(err)
+		}
+	}
+
 	// give loadpoints access to vehicles and database
 	for _, lp := range loadpoints {
 		lp.coordinator = coordinator.NewAdapter(lp, site.coordinator)
 		lp.planner = planner.New(lp.log, tariff)
+		lp.solarForecast = site.GetTariff(SolarTariff)
+		lp.vehicleAway = site.isVehicleAway
 
 		if db.Instance != nil {
 			var err error
 			if lp.db, err = session.NewStore(lp.Title(), db.Instance); err != nil {
-				return nil, err
-			}
-			// Fix any dangling history
-			if err := lp.db.ClosePendingSessionsInHistory(lp.chargeMeterTotal()); err != nil {
-				return nil, err
+				lp.log.ERROR.Println("sessions:", err)
+				db.SetError(err)
+			} else if err := lp.db.ClosePendingSessionsInHistory(lp.chargeMeterTotal()); err != nil {
+				// Fix any dangling history
+				lp.log.ERROR.Println("sessions:", err)
 			}
 
 			// NOTE: this requires stopSession to respect async access
@@ -202,11 +280,51 @@ func NewSiteFromConfig(
 		site.auxMeters = append(site.auxMeters, dev.Instance())
 	}
 
+	if err := site.configureConsumers(); err != nil {
+		return nil, err
+	}
+
 	// configure meter from references
 	if site.gridMeter == nil && len(site.pvMeters) == 0 {
 		return nil, errors.New("missing either grid or pv meter")
 	}
 
+	if err := site.configureOffGrid(); err != nil {
+		return nil, err
+	}
+
+	if err := site.configurePeakShaving(); err != nil {
+		return nil, err
+	}
+
+	if err := site.configureArbitration(); err != nil {
+		return nil, err
+	}
+
+	if err := site.configurePvForecast(); err != nil {
+		return nil, err
+	}
+
+	if err := site.configureGridSignal(); err != nil {
+		return nil, err
+	}
+
+	if err := site.configureExportLimit(); err != nil {
+		return nil, err
+	}
+
+	if err := site.configurePresence(); err != nil {
+		return nil, err
+	}
+
+	if err := site.configureCircuits(); err != nil {
+		return nil, err
+	}
+
+	if err := site.configureArrivals(); err != nil {
+		return nil, err
+	}
+
 	// revert battery mode on shutdown
 	shutdown.Register(func() {
 		if mode := site.GetBatteryMode(); batteryModeModified(mode) {
@@ -224,6 +342,7 @@ func NewSite() *Site {
 	lp := &Site{
 		log:          util.NewLogger("site"),
 		publishCache: make(map[string]any),
+		fleet:        fleet.New(),
 		Voltage:      230, // V
 	}
 
@@ -272,11 +391,17 @@ func (site *Site) restoreSettings() error {
 			return err
 		}
 	}
+	if v, err := settings.Float(keys.ReferencePrice); err == nil {
+		if err := site.SetReferencePrice(v); err != nil {
+			return err
+		}
+	}
 	if v, err := settings.Bool(keys.BatteryDischargeControl); err == nil {
 		if err := site.SetBatteryDischargeControl(v); err != nil {
 			return err
 		}
 	}
+	site.restoreFleet()
 	return nil
 }
 
@@ -413,7 +538,7 @@ func (site *Site) updatePvMeters() {
 
 	for i, meter := range site.pvMeters {
 		// pv power
-		power, err := backoff.RetryWithData(meter.CurrentPower, bo())
+		power, err := site.meterPower(meter)
 		if err == nil {
 			// ignore negative values which represent self-consumption
 			site.pvPower += max(0, power)
@@ -461,7 +586,7 @@ func (site *Site) updateBatteryMeters() error {
 	mm := make([]batteryMeasurement, len(site.batteryMeters))
 
 	for i, meter := range site.batteryMeters {
-		power, err := backoff.RetryWithData(meter.CurrentPower, bo())
+		power, err := site.meterPower(meter)
 		if err == nil {
 			site.batteryPower += power
 			if len(site.batteryMeters) > 1 {
@@ -542,7 +667,7 @@ func (site *Site) updateGridMeter() error {
 		return nil
 	}
 
-	res, err := backoff.RetryWithData(site.gridMeter.CurrentPower, bo())
+	res, err := site.meterPower(site.gridMeter)
 	if err == nil {
 		site.gridPower = res
 		site.log.DEBUG.Printf("grid meter: %.0fW", res)
@@ -553,9 +678,11 @@ func (site *Site) updateGridMeter() error {
 
 	// grid phase powers
 	var p1, p2, p3 float64
+	var signed bool
 	if phaseMeter, ok := site.gridMeter.(api.PhasePowers); ok {
 		p1, p2, p3, err = phaseMeter.Powers()
 		if err == nil {
+			signed = true
 			phases := []float64{p1, p2, p3}
 			site.log.DEBUG.Printf("grid powers: %.0fW", phases)
 			site.publish(keys.GridPowers, phases)
@@ -565,12 +692,18 @@ func (site *Site) updateGridMeter() error {
 	}
 
 	// grid phase currents (signed)
+	site.gridCurrents = nil
 	if phaseMeter, ok := site.gridMeter.(api.PhaseCurrents); ok {
 		i1, i2, i3, err := phaseMeter.Currents()
 		if err == nil {
 			phases := []float64{util.SignFromPower(i1, p1), util.SignFromPower(i2, p2), util.SignFromPower(i3, p3)}
 			site.log.DEBUG.Printf("grid currents: %.3gA", phases)
 			site.publish(keys.GridCurrents, phases)
+
+			// direction is only known if phase powers are available
+			if signed {
+				site.gridCurrents = phases
+			}
 		} else {
 			site.log.ERROR.Printf("grid currents: %v", err)
 		}
@@ -591,7 +724,8 @@ func (site *Site) updateGridMeter() error {
 
 // updateMeter updates and publishes single meter
 func (site *Site) updateMeters() error {
-	// TODO parallelize once modbus supports that
+	site.pollMeters()
+
 	site.updatePvMeters()
 	if err := site.updateBatteryMeters(); err != nil {
 		return err
@@ -634,13 +768,17 @@ func (site *Site) sitePower(totalChargePower, flexiblePower float64) (float64, b
 		defer site.RUnlock()
 
 		// if battery is charging below prioritySoc give it priority
-		if site.batterySoc < site.prioritySoc && batteryPower < 0 {
-			site.log.DEBUG.Printf("battery has priority at soc %.0f%% (< %.0f%%)", site.batterySoc, site.prioritySoc)
+		if prioritySoc := site.effectivePrioritySoc(); site.batterySoc < prioritySoc && batteryPower < 0 {
+			site.log.DEBUG.Printf("battery has priority at soc %.0f%% (< %.0f%%)", site.batterySoc, prioritySoc)
+			batteryPower = 0
+		} else if site.arbitrationPriority && batteryPower < 0 {
+			site.log.DEBUG.Println("battery has priority based on forecast prices")
 			batteryPower = 0
 		} else {
 			// if battery is above bufferSoc allow using it for charging
-			batteryBuffered = site.bufferSoc > 0 && site.batterySoc > site.bufferSoc
-			batteryStart = site.bufferStartSoc > 0 && site.batterySoc > site.bufferStartSoc
+			bufferSoc := site.effectiveBufferSoc()
+			batteryBuffered = bufferSoc > 0 && site.batterySoc > bufferSoc
+			batteryStart = site.bufferStartSoc > 0 && site.batterySoc > site.bufferStartSoc && !site.offGrid
 		}
 	}
 
@@ -652,7 +790,7 @@ func (site *Site) sitePower(totalChargePower, flexiblePower float64) (float64, b
 		mm := make([]meterMeasurement, len(site.auxMeters))
 
 		for i, meter := range site.auxMeters {
-			if power, err := meter.CurrentPower(); err == nil {
+			if power, err := site.meterPower(meter); err == nil {
 				auxPower += power
 				mm[i].Power = power
 				site.log.DEBUG.Printf("aux power %d: %.0fW", i+1, power)
@@ -669,6 +807,12 @@ func (site *Site) sitePower(totalChargePower, flexiblePower float64) (float64, b
 		site.publish(keys.Aux, mm)
 	}
 
+	// deduct consumers yielding to charging
+	if auxPower := site.updateConsumers(); auxPower != 0 {
+		site.log.DEBUG.Printf("consumer aux power: %.0fW", auxPower)
+		sitePower -= auxPower
+	}
+
 	// handle priority
 	if flexiblePower > 0 {
 		site.log.DEBUG.Printf("giving loadpoint priority for additional: %.0fW", flexiblePower)
@@ -714,6 +858,53 @@ func (site *Site) effectivePrice(greenShare float64) *float64 {
 	return nil
 }
 
+// sessionReferencePrice returns the configured reference grid price or the grid tariff's average price until
+// the loadpoint's plan time, or over all known rates without plan. It is stored with the charging sessions so that
+// savings reflect charging at cheaper times and do not change with later tariffs.
+func (site *Site) sessionReferencePrice(lp updater, now time.Time) *float64 {
+	if price := site.GetReferencePrice(); price > 0 {
+		return &price
+	}
+
+	tariff := site.GetTariff(GridTariff)
+	if tariff == nil {
+		return nil
+	}
+
+	rates, err := tariff.Rates()
+	if err != nil {
+		return nil
+	}
+
+	end := lp.EffectivePlanTime()
+	if !end.After(now) {
+		end = time.Time{}
+	}
+
+	var sum, hours float64
+	for _, r := range rates {
+		start, stop := r.Start, r.End
+		if start.Before(now) {
+			start = now
+		}
+		if !end.IsZero() && stop.After(end) {
+			stop = end
+		}
+
+		if d := stop.Sub(start).Hours(); d > 0 {
+			sum += r.Price * d
+			hours += d
+		}
+	}
+
+	if hours == 0 {
+		return nil
+	}
+
+	price := sum / hours
+	return &price
+}
+
 // effectiveCo2 calculates the amount of emitted co2 based on self-produced and grid-imported energy.
 func (site *Site) effectiveCo2(greenShare float64) *float64 {
 	if co2, err := site.tariffs.CurrentCo2(); err == nil {
@@ -753,10 +944,20 @@ func (site *Site) publishTariffs(greenShareHome float64, greenShareLoadpoints fl
 func (site *Site) update(lp updater) {
 	site.log.DEBUG.Println("----")
 
+	start := time.Now()
+
+	site.updatePresence()
+	site.updateArrivals()
+	site.updateOffGrid()
+	site.updateDatabase()
+	site.updateGridSignal(time.Now())
+	site.updateFleet()
+
 	// update all loadpoint's charge power
+	site.pollChargePower()
+
 	var totalChargePower float64
 	for _, lp := range site.loadpoints {
-		lp.UpdateChargePower()
 		totalChargePower += lp.GetChargePower()
 
 		site.prioritizer.UpdateChargePowerFlexibility(lp)
@@ -768,28 +969,34 @@ func (site *Site) update(lp updater) {
 		flexiblePower = site.prioritizer.GetChargePowerFlexibility(lp)
 	}
 
+	// grid prices don't apply while off-grid
 	var smartCostActive bool
-	if tariff := site.GetTariff(PlannerTariff); tariff != nil && tariff.Type() != api.TariffTypePriceStatic {
+	if tariff := site.GetTariff(PlannerTariff); tariff != nil && tariff.Type() != api.TariffTypePriceStatic && !site.isOffGrid() {
 		rates, err := tariff.Rates()
 
-		var rate api.Rate
 		if err == nil {
-			rate, err = rates.Current(time.Now())
+			smartCostActive, err = site.smartCostActive(lp, rates, time.Now())
 		}
 
-		if err == nil {
-			limit := lp.GetSmartCostLimit()
-			smartCostActive = limit != 0 && rate.Price <= limit
-		} else {
+		if err != nil {
 			site.log.ERROR.Println("smartCost:", err)
 		}
 	}
 
+	site.updateArbitration(time.Now())
+
 	if sitePower, batteryBuffered, batteryStart, err := site.sitePower(totalChargePower, flexiblePower); err == nil {
+		sitePower = site.exportLimitSitePower(sitePower)
+		sitePower = site.pvForecastSitePower(lp, sitePower, time.Now())
+		site.updateCurtailment()
+		site.updatePeakDemand(time.Now())
+		site.updateCircuits(time.Now())
+
 		// ignore negative pvPower values as that means it is not an energy source but consumption
 		homePower := site.gridPower + max(0, site.pvPower) + site.batteryPower - totalChargePower
 		homePower = max(homePower, 0)
 		site.publish(keys.HomePower, homePower)
+		site.updateHistory(homePower)
 
 		// add battery charging power to homePower to ignore all consumption which does not occur on loadpoints
 		// fix for: https://github.com/evcc-io/evcc/issues/11032
@@ -797,9 +1004,20 @@ func (site *Site) update(lp updater) {
 		greenShareHome := site.greenShare(0, homePower)
 		greenShareLoadpoints := site.greenShare(nonChargePower, nonChargePower+totalChargePower)
 
+		lp.SetPowerLimit(minPowerLimit(
+			site.offGridPowerLimit(lp, totalChargePower),
+			site.gridSignalPowerLimit(lp, totalChargePower),
+			site.demandResponsePowerLimit(lp, totalChargePower),
+			site.peakPowerLimit(lp, totalChargePower, time.Now()),
+			site.phasePowerLimit(lp),
+			site.circuitPowerLimit(lp),
+			site.fleetPowerLimit(lp),
+		))
+		lp.setReferencePrice(site.sessionReferencePrice(lp, time.Now()))
 		lp.Update(sitePower, smartCostActive, batteryBuffered, batteryStart, greenShareLoadpoints, site.effectivePrice(greenShareLoadpoints), site.effectiveCo2(greenShareLoadpoints))
 
 		site.Health.Update()
+		site.Health.Loop(time.Since(start), nil)
 
 		site.publishTariffs(greenShareHome, greenShareLoadpoints)
 
@@ -808,6 +1026,7 @@ func (site *Site) update(lp updater) {
 		}
 	} else {
 		site.log.ERROR.Println(err)
+		site.Health.Loop(time.Since(start), err)
 	}
 
 	if batMode := site.GetBatteryMode(); site.batteryDischargeControl {
@@ -818,7 +1037,7 @@ func (site *Site) update(lp updater) {
 		}
 	}
 
-	site.stats.Update(site)
+	site.stats.Update(site, site.GetReferencePrice())
 }
 
 // prepare publishes initial values
@@ -838,6 +1057,8 @@ func (site *Site) prepare() {
 	site.publish(keys.BatteryMode, site.batteryMode)
 	site.publish(keys.BatteryDischargeControl, site.batteryDischargeControl)
 	site.publish(keys.ResidualPower, site.ResidualPower)
+	site.publish(keys.PeakDemandLimit, site.PeakShaving.Limit)
+	site.publish(keys.PeakDemands, maps.Clone(site.peak.peaks))
 
 	site.publish(keys.Currency, site.tariffs.Currency)
 	if tariff := site.GetTariff(PlannerTariff); tariff != nil {
@@ -846,6 +1067,7 @@ func (site *Site) prepare() {
 		site.publish(keys.SmartCostType, nil)
 	}
 
+	site.publishFleet()
 	site.publishVehicles()
 	vehicle.Publish = site.publishVehicles
 }
@@ -869,6 +1091,7 @@ func (site *Site) Prepare(uiChan chan<- util.Param, pushChan chan<- push.Event)
 	}()
 
 	site.lpUpdateChan = make(chan *Loadpoint, 1) // 1 capacity to avoid deadlock
+	site.pushChan = pushChan
 
 	site.prepare()
 
@@ -907,6 +1130,11 @@ func (site *Site) loopLoadpoints(next chan<- updater) {
 // updating measurements and executing control logic.
 func (site *Site) Run(stopC chan struct{}, interval time.Duration) {
 	site.Health = NewHealth(time.Minute + interval)
+	site.interval = interval
+	for _, lp := range site.loadpoints {
+		lp.health = site.Health
+		lp.setInterval(interval)
+	}
 
 	if max := 30 * time.Second; interval < max {
 		site.log.WARN.Printf("interval <%.0fs can lead to unexpected behavior, see https://docs.evcc.io/docs/reference/configuration/interval", max.Seconds())
@@ -2,14 +2,17 @@ package site
 
 import (
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/fleet"
 	"github.com/evcc-io/evcc/core/loadpoint"
 )
 
 // API is the external site API
 type API interface {
 	Healthy() bool
+	HealthStatus() Health
 	Loadpoints() []loadpoint.API
 	Vehicles() Vehicles
+	GetCircuits() []Circuit
 
 	// Meta
 	GetTitle() string
@@ -41,10 +44,18 @@ type API interface {
 	GetResidualPower() float64
 	SetResidualPower(float64) error
 
+	// SetDemandResponse caps the total charge power while a demand response event is active
+	SetDemandResponse(active bool, power float64)
+
 	//
 	// tariffs and costs
 	//
 
+	// GetReferencePrice returns the configured reference grid price for savings
+	GetReferencePrice() float64
+	// SetReferencePrice sets the reference grid price for savings, 0 uses the grid tariff's average price
+	SetReferencePrice(float64) error
+
 	// GetTariff returns the respective tariff
 	GetTariff(string) api.Tariff
 
@@ -54,4 +65,19 @@ type API interface {
 
 	GetBatteryDischargeControl() bool
 	SetBatteryDischargeControl(bool) error
+
+	//
+	// fleet
+	//
+
+	// GetFleet returns reservations, queue and current loadpoint assignments
+	GetFleet() (fleet.State, []fleet.Assignment)
+	// Reserve claims a loadpoint for a vehicle during a time window
+	Reserve(fleet.Reservation) (fleet.Reservation, error)
+	// CancelReservation removes a reservation
+	CancelReservation(int) error
+	// Enqueue adds a vehicle waiting for a free loadpoint
+	Enqueue(fleet.Request) error
+	// Dequeue removes a vehicle from the queue
+	Dequeue(string)
 }
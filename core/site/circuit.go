@@ -0,0 +1,24 @@
+package site
+
+// Circuit is the live state of an electrical circuit
+type Circuit struct {
+	Name        string        `json:"name"`
+	Title       string        `json:"title,omitempty"`
+	Parent      string        `json:"parent,omitempty"`
+	MaxPower    float64       `json:"maxPower,omitempty"`   // W
+	MaxCurrent  float64       `json:"maxCurrent,omitempty"` // A per phase
+	Power       float64       `json:"power"`
+	Currents    []float64     `json:"currents,omitempty"`
+	Utilization float64       `json:"utilization"` // share of the tighter limit
+	Capped      bool          `json:"capped"`      // persistently at its limit
+	Loadpoints  []int         `json:"loadpoints,omitempty"`
+	Loads       []CircuitLoad `json:"loads,omitempty"` // sheddable loads by priority
+}
+
+// CircuitLoad is the live state of a sheddable load
+type CircuitLoad struct {
+	Name     string  `json:"name"`
+	Priority int     `json:"priority"`
+	Power    float64 `json:"power"`
+	Shed     bool    `json:"shed"`
+}
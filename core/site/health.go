@@ -0,0 +1,27 @@
+package site
+
+import "time"
+
+// Health is the self-monitoring status of the control loop and its devices
+type Health struct {
+	Healthy    bool                    `json:"healthy"`
+	Updated    time.Time               `json:"updated"`    // last completed control loop
+	Latency    float64                 `json:"latency"`    // duration of last control loop (s)
+	AvgLatency float64                 `json:"avgLatency"` // average control loop duration (s)
+	MaxLatency float64                 `json:"maxLatency"` // max control loop duration (s)
+	Loops      int64                   `json:"loops"`      // completed control loops
+	Errors     int64                   `json:"errors"`     // control loops aborted by errors
+	Devices    map[string]DeviceHealth `json:"devices"`
+}
+
+// DeviceHealth is the health status of a single device
+type DeviceHealth struct {
+	Updated     time.Time `json:"updated"`             // last successful reading
+	Age         float64   `json:"age"`                 // time since last successful reading (s)
+	Stale       bool      `json:"stale"`               // no successful reading within health timeout
+	Errors      int64     `json:"errors"`              // failed readings
+	LastError   string    `json:"lastError,omitempty"` // last reading error
+	Duration    float64   `json:"duration"`            // duration of last reading (s)
+	AvgDuration float64   `json:"avgDuration"`         // average reading duration (s)
+	MaxDuration float64   `json:"maxDuration"`         // max reading duration (s)
+}
@@ -20,6 +20,7 @@ const (
 	GridTariff    = "grid"
 	FeedinTariff  = "feedin"
 	PlannerTariff = "planner"
+	SolarTariff   = "solar"
 )
 
 // isConfigurable checks if the meter is configurable
@@ -254,6 +255,34 @@ func (site *Site) SetResidualPower(power float64) error {
 	return nil
 }
 
+// GetReferencePrice returns the configured reference grid price
+func (site *Site) GetReferencePrice() float64 {
+	site.RLock()
+	defer site.RUnlock()
+	return site.referencePrice
+}
+
+// SetReferencePrice sets the reference grid price
+func (site *Site) SetReferencePrice(price float64) error {
+	if price < 0 {
+		return errors.New("reference price must not be negative")
+	}
+
+	site.Lock()
+	defer site.Unlock()
+
+	site.log.DEBUG.Println("set reference price:", price)
+
+	if site.referencePrice != price {
+		site.referencePrice = price
+		settings.SetFloat(keys.ReferencePrice, site.referencePrice)
+		site.publish(keys.ReferencePrice, site.referencePrice)
+		site.stats.Refresh()
+	}
+
+	return nil
+}
+
 // GetTariff returns the respective tariff if configured or nil
 func (site *Site) GetTariff(tariff string) api.Tariff {
 	site.RLock()
@@ -266,6 +295,9 @@ func (site *Site) GetTariff(tariff string) api.Tariff {
 	case FeedinTariff:
 		return site.tariffs.FeedIn
 
+	case SolarTariff:
+		return site.tariffs.Solar
+
 	case PlannerTariff:
 		switch {
 		case site.tariffs.Planner != nil:
@@ -319,3 +351,8 @@ func (site *Site) SetBatteryDischargeControl(val bool) error {
 
 	return nil
 }
+
+// HealthStatus returns the self-monitoring status of the control loop and its devices
+func (site *Site) HealthStatus() site.Health {
+	return site.Health.Status()
+}
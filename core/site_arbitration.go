@@ -0,0 +1,140 @@
+package core
+
+import (
+	"errors"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/loadpoint"
+)
+
+// ArbitrationConfig decides whether pv surplus is stored in the home battery or charged to vehicles based on forecast grid prices.
+// Battery priority below prioritySoc is not affected.
+type ArbitrationConfig struct {
+	Enabled    bool    `mapstructure:"enabled"`    // replace static battery priority above prioritySoc
+	Efficiency float64 `mapstructure:"efficiency"` // battery round-trip efficiency
+}
+
+// arbitrationWindow is the price forecast horizon for valuing stored battery energy and vehicles without plan
+const arbitrationWindow = 24 * time.Hour
+
+// configureArbitration validates battery vs vehicle arbitration configuration
+func (site *Site) configureArbitration() error {
+	if site.Arbitration.Efficiency == 0 {
+		site.Arbitration.Efficiency = 0.9
+	}
+
+	if site.Arbitration.Efficiency < 0 || site.Arbitration.Efficiency > 1 {
+		return errors.New("arbitration: efficiency must be between 0 and 1")
+	}
+
+	return nil
+}
+
+// updateArbitration decides if the home battery has priority over vehicles for pv surplus
+func (site *Site) updateArbitration(now time.Time) {
+	if !site.Arbitration.Enabled || len(site.batteryMeters) == 0 {
+		return
+	}
+
+	tariff := site.GetTariff(GridTariff)
+	if tariff == nil {
+		return
+	}
+
+	rates, err := tariff.Rates()
+	if err != nil {
+		site.log.ERROR.Println("arbitration:", err)
+	}
+
+	// without prices, the static priority applies
+	res := err == nil && site.batteryPriority(site.Loadpoints(), rates, now)
+
+	if res != site.arbitrationPriority {
+		site.log.DEBUG.Printf("arbitration: battery priority %v", res)
+	}
+
+	site.arbitrationPriority = res
+	site.publish(keys.BatteryPriority, res)
+}
+
+// batteryPriority values pv energy stored in the battery at the average forecast grid price after round-trip losses
+// and pv energy charged to vehicles at the cheapest grid price until departure. The battery has priority unless
+// any pv charging vehicle values energy at least as much.
+func (site *Site) batteryPriority(loadpoints []loadpoint.API, rates api.Rates, now time.Time) bool {
+	horizon := now.Add(arbitrationWindow)
+
+	battery, ok := averagePrice(rates, now, horizon)
+	if !ok {
+		return false
+	}
+	battery *= site.Arbitration.Efficiency
+
+	for _, lp := range loadpoints {
+		if mode := lp.GetMode(); mode != api.ModePV && mode != api.ModeMinPV {
+			continue
+		}
+		if status := lp.GetStatus(); status != api.StatusB && status != api.StatusC {
+			continue
+		}
+
+		departure := horizon
+		if ts := lp.EffectivePlanTime(); !ts.IsZero() && ts.Before(horizon) {
+			departure = ts
+		}
+
+		// no grid charging possible before departure
+		vehicle, ok := minPrice(rates, now, departure)
+		if !ok || vehicle >= battery {
+			return false
+		}
+	}
+
+	return true
+}
+
+// averagePrice returns the duration weighted average price of the rates between from and to
+func averagePrice(rates api.Rates, from, to time.Time) (float64, bool) {
+	var sum float64
+	var total time.Duration
+
+	for _, r := range rates {
+		if d := overlap(r, from, to); d > 0 {
+			sum += r.Price * d.Hours()
+			total += d
+		}
+	}
+
+	if total == 0 {
+		return 0, false
+	}
+
+	return sum / total.Hours(), true
+}
+
+// minPrice returns the lowest price of the rates between from and to
+func minPrice(rates api.Rates, from, to time.Time) (float64, bool) {
+	var res float64
+	var ok bool
+
+	for _, r := range rates {
+		if overlap(r, from, to) > 0 && (!ok || r.Price < res) {
+			res, ok = r.Price, true
+		}
+	}
+
+	return res, ok
+}
+
+// overlap returns the duration of the rate between from and to
+func overlap(r api.Rate, from, to time.Time) time.Duration {
+	start, end := r.Start, r.End
+	if start.Before(from) {
+		start = from
+	}
+	if end.After(to) {
+		end = to
+	}
+	return max(0, end.Sub(start))
+}
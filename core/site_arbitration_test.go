@@ -0,0 +1,63 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestBatteryPriority(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	site := &Site{Arbitration: ArbitrationConfig{Enabled: true, Efficiency: 0.9}}
+
+	// 10 h cheap, 14 h expensive: average 20.5, battery value 18.45
+	prices := make([]float64, 24)
+	for i := range prices {
+		prices[i] = 30
+		if i < 10 {
+			prices[i] = 10
+		}
+	}
+	rates := hourlyRates(now, prices...)
+
+	for _, tc := range []struct {
+		mode     api.ChargeMode
+		status   api.ChargeStatus
+		planTime time.Time
+		battery  bool
+	}{
+		// vehicle can be charged cheaply from grid later
+		{api.ModePV, api.StatusB, time.Time{}, true},
+		// vehicle departs before cheap rates are over
+		{api.ModePV, api.StatusC, now.Add(5 * time.Hour), true},
+		// not pv charging
+		{api.ModeNow, api.StatusC, time.Time{}, true},
+		{api.ModePV, api.StatusA, time.Time{}, true},
+	} {
+		lp := loadpoint.NewMockAPI(ctrl)
+		lp.EXPECT().GetMode().Return(tc.mode).AnyTimes()
+		lp.EXPECT().GetStatus().Return(tc.status).AnyTimes()
+		lp.EXPECT().EffectivePlanTime().Return(tc.planTime).AnyTimes()
+
+		assert.Equal(t, tc.battery, site.batteryPriority([]loadpoint.API{lp}, rates, now), tc)
+	}
+
+	// vehicle departs during expensive rates only
+	expensive := hourlyRates(now, 30, 30, 30, 10, 10, 10, 10, 10, 10, 10)
+
+	lp := loadpoint.NewMockAPI(ctrl)
+	lp.EXPECT().GetMode().Return(api.ModePV).AnyTimes()
+	lp.EXPECT().GetStatus().Return(api.StatusC).AnyTimes()
+	lp.EXPECT().EffectivePlanTime().Return(now.Add(2 * time.Hour)).AnyTimes()
+
+	assert.False(t, site.batteryPriority([]loadpoint.API{lp}, expensive, now))
+
+	// no rates
+	assert.False(t, site.batteryPriority([]loadpoint.API{lp}, nil, now))
+}
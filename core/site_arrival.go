@@ -0,0 +1,156 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/vehicle"
+	"github.com/evcc-io/evcc/util/config"
+)
+
+// ArrivalConfig defines the actions taken when a vehicle enters the presence geofence
+type ArrivalConfig struct {
+	Vehicle   string `mapstructure:"vehicle"`   // vehicle reference
+	Loadpoint int    `mapstructure:"loadpoint"` // pre-select the vehicle on this loadpoint, 1-based
+	Soc       int    `mapstructure:"soc"`       // plan soc armed on arrival
+	Departure string `mapstructure:"departure"` // plan departure time of day, HH:MM
+}
+
+// arrival tracks a vehicle's position relative to the geofence
+type arrival struct {
+	ArrivalConfig
+	vehicle   vehicle.API
+	position  api.VehiclePosition
+	departure time.Duration // since midnight
+	known     bool          // position has been determined
+	home      bool          // vehicle within geofence
+}
+
+// configureArrivals creates the vehicle arrival automation from configuration
+func (site *Site) configureArrivals() error {
+	conf := site.Presence
+
+	for i, cc := range conf.Arrival {
+		if conf.Geofence.Radius <= 0 {
+			return errors.New("arrival: missing geofence radius")
+		}
+
+		dev, err := config.Vehicles().ByName(cc.Vehicle)
+		if err != nil {
+			return fmt.Errorf("arrival %d: %w", i+1, err)
+		}
+
+		position, ok := dev.Instance().(api.VehiclePosition)
+		if !ok {
+			return fmt.Errorf("arrival %d: vehicle %s does not provide position", i+1, cc.Vehicle)
+		}
+
+		if cc.Loadpoint < 0 || cc.Loadpoint > len(site.loadpoints) {
+			return fmt.Errorf("arrival %d: invalid loadpoint: %d", i+1, cc.Loadpoint)
+		}
+
+		a := &arrival{
+			ArrivalConfig: cc,
+			vehicle:       vehicle.Adapter(site.log, dev),
+			position:      position,
+		}
+
+		if cc.Soc != 0 {
+			if cc.Soc < 0 || cc.Soc > 100 {
+				return fmt.Errorf("arrival %d: invalid soc: %d", i+1, cc.Soc)
+			}
+
+			ts, err := time.Parse("15:04", cc.Departure)
+			if err != nil {
+				return fmt.Errorf("arrival %d: invalid departure: %w", i+1, err)
+			}
+			a.departure = time.Duration(ts.Hour())*time.Hour + time.Duration(ts.Minute())*time.Minute
+		}
+
+		site.arrivals = append(site.arrivals, a)
+	}
+
+	return nil
+}
+
+// nextDeparture returns the next departure time of day after now
+func (a *arrival) nextDeparture(now time.Time) time.Time {
+	y, m, d := now.Date()
+	ts := time.Date(y, m, d, 0, 0, 0, 0, now.Location()).Add(a.departure)
+	if !ts.After(now) {
+		ts = ts.AddDate(0, 0, 1)
+	}
+	return ts
+}
+
+// updateArrivals evaluates vehicle positions and handles arrivals
+func (site *Site) updateArrivals() {
+	if len(site.arrivals) == 0 || time.Since(site.arrivalUpdated) < site.Presence.Interval {
+		return
+	}
+
+	site.arrivalUpdated = time.Now()
+
+	for _, a := range site.arrivals {
+		lat, lon, err := a.position.Position()
+		if err != nil {
+			site.log.ERROR.Printf("arrival: %s: %v", a.vehicle.Name(), err)
+			continue
+		}
+
+		site.setVehicleHome(a, distance(site.Presence.Geofence.Lat, site.Presence.Geofence.Lon, lat, lon) <= site.Presence.Geofence.Radius, time.Now())
+	}
+}
+
+// setVehicleHome updates the vehicle's position state and arms plan and vehicle on arrival
+func (site *Site) setVehicleHome(a *arrival, home bool, now time.Time) {
+	site.Lock()
+	arrived := a.known && home && !a.home
+	changed := !a.known || home != a.home
+	a.known = true
+	a.home = home
+	site.Unlock()
+
+	if changed {
+		site.log.DEBUG.Printf("arrival: %s home: %t", a.vehicle.Name(), home)
+	}
+
+	if !arrived {
+		return
+	}
+
+	site.log.INFO.Printf("arrival: %s arrived home", a.vehicle.Name())
+
+	// arm plan unless already planned
+	if a.Soc > 0 {
+		if planTime, _ := a.vehicle.GetPlanSoc(); planTime.Before(now) {
+			ts := a.nextDeparture(now)
+			if err := a.vehicle.SetPlanSoc(ts, a.Soc); err != nil {
+				site.log.ERROR.Printf("arrival: %s: %v", a.vehicle.Name(), err)
+			}
+		}
+	}
+
+	// pre-select vehicle unless already identified
+	if a.Loadpoint > 0 {
+		if lp := site.loadpoints[a.Loadpoint-1]; lp.GetVehicle() == nil {
+			lp.SetVehicle(a.vehicle.Instance())
+		}
+	}
+}
+
+// isVehicleAway returns true if the vehicle is known to be outside the geofence
+func (site *Site) isVehicleAway(v api.Vehicle) bool {
+	site.RLock()
+	defer site.RUnlock()
+
+	for _, a := range site.arrivals {
+		if a.vehicle.Instance() == v {
+			return a.known && !a.home
+		}
+	}
+
+	return false
+}
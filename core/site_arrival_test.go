@@ -0,0 +1,70 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/vehicle"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestArrival(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	v := api.NewMockVehicle(ctrl)
+	v.EXPECT().Title().Return("car").AnyTimes()
+	v.EXPECT().Capacity().AnyTimes()
+	v.EXPECT().Icon().AnyTimes()
+	v.EXPECT().Features().AnyTimes()
+	v.EXPECT().Phases().AnyTimes()
+	v.EXPECT().OnIdentified().AnyTimes()
+	vs := vehicle.NewMockAPI(ctrl)
+	vs.EXPECT().Name().Return("car").AnyTimes()
+	vs.EXPECT().Instance().Return(v).AnyTimes()
+
+	lp := NewLoadpoint(util.NewLogger("foo"), nil)
+
+	site := NewSite()
+	site.loadpoints = []*Loadpoint{lp}
+	lp.vehicleAway = site.isVehicleAway
+
+	a := &arrival{
+		ArrivalConfig: ArrivalConfig{Soc: 80, Loadpoint: 1},
+		vehicle:       vs,
+		departure:     7 * time.Hour,
+	}
+	site.arrivals = []*arrival{a}
+
+	now := time.Date(2024, 1, 1, 18, 0, 0, 0, time.Local)
+
+	// unknown position
+	assert.False(t, lp.isVehicleAway(v))
+
+	// initial position does not arm
+	site.setVehicleHome(a, false, now)
+	assert.True(t, lp.isVehicleAway(v))
+
+	// arrival arms plan and selects vehicle
+	vs.EXPECT().GetPlanSoc().Return(time.Time{}, 0)
+	vs.EXPECT().SetPlanSoc(time.Date(2024, 1, 2, 7, 0, 0, 0, time.Local), 80)
+
+	site.setVehicleHome(a, true, now)
+	assert.False(t, lp.isVehicleAway(v))
+	assert.Equal(t, v, lp.GetVehicle())
+
+	// staying home does not re-arm
+	site.setVehicleHome(a, true, now)
+}
+
+func TestArrivalNextDeparture(t *testing.T) {
+	a := &arrival{departure: 7 * time.Hour}
+
+	now := time.Date(2024, 1, 1, 6, 0, 0, 0, time.Local)
+	assert.Equal(t, time.Date(2024, 1, 1, 7, 0, 0, 0, time.Local), a.nextDeparture(now))
+
+	now = time.Date(2024, 1, 1, 7, 0, 0, 0, time.Local)
+	assert.Equal(t, time.Date(2024, 1, 2, 7, 0, 0, 0, time.Local), a.nextDeparture(now))
+}
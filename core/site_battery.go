@@ -31,6 +31,11 @@ func (site *Site) SetBatteryMode(batMode api.BatteryMode) {
 }
 
 func (site *Site) determineBatteryMode(loadpoints []loadpoint.API, smartCostActive bool) api.BatteryMode {
+	// let the battery absorb curtailed pv power instead of holding it
+	if site.exportLimited() {
+		return api.BatteryNormal
+	}
+
 	for _, lp := range loadpoints {
 		if lp.GetStatus() == api.StatusC && (smartCostActive || lp.IsFastChargingActive()) {
 			return api.BatteryHold
@@ -0,0 +1,196 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/provider"
+	"github.com/evcc-io/evcc/util/config"
+)
+
+const circuitRestoreDelay = 5 * time.Minute // min duration a load stays shed
+
+// CircuitLoadConfig defines a controllable non-EV consumer that is shed before loadpoints are limited
+type CircuitLoadConfig struct {
+	Name     string          `mapstructure:"name"`
+	Priority int             `mapstructure:"priority"` // loads with lower priority are shed first
+	Power    float64         `mapstructure:"power"`    // nominal power (W), used if not metered
+	Meter    string          `mapstructure:"meter"`    // optional meter measuring the load
+	Switch   provider.Config `mapstructure:"switch"`   // bool setter, false sheds the load (smart plug relay, SG-Ready blocking)
+}
+
+// circuitLoad is the runtime state of a sheddable load
+type circuitLoad struct {
+	CircuitLoadConfig
+	meter   api.Meter
+	switchS func(bool) error
+	power   float64   // measured or nominal power while not shed
+	restore float64   // power required for restoring
+	shed    bool      // load is shed
+	shedAt  time.Time // load shed since
+	shedBy  *circuit  // circuit that has shed the load
+}
+
+// newCircuitLoads creates the circuit's sheddable loads
+func newCircuitLoads(c *circuit) ([]*circuitLoad, error) {
+	var res []*circuitLoad
+
+	for _, lc := range c.Loads {
+		if lc.Name == "" {
+			return nil, fmt.Errorf("circuit %s: missing load name", c.Name)
+		}
+
+		l := &circuitLoad{CircuitLoadConfig: lc}
+
+		if lc.Meter != "" {
+			dev, err := config.Meters().ByName(lc.Meter)
+			if err != nil {
+				return nil, fmt.Errorf("circuit %s: load %s: %w", c.Name, lc.Name, err)
+			}
+			l.meter = dev.Instance()
+		} else if lc.Power <= 0 {
+			return nil, fmt.Errorf("circuit %s: load %s: missing power or meter", c.Name, lc.Name)
+		}
+
+		switchS, err := provider.NewBoolSetterFromConfig("switch", lc.Switch)
+		if err != nil {
+			return nil, fmt.Errorf("circuit %s: load %s: %w", c.Name, lc.Name, err)
+		}
+		l.switchS = switchS
+
+		res = append(res, l)
+	}
+
+	return res, nil
+}
+
+// measure returns the load's current power
+func (l *circuitLoad) measure() (float64, error) {
+	switch {
+	case l.shed:
+		return 0, nil
+	case l.meter == nil:
+		return l.Power, nil
+	default:
+		return l.meter.CurrentPower()
+	}
+}
+
+// updateLoadsPower measures all sheddable loads
+func (site *Site) updateLoadsPower() {
+	for _, c := range site.circuits {
+		// root circuits contain all loads
+		if c.parent != nil {
+			continue
+		}
+
+		for _, l := range c.loads {
+			power, err := l.measure()
+			if err != nil {
+				site.log.ERROR.Printf("circuit %s: load %s: %v", c.Name, l.Name, err)
+				continue
+			}
+
+			site.Lock()
+			l.power = power
+			site.Unlock()
+		}
+	}
+}
+
+// loadsPower returns the summed power of the loads
+func loadsPower(loads []*circuitLoad) float64 {
+	var res float64
+	for _, l := range loads {
+		res += l.power
+	}
+	return res
+}
+
+// excessPower returns the power above the circuit's tighter limit, negative while headroom remains
+func (c *circuit) excessPower(voltage float64) float64 {
+	res := math.Inf(-1)
+	if c.MaxPower > 0 {
+		res = c.power - c.MaxPower
+	}
+	if c.MaxCurrent > 0 && len(c.currents) > 0 {
+		res = max(res, (slices.Max(c.currents)-c.MaxCurrent)*voltage)
+	}
+	return res
+}
+
+// updateLoads sheds loads in priority order while the circuit is above its limit and restores them once the headroom allows
+func (site *Site) updateLoads(c *circuit, now time.Time) {
+	excess := c.excessPower(site.Voltage)
+
+	if excess > 0 {
+		for _, l := range c.loads {
+			if excess <= 0 {
+				break
+			}
+			if l.shed {
+				continue
+			}
+
+			site.log.WARN.Printf("circuit %s: shedding %s (%.0fW)", c.Name, l.Name, l.power)
+
+			if err := l.switchS(false); err != nil {
+				site.log.ERROR.Printf("circuit %s: load %s: %v", c.Name, l.Name, err)
+				continue
+			}
+
+			// expect the load's power to be available for loadpoints right away
+			excess -= l.power
+
+			site.Lock()
+			c.power -= l.power
+			l.restore = max(l.Power, l.power)
+			l.power = 0
+			l.shed = true
+			l.shedAt = now
+			l.shedBy = c
+			site.Unlock()
+		}
+
+		return
+	}
+
+	// restore highest priority load first
+	for i := len(c.loads) - 1; i >= 0; i-- {
+		l := c.loads[i]
+		if !l.shed || l.shedBy != c {
+			continue
+		}
+
+		if now.Sub(l.shedAt) < circuitRestoreDelay || -excess < l.restore {
+			return
+		}
+
+		site.log.INFO.Printf("circuit %s: restoring %s", c.Name, l.Name)
+
+		if err := l.switchS(true); err != nil {
+			site.log.ERROR.Printf("circuit %s: load %s: %v", c.Name, l.Name, err)
+			return
+		}
+
+		site.Lock()
+		l.shed = false
+		site.Unlock()
+
+		return
+	}
+}
+
+// status returns the load's live state
+func (l *circuitLoad) status() site.CircuitLoad {
+	return site.CircuitLoad{
+		Name:     l.Name,
+		Priority: l.Priority,
+		Power:    l.power,
+		Shed:     l.shed,
+	}
+}
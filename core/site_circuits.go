@@ -0,0 +1,367 @@
+package core
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/push"
+	"github.com/evcc-io/evcc/util/config"
+)
+
+const (
+	circuitCapUtilization = 0.98             // utilization considered at the limit
+	circuitCapDuration    = 15 * time.Minute // duration at the limit before alerting
+
+	evCircuitCapped = "circuit" // circuit persistently at its limit
+)
+
+// CircuitConfig defines an electrical circuit whose limits are shared by its loadpoints and sub-circuits
+type CircuitConfig struct {
+	Name       string              `mapstructure:"name"`
+	Title      string              `mapstructure:"title"`
+	Parent     string              `mapstructure:"parent"`     // parent circuit name
+	Meter      string              `mapstructure:"meter"`      // optional meter measuring the entire circuit
+	MaxPower   float64             `mapstructure:"maxPower"`   // W
+	MaxCurrent float64             `mapstructure:"maxCurrent"` // A per phase
+	Loadpoints []int               `mapstructure:"loadpoints"` // loadpoint ids, 1-based
+	Loads      []CircuitLoadConfig `mapstructure:"loads"`      // controllable consumers shed before loadpoints are limited
+}
+
+// circuit is the runtime state of a configured circuit
+type circuit struct {
+	CircuitConfig
+	parent     *circuit
+	meter      api.Meter
+	loadpoints []*Loadpoint   // loadpoints of this circuit and its sub-circuits
+	loads      []*circuitLoad // sheddable loads of this circuit and its sub-circuits by priority
+	power      float64
+	currents   []float64
+	atLimit    time.Time // at the limit since
+	capped     bool
+}
+
+// utilization returns the share of the tighter limit in use
+func (c *circuit) utilization() float64 {
+	var res float64
+	if c.MaxPower > 0 {
+		res = c.power / c.MaxPower
+	}
+	if c.MaxCurrent > 0 && len(c.currents) > 0 {
+		res = max(res, slices.Max(c.currents)/c.MaxCurrent)
+	}
+	return res
+}
+
+// configureCircuits creates the circuit hierarchy from configuration
+func (site *Site) configureCircuits() error {
+	byName := make(map[string]*circuit)
+
+	for i, cc := range site.Circuits {
+		if cc.Name == "" {
+			return fmt.Errorf("circuit %d: missing name", i+1)
+		}
+		if _, ok := byName[cc.Name]; ok {
+			return fmt.Errorf("circuit %d: duplicate name: %s", i+1, cc.Name)
+		}
+		if cc.MaxPower <= 0 && cc.MaxCurrent <= 0 {
+			return fmt.Errorf("circuit %s: missing maxPower or maxCurrent", cc.Name)
+		}
+
+		c := &circuit{CircuitConfig: cc}
+
+		if cc.Meter != "" {
+			dev, err := config.Meters().ByName(cc.Meter)
+			if err != nil {
+				return fmt.Errorf("circuit %s: %w", cc.Name, err)
+			}
+			c.meter = dev.Instance()
+		}
+
+		byName[cc.Name] = c
+		site.circuits = append(site.circuits, c)
+	}
+
+	for _, c := range site.circuits {
+		if c.Parent != "" {
+			parent, ok := byName[c.Parent]
+			if !ok {
+				return fmt.Errorf("circuit %s: invalid parent: %s", c.Name, c.Parent)
+			}
+			c.parent = parent
+		}
+
+		// detect cycles
+		for p, depth := c.parent, 0; p != nil; p, depth = p.parent, depth+1 {
+			if p == c || depth > len(site.circuits) {
+				return errors.New("circuit " + c.Name + ": circular parent")
+			}
+		}
+
+		for _, id := range c.Loadpoints {
+			if id < 1 || id > len(site.loadpoints) {
+				return fmt.Errorf("circuit %s: invalid loadpoint: %d", c.Name, id)
+			}
+
+			// loadpoints count towards all parent circuits
+			lp := site.loadpoints[id-1]
+			for p := c; p != nil; p = p.parent {
+				if !slices.Contains(p.loadpoints, lp) {
+					p.loadpoints = append(p.loadpoints, lp)
+				}
+			}
+		}
+
+		loads, err := newCircuitLoads(c)
+		if err != nil {
+			return err
+		}
+
+		// loads count towards all parent circuits
+		for p := c; p != nil; p = p.parent {
+			p.loads = append(p.loads, loads...)
+		}
+	}
+
+	for _, c := range site.circuits {
+		slices.SortStableFunc(c.loads, func(a, b *circuitLoad) int {
+			return cmp.Compare(a.Priority, b.Priority)
+		})
+	}
+
+	return nil
+}
+
+// loadpointCurrents returns the summed phase currents of the loadpoints
+func loadpointCurrents(loadpoints []*Loadpoint) []float64 {
+	res := make([]float64, 3)
+	for _, lp := range loadpoints {
+		for i, c := range lp.GetChargeCurrents() {
+			if i < len(res) {
+				res[i] += c
+			}
+		}
+	}
+	return res
+}
+
+// loadpointPower returns the summed charge power of the loadpoints
+func loadpointPower(loadpoints []*Loadpoint) float64 {
+	var res float64
+	for _, lp := range loadpoints {
+		res += lp.GetChargePower()
+	}
+	return res
+}
+
+// updateCircuits measures circuit utilization and alerts on circuits persistently at their limit
+func (site *Site) updateCircuits(now time.Time) {
+	if len(site.circuits) == 0 {
+		return
+	}
+
+	site.updateLoadsPower()
+
+	for _, c := range site.circuits {
+		power := loadpointPower(c.loadpoints) + loadsPower(c.loads)
+		currents := loadpointCurrents(c.loadpoints)
+
+		if c.meter != nil {
+			var err error
+			if power, err = c.meter.CurrentPower(); err != nil {
+				site.log.ERROR.Printf("circuit %s: %v", c.Name, err)
+				continue
+			}
+
+			if m, ok := c.meter.(api.PhaseCurrents); ok {
+				l1, l2, l3, err := m.Currents()
+				if err != nil {
+					site.log.ERROR.Printf("circuit %s: %v", c.Name, err)
+					continue
+				}
+				currents = []float64{l1, l2, l3}
+			}
+		}
+
+		site.Lock()
+		c.power = power
+		c.currents = currents
+		site.Unlock()
+
+		site.updateLoads(c, now)
+
+		site.Lock()
+		capped := c.updateCap(now)
+		site.Unlock()
+
+		if capped {
+			title := c.Title
+			if title == "" {
+				title = c.Name
+			}
+
+			site.log.WARN.Printf("circuit %s: at limit since %v", c.Name, c.atLimit.Round(time.Second))
+			site.publish(keys.CircuitCapped, title)
+
+			if site.pushChan != nil {
+				site.pushChan <- push.Event{Event: evCircuitCapped}
+			}
+		}
+	}
+
+	site.updateCircuitLimiters()
+
+	site.publish(keys.Circuits, site.GetCircuits())
+}
+
+// circuitLimiterCurrent returns the per-phase current available to the loadpoints with chargers
+// doing their own load balancing, i.e. the circuit limit less all other consumers
+func (c *circuit) circuitLimiterCurrent() (float64, bool) {
+	if c.MaxCurrent <= 0 || len(c.currents) == 0 {
+		return 0, false
+	}
+
+	others := slices.Clone(c.currents)
+	for _, lp := range c.loadpoints {
+		if _, ok := lp.charger.(api.CircuitCurrentLimiter); !ok {
+			continue
+		}
+
+		for i, cur := range lp.GetChargeCurrents() {
+			if i < len(others) {
+				others[i] -= cur
+			}
+		}
+	}
+
+	return max(c.MaxCurrent-slices.Max(others), 0), true
+}
+
+// updateCircuitLimiters coordinates chargers doing their own load balancing with the limits of their circuits
+func (site *Site) updateCircuitLimiters() {
+	for _, lp := range site.loadpoints {
+		cl, ok := lp.charger.(api.CircuitCurrentLimiter)
+		if !ok {
+			continue
+		}
+
+		var (
+			current float64
+			limited bool
+		)
+
+		for _, c := range site.circuits {
+			if !slices.Contains(c.loadpoints, lp) {
+				continue
+			}
+
+			if cur, ok := c.circuitLimiterCurrent(); ok && (!limited || cur < current) {
+				current, limited = cur, true
+			}
+		}
+
+		if !limited {
+			continue
+		}
+
+		if err := cl.SetCircuitCurrent(current); err != nil && !errors.Is(err, api.ErrNotAvailable) {
+			site.log.ERROR.Printf("circuit: %s: %v", lp.Title(), err)
+		}
+	}
+}
+
+// updateCap tracks how long the circuit is at its limit and returns true when it becomes capped
+func (c *circuit) updateCap(now time.Time) bool {
+	if c.utilization() < circuitCapUtilization {
+		c.atLimit = time.Time{}
+		c.capped = false
+		return false
+	}
+
+	if c.atLimit.IsZero() {
+		c.atLimit = now
+	}
+
+	if !c.capped && now.Sub(c.atLimit) >= circuitCapDuration {
+		c.capped = true
+		return true
+	}
+
+	return false
+}
+
+// circuitPowerLimit limits the loadpoint to its share of the remaining capacity of all circuits it belongs to
+func (site *Site) circuitPowerLimit(lp updater) float64 {
+	var res float64
+
+	for _, c := range site.circuits {
+		idx := slices.IndexFunc(c.loadpoints, func(l *Loadpoint) bool { return updater(l) == lp })
+		if idx < 0 {
+			continue
+		}
+
+		// loadpoint keeps its current power plus an equal share of the headroom
+		// including the power of loads that are shed before loadpoints are limited
+		n := float64(len(c.loadpoints))
+		sheddable := loadsPower(c.loads)
+
+		if c.MaxPower > 0 {
+			limit := lp.GetChargePower() + (c.MaxPower-c.power+sheddable)/n
+			res = minPowerLimit(res, max(limit, 1))
+		}
+
+		if c.MaxCurrent > 0 && len(c.currents) > 0 {
+			var current float64
+			if currents := lp.GetChargeCurrents(); len(currents) > 0 {
+				current = slices.Max(currents)
+			}
+
+			limit := (current + (c.MaxCurrent-slices.Max(c.currents)+sheddable/site.Voltage)/n) * lp.GetVoltage() * float64(lp.ActivePhases())
+			res = minPowerLimit(res, max(limit, 1))
+		}
+	}
+
+	return res
+}
+
+// status returns the circuit's live state
+func (c *circuit) status(loadpoints []*Loadpoint) site.Circuit {
+	res := site.Circuit{
+		Name:        c.Name,
+		Title:       c.Title,
+		Parent:      c.Parent,
+		MaxPower:    c.MaxPower,
+		MaxCurrent:  c.MaxCurrent,
+		Power:       c.power,
+		Currents:    slices.Clone(c.currents),
+		Utilization: c.utilization(),
+		Capped:      c.capped,
+	}
+
+	for _, lp := range c.loadpoints {
+		res.Loadpoints = append(res.Loadpoints, slices.Index(loadpoints, lp)+1)
+	}
+
+	for _, l := range c.loads {
+		res.Loads = append(res.Loads, l.status())
+	}
+
+	return res
+}
+
+// GetCircuits returns the circuits' live state
+func (site *Site) GetCircuits() (res []site.Circuit) {
+	site.RLock()
+	defer site.RUnlock()
+
+	for _, c := range site.circuits {
+		res = append(res, c.status(site.loadpoints))
+	}
+
+	return res
+}
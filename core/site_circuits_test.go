@@ -0,0 +1,161 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuits(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+
+	lp1 := NewLoadpoint(util.NewLogger("foo"), nil)
+	lp1.chargePower = 4000
+	lp2 := NewLoadpoint(util.NewLogger("foo"), nil)
+	lp2.chargePower = 1000
+
+	site := NewSite()
+	site.loadpoints = []*Loadpoint{lp1, lp2}
+	site.Circuits = []CircuitConfig{
+		{Name: "main", MaxPower: 10000},
+		{Name: "garage", Parent: "main", MaxPower: 6000, Loadpoints: []int{1, 2}},
+	}
+	require.NoError(t, site.configureCircuits())
+
+	site.updateCircuits(now)
+
+	res := site.GetCircuits()
+	require.Len(t, res, 2)
+	assert.Equal(t, []int{1, 2}, res[0].Loadpoints)
+	assert.Equal(t, 5000.0, res[1].Power)
+	assert.InDelta(t, 5.0/6, res[1].Utilization, 1e-6)
+
+	// limited by the tighter sub-circuit
+	assert.Equal(t, 4500.0, site.circuitPowerLimit(lp1))
+
+	// capped after persistently at limit
+	lp1.chargePower = 5000
+	site.updateCircuits(now)
+	assert.False(t, site.GetCircuits()[1].Capped)
+	site.updateCircuits(now.Add(circuitCapDuration))
+	assert.True(t, site.GetCircuits()[1].Capped)
+	assert.False(t, site.GetCircuits()[0].Capped)
+
+	lp1.chargePower = 2000
+	site.updateCircuits(now.Add(circuitCapDuration + time.Minute))
+	assert.False(t, site.GetCircuits()[1].Capped)
+}
+
+func TestCircuitsConfig(t *testing.T) {
+	site := NewSite()
+	site.Circuits = []CircuitConfig{
+		{Name: "a", Parent: "b", MaxPower: 1000},
+		{Name: "b", Parent: "a", MaxPower: 1000},
+	}
+	assert.Error(t, site.configureCircuits())
+
+	site = NewSite()
+	site.Circuits = []CircuitConfig{{Name: "a"}}
+	assert.Error(t, site.configureCircuits())
+}
+
+func TestCircuitLoads(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+
+	lp := NewLoadpoint(util.NewLogger("foo"), nil)
+	lp.chargePower = 8000
+
+	site := NewSite()
+	site.loadpoints = []*Loadpoint{lp}
+	site.Circuits = []CircuitConfig{
+		{Name: "main", MaxPower: 10000, Loadpoints: []int{1}},
+	}
+	require.NoError(t, site.configureCircuits())
+
+	switched := make(map[string]bool)
+	load := func(name string, priority int, power float64) *circuitLoad {
+		switched[name] = true
+		return &circuitLoad{
+			CircuitLoadConfig: CircuitLoadConfig{Name: name, Priority: priority, Power: power},
+			switchS: func(b bool) error {
+				switched[name] = b
+				return nil
+			},
+		}
+	}
+
+	c := site.circuits[0]
+	c.loads = []*circuitLoad{load("heatpump", 1, 3000), load("plug", 2, 1000)}
+
+	// lowest priority load is shed first
+	site.updateCircuits(now)
+	assert.False(t, switched["heatpump"])
+	assert.True(t, switched["plug"])
+	assert.Equal(t, 9000.0, c.power)
+
+	// loadpoint may use the power of loads not yet shed
+	assert.Equal(t, 10000.0, site.circuitPowerLimit(lp))
+
+	lp.chargePower = 10000
+	site.updateCircuits(now)
+	assert.False(t, switched["plug"])
+
+	// loads stay shed until restore delay
+	lp.chargePower = 5000
+	site.updateCircuits(now.Add(time.Minute))
+	assert.False(t, switched["plug"])
+
+	// highest priority load is restored first
+	site.updateCircuits(now.Add(circuitRestoreDelay))
+	assert.True(t, switched["plug"])
+	assert.False(t, switched["heatpump"])
+
+	site.updateCircuits(now.Add(circuitRestoreDelay))
+	assert.True(t, switched["heatpump"])
+
+	res := site.GetCircuits()[0].Loads
+	require.Len(t, res, 2)
+	assert.Equal(t, "heatpump", res[0].Name)
+	assert.False(t, res[0].Shed)
+}
+
+type limiterCharger struct {
+	api.Charger
+	current float64
+}
+
+func (c *limiterCharger) SetCircuitCurrent(current float64) error {
+	c.current = current
+	return nil
+}
+
+func TestCircuitLimiter(t *testing.T) {
+	charger := new(limiterCharger)
+
+	lp1 := NewLoadpoint(util.NewLogger("foo"), nil)
+	lp1.charger = charger
+	lp1.chargeCurrents = []float64{10, 10, 10}
+	lp2 := NewLoadpoint(util.NewLogger("foo"), nil)
+	lp2.chargeCurrents = []float64{6, 6, 6}
+
+	site := NewSite()
+	site.loadpoints = []*Loadpoint{lp1, lp2}
+	site.Circuits = []CircuitConfig{
+		{Name: "main", MaxCurrent: 35},
+		{Name: "garage", Parent: "main", MaxCurrent: 32, Loadpoints: []int{1, 2}},
+	}
+	require.NoError(t, site.configureCircuits())
+
+	// circuit limit less other loadpoints
+	site.updateCircuits(time.Now())
+	assert.Equal(t, 26.0, charger.current)
+
+	// tighter parent circuit
+	site.circuits[0].MaxCurrent = 25
+	site.updateCircuits(time.Now())
+	assert.Equal(t, 19.0, charger.current)
+}
@@ -0,0 +1,128 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/util/config"
+)
+
+// ConsumerConfig is a named household consumer measured by a sub-meter, e.g. heat pump or sauna
+type ConsumerConfig struct {
+	Title string `mapstructure:"title"` // display name
+	Meter string `mapstructure:"meter"` // meter reference
+	Aux   bool   `mapstructure:"aux"`   // consumer yields to charging, its consumption is available as surplus
+}
+
+// consumer is a configured household consumer
+type consumer struct {
+	title string
+	meter api.Meter
+	aux   bool
+	power float64
+}
+
+// consumerMeasurement is used as slice element for publishing structured data
+type consumerMeasurement struct {
+	Title  string  `json:"title"`
+	Power  float64 `json:"power"`
+	Energy float64 `json:"energy,omitempty"`
+	Aux    bool    `json:"aux,omitempty"`
+}
+
+// configureConsumers resolves the consumer meters
+func (site *Site) configureConsumers() error {
+	for i, cc := range site.Consumers {
+		if cc.Meter == "" {
+			return fmt.Errorf("consumer %d: missing meter", i+1)
+		}
+
+		dev, err := config.Meters().ByName(cc.Meter)
+		if err != nil {
+			return fmt.Errorf("consumer %d: %w", i+1, err)
+		}
+
+		title := cc.Title
+		if title == "" {
+			title = cc.Meter
+		}
+
+		site.consumers = append(site.consumers, &consumer{
+			title: title,
+			meter: dev.Instance(),
+			aux:   cc.Aux,
+		})
+	}
+
+	return nil
+}
+
+// consumerMeters returns the meters of all consumers for polling
+func (site *Site) consumerMeters() ([]api.Meter, []string) {
+	meters := make([]api.Meter, 0, len(site.consumers))
+	refs := make([]string, 0, len(site.consumers))
+
+	for i, c := range site.consumers {
+		meters = append(meters, c.meter)
+		refs = append(refs, site.Consumers[i].Meter)
+	}
+
+	return meters, refs
+}
+
+// updateConsumers reads and publishes consumer power and returns the power of consumers yielding to charging
+func (site *Site) updateConsumers() float64 {
+	if len(site.consumers) == 0 {
+		return 0
+	}
+
+	var auxPower float64
+	mm := make([]consumerMeasurement, len(site.consumers))
+
+	for i, c := range site.consumers {
+		mm[i] = consumerMeasurement{Title: c.title, Aux: c.aux}
+
+		power, err := site.meterPower(c.meter)
+		if err != nil {
+			site.log.ERROR.Printf("consumer %s: %v", c.title, err)
+			continue
+		}
+
+		c.power = power
+		mm[i].Power = power
+
+		if c.aux {
+			auxPower += power
+		}
+
+		if m, ok := c.meter.(api.MeterEnergy); ok {
+			if energy, err := m.TotalEnergy(); err == nil {
+				mm[i].Energy = energy
+			} else if !errors.Is(err, api.ErrNotAvailable) {
+				site.log.ERROR.Printf("consumer %s energy: %v", c.title, err)
+			}
+		}
+
+		site.log.DEBUG.Printf("consumer %s power: %.0fW", c.title, power)
+	}
+
+	site.publish(keys.Consumers, mm)
+
+	return auxPower
+}
+
+// consumerPowers returns the last consumer power readings by title
+func (site *Site) consumerPowers() map[string]float64 {
+	if len(site.consumers) == 0 {
+		return nil
+	}
+
+	res := make(map[string]float64, len(site.consumers))
+	for _, c := range site.consumers {
+		res[c.title] += c.power
+	}
+
+	return res
+}
@@ -0,0 +1,30 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestConsumers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	heatpump := api.NewMockMeter(ctrl)
+	sauna := api.NewMockMeter(ctrl)
+
+	site := NewSite()
+	site.consumers = []*consumer{
+		{title: "Heat pump", meter: heatpump},
+		{title: "Sauna", meter: sauna, aux: true},
+	}
+	site.meterPowers = map[api.Meter]powerReading{
+		heatpump: {power: 2000},
+		sauna:    {power: 6000},
+	}
+
+	// only consumers yielding to charging count as surplus
+	assert.Equal(t, 6000.0, site.updateConsumers())
+	assert.Equal(t, map[string]float64{"Heat pump": 2000, "Sauna": 6000}, site.consumerPowers())
+}
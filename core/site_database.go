@@ -0,0 +1,26 @@
+package core
+
+import (
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/server/db"
+)
+
+// updateDatabase publishes the database error while persistence is failing.
+// Control continues, settings and sessions are written once the database is available again.
+func (site *Site) updateDatabase() {
+	var msg string
+	if err := db.Err(); err != nil {
+		msg = err.Error()
+	}
+
+	if msg != site.databaseError {
+		if msg != "" {
+			site.log.WARN.Println("database unavailable, writes are queued:", msg)
+		} else if site.databaseError != "" {
+			site.log.INFO.Println("database available")
+		}
+		site.databaseError = msg
+	}
+
+	site.publish(keys.DatabaseError, msg)
+}
@@ -0,0 +1,59 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/evcc-io/evcc/core/audit"
+	"github.com/evcc-io/evcc/core/keys"
+)
+
+// demandResponse is the load reduction requested by a demand response program, e.g. OpenADR events
+type demandResponse struct {
+	active bool
+	power  float64 // total charge power while active (W), 0 stops charging
+}
+
+// SetDemandResponse caps the total charge power while a demand response event is active
+func (site *Site) SetDemandResponse(active bool, power float64) {
+	site.Lock()
+	defer site.Unlock()
+
+	dr := &site.demandResponse
+	if dr.active == active && (!active || dr.power == power) {
+		return
+	}
+
+	dr.active = active
+	dr.power = max(power, 0)
+
+	if active {
+		site.log.WARN.Printf("demand response: limiting charge power to %.0fW", dr.power)
+	} else {
+		site.log.INFO.Println("demand response: limit released")
+	}
+
+	audit.Record(audit.Entry{
+		Created: time.Now(),
+		Source:  audit.SourceSignal,
+		Action:  "demand response",
+		Value:   fmt.Sprintf("%t (%.0fW)", active, dr.power),
+	})
+
+	site.publish(keys.DemandResponse, active)
+	site.publish(keys.DemandResponseLimit, dr.power)
+}
+
+// demandResponsePowerLimit returns the charge power available to the loadpoint during a demand response event, 0 for unlimited
+func (site *Site) demandResponsePowerLimit(lp updater, totalChargePower float64) float64 {
+	site.RLock()
+	dr := site.demandResponse
+	site.RUnlock()
+
+	if !dr.active {
+		return 0
+	}
+
+	// capacity not used by other loadpoints
+	return max(dr.power-(totalChargePower-lp.GetChargePower()), powerLimitStop)
+}
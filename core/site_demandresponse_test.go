@@ -0,0 +1,28 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemandResponsePowerLimit(t *testing.T) {
+	site := NewSite()
+
+	lp := NewLoadpoint(util.NewLogger("foo"), nil)
+	lp.chargePower = 1000
+
+	assert.Equal(t, 0.0, site.demandResponsePowerLimit(lp, 3000))
+
+	// other loadpoints use 2000W of the 2500W event limit
+	site.SetDemandResponse(true, 2500)
+	assert.Equal(t, 500.0, site.demandResponsePowerLimit(lp, 3000))
+
+	// stop charging
+	site.SetDemandResponse(true, 0)
+	assert.Equal(t, powerLimitStop, site.demandResponsePowerLimit(lp, 3000))
+
+	site.SetDemandResponse(false, 0)
+	assert.Equal(t, 0.0, site.demandResponsePowerLimit(lp, 3000))
+}
@@ -0,0 +1,108 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/provider"
+)
+
+// ExportLimitConfig limits the power fed into the grid, e.g. for 70% rule or zero export installations
+type ExportLimitConfig struct {
+	Power   *float64         `mapstructure:"power"`   // max grid export power (W), 0 for zero export
+	Margin  float64          `mapstructure:"margin"`  // raise consumption when export is this close to the limit (W)
+	Curtail *provider.Config `mapstructure:"curtail"` // inverter active power limit (%), e.g. SunSpec WMaxLimPct
+	PvPower float64          `mapstructure:"pvPower"` // rated inverter power (W) for curtailment
+}
+
+// configureExportLimit creates the export limiter from configuration
+func (site *Site) configureExportLimit() error {
+	conf := site.ExportLimit
+
+	if conf.Power == nil {
+		if conf.Curtail != nil {
+			return errors.New("export limit: curtailment requires power")
+		}
+		return nil
+	}
+
+	if *conf.Power < 0 || conf.Margin < 0 {
+		return errors.New("export limit: power and margin must not be negative")
+	}
+
+	if conf.Curtail != nil {
+		if conf.PvPower <= 0 {
+			return errors.New("export limit: curtailment requires pvPower")
+		}
+
+		curtailS, err := provider.NewFloatSetterFromConfig("limit", *conf.Curtail)
+		if err != nil {
+			return fmt.Errorf("export limit: %w", err)
+		}
+
+		site.curtailS = curtailS
+		site.curtailLimit = -1
+	}
+
+	return nil
+}
+
+// exportLimited returns true if pv output is curtailed to keep export within the limit.
+// With curtailment control, this is the case while the inverter limit is below 100%.
+// Otherwise the inverter is assumed to curtail itself while export is pinned within margin of the limit.
+func (site *Site) exportLimited() bool {
+	limit := site.ExportLimit.Power
+	if limit == nil {
+		return false
+	}
+
+	if site.curtailS != nil {
+		return site.curtailLimit >= 0 && site.curtailLimit < 100
+	}
+
+	return -site.gridPower >= max(*limit-site.ExportLimit.Margin, 0)
+}
+
+// exportLimitSitePower adjusts site power for export limitation.
+// While pv output is curtailed, the curtailed power is hidden from the available surplus.
+// Loadpoints are offered additional margin to raise consumption and release the curtailed power.
+func (site *Site) exportLimitSitePower(sitePower float64) float64 {
+	limited := site.exportLimited()
+	site.publish(keys.ExportLimited, limited)
+
+	if !limited {
+		return sitePower
+	}
+
+	site.log.DEBUG.Printf("export limit: %.0fW export (limit %.0fW)", -site.gridPower, *site.ExportLimit.Power)
+
+	return sitePower - site.ExportLimit.Margin
+}
+
+// updateCurtailment sets the inverter power limit to keep export below the limit
+func (site *Site) updateCurtailment() {
+	if site.curtailS == nil {
+		return
+	}
+
+	// pv power allowed to keep export at the limit
+	allowed := site.pvPower + *site.ExportLimit.Power + site.gridPower
+	limit := math.Round(100 * min(max(allowed/site.ExportLimit.PvPower, 0), 1))
+
+	// avoid needless writes
+	if math.Abs(limit-site.curtailLimit) < 1 {
+		return
+	}
+
+	site.log.DEBUG.Printf("export limit: curtail inverter to %.0f%%", limit)
+
+	if err := site.curtailS(limit); err != nil {
+		site.log.ERROR.Println("export limit:", err)
+		return
+	}
+
+	site.curtailLimit = limit
+	site.publish(keys.PvCurtailment, limit)
+}
@@ -0,0 +1,108 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestExportLimitSitePower(t *testing.T) {
+	site := NewSite()
+
+	// unlimited
+	site.gridPower = -5000
+	assert.Equal(t, -5000.0, site.exportLimitSitePower(-5000))
+
+	limit := 3000.0
+	site.ExportLimit = ExportLimitConfig{Power: &limit, Margin: 200}
+
+	// below limit
+	site.gridPower = -2000
+	assert.Equal(t, -2000.0, site.exportLimitSitePower(-2000))
+
+	// approaching limit
+	site.gridPower = -2900
+	assert.Equal(t, -3100.0, site.exportLimitSitePower(-2900))
+}
+
+func TestExportLimitZeroExport(t *testing.T) {
+	site := NewSite()
+
+	limit := 0.0
+	site.ExportLimit = ExportLimitConfig{Power: &limit, Margin: 200}
+
+	// importing, nothing curtailed
+	site.gridPower = 150
+	assert.False(t, site.exportLimited())
+	assert.Equal(t, 150.0, site.exportLimitSitePower(150))
+
+	// export pinned at zero by the inverter
+	site.gridPower = 0
+	assert.True(t, site.exportLimited())
+	assert.Equal(t, -200.0, site.exportLimitSitePower(0))
+
+	// curtailment control not limiting
+	site.curtailS = func(float64) error { return nil }
+	site.curtailLimit = 100
+	assert.False(t, site.exportLimited())
+	assert.Equal(t, 0.0, site.exportLimitSitePower(0))
+
+	// curtailment control limiting
+	site.curtailLimit = 60
+	assert.True(t, site.exportLimited())
+	assert.Equal(t, -200.0, site.exportLimitSitePower(0))
+}
+
+func TestExportLimitBatteryMode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	lp := loadpoint.NewMockAPI(ctrl)
+	lp.EXPECT().GetStatus().Return(api.StatusC).AnyTimes()
+	lp.EXPECT().IsFastChargingActive().Return(true).AnyTimes()
+
+	site := NewSite()
+
+	limit := 0.0
+	site.ExportLimit = ExportLimitConfig{Power: &limit}
+	site.curtailS = func(float64) error { return nil }
+
+	// battery is held while fast charging
+	site.curtailLimit = 100
+	assert.Equal(t, api.BatteryHold, site.determineBatteryMode([]loadpoint.API{lp}, false))
+
+	// battery absorbs curtailed power
+	site.curtailLimit = 60
+	assert.Equal(t, api.BatteryNormal, site.determineBatteryMode([]loadpoint.API{lp}, false))
+}
+
+func TestUpdateCurtailment(t *testing.T) {
+	var limits []float64
+
+	site := NewSite()
+	limit := 3000.0
+	site.ExportLimit = ExportLimitConfig{Power: &limit, PvPower: 10000}
+	site.curtailLimit = -1
+	site.curtailS = func(f float64) error {
+		limits = append(limits, f)
+		return nil
+	}
+
+	// export exceeds limit by 2000W
+	site.pvPower = 8000
+	site.gridPower = -5000
+	site.updateCurtailment()
+
+	// unchanged
+	site.pvPower = 6000
+	site.gridPower = -3000
+	site.updateCurtailment()
+
+	// consumption raised
+	site.gridPower = -1000
+	site.updateCurtailment()
+
+	assert.Equal(t, []float64{60, 80}, limits)
+}
@@ -0,0 +1,171 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/fleet"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/vehicle"
+	"github.com/evcc-io/evcc/server/db/settings"
+)
+
+type fleetStruct struct {
+	fleet.State
+	Assignments []fleet.Assignment `json:"assignments"`
+}
+
+// GetFleet returns reservations, queue and current loadpoint assignments
+func (site *Site) GetFleet() (fleet.State, []fleet.Assignment) {
+	site.RLock()
+	defer site.RUnlock()
+	return site.fleet.State(), slices.Clone(site.fleetAssignments)
+}
+
+// Reserve claims a loadpoint for a vehicle during a time window
+func (site *Site) Reserve(r fleet.Reservation) (fleet.Reservation, error) {
+	if r.Loadpoint > len(site.loadpoints) {
+		return r, fmt.Errorf("invalid loadpoint: %d", r.Loadpoint)
+	}
+
+	res, err := site.fleet.Reserve(r)
+	if err == nil {
+		site.log.DEBUG.Printf("fleet: loadpoint %d reserved for %s from %v to %v", res.Loadpoint, res.Vehicle, res.From.Round(0), res.To.Round(0))
+		site.persistFleet()
+	}
+
+	return res, err
+}
+
+// CancelReservation removes a reservation
+func (site *Site) CancelReservation(id int) error {
+	err := site.fleet.Cancel(id)
+	if err == nil {
+		site.log.DEBUG.Printf("fleet: reservation %d cancelled", id)
+		site.persistFleet()
+	}
+
+	return err
+}
+
+// Enqueue adds a vehicle waiting for a free loadpoint
+func (site *Site) Enqueue(r fleet.Request) error {
+	if _, err := site.Vehicles().ByName(r.Vehicle); err != nil {
+		return err
+	}
+
+	err := site.fleet.Enqueue(r)
+	if err == nil {
+		site.log.DEBUG.Printf("fleet: %s queued", r.Vehicle)
+		site.persistFleet()
+	}
+
+	return err
+}
+
+// Dequeue removes a vehicle from the queue
+func (site *Site) Dequeue(vehicle string) {
+	site.dequeue(vehicle)
+}
+
+// dequeue removes a vehicle from the queue and returns its request if it was queued
+func (site *Site) dequeue(vehicle string) (fleet.Request, bool) {
+	r, ok := site.fleet.Dequeue(vehicle)
+	if ok {
+		site.log.DEBUG.Printf("fleet: %s removed from queue", vehicle)
+		site.persistFleet()
+	}
+
+	return r, ok
+}
+
+// restoreFleet restores reservations and queue
+func (site *Site) restoreFleet() {
+	var state fleet.State
+	if err := settings.Json(keys.Fleet, &state); err == nil {
+		site.fleet.Restore(state)
+	}
+}
+
+// persistFleet stores reservations and queue
+func (site *Site) persistFleet() {
+	if err := settings.SetJson(keys.Fleet, site.fleet.State()); err != nil {
+		site.log.ERROR.Println("fleet:", err)
+	}
+
+	site.publishFleet()
+}
+
+func (site *Site) publishFleet() {
+	state, assignments := site.GetFleet()
+	site.publish(keys.Fleet, fleetStruct{State: state, Assignments: assignments})
+}
+
+// updateFleet removes connected vehicles from the queue and offers free loadpoints to waiting vehicles
+func (site *Site) updateFleet() {
+	var free []int
+
+	for id, lp := range site.loadpoints {
+		if lp.GetStatus() == api.StatusA {
+			free = append(free, id+1)
+			continue
+		}
+
+		if v := lp.GetVehicle(); v != nil {
+			if name := vehicle.Settings(site.log, v).Name(); name != "" {
+				// charge the queued vehicle's energy until departure
+				if r, ok := site.dequeue(name); ok && r.Departure != nil && r.Energy > 0 && r.Departure.After(time.Now()) {
+					site.log.DEBUG.Printf("fleet: loadpoint %d plan %.1fkWh until %v for %s", id+1, r.Energy, r.Departure.Round(time.Second), name)
+					if err := lp.SetPlanEnergy(*r.Departure, r.Energy); err != nil {
+						site.log.ERROR.Println("fleet:", err)
+					}
+				}
+			}
+		}
+	}
+
+	assignments := site.fleet.Assign(free)
+
+	site.Lock()
+	changed := !slices.Equal(assignments, site.fleetAssignments)
+	site.fleetAssignments = assignments
+	site.Unlock()
+
+	if changed {
+		for _, a := range assignments {
+			site.log.INFO.Printf("fleet: loadpoint %d assigned to %s", a.Loadpoint, a.Vehicle)
+		}
+
+		site.publishFleet()
+	}
+}
+
+// fleetPowerLimit stops charging other vehicles on a loadpoint reserved for a vehicle.
+// Vehicles that are not identified are allowed to charge since they may be the reserved vehicle.
+func (site *Site) fleetPowerLimit(lp updater) float64 {
+	id := slices.IndexFunc(site.loadpoints, func(l *Loadpoint) bool {
+		return updater(l) == lp
+	})
+	if id < 0 {
+		return 0
+	}
+
+	r, ok := site.fleet.Reservation(id + 1)
+	if !ok || lp.GetStatus() == api.StatusA {
+		return 0
+	}
+
+	v := lp.GetVehicle()
+	if v == nil {
+		return 0
+	}
+
+	if name := vehicle.Settings(site.log, v).Name(); name != "" && name != r.Vehicle {
+		site.log.DEBUG.Printf("fleet: loadpoint %d reserved for %s, not charging %s", id+1, r.Vehicle, name)
+		return powerLimitStop
+	}
+
+	return 0
+}
@@ -0,0 +1,77 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/fleet"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFleetPowerLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	reserved := api.NewMockVehicle(ctrl)
+	other := api.NewMockVehicle(ctrl)
+
+	for name, v := range map[string]api.Vehicle{"reserved": reserved, "other": other} {
+		require.NoError(t, config.Vehicles().Add(config.NewStaticDevice(config.Named{Name: name}, v)))
+		defer config.Vehicles().Delete(name)
+	}
+
+	lp := NewLoadpoint(util.NewLogger("foo"), nil)
+	lp.status = api.StatusB
+
+	site := NewSite()
+	site.loadpoints = []*Loadpoint{lp}
+
+	// not reserved
+	lp.vehicle = other
+	assert.Zero(t, site.fleetPowerLimit(lp))
+
+	now := time.Now()
+	_, err := site.fleet.Reserve(fleet.Reservation{Loadpoint: 1, Vehicle: "reserved", From: now.Add(-time.Minute), To: now.Add(time.Hour)})
+	require.NoError(t, err)
+
+	// other vehicle is stopped
+	assert.Equal(t, powerLimitStop, site.fleetPowerLimit(lp))
+
+	// reserved or unidentified vehicle charges
+	lp.vehicle = reserved
+	assert.Zero(t, site.fleetPowerLimit(lp))
+
+	lp.vehicle = nil
+	assert.Zero(t, site.fleetPowerLimit(lp))
+}
+
+func TestFleetDeparture(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	v := api.NewMockVehicle(ctrl)
+	v.EXPECT().Capacity().Return(50.0).AnyTimes()
+	require.NoError(t, config.Vehicles().Add(config.NewStaticDevice(config.Named{Name: "queued"}, api.Vehicle(v))))
+	defer config.Vehicles().Delete("queued")
+
+	lp := NewLoadpoint(util.NewLogger("foo"), nil)
+	lp.status = api.StatusB
+
+	site := NewSite()
+	site.loadpoints = []*Loadpoint{lp}
+
+	departure := time.Now().Add(4 * time.Hour).Truncate(time.Minute)
+	require.NoError(t, site.fleet.Enqueue(fleet.Request{Vehicle: "queued", Departure: &departure, Energy: 20}))
+
+	// connected queued vehicle charges its energy until departure
+	lp.vehicle = v
+	site.updateFleet()
+
+	ts, energy := lp.GetPlanEnergy()
+	assert.True(t, departure.Equal(ts))
+	assert.Equal(t, 20.0, energy)
+	assert.Empty(t, site.fleet.State().Queue)
+}
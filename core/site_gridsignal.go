@@ -0,0 +1,161 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/evcc-io/evcc/core/audit"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/provider"
+)
+
+// GridSignalConfig caps charging site-wide on external curtailment or frequency response signals, e.g. ripple control relays
+type GridSignalConfig struct {
+	Curtail      *provider.Config `mapstructure:"curtail"`      // plugin returning true while curtailment is requested
+	Frequency    *provider.Config `mapstructure:"frequency"`    // plugin returning the grid frequency (Hz)
+	MinFrequency float64          `mapstructure:"minFrequency"` // curtail while grid frequency is below (Hz)
+	Power        float64          `mapstructure:"power"`        // total charge power while curtailed (W), 0 stops charging
+	Release      time.Duration    `mapstructure:"release"`      // delay after the signal ends before releasing curtailment
+	Timeout      time.Duration    `mapstructure:"timeout"`      // automatic release if the signal persists, 0 disables
+}
+
+// gridSignal is the curtailment state
+type gridSignal struct {
+	curtailG   func() (bool, error)
+	frequencyG func() (float64, error)
+	active     bool      // curtailment applied
+	expired    bool      // released by timeout until the signal ends
+	since      time.Time // curtailment start
+	cleared    time.Time // signal end while active
+}
+
+// configureGridSignal creates the curtailment and frequency signals from configuration
+func (site *Site) configureGridSignal() error {
+	conf := &site.GridSignal
+
+	if conf.Power < 0 {
+		return errors.New("grid signal: power must not be negative")
+	}
+
+	if conf.Curtail != nil {
+		curtailG, err := provider.NewBoolGetterFromConfig(*conf.Curtail)
+		if err != nil {
+			return fmt.Errorf("grid signal: curtail: %w", err)
+		}
+		site.gridSignal.curtailG = curtailG
+	}
+
+	if conf.Frequency != nil {
+		if conf.MinFrequency == 0 {
+			conf.MinFrequency = 49.8
+		}
+
+		frequencyG, err := provider.NewFloatGetterFromConfig(*conf.Frequency)
+		if err != nil {
+			return fmt.Errorf("grid signal: frequency: %w", err)
+		}
+		site.gridSignal.frequencyG = frequencyG
+	}
+
+	return nil
+}
+
+// gridSignalRequested returns true if any signal requests curtailment and the reason
+func (site *Site) gridSignalRequested() (bool, string, error) {
+	if g := site.gridSignal.curtailG; g != nil {
+		curtail, err := g()
+		if err != nil {
+			return false, "", fmt.Errorf("curtail: %w", err)
+		}
+		if curtail {
+			return true, "curtail", nil
+		}
+	}
+
+	if g := site.gridSignal.frequencyG; g != nil {
+		f, err := g()
+		if err != nil {
+			return false, "", fmt.Errorf("frequency: %w", err)
+		}
+		if f > 0 && f < site.GridSignal.MinFrequency {
+			return true, fmt.Sprintf("frequency %.2fHz", f), nil
+		}
+	}
+
+	return false, "", nil
+}
+
+// updateGridSignal evaluates the grid signals and applies or releases curtailment.
+// While signals can't be read, the current state is kept.
+func (site *Site) updateGridSignal(now time.Time) {
+	gs := &site.gridSignal
+	if gs.curtailG == nil && gs.frequencyG == nil {
+		return
+	}
+
+	requested, reason, err := site.gridSignalRequested()
+	if err != nil {
+		site.log.ERROR.Println("grid signal:", err)
+		return
+	}
+
+	if !requested {
+		gs.expired = false
+	}
+
+	switch {
+	case requested && !gs.active && !gs.expired:
+		site.setGridSignal(true, reason, now)
+
+	case gs.active && requested:
+		gs.cleared = time.Time{}
+
+		if timeout := site.GridSignal.Timeout; timeout > 0 && now.Sub(gs.since) >= timeout {
+			gs.expired = true
+			site.setGridSignal(false, "timeout", now)
+		}
+
+	case gs.active:
+		if gs.cleared.IsZero() {
+			gs.cleared = now
+		}
+
+		if now.Sub(gs.cleared) >= site.GridSignal.Release {
+			site.setGridSignal(false, "released", now)
+		}
+	}
+}
+
+// setGridSignal applies or releases curtailment and logs the event
+func (site *Site) setGridSignal(active bool, reason string, now time.Time) {
+	gs := &site.gridSignal
+	gs.active = active
+	gs.since = now
+	gs.cleared = time.Time{}
+
+	if active {
+		site.log.WARN.Printf("grid signal: curtailing charge power to %.0fW (%s)", site.GridSignal.Power, reason)
+	} else {
+		site.log.INFO.Printf("grid signal: curtailment %s", reason)
+	}
+
+	audit.Record(audit.Entry{
+		Created: now,
+		Source:  audit.SourceSignal,
+		Action:  "curtail",
+		Value:   fmt.Sprintf("%t (%s)", active, reason),
+	})
+
+	site.publish(keys.GridCurtailed, active)
+}
+
+// gridSignalPowerLimit returns the charge power available to the loadpoint while curtailed, 0 for unlimited
+func (site *Site) gridSignalPowerLimit(lp updater, totalChargePower float64) float64 {
+	if !site.gridSignal.active {
+		return 0
+	}
+
+	// capacity not used by other loadpoints
+	return max(site.GridSignal.Power-(totalChargePower-lp.GetChargePower()), powerLimitStop)
+}
@@ -0,0 +1,71 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGridSignal(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+
+	var curtail bool
+	frequency := 50.0
+
+	site := NewSite()
+	site.GridSignal = GridSignalConfig{MinFrequency: 49.8, Power: 4200, Release: time.Minute, Timeout: time.Hour}
+	site.gridSignal.curtailG = func() (bool, error) { return curtail, nil }
+	site.gridSignal.frequencyG = func() (float64, error) { return frequency, nil }
+
+	site.updateGridSignal(now)
+	assert.False(t, site.gridSignal.active)
+
+	// curtailment applies immediately
+	curtail = true
+	site.updateGridSignal(now)
+	assert.True(t, site.gridSignal.active)
+
+	// release is delayed
+	curtail = false
+	site.updateGridSignal(now.Add(time.Minute))
+	assert.True(t, site.gridSignal.active)
+	site.updateGridSignal(now.Add(2 * time.Minute))
+	assert.False(t, site.gridSignal.active)
+
+	// under-frequency
+	frequency = 49.7
+	site.updateGridSignal(now.Add(3 * time.Minute))
+	assert.True(t, site.gridSignal.active)
+
+	// automatic release after timeout until the signal ends
+	site.updateGridSignal(now.Add(2 * time.Hour))
+	assert.False(t, site.gridSignal.active)
+	site.updateGridSignal(now.Add(3 * time.Hour))
+	assert.False(t, site.gridSignal.active)
+
+	frequency = 50
+	site.updateGridSignal(now.Add(4 * time.Hour))
+	curtail = true
+	site.updateGridSignal(now.Add(5 * time.Hour))
+	assert.True(t, site.gridSignal.active)
+}
+
+func TestGridSignalPowerLimit(t *testing.T) {
+	site := NewSite()
+	site.GridSignal.Power = 4200
+
+	lp := NewLoadpoint(util.NewLogger("foo"), nil)
+	lp.chargePower = 1000
+
+	assert.Equal(t, 0.0, site.gridSignalPowerLimit(lp, 3000))
+
+	// other loadpoints use 2000W
+	site.gridSignal.active = true
+	assert.Equal(t, 2200.0, site.gridSignalPowerLimit(lp, 3000))
+
+	// stop charging
+	site.GridSignal.Power = 0
+	assert.Equal(t, powerLimitStop, site.gridSignalPowerLimit(lp, 3000))
+}
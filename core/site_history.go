@@ -0,0 +1,29 @@
+package core
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/core/history"
+)
+
+// updateHistory records the current power flows for the ui history
+func (site *Site) updateHistory(homePower float64) {
+	if site.history == nil {
+		return
+	}
+
+	lps := make([]float64, 0, len(site.loadpoints))
+	for _, lp := range site.loadpoints {
+		lps = append(lps, lp.GetChargePower())
+	}
+
+	site.history.Add(history.Flow{
+		Created:    time.Now(),
+		Grid:       site.gridPower,
+		PV:         site.pvPower,
+		Battery:    site.batteryPower,
+		Home:       homePower,
+		Loadpoints: lps,
+		Consumers:  site.consumerPowers(),
+	})
+}
@@ -0,0 +1,98 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/provider"
+)
+
+// OffGridConfig defines the site behaviour while running from a generator or in island mode
+type OffGridConfig struct {
+	Signal      *provider.Config `mapstructure:"signal"`      // plugin returning true while off-grid
+	Power       float64          `mapstructure:"power"`       // generator capacity available for charging (W)
+	PrioritySoc float64          `mapstructure:"prioritySoc"` // battery has priority below this soc while off-grid
+	ReserveSoc  float64          `mapstructure:"reserveSoc"`  // vehicle charging is paused below this battery soc while off-grid
+}
+
+// configureOffGrid creates the off-grid signal from configuration or the meters' backup mode
+func (site *Site) configureOffGrid() error {
+	if site.OffGrid.Signal == nil {
+		for _, m := range append([]api.Meter{site.gridMeter}, site.batteryMeters...) {
+			if bm, ok := m.(api.BackupMode); ok {
+				site.log.DEBUG.Println("off-grid: using meter backup mode")
+				site.offGridG = bm.BackupMode
+				break
+			}
+		}
+
+		return nil
+	}
+
+	offGridG, err := provider.NewBoolGetterFromConfig(*site.OffGrid.Signal)
+	if err != nil {
+		return fmt.Errorf("off-grid: %w", err)
+	}
+
+	site.offGridG = offGridG
+
+	return nil
+}
+
+// updateOffGrid evaluates the off-grid signal
+func (site *Site) updateOffGrid() {
+	if site.offGridG == nil {
+		return
+	}
+
+	offGrid, err := site.offGridG()
+	if err != nil {
+		site.log.ERROR.Println("off-grid:", err)
+		return
+	}
+
+	if offGrid != site.isOffGrid() {
+		site.log.INFO.Println("off-grid:", offGrid)
+
+		site.Lock()
+		site.offGrid = offGrid
+		site.Unlock()
+
+		site.publish(keys.OffGrid, offGrid)
+	}
+}
+
+// isOffGrid returns true while running from a generator or in island mode
+func (site *Site) isOffGrid() bool {
+	site.RLock()
+	defer site.RUnlock()
+	return site.offGrid
+}
+
+// offGridPowerLimit returns the charge power available to the loadpoint while off-grid, 0 for unlimited
+func (site *Site) offGridPowerLimit(lp updater, totalChargePower float64) float64 {
+	if !site.isOffGrid() {
+		return 0
+	}
+
+	// protect battery reserve
+	if site.OffGrid.ReserveSoc > 0 && len(site.batteryMeters) > 0 && site.batterySoc < site.OffGrid.ReserveSoc {
+		return powerLimitStop
+	}
+
+	if site.OffGrid.Power <= 0 {
+		return 0
+	}
+
+	// capacity not used by other loadpoints
+	return max(site.OffGrid.Power-(totalChargePower-lp.GetChargePower()), powerLimitStop)
+}
+
+// effectivePrioritySoc returns the priority soc considering off-grid operation. Caller must hold the lock.
+func (site *Site) effectivePrioritySoc() float64 {
+	if site.offGrid && site.OffGrid.PrioritySoc > 0 {
+		return site.OffGrid.PrioritySoc
+	}
+	return site.prioritySoc
+}
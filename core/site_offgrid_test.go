@@ -0,0 +1,101 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOffGridPowerLimit(t *testing.T) {
+	site := NewSite()
+	site.OffGrid = OffGridConfig{Power: 5000}
+
+	lp := NewLoadpoint(util.NewLogger("foo"), nil)
+	lp.chargePower = 2000
+
+	// grid connected
+	assert.Equal(t, 0.0, site.offGridPowerLimit(lp, 3000))
+
+	site.offGrid = true
+
+	// other loadpoints use 1000W
+	assert.Equal(t, 4000.0, site.offGridPowerLimit(lp, 3000))
+
+	// other loadpoints exceed capacity
+	assert.Equal(t, powerLimitStop, site.offGridPowerLimit(lp, 8000))
+}
+
+func TestOffGridReserve(t *testing.T) {
+	site := NewSite()
+	site.OffGrid = OffGridConfig{ReserveSoc: 40}
+	site.batteryMeters = []api.Meter{nil}
+	site.batterySoc = 30
+
+	lp := NewLoadpoint(util.NewLogger("foo"), nil)
+
+	// grid connected
+	assert.Equal(t, 0.0, site.offGridPowerLimit(lp, 0))
+
+	// paused below reserve
+	site.offGrid = true
+	assert.Equal(t, powerLimitStop, site.offGridPowerLimit(lp, 0))
+
+	// resumed above reserve
+	site.batterySoc = 40
+	assert.Equal(t, 0.0, site.offGridPowerLimit(lp, 0))
+}
+
+type backupMeter struct {
+	api.Meter
+	backup bool
+}
+
+func (m *backupMeter) BackupMode() (bool, error) {
+	return m.backup, nil
+}
+
+func TestOffGridBackupMode(t *testing.T) {
+	bm := new(backupMeter)
+
+	site := NewSite()
+	site.batteryMeters = []api.Meter{bm}
+	assert.NoError(t, site.configureOffGrid())
+
+	site.updateOffGrid()
+	assert.False(t, site.isOffGrid())
+
+	bm.backup = true
+	site.updateOffGrid()
+	assert.True(t, site.isOffGrid())
+}
+
+func TestOffGridBattery(t *testing.T) {
+	site := NewSite()
+	site.prioritySoc = 20
+	site.bufferSoc = 50
+	site.OffGrid = OffGridConfig{PrioritySoc: 80}
+
+	assert.Equal(t, 20.0, site.effectivePrioritySoc())
+	assert.Equal(t, 50.0, site.effectiveBufferSoc())
+
+	site.offGrid = true
+
+	assert.Equal(t, 80.0, site.effectivePrioritySoc())
+	assert.Equal(t, 0.0, site.effectiveBufferSoc())
+}
+
+func TestUpdateOffGrid(t *testing.T) {
+	site := NewSite()
+
+	var signal bool
+	site.offGridG = func() (bool, error) { return signal, nil }
+
+	site.updateOffGrid()
+	assert.False(t, site.isOffGrid())
+
+	signal = true
+	site.updateOffGrid()
+	assert.True(t, site.isOffGrid())
+}
@@ -0,0 +1,144 @@
+package core
+
+import (
+	"errors"
+	"maps"
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/server/db/settings"
+)
+
+// PeakShavingConfig limits the average grid import per demand interval to avoid demand charges
+type PeakShavingConfig struct {
+	Limit    float64       `mapstructure:"limit"`    // max average grid import per interval (W)
+	Interval time.Duration `mapstructure:"interval"` // demand measurement interval
+}
+
+// peakDemand tracks grid import of the current demand interval and monthly peaks
+type peakDemand struct {
+	start   time.Time          // current interval start
+	updated time.Time          // last grid power sample
+	energy  float64            // grid import of current interval (Wh)
+	peaks   map[string]float64 // peak demand (W) per month
+}
+
+const peakMonthFormat = "2006-01"
+
+// accumulate adds grid import between from and to within the current interval
+func (p *peakDemand) accumulate(power float64, from, to time.Time, interval time.Duration) {
+	if from.Before(p.start) {
+		from = p.start
+	}
+	if end := p.start.Add(interval); to.After(end) {
+		to = end
+	}
+	if to.After(from) {
+		p.energy += max(power, 0) * to.Sub(from).Hours()
+	}
+}
+
+// configurePeakShaving validates peak shaving configuration and restores monthly peaks
+func (site *Site) configurePeakShaving() error {
+	if site.PeakShaving.Limit < 0 {
+		return errors.New("peak shaving: limit must not be negative")
+	}
+
+	if site.PeakShaving.Interval == 0 {
+		site.PeakShaving.Interval = 15 * time.Minute
+	}
+
+	site.peak.peaks = make(map[string]float64)
+
+	if !testing.Testing() {
+		if err := settings.Json(keys.PeakDemands, &site.peak.peaks); err != nil && !errors.Is(err, settings.ErrNotFound) {
+			site.log.ERROR.Println("peak shaving:", err)
+		}
+	}
+
+	return nil
+}
+
+// updatePeakDemand accumulates grid import and closes finished demand intervals
+func (site *Site) updatePeakDemand(now time.Time) {
+	interval := site.PeakShaving.Interval
+	if interval == 0 {
+		return
+	}
+
+	p := &site.peak
+
+	// accumulate import since last sample, split at the interval boundary
+	if !p.updated.IsZero() {
+		p.accumulate(site.gridPower, p.updated, now, interval)
+	}
+
+	if start := now.Truncate(interval); !start.Equal(p.start) {
+		if !p.start.IsZero() {
+			site.closePeakInterval()
+		}
+
+		p.start = start
+		p.energy = 0
+
+		if !p.updated.IsZero() {
+			p.accumulate(site.gridPower, p.updated, now, interval)
+		}
+	}
+
+	p.updated = now
+
+	site.publish(keys.PeakDemand, site.peakDemandForecast(now))
+}
+
+// closePeakInterval records the average demand of the finished interval as monthly peak
+func (site *Site) closePeakInterval() {
+	p := &site.peak
+
+	demand := p.energy / site.PeakShaving.Interval.Hours()
+	month := p.start.Format(peakMonthFormat)
+
+	if demand <= p.peaks[month] {
+		return
+	}
+
+	site.log.DEBUG.Printf("peak shaving: new %s peak %.0fW", month, demand)
+
+	p.peaks[month] = demand
+	site.publish(keys.PeakDemands, maps.Clone(p.peaks))
+
+	if !testing.Testing() {
+		if err := settings.SetJson(keys.PeakDemands, p.peaks); err != nil {
+			site.log.ERROR.Println("peak shaving:", err)
+		}
+	}
+}
+
+// peakDemandForecast estimates the current interval's average demand assuming current grid power is maintained
+func (site *Site) peakDemandForecast(now time.Time) float64 {
+	p := &site.peak
+	remaining := p.start.Add(site.PeakShaving.Interval).Sub(now)
+	return (p.energy + max(site.gridPower, 0)*remaining.Hours()) / site.PeakShaving.Interval.Hours()
+}
+
+// peakPowerLimit returns the charge power available to the loadpoint without exceeding the demand limit, 0 for unlimited
+func (site *Site) peakPowerLimit(lp updater, totalChargePower float64, now time.Time) float64 {
+	if site.PeakShaving.Limit == 0 || site.peak.start.IsZero() {
+		return 0
+	}
+
+	interval := site.PeakShaving.Interval
+	remaining := site.peak.start.Add(interval).Sub(now)
+	if remaining <= 0 {
+		return 0
+	}
+
+	// grid power allowed for the remainder of the interval
+	allowed := (site.PeakShaving.Limit*interval.Hours() - site.peak.energy) / remaining.Hours()
+
+	// remove consumption not caused by loadpoints and other loadpoints' charging
+	budget := allowed - (site.gridPower - totalChargePower) - (totalChargePower - lp.GetChargePower())
+
+	return max(budget, powerLimitStop)
+}
@@ -0,0 +1,64 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeakDemand(t *testing.T) {
+	site := NewSite()
+	site.PeakShaving = PeakShavingConfig{Limit: 10000}
+	assert.NoError(t, site.configurePeakShaving())
+
+	start := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	// 8kW for 5 minutes, 20kW for 10 minutes
+	site.gridPower = 8000
+	site.updatePeakDemand(start)
+	site.updatePeakDemand(start.Add(5 * time.Minute))
+	assert.Equal(t, 8000.0, site.peakDemandForecast(start.Add(5*time.Minute)))
+
+	site.gridPower = 20000
+	site.updatePeakDemand(start.Add(10 * time.Minute))
+	assert.InDelta(t, 16000.0, site.peakDemandForecast(start.Add(10*time.Minute)), 1e-6)
+
+	// next interval closes previous one
+	site.updatePeakDemand(start.Add(16 * time.Minute))
+	assert.InDelta(t, 16000.0, site.peak.peaks["2026-10"], 1e-6)
+	assert.InDelta(t, 1000.0/3, site.peak.energy, 1e-6)
+
+	// lower interval does not change monthly peak
+	site.gridPower = 4000
+	site.updatePeakDemand(start.Add(31 * time.Minute))
+	assert.InDelta(t, 16000.0, site.peak.peaks["2026-10"], 1e-6)
+}
+
+func TestPeakPowerLimit(t *testing.T) {
+	site := NewSite()
+	site.PeakShaving = PeakShavingConfig{Limit: 10000}
+	assert.NoError(t, site.configurePeakShaving())
+
+	lp := NewLoadpoint(util.NewLogger("foo"), nil)
+	lp.chargePower = 4000
+
+	start := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	site.gridPower = 12000
+	site.updatePeakDemand(start)
+	site.updatePeakDemand(start.Add(5 * time.Minute))
+
+	// 1kWh used, 1.5kWh left for 10 minutes allows 9kW grid import, 8kW is household
+	assert.InDelta(t, 1000.0, site.peakPowerLimit(lp, 4000, start.Add(5*time.Minute)), 1e-6)
+
+	// unlimited without limit
+	site.PeakShaving.Limit = 0
+	assert.Equal(t, 0.0, site.peakPowerLimit(lp, 4000, start.Add(5*time.Minute)))
+}
+
+func TestMinPowerLimit(t *testing.T) {
+	assert.Equal(t, 0.0, minPowerLimit(0, 0))
+	assert.Equal(t, 2000.0, minPowerLimit(0, 2000))
+	assert.Equal(t, 1000.0, minPowerLimit(3000, 1000))
+}
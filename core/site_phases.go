@@ -0,0 +1,48 @@
+package core
+
+import (
+	"math"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+// phasePowerLimit limits pv charging to the surplus of each phase the loadpoint is charging on.
+// A loadpoint charging single-phase on a heavily loaded phase would otherwise cause import
+// on that phase while the other phases export.
+// Only applies if enabled, since balancing meters settle import and export across phases.
+func (site *Site) phasePowerLimit(lp updater) float64 {
+	if !site.PhaseSurplus || site.gridCurrents == nil {
+		return 0
+	}
+
+	if mode := lp.GetMode(); mode != api.ModePV && mode != api.ModeMinPV {
+		return 0
+	}
+
+	currents := lp.GetChargeCurrents()
+	if len(currents) != len(site.gridCurrents) {
+		return 0
+	}
+
+	limit := math.MaxFloat64
+	var phases int
+
+	for i, current := range currents {
+		if current < minActiveCurrent {
+			continue
+		}
+
+		// loadpoint current plus export of this phase
+		limit = min(limit, current-site.gridCurrents[i])
+		phases++
+	}
+
+	if phases == 0 {
+		return 0
+	}
+
+	// enabling and disabling is decided on total surplus including hysteresis
+	limit = max(limit, lp.GetMinCurrent())
+
+	return limit * float64(phases) * lp.GetVoltage()
+}
@@ -0,0 +1,46 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhasePowerLimit(t *testing.T) {
+	Voltage = 230 // V
+
+	site := NewSite()
+	site.PhaseSurplus = true
+
+	lp := NewLoadpoint(util.NewLogger("foo"), nil)
+	lp.mode = api.ModePV
+	lp.minCurrent = 6
+	lp.chargeCurrents = []float64{8, 0, 0}
+
+	// unknown grid currents
+	assert.Equal(t, 0.0, site.phasePowerLimit(lp))
+
+	// charging on L1 which exports 2A while L2/L3 export more
+	site.gridCurrents = []float64{-2, -10, -10}
+	assert.InDelta(t, 10*230.0, site.phasePowerLimit(lp), 1e-6)
+
+	// import on L1 limits to min current, disabling is left to the surplus hysteresis
+	site.gridCurrents = []float64{3, -10, -10}
+	assert.InDelta(t, 6*230.0, site.phasePowerLimit(lp), 1e-6)
+
+	// three-phase charging is limited by the weakest phase
+	lp.chargeCurrents = []float64{8, 8, 8}
+	site.gridCurrents = []float64{-1, -5, -5}
+	assert.InDelta(t, 9*3*230.0, site.phasePowerLimit(lp), 1e-6)
+
+	// not applicable outside pv modes
+	lp.mode = api.ModeNow
+	assert.Equal(t, 0.0, site.phasePowerLimit(lp))
+
+	// disabled by default
+	lp.mode = api.ModePV
+	site.PhaseSurplus = false
+	assert.Equal(t, 0.0, site.phasePowerLimit(lp))
+}
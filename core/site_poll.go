@@ -0,0 +1,125 @@
+package core
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/evcc-io/evcc/api"
+	"golang.org/x/sync/errgroup"
+)
+
+// currentPower reads the meter's power with retry. The reading is cancelled with the context if supported by the meter.
+func currentPower(ctx context.Context, meter api.Meter) (float64, error) {
+	return backoff.RetryWithData(func() (float64, error) {
+		if m, ok := meter.(api.MeterContext); ok {
+			return m.CurrentPowerContext(ctx)
+		}
+		return meter.CurrentPower()
+	}, backoff.WithContext(bo(), ctx))
+}
+
+type powerReading struct {
+	power float64
+	err   error
+}
+
+// siteMeters returns all site meters with their configured references
+func (site *Site) siteMeters() map[api.Meter]string {
+	res := make(map[api.Meter]string)
+
+	add := func(meters []api.Meter, refs []string) {
+		for i, meter := range meters {
+			var ref string
+			if i < len(refs) {
+				ref = refs[i]
+			}
+			res[meter] = ref
+		}
+	}
+
+	add(site.auxMeters, site.Meters.AuxMetersRef)
+	add(site.consumerMeters())
+	add(site.batteryMeters, site.Meters.BatteryMetersRef)
+	add(site.pvMeters, site.Meters.PVMetersRef)
+
+	if site.gridMeter != nil {
+		res[site.gridMeter] = site.Meters.GridMeterRef
+	}
+
+	return res
+}
+
+// meterPoller returns the meter's background power poller
+func (site *Site) meterPoller(meter api.Meter, ref string) *poller[float64] {
+	if site.meterPollers == nil {
+		site.meterPollers = make(map[api.Meter]*poller[float64])
+	}
+
+	p, ok := site.meterPollers[meter]
+	if !ok {
+		p = newPoller(func(ctx context.Context) (float64, error) {
+			start := time.Now()
+			res, err := currentPower(ctx, meter)
+			site.Health.Device(ref, time.Since(start), err)
+			return res, err
+		}, site.interval)
+		site.meterPollers[meter] = p
+	}
+
+	return p
+}
+
+// pollMeters reads the power of all site meters concurrently
+func (site *Site) pollMeters() {
+	var (
+		mu  sync.Mutex
+		eg  errgroup.Group
+		res = make(map[api.Meter]powerReading)
+	)
+
+	eg.SetLimit(pollWorkers)
+
+	for meter, ref := range site.siteMeters() {
+		p := site.meterPoller(meter, ref)
+
+		eg.Go(func() error {
+			power, err := p.Get()
+
+			mu.Lock()
+			res[meter] = powerReading{power: power, err: err}
+			mu.Unlock()
+
+			return nil
+		})
+	}
+
+	_ = eg.Wait()
+
+	site.meterPowers = res
+}
+
+// meterPower returns the meter's power reading of the current control loop
+func (site *Site) meterPower(meter api.Meter) (float64, error) {
+	if r, ok := site.meterPowers[meter]; ok {
+		return r.power, r.err
+	}
+
+	return site.meterPoller(meter, "").Get()
+}
+
+// pollChargePower reads the charge power of all loadpoints concurrently
+func (site *Site) pollChargePower() {
+	var eg errgroup.Group
+	eg.SetLimit(pollWorkers)
+
+	for _, lp := range site.loadpoints {
+		eg.Go(func() error {
+			lp.UpdateChargePower()
+			return nil
+		})
+	}
+
+	_ = eg.Wait()
+}
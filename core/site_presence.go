@@ -0,0 +1,210 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/provider"
+	"github.com/evcc-io/evcc/util/config"
+)
+
+// PresenceConfig defines occupancy detection and the charging policy applied while nobody is home
+type PresenceConfig struct {
+	Home     *provider.Config `mapstructure:"home"`     // plugin returning true while somebody is home
+	Geofence GeofenceConfig   `mapstructure:"geofence"` // home while any vehicle is within the geofence
+	Interval time.Duration    `mapstructure:"interval"` // presence update interval
+	Away     AwayConfig       `mapstructure:"away"`     // policy applied while away
+	Arrival  []ArrivalConfig  `mapstructure:"arrival"`  // vehicle arrival automation within the geofence
+}
+
+// GeofenceConfig defines the home area for vehicle position based presence detection
+type GeofenceConfig struct {
+	Lat, Lon float64
+	Radius   float64  // m
+	Vehicles []string // vehicle references
+}
+
+// AwayConfig defines the charging policy applied while nobody is home
+type AwayConfig struct {
+	Mode      api.ChargeMode `mapstructure:"mode"`      // loadpoint charge mode
+	Priority  int            `mapstructure:"priority"`  // loadpoint priority
+	BufferSoc float64        `mapstructure:"bufferSoc"` // allow battery to feed vehicles above this soc
+}
+
+// homePolicy stores a loadpoint's settings before leaving
+type homePolicy struct {
+	mode     api.ChargeMode
+	priority int
+}
+
+// configurePresence creates the presence detection from configuration
+func (site *Site) configurePresence() error {
+	conf := site.Presence
+
+	if conf.Home != nil {
+		homeG, err := provider.NewBoolGetterFromConfig(*conf.Home)
+		if err != nil {
+			return fmt.Errorf("presence: %w", err)
+		}
+		site.presenceG = append(site.presenceG, homeG)
+	}
+
+	if len(conf.Geofence.Vehicles) > 0 {
+		if conf.Geofence.Radius <= 0 {
+			return errors.New("presence: missing geofence radius")
+		}
+
+		var vehicles []api.VehiclePosition
+		for _, ref := range conf.Geofence.Vehicles {
+			dev, err := config.Vehicles().ByName(ref)
+			if err != nil {
+				return fmt.Errorf("presence: %w", err)
+			}
+
+			v, ok := dev.Instance().(api.VehiclePosition)
+			if !ok {
+				return fmt.Errorf("presence: vehicle %s does not provide position", ref)
+			}
+
+			vehicles = append(vehicles, v)
+		}
+
+		site.presenceG = append(site.presenceG, geofence(conf.Geofence, vehicles))
+	}
+
+	if site.Presence.Interval == 0 {
+		site.Presence.Interval = 5 * time.Minute
+	}
+
+	return nil
+}
+
+// geofence returns a presence getter that is true if any vehicle is within the geofence
+func geofence(conf GeofenceConfig, vehicles []api.VehiclePosition) func() (bool, error) {
+	return func() (bool, error) {
+		var err error
+		for _, v := range vehicles {
+			lat, lon, perr := v.Position()
+			if perr != nil {
+				err = perr
+				continue
+			}
+
+			if distance(conf.Lat, conf.Lon, lat, lon) <= conf.Radius {
+				return true, nil
+			}
+		}
+		return false, err
+	}
+}
+
+// distance returns the great circle distance between two coordinates in meters
+func distance(lat1, lon1, lat2, lon2 float64) float64 {
+	const earthRadius = 6371e3 // m
+
+	rad := func(deg float64) float64 { return deg * math.Pi / 180 }
+
+	dLat := rad(lat2 - lat1)
+	dLon := rad(lon2 - lon1)
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(rad(lat1))*math.Cos(rad(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+
+	return 2 * earthRadius * math.Asin(math.Sqrt(a))
+}
+
+// updatePresence evaluates presence detection and applies the away policy on change
+func (site *Site) updatePresence() {
+	if len(site.presenceG) == 0 || time.Since(site.presenceUpdated) < site.Presence.Interval {
+		return
+	}
+
+	site.presenceUpdated = time.Now()
+
+	// home if any source reports presence
+	var home bool
+	for _, homeG := range site.presenceG {
+		res, err := homeG()
+		if err != nil {
+			site.log.ERROR.Println("presence:", err)
+			return
+		}
+
+		if res {
+			home = true
+			break
+		}
+	}
+
+	if away := !home; away != site.isAway() {
+		site.setAway(away)
+	}
+}
+
+// isAway returns true if nobody is home
+func (site *Site) isAway() bool {
+	site.RLock()
+	defer site.RUnlock()
+	return site.away
+}
+
+// setAway applies or reverts the away policy
+func (site *Site) setAway(away bool) {
+	site.log.DEBUG.Println("set away:", away)
+
+	site.Lock()
+	site.away = away
+	site.Unlock()
+
+	site.publish(keys.Away, away)
+
+	policy := site.Presence.Away
+
+	if away {
+		site.homePolicies = make(map[*Loadpoint]homePolicy, len(site.loadpoints))
+
+		for _, lp := range site.loadpoints {
+			site.homePolicies[lp] = homePolicy{
+				mode:     lp.GetMode(),
+				priority: lp.GetPriority(),
+			}
+
+			if policy.Mode != "" {
+				lp.SetMode(policy.Mode)
+			}
+			if policy.Priority > 0 {
+				lp.SetPriority(policy.Priority)
+			}
+		}
+
+		return
+	}
+
+	// restore settings unless changed by the user while away
+	for lp, home := range site.homePolicies {
+		if policy.Mode != "" && lp.GetMode() == policy.Mode {
+			lp.SetMode(home.mode)
+		}
+		if policy.Priority > 0 && lp.GetPriority() == policy.Priority {
+			lp.SetPriority(home.priority)
+		}
+	}
+
+	site.homePolicies = nil
+}
+
+// effectiveBufferSoc returns the buffer soc considering off-grid operation and the away policy. Caller must hold the lock.
+func (site *Site) effectiveBufferSoc() float64 {
+	// battery must not feed vehicles while off-grid
+	if site.offGrid {
+		return 0
+	}
+	if site.away && site.Presence.Away.BufferSoc > 0 {
+		return site.Presence.Away.BufferSoc
+	}
+	return site.bufferSoc
+}
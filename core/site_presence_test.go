@@ -0,0 +1,44 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistance(t *testing.T) {
+	assert.Equal(t, 0.0, distance(52.52, 13.40, 52.52, 13.40))
+	// Berlin to Hamburg
+	assert.InDelta(t, 255e3, distance(52.52, 13.40, 53.55, 9.99), 2e3)
+}
+
+func TestSetAway(t *testing.T) {
+	lp1 := NewLoadpoint(util.NewLogger("foo"), nil)
+	lp1.mode = api.ModePV
+
+	lp2 := NewLoadpoint(util.NewLogger("foo"), nil)
+	lp2.mode = api.ModeNow
+
+	site := NewSite()
+	site.loadpoints = []*Loadpoint{lp1, lp2}
+	site.bufferSoc = 80
+	site.Presence.Away = AwayConfig{
+		Mode:      api.ModeMinPV,
+		BufferSoc: 30,
+	}
+
+	site.setAway(true)
+	assert.Equal(t, api.ModeMinPV, lp1.GetMode())
+	assert.Equal(t, api.ModeMinPV, lp2.GetMode())
+	assert.Equal(t, 30.0, site.effectiveBufferSoc())
+
+	// changed by user while away
+	lp2.SetMode(api.ModeOff)
+
+	site.setAway(false)
+	assert.Equal(t, api.ModePV, lp1.GetMode())
+	assert.Equal(t, api.ModeOff, lp2.GetMode())
+	assert.Equal(t, 80.0, site.effectiveBufferSoc())
+}
@@ -0,0 +1,102 @@
+package core
+
+import (
+	"errors"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/loadpoint"
+)
+
+// PvForecastConfig compensates pv surplus for the short-term solar forecast.
+// Charging is not enabled right before a cloudy period and current is raised ahead of clear spells.
+type PvForecastConfig struct {
+	Enabled   bool          `mapstructure:"enabled"`
+	Horizon   time.Duration `mapstructure:"horizon"`   // forecast window
+	Threshold float64       `mapstructure:"threshold"` // relative forecast change triggering compensation
+	MaxBoost  float64       `mapstructure:"maxBoost"`  // max additional power offered to charging loadpoints ahead of clear spells (W), 0 disables
+}
+
+// configurePvForecast validates pv forecast compensation configuration
+func (site *Site) configurePvForecast() error {
+	if site.PvForecast.Horizon == 0 {
+		site.PvForecast.Horizon = time.Hour
+	}
+
+	if site.PvForecast.Threshold == 0 {
+		site.PvForecast.Threshold = 0.3
+	}
+
+	if site.PvForecast.Threshold < 0 || site.PvForecast.Threshold > 1 {
+		return errors.New("pv forecast: threshold must be between 0 and 1")
+	}
+
+	if site.PvForecast.MaxBoost < 0 {
+		return errors.New("pv forecast: maxBoost must not be negative")
+	}
+
+	return nil
+}
+
+// pvForecastTrend returns the ratio of the average forecast pv production within the horizon following the current slot to the current forecast
+func pvForecastTrend(rates api.Rates, now time.Time, horizon time.Duration) (float64, bool) {
+	current, err := rates.Current(now)
+	if err != nil || current.Price <= 0 {
+		return 0, false
+	}
+
+	future, ok := averagePrice(rates, current.End, current.End.Add(horizon))
+	if !ok {
+		return 0, false
+	}
+
+	return future / current.Price, true
+}
+
+// pvForecastSitePower adjusts site power for the short-term solar forecast.
+// Before a cloudy period, surplus of a not charging loadpoint is reduced to the expected pv production to avoid enabling.
+// Before a clear spell, charging loadpoints are offered the expected additional pv production up to maxBoost.
+func (site *Site) pvForecastSitePower(lp loadpoint.API, sitePower float64, now time.Time) float64 {
+	conf := site.PvForecast
+	if !conf.Enabled || site.pvPower <= 0 {
+		return sitePower
+	}
+
+	tariff := site.GetTariff(SolarTariff)
+	if tariff == nil {
+		return sitePower
+	}
+
+	rates, err := tariff.Rates()
+	if err != nil {
+		site.log.ERROR.Println("pv forecast:", err)
+		return sitePower
+	}
+
+	trend, ok := pvForecastTrend(rates, now, conf.Horizon)
+	if !ok {
+		return sitePower
+	}
+
+	site.publish(keys.PvForecastTrend, trend)
+
+	if mode := lp.GetMode(); mode != api.ModePV && mode != api.ModeMinPV {
+		return sitePower
+	}
+
+	delta := site.pvPower * (trend - 1)
+
+	switch status := lp.GetStatus(); {
+	case status == api.StatusB && trend < 1-conf.Threshold:
+		site.log.DEBUG.Printf("pv forecast: %.0f%% within %v, reducing surplus by %.0fW", trend*100, conf.Horizon, -delta)
+		return sitePower - delta
+
+	case status == api.StatusC && trend > 1+conf.Threshold && conf.MaxBoost > 0:
+		boost := min(delta, conf.MaxBoost)
+		site.log.DEBUG.Printf("pv forecast: %.0f%% within %v, raising surplus by %.0fW", trend*100, conf.Horizon, boost)
+		return sitePower - boost
+	}
+
+	return sitePower
+}
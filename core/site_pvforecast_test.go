@@ -0,0 +1,50 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/tariff"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPvForecastSitePower(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	solar := api.NewMockTariff(ctrl)
+
+	site := NewSite()
+	site.tariffs = &tariff.Tariffs{Solar: solar}
+	site.PvForecast = PvForecastConfig{Enabled: true, MaxBoost: 1000}
+	assert.NoError(t, site.configurePvForecast())
+	site.pvPower = 4000
+
+	for _, tc := range []struct {
+		forecast []float64
+		status   api.ChargeStatus
+		res      float64
+	}{
+		// clouds ahead: not charging loadpoint loses surplus
+		{[]float64{5000, 2000}, api.StatusB, -3000 + 2400},
+		// clouds ahead: charging continues
+		{[]float64{5000, 2000}, api.StatusC, -3000},
+		// clear spell: charging loadpoint gets boost
+		{[]float64{2000, 5000}, api.StatusC, -3000 - 1000},
+		// clear spell: not charging loadpoint unchanged
+		{[]float64{2000, 5000}, api.StatusB, -3000},
+		// stable
+		{[]float64{3000, 3100}, api.StatusB, -3000},
+	} {
+		solar.EXPECT().Rates().Return(hourlyRates(now, tc.forecast...), nil)
+
+		lp := loadpoint.NewMockAPI(ctrl)
+		lp.EXPECT().GetMode().Return(api.ModePV).AnyTimes()
+		lp.EXPECT().GetStatus().Return(tc.status).AnyTimes()
+
+		assert.Equal(t, tc.res, site.pvForecastSitePower(lp, -3000, now), tc)
+	}
+}
@@ -0,0 +1,77 @@
+package core
+
+import (
+	"slices"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
+)
+
+// smartCostWindow is the tariff window of percentile based smart cost limits
+const smartCostWindow = 24 * time.Hour
+
+// smartCostActive determines if the current rate is below the loadpoint's absolute or percentile based smart cost limit
+func (site *Site) smartCostActive(lp loadpoint.API, rates api.Rates, now time.Time) (bool, error) {
+	rate, err := rates.Current(now)
+	if err != nil {
+		return false, err
+	}
+
+	if limit := lp.GetSmartCostLimit(); limit != 0 && rate.Price <= limit {
+		return true, nil
+	}
+
+	if percentile := lp.GetSmartCostPercentile(); percentile > 0 {
+		return rate.Price <= percentilePrice(rates, now, smartCostWindow, percentile), nil
+	}
+
+	return false, nil
+}
+
+// percentilePrice returns the highest price of the cheapest percentile of the time window, weighted by rate duration
+func percentilePrice(rates api.Rates, from time.Time, window time.Duration, percentile float64) float64 {
+	type slot struct {
+		price    float64
+		duration time.Duration
+	}
+
+	to := from.Add(window)
+
+	var (
+		slots []slot
+		total time.Duration
+	)
+
+	for _, r := range rates {
+		if d := overlap(r, from, to); d > 0 {
+			slots = append(slots, slot{price: r.Price, duration: d})
+			total += d
+		}
+	}
+
+	slices.SortStableFunc(slots, func(a, b slot) int {
+		switch {
+		case a.price < b.price:
+			return -1
+		case a.price > b.price:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	target := time.Duration(float64(total) * min(percentile, 100) / 100)
+
+	var res float64
+	var acc time.Duration
+
+	for _, s := range slots {
+		res = s.price
+		if acc += s.duration; acc >= target {
+			break
+		}
+	}
+
+	return res
+}
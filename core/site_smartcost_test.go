@@ -0,0 +1,67 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func hourlyRates(now time.Time, prices ...float64) api.Rates {
+	res := make(api.Rates, 0, len(prices))
+	for i, p := range prices {
+		start := now.Add(time.Duration(i) * time.Hour)
+		res = append(res, api.Rate{Start: start, End: start.Add(time.Hour), Price: p})
+	}
+	return res
+}
+
+func TestPercentilePrice(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	rates := hourlyRates(now, 5, 1, 4, 2)
+
+	assert.Equal(t, 1.0, percentilePrice(rates, now, 4*time.Hour, 25))
+	assert.Equal(t, 2.0, percentilePrice(rates, now, 4*time.Hour, 50))
+	assert.Equal(t, 4.0, percentilePrice(rates, now, 4*time.Hour, 60))
+	assert.Equal(t, 5.0, percentilePrice(rates, now, 4*time.Hour, 100))
+
+	// window limits considered rates
+	assert.Equal(t, 5.0, percentilePrice(rates, now, 2*time.Hour, 60))
+
+	// partial rates are weighted by duration
+	assert.Equal(t, 4.0, percentilePrice(rates, now.Add(30*time.Minute), 3*time.Hour, 60))
+}
+
+func TestSmartCostActive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	site := new(Site)
+
+	for _, tc := range []struct {
+		limit, percentile float64
+		prices            []float64
+		active            bool
+	}{
+		{0, 0, []float64{1, 2}, false},
+		{1, 0, []float64{1, 2}, true},
+		{1, 0, []float64{2, 1}, false},
+		{0, 50, []float64{1, 2}, true},
+		{0, 50, []float64{2, 1}, false},
+		{2, 50, []float64{2, 1}, true},
+	} {
+		lp := loadpoint.NewMockAPI(ctrl)
+		lp.EXPECT().GetSmartCostLimit().Return(tc.limit).AnyTimes()
+		lp.EXPECT().GetSmartCostPercentile().Return(tc.percentile).AnyTimes()
+
+		active, err := site.smartCostActive(lp, hourlyRates(now, tc.prices...), now)
+		require.NoError(t, err)
+		assert.Equal(t, tc.active, active, tc)
+	}
+
+	_, err := site.smartCostActive(loadpoint.NewMockAPI(ctrl), nil, now)
+	assert.Error(t, err)
+}
@@ -2,8 +2,14 @@ package core
 
 import (
 	"testing"
+	"time"
 
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/tariff"
 	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 )
 
 func TestSitePower(t *testing.T) {
@@ -143,3 +149,56 @@ func TestGreenShare(t *testing.T) {
 		}
 	}
 }
+
+func TestSessionReferencePrice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	rates := api.Rates{
+		{Start: now, End: now.Add(time.Hour), Price: 0.2},
+		{Start: now.Add(time.Hour), End: now.Add(2 * time.Hour), Price: 0.4},
+		{Start: now.Add(2 * time.Hour), End: now.Add(3 * time.Hour), Price: 0.3},
+	}
+
+	grid := api.NewMockTariff(ctrl)
+	grid.EXPECT().Rates().Return(rates, nil).AnyTimes()
+
+	site := NewSite()
+	site.tariffs = &tariff.Tariffs{Grid: grid}
+
+	lp := NewLoadpoint(util.NewLogger("foo"), nil)
+
+	// average of all known rates
+	ref := site.sessionReferencePrice(lp, now)
+	require.NotNil(t, ref)
+	assert.InDelta(t, 0.3, *ref, 1e-6)
+
+	// average until plan time
+	lp.planTime = now.Add(2 * time.Hour)
+	ref = site.sessionReferencePrice(lp, now)
+	require.NotNil(t, ref)
+	assert.InDelta(t, 0.3, *ref, 1e-6)
+
+	lp.planTime = now.Add(90 * time.Minute)
+	ref = site.sessionReferencePrice(lp, now)
+	require.NotNil(t, ref)
+	assert.InDelta(t, (0.2+0.4*0.5)/1.5, *ref, 1e-6)
+
+	// charging at the cheapest rate saves against the average
+	lp.planTime = time.Time{}
+	ref = site.sessionReferencePrice(lp, now)
+
+	price := rates[0].Price
+	em := NewEnergyMetrics()
+	em.SetEnvironment(0, &price, nil)
+	em.SetReferencePrice(ref)
+	em.Update(10)
+
+	assert.InDelta(t, 10*(0.3-0.2), 10**em.ReferencePricePerKWh()-*em.Price(), 1e-6)
+
+	// configured reference price takes precedence
+	site.referencePrice = 0.5
+	ref = site.sessionReferencePrice(lp, now)
+	require.NotNil(t, ref)
+	assert.Equal(t, 0.5, *ref)
+}
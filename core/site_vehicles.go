@@ -13,18 +13,21 @@ import (
 )
 
 type planStruct struct {
-	Soc  int       `json:"soc"`
-	Time time.Time `json:"time"`
+	Soc          int       `json:"soc"`
+	Time         time.Time `json:"time"`
+	Precondition int64     `json:"precondition,omitempty"` // seconds
 }
 
 type vehicleStruct struct {
-	Title    string       `json:"title"`
-	Icon     string       `json:"icon,omitempty"`
-	Capacity float64      `json:"capacity,omitempty"`
-	MinSoc   int          `json:"minSoc,omitempty"`
-	LimitSoc int          `json:"limitSoc,omitempty"`
-	Features []string     `json:"features,omitempty"`
-	Plans    []planStruct `json:"plans,omitempty"`
+	Title        string       `json:"title"`
+	Icon         string       `json:"icon,omitempty"`
+	Capacity     float64      `json:"capacity,omitempty"`
+	MinSoc       int          `json:"minSoc,omitempty"`
+	LimitSoc     int          `json:"limitSoc,omitempty"`
+	TripLimitSoc int          `json:"tripLimitSoc,omitempty"`
+	ManualSoc    bool         `json:"manualSoc,omitempty"` // soc is entered by the user
+	Features     []string     `json:"features,omitempty"`
+	Plans        []planStruct `json:"plans,omitempty"`
 }
 
 // publishVehicles returns a list of vehicle titles
@@ -37,19 +40,22 @@ func (site *Site) publishVehicles() {
 
 		// TODO: add support for multiple plans
 		if time, soc := v.GetPlanSoc(); !time.IsZero() {
-			plans = append(plans, planStruct{Soc: soc, Time: time})
+			plans = append(plans, planStruct{Soc: soc, Time: time, Precondition: int64(v.GetPlanPrecondition().Seconds())})
 		}
 
 		instance := v.Instance()
+		_, manualSoc := instance.(api.SocSetter)
 
 		res[v.Name()] = vehicleStruct{
-			Title:    instance.Title(),
-			Icon:     instance.Icon(),
-			Capacity: instance.Capacity(),
-			MinSoc:   v.GetMinSoc(),
-			LimitSoc: v.GetLimitSoc(),
-			Features: lo.Map(instance.Features(), func(f api.Feature, _ int) string { return f.String() }),
-			Plans:    plans,
+			Title:        instance.Title(),
+			Icon:         instance.Icon(),
+			Capacity:     instance.Capacity(),
+			MinSoc:       v.GetMinSoc(),
+			LimitSoc:     v.GetLimitSoc(),
+			TripLimitSoc: v.GetTripLimitSoc(),
+			ManualSoc:    manualSoc,
+			Features:     lo.Map(instance.Features(), func(f api.Feature, _ int) string { return f.String() }),
+			Plans:        plans,
 		}
 
 		if lp := site.coordinator.Owner(instance); lp != nil {
@@ -67,6 +73,7 @@ func (site *Site) updateVehicles(op config.Operation, dev config.Device[api.Vehi
 	switch op {
 	case config.OpAdd:
 		site.coordinator.Add(vehicle)
+		site.restoreManualSoc(dev)
 
 	case config.OpDelete:
 		site.coordinator.Delete(vehicle)
@@ -76,6 +83,20 @@ func (site *Site) updateVehicles(op config.Operation, dev config.Device[api.Vehi
 	site.publishVehicles()
 }
 
+// restoreManualSoc restores the persisted soc of vehicles without api
+func (site *Site) restoreManualSoc(dev config.Device[api.Vehicle]) {
+	if _, ok := dev.Instance().(api.SocSetter); !ok {
+		return
+	}
+
+	v := vehicle.Adapter(site.log, dev)
+	if soc, ok := v.GetManualSoc(); ok {
+		if err := v.SetManualSoc(soc); err != nil {
+			site.log.ERROR.Printf("vehicle %s: %v", v.Name(), err)
+		}
+	}
+}
+
 var _ site.Vehicles = (*vehicles)(nil)
 
 type vehicles struct {
@@ -18,17 +18,21 @@ type Estimator struct {
 	vehicle  api.Vehicle
 	estimate bool
 
-	capacity          float64 // vehicle capacity in Wh cached to simplify testing
-	virtualCapacity   float64 // estimated virtual vehicle capacity in Wh
-	vehicleSoc        float64 // estimated vehicle Soc
-	initialSoc        float64 // first received valid vehicle Soc
-	initialEnergy     float64 // energy counter at first valid Soc
-	prevSoc           float64 // previous vehicle Soc in %
-	prevChargedEnergy float64 // previous charged energy in Wh
-	energyPerSocStep  float64 // Energy per Soc percent in Wh
-	minChargePower    float64 // Lowest charge power (just before vehicle stops charging at 100%)
-	maxChargePower    float64 // Highest charge power the battery can handle on any charger
-	maxChargeSoc      float64 // SoC at/after which maxChargePower is degressive
+	vehicleSocG func() (float64, error) // vehicle soc, bounded by the read timeout
+	chargerSocG func() (float64, error) // charger soc if supported, bounded by the read timeout
+
+	capacity          float64         // vehicle capacity in Wh cached to simplify testing
+	virtualCapacity   float64         // estimated virtual vehicle capacity in Wh
+	vehicleSoc        float64         // estimated vehicle Soc
+	initialSoc        float64         // first received valid vehicle Soc
+	initialEnergy     float64         // energy counter at first valid Soc
+	prevSoc           float64         // previous vehicle Soc in %
+	prevChargedEnergy float64         // previous charged energy in Wh
+	energyPerSocStep  float64         // Energy per Soc percent in Wh
+	minChargePower    float64         // Lowest charge power (just before vehicle stops charging at 100%)
+	maxChargePower    float64         // Highest charge power the battery can handle on any charger
+	maxChargeSoc      float64         // SoC at/after which maxChargePower is degressive
+	chargeCurve       api.ChargeCurve // Vehicle charge power over soc, replaces the degressive model
 }
 
 // NewEstimator creates new estimator
@@ -40,11 +44,25 @@ func NewEstimator(log *util.Logger, charger api.Charger, vehicle api.Vehicle, es
 		estimate: estimate,
 	}
 
+	if v, ok := vehicle.(api.ChargeCurveDescriber); ok {
+		s.chargeCurve = v.ChargeCurve()
+	}
+
+	s.SetTimeout(0)
 	s.Reset()
 
 	return s
 }
 
+// SetTimeout cancels soc readings exceeding the timeout, usually the control loop interval. A timeout of 0 disables cancellation.
+func (s *Estimator) SetTimeout(timeout time.Duration) {
+	s.vehicleSocG = util.WithTimeout(s.vehicle.Soc, timeout)
+	s.chargerSocG = nil
+	if charger, ok := s.charger.(api.Battery); ok {
+		s.chargerSocG = util.WithTimeout(charger.Soc, timeout)
+	}
+}
+
 // Reset resets the estimation process to default values
 func (s *Estimator) Reset() {
 	s.prevSoc = 0
@@ -58,10 +76,22 @@ func (s *Estimator) Reset() {
 	s.maxChargeSoc = 50      // default 50%
 }
 
+// SetChargeCurve sets the vehicle's charge curve unless configured
+func (s *Estimator) SetChargeCurve(curve api.ChargeCurve) {
+	if v, ok := s.vehicle.(api.ChargeCurveDescriber); ok && len(v.ChargeCurve()) > 0 {
+		return
+	}
+	s.chargeCurve = curve
+}
+
 // RemainingChargeDuration returns the estimated remaining duration
 func (s *Estimator) RemainingChargeDuration(targetSoc int, chargePower float64) time.Duration {
 	const minChargeSoc = 100
 
+	if len(s.chargeCurve) > 0 {
+		return s.remainingChargeDurationByCurve(targetSoc, chargePower)
+	}
+
 	dy := s.minChargePower - s.maxChargePower
 	dx := minChargeSoc - s.maxChargeSoc
 
@@ -90,6 +120,24 @@ func (s *Estimator) RemainingChargeDuration(targetSoc int, chargePower float64)
 	return max(0, time.Duration(float64(time.Hour)*(t1+t2))).Round(time.Second)
 }
 
+// remainingChargeDurationByCurve integrates the remaining duration in 1% soc steps, limiting charge power by the charge curve
+func (s *Estimator) remainingChargeDurationByCurve(targetSoc int, chargePower float64) time.Duration {
+	var hours float64
+
+	for soc := s.vehicleSoc; soc < float64(targetSoc); soc++ {
+		step := min(1, float64(targetSoc)-soc)
+
+		power := chargePower
+		if p := s.chargeCurve.Power(soc + step/2); p > 0 {
+			power = min(power, p)
+		}
+
+		hours += step / 100 * s.virtualCapacity / power
+	}
+
+	return max(0, time.Duration(float64(time.Hour)*hours)).Round(time.Second)
+}
+
 // RemainingChargeEnergy returns the remaining charge energy in kWh
 func (s *Estimator) RemainingChargeEnergy(targetSoc int) float64 {
 	percentRemaining := float64(targetSoc) - s.vehicleSoc
@@ -106,8 +154,8 @@ func (s *Estimator) RemainingChargeEnergy(targetSoc int) float64 {
 func (s *Estimator) Soc(chargedEnergy float64) (float64, error) {
 	var fetchedSoc *float64
 
-	if charger, ok := s.charger.(api.Battery); ok {
-		f, err := Guard(charger.Soc())
+	if s.chargerSocG != nil {
+		f, err := Guard(s.chargerSocG())
 
 		// if the charger does or could provide Soc, we always use it instead of using the vehicle API
 		if err == nil || !errors.Is(err, api.ErrNotAvailable) {
@@ -128,7 +176,7 @@ func (s *Estimator) Soc(chargedEnergy float64) (float64, error) {
 	}
 
 	if fetchedSoc == nil {
-		f, err := Guard(s.vehicle.Soc())
+		f, err := Guard(s.vehicleSocG())
 		if err != nil {
 			// required for online APIs with refreshkey
 			if errors.Is(err, api.ErrMustRetry) {
@@ -29,6 +29,25 @@ func TestRemainingChargeDuration(t *testing.T) {
 	}
 }
 
+func TestRemainingChargeDurationByCurve(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	charger := api.NewMockCharger(ctrl)
+	vehicle := api.NewMockVehicle(ctrl)
+	// 9 kWh userBatCap => 10 kWh virtualBatCap
+	vehicle.EXPECT().Capacity().Return(float64(9))
+
+	ce := NewEstimator(util.NewLogger("foo"), charger, vehicle, false)
+	ce.vehicleSoc = 20.0
+
+	// curve above charge power
+	ce.SetChargeCurve(api.ChargeCurve{{Soc: 0, Power: 11000}, {Soc: 100, Power: 11000}})
+	assert.Equal(t, 6*time.Hour, ce.RemainingChargeDuration(80, 1000))
+
+	// curve limits charge power above 50%
+	ce.SetChargeCurve(api.ChargeCurve{{Soc: 0, Power: 2000}, {Soc: 50, Power: 2000}, {Soc: 50, Power: 1000}, {Soc: 100, Power: 1000}})
+	assert.Equal(t, 4*time.Hour+30*time.Minute, ce.RemainingChargeDuration(80, 2000))
+}
+
 func TestSocEstimation(t *testing.T) {
 	type chargerStruct struct {
 		*api.MockCharger
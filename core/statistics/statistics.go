@@ -0,0 +1,276 @@
+package statistics
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/core/history"
+	"github.com/evcc-io/evcc/core/session"
+	"github.com/evcc-io/evcc/util"
+	"gorm.io/gorm"
+)
+
+// Period is the aggregation period
+type Period string
+
+const (
+	Day   Period = "day"
+	Month Period = "month"
+	Year  Period = "year"
+)
+
+// Energy is the aggregated energy, cost and co2 of a period. Site totals have an empty loadpoint.
+type Energy struct {
+	Period              Period    `json:"period" gorm:"primaryKey"`
+	Start               time.Time `json:"start" gorm:"primaryKey"`
+	Loadpoint           string    `json:"loadpoint,omitempty" gorm:"primaryKey"`
+	ChargedKWh          float64   `json:"chargedKWh" gorm:"column:charged_kwh"`
+	SolarKWh            float64   `json:"solarKWh" gorm:"column:solar_kwh"`
+	SolarPercentage     float64   `json:"solarPercentage"`
+	PricedKWh           float64   `json:"-" gorm:"column:priced_kwh"` // charged energy with known price
+	Cost                float64   `json:"cost"`
+	Savings             float64   `json:"savings"` // compared to reference price
+	Co2                 float64   `json:"co2"`     // g
+	GridImportKWh       float64   `json:"gridImportKWh" gorm:"column:grid_import_kwh"`
+	GridExportKWh       float64   `json:"gridExportKWh" gorm:"column:grid_export_kwh"`
+	PVKWh               float64   `json:"pvKWh" gorm:"column:pv_kwh"`
+	HomeKWh             float64   `json:"homeKWh" gorm:"column:home_kwh"`
+	BatteryChargeKWh    float64   `json:"batteryChargeKWh" gorm:"column:battery_charge_kwh"`
+	BatteryDischargeKWh float64   `json:"batteryDischargeKWh" gorm:"column:battery_discharge_kwh"`
+}
+
+// TableName implements gorm's Tabler interface
+func (Energy) TableName() string {
+	return "statistics"
+}
+
+// Aggregator rolls charging sessions and power flow history into daily, monthly and yearly statistics
+type Aggregator struct {
+	log *util.Logger
+	db  *gorm.DB
+}
+
+// New creates a statistics aggregator
+func New(db *gorm.DB) (*Aggregator, error) {
+	err := db.AutoMigrate(new(Energy))
+
+	return &Aggregator{
+		log: util.NewLogger("stats"),
+		db:  db,
+	}, err
+}
+
+// start returns the start of the period containing ts
+func start(period Period, ts time.Time) time.Time {
+	y, m, d := ts.Date()
+
+	switch period {
+	case Year:
+		return time.Date(y, 1, 1, 0, 0, 0, 0, ts.Location())
+	case Month:
+		return time.Date(y, m, 1, 0, 0, 0, 0, ts.Location())
+	default:
+		return time.Date(y, m, d, 0, 0, 0, 0, ts.Location())
+	}
+}
+
+// key identifies a period's statistics
+type key struct {
+	loadpoint string
+	start     time.Time
+}
+
+// Update recalculates the statistics. Savings are calculated against the grid price stored with each
+// session, the reference price applies to sessions without reference price.
+func (a *Aggregator) Update(now time.Time, reference float64) error {
+	var existing []Energy
+	if err := a.db.Where("period = ?", Day).Find(&existing).Error; err != nil {
+		return err
+	}
+
+	days := make(map[key]*Energy)
+	day := func(loadpoint string, ts time.Time) *Energy {
+		k := key{loadpoint, start(Day, ts.In(now.Location()))}
+		if _, ok := days[k]; !ok {
+			days[k] = &Energy{Period: Day, Start: k.start, Loadpoint: loadpoint}
+		}
+		return days[k]
+	}
+
+	// sessions of days before are pruned or incomplete, their statistics are retained
+	var pruned time.Time
+	if session.Retention > 0 {
+		pruned = start(Day, now.Add(-session.Retention)).AddDate(0, 0, 1)
+	}
+
+	// meter energies are only available for the history retention period and are retained otherwise
+	for _, e := range existing {
+		d := day(e.Loadpoint, e.Start)
+		d.GridImportKWh, d.GridExportKWh = e.GridImportKWh, e.GridExportKWh
+		d.PVKWh, d.HomeKWh = e.PVKWh, e.HomeKWh
+		d.BatteryChargeKWh, d.BatteryDischargeKWh = e.BatteryChargeKWh, e.BatteryDischargeKWh
+
+		if d.Start.Before(pruned) {
+			d.ChargedKWh, d.SolarKWh, d.PricedKWh = e.ChargedKWh, e.SolarKWh, e.PricedKWh
+			d.Cost, d.Savings, d.Co2 = e.Cost, e.Savings, e.Co2
+		}
+	}
+
+	if err := a.addSessions(pruned, reference, day); err != nil {
+		return err
+	}
+
+	if err := a.addFlows(now, day); err != nil {
+		return err
+	}
+
+	res := make([]Energy, 0, len(days))
+	rollup := map[Period]map[key]*Energy{Month: {}, Year: {}}
+
+	for k, d := range days {
+		for period, m := range rollup {
+			k := key{k.loadpoint, start(period, d.Start)}
+			if _, ok := m[k]; !ok {
+				m[k] = &Energy{Period: period, Start: k.start, Loadpoint: k.loadpoint}
+			}
+			m[k].add(*d)
+		}
+
+		d.update()
+		res = append(res, *d)
+	}
+
+	for _, m := range rollup {
+		for _, e := range m {
+			e.update()
+			res = append(res, *e)
+		}
+	}
+
+	if len(res) == 0 {
+		return nil
+	}
+
+	return a.db.Save(&res).Error
+}
+
+// addSessions adds the charging sessions' energy, cost and co2 to the site and loadpoint statistics
+func (a *Aggregator) addSessions(pruned time.Time, reference float64, day func(string, time.Time) *Energy) error {
+	var sessions session.Sessions
+	if err := a.db.Where("charged_kwh > 0").Find(&sessions).Error; err != nil {
+		return err
+	}
+
+	for _, s := range sessions {
+		ts := s.Finished
+		if ts.IsZero() {
+			ts = s.Created
+		}
+
+		// already aggregated
+		if ts.Before(pruned) {
+			continue
+		}
+
+		ref := reference
+		if s.ReferencePrice != nil {
+			ref = *s.ReferencePrice
+		}
+
+		res := []*Energy{day("", ts)}
+		if s.Loadpoint != "" {
+			res = append(res, day(s.Loadpoint, ts))
+		}
+
+		for _, d := range res {
+			d.ChargedKWh += s.ChargedEnergy
+
+			if s.SolarPercentage != nil {
+				d.SolarKWh += s.ChargedEnergy * *s.SolarPercentage / 100
+			}
+			if s.Price != nil {
+				d.PricedKWh += s.ChargedEnergy
+				d.Cost += *s.Price
+
+				if ref > 0 {
+					d.Savings += s.ChargedEnergy*ref - *s.Price
+				}
+			}
+			if s.Co2PerKWh != nil {
+				d.Co2 += s.ChargedEnergy * *s.Co2PerKWh
+			}
+		}
+	}
+
+	return nil
+}
+
+// addFlows integrates the power flow history. Since the history is pruned, the larger of the
+// previously aggregated and the integrated energy is used.
+func (a *Aggregator) addFlows(now time.Time, day func(string, time.Time) *Energy) error {
+	flows, err := history.Flows(a.db, now.Add(-history.Retention))
+	if err != nil {
+		return err
+	}
+
+	integrated := make(map[*Energy]*Energy)
+
+	for _, f := range flows {
+		d := day("", f.Created)
+		if _, ok := integrated[d]; !ok {
+			integrated[d] = new(Energy)
+		}
+		e := integrated[d]
+
+		// each snapshot represents one history interval unless downsampled
+		kwh := f.Resolution().Hours() / 1e3
+
+		e.GridImportKWh += f.Import() * kwh
+		e.GridExportKWh += f.Export() * kwh
+		e.PVKWh += max(0, f.PV) * kwh
+		e.HomeKWh += max(0, f.Home) * kwh
+		e.BatteryChargeKWh += f.Charge() * kwh
+		e.BatteryDischargeKWh += f.Discharge() * kwh
+	}
+
+	for d, e := range integrated {
+		d.GridImportKWh = max(d.GridImportKWh, e.GridImportKWh)
+		d.GridExportKWh = max(d.GridExportKWh, e.GridExportKWh)
+		d.PVKWh = max(d.PVKWh, e.PVKWh)
+		d.HomeKWh = max(d.HomeKWh, e.HomeKWh)
+		d.BatteryChargeKWh = max(d.BatteryChargeKWh, e.BatteryChargeKWh)
+		d.BatteryDischargeKWh = max(d.BatteryDischargeKWh, e.BatteryDischargeKWh)
+	}
+
+	return nil
+}
+
+// add adds the energies of other
+func (e *Energy) add(other Energy) {
+	e.ChargedKWh += other.ChargedKWh
+	e.SolarKWh += other.SolarKWh
+	e.PricedKWh += other.PricedKWh
+	e.Cost += other.Cost
+	e.Savings += other.Savings
+	e.Co2 += other.Co2
+	e.GridImportKWh += other.GridImportKWh
+	e.GridExportKWh += other.GridExportKWh
+	e.PVKWh += other.PVKWh
+	e.HomeKWh += other.HomeKWh
+	e.BatteryChargeKWh += other.BatteryChargeKWh
+	e.BatteryDischargeKWh += other.BatteryDischargeKWh
+}
+
+// update updates the derived values
+func (e *Energy) update() {
+	e.SolarPercentage = 0
+	if e.ChargedKWh > 0 {
+		e.SolarPercentage = 100 * e.SolarKWh / e.ChargedKWh
+	}
+}
+
+// Query returns the site or loadpoint statistics of the given period starting within [from, to)
+func Query(db *gorm.DB, period Period, loadpoint string, from, to time.Time) ([]Energy, error) {
+	res := make([]Energy, 0)
+	tx := db.Where("period = ? AND loadpoint = ? AND start >= ? AND start < ?", period, loadpoint, from, to).Order("start").Find(&res)
+	return res, tx.Error
+}
@@ -0,0 +1,116 @@
+package statistics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/core/history"
+	"github.com/evcc-io/evcc/core/session"
+	serverdb "github.com/evcc-io/evcc/server/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregation(t *testing.T) {
+	db, err := serverdb.New("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	require.NoError(t, db.AutoMigrate(new(session.Session)))
+	hist, err := history.NewStore(db)
+	require.NoError(t, err)
+
+	a, err := New(db)
+	require.NoError(t, err)
+
+	now := time.Date(2024, 3, 2, 12, 0, 0, 0, time.Local)
+	solar, price, co2 := 50.0, 3.0, 400.0
+
+	for _, s := range []session.Session{
+		{Loadpoint: "garage", Finished: time.Date(2024, 2, 10, 12, 0, 0, 0, time.Local), ChargedEnergy: 10, SolarPercentage: &solar, Price: &price, Co2PerKWh: &co2},
+		{Finished: time.Date(2024, 3, 1, 12, 0, 0, 0, time.Local), ChargedEnergy: 20},
+		{Finished: time.Date(2024, 3, 2, 8, 0, 0, 0, time.Local), ChargedEnergy: 10, SolarPercentage: &solar},
+	} {
+		require.NoError(t, db.Create(&s).Error)
+	}
+
+	// 1 hour of 2kW grid import
+	for ts := now.Add(-time.Hour); ts.Before(now); ts = ts.Add(history.Interval) {
+		hist.Add(history.Flow{Created: ts, Grid: 2000, Battery: -1000})
+	}
+
+	require.NoError(t, a.Update(now, 0.4))
+
+	res, err := Query(db, Day, "", time.Date(2024, 3, 2, 0, 0, 0, 0, time.Local), now)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.Equal(t, 10.0, res[0].ChargedKWh)
+	assert.Equal(t, 50.0, res[0].SolarPercentage)
+	assert.InDelta(t, 2.0, res[0].GridImportKWh, 1e-6)
+	assert.InDelta(t, 1.0, res[0].BatteryChargeKWh, 1e-6)
+
+	res, err = Query(db, Month, "", time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local), now)
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	assert.Equal(t, 10.0, res[0].ChargedKWh)
+	assert.Equal(t, 3.0, res[0].Cost)
+	assert.Equal(t, 4000.0, res[0].Co2)
+	assert.InDelta(t, 1.0, res[0].Savings, 1e-6)
+	assert.Equal(t, 30.0, res[1].ChargedKWh)
+	assert.InDelta(t, 100.0/6, res[1].SolarPercentage, 1e-6)
+
+	// loadpoint breakdown
+	res, err = Query(db, Month, "garage", time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local), now)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.Equal(t, 10.0, res[0].ChargedKWh)
+	assert.InDelta(t, 1.0, res[0].Savings, 1e-6)
+	assert.Equal(t, 0.0, res[0].GridImportKWh)
+
+	// meter energies are retained when history has been pruned
+	require.NoError(t, db.Where("1 = 1").Delete(new(history.Flow)).Error)
+	require.NoError(t, a.Update(now, 0.4))
+
+	res, err = Query(db, Year, "", time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local), now)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.Equal(t, 40.0, res[0].ChargedKWh)
+	assert.InDelta(t, 2.0, res[0].GridImportKWh, 1e-6)
+}
+
+func TestSessionReferencePrice(t *testing.T) {
+	db, err := serverdb.New("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	require.NoError(t, db.AutoMigrate(new(session.Session)))
+	_, err = history.NewStore(db)
+	require.NoError(t, err)
+
+	a, err := New(db)
+	require.NoError(t, err)
+
+	now := time.Date(2024, 3, 2, 12, 0, 0, 0, time.Local)
+	price, reference := 3.0, 0.5
+
+	for _, s := range []session.Session{
+		{Finished: time.Date(2024, 3, 1, 12, 0, 0, 0, time.Local), ChargedEnergy: 10, Price: &price, ReferencePrice: &reference},
+		{Finished: time.Date(2024, 3, 2, 8, 0, 0, 0, time.Local), ChargedEnergy: 10, Price: &price},
+	} {
+		require.NoError(t, db.Create(&s).Error)
+	}
+
+	// stored reference price takes precedence, configured reference price applies otherwise
+	require.NoError(t, a.Update(now, 0.4))
+
+	res, err := Query(db, Month, "", time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local), now)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.InDelta(t, 3.0, res[0].Savings, 1e-6)
+
+	// changed reference price does not alter sessions with stored reference price
+	require.NoError(t, a.Update(now, 0.3))
+
+	res, err = Query(db, Month, "", time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local), now)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.InDelta(t, 2.0, res[0].Savings, 1e-6)
+}
@@ -1,9 +1,11 @@
 package core
 
 import (
+	"sync/atomic"
 	"time"
 
 	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/statistics"
 	"github.com/evcc-io/evcc/server/db"
 	"github.com/evcc-io/evcc/util"
 )
@@ -15,8 +17,10 @@ type publisher interface {
 
 // Publishes long term charging statistics
 type Stats struct {
-	updated time.Time // Time of last charged value update
-	log     *util.Logger
+	updated    time.Time   // Time of last charged value update
+	refresh    atomic.Bool // Update with next cycle
+	log        *util.Logger
+	aggregator *statistics.Aggregator // Long-term statistics
 }
 
 func NewStats() *Stats {
@@ -25,24 +29,36 @@ func NewStats() *Stats {
 	}
 }
 
-// Update publishes stats based on charging sessions
-func (s *Stats) Update(p publisher) {
-	if time.Since(s.updated) < time.Hour {
+// Refresh updates the stats with the next cycle, e.g. after changing the reference price
+func (s *Stats) Refresh() {
+	s.refresh.Store(true)
+}
+
+// Update publishes stats based on charging sessions. Savings are calculated against the grid price stored
+// with each session, the configured reference price applies to sessions without reference price.
+func (s *Stats) Update(p publisher, reference float64) {
+	if time.Since(s.updated) < time.Hour && !s.refresh.Swap(false) {
 		return
 	}
 
 	stats := map[string]map[string]float64{
-		"30d":   s.calculate(30),
-		"365d":  s.calculate(365),
-		"total": s.calculate(365 * 100), // 100 years
+		"30d":   s.calculate(30, reference),
+		"365d":  s.calculate(365, reference),
+		"total": s.calculate(365*100, reference), // 100 years
 	}
 	p.publish(keys.Statistics, stats)
 
+	if s.aggregator != nil {
+		if err := s.aggregator.Update(time.Now(), reference); err != nil {
+			s.log.ERROR.Printf("aggregation: %v", err)
+		}
+	}
+
 	s.updated = time.Now()
 }
 
 // calculate reads the stats for the last n-days
-func (s *Stats) calculate(days int) map[string]float64 {
+func (s *Stats) calculate(days int, reference float64) map[string]float64 {
 	result := make(map[string]float64)
 
 	// Calculate the date from numberOfDays ago
@@ -54,6 +70,9 @@ func (s *Stats) calculate(days int) map[string]float64 {
 		ChargedKWh      float64
 		AvgPrice        float64
 		AvgCo2          float64
+		ReferencePrice  float64
+		Savings         float64
+		ReferencedKWh   float64
 	}
 
 	// Calculate solar_percentage and total_kwh
@@ -77,6 +96,19 @@ func (s *Stats) calculate(days int) map[string]float64 {
 		s.log.ERROR.Printf("error executing price stats query: %v", err)
 	}
 
+	// Calculate savings of sessions with known price and reference price
+	if err := db.Instance.Raw(`
+		SELECT SUM(charged_kwh * COALESCE(reference_price_per_kwh, ?)) / SUM(charged_kwh) AS ReferencePrice,
+			SUM(charged_kwh * COALESCE(reference_price_per_kwh, ?) - price) AS Savings,
+			SUM(charged_kwh) AS ReferencedKWh
+		FROM sessions
+		WHERE finished >= ?
+		AND charged_kwh > 0
+		AND price IS NOT NULL
+		AND COALESCE(reference_price_per_kwh, ?) > 0`, reference, reference, fromDate, reference).Scan(&dbResult).Error; err != nil {
+		s.log.ERROR.Printf("error executing savings stats query: %v", err)
+	}
+
 	// Calculate avg_co2
 	if err := db.Instance.Raw(`
 		SELECT SUM(charged_kwh * co2_per_kwh) / SUM(charged_kwh) AS AvgCo2
@@ -91,6 +123,11 @@ func (s *Stats) calculate(days int) map[string]float64 {
 	result["chargedKWh"] = dbResult.ChargedKWh
 	result["avgPrice"] = dbResult.AvgPrice
 	result["avgCo2"] = dbResult.AvgCo2
+	result["referencePrice"] = dbResult.ReferencePrice
+
+	if dbResult.ReferencedKWh > 0 {
+		result["savings"] = dbResult.Savings
+	}
 
 	return result
 }
@@ -0,0 +1,134 @@
+package user
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// User owns RFID tags and vehicles. Charging sessions are attributed to users for billing.
+type User struct {
+	Name        string   `json:"name"`
+	Title       string   `json:"title,omitempty"`
+	Identifiers []string `json:"identifiers,omitempty"` // RFID tags or other charger identifiers
+	Vehicles    []string `json:"vehicles,omitempty"`    // vehicle references
+	Role        string   `json:"role,omitempty"`        // permission role, e.g. for overriding loadpoint lockout
+}
+
+var (
+	mu    sync.RWMutex
+	users []User
+)
+
+// Configure replaces the configured users
+func Configure(uu []User) error {
+	names := make(map[string]bool)
+	ids := make(map[string]string)
+	vehicles := make(map[string]string)
+
+	for i, u := range uu {
+		if u.Name == "" {
+			return fmt.Errorf("user %d: missing name", i+1)
+		}
+
+		if names[u.Name] {
+			return fmt.Errorf("user %s: duplicate name", u.Name)
+		}
+		names[u.Name] = true
+
+		for _, id := range u.Identifiers {
+			id = strings.ToLower(id)
+			if other, ok := ids[id]; ok {
+				return fmt.Errorf("user %s: identifier %s already owned by %s", u.Name, id, other)
+			}
+			ids[id] = u.Name
+		}
+
+		for _, v := range u.Vehicles {
+			if other, ok := vehicles[v]; ok {
+				return fmt.Errorf("user %s: vehicle %s already owned by %s", u.Name, v, other)
+			}
+			vehicles[v] = u.Name
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	users = slices.Clone(uu)
+
+	return nil
+}
+
+// All returns the configured users
+func All() []User {
+	mu.RLock()
+	defer mu.RUnlock()
+	return slices.Clone(users)
+}
+
+// ByIdentifier returns the user owning the identifier
+func ByIdentifier(id string) (User, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	for _, u := range users {
+		if slices.ContainsFunc(u.Identifiers, func(uid string) bool {
+			return strings.EqualFold(uid, id)
+		}) {
+			return u, true
+		}
+	}
+
+	return User{}, false
+}
+
+// ByVehicle returns the user owning the vehicle
+func ByVehicle(name string) (User, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	for _, u := range users {
+		if slices.Contains(u.Vehicles, name) {
+			return u, true
+		}
+	}
+
+	return User{}, false
+}
+
+// HasRole returns true if the named user has the role
+func HasRole(name, role string) bool {
+	if name == "" || role == "" {
+		return false
+	}
+
+	mu.RLock()
+	defer mu.RUnlock()
+
+	for _, u := range users {
+		if u.Name == name {
+			return u.Role == role
+		}
+	}
+
+	return false
+}
+
+// Attribute returns the user name for a charging session. The charger identifier takes precedence over the vehicle.
+func Attribute(identifier, vehicle string) string {
+	if identifier != "" {
+		if u, ok := ByIdentifier(identifier); ok {
+			return u.Name
+		}
+	}
+
+	if vehicle != "" {
+		if u, ok := ByVehicle(vehicle); ok {
+			return u.Name
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,40 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttribute(t *testing.T) {
+	require.NoError(t, Configure([]User{
+		{Name: "alice", Identifiers: []string{"04A1B2"}, Vehicles: []string{"car1"}},
+		{Name: "bob", Vehicles: []string{"car2"}},
+	}))
+	t.Cleanup(func() { _ = Configure(nil) })
+
+	assert.Equal(t, "alice", Attribute("04a1b2", ""))
+	assert.Equal(t, "alice", Attribute("04A1B2", "car2"), "identifier takes precedence")
+	assert.Equal(t, "bob", Attribute("unknown", "car2"))
+	assert.Equal(t, "", Attribute("", "car3"))
+}
+
+func TestConfigureDuplicates(t *testing.T) {
+	assert.Error(t, Configure([]User{{Name: "a"}, {Name: "a"}}))
+	assert.Error(t, Configure([]User{{Name: "a", Identifiers: []string{"x"}}, {Name: "b", Identifiers: []string{"X"}}}))
+	assert.Error(t, Configure([]User{{Name: "a", Vehicles: []string{"v"}}, {Name: "b", Vehicles: []string{"v"}}}))
+}
+
+func TestHasRole(t *testing.T) {
+	require.NoError(t, Configure([]User{
+		{Name: "alice", Role: "admin"},
+		{Name: "bob"},
+	}))
+	t.Cleanup(func() { _ = Configure(nil) })
+
+	assert.True(t, HasRole("alice", "admin"))
+	assert.False(t, HasRole("bob", "admin"))
+	assert.False(t, HasRole("", "admin"))
+	assert.False(t, HasRole("bob", ""))
+}
@@ -70,6 +70,45 @@ func (v *adapter) SetLimitSoc(soc int) {
 	v.publish()
 }
 
+// GetTripLimitSoc returns the trip limit soc
+func (v *adapter) GetTripLimitSoc() int {
+	if v, err := settings.Int(v.key() + keys.TripLimitSoc); err == nil {
+		return int(v)
+	}
+	return 0
+}
+
+// SetTripLimitSoc sets the trip limit soc
+func (v *adapter) SetTripLimitSoc(soc int) {
+	v.log.DEBUG.Printf("set %s trip limit soc: %d", v.name, soc)
+	settings.SetInt(v.key()+keys.TripLimitSoc, int64(soc))
+	v.publish()
+}
+
+// GetManualSoc returns the manual soc
+func (v *adapter) GetManualSoc() (float64, bool) {
+	if v, err := settings.Float(v.key() + keys.ManualSoc); err == nil {
+		return v, true
+	}
+	return 0, false
+}
+
+// SetManualSoc sets the manual soc
+func (v *adapter) SetManualSoc(soc float64) error {
+	vv, ok := v.Vehicle.(api.SocSetter)
+	if !ok {
+		return api.ErrNotAvailable
+	}
+
+	if err := vv.SetSoc(soc); err != nil {
+		return err
+	}
+
+	settings.SetFloat(v.key()+keys.ManualSoc, soc)
+
+	return nil
+}
+
 // GetPlanSoc returns the charge plan soc
 func (v *adapter) GetPlanSoc() (time.Time, int) {
 	var ts time.Time
@@ -103,3 +142,36 @@ func (v *adapter) SetPlanSoc(ts time.Time, soc int) error {
 
 	return nil
 }
+
+// GetPlanPrecondition returns the battery preconditioning duration before plan time
+func (v *adapter) GetPlanPrecondition() time.Duration {
+	if v, err := settings.Int(v.key() + keys.PlanPrecondition); err == nil {
+		return time.Duration(v) * time.Second
+	}
+	return 0
+}
+
+// SetPlanPrecondition sets the battery preconditioning duration before plan time
+func (v *adapter) SetPlanPrecondition(d time.Duration) {
+	v.log.DEBUG.Printf("set %s plan precondition: %v", v.name, d)
+	settings.SetInt(v.key()+keys.PlanPrecondition, int64(d/time.Second))
+	v.publish()
+}
+
+// GetLearnedChargeCurve returns the learned charge curve
+func (v *adapter) GetLearnedChargeCurve() api.ChargeCurve {
+	var res api.ChargeCurve
+	if err := settings.Json(v.key()+keys.ChargeCurve, &res); err == nil {
+		res.Sort()
+		return res
+	}
+	return nil
+}
+
+// SetLearnedChargeCurve sets the learned charge curve
+func (v *adapter) SetLearnedChargeCurve(curve api.ChargeCurve) {
+	v.log.DEBUG.Printf("set %s charge curve: %v", v.name, curve)
+	if err := settings.SetJson(v.key()+keys.ChargeCurve, curve); err != nil {
+		v.log.ERROR.Printf("charge curve: %v", err)
+	}
+}
@@ -37,11 +37,29 @@ type API interface {
 	GetLimitSoc() int
 	// SetLimitSoc sets the limit soc
 	SetLimitSoc(soc int)
+	// GetTripLimitSoc returns the limit soc overriding the limit soc for the next session only
+	GetTripLimitSoc() int
+	// SetTripLimitSoc sets the limit soc for the next session only, 0 to disable
+	SetTripLimitSoc(soc int)
+
+	// GetManualSoc returns the persisted soc of vehicles without api, false if unknown
+	GetManualSoc() (float64, bool)
+	// SetManualSoc sets and persists the soc of vehicles without api
+	SetManualSoc(soc float64) error
 
 	// GetPlanSoc returns the charge plan soc
 	GetPlanSoc() (time.Time, int)
 	// SetPlanSoc sets the charge plan time and soc
 	SetPlanSoc(time.Time, int) error
+	// GetPlanPrecondition returns the battery preconditioning duration before plan time
+	GetPlanPrecondition() time.Duration
+	// SetPlanPrecondition sets the battery preconditioning duration before plan time, 0 to disable
+	SetPlanPrecondition(time.Duration)
+
+	// GetLearnedChargeCurve returns the charge curve learned from completed sessions
+	GetLearnedChargeCurve() api.ChargeCurve
+	// SetLearnedChargeCurve sets the learned charge curve
+	SetLearnedChargeCurve(api.ChargeCurve)
 
 	// // GetMinCurrent returns the min charging current
 	// GetMinCurrent() float64
@@ -38,6 +38,25 @@ func (v *dummy) GetLimitSoc() int {
 func (v *dummy) SetLimitSoc(soc int) {
 }
 
+// GetTripLimitSoc returns the trip limit soc
+func (v *dummy) GetTripLimitSoc() int {
+	return 0
+}
+
+// SetTripLimitSoc sets the trip limit soc
+func (v *dummy) SetTripLimitSoc(soc int) {
+}
+
+// GetManualSoc returns the manual soc
+func (v *dummy) GetManualSoc() (float64, bool) {
+	return 0, false
+}
+
+// SetManualSoc sets the manual soc
+func (v *dummy) SetManualSoc(soc float64) error {
+	return api.ErrNotAvailable
+}
+
 // GetPlanSoc returns the charge plan soc
 func (v *dummy) GetPlanSoc() (time.Time, int) {
 	return time.Time{}, 0
@@ -47,3 +66,21 @@ func (v *dummy) GetPlanSoc() (time.Time, int) {
 func (v *dummy) SetPlanSoc(ts time.Time, soc int) error {
 	return nil
 }
+
+// GetPlanPrecondition returns the battery preconditioning duration before plan time
+func (v *dummy) GetPlanPrecondition() time.Duration {
+	return 0
+}
+
+// SetPlanPrecondition sets the battery preconditioning duration before plan time
+func (v *dummy) SetPlanPrecondition(d time.Duration) {
+}
+
+// GetLearnedChargeCurve returns the learned charge curve
+func (v *dummy) GetLearnedChargeCurve() api.ChargeCurve {
+	return nil
+}
+
+// SetLearnedChargeCurve sets the learned charge curve
+func (v *dummy) SetLearnedChargeCurve(curve api.ChargeCurve) {
+}
@@ -40,6 +40,20 @@ func (m *MockAPI) EXPECT() *MockAPIMockRecorder {
 	return m.recorder
 }
 
+// GetLearnedChargeCurve mocks base method.
+func (m *MockAPI) GetLearnedChargeCurve() api.ChargeCurve {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLearnedChargeCurve")
+	ret0, _ := ret[0].(api.ChargeCurve)
+	return ret0
+}
+
+// GetLearnedChargeCurve indicates an expected call of GetLearnedChargeCurve.
+func (mr *MockAPIMockRecorder) GetLearnedChargeCurve() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLearnedChargeCurve", reflect.TypeOf((*MockAPI)(nil).GetLearnedChargeCurve))
+}
+
 // GetLimitSoc mocks base method.
 func (m *MockAPI) GetLimitSoc() int {
 	m.ctrl.T.Helper()
@@ -54,6 +68,21 @@ func (mr *MockAPIMockRecorder) GetLimitSoc() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLimitSoc", reflect.TypeOf((*MockAPI)(nil).GetLimitSoc))
 }
 
+// GetManualSoc mocks base method.
+func (m *MockAPI) GetManualSoc() (float64, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetManualSoc")
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetManualSoc indicates an expected call of GetManualSoc.
+func (mr *MockAPIMockRecorder) GetManualSoc() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManualSoc", reflect.TypeOf((*MockAPI)(nil).GetManualSoc))
+}
+
 // GetMinSoc mocks base method.
 func (m *MockAPI) GetMinSoc() int {
 	m.ctrl.T.Helper()
@@ -68,6 +97,20 @@ func (mr *MockAPIMockRecorder) GetMinSoc() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMinSoc", reflect.TypeOf((*MockAPI)(nil).GetMinSoc))
 }
 
+// GetPlanPrecondition mocks base method.
+func (m *MockAPI) GetPlanPrecondition() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlanPrecondition")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetPlanPrecondition indicates an expected call of GetPlanPrecondition.
+func (mr *MockAPIMockRecorder) GetPlanPrecondition() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlanPrecondition", reflect.TypeOf((*MockAPI)(nil).GetPlanPrecondition))
+}
+
 // GetPlanSoc mocks base method.
 func (m *MockAPI) GetPlanSoc() (time.Time, int) {
 	m.ctrl.T.Helper()
@@ -83,6 +126,20 @@ func (mr *MockAPIMockRecorder) GetPlanSoc() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlanSoc", reflect.TypeOf((*MockAPI)(nil).GetPlanSoc))
 }
 
+// GetTripLimitSoc mocks base method.
+func (m *MockAPI) GetTripLimitSoc() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTripLimitSoc")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetTripLimitSoc indicates an expected call of GetTripLimitSoc.
+func (mr *MockAPIMockRecorder) GetTripLimitSoc() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTripLimitSoc", reflect.TypeOf((*MockAPI)(nil).GetTripLimitSoc))
+}
+
 // Instance mocks base method.
 func (m *MockAPI) Instance() api.Vehicle {
 	m.ctrl.T.Helper()
@@ -111,6 +168,18 @@ func (mr *MockAPIMockRecorder) Name() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockAPI)(nil).Name))
 }
 
+// SetLearnedChargeCurve mocks base method.
+func (m *MockAPI) SetLearnedChargeCurve(arg0 api.ChargeCurve) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetLearnedChargeCurve", arg0)
+}
+
+// SetLearnedChargeCurve indicates an expected call of SetLearnedChargeCurve.
+func (mr *MockAPIMockRecorder) SetLearnedChargeCurve(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLearnedChargeCurve", reflect.TypeOf((*MockAPI)(nil).SetLearnedChargeCurve), arg0)
+}
+
 // SetLimitSoc mocks base method.
 func (m *MockAPI) SetLimitSoc(arg0 int) {
 	m.ctrl.T.Helper()
@@ -123,6 +192,20 @@ func (mr *MockAPIMockRecorder) SetLimitSoc(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLimitSoc", reflect.TypeOf((*MockAPI)(nil).SetLimitSoc), arg0)
 }
 
+// SetManualSoc mocks base method.
+func (m *MockAPI) SetManualSoc(arg0 float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetManualSoc", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetManualSoc indicates an expected call of SetManualSoc.
+func (mr *MockAPIMockRecorder) SetManualSoc(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetManualSoc", reflect.TypeOf((*MockAPI)(nil).SetManualSoc), arg0)
+}
+
 // SetMinSoc mocks base method.
 func (m *MockAPI) SetMinSoc(arg0 int) {
 	m.ctrl.T.Helper()
@@ -135,6 +218,18 @@ func (mr *MockAPIMockRecorder) SetMinSoc(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMinSoc", reflect.TypeOf((*MockAPI)(nil).SetMinSoc), arg0)
 }
 
+// SetPlanPrecondition mocks base method.
+func (m *MockAPI) SetPlanPrecondition(arg0 time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetPlanPrecondition", arg0)
+}
+
+// SetPlanPrecondition indicates an expected call of SetPlanPrecondition.
+func (mr *MockAPIMockRecorder) SetPlanPrecondition(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPlanPrecondition", reflect.TypeOf((*MockAPI)(nil).SetPlanPrecondition), arg0)
+}
+
 // SetPlanSoc mocks base method.
 func (m *MockAPI) SetPlanSoc(arg0 time.Time, arg1 int) error {
 	m.ctrl.T.Helper()
@@ -148,3 +243,15 @@ func (mr *MockAPIMockRecorder) SetPlanSoc(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPlanSoc", reflect.TypeOf((*MockAPI)(nil).SetPlanSoc), arg0, arg1)
 }
+
+// SetTripLimitSoc mocks base method.
+func (m *MockAPI) SetTripLimitSoc(arg0 int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTripLimitSoc", arg0)
+}
+
+// SetTripLimitSoc indicates an expected call of SetTripLimitSoc.
+func (mr *MockAPIMockRecorder) SetTripLimitSoc(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTripLimitSoc", reflect.TypeOf((*MockAPI)(nil).SetTripLimitSoc), arg0)
+}
@@ -6,7 +6,9 @@ import (
 
 	"github.com/evcc-io/evcc/core/site"
 	"github.com/evcc-io/evcc/hems/ocpp"
+	"github.com/evcc-io/evcc/hems/openadr"
 	"github.com/evcc-io/evcc/hems/semp"
+	"github.com/evcc-io/evcc/hems/victron"
 	"github.com/evcc-io/evcc/server"
 )
 
@@ -22,6 +24,10 @@ func NewFromConfig(typ string, other map[string]interface{}, site site.API, http
 		return semp.New(other, site, httpd)
 	case "ocpp":
 		return ocpp.New(other, site)
+	case "openadr":
+		return openadr.New(other, site, httpd)
+	case "victron":
+		return victron.New(other, site)
 	default:
 		return nil, errors.New("unknown hems: " + typ)
 	}
@@ -7,29 +7,49 @@ import (
 	"time"
 
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
 	"github.com/evcc-io/evcc/core/site"
 	"github.com/evcc-io/evcc/hems/ocpp/profile"
+	"github.com/evcc-io/evcc/server/db/settings"
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/machine"
 	ocpp16 "github.com/lorenzodonini/ocpp-go/ocpp1.6"
 	ocppcore "github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
 	"github.com/lorenzodonini/ocpp-go/ws"
 )
 
-// OCPP is an OCPP client
+// OCPP is an OCPP client presenting the site's loadpoints as connectors to an upstream central system
 type OCPP struct {
-	log  *util.Logger
-	site site.API
-	cp   ocpp16.ChargePoint
+	log           *util.Logger
+	site          site.API
+	cp            ocpp16.ChargePoint
+	idTag         string
+	meterInterval time.Duration
+	connectors    map[int]*connector
+}
+
+// connector tracks the upstream transaction state of a loadpoint
+type connector struct {
+	id          int
+	status      ocppcore.ChargePointStatus
+	txn         int       // upstream transaction id, 0 if none
+	meterStart  float64   // meter register at transaction start in Wh
+	meterValues time.Time // last meter values sent
 }
 
 const retryTimeout = 5 * time.Second
 
 // New generates OCPP chargepoint client
 func New(conf map[string]interface{}, site site.API) (*OCPP, error) {
-	var cc struct {
-		URI       string
-		StationID string
+	cc := struct {
+		URI           string
+		StationID     string
+		IdTag         string
+		MeterInterval time.Duration
+	}{
+		IdTag:         "evcc",
+		MeterInterval: time.Minute,
 	}
 
 	if err := util.DecodeOther(conf, &cc); err != nil {
@@ -52,9 +72,12 @@ func New(conf map[string]interface{}, site site.API) (*OCPP, error) {
 	cp := ocpp16.NewChargePoint(cc.StationID, nil, ws)
 
 	s := &OCPP{
-		log:  log,
-		site: site,
-		cp:   cp,
+		log:           log,
+		site:          site,
+		cp:            cp,
+		idTag:         cc.IdTag,
+		meterInterval: cc.MeterInterval,
+		connectors:    make(map[int]*connector),
 	}
 
 	err := cp.Start(cc.URI)
@@ -64,6 +87,10 @@ func New(conf map[string]interface{}, site site.API) (*OCPP, error) {
 
 		go s.errorHandler(ws.Errors())
 		go s.errorHandler(cp.Errors())
+
+		if _, err := cp.BootNotification("evcc", "evcc"); err != nil {
+			log.ERROR.Println("boot notification:", err)
+		}
 	}
 
 	return s, err
@@ -80,19 +107,116 @@ func (s *OCPP) errorHandler(errC <-chan error) {
 func (s *OCPP) Run() {
 	for {
 		for id, lp := range s.site.Loadpoints() {
-			connector := id + 1
-
-			status := ocppcore.ChargePointStatusAvailable
-			if lp.GetStatus() == api.StatusC {
-				status = ocppcore.ChargePointStatusCharging
+			conn, ok := s.connectors[id+1]
+			if !ok {
+				conn = &connector{id: id + 1}
+				s.connectors[conn.id] = conn
 			}
 
-			s.log.DEBUG.Printf("send: lp-%d status: %+v", connector, status)
-			if _, err := s.cp.StatusNotification(connector, ocppcore.NoError, status); err != nil {
-				s.log.ERROR.Printf("lp-%d: %v", connector, err)
+			if err := s.update(conn, lp); err != nil {
+				s.log.ERROR.Printf("lp-%d: %v", conn.id, err)
 			}
 		}
 
 		time.Sleep(retryTimeout)
 	}
 }
+
+// meterKey is the settings key of the connector's persistent energy register
+func (conn *connector) meterKey() string {
+	return fmt.Sprintf("ocpp.connector%d.meter", conn.id)
+}
+
+// meter returns the connector's energy register in Wh
+func (conn *connector) meter() float64 {
+	res, _ := settings.Float(conn.meterKey())
+	return res
+}
+
+// connectorStatus maps loadpoint status to OCPP connector status
+func connectorStatus(status api.ChargeStatus, enabled bool) ocppcore.ChargePointStatus {
+	switch {
+	case status == api.StatusC:
+		return ocppcore.ChargePointStatusCharging
+	case status == api.StatusB && enabled:
+		return ocppcore.ChargePointStatusSuspendedEV
+	case status == api.StatusB:
+		return ocppcore.ChargePointStatusSuspendedEVSE
+	default:
+		return ocppcore.ChargePointStatusAvailable
+	}
+}
+
+// update synchronizes status, transaction and meter values of a single connector
+func (s *OCPP) update(conn *connector, lp loadpoint.API) error {
+	lpStatus := lp.GetStatus()
+	status := connectorStatus(lpStatus, lp.GetMode() != api.ModeOff)
+	now := types.NewDateTime(time.Now())
+
+	// start transaction on connect
+	if conn.txn == 0 && (lpStatus == api.StatusB || lpStatus == api.StatusC) {
+		conn.meterStart = conn.meter()
+
+		s.log.DEBUG.Printf("send: lp-%d start transaction", conn.id)
+		res, err := s.cp.StartTransaction(conn.id, s.idTag, int(conn.meterStart), now)
+		if err != nil {
+			return fmt.Errorf("start transaction: %w", err)
+		}
+
+		if res.IdTagInfo != nil && res.IdTagInfo.Status != types.AuthorizationStatusAccepted {
+			s.log.WARN.Printf("lp-%d: transaction not authorized: %s", conn.id, res.IdTagInfo.Status)
+		}
+
+		conn.txn = res.TransactionId
+	}
+
+	meter := conn.meterStart + lp.GetChargedEnergy()
+
+	// stop transaction on disconnect
+	if conn.txn != 0 && lpStatus == api.StatusA {
+		s.log.DEBUG.Printf("send: lp-%d stop transaction %d", conn.id, conn.txn)
+		if _, err := s.cp.StopTransaction(int(meter), now, conn.txn); err != nil {
+			return fmt.Errorf("stop transaction: %w", err)
+		}
+
+		settings.SetFloat(conn.meterKey(), meter)
+		conn.txn = 0
+	}
+
+	if status != conn.status {
+		s.log.DEBUG.Printf("send: lp-%d status: %+v", conn.id, status)
+		if _, err := s.cp.StatusNotification(conn.id, ocppcore.NoError, status); err != nil {
+			return fmt.Errorf("status: %w", err)
+		}
+
+		conn.status = status
+	}
+
+	if conn.txn != 0 && time.Since(conn.meterValues) >= s.meterInterval {
+		mv := []types.MeterValue{{
+			Timestamp: now,
+			SampledValue: []types.SampledValue{
+				{
+					Value:     fmt.Sprintf("%.0f", meter),
+					Measurand: types.MeasurandEnergyActiveImportRegister,
+					Unit:      types.UnitOfMeasureWh,
+				},
+				{
+					Value:     fmt.Sprintf("%.0f", lp.GetChargePower()),
+					Measurand: types.MeasurandPowerActiveImport,
+					Unit:      types.UnitOfMeasureW,
+				},
+			},
+		}}
+
+		if _, err := s.cp.MeterValues(conn.id, mv, func(req *ocppcore.MeterValuesRequest) {
+			req.TransactionId = &conn.txn
+		}); err != nil {
+			return fmt.Errorf("meter values: %w", err)
+		}
+
+		conn.meterValues = time.Now()
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package openadr
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+func (c *OpenADR) handlers(router *mux.Router) {
+	r := router.PathPrefix(basePath).Subrouter()
+	r.Methods(http.MethodGet).Path("/events").HandlerFunc(c.eventsHandler)
+	r.Methods(http.MethodPost).Path("/events/{id}/optout").HandlerFunc(c.optHandler(true))
+	r.Methods(http.MethodPost).Path("/events/{id}/optin").HandlerFunc(c.optHandler(false))
+}
+
+// eventsHandler returns the event history
+func (c *OpenADR) eventsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(c.Events())
+}
+
+// optHandler opts out of or back into an event
+func (c *OpenADR) optHandler(out bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := c.setOptOut(mux.Vars(r)["id"], out); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,181 @@
+package openadr
+
+import (
+	"encoding/xml"
+	"time"
+
+	"github.com/dylanmei/iso8601"
+)
+
+const schemaVersion = "2.0b"
+
+// service endpoints relative to the VTN url
+const (
+	serviceRegisterParty = "EiRegisterParty"
+	serviceEvent         = "EiEvent"
+	servicePoll          = "OadrPoll"
+)
+
+// event opt types
+const (
+	optIn  = "optIn"
+	optOut = "optOut"
+)
+
+// simple signal name, levels 0 (normal) to 3 (special)
+const signalSimple = "SIMPLE"
+
+// outgoing messages are written with explicit oadr, ei and pyld namespaces
+
+type payload struct {
+	XMLName      xml.Name `xml:"http://openadr.org/oadr-2.0b/2012/07 oadrPayload"`
+	SignedObject any      `xml:"http://openadr.org/oadr-2.0b/2012/07 oadrSignedObject"`
+}
+
+type createPartyRegistration struct {
+	XMLName       xml.Name `xml:"http://openadr.org/oadr-2.0b/2012/07 oadrCreatePartyRegistration"`
+	SchemaVersion string   `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 schemaVersion,attr"`
+	RequestID     string   `xml:"http://docs.oasis-open.org/ns/energyinterop/201110/payloads requestID"`
+	ProfileName   string   `xml:"http://openadr.org/oadr-2.0b/2012/07 oadrProfileName"`
+	TransportName string   `xml:"http://openadr.org/oadr-2.0b/2012/07 oadrTransportName"`
+	ReportOnly    bool     `xml:"http://openadr.org/oadr-2.0b/2012/07 oadrReportOnly"`
+	XmlSignature  bool     `xml:"http://openadr.org/oadr-2.0b/2012/07 oadrXmlSignature"`
+	VenName       string   `xml:"http://openadr.org/oadr-2.0b/2012/07 oadrVenName"`
+	HttpPullModel bool     `xml:"http://openadr.org/oadr-2.0b/2012/07 oadrHttpPullModel"`
+}
+
+type requestEvent struct {
+	XMLName       xml.Name `xml:"http://openadr.org/oadr-2.0b/2012/07 oadrRequestEvent"`
+	SchemaVersion string   `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 schemaVersion,attr"`
+	RequestID     string   `xml:"http://docs.oasis-open.org/ns/energyinterop/201110/payloads eiRequestEvent>requestID"`
+	VenID         string   `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 eiRequestEvent>venID"`
+}
+
+type poll struct {
+	XMLName       xml.Name `xml:"http://openadr.org/oadr-2.0b/2012/07 oadrPoll"`
+	SchemaVersion string   `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 schemaVersion,attr"`
+	VenID         string   `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 venID"`
+}
+
+type createdEvent struct {
+	XMLName       xml.Name        `xml:"http://openadr.org/oadr-2.0b/2012/07 oadrCreatedEvent"`
+	SchemaVersion string          `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 schemaVersion,attr"`
+	Response      eiResponse      `xml:"http://docs.oasis-open.org/ns/energyinterop/201110/payloads eiCreatedEvent>eiResponse"`
+	Responses     []eventResponse `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 eiCreatedEvent>eventResponses>eventResponse"`
+	VenID         string          `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 eiCreatedEvent>venID"`
+}
+
+type eiResponse struct {
+	ResponseCode        string `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 responseCode"`
+	ResponseDescription string `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 responseDescription,omitempty"`
+	RequestID           string `xml:"http://docs.oasis-open.org/ns/energyinterop/201110/payloads requestID"`
+}
+
+type eventResponse struct {
+	ResponseCode       string `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 responseCode"`
+	RequestID          string `xml:"http://docs.oasis-open.org/ns/energyinterop/201110/payloads requestID"`
+	EventID            string `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 qualifiedEventID>eventID"`
+	ModificationNumber int    `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 qualifiedEventID>modificationNumber"`
+	OptType            string `xml:"http://docs.oasis-open.org/ns/energyinterop/201110 optType"`
+}
+
+// incoming messages are matched by local name
+
+type response struct {
+	XMLName      xml.Name `xml:"oadrPayload"`
+	SignedObject struct {
+		Response                 *vtnResponse              `xml:"oadrResponse"`
+		CreatedPartyRegistration *createdPartyRegistration `xml:"oadrCreatedPartyRegistration"`
+		DistributeEvent          *distributeEvent          `xml:"oadrDistributeEvent"`
+		RequestReregistration    *struct{}                 `xml:"oadrRequestReregistration"`
+		CancelPartyRegistration  *struct{}                 `xml:"oadrCancelPartyRegistration"`
+	} `xml:"oadrSignedObject"`
+}
+
+type vtnResponse struct {
+	ResponseCode        string `xml:"eiResponse>responseCode"`
+	ResponseDescription string `xml:"eiResponse>responseDescription"`
+}
+
+type createdPartyRegistration struct {
+	vtnResponse
+	RegistrationID string   `xml:"registrationID"`
+	VenID          string   `xml:"venID"`
+	VtnID          string   `xml:"vtnID"`
+	PollFreq       duration `xml:"oadrRequestedOadrPollFreq>duration"`
+}
+
+type distributeEvent struct {
+	vtnResponse
+	RequestID string  `xml:"requestID"`
+	VtnID     string  `xml:"vtnID"`
+	Events    []event `xml:"oadrEvent"`
+}
+
+type event struct {
+	Descriptor struct {
+		EventID            string `xml:"eventID"`
+		ModificationNumber int    `xml:"modificationNumber"`
+		EventStatus        string `xml:"eventStatus"`
+		TestEvent          string `xml:"testEvent"`
+	} `xml:"eiEvent>eventDescriptor"`
+	Start            time.Time     `xml:"eiEvent>eiActivePeriod>properties>dtstart>date-time"`
+	Duration         duration      `xml:"eiEvent>eiActivePeriod>properties>duration>duration"`
+	Signals          []eventSignal `xml:"eiEvent>eiEventSignals>eiEventSignal"`
+	ResponseRequired string        `xml:"oadrResponseRequired"`
+}
+
+type eventSignal struct {
+	SignalName   string     `xml:"signalName"`
+	SignalType   string     `xml:"signalType"`
+	CurrentValue *float64   `xml:"currentValue>payloadFloat>value"`
+	Intervals    []interval `xml:"intervals>interval"`
+}
+
+type interval struct {
+	Duration duration `xml:"duration>duration"`
+	Value    float64  `xml:"signalPayload>payloadFloat>value"`
+}
+
+// duration is an ISO 8601 duration
+type duration time.Duration
+
+func (d *duration) UnmarshalText(b []byte) error {
+	res, err := iso8601.ParseDuration(string(b))
+	*d = duration(res)
+	return err
+}
+
+// end returns the end of the event's active period, zero for open ended events
+func (e event) end() time.Time {
+	if e.Duration == 0 {
+		return time.Time{}
+	}
+	return e.Start.Add(time.Duration(e.Duration))
+}
+
+// active returns true if the event's active period includes ts
+func (e event) active(ts time.Time) bool {
+	if e.Descriptor.EventStatus == "cancelled" || ts.Before(e.Start) {
+		return false
+	}
+	end := e.end()
+	return end.IsZero() || ts.Before(end)
+}
+
+// value returns the signal's interval value at ts, falling back to the signal's current value
+func (s eventSignal) value(start, ts time.Time) (float64, bool) {
+	for _, i := range s.Intervals {
+		end := start.Add(time.Duration(i.Duration))
+		if i.Duration == 0 || ts.Before(end) {
+			return i.Value, !ts.Before(start)
+		}
+		start = end
+	}
+
+	if s.CurrentValue != nil {
+		return *s.CurrentValue, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,476 @@
+package openadr
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/server"
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+	"github.com/evcc-io/evcc/util/transport"
+	"github.com/google/uuid"
+)
+
+const (
+	basePath    = "/openadr"
+	historyKey  = "openadr.events"
+	maxHistory  = 100
+	statusOK    = "200"
+	profileName = "2.0b"
+	transportID = "simpleHttp"
+)
+
+// event status as exposed to the ui
+const (
+	StatusPending   = "pending"
+	StatusActive    = "active"
+	StatusCompleted = "completed"
+	StatusCancelled = "cancelled"
+)
+
+// Event is a demand response event received from the VTN
+type Event struct {
+	ID           string    `json:"id"`
+	Modification int       `json:"modification"`
+	Start        time.Time `json:"start"`
+	End          time.Time `json:"end"` // zero for open ended events
+	Level        float64   `json:"level"`
+	Test         bool      `json:"test"`
+	Status       string    `json:"status"`
+	OptOut       bool      `json:"optOut"`
+}
+
+// OpenADR is an OpenADR 2.0b virtual end node (VEN) polling the utility's VTN for demand response events.
+// Active events cap the site's total charge power unless opted out.
+type OpenADR struct {
+	mu sync.Mutex
+	*request.Helper
+	log      *util.Logger
+	site     site.API
+	uri      string
+	venName  string
+	power    float64
+	interval time.Duration
+
+	venID   string
+	events  map[string]event // events distributed by the VTN
+	history []Event          // most recent last
+	active  bool
+}
+
+// New creates OpenADR VEN from generic config
+func New(other map[string]interface{}, site site.API, httpd *server.HTTPd) (*OpenADR, error) {
+	cc := struct {
+		URI      string
+		VenName  string
+		Power    float64
+		Cert     string
+		Key      string
+		Insecure bool
+		Interval time.Duration
+	}{
+		VenName:  "evcc",
+		Interval: time.Minute,
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.URI == "" {
+		return nil, errors.New("missing uri")
+	}
+
+	if cc.Power < 0 {
+		return nil, errors.New("power must not be negative")
+	}
+
+	log := util.NewLogger("openadr")
+
+	tr := transport.Default()
+	tr.TLSClientConfig = &tls.Config{InsecureSkipVerify: cc.Insecure}
+
+	// OpenADR 2.0b requires client certificates issued by the alliance's PKI
+	if cc.Cert != "" || cc.Key != "" {
+		cert, err := tls.LoadX509KeyPair(cc.Cert, cc.Key)
+		if err != nil {
+			return nil, fmt.Errorf("certificate: %w", err)
+		}
+		tr.TLSClientConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	c := &OpenADR{
+		Helper:   request.NewHelper(log),
+		log:      log,
+		site:     site,
+		uri:      strings.TrimSuffix(cc.URI, "/") + "/OpenADR2/Simple/2.0b/",
+		venName:  cc.VenName,
+		power:    cc.Power,
+		interval: cc.Interval,
+		events:   make(map[string]event),
+	}
+
+	c.Client.Transport = tr
+
+	if err := settings.Json(historyKey, &c.history); err != nil && !errors.Is(err, settings.ErrNotFound) {
+		log.ERROR.Println("history:", err)
+	}
+
+	c.handlers(httpd.Router())
+
+	return c, nil
+}
+
+// Run executes the VEN poll loop
+func (c *OpenADR) Run() {
+	for tick := time.Tick(c.interval); ; <-tick {
+		if err := c.run(); err != nil {
+			c.log.ERROR.Println(err)
+		}
+
+		c.evaluate(time.Now())
+	}
+}
+
+func (c *OpenADR) run() error {
+	c.mu.Lock()
+	venID := c.venID
+	c.mu.Unlock()
+
+	if venID == "" {
+		if err := c.register(); err != nil {
+			return fmt.Errorf("register: %w", err)
+		}
+
+		res, err := c.post(serviceEvent, requestEvent{
+			SchemaVersion: schemaVersion,
+			RequestID:     uuid.NewString(),
+			VenID:         c.vtnVenID(),
+		})
+		if err != nil {
+			return fmt.Errorf("request events: %w", err)
+		}
+
+		return c.handle(res)
+	}
+
+	res, err := c.post(servicePoll, poll{
+		SchemaVersion: schemaVersion,
+		VenID:         venID,
+	})
+	if err != nil {
+		return fmt.Errorf("poll: %w", err)
+	}
+
+	return c.handle(res)
+}
+
+// post sends a signed object and decodes the VTN's response
+func (c *OpenADR) post(service string, obj any) (response, error) {
+	var res response
+
+	b, err := xml.Marshal(payload{SignedObject: obj})
+	if err != nil {
+		return res, err
+	}
+
+	req, err := request.New(http.MethodPost, c.uri+service, bytes.NewReader(append([]byte(xml.Header), b...)), request.XMLEncoding)
+	if err != nil {
+		return res, err
+	}
+
+	body, err := c.DoBody(req)
+	if err == nil {
+		err = xml.Unmarshal(body, &res)
+	}
+
+	return res, err
+}
+
+// register creates the party registration and stores the assigned VEN id
+func (c *OpenADR) register() error {
+	res, err := c.post(serviceRegisterParty, createPartyRegistration{
+		SchemaVersion: schemaVersion,
+		RequestID:     uuid.NewString(),
+		ProfileName:   profileName,
+		TransportName: transportID,
+		VenName:       c.venName,
+		HttpPullModel: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	reg := res.SignedObject.CreatedPartyRegistration
+	if reg == nil {
+		return errors.New("invalid response")
+	}
+
+	if reg.ResponseCode != statusOK {
+		return fmt.Errorf("%s: %s", reg.ResponseCode, reg.ResponseDescription)
+	}
+
+	if reg.VenID == "" {
+		return errors.New("missing ven id")
+	}
+
+	c.log.DEBUG.Printf("registered as %s (registration %s)", reg.VenID, reg.RegistrationID)
+
+	c.mu.Lock()
+	c.venID = reg.VenID
+	c.mu.Unlock()
+
+	return nil
+}
+
+// handle processes the VTN's response to a poll or event request
+func (c *OpenADR) handle(res response) error {
+	obj := res.SignedObject
+
+	switch {
+	case obj.DistributeEvent != nil:
+		return c.distribute(obj.DistributeEvent.Events)
+
+	case obj.RequestReregistration != nil:
+		c.log.DEBUG.Println("reregistration requested")
+		return c.register()
+
+	case obj.CancelPartyRegistration != nil:
+		c.log.WARN.Println("registration cancelled by vtn")
+		c.mu.Lock()
+		c.venID = ""
+		c.mu.Unlock()
+
+	case obj.Response != nil && obj.Response.ResponseCode != statusOK:
+		return fmt.Errorf("%s: %s", obj.Response.ResponseCode, obj.Response.ResponseDescription)
+	}
+
+	return nil
+}
+
+// distribute replaces the current events with the VTN's event list and responds where required
+func (c *OpenADR) distribute(events []event) error {
+	c.mu.Lock()
+
+	var responses []eventResponse
+
+	current := make(map[string]event, len(events))
+	for _, e := range events {
+		id := e.Descriptor.EventID
+		current[id] = e
+
+		prev, ok := c.events[id]
+		if ok && prev.Descriptor.ModificationNumber == e.Descriptor.ModificationNumber {
+			continue
+		}
+
+		rec := c.record(e)
+		c.log.INFO.Printf("event %s: level %.0f from %s (%s)", id, rec.Level, e.Start.Local().Format(time.DateTime), e.Descriptor.EventStatus)
+
+		if e.ResponseRequired != "never" {
+			responses = append(responses, c.eventResponse(e, rec.OptOut))
+		}
+	}
+
+	// events removed by the VTN
+	now := time.Now()
+	for id, e := range c.events {
+		if _, ok := current[id]; ok {
+			continue
+		}
+
+		if end := e.end(); !end.IsZero() && !end.After(now) {
+			c.setStatus(id, StatusCompleted)
+		} else {
+			c.setStatus(id, StatusCancelled)
+		}
+	}
+
+	c.events = current
+	c.mu.Unlock()
+
+	if len(responses) == 0 {
+		return nil
+	}
+
+	return c.created(responses)
+}
+
+// eventResponse creates the opt response for an event
+func (c *OpenADR) eventResponse(e event, out bool) eventResponse {
+	opt := optIn
+	if out {
+		opt = optOut
+	}
+
+	return eventResponse{
+		ResponseCode:       statusOK,
+		RequestID:          uuid.NewString(),
+		EventID:            e.Descriptor.EventID,
+		ModificationNumber: e.Descriptor.ModificationNumber,
+		OptType:            opt,
+	}
+}
+
+// vtnVenID returns the VEN id assigned by the VTN
+func (c *OpenADR) vtnVenID() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.venID
+}
+
+// created sends event opt responses to the VTN
+func (c *OpenADR) created(responses []eventResponse) error {
+	res, err := c.post(serviceEvent, createdEvent{
+		SchemaVersion: schemaVersion,
+		Response: eiResponse{
+			ResponseCode: statusOK,
+			RequestID:    uuid.NewString(),
+		},
+		Responses: responses,
+		VenID:     c.vtnVenID(),
+	})
+	if err != nil {
+		return fmt.Errorf("created event: %w", err)
+	}
+
+	return c.handle(res)
+}
+
+// level returns the event's simple signal level at ts
+func level(e event, ts time.Time) float64 {
+	var res float64
+	for _, s := range e.Signals {
+		if s.SignalName != signalSimple {
+			continue
+		}
+		if v, ok := s.value(e.Start, ts); ok {
+			res = max(res, v)
+		}
+	}
+	return res
+}
+
+// record adds or updates the event's history entry. Must be called with lock held.
+func (c *OpenADR) record(e event) *Event {
+	id := e.Descriptor.EventID
+
+	idx := slices.IndexFunc(c.history, func(h Event) bool { return h.ID == id })
+	if idx < 0 {
+		c.history = append(c.history, Event{ID: id})
+		if len(c.history) > maxHistory {
+			c.history = c.history[len(c.history)-maxHistory:]
+		}
+		idx = len(c.history) - 1
+	}
+
+	rec := &c.history[idx]
+	rec.Modification = e.Descriptor.ModificationNumber
+	rec.Start = e.Start
+	rec.End = e.end()
+	rec.Level = level(e, e.Start)
+	rec.Test = e.Descriptor.TestEvent != "" && e.Descriptor.TestEvent != "false"
+	rec.Status = StatusPending
+
+	if e.Descriptor.EventStatus == "cancelled" {
+		rec.Status = StatusCancelled
+	}
+
+	c.save()
+
+	return rec
+}
+
+// setStatus updates the status of the event's history entry. Must be called with lock held.
+func (c *OpenADR) setStatus(id, status string) {
+	for i := range c.history {
+		if rec := &c.history[i]; rec.ID == id && rec.Status != status && rec.Status != StatusCancelled {
+			rec.Status = status
+			c.save()
+		}
+	}
+}
+
+// save persists the event history. Must be called with lock held.
+func (c *OpenADR) save() {
+	if err := settings.SetJson(historyKey, c.history); err != nil {
+		c.log.ERROR.Println("history:", err)
+	}
+}
+
+// optedOut returns true if the event has been opted out. Must be called with lock held.
+func (c *OpenADR) optedOut(id string) bool {
+	idx := slices.IndexFunc(c.history, func(h Event) bool { return h.ID == id })
+	return idx >= 0 && c.history[idx].OptOut
+}
+
+// evaluate updates event status and applies the strictest active event's limit to the site
+func (c *OpenADR) evaluate(ts time.Time) {
+	c.mu.Lock()
+
+	var active bool
+	for id, e := range c.events {
+		switch {
+		case e.active(ts):
+			c.setStatus(id, StatusActive)
+			if !c.optedOut(id) {
+				active = active || level(e, ts) > 0
+			}
+
+		case !e.Start.After(ts):
+			c.setStatus(id, StatusCompleted)
+		}
+	}
+
+	changed := active != c.active
+	c.active = active
+	c.mu.Unlock()
+
+	if changed {
+		c.site.SetDemandResponse(active, c.power)
+	}
+}
+
+// setOptOut opts in or out of an event and notifies the VTN
+func (c *OpenADR) setOptOut(id string, out bool) error {
+	c.mu.Lock()
+
+	e, ok := c.events[id]
+	idx := slices.IndexFunc(c.history, func(h Event) bool { return h.ID == id })
+	if !ok || idx < 0 {
+		c.mu.Unlock()
+		return fmt.Errorf("unknown event: %s", id)
+	}
+
+	c.history[idx].OptOut = out
+	c.save()
+	c.mu.Unlock()
+
+	c.log.INFO.Printf("event %s: opt out %t", id, out)
+
+	err := c.created([]eventResponse{c.eventResponse(e, out)})
+	c.evaluate(time.Now())
+
+	return err
+}
+
+// Events returns the event history, most recent first
+func (c *OpenADR) Events() []Event {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	res := slices.Clone(c.history)
+	slices.Reverse(res)
+
+	return res
+}
@@ -0,0 +1,155 @@
+package openadr
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const distributeEventXML = `<?xml version="1.0" encoding="utf-8"?>
+<oadr:oadrPayload xmlns:oadr="http://openadr.org/oadr-2.0b/2012/07" xmlns:ei="http://docs.oasis-open.org/ns/energyinterop/201110" xmlns:pyld="http://docs.oasis-open.org/ns/energyinterop/201110/payloads" xmlns:xcal="urn:ietf:params:xml:ns:icalendar-2.0" xmlns:strm="urn:ietf:params:xml:ns:icalendar-2.0:stream">
+  <oadr:oadrSignedObject>
+    <oadr:oadrDistributeEvent ei:schemaVersion="2.0b">
+      <ei:eiResponse><ei:responseCode>200</ei:responseCode><pyld:requestID/></ei:eiResponse>
+      <pyld:requestID>req-1</pyld:requestID>
+      <ei:vtnID>vtn</ei:vtnID>
+      <oadr:oadrEvent>
+        <ei:eiEvent>
+          <ei:eventDescriptor>
+            <ei:eventID>event-1</ei:eventID>
+            <ei:modificationNumber>2</ei:modificationNumber>
+            <ei:eventStatus>far</ei:eventStatus>
+            <ei:testEvent>false</ei:testEvent>
+          </ei:eventDescriptor>
+          <ei:eiActivePeriod>
+            <xcal:properties>
+              <xcal:dtstart><xcal:date-time>2026-10-15T16:00:00Z</xcal:date-time></xcal:dtstart>
+              <xcal:duration><xcal:duration>PT2H</xcal:duration></xcal:duration>
+            </xcal:properties>
+          </ei:eiActivePeriod>
+          <ei:eiEventSignals>
+            <ei:eiEventSignal>
+              <strm:intervals>
+                <ei:interval>
+                  <xcal:duration><xcal:duration>PT1H</xcal:duration></xcal:duration>
+                  <ei:signalPayload><ei:payloadFloat><ei:value>1.0</ei:value></ei:payloadFloat></ei:signalPayload>
+                </ei:interval>
+                <ei:interval>
+                  <xcal:duration><xcal:duration>PT1H</xcal:duration></xcal:duration>
+                  <ei:signalPayload><ei:payloadFloat><ei:value>2.0</ei:value></ei:payloadFloat></ei:signalPayload>
+                </ei:interval>
+              </strm:intervals>
+              <ei:signalName>SIMPLE</ei:signalName>
+              <ei:signalType>level</ei:signalType>
+              <ei:currentValue><ei:payloadFloat><ei:value>0.0</ei:value></ei:payloadFloat></ei:currentValue>
+            </ei:eiEventSignal>
+          </ei:eiEventSignals>
+        </ei:eiEvent>
+        <oadr:oadrResponseRequired>always</oadr:oadrResponseRequired>
+      </oadr:oadrEvent>
+    </oadr:oadrDistributeEvent>
+  </oadr:oadrSignedObject>
+</oadr:oadrPayload>`
+
+func TestDistributeEvent(t *testing.T) {
+	var res response
+	require.NoError(t, xml.Unmarshal([]byte(distributeEventXML), &res))
+
+	de := res.SignedObject.DistributeEvent
+	require.NotNil(t, de)
+	require.Len(t, de.Events, 1)
+
+	e := de.Events[0]
+	start := time.Date(2026, 10, 15, 16, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "event-1", e.Descriptor.EventID)
+	assert.Equal(t, 2, e.Descriptor.ModificationNumber)
+	assert.Equal(t, start, e.Start)
+	assert.Equal(t, start.Add(2*time.Hour), e.end())
+
+	assert.False(t, e.active(start.Add(-time.Minute)))
+	assert.True(t, e.active(start))
+	assert.False(t, e.active(start.Add(2*time.Hour)))
+
+	assert.Equal(t, 1.0, level(e, start))
+	assert.Equal(t, 2.0, level(e, start.Add(90*time.Minute)))
+}
+
+type demandResponseSite struct {
+	site.API
+	active bool
+	power  float64
+}
+
+func (s *demandResponseSite) SetDemandResponse(active bool, power float64) {
+	s.active = active
+	s.power = power
+}
+
+func TestEvaluate(t *testing.T) {
+	var opt []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		for _, o := range []string{optIn, optOut} {
+			if strings.Contains(string(b), ">"+o+"<") {
+				opt = append(opt, o)
+			}
+		}
+		_, _ = w.Write([]byte(`<oadrPayload><oadrSignedObject><oadrResponse><eiResponse><responseCode>200</responseCode></eiResponse></oadrResponse></oadrSignedObject></oadrPayload>`))
+	}))
+	defer srv.Close()
+
+	site := new(demandResponseSite)
+	log := util.NewLogger("foo")
+
+	c := &OpenADR{
+		Helper: request.NewHelper(log),
+		log:    log,
+		site:   site,
+		uri:    srv.URL + "/",
+		venID:  "ven",
+		power:  1000,
+		events: make(map[string]event),
+	}
+
+	var res response
+	require.NoError(t, xml.Unmarshal([]byte(distributeEventXML), &res))
+	require.NoError(t, c.handle(res))
+	assert.Equal(t, []string{optIn}, opt)
+
+	// repeated distribution is not acknowledged again
+	require.NoError(t, c.handle(res))
+	assert.Len(t, opt, 1)
+
+	start := time.Date(2026, 10, 15, 16, 0, 0, 0, time.UTC)
+
+	c.evaluate(start.Add(-time.Minute))
+	assert.False(t, site.active)
+	assert.Equal(t, StatusPending, c.Events()[0].Status)
+
+	c.evaluate(start)
+	assert.True(t, site.active)
+	assert.Equal(t, 1000.0, site.power)
+	assert.Equal(t, StatusActive, c.Events()[0].Status)
+
+	require.NoError(t, c.setOptOut("event-1", true))
+	assert.Equal(t, []string{optIn, optOut}, opt)
+	assert.True(t, c.Events()[0].OptOut)
+
+	c.evaluate(start)
+	assert.False(t, site.active)
+
+	c.evaluate(start.Add(2 * time.Hour))
+	assert.Equal(t, StatusCompleted, c.Events()[0].Status)
+}
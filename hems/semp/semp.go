@@ -451,6 +451,13 @@ func (s *SEMP) planningRequest(id int, lp loadpoint.API) (res PlanningRequest) {
 		latestEnd = 24 * 3600
 	}
 
+	// charge plan defines the demand window
+	planTime := lp.EffectivePlanTime()
+	planned := !planTime.IsZero() && (mode == api.ModeMinPV || mode == api.ModePV)
+	if planned {
+		latestEnd = max(int(time.Until(planTime)/time.Second), 0)
+	}
+
 	// remaining max energy demand in Wh
 	chargeRemainingEnergy := lp.GetRemainingEnergy()
 	maxEnergy := int(chargeRemainingEnergy)
@@ -460,8 +467,9 @@ func (s *SEMP) planningRequest(id int, lp loadpoint.API) (res PlanningRequest) {
 		maxEnergy = 1e3 // 1kWh
 	}
 
+	// energy is optional in pv mode unless required by the charge plan
 	minEnergy := maxEnergy
-	if mode == api.ModePV {
+	if mode == api.ModePV && !planned {
 		minEnergy = 0
 	}
 
@@ -533,7 +541,14 @@ func (s *SEMP) deviceControlHandler(w http.ResponseWriter, r *http.Request) {
 				demand = loadpoint.RemoteEnable
 			}
 
+			// follow the recommended power instead of competing for the same surplus
+			var power float64
+			if dev.On {
+				power = dev.RecommendedPowerConsumption
+			}
+
 			lp.RemoteControl(sempController, demand)
+			lp.RemotePower(sempController, power)
 		}
 	}
 
@@ -0,0 +1,207 @@
+package victron
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/provider/mqtt"
+	"github.com/evcc-io/evcc/server"
+	"github.com/evcc-io/evcc/util"
+)
+
+// Victron publishes loadpoints as EV chargers to a Venus OS GX device.
+// Registration uses the dbus-mqtt-devices driver which must be installed on the GX device.
+type Victron struct {
+	mu        sync.Mutex
+	log       *util.Logger
+	client    *mqtt.Client
+	site      site.API
+	clientID  string
+	interval  time.Duration
+	portal    string
+	instances map[string]string
+}
+
+// status is the dbus-mqtt-devices registration message
+type status struct {
+	ClientID  string            `json:"clientId"`
+	Connected int               `json:"connected"`
+	Version   string            `json:"version"`
+	Services  map[string]string `json:"services"`
+}
+
+// registration is the dbus-mqtt-devices registration response
+type registration struct {
+	PortalID       string            `json:"portalId"`
+	DeviceInstance map[string]string `json:"deviceInstance"`
+}
+
+// evcharger status values
+const (
+	statusDisconnected = 0
+	statusConnected    = 1
+	statusCharging     = 2
+)
+
+// evcharger modes
+const (
+	modeManual = 0
+	modeAuto   = 1
+)
+
+// New creates Victron HEMS from generic config
+func New(other map[string]interface{}, site site.API) (*Victron, error) {
+	cc := struct {
+		mqtt.Config `mapstructure:",squash"`
+		Interval    time.Duration
+	}{
+		Interval: 5 * time.Second,
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	log := util.NewLogger("victron")
+
+	client, err := mqtt.RegisteredClientOrDefault(log, cc.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	clientID := cc.ClientID
+	if clientID == "" {
+		clientID = "evcc"
+	}
+
+	v := &Victron{
+		log:      log,
+		client:   client,
+		site:     site,
+		clientID: clientID,
+		interval: cc.Interval,
+	}
+
+	if err := client.Listen(fmt.Sprintf("device/%s/DBus", clientID), v.registered); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// service returns the service id of the loadpoint
+func service(id int) string {
+	return fmt.Sprintf("evcharger%d", id+1)
+}
+
+// registered handles the registration response
+func (v *Victron) registered(payload string) {
+	var res registration
+	if err := json.Unmarshal([]byte(payload), &res); err != nil {
+		v.log.ERROR.Println("registration:", err)
+		return
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.log.DEBUG.Printf("registered at %s: %v", res.PortalID, res.DeviceInstance)
+
+	v.portal = res.PortalID
+	v.instances = res.DeviceInstance
+}
+
+// register announces the loadpoints as EV charger services
+func (v *Victron) register() error {
+	services := make(map[string]string)
+	for id := range v.site.Loadpoints() {
+		services[service(id)] = "evcharger"
+	}
+
+	b, err := json.Marshal(status{
+		ClientID:  v.clientID,
+		Connected: 1,
+		Version:   server.Version,
+		Services:  services,
+	})
+	if err != nil {
+		return err
+	}
+
+	return v.client.Publish(fmt.Sprintf("device/%s/Status", v.clientID), false, b)
+}
+
+// write writes the dbus path value
+func (v *Victron) write(prefix, path string, val any) {
+	b, err := json.Marshal(map[string]any{"value": val})
+	if err == nil {
+		err = v.client.Publish(prefix+path, false, b)
+	}
+
+	if err != nil {
+		v.log.ERROR.Printf("%s: %v", path, err)
+	}
+}
+
+// publish publishes the loadpoint values
+func (v *Victron) publish(prefix string, lp loadpoint.API) {
+	state := statusDisconnected
+	switch lp.GetStatus() {
+	case api.StatusB:
+		state = statusConnected
+	case api.StatusC:
+		state = statusCharging
+	}
+
+	mode := modeManual
+	if m := lp.GetMode(); m == api.ModePV || m == api.ModeMinPV {
+		mode = modeAuto
+	}
+
+	var startStop int
+	if lp.GetMode() != api.ModeOff {
+		startStop = 1
+	}
+
+	v.write(prefix, "/ProductName", "evcc")
+	v.write(prefix, "/CustomName", lp.Title())
+	v.write(prefix, "/Connected", 1)
+	v.write(prefix, "/Position", 0) // ac output
+	v.write(prefix, "/Status", state)
+	v.write(prefix, "/Mode", mode)
+	v.write(prefix, "/StartStop", startStop)
+	v.write(prefix, "/MaxCurrent", lp.GetMaxCurrent())
+	v.write(prefix, "/Ac/Power", lp.GetChargePower())
+	v.write(prefix, "/Ac/Energy/Forward", lp.GetChargedEnergy()/1e3)
+}
+
+// Run executes the Victron runtime
+func (v *Victron) Run() {
+	for tick := time.Tick(v.interval); ; <-tick {
+		v.mu.Lock()
+		portal, instances := v.portal, v.instances
+		v.mu.Unlock()
+
+		// (re)register until the driver has assigned device instances
+		if portal == "" {
+			if err := v.register(); err != nil {
+				v.log.ERROR.Println("register:", err)
+			}
+			continue
+		}
+
+		for id, lp := range v.site.Loadpoints() {
+			instance, ok := instances[service(id)]
+			if !ok {
+				continue
+			}
+
+			v.publish(fmt.Sprintf("W/%s/evcharger/%s", portal, instance), lp)
+		}
+	}
+}
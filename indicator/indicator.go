@@ -0,0 +1,227 @@
+package indicator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/provider"
+	"github.com/evcc-io/evcc/util"
+)
+
+// State is the condition shown by an indicator
+type State string
+
+const (
+	StateOff      State = "off"      // no vehicle connected
+	StateIdle     State = "idle"     // vehicle connected but not charging
+	StateSurplus  State = "surplus"  // not charging while pv surplus is available
+	StateCharging State = "charging" // charging
+	StateFault    State = "fault"    // recent error
+)
+
+// Config is the indicator configuration
+type Config struct {
+	Loadpoint  int               // loadpoint number, 0 for site
+	MaxSurplus float64           // surplus power corresponding to full level
+	FaultHold  time.Duration     // duration an error is indicated
+	Colors     map[string]string // color per state
+	State      *provider.Config  // receives the state name
+	Color      *provider.Config  // receives the state color, e.g. for MQTT lights or WS2812 strips
+	Level      *provider.Config  // receives the surplus level in %
+}
+
+// Indicator mirrors evcc states to physical outputs like LEDs or displays
+type Indicator struct {
+	log        *util.Logger
+	clock      clock.Clock
+	lp         int
+	maxSurplus float64
+	faultHold  time.Duration
+	colors     map[State]string
+
+	stateS func(string) error
+	colorS func(string) error
+	levelS func(int64) error
+
+	connected, charging bool
+	gridPower           float64
+	faulted             time.Time
+
+	state State
+	level int64
+}
+
+var defaultColors = map[State]string{
+	StateOff:      "#000000",
+	StateIdle:     "#0000ff",
+	StateSurplus:  "#ffff00",
+	StateCharging: "#00ff00",
+	StateFault:    "#ff0000",
+}
+
+// NewFromConfig creates an indicator from configuration
+func NewFromConfig(cc Config) (*Indicator, error) {
+	if cc.State == nil && cc.Color == nil && cc.Level == nil {
+		return nil, errors.New("missing state, color or level output")
+	}
+
+	if cc.MaxSurplus == 0 {
+		cc.MaxSurplus = 5000
+	}
+
+	if cc.FaultHold == 0 {
+		cc.FaultHold = 5 * time.Minute
+	}
+
+	name := "indicator"
+	if cc.Loadpoint > 0 {
+		name = fmt.Sprintf("indicator-%d", cc.Loadpoint)
+	}
+
+	v := &Indicator{
+		log:        util.NewLogger(name),
+		clock:      clock.New(),
+		lp:         cc.Loadpoint,
+		maxSurplus: cc.MaxSurplus,
+		faultHold:  cc.FaultHold,
+		colors:     make(map[State]string),
+		level:      -1,
+	}
+
+	for k, c := range defaultColors {
+		v.colors[k] = c
+	}
+
+	for k, c := range cc.Colors {
+		s := State(strings.ToLower(k))
+		if _, ok := defaultColors[s]; !ok {
+			return nil, fmt.Errorf("invalid state: %s", k)
+		}
+		v.colors[s] = c
+	}
+
+	var err error
+	if cc.State != nil {
+		if v.stateS, err = provider.NewStringSetterFromConfig("state", *cc.State); err != nil {
+			return nil, fmt.Errorf("state: %w", err)
+		}
+	}
+
+	if cc.Color != nil {
+		if v.colorS, err = provider.NewStringSetterFromConfig("color", *cc.Color); err != nil {
+			return nil, fmt.Errorf("color: %w", err)
+		}
+	}
+
+	if cc.Level != nil {
+		if v.levelS, err = provider.NewIntSetterFromConfig("level", *cc.Level); err != nil {
+			return nil, fmt.Errorf("level: %w", err)
+		}
+	}
+
+	return v, nil
+}
+
+// Run updates the outputs from published values
+func (v *Indicator) Run(in <-chan util.Param) {
+	for p := range in {
+		if v.process(p) {
+			v.update()
+		}
+	}
+}
+
+// process applies a published value and returns true if it is relevant to the indicator
+func (v *Indicator) process(p util.Param) bool {
+	if p.Key == "log" {
+		if msg, ok := p.Val.(util.LogMessage); ok && msg.Level == "error" && (v.lp == 0 || msg.Loadpoint == v.lp) {
+			v.faulted = v.clock.Now()
+			return true
+		}
+		return false
+	}
+
+	// site values
+	if p.Loadpoint == nil {
+		if val, ok := p.Val.(float64); ok && p.Key == keys.GridPower {
+			v.gridPower = val
+			return true
+		}
+		return false
+	}
+
+	// loadpoint values
+	if v.lp != 0 && *p.Loadpoint != v.lp-1 {
+		return false
+	}
+
+	val, ok := p.Val.(bool)
+	if !ok {
+		return false
+	}
+
+	switch p.Key {
+	case keys.Connected:
+		v.connected = val
+	case keys.Charging:
+		v.charging = val
+	default:
+		return false
+	}
+
+	return true
+}
+
+// State returns the indicated state
+func (v *Indicator) State() State {
+	switch {
+	case !v.faulted.IsZero() && v.clock.Since(v.faulted) < v.faultHold:
+		return StateFault
+	case v.charging:
+		return StateCharging
+	case v.gridPower < 0 && (v.connected || v.lp == 0):
+		return StateSurplus
+	case v.connected:
+		return StateIdle
+	default:
+		return StateOff
+	}
+}
+
+// Level returns the surplus level in %
+func (v *Indicator) Level() int64 {
+	return int64(math.Round(100 * min(max(-v.gridPower/v.maxSurplus, 0), 1)))
+}
+
+// update writes changed values to the outputs
+func (v *Indicator) update() {
+	if state := v.State(); state != v.state {
+		v.state = state
+		v.log.DEBUG.Println("state:", state)
+
+		if v.stateS != nil {
+			if err := v.stateS(string(state)); err != nil {
+				v.log.ERROR.Printf("state: %v", err)
+			}
+		}
+
+		if v.colorS != nil {
+			if err := v.colorS(v.colors[state]); err != nil {
+				v.log.ERROR.Printf("color: %v", err)
+			}
+		}
+	}
+
+	if level := v.Level(); level != v.level && v.levelS != nil {
+		v.level = level
+
+		if err := v.levelS(level); err != nil {
+			v.log.ERROR.Printf("level: %v", err)
+		}
+	}
+}
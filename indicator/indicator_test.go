@@ -0,0 +1,60 @@
+package indicator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndicator(t *testing.T) {
+	clock := clock.NewMock()
+
+	var states []string
+	var levels []int64
+
+	v := &Indicator{
+		log:        util.NewLogger("foo"),
+		clock:      clock,
+		lp:         1,
+		maxSurplus: 4000,
+		faultHold:  time.Minute,
+		colors:     defaultColors,
+		stateS: func(s string) error {
+			states = append(states, s)
+			return nil
+		},
+		levelS: func(l int64) error {
+			levels = append(levels, l)
+			return nil
+		},
+		level: -1,
+	}
+
+	lp := func(i int) *int { return &i }
+
+	for _, p := range []util.Param{
+		{Key: keys.GridPower, Val: 1000.0},
+		{Loadpoint: lp(0), Key: keys.Connected, Val: true},
+		{Loadpoint: lp(1), Key: keys.Charging, Val: true}, // other loadpoint
+		{Key: keys.GridPower, Val: -2000.0},
+		{Loadpoint: lp(0), Key: keys.Charging, Val: true},
+		{Key: "log", Val: util.LogMessage{Level: "error", Loadpoint: 2}}, // other loadpoint
+		{Key: "log", Val: util.LogMessage{Level: "error", Loadpoint: 1}},
+	} {
+		if v.process(p) {
+			v.update()
+		}
+	}
+
+	assert.Equal(t, []string{"off", "idle", "surplus", "charging", "fault"}, states)
+	assert.Equal(t, []int64{0, 50}, levels)
+
+	// fault expires
+	clock.Add(2 * time.Minute)
+	v.update()
+	assert.Equal(t, StateCharging, v.State())
+}
@@ -0,0 +1,72 @@
+package meter
+
+import (
+	"errors"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/charger/eebus"
+	"github.com/evcc-io/evcc/util"
+)
+
+// EEBus is an appliance providing power consumption monitoring, e.g. a heat pump or white goods
+type EEBus struct {
+	log       *util.Logger
+	appliance *eebus.Appliance
+}
+
+func init() {
+	registry.Add("eebus", NewEEBusFromConfig)
+}
+
+// NewEEBusFromConfig creates an EEBus meter from generic config
+func NewEEBusFromConfig(other map[string]interface{}) (api.Meter, error) {
+	var cc struct {
+		Ski string
+		Ip  string
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	return NewEEBus(cc.Ski, cc.Ip)
+}
+
+// NewEEBus creates EEBus meter
+func NewEEBus(ski, ip string) (*EEBus, error) {
+	if eebus.Instance == nil {
+		return nil, errors.New("eebus not configured")
+	}
+
+	if ski == "" {
+		return nil, errors.New("missing ski")
+	}
+
+	m := &EEBus{
+		log: util.NewLogger("eebus"),
+	}
+
+	m.appliance = eebus.Instance.RegisterAppliance(ski, ip, m.onConnect, m.onDisconnect)
+
+	return m, nil
+}
+
+func (m *EEBus) onConnect(ski string) {
+	m.log.DEBUG.Println("appliance connected:", ski)
+}
+
+func (m *EEBus) onDisconnect(ski string) {
+	m.log.DEBUG.Println("appliance disconnected:", ski)
+}
+
+// CurrentPower implements the api.Meter interface
+func (m *EEBus) CurrentPower() (float64, error) {
+	return m.appliance.Power()
+}
+
+var _ api.MeterEnergy = (*EEBus)(nil)
+
+// TotalEnergy implements the api.MeterEnergy interface
+func (m *EEBus) TotalEnergy() (float64, error) {
+	return m.appliance.Energy()
+}
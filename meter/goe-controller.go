@@ -0,0 +1,100 @@
+package meter
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/meter/goecontroller"
+	"github.com/evcc-io/evcc/util"
+)
+
+// GoEController meter implementation
+type GoEController struct {
+	conn     *goecontroller.Connection
+	category int
+}
+
+func init() {
+	registry.Add("go-e-controller", NewGoEControllerFromConfig)
+}
+
+// NewGoEControllerFromConfig creates a go-e Controller meter from generic config
+func NewGoEControllerFromConfig(other map[string]interface{}) (api.Meter, error) {
+	cc := struct {
+		URI      string
+		Usage    string
+		Category string
+		Cache    time.Duration
+	}{
+		Cache: time.Second,
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	return NewGoEController(cc.URI, cc.Usage, cc.Category, cc.Cache)
+}
+
+// NewGoEController creates go-e Controller meter. The category defaults to the usage's default category.
+func NewGoEController(uri, usage, category string, cache time.Duration) (*GoEController, error) {
+	conn, err := goecontroller.NewConnection(uri, cache)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &GoEController{
+		conn: conn,
+	}
+
+	if category != "" {
+		status, err := conn.Status()
+		if err != nil {
+			return nil, err
+		}
+
+		if c.category = status.Category(category); c.category < 0 {
+			return nil, fmt.Errorf("invalid category: %s", category)
+		}
+
+		return c, nil
+	}
+
+	switch usage {
+	case "grid":
+		c.category = goecontroller.CategoryGrid
+	case "pv":
+		c.category = goecontroller.CategorySolar
+	case "battery":
+		c.category = goecontroller.CategoryBattery
+	default:
+		return nil, fmt.Errorf("invalid usage: %s", usage)
+	}
+
+	return c, nil
+}
+
+var _ api.Meter = (*GoEController)(nil)
+
+// CurrentPower implements the api.Meter interface
+func (c *GoEController) CurrentPower() (float64, error) {
+	status, err := c.conn.Status()
+	if err != nil {
+		return 0, err
+	}
+
+	return status.Power(c.category)
+}
+
+var _ api.MeterEnergy = (*GoEController)(nil)
+
+// TotalEnergy implements the api.MeterEnergy interface
+func (c *GoEController) TotalEnergy() (float64, error) {
+	status, err := c.conn.Status()
+	if err != nil {
+		return 0, err
+	}
+
+	return status.Energy(c.category)
+}
@@ -0,0 +1,139 @@
+package goecontroller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+	"nhooyr.io/websocket"
+)
+
+// Connection is the go-e Controller connection. Status is pushed via websocket and polled while disconnected.
+type Connection struct {
+	*request.Helper
+	log       *util.Logger
+	uri       string
+	mu        sync.Mutex
+	status    Status
+	updated   time.Time
+	cache     time.Duration
+	connected bool
+}
+
+// NewConnection creates a go-e Controller connection
+func NewConnection(uri string, cache time.Duration) (*Connection, error) {
+	log := util.NewLogger("go-e-controller")
+
+	c := &Connection{
+		Helper: request.NewHelper(log),
+		log:    log,
+		uri:    util.DefaultScheme(strings.TrimRight(uri, "/"), "http"),
+		cache:  cache,
+	}
+
+	if _, err := c.Status(); err != nil {
+		return nil, err
+	}
+
+	go c.run()
+
+	return c, nil
+}
+
+// Status returns the pushed status or polls while not connected
+func (c *Connection) Status() (Status, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.connected && time.Since(c.updated) > c.cache {
+		var res Status
+		if err := c.GetJSON(c.uri+"/api/status?filter=ccp,cec,ccn", &res); err != nil {
+			return res, err
+		}
+
+		c.status = res
+		c.updated = time.Now()
+	}
+
+	return c.status, nil
+}
+
+// run receives status updates via websocket and reconnects on errors
+func (c *Connection) run() {
+	uri := "ws" + strings.TrimPrefix(c.uri, "http") + "/ws"
+
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = time.Second
+	bo.MaxInterval = time.Minute
+	bo.MaxElapsedTime = 0 // retry forever
+
+	for {
+		if err := c.receive(uri); err != nil {
+			c.log.DEBUG.Println("websocket:", err)
+		}
+
+		c.mu.Lock()
+		if c.connected {
+			bo.Reset()
+		}
+		c.connected = false
+		c.mu.Unlock()
+
+		time.Sleep(bo.NextBackOff())
+	}
+}
+
+func (c *Connection) receive(uri string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), request.Timeout)
+	conn, _, err := websocket.Dial(ctx, uri, nil)
+	cancel()
+	if err != nil {
+		return err
+	}
+	defer conn.Close(websocket.StatusNormalClosure, "")
+
+	for {
+		// status is pushed at least every few seconds
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		_, b, err := conn.Read(ctx)
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		if err := c.update(b); err != nil {
+			return err
+		}
+	}
+}
+
+// update applies a full or delta status message
+func (c *Connection) update(b []byte) error {
+	var msg Message
+	if err := json.Unmarshal(b, &msg); err != nil {
+		return fmt.Errorf("invalid message: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch msg.Type {
+	case "fullStatus":
+		c.status = c.status.merge(msg.Status)
+		c.connected = true
+	case "deltaStatus":
+		c.status = c.status.merge(msg.Status)
+	default:
+		return nil
+	}
+
+	c.updated = time.Now()
+
+	return nil
+}
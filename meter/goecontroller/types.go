@@ -0,0 +1,69 @@
+package goecontroller
+
+import (
+	"errors"
+	"strings"
+)
+
+// Category indices of the default category configuration
+const (
+	CategoryHome    = 0
+	CategoryGrid    = 1
+	CategoryCar     = 2
+	CategoryRelais  = 3
+	CategorySolar   = 4
+	CategoryBattery = 5
+)
+
+// Status is the v2 api status
+type Status struct {
+	Ccp []*float64  `json:"ccp,omitempty"` // category power [W]
+	Cec [][]float64 `json:"cec,omitempty"` // category energy in/out [Wh]
+	Ccn []string    `json:"ccn,omitempty"` // category names
+}
+
+// Message is the websocket message
+type Message struct {
+	Type   string // hello, fullStatus, deltaStatus
+	Status Status
+}
+
+// merge returns the status updated with the keys present in the delta
+func (s Status) merge(delta Status) Status {
+	if delta.Ccp != nil {
+		s.Ccp = delta.Ccp
+	}
+	if delta.Cec != nil {
+		s.Cec = delta.Cec
+	}
+	if delta.Ccn != nil {
+		s.Ccn = delta.Ccn
+	}
+	return s
+}
+
+// Category returns the index of the named category or -1
+func (s Status) Category(name string) int {
+	for i, n := range s.Ccn {
+		if strings.EqualFold(n, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Power returns the category power
+func (s Status) Power(category int) (float64, error) {
+	if category < 0 || category >= len(s.Ccp) || s.Ccp[category] == nil {
+		return 0, errors.New("invalid category power")
+	}
+	return *s.Ccp[category], nil
+}
+
+// Energy returns the category energy in kWh
+func (s Status) Energy(category int) (float64, error) {
+	if category < 0 || category >= len(s.Cec) || len(s.Cec[category]) == 0 {
+		return 0, errors.New("invalid category energy")
+	}
+	return s.Cec[category][0] / 1e3, nil
+}
@@ -0,0 +1,33 @@
+package goecontroller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdate(t *testing.T) {
+	c := new(Connection)
+
+	require.NoError(t, c.update([]byte(`{"type":"fullStatus","status":{"ccp":[500,-1200,null,0,1700],"cec":[[0,0],[12000,3000]],"ccn":["Home","Grid","Car","Relais","Solar"]}}`)))
+	assert.True(t, c.connected)
+
+	p, err := c.status.Power(CategoryGrid)
+	require.NoError(t, err)
+	assert.Equal(t, -1200.0, p)
+
+	_, err = c.status.Power(CategoryCar)
+	assert.Error(t, err)
+
+	e, err := c.status.Energy(CategoryGrid)
+	require.NoError(t, err)
+	assert.Equal(t, 12.0, e)
+
+	// delta keeps other keys
+	require.NoError(t, c.update([]byte(`{"type":"deltaStatus","status":{"ccp":[500,300,null,0,0]}}`)))
+	p, _ = c.status.Power(CategoryGrid)
+	assert.Equal(t, 300.0, p)
+	assert.Equal(t, CategorySolar, c.status.Category("solar"))
+	assert.Equal(t, -1, c.status.Category("foo"))
+}
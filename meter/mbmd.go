@@ -79,7 +79,7 @@ func NewModbusMbmdFromConfig(other map[string]interface{}) (api.Meter, error) {
 	device, err := modbus.NewDevice(cc.Model, cc.SubDevice)
 
 	if err == nil {
-		err = device.Initialize(conn)
+		err = conn.InitializeDevice(device)
 
 		// silence Kostal implementation errors
 		if errors.Is(err, meters.ErrPartiallyOpened) {
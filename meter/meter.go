@@ -1,6 +1,7 @@
 package meter
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -13,7 +14,7 @@ func init() {
 	registry.Add(api.Custom, NewConfigurableFromConfig)
 }
 
-//go:generate go run ../cmd/tools/decorate.go -f decorateMeter -b api.Meter -t "api.MeterEnergy,TotalEnergy,func() (float64, error)" -t "api.PhaseCurrents,Currents,func() (float64, float64, float64, error)" -t "api.PhaseVoltages,Voltages,func() (float64, float64, float64, error)" -t "api.PhasePowers,Powers,func() (float64, float64, float64, error)" -t "api.Battery,Soc,func() (float64, error)" -t "api.BatteryCapacity,Capacity,func() float64" -t "api.BatteryController,SetBatteryMode,func(api.BatteryMode) error"
+//go:generate go run ../cmd/tools/decorate.go -f decorateMeter -b *Meter -r api.Meter -t "api.MeterEnergy,TotalEnergy,func() (float64, error)" -t "api.PhaseCurrents,Currents,func() (float64, float64, float64, error)" -t "api.PhaseVoltages,Voltages,func() (float64, float64, float64, error)" -t "api.PhasePowers,Powers,func() (float64, float64, float64, error)" -t "api.Battery,Soc,func() (float64, error)" -t "api.BatteryCapacity,Capacity,func() float64" -t "api.BatteryController,SetBatteryMode,func(api.BatteryMode) error"
 
 // NewConfigurableFromConfig creates api.Meter from config
 func NewConfigurableFromConfig(other map[string]interface{}) (api.Meter, error) {
@@ -41,12 +42,12 @@ func NewConfigurableFromConfig(other map[string]interface{}) (api.Meter, error)
 		return nil, err
 	}
 
-	power, err := provider.NewFloatGetterFromConfig(cc.Power)
+	power, err := provider.NewFloatGetterContextFromConfig(cc.Power)
 	if err != nil {
 		return nil, fmt.Errorf("power: %w", err)
 	}
 
-	m, _ := NewConfigurable(power)
+	m, _ := NewConfigurableContext(power)
 
 	// decorate energy
 	var totalEnergyG func() (float64, error)
@@ -151,6 +152,13 @@ func collectPhaseProviders(g []func() (float64, error)) func() (float64, float64
 
 // NewConfigurable creates a new meter
 func NewConfigurable(currentPowerG func() (float64, error)) (*Meter, error) {
+	return NewConfigurableContext(func(context.Context) (float64, error) {
+		return currentPowerG()
+	})
+}
+
+// NewConfigurableContext creates a new meter with cancellable power reading
+func NewConfigurableContext(currentPowerG func(context.Context) (float64, error)) (*Meter, error) {
 	m := &Meter{
 		currentPowerG: currentPowerG,
 	}
@@ -159,7 +167,7 @@ func NewConfigurable(currentPowerG func() (float64, error)) (*Meter, error) {
 
 // Meter is an api.Meter implementation with configurable getters and setters.
 type Meter struct {
-	currentPowerG func() (float64, error)
+	currentPowerG func(context.Context) (float64, error)
 }
 
 // Decorate attaches additional capabilities to the base meter
@@ -177,5 +185,12 @@ func (m *Meter) Decorate(
 
 // CurrentPower implements the api.Meter interface
 func (m *Meter) CurrentPower() (float64, error) {
-	return m.currentPowerG()
+	return m.currentPowerG(context.Background())
+}
+
+var _ api.MeterContext = (*Meter)(nil)
+
+// CurrentPowerContext implements the api.MeterContext interface
+func (m *Meter) CurrentPowerContext(ctx context.Context) (float64, error) {
+	return m.currentPowerG(ctx)
 }
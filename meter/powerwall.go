@@ -170,6 +170,18 @@ func NewPowerWall(uri, usage, user, password string, cache time.Duration, refres
 	return decoratePowerWall(m, totalEnergy, batterySoc, batteryCapacity, batModeS), nil
 }
 
+var _ api.BackupMode = (*PowerWall)(nil)
+
+// BackupMode implements the api.BackupMode interface
+func (m *PowerWall) BackupMode() (bool, error) {
+	res, err := m.client.GetGridStatus()
+	if err != nil {
+		return false, err
+	}
+
+	return res.GridStatus == powerwall.GridStatusIslanded, nil
+}
+
 var _ api.Meter = (*PowerWall)(nil)
 
 // CurrentPower implements the api.Meter interface
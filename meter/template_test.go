@@ -27,6 +27,7 @@ var acceptable = []string{
 	"context deadline exceeded",                        // LG ESS
 	"no ping response for 192.0.2.2",                   // SMA
 	"no such network interface",                        // SMA
+	"eebus not configured",
 }
 
 func TestTemplates(t *testing.T) {
@@ -0,0 +1,180 @@
+package meter
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/meter/victron"
+	"github.com/evcc-io/evcc/provider/mqtt"
+	"github.com/evcc-io/evcc/util"
+)
+
+/*
+This meter supports Victron GX devices running Venus OS using the local MQTT broker.
+
+** Usages **
+The following usages are supported:
+- grid    ... for reading the power imported or exported to the grid
+- pv      ... for reading the power produced by dc and ac coupled pv
+- battery ... for reading the power and soc of the battery, battery mode is controlled using ESS settings
+
+** Example configuration **
+meters:
+- name: battery
+  type: victron
+  broker: venus.local:1883
+  usage: battery
+  chargepower: 3000 # grid setpoint while charging from grid, battery charge mode is disabled if empty
+*/
+
+const (
+	victronMaxDischargePower = "settings/0/Settings/CGwacs/MaxDischargePower"
+	victronAcPowerSetPoint   = "settings/0/Settings/CGwacs/AcPowerSetPoint"
+)
+
+var victronPaths = map[string][]string{
+	"grid": {
+		"system/0/Ac/Grid/L1/Power", "system/0/Ac/Grid/L2/Power", "system/0/Ac/Grid/L3/Power",
+	},
+	"pv": {
+		"system/0/Dc/Pv/Power",
+		"system/0/Ac/PvOnGrid/L1/Power", "system/0/Ac/PvOnGrid/L2/Power", "system/0/Ac/PvOnGrid/L3/Power",
+		"system/0/Ac/PvOnOutput/L1/Power", "system/0/Ac/PvOnOutput/L2/Power", "system/0/Ac/PvOnOutput/L3/Power",
+	},
+	"battery": {
+		"system/0/Dc/Battery/Power",
+	},
+}
+
+const victronSoc = "system/0/Dc/Battery/Soc"
+
+// Victron implements the api.Meter interface
+type Victron struct {
+	venus        *victron.Venus
+	usage        string
+	gridSetpoint float64
+	chargePower  float64
+}
+
+func init() {
+	registry.Add("victron", NewVictronFromConfig)
+}
+
+//go:generate go run ../cmd/tools/decorate.go -f decorateVictron -b *Victron -r api.Meter -t "api.Battery,Soc,func() (float64, error)" -t "api.BatteryCapacity,Capacity,func() float64" -t "api.BatteryController,SetBatteryMode,func(api.BatteryMode) error"
+
+// NewVictronFromConfig creates a Victron meter from generic config
+func NewVictronFromConfig(other map[string]interface{}) (api.Meter, error) {
+	cc := struct {
+		mqtt.Config  `mapstructure:",squash"`
+		capacity     `mapstructure:",squash"`
+		Portal       string
+		Usage        string
+		GridSetpoint float64
+		ChargePower  float64
+		Timeout      time.Duration
+	}{
+		Timeout: time.Minute,
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	usage := strings.ToLower(cc.Usage)
+	if _, ok := victronPaths[usage]; !ok {
+		return nil, fmt.Errorf("invalid usage: %s", cc.Usage)
+	}
+
+	log := util.NewLogger("victron")
+
+	client, err := mqtt.RegisteredClientOrDefault(log, cc.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	venus, err := victron.NewVenus(log, client, cc.Portal, cc.Timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewVictron(venus, usage, cc.GridSetpoint, cc.ChargePower, cc.capacity.Decorator())
+}
+
+// NewVictron creates a Victron meter
+func NewVictron(venus *victron.Venus, usage string, gridSetpoint, chargePower float64, capacity func() float64) (api.Meter, error) {
+	m := &Victron{
+		venus:        venus,
+		usage:        usage,
+		gridSetpoint: gridSetpoint,
+		chargePower:  chargePower,
+	}
+
+	paths := victronPaths[usage]
+	if usage == "battery" {
+		paths = append(paths, victronSoc)
+	}
+
+	if err := venus.Subscribe(paths...); err != nil {
+		return nil, err
+	}
+
+	// decorate battery
+	var batterySoc func() (float64, error)
+	var batteryMode func(api.BatteryMode) error
+	if usage == "battery" {
+		batterySoc = m.batterySoc
+		batteryMode = m.setBatteryMode
+	}
+
+	return decorateVictron(m, batterySoc, capacity, batteryMode), nil
+}
+
+// CurrentPower implements the api.Meter interface
+func (m *Victron) CurrentPower() (float64, error) {
+	res, err := m.venus.Sum(victronPaths[m.usage]...)
+
+	// battery power is positive when charging
+	if m.usage == "battery" {
+		res = -res
+	}
+
+	return res, err
+}
+
+// batterySoc implements the api.Battery interface
+func (m *Victron) batterySoc() (float64, error) {
+	return m.venus.Sum(victronSoc)
+}
+
+// setBatteryMode implements the api.BatteryController interface
+func (m *Victron) setBatteryMode(mode api.BatteryMode) error {
+	switch mode {
+	case api.BatteryNormal:
+		// unlimited discharge
+		if err := m.venus.Write(victronMaxDischargePower, -1); err != nil {
+			return err
+		}
+		return m.venus.Write(victronAcPowerSetPoint, m.gridSetpoint)
+
+	case api.BatteryHold:
+		if err := m.venus.Write(victronMaxDischargePower, 0); err != nil {
+			return err
+		}
+		return m.venus.Write(victronAcPowerSetPoint, m.gridSetpoint)
+
+	case api.BatteryCharge:
+		if m.chargePower == 0 {
+			return errors.New("charge mode requires chargepower")
+		}
+		if err := m.venus.Write(victronMaxDischargePower, 0); err != nil {
+			return err
+		}
+		return m.venus.Write(victronAcPowerSetPoint, m.chargePower)
+
+	default:
+		return api.ErrNotAvailable
+	}
+}
@@ -0,0 +1,143 @@
+package victron
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/evcc-io/evcc/provider/mqtt"
+	"github.com/evcc-io/evcc/util"
+)
+
+const keepaliveInterval = 30 * time.Second
+
+// Venus is a Venus OS GX device accessed via its MQTT broker
+type Venus struct {
+	log    *util.Logger
+	client *mqtt.Client
+	portal string
+	data   *util.Monitor[map[string]float64]
+}
+
+// value is the Venus OS MQTT payload, invalid values are null
+type value struct {
+	Value *float64 `json:"value"`
+}
+
+// NewVenus creates a Venus OS connection. If portal is empty, it is discovered from the broker.
+func NewVenus(log *util.Logger, client *mqtt.Client, portal string, timeout time.Duration) (*Venus, error) {
+	if portal == "" {
+		var err error
+		if portal, err = Discover(client, timeout); err != nil {
+			return nil, err
+		}
+		log.DEBUG.Println("portal id:", portal)
+	}
+
+	v := &Venus{
+		log:    log,
+		client: client,
+		portal: portal,
+		data:   util.NewMonitor[map[string]float64](timeout),
+	}
+
+	go v.keepalive()
+
+	return v, nil
+}
+
+// Discover returns the portal id announced by the GX device
+func Discover(client *mqtt.Client, timeout time.Duration) (string, error) {
+	portalC := make(chan string, 1)
+
+	if err := client.Listen("N/+/system/0/Serial", func(payload string) {
+		var res struct {
+			Value string `json:"value"`
+		}
+
+		if err := json.Unmarshal([]byte(payload), &res); err == nil && res.Value != "" {
+			select {
+			case portalC <- res.Value:
+			default:
+			}
+		}
+	}); err != nil {
+		return "", err
+	}
+
+	select {
+	case portal := <-portalC:
+		return portal, nil
+	case <-time.After(timeout):
+		return "", errors.New("portal id not found")
+	}
+}
+
+// keepalive requests the GX device to continue publishing values
+func (v *Venus) keepalive() {
+	topic := "R/" + v.portal + "/keepalive"
+
+	for tick := time.Tick(keepaliveInterval); ; <-tick {
+		if err := v.client.Publish(topic, false, ""); err != nil {
+			v.log.ERROR.Println("keepalive:", err)
+		}
+	}
+}
+
+// Portal returns the portal id
+func (v *Venus) Portal() string {
+	return v.portal
+}
+
+// Subscribe subscribes to the dbus paths, e.g. system/0/Dc/Battery/Soc
+func (v *Venus) Subscribe(paths ...string) error {
+	for _, path := range paths {
+		if err := v.client.Listen("N/"+v.portal+"/"+path, func(payload string) {
+			var res value
+			if err := json.Unmarshal([]byte(payload), &res); err != nil {
+				v.log.ERROR.Printf("%s: %v", path, err)
+				return
+			}
+
+			v.data.SetFunc(func(data map[string]float64) map[string]float64 {
+				if data == nil {
+					data = make(map[string]float64)
+				}
+
+				data[path] = 0
+				if res.Value != nil {
+					data[path] = *res.Value
+				}
+
+				return data
+			})
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Sum returns the sum of subscribed values. Paths not published by the device count as zero.
+func (v *Venus) Sum(paths ...string) (float64, error) {
+	var res float64
+
+	err := v.data.GetFunc(func(data map[string]float64) {
+		for _, path := range paths {
+			res += data[path]
+		}
+	})
+
+	return res, err
+}
+
+// Write writes the dbus path value
+func (v *Venus) Write(path string, val float64) error {
+	b, err := json.Marshal(map[string]float64{"value": val})
+	if err != nil {
+		return err
+	}
+
+	return v.client.Publish("W/"+v.portal+"/"+path, false, b)
+}
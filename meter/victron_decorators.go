@@ -0,0 +1,137 @@
+package meter
+
+// Code generated by github.com/evcc-io/evcc/cmd/tools/decorate.go. DO NOT EDIT.
+
+import (
+	"github.com/evcc-io/evcc/api"
+)
+
+func decorateVictron(base *Victron, battery func() (float64, error), batteryCapacity func() float64, batteryController func(api.BatteryMode) error) api.Meter {
+	switch {
+	case battery == nil && batteryCapacity == nil && batteryController == nil:
+		return base
+
+	case battery != nil && batteryCapacity == nil && batteryController == nil:
+		return &struct {
+			*Victron
+			api.Battery
+		}{
+			Victron: base,
+			Battery: &decorateVictronBatteryImpl{
+				battery: battery,
+			},
+		}
+
+	case battery == nil && batteryCapacity != nil && batteryController == nil:
+		return &struct {
+			*Victron
+			api.BatteryCapacity
+		}{
+			Victron: base,
+			BatteryCapacity: &decorateVictronBatteryCapacityImpl{
+				batteryCapacity: batteryCapacity,
+			},
+		}
+
+	case battery != nil && batteryCapacity != nil && batteryController == nil:
+		return &struct {
+			*Victron
+			api.Battery
+			api.BatteryCapacity
+		}{
+			Victron: base,
+			Battery: &decorateVictronBatteryImpl{
+				battery: battery,
+			},
+			BatteryCapacity: &decorateVictronBatteryCapacityImpl{
+				batteryCapacity: batteryCapacity,
+			},
+		}
+
+	case battery == nil && batteryCapacity == nil && batteryController != nil:
+		return &struct {
+			*Victron
+			api.BatteryController
+		}{
+			Victron: base,
+			BatteryController: &decorateVictronBatteryControllerImpl{
+				batteryController: batteryController,
+			},
+		}
+
+	case battery != nil && batteryCapacity == nil && batteryController != nil:
+		return &struct {
+			*Victron
+			api.Battery
+			api.BatteryController
+		}{
+			Victron: base,
+			Battery: &decorateVictronBatteryImpl{
+				battery: battery,
+			},
+			BatteryController: &decorateVictronBatteryControllerImpl{
+				batteryController: batteryController,
+			},
+		}
+
+	case battery == nil && batteryCapacity != nil && batteryController != nil:
+		return &struct {
+			*Victron
+			api.BatteryCapacity
+			api.BatteryController
+		}{
+			Victron: base,
+			BatteryCapacity: &decorateVictronBatteryCapacityImpl{
+				batteryCapacity: batteryCapacity,
+			},
+			BatteryController: &decorateVictronBatteryControllerImpl{
+				batteryController: batteryController,
+			},
+		}
+
+	case battery != nil && batteryCapacity != nil && batteryController != nil:
+		return &struct {
+			*Victron
+			api.Battery
+			api.BatteryCapacity
+			api.BatteryController
+		}{
+			Victron: base,
+			Battery: &decorateVictronBatteryImpl{
+				battery: battery,
+			},
+			BatteryCapacity: &decorateVictronBatteryCapacityImpl{
+				batteryCapacity: batteryCapacity,
+			},
+			BatteryController: &decorateVictronBatteryControllerImpl{
+				batteryController: batteryController,
+			},
+		}
+	}
+
+	return nil
+}
+
+type decorateVictronBatteryImpl struct {
+	battery func() (float64, error)
+}
+
+func (impl *decorateVictronBatteryImpl) Soc() (float64, error) {
+	return impl.battery()
+}
+
+type decorateVictronBatteryCapacityImpl struct {
+	batteryCapacity func() float64
+}
+
+func (impl *decorateVictronBatteryCapacityImpl) Capacity() float64 {
+	return impl.batteryCapacity()
+}
+
+type decorateVictronBatteryControllerImpl struct {
+	batteryController func(api.BatteryMode) error
+}
+
+func (impl *decorateVictronBatteryControllerImpl) SetBatteryMode(p0 api.BatteryMode) error {
+	return impl.batteryController(p0)
+}
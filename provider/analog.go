@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"errors"
+	"math"
+
+	"github.com/evcc-io/evcc/util"
+)
+
+// analogProvider scales a value linearly to an analog output range, e.g. power (W) to 0-10V
+type analogProvider struct {
+	InMin, InMax   float64 // input range
+	OutMin, OutMax float64 // output range, e.g. V or raw DAC/AO register value
+	Set            Config
+}
+
+func init() {
+	registry.Add("analog", NewAnalogFromConfig)
+}
+
+// NewAnalogFromConfig creates analog output provider
+func NewAnalogFromConfig(other map[string]interface{}) (Provider, error) {
+	var cc analogProvider
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.InMax <= cc.InMin {
+		return nil, errors.New("analog: invalid input range")
+	}
+
+	if cc.OutMax == cc.OutMin {
+		return nil, errors.New("analog: invalid output range")
+	}
+
+	return &cc, nil
+}
+
+// scale maps the input to the output range. Inputs below the input range are mapped to the minimum output (off).
+func (o *analogProvider) scale(val float64) float64 {
+	val = min(max(val, o.InMin), o.InMax)
+	return o.OutMin + (val-o.InMin)/(o.InMax-o.InMin)*(o.OutMax-o.OutMin)
+}
+
+var _ SetFloatProvider = (*analogProvider)(nil)
+
+func (o *analogProvider) FloatSetter(param string) (func(float64) error, error) {
+	set, err := NewFloatSetterFromConfig(param, o.Set)
+
+	return func(val float64) error {
+		return set(o.scale(val))
+	}, err
+}
+
+var _ SetIntProvider = (*analogProvider)(nil)
+
+// IntSetter rounds the output for raw register values
+func (o *analogProvider) IntSetter(param string) (func(int64) error, error) {
+	set, err := NewIntSetterFromConfig(param, o.Set)
+
+	return func(val int64) error {
+		return set(int64(math.Round(o.scale(float64(val)))))
+	}, err
+}
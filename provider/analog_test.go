@@ -0,0 +1,20 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalogScale(t *testing.T) {
+	o := &analogProvider{InMin: 0, InMax: 3000, OutMin: 0, OutMax: 10}
+
+	assert.Equal(t, 0.0, o.scale(-100))
+	assert.Equal(t, 5.0, o.scale(1500))
+	assert.Equal(t, 10.0, o.scale(4000))
+
+	// inverted 12 bit dac
+	o = &analogProvider{InMin: 0, InMax: 100, OutMin: 4095, OutMax: 0}
+	assert.Equal(t, 4095.0, o.scale(0))
+	assert.InDelta(t, 2047.5, o.scale(50), 1e-6)
+}
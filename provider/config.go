@@ -1,8 +1,12 @@
 package provider
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
 )
 
 // provider types
@@ -17,6 +21,9 @@ type (
 	FloatProvider interface {
 		FloatGetter() (func() (float64, error), error)
 	}
+	FloatContextProvider interface {
+		FloatGetterContext() (func(context.Context) (float64, error), error)
+	}
 	BoolProvider interface {
 		BoolGetter() (func() (bool, error), error)
 	}
@@ -58,8 +65,10 @@ var registry providerRegistry = make(map[string]func(map[string]interface{}) (Pr
 
 // Config is the general provider config
 type Config struct {
-	Source string
-	Other  map[string]interface{} `mapstructure:",remain"`
+	Source   string
+	MaxAge   time.Duration          `mapstructure:"maxAge"`   // values unchanged for longer are stale, 0 disables
+	Fallback string                 `mapstructure:"fallback"` // stale values return an error (default) or zero
+	Other    map[string]interface{} `mapstructure:",remain"`
 }
 
 // NewIntGetterFromConfig creates a IntGetter from config
@@ -79,7 +88,13 @@ func NewIntGetterFromConfig(config Config) (func() (int64, error), error) {
 		return nil, fmt.Errorf("invalid plugin source for type int: %s", config.Source)
 	}
 
-	return prov.IntGetter()
+	g, err := prov.IntGetter()
+	if err != nil {
+		return nil, err
+	}
+
+	g, err = staleGetter(config, g)
+	return instrument(config.label(), g), err
 }
 
 // NewFloatGetterFromConfig creates a FloatGetter from config
@@ -99,7 +114,51 @@ func NewFloatGetterFromConfig(config Config) (func() (float64, error), error) {
 		return nil, fmt.Errorf("invalid plugin source for type float: %s", config.Source)
 	}
 
-	return prov.FloatGetter()
+	g, err := prov.FloatGetter()
+	if err != nil {
+		return nil, err
+	}
+
+	g, err = staleGetter(config, g)
+	return instrument(config.label(), g), err
+}
+
+// NewFloatGetterContextFromConfig creates a cancellable FloatGetter from config.
+// Http and script plugins abort the request. Other plugins like modbus or mqtt can't abort a pending
+// read and continue in the background until their own timeout, without starting further reads meanwhile.
+func NewFloatGetterContextFromConfig(config Config) (func(context.Context) (float64, error), error) {
+	factory, err := registry.Get(config.Source)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := factory(config.Other)
+	if err != nil {
+		return nil, err
+	}
+
+	if prov, ok := provider.(FloatContextProvider); ok {
+		g, err := prov.FloatGetterContext()
+		if err != nil {
+			return nil, err
+		}
+
+		g, err = staleGetterContext(config, g)
+		return instrumentContext(config.label(), g), err
+	}
+
+	prov, ok := provider.(FloatProvider)
+	if !ok {
+		return nil, fmt.Errorf("invalid plugin source for type float: %s", config.Source)
+	}
+
+	g, err := prov.FloatGetter()
+	if err != nil {
+		return nil, err
+	}
+
+	gc, err := staleGetterContext(config, util.Cancellable(g))
+	return instrumentContext(config.label(), gc), err
 }
 
 // NewStringGetterFromConfig creates a StringGetter from config
@@ -119,7 +178,13 @@ func NewStringGetterFromConfig(config Config) (func() (string, error), error) {
 		return nil, fmt.Errorf("invalid plugin source for type string: %s", config.Source)
 	}
 
-	return prov.StringGetter()
+	g, err := prov.StringGetter()
+	if err != nil {
+		return nil, err
+	}
+
+	g, err = staleGetter(config, g)
+	return instrument(config.label(), g), err
 }
 
 // NewBoolGetterFromConfig creates a BoolGetter from config
@@ -139,7 +204,13 @@ func NewBoolGetterFromConfig(config Config) (func() (bool, error), error) {
 		return nil, fmt.Errorf("invalid plugin source for type bool: %s", config.Source)
 	}
 
-	return prov.BoolGetter()
+	g, err := prov.BoolGetter()
+	if err != nil {
+		return nil, err
+	}
+
+	g, err = staleGetter(config, g)
+	return instrument(config.label(), g), err
 }
 
 // NewIntSetterFromConfig creates a IntSetter from config
@@ -159,7 +230,8 @@ func NewIntSetterFromConfig(param string, config Config) (func(int64) error, err
 		return nil, fmt.Errorf("invalid plugin source for type int: %s", config.Source)
 	}
 
-	return prov.IntSetter(param)
+	s, err := prov.IntSetter(param)
+	return instrumentSetter(config.label(), s), err
 }
 
 // NewFloatSetterFromConfig creates a FloatSetter from config
@@ -179,7 +251,8 @@ func NewFloatSetterFromConfig(param string, config Config) (func(float642 float6
 		return nil, fmt.Errorf("invalid plugin source for type float: %s", config.Source)
 	}
 
-	return prov.FloatSetter(param)
+	s, err := prov.FloatSetter(param)
+	return instrumentSetter(config.label(), s), err
 }
 
 // NewStringSetterFromConfig creates a StringSetter from config
@@ -199,7 +272,8 @@ func NewStringSetterFromConfig(param string, config Config) (func(string) error,
 		return nil, fmt.Errorf("invalid plugin source for type string: %s", config.Source)
 	}
 
-	return prov.StringSetter(param)
+	s, err := prov.StringSetter(param)
+	return instrumentSetter(config.label(), s), err
 }
 
 // NewBoolSetterFromConfig creates a BoolSetter from config
@@ -219,5 +293,6 @@ func NewBoolSetterFromConfig(param string, config Config) (func(bool) error, err
 		return nil, fmt.Errorf("invalid plugin source for type bool: %s", config.Source)
 	}
 
-	return prov.BoolSetter(param)
+	s, err := prov.BoolSetter(param)
+	return instrumentSetter(config.label(), s), err
 }
@@ -1,6 +1,8 @@
 package provider
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"math"
@@ -89,6 +91,9 @@ func NewHTTPProviderFromConfig(other map[string]interface{}) (Provider, error) {
 	return http, err
 }
 
+// ResponseCache enables in-memory caching of http responses according to their cache headers
+var ResponseCache = true
+
 // NewHTTP create HTTP provider
 func NewHTTP(log *util.Logger, method, uri string, insecure bool, scale float64, cache time.Duration) *HTTP {
 	url := util.DefaultScheme(uri, "http")
@@ -105,9 +110,11 @@ func NewHTTP(log *util.Logger, method, uri string, insecure bool, scale float64,
 	}
 
 	// http cache
-	cacheTransport := httpcache.NewMemoryCacheTransport()
-	cacheTransport.Transport = p.Client.Transport
-	p.Client.Transport = cacheTransport
+	if ResponseCache {
+		cacheTransport := httpcache.NewMemoryCacheTransport()
+		cacheTransport.Transport = p.Client.Transport
+		p.Client.Transport = cacheTransport
+	}
 
 	// ignore the self signed certificate
 	if insecure {
@@ -155,7 +162,7 @@ func (p *HTTP) WithAuth(typ, user, password string) (*HTTP, error) {
 }
 
 // request executes the configured request or returns the cached value
-func (p *HTTP) request(url string, body ...string) ([]byte, error) {
+func (p *HTTP) request(ctx context.Context, url string, body ...string) ([]byte, error) {
 	if time.Since(p.updated) >= p.cache {
 		var b io.Reader
 		if len(body) == 1 {
@@ -167,8 +174,16 @@ func (p *HTTP) request(url string, body ...string) ([]byte, error) {
 		if err != nil {
 			return []byte{}, err
 		}
+		req = req.WithContext(ctx)
 
-		p.val, p.err = p.DoBody(req)
+		val, err := p.DoBody(req)
+
+		// cancelled requests are not cached, the next call retries
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return val, err
+		}
+
+		p.val, p.err = val, err
 		p.updated = time.Now()
 	}
 
@@ -180,32 +195,45 @@ var _ StringProvider = (*HTTP)(nil)
 // StringGetter sends string request
 func (p *HTTP) StringGetter() (func() (string, error), error) {
 	return func() (string, error) {
-		b, err := p.request(p.url, p.body)
+		return p.stringGetter(context.Background())
+	}, nil
+}
 
-		if err == nil && p.pipeline != nil {
-			b, err = p.pipeline.Process(b)
-		}
+func (p *HTTP) stringGetter(ctx context.Context) (string, error) {
+	b, err := p.request(ctx, p.url, p.body)
 
-		return string(b), err
-	}, nil
+	if err == nil && p.pipeline != nil {
+		b, err = p.pipeline.Process(b)
+	}
+
+	return string(b), err
 }
 
 var _ FloatProvider = (*HTTP)(nil)
 
 // FloatGetter parses float from request
 func (p *HTTP) FloatGetter() (func() (float64, error), error) {
-	g, err := p.StringGetter()
-
 	return func() (float64, error) {
-		s, err := g()
-		if err != nil {
-			return 0, err
-		}
+		return p.floatGetter(context.Background())
+	}, nil
+}
 
-		f, err := strconv.ParseFloat(s, 64)
+var _ FloatContextProvider = (*HTTP)(nil)
 
-		return f * p.scale, err
-	}, err
+// FloatGetterContext parses float from request. The request is cancelled with the context.
+func (p *HTTP) FloatGetterContext() (func(context.Context) (float64, error), error) {
+	return p.floatGetter, nil
+}
+
+func (p *HTTP) floatGetter(ctx context.Context) (float64, error) {
+	s, err := p.stringGetter(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+
+	return f * p.scale, err
 }
 
 var _ IntProvider = (*HTTP)(nil)
@@ -243,7 +271,7 @@ func (p *HTTP) set(param string, val interface{}) error {
 		return err
 	}
 
-	_, err = p.request(url, body)
+	_, err = p.request(context.Background(), url, body)
 
 	return err
 }
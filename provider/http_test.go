@@ -1,10 +1,13 @@
 package provider
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/evcc-io/evcc/util"
 	"github.com/samber/lo"
@@ -42,6 +45,36 @@ func TestHttpGet(t *testing.T) {
 	assert.Equal(t, h.val, res)
 }
 
+func TestHttpGetContext(t *testing.T) {
+	var requests atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		// first request hangs
+		if requests.Add(1) == 1 {
+			<-req.Context().Done()
+			return
+		}
+		_, _ = w.Write([]byte("42"))
+	}))
+	defer srv.Close()
+
+	p := NewHTTP(util.NewLogger("foo"), http.MethodGet, srv.URL, false, 1, time.Minute)
+
+	g, err := p.FloatGetterContext()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = g(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// cancellation is not cached
+	f, err := g(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 42.0, f)
+}
+
 func TestHttpSet(t *testing.T) {
 	h := new(httpHandler)
 	srv := httptest.NewServer(h)
@@ -0,0 +1,196 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metric is the call statistics of a plugin
+type Metric struct {
+	Calls      int64   `json:"calls"`
+	Errors     int64   `json:"errors"`
+	Timeouts   int64   `json:"timeouts"`
+	Latency    float64 `json:"latency"`    // average call duration (s)
+	MaxLatency float64 `json:"maxLatency"` // max call duration (s)
+}
+
+type metric struct {
+	calls, errors, timeouts int64
+	total, max              time.Duration
+}
+
+var (
+	metricsMu      sync.Mutex
+	metrics        = make(map[string]*metric)
+	durationMetric *prometheus.SummaryVec
+	callMetric     *prometheus.CounterVec
+)
+
+func init() {
+	durationMetric = prometheus.NewSummaryVec(prometheus.SummaryOpts{
+		Namespace: "evcc",
+		Subsystem: "provider",
+		Name:      "duration_seconds",
+		Help:      "A summary of plugin call durations",
+		Objectives: map[float64]float64{
+			0.5:  0.05,  // 50th percentile with a max. absolute error of 0.05
+			0.9:  0.01,  // 90th percentile with a max. absolute error of 0.01
+			0.99: 0.001, // 99th percentile with a max. absolute error of 0.001
+		},
+	}, []string{"plugin"})
+
+	callMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "evcc",
+		Subsystem: "provider",
+		Name:      "calls_total",
+		Help:      "Total count of plugin calls",
+	}, []string{"plugin", "result"})
+
+	prometheus.MustRegister(durationMetric, callMetric)
+}
+
+// Metrics returns the call statistics of all plugins
+func Metrics() map[string]Metric {
+	metricsMu.Lock()
+	defer metricsMu.Unlock()
+
+	res := make(map[string]Metric, len(metrics))
+	for label, m := range metrics {
+		res[label] = Metric{
+			Calls:      m.calls,
+			Errors:     m.errors,
+			Timeouts:   m.timeouts,
+			Latency:    (m.total / time.Duration(m.calls)).Seconds(),
+			MaxLatency: m.max.Seconds(),
+		}
+	}
+
+	return res
+}
+
+// isTimeout returns true if the error is caused by a timeout
+func isTimeout(err error) bool {
+	var ne net.Error
+	return errors.Is(err, api.ErrTimeout) || errors.Is(err, context.DeadlineExceeded) || errors.As(err, &ne) && ne.Timeout()
+}
+
+// observe records a plugin call
+func observe(label string, start time.Time, err error) {
+	d := time.Since(start)
+
+	result := "ok"
+	switch {
+	case isTimeout(err):
+		result = "timeout"
+	case err != nil:
+		result = "error"
+	}
+
+	durationMetric.WithLabelValues(label).Observe(d.Seconds())
+	callMetric.WithLabelValues(label, result).Inc()
+
+	metricsMu.Lock()
+	defer metricsMu.Unlock()
+
+	m, ok := metrics[label]
+	if !ok {
+		m = new(metric)
+		metrics[label] = m
+	}
+
+	m.calls++
+	m.total += d
+	m.max = max(m.max, d)
+
+	switch result {
+	case "timeout":
+		m.timeouts++
+		m.errors++
+	case "error":
+		m.errors++
+	}
+}
+
+// label identifies the plugin by source and address. Credentials and query parameters are not included.
+func (c Config) label() string {
+	var addr string
+
+	for _, key := range []string{"uri", "url", "host", "device", "topic"} {
+		for k, v := range c.Other {
+			if s, ok := v.(string); ok && s != "" && strings.EqualFold(k, key) {
+				addr = s
+				break
+			}
+		}
+		if addr != "" {
+			break
+		}
+	}
+
+	if u, err := url.Parse(addr); err == nil && u.Host != "" {
+		addr = u.Host
+	}
+
+	for k, v := range c.Other {
+		if strings.EqualFold(k, "id") && addr != "" {
+			addr = fmt.Sprintf("%s#%v", addr, v)
+		}
+	}
+
+	if addr == "" {
+		return c.Source
+	}
+
+	return c.Source + ":" + addr
+}
+
+// instrument records call metrics of a getter
+func instrument[T any](label string, g func() (T, error)) func() (T, error) {
+	if g == nil {
+		return nil
+	}
+
+	return func() (T, error) {
+		start := time.Now()
+		res, err := g()
+		observe(label, start, err)
+		return res, err
+	}
+}
+
+// instrumentContext records call metrics of a cancellable getter
+func instrumentContext[T any](label string, g func(context.Context) (T, error)) func(context.Context) (T, error) {
+	if g == nil {
+		return nil
+	}
+
+	return func(ctx context.Context) (T, error) {
+		start := time.Now()
+		res, err := g(ctx)
+		observe(label, start, err)
+		return res, err
+	}
+}
+
+// instrumentSetter records call metrics of a setter
+func instrumentSetter[T any](label string, s func(T) error) func(T) error {
+	if s == nil {
+		return nil
+	}
+
+	return func(val T) error {
+		start := time.Now()
+		err := s(val)
+		observe(label, start, err)
+		return err
+	}
+}
@@ -18,6 +18,7 @@ type Modbus struct {
 	conn  *modbus.Connection
 	reg   modbus.Register
 	scale float64
+	cache time.Duration
 }
 
 func init() {
@@ -33,6 +34,7 @@ func NewModbusFromConfig(other map[string]interface{}) (Provider, error) {
 		Delay           time.Duration
 		ConnectDelay    time.Duration
 		Timeout         time.Duration
+		Cache           time.Duration
 	}{
 		Scale: 1,
 	}
@@ -73,17 +75,15 @@ func NewModbusFromConfig(other map[string]interface{}) (Provider, error) {
 		conn:  conn,
 		reg:   cc.Register,
 		scale: cc.Scale,
+		cache: cc.Cache,
 	}
 	return mb, nil
 }
 
 func (m *Modbus) readBytes(op modbus.RegisterOperation) ([]byte, error) {
 	switch op.FuncCode {
-	case gridx.FuncCodeReadHoldingRegisters:
-		return m.conn.ReadHoldingRegisters(op.Addr, op.Length)
-
-	case gridx.FuncCodeReadInputRegisters:
-		return m.conn.ReadInputRegisters(op.Addr, op.Length)
+	case gridx.FuncCodeReadHoldingRegisters, gridx.FuncCodeReadInputRegisters:
+		return m.conn.ReadRegistersCached(op.FuncCode, op.Addr, op.Length, m.cache)
 
 	case gridx.FuncCodeReadCoils:
 		return m.conn.ReadCoils(op.Addr, op.Length)
@@ -102,6 +102,8 @@ func (m *Modbus) FloatGetter() (func() (f float64, err error), error) {
 		return nil, err
 	}
 
+	m.conn.RegisterRead(op.FuncCode, op.Addr, op.Length)
+
 	decode, err := m.reg.DecodeFunc()
 	if err != nil {
 		return nil, err
@@ -138,6 +140,8 @@ func (m *Modbus) StringGetter() (func() (string, error), error) {
 		return nil, err
 	}
 
+	m.conn.RegisterRead(op.FuncCode, op.Addr, op.Length)
+
 	return func() (string, error) {
 		b, err := m.readBytes(op)
 		if err != nil {
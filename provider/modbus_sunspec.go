@@ -73,7 +73,7 @@ func NewModbusSunspecFromConfig(other map[string]interface{}) (Provider, error)
 
 	// silence KOSTAL implementation errors
 	device := sunsdev.NewDevice("sunspec", cc.SubDevice)
-	if err := device.Initialize(conn); err != nil && !errors.Is(err, meters.ErrPartiallyOpened) {
+	if err := conn.InitializeDevice(device); err != nil && !errors.Is(err, meters.ErrPartiallyOpened) {
 		return nil, err
 	}
 
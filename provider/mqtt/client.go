@@ -1,15 +1,19 @@
 package mqtt
 
 import (
+	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"math/rand"
+	"net/url"
 	"strings"
 	"sync"
-	"sync/atomic"
 	"time"
 
-	paho "github.com/eclipse/paho.mqtt.golang"
+	"github.com/eclipse/paho.golang/autopaho"
+	"github.com/eclipse/paho.golang/autopaho/queue/memory"
+	"github.com/eclipse/paho.golang/paho"
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/request"
@@ -26,90 +30,188 @@ func ClientID() string {
 
 // Config is the public configuration
 type Config struct {
-	Broker   string
-	User     string
-	Password string
-	ClientID string
-	Insecure bool
+	Broker     string
+	User       string
+	Password   string
+	ClientID   string
+	Insecure   bool
+	CaCert     string        // server ca certificate, PEM or file
+	ClientCert string        // client certificate for mutual tls, PEM or file
+	ClientKey  string        // client key for mutual tls, PEM or file
+	Expiry     time.Duration // message expiry of published values
+	Group      string        // shared subscription group for setter topics
 }
 
+// Options returns the client options corresponding to the configuration
+func (cc Config) Options() ([]Option, error) {
+	tlsConfig, err := TLSConfig(cc)
+	if err != nil {
+		return nil, fmt.Errorf("tls: %w", err)
+	}
+
+	return []Option{WithTLSConfig(tlsConfig), WithExpiry(cc.Expiry), WithGroup(cc.Group)}, nil
+}
+
+// Options are the connection options applied before connecting
+type Options struct {
+	autopaho.ClientConfig
+	Expiry time.Duration
+	Group  string
+}
+
+// Option modifies the connection options
+type Option func(*Options)
+
+// WithExpiry lets the broker discard published values that are older than expiry
+func WithExpiry(expiry time.Duration) Option {
+	return func(o *Options) {
+		o.Expiry = expiry
+	}
+}
+
+// WithGroup subscribes setter topics as shared subscriptions of the given group
+func WithGroup(group string) Option {
+	return func(o *Options) {
+		o.Group = group
+	}
+}
+
+// WithStatus publishes online status to topic and registers offline as last will
+func WithStatus(topic string) Option {
+	return func(o *Options) {
+		o.SetWillMessage(topic, []byte("offline"), 1, true)
+
+		oc := o.OnConnectionUp
+		o.OnConnectionUp = func(cm *autopaho.ConnectionManager, ack *paho.Connack) {
+			oc(cm, ack) // original handler
+
+			ctx, cancel := context.WithTimeout(context.Background(), request.Timeout)
+			defer cancel()
+
+			// alive - not logged
+			_, _ = cm.Publish(ctx, &paho.Publish{QoS: 1, Retain: true, Topic: topic, Payload: []byte("online")})
+		}
+	}
+}
+
+// handler receives messages matching a subscription
+type handler func(*paho.Publish)
+
 // Client encapsulates mqtt publish/subscribe functions
 type Client struct {
 	log      *util.Logger
 	mux      sync.Mutex
-	Client   paho.Client
+	cm       *autopaho.ConnectionManager
 	broker   string
 	Qos      byte
-	inflight uint32
-	listener map[string][]func(string)
+	expiry   uint32
+	group    string
+	listener map[string][]handler
+	shared   map[string]bool
 }
 
-type Option func(*paho.ClientOptions)
+// brokerURL converts the broker address into a connection url
+func brokerURL(broker string) (*url.URL, error) {
+	if !strings.Contains(broker, "://") {
+		broker = "mqtt://" + broker
+	}
 
-const secure = "tls://"
+	u, err := url.Parse(broker)
+	if err != nil {
+		return nil, err
+	}
+
+	u.Host = util.DefaultPort(u.Host, 1883)
+
+	return u, nil
+}
 
 // NewClient creates new Mqtt publisher
 func NewClient(log *util.Logger, broker, user, password, clientID string, qos byte, insecure bool, opts ...Option) (*Client, error) {
-	broker, isSecure := strings.CutPrefix(broker, secure)
-
-	// strip schema as it breaks net.SplitHostPort
-	broker = util.DefaultPort(broker, 1883)
-	if isSecure {
-		broker = secure + broker
+	u, err := brokerURL(broker)
+	if err != nil {
+		return nil, fmt.Errorf("broker: %w", err)
 	}
 
 	mc := &Client{
 		log:      log,
+		broker:   u.String(),
 		Qos:      qos,
-		listener: make(map[string][]func(string)),
+		listener: make(map[string][]handler),
+		shared:   make(map[string]bool),
 	}
 
-	options := paho.NewClientOptions()
-	options.AddBroker(broker)
-	options.SetUsername(user)
-	options.SetPassword(password)
-	options.SetClientID(clientID)
-	options.SetCleanSession(true)
-	options.SetAutoReconnect(true)
-	options.SetOnConnectHandler(mc.ConnectionHandler)
-	options.SetConnectionLostHandler(mc.ConnectionLostHandler)
-	options.SetConnectTimeout(request.Timeout)
-	options.SetOrderMatters(false)
+	options := Options{
+		ClientConfig: autopaho.ClientConfig{
+			ServerUrls:                    []*url.URL{u},
+			KeepAlive:                     30,
+			CleanStartOnInitialConnection: true,
+			ConnectTimeout:                request.Timeout,
+			ConnectRetryDelay:             10 * time.Second,
+			Queue:                         memory.New(),
+			OnConnectionUp:                mc.ConnectionHandler,
+			OnConnectError:                mc.ConnectionLostHandler,
+			ClientConfig: paho.ClientConfig{
+				ClientID:           clientID,
+				OnPublishReceived:  []func(paho.PublishReceived) (bool, error){mc.receive},
+				OnClientError:      mc.ConnectionLostHandler,
+				OnServerDisconnect: mc.ServerDisconnectHandler,
+			},
+		},
+	}
+
+	if user != "" {
+		options.SetUsernamePassword(user, []byte(password))
+	}
 
 	if insecure {
-		options.SetTLSConfig(&tls.Config{InsecureSkipVerify: true})
+		options.TlsCfg = &tls.Config{InsecureSkipVerify: true}
 	}
 
 	// additional options
 	for _, o := range opts {
-		o(options)
+		o(&options)
 	}
 
-	client := paho.NewClient(options)
+	mc.expiry = uint32(options.Expiry.Seconds())
+	mc.group = options.Group
 
-	or := client.OptionsReader()
-	mc.broker = fmt.Sprintf("%v", or.Servers())
-	if len(or.Servers()) == 1 {
-		mc.broker = or.Servers()[0].String()
-	}
 	log.INFO.Printf("connecting %s at %s", clientID, mc.broker)
 
-	if token := client.Connect(); token.Wait() && token.Error() != nil {
-		return nil, fmt.Errorf("error connecting: %w", token.Error())
+	cm, err := autopaho.NewConnection(context.Background(), options.ClientConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), request.Timeout)
+	defer cancel()
+
+	if err := cm.AwaitConnection(ctx); err != nil {
+		_ = cm.Disconnect(context.Background())
+		return nil, fmt.Errorf("error connecting: %w", err)
 	}
 
-	mc.Client = client
+	mc.cm = cm
 
 	return mc, nil
 }
 
 // ConnectionLostHandler logs cause of connection loss as warning
-func (m *Client) ConnectionLostHandler(client paho.Client, reason error) {
-	m.log.ERROR.Printf("%s connection lost: %v", m.broker, reason.Error())
+func (m *Client) ConnectionLostHandler(reason error) {
+	m.log.ERROR.Printf("%s connection lost: %v", m.broker, reason)
+}
+
+// ServerDisconnectHandler logs the server's reason for disconnecting
+func (m *Client) ServerDisconnectHandler(d *paho.Disconnect) {
+	if d.Properties != nil && d.Properties.ReasonString != "" {
+		m.log.ERROR.Printf("%s disconnected: %s", m.broker, d.Properties.ReasonString)
+		return
+	}
+	m.log.ERROR.Printf("%s disconnected: reason %d", m.broker, d.ReasonCode)
 }
 
 // ConnectionHandler restores listeners
-func (m *Client) ConnectionHandler(client paho.Client) {
+func (m *Client) ConnectionHandler(cm *autopaho.ConnectionManager, _ *paho.Connack) {
 	m.log.DEBUG.Printf("%s connected", m.broker)
 
 	m.mux.Lock()
@@ -117,103 +219,208 @@ func (m *Client) ConnectionHandler(client paho.Client) {
 
 	for topic := range m.listener {
 		m.log.DEBUG.Printf("%s subscribe %s", m.broker, topic)
-		go m.listen(topic)
+		go func(filter string) {
+			if err := m.subscribe(cm, filter); err != nil {
+				m.log.ERROR.Printf("subscribe: %s: %v", filter, err)
+			}
+		}(m.filter(topic))
+	}
+}
+
+// filter returns the subscription filter for topic, considering shared subscriptions
+func (m *Client) filter(topic string) string {
+	if m.shared[topic] {
+		return fmt.Sprintf("$share/%s/%s", m.group, topic)
+	}
+	return topic
+}
+
+// subscribe subscribes to filter
+func (m *Client) subscribe(cm *autopaho.ConnectionManager, filter string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), request.Timeout)
+	defer cancel()
+
+	_, err := cm.Subscribe(ctx, &paho.Subscribe{
+		Subscriptions: []paho.SubscribeOptions{{Topic: filter, QoS: m.Qos}},
+	})
+	if errors.Is(err, context.DeadlineExceeded) {
+		err = api.ErrTimeout
+	}
+
+	return err
+}
+
+// receive dispatches received messages to the matching listeners
+func (m *Client) receive(pr paho.PublishReceived) (bool, error) {
+	msg := pr.Packet
+	m.log.TRACE.Printf("recv %s: '%s'", msg.Topic, msg.Payload)
+
+	var handlers []handler
+
+	m.mux.Lock()
+	for topic, hs := range m.listener {
+		if match(topic, msg.Topic) {
+			handlers = append(handlers, hs...)
+		}
+	}
+	m.mux.Unlock()
+
+	for _, h := range handlers {
+		h(msg)
 	}
+
+	return true, nil
 }
 
 // Cleanup recursively removes a topic
 func (m *Client) Cleanup(topic string, retained bool) error {
-	if m.Client.Subscribe(topic, m.Qos, func(c paho.Client, msg paho.Message) {
-		if len(msg.Payload()) == 0 {
+	if err := m.listen(topic, false, func(msg *paho.Publish) {
+		if len(msg.Payload) == 0 {
 			return
 		}
 
-		m.log.TRACE.Printf("delete: %s", msg.Topic())
-		m.Client.Publish(msg.Topic(), m.Qos, true, []byte{})
-	}).WaitTimeout(request.Timeout) {
-		return api.ErrTimeout
+		m.log.TRACE.Printf("delete: %s", msg.Topic)
+		_ = m.publish(msg.Topic, true, []byte{}, nil)
+	}); err != nil {
+		return err
 	}
 
 	time.Sleep(time.Second)
 
-	if m.Client.Unsubscribe(topic).WaitTimeout(request.Timeout) {
-		return api.ErrTimeout
+	m.mux.Lock()
+	delete(m.listener, topic)
+	m.mux.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), request.Timeout)
+	defer cancel()
+
+	if _, err := m.cm.Unsubscribe(ctx, &paho.Unsubscribe{Topics: []string{topic}}); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return api.ErrTimeout
+		}
+		return err
 	}
 
 	return nil
 }
 
-// Publish synchronously publishes payload using client qos
+// Publish asynchronously publishes payload using client qos
 func (m *Client) Publish(topic string, retained bool, payload interface{}) error {
 	m.log.TRACE.Printf("send %s: '%v'", topic, payload)
-	token := m.Client.Publish(topic, m.Qos, retained, payload)
-	go m.WaitForToken("send", topic, token)
+
+	var b []byte
+	switch p := payload.(type) {
+	case []byte:
+		b = p
+	case string:
+		b = []byte(p)
+	default:
+		b = []byte(fmt.Sprintf("%v", p))
+	}
+
+	var props *paho.PublishProperties
+	if m.expiry > 0 && len(b) > 0 {
+		props = &paho.PublishProperties{MessageExpiry: &m.expiry}
+	}
+
+	if err := m.publish(topic, retained, b, props); err != nil {
+		m.log.ERROR.Printf("send: %s: %v", topic, err)
+	}
+
 	return nil
 }
 
+// publish queues the message for sending. While disconnected, messages remain queued until the connection is restored.
+func (m *Client) publish(topic string, retained bool, payload []byte, props *paho.PublishProperties) error {
+	return m.cm.PublishViaQueue(context.Background(), &autopaho.QueuePublish{
+		Publish: &paho.Publish{
+			QoS:        m.Qos,
+			Retain:     retained,
+			Topic:      topic,
+			Payload:    payload,
+			Properties: props,
+		},
+	})
+}
+
 // Listen attaches listener to slice of listeners for given topic
 func (m *Client) Listen(topic string, callback func(string)) error {
-	m.mux.Lock()
-	m.listener[topic] = append(m.listener[topic], callback)
-	m.mux.Unlock()
-
-	token := m.listen(topic)
-
-	select {
-	case <-time.After(request.Timeout):
-		return fmt.Errorf("subscribe: %s: %w", topic, api.ErrTimeout)
-	case <-token.Done():
-		return nil
-	}
+	return m.listen(topic, false, func(msg *paho.Publish) {
+		if len(msg.Payload) > 0 {
+			callback(string(msg.Payload))
+		}
+	})
 }
 
-// ListenSetter creates a /set listener that resets the payload after handling
+// ListenSetter creates a /set listener that resets the payload after handling.
+// If the request carries a response topic, the result is acknowledged with the request's correlation data.
 func (m *Client) ListenSetter(topic string, callback func(string) error) error {
 	topic += "/set"
-	err := m.Listen(topic, func(payload string) {
-		if err := callback(payload); err != nil {
-			m.log.ERROR.Printf("set %s: %v", topic, err)
+	return m.listen(topic, m.group != "", func(msg *paho.Publish) {
+		if len(msg.Payload) == 0 {
+			return
 		}
-		if err := m.Publish(topic, true, ""); err != nil {
-			m.log.ERROR.Printf("clear: %s: %v", topic, err)
+
+		err := callback(string(msg.Payload))
+		if err != nil {
+			m.log.ERROR.Printf("set %s: %v", topic, err)
 		}
-	})
-	return err
-}
 
-// listen attaches listener to topic
-func (m *Client) listen(topic string) paho.Token {
-	token := m.Client.Subscribe(topic, m.Qos, func(c paho.Client, msg paho.Message) {
-		payload := string(msg.Payload())
-		m.log.TRACE.Printf("recv %s: '%v'", topic, payload)
-		if len(payload) > 0 {
-			m.mux.Lock()
-			callbacks := m.listener[topic]
-			m.mux.Unlock()
-
-			for _, cb := range callbacks {
-				cb(payload)
+		if msg.Properties != nil && msg.Properties.ResponseTopic != "" {
+			payload, status := ack(err)
+			props := &paho.PublishProperties{
+				CorrelationData: msg.Properties.CorrelationData,
+				User:            paho.UserProperties{{Key: "status", Value: status}},
 			}
+			if err := m.publish(msg.Properties.ResponseTopic, false, payload, props); err != nil {
+				m.log.ERROR.Printf("ack: %s: %v", topic, err)
+			}
+		}
+
+		if err := m.Publish(msg.Topic, true, ""); err != nil {
+			m.log.ERROR.Printf("clear: %s: %v", topic, err)
 		}
 	})
-	return token
 }
 
-// WaitForToken synchronously waits until token operation completed
-func (m *Client) WaitForToken(action, topic string, token paho.Token) {
-	if inflight := atomic.LoadUint32(&m.inflight); inflight > 64 {
-		return
+// ack returns the response payload and status for a setter result
+func ack(err error) ([]byte, string) {
+	if err != nil {
+		return []byte(err.Error()), "error"
 	}
+	return []byte("ok"), "ok"
+}
 
-	// track inflight token waits
-	atomic.AddUint32(&m.inflight, 1)
-	defer atomic.AddUint32(&m.inflight, ^uint32(0))
+// listen attaches listener to topic
+func (m *Client) listen(topic string, shared bool, h handler) error {
+	m.mux.Lock()
+	m.listener[topic] = append(m.listener[topic], h)
+	m.shared[topic] = shared
+	filter := m.filter(topic)
+	m.mux.Unlock()
 
-	err := api.ErrTimeout
-	if token.WaitTimeout(request.Timeout) {
-		err = token.Error()
+	if err := m.subscribe(m.cm, filter); err != nil {
+		return fmt.Errorf("subscribe: %s: %w", topic, err)
 	}
-	if err != nil {
-		m.log.ERROR.Printf("%s: %s: %v", action, topic, err)
+
+	return nil
+}
+
+// match returns true if topic matches the subscription filter including wildcards
+func match(filter, topic string) bool {
+	fs := strings.Split(filter, "/")
+	ts := strings.Split(topic, "/")
+
+	for i, f := range fs {
+		switch {
+		case f == "#":
+			return true
+		case i >= len(ts):
+			return false
+		case f != "+" && f != ts[i]:
+			return false
+		}
 	}
+
+	return len(fs) == len(ts)
 }
@@ -0,0 +1,81 @@
+package mqtt
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/eclipse/paho.golang/autopaho"
+	"github.com/eclipse/paho.golang/autopaho/queue/memory"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatch(t *testing.T) {
+	for _, tc := range []struct {
+		filter, topic string
+		res           bool
+	}{
+		{"a/b", "a/b", true},
+		{"a/b", "a/c", false},
+		{"a/+", "a/b", true},
+		{"a/+", "a/b/c", false},
+		{"a/+/c", "a/b/c", true},
+		{"a/#", "a", true},
+		{"a/#", "a/b/c", true},
+		{"#", "a/b", true},
+		{"a/b/c", "a/b", false},
+	} {
+		assert.Equal(t, tc.res, match(tc.filter, tc.topic), "%s %s", tc.filter, tc.topic)
+	}
+}
+
+func TestBrokerURL(t *testing.T) {
+	for in, out := range map[string]string{
+		"localhost":           "mqtt://localhost:1883",
+		"localhost:1884":      "mqtt://localhost:1884",
+		"tls://broker":        "tls://broker:1883",
+		"tls://broker:8883":   "tls://broker:8883",
+		"ws://broker:9001/ws": "ws://broker:9001/ws",
+	} {
+		u, err := brokerURL(in)
+		require.NoError(t, err)
+		assert.Equal(t, out, u.String())
+	}
+}
+
+func TestAck(t *testing.T) {
+	payload, status := ack(nil)
+	assert.Equal(t, "ok", string(payload))
+	assert.Equal(t, "ok", status)
+
+	payload, status = ack(errors.New("invalid value"))
+	assert.Equal(t, "invalid value", string(payload))
+	assert.Equal(t, "error", status)
+}
+
+func TestPublishOffline(t *testing.T) {
+	u, err := url.Parse("mqtt://127.0.0.1:1")
+	require.NoError(t, err)
+
+	q := memory.New()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cm, err := autopaho.NewConnection(ctx, autopaho.ClientConfig{
+		ServerUrls: []*url.URL{u},
+		Queue:      q,
+	})
+	require.NoError(t, err)
+
+	m := &Client{log: util.NewLogger("test"), cm: cm}
+
+	// messages are queued until the connection is established
+	require.NoError(t, m.publish("foo", true, []byte("bar"), nil))
+
+	_, err = q.Peek()
+	assert.NoError(t, err)
+}
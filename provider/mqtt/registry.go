@@ -28,8 +28,51 @@ func (r clientRegistry) Get(broker string) (*Client, error) {
 var (
 	mu       sync.Mutex
 	registry clientRegistry = make(map[string]*Client)
+	named    clientRegistry = make(map[string]*Client)
 )
 
+// NamedConfig is the configuration of a broker connection that plugins refer to by name
+type NamedConfig struct {
+	Name   string
+	Config `mapstructure:",squash"`
+}
+
+// RegisterNamed connects to a named broker
+func RegisterNamed(log *util.Logger, cc NamedConfig) error {
+	if cc.Name == "" {
+		return errors.New("missing name")
+	}
+
+	mu.Lock()
+	_, exists := named[cc.Name]
+	mu.Unlock()
+
+	if exists {
+		return fmt.Errorf("duplicate broker name: %s", cc.Name)
+	}
+
+	opts, err := cc.Options()
+	if err != nil {
+		return err
+	}
+
+	clientID := cc.ClientID
+	if clientID == "" {
+		clientID = ClientID()
+	}
+
+	client, err := NewClient(log, cc.Broker, cc.User, cc.Password, clientID, 1, cc.Insecure, opts...)
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	named.Add(cc.Name, client)
+	mu.Unlock()
+
+	return nil
+}
+
 // RegisteredClient reuses an registered Mqtt publisher or creates a new one
 func RegisteredClient(log *util.Logger, broker, user, password, clientID string, qos byte, insecure bool, opts ...Option) (*Client, error) {
 	key := fmt.Sprintf("%s.%s:%s", broker, user, password)
@@ -51,13 +94,27 @@ func RegisteredClient(log *util.Logger, broker, user, password, clientID string,
 }
 
 // RegisteredClientOrDefault reuses an registered Mqtt publisher or creates a new one.
+// If broker refers to a named broker, its connection is used.
 // If no publisher is configured, it uses the default instance.
 func RegisteredClientOrDefault(log *util.Logger, cc Config) (*Client, error) {
-	client := Instance
+	mu.Lock()
+	client, exists := named[cc.Broker]
+	mu.Unlock()
+
+	if exists {
+		return client, nil
+	}
+
+	client = Instance
 
 	var err error
 	if cc.Broker != "" {
-		client, err = RegisteredClient(log, cc.Broker, cc.User, cc.Password, cc.ClientID, 1, cc.Insecure)
+		var opts []Option
+		if opts, err = cc.Options(); err != nil {
+			return nil, err
+		}
+
+		client, err = RegisteredClient(log, cc.Broker, cc.User, cc.Password, cc.ClientID, 1, cc.Insecure, opts...)
 	}
 
 	if client == nil && err == nil {
@@ -0,0 +1,75 @@
+package mqtt
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"os"
+	"strings"
+)
+
+// pemOrFile returns inline PEM data or reads it from file
+func pemOrFile(s string) ([]byte, error) {
+	if strings.Contains(s, "-----BEGIN") {
+		return []byte(s), nil
+	}
+	return os.ReadFile(s)
+}
+
+// TLSConfig creates the tls configuration for server verification and client certificate authentication.
+// Certificates and keys are given either as inline PEM or as file name. Returns nil if nothing is configured.
+func TLSConfig(cc Config) (*tls.Config, error) {
+	if cc.CaCert == "" && cc.ClientCert == "" && cc.ClientKey == "" && !cc.Insecure {
+		return nil, nil
+	}
+
+	res := &tls.Config{InsecureSkipVerify: cc.Insecure}
+
+	if cc.CaCert != "" {
+		b, err := pemOrFile(cc.CaCert)
+		if err != nil {
+			return nil, err
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(b) {
+			return nil, errors.New("invalid ca certificate")
+		}
+
+		res.RootCAs = pool
+	}
+
+	if cc.ClientCert != "" || cc.ClientKey != "" {
+		if cc.ClientCert == "" || cc.ClientKey == "" {
+			return nil, errors.New("client certificate requires both cert and key")
+		}
+
+		cert, err := pemOrFile(cc.ClientCert)
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := pemOrFile(cc.ClientKey)
+		if err != nil {
+			return nil, err
+		}
+
+		pair, err := tls.X509KeyPair(cert, key)
+		if err != nil {
+			return nil, err
+		}
+
+		res.Certificates = []tls.Certificate{pair}
+	}
+
+	return res, nil
+}
+
+// WithTLSConfig sets the tls configuration, e.g. for client certificate authentication
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(o *Options) {
+		if tlsConfig != nil {
+			o.TlsCfg = tlsConfig
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package mqtt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func selfSigned(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "evcc"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		IsCA:         true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	kb, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	pk := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: kb})
+
+	return string(cert), string(pk)
+}
+
+func TestTLSConfig(t *testing.T) {
+	res, err := TLSConfig(Config{})
+	require.NoError(t, err)
+	assert.Nil(t, res)
+
+	cert, key := selfSigned(t)
+
+	// inline PEM
+	res, err = TLSConfig(Config{CaCert: cert, ClientCert: cert, ClientKey: key})
+	require.NoError(t, err)
+	assert.NotNil(t, res.RootCAs)
+	assert.Len(t, res.Certificates, 1)
+
+	// files
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "client.pem")
+	keyFile := filepath.Join(dir, "client.key")
+	require.NoError(t, os.WriteFile(certFile, []byte(cert), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, []byte(key), 0o600))
+
+	res, err = TLSConfig(Config{ClientCert: certFile, ClientKey: keyFile})
+	require.NoError(t, err)
+	assert.Len(t, res.Certificates, 1)
+
+	_, err = TLSConfig(Config{ClientCert: certFile})
+	assert.Error(t, err)
+
+	_, err = TLSConfig(Config{CaCert: "-----BEGIN invalid"})
+	assert.Error(t, err)
+}
@@ -103,13 +103,13 @@ func (p *Script) WithJq(jq string) (*Script, error) {
 	return p, nil
 }
 
-func (p *Script) exec(script string) (string, error) {
+func (p *Script) exec(ctx context.Context, script string) (string, error) {
 	args, err := shellquote.Split(script)
 	if err != nil {
 		return "", err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
 	defer cancel()
 
 	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
@@ -138,48 +138,61 @@ var _ StringProvider = (*Script)(nil)
 // StringGetter returns string from exec result. Only STDOUT is considered.
 func (p *Script) StringGetter() (func() (string, error), error) {
 	return func() (string, error) {
-		if time.Since(p.updated) > p.cache {
-			p.val, p.err = p.exec(p.script)
-			p.updated = time.Now()
-
-			if p.err == nil && p.re != nil {
-				m := p.re.FindStringSubmatch(p.val)
-				if len(m) > 1 {
-					p.val = m[1] // first submatch
-				}
+		return p.stringGetter(context.Background())
+	}, nil
+}
+
+func (p *Script) stringGetter(ctx context.Context) (string, error) {
+	if time.Since(p.updated) > p.cache {
+		p.val, p.err = p.exec(ctx, p.script)
+		p.updated = time.Now()
+
+		if p.err == nil && p.re != nil {
+			m := p.re.FindStringSubmatch(p.val)
+			if len(m) > 1 {
+				p.val = m[1] // first submatch
 			}
+		}
 
-			if p.err == nil && p.jq != nil {
-				var v interface{}
-				if v, p.err = jq.Query(p.jq, []byte(p.val)); p.err == nil {
-					p.val = fmt.Sprintf("%v", v)
-				}
+		if p.err == nil && p.jq != nil {
+			var v interface{}
+			if v, p.err = jq.Query(p.jq, []byte(p.val)); p.err == nil {
+				p.val = fmt.Sprintf("%v", v)
 			}
 		}
+	}
 
-		return p.val, p.err
-	}, nil
+	return p.val, p.err
 }
 
 var _ FloatProvider = (*Script)(nil)
 
 // FloatGetter parses float from exec result
 func (p *Script) FloatGetter() (func() (float64, error), error) {
-	g, err := p.StringGetter()
-
 	return func() (float64, error) {
-		s, err := g()
-		if err != nil {
-			return 0, err
-		}
+		return p.floatGetter(context.Background())
+	}, nil
+}
 
-		f, err := strconv.ParseFloat(s, 64)
-		if err == nil {
-			f *= p.scale
-		}
+var _ FloatContextProvider = (*Script)(nil)
 
-		return f, err
-	}, err
+// FloatGetterContext parses float from exec result. The script is killed when the context is cancelled.
+func (p *Script) FloatGetterContext() (func(context.Context) (float64, error), error) {
+	return p.floatGetter, nil
+}
+
+func (p *Script) floatGetter(ctx context.Context) (float64, error) {
+	s, err := p.stringGetter(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err == nil {
+		f *= p.scale
+	}
+
+	return f, err
 }
 
 var _ IntProvider = (*Script)(nil)
@@ -221,7 +234,7 @@ func (p *Script) IntSetter(param string) (func(int64) error, error) {
 		})
 
 		if err == nil {
-			_, err = p.exec(cmd)
+			_, err = p.exec(context.Background(), cmd)
 		}
 
 		return err
@@ -239,7 +252,7 @@ func (p *Script) BoolSetter(param string) (func(bool) error, error) {
 		})
 
 		if err == nil {
-			_, err = p.exec(cmd)
+			_, err = p.exec(context.Background(), cmd)
 		}
 
 		return err
@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+)
+
+// stale value fallbacks
+const (
+	fallbackError = "error" // stale values return api.ErrOutdated
+	fallbackZero  = "zero"  // stale values are replaced by the zero value
+)
+
+// staleness tracks the timestamp of a getter's value and invalidates values unchanged for longer than maxAge.
+// This detects frozen readings of hung devices that keep answering with their last value.
+type staleness[T comparable] struct {
+	mu      sync.Mutex
+	clock   clock.Clock
+	maxAge  time.Duration
+	zero    bool
+	val     T
+	updated time.Time
+}
+
+func newStaleness[T comparable](maxAge time.Duration, fallback string) (*staleness[T], error) {
+	switch fallback {
+	case "", fallbackError, fallbackZero:
+	default:
+		return nil, fmt.Errorf("invalid stale fallback: %s", fallback)
+	}
+
+	return &staleness[T]{
+		clock:  clock.New(),
+		maxAge: maxAge,
+		zero:   fallback == fallbackZero,
+	}, nil
+}
+
+// check records the value's timestamp and applies the fallback to stale values
+func (s *staleness[T]) check(val T, err error) (T, error) {
+	if err != nil {
+		return val, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+	if s.updated.IsZero() || val != s.val {
+		s.val = val
+		s.updated = now
+		return val, nil
+	}
+
+	if age := now.Sub(s.updated); age > s.maxAge {
+		var zero T
+		if s.zero {
+			return zero, nil
+		}
+		return zero, fmt.Errorf("%w: unchanged for %v", api.ErrOutdated, age.Truncate(time.Second))
+	}
+
+	return val, nil
+}
+
+// staleGetter invalidates values of the getter unchanged for longer than the configured max age
+func staleGetter[T comparable](config Config, g func() (T, error)) (func() (T, error), error) {
+	if g == nil || config.MaxAge <= 0 {
+		return g, nil
+	}
+
+	s, err := newStaleness[T](config.MaxAge, config.Fallback)
+	if err != nil {
+		return nil, err
+	}
+
+	return func() (T, error) {
+		return s.check(g())
+	}, nil
+}
+
+// staleGetterContext invalidates values of the cancellable getter unchanged for longer than the configured max age
+func staleGetterContext[T comparable](config Config, g func(context.Context) (T, error)) (func(context.Context) (T, error), error) {
+	if g == nil || config.MaxAge <= 0 {
+		return g, nil
+	}
+
+	s, err := newStaleness[T](config.MaxAge, config.Fallback)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) (T, error) {
+		return s.check(g(ctx))
+	}, nil
+}
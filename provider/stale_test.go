@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaleness(t *testing.T) {
+	clock := clock.NewMock()
+
+	s, err := newStaleness[float64](time.Minute, "")
+	require.NoError(t, err)
+	s.clock = clock
+
+	v, err := s.check(1, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, v)
+
+	// unchanged within max age
+	clock.Add(time.Minute)
+	_, err = s.check(1, nil)
+	assert.NoError(t, err)
+
+	// frozen value
+	clock.Add(time.Second)
+	_, err = s.check(1, nil)
+	assert.ErrorIs(t, err, api.ErrOutdated)
+
+	// value changes
+	v, err = s.check(2, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 2.0, v)
+
+	// errors are passed through
+	_, err = s.check(2, errors.New("foo"))
+	assert.EqualError(t, err, "foo")
+}
+
+func TestStalenessFallback(t *testing.T) {
+	clock := clock.NewMock()
+
+	s, err := newStaleness[float64](time.Minute, fallbackZero)
+	require.NoError(t, err)
+	s.clock = clock
+
+	_, _ = s.check(1000, nil)
+	clock.Add(2 * time.Minute)
+
+	v, err := s.check(1000, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, v)
+
+	_, err = newStaleness[float64](time.Minute, "foo")
+	assert.Error(t, err)
+}
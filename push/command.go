@@ -0,0 +1,190 @@
+package push
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/core/vehicle"
+)
+
+// Controller is implemented by messengers accepting commands for controlling the site
+type Controller interface {
+	Control(site site.API)
+}
+
+const commandHelp = `/status - loadpoint status
+/mode <off|now|minpv|pv> [loadpoint] - set charge mode
+/plan <soc> <hh:mm> [loadpoint] - set charge plan for the connected vehicle
+/plan off [loadpoint] - remove charge plan`
+
+// Command executes a chat command against the site and returns the reply
+func Command(site site.API, text string, now time.Time) string {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return commandHelp
+	}
+
+	// strip bot name suffix used in group chats
+	cmd, _, _ := strings.Cut(strings.ToLower(fields[0]), "@")
+
+	var (
+		res string
+		err error
+	)
+
+	switch cmd {
+	case "/status":
+		res = commandStatus(site)
+	case "/mode":
+		res, err = commandMode(site, fields[1:])
+	case "/plan":
+		res, err = commandPlan(site, fields[1:], now)
+	default:
+		res = commandHelp
+	}
+
+	if err != nil {
+		return err.Error()
+	}
+
+	return res
+}
+
+// commandLoadpoint returns the loadpoint selected by the optional 1-based argument
+func commandLoadpoint(site site.API, args []string, pos int) (loadpoint.API, error) {
+	lps := site.Loadpoints()
+
+	id := 1
+	if len(args) > pos {
+		var err error
+		if id, err = strconv.Atoi(args[pos]); err != nil {
+			return nil, fmt.Errorf("invalid loadpoint: %s", args[pos])
+		}
+	}
+
+	if id < 1 || id > len(lps) {
+		return nil, fmt.Errorf("invalid loadpoint: %d", id)
+	}
+
+	return lps[id-1], nil
+}
+
+func commandStatus(site site.API) string {
+	var b strings.Builder
+
+	for i, lp := range site.Loadpoints() {
+		if i > 0 {
+			b.WriteString("\n\n")
+		}
+
+		fmt.Fprintf(&b, "%d: %s\nmode: %s, status: %s\npower: %.1fkW, charged: %.1fkWh",
+			i+1, lp.Title(), lp.GetMode(), lp.GetStatus(), lp.GetChargePower()/1e3, lp.GetChargedEnergy()/1e3)
+
+		if v := lp.GetVehicle(); v != nil {
+			fmt.Fprintf(&b, "\nvehicle: %s", v.Title())
+		}
+
+		if ts := lp.EffectivePlanTime(); !ts.IsZero() {
+			fmt.Fprintf(&b, "\nplan: %s", ts.Local().Format("Mon 15:04"))
+		}
+	}
+
+	return b.String()
+}
+
+func commandMode(site site.API, args []string) (string, error) {
+	if len(args) == 0 {
+		return "", errors.New("missing mode")
+	}
+
+	mode, err := api.ChargeModeString(args[0])
+	if err != nil || mode == api.ModeEmpty {
+		return "", fmt.Errorf("invalid mode: %s", args[0])
+	}
+
+	lp, err := commandLoadpoint(site, args, 1)
+	if err != nil {
+		return "", err
+	}
+
+	lp.SetMode(mode)
+
+	return fmt.Sprintf("%s: mode %s", lp.Title(), mode), nil
+}
+
+func commandPlan(site site.API, args []string, now time.Time) (string, error) {
+	if len(args) == 0 {
+		return "", errors.New("missing soc")
+	}
+
+	remove := strings.EqualFold(args[0], "off")
+
+	pos := 2
+	if remove {
+		pos = 1
+	}
+
+	lp, err := commandLoadpoint(site, args, pos)
+	if err != nil {
+		return "", err
+	}
+
+	v := commandVehicle(site, lp.GetVehicle())
+	if v == nil {
+		return "", fmt.Errorf("%s: no vehicle", lp.Title())
+	}
+
+	if remove {
+		if err := v.SetPlanSoc(time.Time{}, 0); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s: plan removed", v.Instance().Title()), nil
+	}
+
+	soc, err := strconv.Atoi(strings.TrimSuffix(args[0], "%"))
+	if err != nil || soc <= 0 || soc > 100 {
+		return "", fmt.Errorf("invalid soc: %s", args[0])
+	}
+
+	if len(args) < 2 {
+		return "", errors.New("missing time")
+	}
+
+	hm, err := time.Parse("15:04", args[1])
+	if err != nil {
+		return "", fmt.Errorf("invalid time: %s", args[1])
+	}
+
+	y, m, d := now.Date()
+	ts := time.Date(y, m, d, hm.Hour(), hm.Minute(), 0, 0, now.Location())
+	if !ts.After(now) {
+		ts = ts.AddDate(0, 0, 1)
+	}
+
+	if err := v.SetPlanSoc(ts, soc); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s: plan %d%% by %s", v.Instance().Title(), soc, ts.Format("Mon 15:04")), nil
+}
+
+// commandVehicle returns the vehicle settings for the loadpoint's vehicle
+func commandVehicle(site site.API, v api.Vehicle) vehicle.API {
+	if v == nil {
+		return nil
+	}
+
+	for _, vv := range site.Vehicles().Settings() {
+		if vv.Instance() == v {
+			return vv
+		}
+	}
+
+	return nil
+}
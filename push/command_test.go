@@ -0,0 +1,57 @@
+package push
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/core/vehicle"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type testSite struct {
+	site.API
+	loadpoints []loadpoint.API
+	vehicles   []vehicle.API
+}
+
+func (s *testSite) Loadpoints() []loadpoint.API { return s.loadpoints }
+func (s *testSite) Vehicles() site.Vehicles     { return s }
+func (s *testSite) Settings() []vehicle.API     { return s.vehicles }
+
+func (s *testSite) ByName(string) (vehicle.API, error) { return nil, nil }
+func (s *testSite) Instances() []api.Vehicle           { return nil }
+
+func TestCommand(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	v := api.NewMockVehicle(ctrl)
+	v.EXPECT().Title().Return("car").AnyTimes()
+
+	vs := vehicle.NewMockAPI(ctrl)
+	vs.EXPECT().Instance().Return(v).AnyTimes()
+
+	lp := loadpoint.NewMockAPI(ctrl)
+	lp.EXPECT().Title().Return("garage").AnyTimes()
+	lp.EXPECT().GetVehicle().Return(v).AnyTimes()
+
+	s := &testSite{loadpoints: []loadpoint.API{lp}, vehicles: []vehicle.API{vs}}
+	now := time.Date(2024, 1, 1, 18, 0, 0, 0, time.Local)
+
+	lp.EXPECT().SetMode(api.ModePV)
+	assert.Equal(t, "garage: mode pv", Command(s, "/mode pv", now))
+	assert.Equal(t, "invalid mode: fast", Command(s, "/mode fast", now))
+	assert.Equal(t, "invalid loadpoint: 2", Command(s, "/mode pv 2", now))
+
+	vs.EXPECT().SetPlanSoc(time.Date(2024, 1, 2, 7, 0, 0, 0, time.Local), 80)
+	assert.Equal(t, "car: plan 80% by Tue 07:00", Command(s, "/plan 80 7:00", now))
+
+	vs.EXPECT().SetPlanSoc(time.Time{}, 0)
+	assert.Equal(t, "car: plan removed", Command(s, "/plan@evccbot off 1", now))
+
+	assert.Equal(t, "invalid soc: 120", Command(s, "/plan 120 7:00", now))
+	assert.Equal(t, commandHelp, Command(s, "/foo", now))
+}
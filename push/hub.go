@@ -8,6 +8,8 @@ import (
 	"github.com/Masterminds/sprig/v3"
 	"github.com/evcc-io/evcc/core/vehicle"
 	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/locale"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
 )
 
 // Event is a notification event
@@ -16,11 +18,18 @@ type Event struct {
 	Event     string
 }
 
-// EventTemplateConfig is the push message configuration for an event
+// EventTemplateConfig is the push message configuration for an event.
+// If both title and message are empty, the translated default template is used.
 type EventTemplateConfig struct {
 	Title, Msg string
 }
 
+// sender is a messenger with its message language
+type sender struct {
+	Messenger
+	localizer *i18n.Localizer
+}
+
 type Vehicles interface {
 	// ByName returns a single vehicle adapter by name
 	ByName(string) (vehicle.API, error)
@@ -29,7 +38,7 @@ type Vehicles interface {
 // Hub subscribes to event notifications and sends them to client devices
 type Hub struct {
 	definitions map[string]EventTemplateConfig
-	sender      []Messenger
+	sender      []sender
 	cache       *util.Cache
 	vehicles    Vehicles
 }
@@ -55,9 +64,39 @@ func NewHub(cc map[string]EventTemplateConfig, vv Vehicles, cache *util.Cache) (
 	return h, nil
 }
 
-// Add adds a sender to the list of senders
-func (h *Hub) Add(sender Messenger) {
-	h.sender = append(h.sender, sender)
+// Add adds a sender to the list of senders. Default templates are translated to the given language
+// or the system language if empty.
+func (h *Hub) Add(m Messenger, lang string) {
+	s := sender{Messenger: m, localizer: locale.Localizer}
+	if lang != "" && locale.Bundle != nil {
+		s.localizer = i18n.NewLocalizer(locale.Bundle, lang, locale.Language)
+	}
+
+	h.sender = append(h.sender, s)
+}
+
+// template returns the event's template, translating the default template if not configured
+func (h *Hub) template(event string, localizer *i18n.Localizer) (EventTemplateConfig, error) {
+	definition := h.definitions[event]
+	if definition.Title != "" || definition.Msg != "" {
+		return definition, nil
+	}
+
+	if localizer == nil {
+		return definition, fmt.Errorf("no default template for %s", event)
+	}
+
+	title, err := localizer.Localize(&locale.Config{MessageID: "push." + event + ".title"})
+	if err != nil {
+		return definition, err
+	}
+
+	msg, err := localizer.Localize(&locale.Config{MessageID: "push." + event + ".msg"})
+	if err != nil {
+		return definition, err
+	}
+
+	return EventTemplateConfig{Title: title, Msg: msg}, nil
 }
 
 // apply applies the event template to the content to produce the actual message
@@ -98,8 +137,7 @@ func (h *Hub) Run(events <-chan Event, valueChan chan util.Param) {
 			continue
 		}
 
-		definition, ok := h.definitions[ev.Event]
-		if !ok {
+		if _, ok := h.definitions[ev.Event]; !ok {
 			continue
 		}
 
@@ -108,19 +146,25 @@ func (h *Hub) Run(events <-chan Event, valueChan chan util.Param) {
 		valueChan <- util.Param{Val: flushC}
 		<-flushC
 
-		title, err := h.apply(ev, definition.Title)
-		if err != nil {
-			log.ERROR.Printf("invalid title template for %s: %v", ev.Event, err)
-			continue
-		}
+		for _, sender := range h.sender {
+			definition, err := h.template(ev.Event, sender.localizer)
+			if err != nil {
+				log.ERROR.Printf("missing template for %s: %v", ev.Event, err)
+				continue
+			}
 
-		msg, err := h.apply(ev, definition.Msg)
-		if err != nil {
-			log.ERROR.Printf("invalid message template for %s: %v", ev.Event, err)
-			continue
-		}
+			title, err := h.apply(ev, definition.Title)
+			if err != nil {
+				log.ERROR.Printf("invalid title template for %s: %v", ev.Event, err)
+				continue
+			}
+
+			msg, err := h.apply(ev, definition.Msg)
+			if err != nil {
+				log.ERROR.Printf("invalid message template for %s: %v", ev.Event, err)
+				continue
+			}
 
-		for _, sender := range h.sender {
 			if strings.TrimSpace(msg) != "" {
 				go sender.Send(title, msg)
 			} else {
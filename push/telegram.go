@@ -4,7 +4,9 @@ import (
 	"errors"
 	"strconv"
 	"sync"
+	"time"
 
+	"github.com/evcc-io/evcc/core/site"
 	"github.com/evcc-io/evcc/util"
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
@@ -13,14 +15,17 @@ func init() {
 	registry.Add("telegram", NewTelegramFromConfig)
 }
 
-// Telegram implements the Telegram messenger
+// Telegram implements the Telegram messenger. Configured chats may control the site using bot commands.
 type Telegram struct {
 	log *util.Logger
 	sync.Mutex
 	bot   *tgbotapi.BotAPI
 	chats map[int64]struct{}
+	site  site.API
 }
 
+var _ Controller = (*Telegram)(nil)
+
 // NewTelegramFromConfig creates new pushover messenger
 func NewTelegramFromConfig(other map[string]interface{}) (Messenger, error) {
 	var cc struct {
@@ -59,17 +64,45 @@ func NewTelegramFromConfig(other map[string]interface{}) (Messenger, error) {
 	return m, nil
 }
 
-// trackChats captures ids of all chats that bot participates in
+// Control implements the Controller interface
+func (m *Telegram) Control(site site.API) {
+	m.Lock()
+	m.site = site
+	m.Unlock()
+}
+
+// trackChats captures ids of all chats that bot participates in and answers commands from configured chats
 func (m *Telegram) trackChats() {
 	conf := tgbotapi.NewUpdate(0)
 	conf.Timeout = 1000
 
 	for update := range m.bot.GetUpdatesChan(conf) {
-		m.Lock()
-		if _, ok := m.chats[update.Message.Chat.ID]; !ok {
-			m.log.INFO.Printf("new chat id: %d", update.Message.Chat.ID)
+		if update.Message == nil {
+			continue
 		}
+
+		chat := update.Message.Chat.ID
+
+		m.Lock()
+		_, authorized := m.chats[chat]
+		site := m.site
 		m.Unlock()
+
+		if !authorized {
+			m.log.INFO.Printf("new chat id: %d", chat)
+			continue
+		}
+
+		if site == nil || !update.Message.IsCommand() {
+			continue
+		}
+
+		m.log.DEBUG.Printf("command from %d: %s", chat, update.Message.Text)
+
+		reply := tgbotapi.NewMessage(chat, Command(site, update.Message.Text, time.Now()))
+		if _, err := m.bot.Send(reply); err != nil {
+			m.log.ERROR.Println("send:", err)
+		}
 	}
 }
 
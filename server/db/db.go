@@ -2,40 +2,64 @@ package db
 
 import (
 	"fmt"
-	"os"
-	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
 
 	"github.com/evcc-io/evcc/util"
-	"github.com/glebarez/sqlite"
-	"github.com/mitchellh/go-homedir"
 	"gorm.io/gorm"
 )
 
 var Instance *gorm.DB
 
+var (
+	mu      sync.Mutex
+	lastErr error
+)
+
+// SetError records the result of the last database write, nil once the database is available again
+func SetError(err error) {
+	mu.Lock()
+	defer mu.Unlock()
+	lastErr = err
+}
+
+// Err returns the database error if the database is currently unavailable
+func Err() error {
+	mu.Lock()
+	defer mu.Unlock()
+	return lastErr
+}
+
+// Dialector creates the gorm dialector for a data source
+type Dialector func(log *util.Logger, dsn string) (gorm.Dialector, error)
+
+var drivers = map[string]Dialector{
+	"sqlite": sqliteDialector,
+}
+
+// Register adds a database driver. SQLite is the default driver.
+func Register(driver string, dialector Dialector) {
+	drivers[strings.ToLower(driver)] = dialector
+}
+
 func New(driver, dsn string) (*gorm.DB, error) {
-	var dialect gorm.Dialector
 	log := util.NewLogger("db")
 
-	switch driver {
-	case "sqlite":
-		file, err := homedir.Expand(dsn)
-		if err != nil {
-			return nil, err
-		}
-		log.INFO.Println("using sqlite database:", file)
-		if err := os.MkdirAll(filepath.Dir(file), os.ModePerm); err != nil {
-			return nil, err
+	dialector, ok := drivers[driver]
+	if !ok {
+		keys := make([]string, 0, len(drivers))
+		for k := range drivers {
+			keys = append(keys, k)
 		}
-		// avoid busy errors
-		dialect = sqlite.Open(file + "?_pragma=busy_timeout(5000)")
-	// case "postgres":
-	// 	dialect = postgres.Open(dsn)
-	// case "mysql":
-	// 	dialect = mysql.Open(dsn)
-	default:
-		return nil, fmt.Errorf("invalid database type: %s not in [sqlite]", driver)
+		slices.Sort(keys)
+
+		return nil, fmt.Errorf("invalid database type: %s not in %v", driver, keys)
+	}
+
+	dialect, err := dialector(log, dsn)
+	if err != nil {
+		return nil, err
 	}
 
 	return gorm.Open(dialect, &gorm.Config{
@@ -25,23 +25,54 @@ var (
 	mu       sync.RWMutex
 	settings []setting
 	dirty    int32
+	store    Store
 )
 
-func Init() error {
-	err := db.Instance.AutoMigrate(new(setting))
-	if err == nil {
-		err = db.Instance.Find(&settings).Error
+// Init loads the persisted settings from the store. Settings changed before the store became available take precedence.
+func Init(s Store) error {
+	res, err := s.Load()
+	if err != nil {
+		return err
 	}
-	return err
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	for k, v := range res {
+		if !slices.ContainsFunc(settings, func(e setting) bool { return e.Key == k }) {
+			settings = append(settings, setting{k, v})
+		}
+	}
+
+	store = s
+
+	return nil
 }
 
+// Persist saves changed settings. Failed writes are retried on next invocation.
 func Persist() error {
-	dirty := atomic.CompareAndSwapInt32(&dirty, 1, 0)
-	if !dirty || len(settings) == 0 {
-		// avoid "empty slice found"
+	mu.RLock()
+	s := store
+	mu.RUnlock()
+
+	if s == nil || !atomic.CompareAndSwapInt32(&dirty, 1, 0) {
 		return nil
 	}
-	return db.Instance.Save(settings).Error
+
+	mu.RLock()
+	res := make(map[string]string, len(settings))
+	for _, e := range settings {
+		res[e.Key] = e.Value
+	}
+	mu.RUnlock()
+
+	err := s.Save(res)
+	if err != nil {
+		atomic.StoreInt32(&dirty, 1)
+	}
+	db.SetError(err)
+
+	return err
 }
 
 func All() []setting {
@@ -4,6 +4,7 @@ import (
 	"math"
 	"testing"
 
+	"github.com/evcc-io/evcc/server/db"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -31,3 +32,30 @@ func TestFloat(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, v, res)
 }
+
+func TestStore(t *testing.T) {
+	instance, err := db.New("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	s, err := NewDBStore(instance)
+	require.NoError(t, err)
+	require.NoError(t, s.Save(map[string]string{"persisted": "db", "changed": "db"}))
+
+	// changes before the store is available take precedence
+	SetString("changed", "memory")
+	require.NoError(t, Init(s))
+
+	res, err := String("persisted")
+	require.NoError(t, err)
+	assert.Equal(t, "db", res)
+
+	res, err = String("changed")
+	require.NoError(t, err)
+	assert.Equal(t, "memory", res)
+
+	require.NoError(t, Persist())
+
+	m, err := s.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "memory", m["changed"])
+}
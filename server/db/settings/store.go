@@ -0,0 +1,51 @@
+package settings
+
+import (
+	"gorm.io/gorm"
+)
+
+// Store is the settings persistence backend
+type Store interface {
+	Load() (map[string]string, error)
+	Save(map[string]string) error
+}
+
+type dbStore struct {
+	db *gorm.DB
+}
+
+// NewDBStore creates a settings store backed by a SQL database
+func NewDBStore(db *gorm.DB) (Store, error) {
+	if err := db.AutoMigrate(new(setting)); err != nil {
+		return nil, err
+	}
+	return &dbStore{db}, nil
+}
+
+func (s *dbStore) Load() (map[string]string, error) {
+	var res []setting
+	if err := s.db.Find(&res).Error; err != nil {
+		return nil, err
+	}
+
+	m := make(map[string]string, len(res))
+	for _, e := range res {
+		m[e.Key] = e.Value
+	}
+
+	return m, nil
+}
+
+func (s *dbStore) Save(m map[string]string) error {
+	if len(m) == 0 {
+		// avoid "empty slice found"
+		return nil
+	}
+
+	res := make([]setting, 0, len(m))
+	for k, v := range m {
+		res = append(res, setting{Key: k, Value: v})
+	}
+
+	return s.db.Save(res).Error
+}
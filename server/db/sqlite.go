@@ -0,0 +1,72 @@
+package db
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/glebarez/sqlite"
+	"github.com/mitchellh/go-homedir"
+	"gorm.io/gorm"
+)
+
+// CacheSize limits the sqlite page cache (KiB), 0 for default
+var CacheSize int
+
+// JournalMode is the sqlite journal mode, e.g. DELETE on file systems without shared memory support
+var JournalMode = "WAL"
+
+func sqliteDialector(log *util.Logger, dsn string) (gorm.Dialector, error) {
+	file, err := homedir.Expand(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	log.INFO.Println("using sqlite database:", file)
+	if err := os.MkdirAll(filepath.Dir(file), os.ModePerm); err != nil {
+		return nil, err
+	}
+
+	// avoid busy errors, allow concurrent reads while writing (WAL) and reclaim space incrementally
+	dsn = file + "?_pragma=busy_timeout(5000)&_pragma=auto_vacuum(incremental)"
+	if JournalMode != "" {
+		if !slices.Contains([]string{"DELETE", "TRUNCATE", "PERSIST", "MEMORY", "WAL", "OFF"}, JournalMode) {
+			return nil, fmt.Errorf("invalid journal mode: %s", JournalMode)
+		}
+		dsn += fmt.Sprintf("&_pragma=journal_mode(%s)", JournalMode)
+	}
+	if CacheSize > 0 {
+		dsn += fmt.Sprintf("&_pragma=cache_size(-%d)", CacheSize)
+	}
+
+	return sqlite.Open(dsn), nil
+}
+
+// Vacuum returns free pages to the file system. Sqlite databases created without
+// incremental auto vacuum are converted once using a full vacuum.
+func Vacuum(db *gorm.DB) error {
+	if db.Dialector.Name() != "sqlite" {
+		return nil
+	}
+
+	var mode int
+	if err := db.Raw("PRAGMA auto_vacuum").Scan(&mode).Error; err != nil {
+		return err
+	}
+
+	// 2 = incremental
+	if mode != 2 {
+		if err := db.Exec("PRAGMA auto_vacuum = incremental").Error; err != nil {
+			return err
+		}
+		return db.Exec("VACUUM").Error
+	}
+
+	if err := db.Exec("PRAGMA incremental_vacuum").Error; err != nil {
+		return err
+	}
+
+	return db.Exec("PRAGMA optimize").Error
+}
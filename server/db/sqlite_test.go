@@ -0,0 +1,45 @@
+package db
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSqliteVacuum(t *testing.T) {
+	db, err := New("sqlite", filepath.Join(t.TempDir(), "evcc.db"))
+	require.NoError(t, err)
+
+	var journal string
+	require.NoError(t, db.Raw("PRAGMA journal_mode").Scan(&journal).Error)
+	require.Equal(t, "wal", journal)
+
+	require.NoError(t, db.Exec("CREATE TABLE t (v TEXT)").Error)
+	require.NoError(t, Vacuum(db))
+
+	var mode int
+	require.NoError(t, db.Raw("PRAGMA auto_vacuum").Scan(&mode).Error)
+	require.Equal(t, 2, mode)
+}
+
+func TestSqliteJournalMode(t *testing.T) {
+	defer func(mode string) { JournalMode = mode }(JournalMode)
+	JournalMode = "DELETE"
+
+	db, err := New("sqlite", filepath.Join(t.TempDir(), "evcc.db"))
+	require.NoError(t, err)
+
+	var journal string
+	require.NoError(t, db.Raw("PRAGMA journal_mode").Scan(&journal).Error)
+	require.Equal(t, "delete", journal)
+
+	JournalMode = "FOO"
+	_, err = New("sqlite", filepath.Join(t.TempDir(), "evcc.db"))
+	require.EqualError(t, err, "invalid journal mode: FOO")
+}
+
+func TestInvalidDriver(t *testing.T) {
+	_, err := New("foo", "")
+	require.EqualError(t, err, "invalid database type: foo not in [sqlite]")
+}
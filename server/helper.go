@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 	"strconv"
+	"time"
 )
 
 // pass converts a simple api without return value to api with nil error return value
@@ -22,3 +23,9 @@ func parseFloat(payload string) (float64, error) {
 	}
 	return f, err
 }
+
+// parseDuration parses duration in seconds
+func parseDuration(payload string) (time.Duration, error) {
+	i, err := strconv.Atoi(payload)
+	return time.Duration(i) * time.Second, err
+}
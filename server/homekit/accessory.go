@@ -0,0 +1,258 @@
+package homekit
+
+import (
+	"errors"
+	"fmt"
+)
+
+// HAP status codes
+const (
+	statusSuccess             = 0
+	statusInsufficientPrivs   = -70401
+	statusCommunication       = -70402
+	statusReadOnly            = -70404
+	statusWriteOnly           = -70405
+	statusNotificationsDenied = -70406
+	statusNotFound            = -70409
+	statusInvalidValue        = -70410
+)
+
+// service types
+const (
+	typeAccessoryInformation = "3E"
+	typeProtocolInformation  = "A2"
+	typeOutlet               = "47"
+	typeBattery              = "96"
+)
+
+// characteristic types
+const (
+	typeIdentify         = "14"
+	typeManufacturer     = "20"
+	typeModel            = "21"
+	typeName             = "23"
+	typeSerialNumber     = "30"
+	typeFirmwareRevision = "52"
+	typeVersion          = "37"
+	typeOn               = "25"
+	typeOutletInUse      = "26"
+	typeBatteryLevel     = "68"
+	typeChargingState    = "8F"
+	typeStatusLowBattery = "79"
+
+	// Eve power and energy characteristics, shown by the Eve and Home+ apps
+	typeEvePower  = "E863F10D-079E-48FF-8F27-9C2605A29F52"
+	typeEveEnergy = "E863F10C-079E-48FF-8F27-9C2605A29F52"
+)
+
+// charging states
+const (
+	chargingStateNotCharging   uint8 = 0
+	chargingStateCharging      uint8 = 1
+	chargingStateNotChargeable uint8 = 2
+)
+
+var (
+	permsRead      = []string{"pr"}
+	permsReadEvent = []string{"pr", "ev"}
+	permsWrite     = []string{"pw"}
+	permsAll       = []string{"pr", "pw", "ev"}
+)
+
+// characteristic is a single value of a service
+type characteristic struct {
+	iid    uint64
+	typ    string
+	perms  []string
+	format string
+	unit   string
+	min    any
+	max    any
+	value  any
+	write  func(any) error
+}
+
+func (c *characteristic) has(perm string) bool {
+	for _, p := range c.perms {
+		if p == perm {
+			return true
+		}
+	}
+	return false
+}
+
+// describe returns the characteristic for the accessory database
+func (c *characteristic) describe() map[string]any {
+	res := map[string]any{
+		"iid":    c.iid,
+		"type":   c.typ,
+		"perms":  c.perms,
+		"format": c.format,
+	}
+
+	if c.has("pr") {
+		res["value"] = c.value
+	}
+	if c.unit != "" {
+		res["unit"] = c.unit
+	}
+	if c.min != nil {
+		res["minValue"] = c.min
+	}
+	if c.max != nil {
+		res["maxValue"] = c.max
+	}
+
+	return res
+}
+
+// convert converts a written json value to the characteristic's format
+func (c *characteristic) convert(v any) (any, error) {
+	switch c.format {
+	case "bool":
+		switch val := v.(type) {
+		case bool:
+			return val, nil
+		case float64:
+			return val != 0, nil
+		}
+	case "uint8":
+		if val, ok := v.(float64); ok && val >= 0 && val <= 255 {
+			return uint8(val), nil
+		}
+	case "float":
+		if val, ok := v.(float64); ok {
+			return val, nil
+		}
+	case "string":
+		if val, ok := v.(string); ok {
+			return val, nil
+		}
+	}
+
+	return nil, errors.New("invalid value")
+}
+
+// service groups characteristics
+type service struct {
+	iid     uint64
+	typ     string
+	primary bool
+	chars   []*characteristic
+}
+
+// accessory is a bridged device
+type accessory struct {
+	aid      uint64
+	services []*service
+	iid      uint64
+}
+
+func newAccessory(aid uint64) *accessory {
+	return &accessory{aid: aid}
+}
+
+// service adds a service
+func (a *accessory) service(typ string) *service {
+	a.iid++
+	s := &service{iid: a.iid, typ: typ}
+	a.services = append(a.services, s)
+	return s
+}
+
+// add adds a characteristic to the service
+func (a *accessory) add(s *service, c *characteristic) *characteristic {
+	a.iid++
+	c.iid = a.iid
+	s.chars = append(s.chars, c)
+	return c
+}
+
+// information adds the mandatory accessory information service
+func (a *accessory) information(name, model, serial, firmware string) {
+	s := a.service(typeAccessoryInformation)
+	a.add(s, &characteristic{typ: typeIdentify, perms: permsWrite, format: "bool", write: func(any) error { return nil }})
+	a.add(s, &characteristic{typ: typeManufacturer, perms: permsRead, format: "string", value: "evcc"})
+	a.add(s, &characteristic{typ: typeModel, perms: permsRead, format: "string", value: model})
+	a.add(s, &characteristic{typ: typeName, perms: permsRead, format: "string", value: name})
+	a.add(s, &characteristic{typ: typeSerialNumber, perms: permsRead, format: "string", value: serial})
+	a.add(s, &characteristic{typ: typeFirmwareRevision, perms: permsRead, format: "string", value: firmware})
+}
+
+// characteristic returns the characteristic by iid
+func (a *accessory) characteristic(iid uint64) *characteristic {
+	for _, s := range a.services {
+		for _, c := range s.chars {
+			if c.iid == iid {
+				return c
+			}
+		}
+	}
+	return nil
+}
+
+// describe returns the accessory for the accessory database
+func (a *accessory) describe() map[string]any {
+	services := make([]map[string]any, 0, len(a.services))
+
+	for _, s := range a.services {
+		chars := make([]map[string]any, 0, len(s.chars))
+		for _, c := range s.chars {
+			chars = append(chars, c.describe())
+		}
+
+		svc := map[string]any{
+			"iid":             s.iid,
+			"type":            s.typ,
+			"characteristics": chars,
+		}
+		if s.primary {
+			svc["primary"] = true
+		}
+
+		services = append(services, svc)
+	}
+
+	return map[string]any{"aid": a.aid, "services": services}
+}
+
+// loadpointAccessory exposes a loadpoint as outlet with vehicle battery
+type loadpointAccessory struct {
+	*accessory
+	on, inUse, power, energy         *characteristic
+	level, chargingState, lowBattery *characteristic
+}
+
+// newLoadpointAccessory creates the loadpoint accessory
+func newLoadpointAccessory(aid uint64, title, serial, firmware string, setOn func(bool) error) *loadpointAccessory {
+	a := &loadpointAccessory{accessory: newAccessory(aid)}
+	a.information(title, "Loadpoint", fmt.Sprintf("%s-%d", serial, aid), firmware)
+
+	outlet := a.service(typeOutlet)
+	outlet.primary = true
+
+	a.on = a.add(outlet, &characteristic{typ: typeOn, perms: permsAll, format: "bool", value: false, write: func(v any) error {
+		return setOn(v.(bool))
+	}})
+	a.inUse = a.add(outlet, &characteristic{typ: typeOutletInUse, perms: permsReadEvent, format: "bool", value: false})
+	a.power = a.add(outlet, &characteristic{typ: typeEvePower, perms: permsReadEvent, format: "float", value: 0.0})
+	a.energy = a.add(outlet, &characteristic{typ: typeEveEnergy, perms: permsReadEvent, format: "float", value: 0.0})
+
+	battery := a.service(typeBattery)
+	a.level = a.add(battery, &characteristic{typ: typeBatteryLevel, perms: permsReadEvent, format: "uint8", unit: "percentage", min: 0, max: 100, value: uint8(0)})
+	a.chargingState = a.add(battery, &characteristic{typ: typeChargingState, perms: permsReadEvent, format: "uint8", min: 0, max: 2, value: chargingStateNotChargeable})
+	a.lowBattery = a.add(battery, &characteristic{typ: typeStatusLowBattery, perms: permsReadEvent, format: "uint8", min: 0, max: 1, value: uint8(0)})
+
+	return a
+}
+
+// newBridgeAccessory creates the bridge accessory
+func newBridgeAccessory(name, serial, firmware string) *accessory {
+	a := newAccessory(1)
+	a.information(name, "Bridge", serial, firmware)
+
+	s := a.service(typeProtocolInformation)
+	a.add(s, &characteristic{typ: typeVersion, perms: permsRead, format: "string", value: "1.1.0"})
+
+	return a
+}
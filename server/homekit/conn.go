@@ -0,0 +1,220 @@
+package homekit
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"sync"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/hkdf"
+)
+
+// max plaintext length of an encrypted frame
+const frameSize = 1024
+
+// deriveKey derives a 32 byte key using HKDF-SHA-512
+func deriveKey(secret []byte, salt, info string) ([]byte, error) {
+	key := make([]byte, 32)
+	_, err := io.ReadFull(hkdf.New(sha512.New, secret, []byte(salt), []byte(info)), key)
+	return key, err
+}
+
+// nonce returns the 12 byte nonce for a counter
+func nonce(counter uint64) []byte {
+	n := make([]byte, 12)
+	binary.LittleEndian.PutUint64(n[4:], counter)
+	return n
+}
+
+// messageNonce returns the 12 byte nonce for a pairing message, e.g. PS-Msg05
+func messageNonce(msg string) []byte {
+	return append(make([]byte, 4), msg...)
+}
+
+// seal encrypts a pairing message
+func seal(key []byte, msg string, plaintext []byte) ([]byte, error) {
+	aead, err := chacha20poly1305.New(key)
+	if err != nil {
+		return nil, err
+	}
+	return aead.Seal(nil, messageNonce(msg), plaintext, nil), nil
+}
+
+// open decrypts a pairing message
+func open(key []byte, msg string, ciphertext []byte) ([]byte, error) {
+	aead, err := chacha20poly1305.New(key)
+	if err != nil {
+		return nil, err
+	}
+	return aead.Open(nil, messageNonce(msg), ciphertext, nil)
+}
+
+// conn is a controller connection that is encrypted after pair verify
+type conn struct {
+	net.Conn
+
+	rmu     sync.Mutex
+	read    cipher.AEAD
+	readCnt uint64
+	buf     bytes.Buffer
+
+	wmu      sync.Mutex
+	write    cipher.AEAD
+	writeCnt uint64
+
+	verify *verifyState // pair verify in progress
+
+	mu     sync.Mutex
+	pairID string          // verified controller
+	events map[string]bool // characteristic subscriptions by aid.iid
+}
+
+func newConn(c net.Conn) *conn {
+	return &conn{Conn: c, events: make(map[string]bool)}
+}
+
+// encrypt enables session encryption after pair verify
+func (c *conn) encrypt(shared []byte, pairID string) error {
+	readKey, err := deriveKey(shared, "Control-Salt", "Control-Write-Encryption-Key")
+	if err != nil {
+		return err
+	}
+
+	writeKey, err := deriveKey(shared, "Control-Salt", "Control-Read-Encryption-Key")
+	if err != nil {
+		return err
+	}
+
+	read, err := chacha20poly1305.New(readKey)
+	if err != nil {
+		return err
+	}
+
+	write, err := chacha20poly1305.New(writeKey)
+	if err != nil {
+		return err
+	}
+
+	c.rmu.Lock()
+	c.read = read
+	c.rmu.Unlock()
+
+	c.wmu.Lock()
+	c.write = write
+	c.wmu.Unlock()
+
+	c.mu.Lock()
+	c.pairID = pairID
+	c.mu.Unlock()
+
+	return nil
+}
+
+// verified returns the controller's pairing id if the session is encrypted
+func (c *conn) verified() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.pairID
+}
+
+// Read reads and decrypts frames
+func (c *conn) Read(b []byte) (int, error) {
+	c.rmu.Lock()
+	defer c.rmu.Unlock()
+
+	if c.read == nil {
+		return c.Conn.Read(b)
+	}
+
+	if c.buf.Len() == 0 {
+		aad := make([]byte, 2)
+		if _, err := io.ReadFull(c.Conn, aad); err != nil {
+			return 0, err
+		}
+
+		n := int(binary.LittleEndian.Uint16(aad))
+		if n > frameSize {
+			return 0, errors.New("invalid frame size")
+		}
+
+		frame := make([]byte, n+chacha20poly1305.Overhead)
+		if _, err := io.ReadFull(c.Conn, frame); err != nil {
+			return 0, err
+		}
+
+		plain, err := c.read.Open(nil, nonce(c.readCnt), frame, aad)
+		if err != nil {
+			return 0, err
+		}
+		c.readCnt++
+
+		c.buf.Write(plain)
+	}
+
+	return c.buf.Read(b)
+}
+
+// Write encrypts and writes frames
+func (c *conn) Write(b []byte) (int, error) {
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+
+	if c.write == nil {
+		return c.Conn.Write(b)
+	}
+
+	var res int
+	for len(b) > 0 {
+		n := min(len(b), frameSize)
+
+		aad := binary.LittleEndian.AppendUint16(nil, uint16(n))
+		frame := c.write.Seal(aad, nonce(c.writeCnt), b[:n], aad)
+		c.writeCnt++
+
+		if _, err := c.Conn.Write(frame); err != nil {
+			return res, err
+		}
+
+		res += n
+		b = b[n:]
+	}
+
+	return res, nil
+}
+
+// subscribe sets the event subscription for a characteristic
+func (c *conn) subscribe(id string, ev bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if ev {
+		c.events[id] = true
+	} else {
+		delete(c.events, id)
+	}
+}
+
+// subscribed returns true if the connection subscribed to the characteristic
+func (c *conn) subscribed(id string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.events[id]
+}
+
+// listener wraps accepted connections
+type listener struct {
+	net.Listener
+}
+
+func (l *listener) Accept() (net.Conn, error) {
+	c, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return newConn(c), nil
+}
@@ -0,0 +1,691 @@
+package homekit
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/evcc-io/evcc/util"
+	"github.com/gorilla/mux"
+	"github.com/libp2p/zeroconf/v2"
+)
+
+// settings key of the bridge identity and pairings
+const settingsKey = "homekit"
+
+// Config is the HomeKit bridge configuration
+type Config struct {
+	Name string // bridge name shown during pairing
+	Pin  string // setup code XXX-XX-XXX, generated if empty
+	Port int    // HAP port
+	Mode string // charge mode when switching a loadpoint on
+}
+
+// pairing is a paired controller
+type pairing struct {
+	PublicKey []byte `json:"publicKey"`
+	Admin     bool   `json:"admin"`
+}
+
+// state is the persisted bridge identity
+type state struct {
+	DeviceID string             `json:"deviceId"`
+	Seed     []byte             `json:"seed"`
+	Pin      string             `json:"pin"`
+	Config   int                `json:"config"`
+	Hash     string             `json:"hash"`
+	Pairings map[string]pairing `json:"pairings"`
+	Failures int                `json:"failures,omitempty"` // unsuccessful pair setup attempts
+	Tried    string             `json:"tried,omitempty"`    // setup code of the unsuccessful attempts
+}
+
+// HomeKit is a HAP bridge exposing loadpoints as outlets with vehicle battery
+type HomeKit struct {
+	log        *util.Logger
+	conf       Config
+	mode       api.ChargeMode
+	loadpoints []loadpoint.API
+
+	mu          sync.Mutex
+	state       state
+	key         ed25519.PrivateKey
+	pin         string
+	bridge      *accessory
+	accessories []*loadpointAccessory
+	conns       map[*conn]struct{}
+	zc          *zeroconf.Server
+
+	setup     *srpServer // pair setup in progress
+	setupConn *conn
+	failed    time.Time // last unsuccessful pair setup attempt
+}
+
+var pinRE = regexp.MustCompile(`^\d{3}-\d{2}-\d{3}$`)
+
+// trivial setup codes are rejected by controllers
+var invalidPins = []string{
+	"000-00-000", "111-11-111", "222-22-222", "333-33-333", "444-44-444",
+	"555-55-555", "666-66-666", "777-77-777", "888-88-888", "999-99-999",
+	"123-45-678", "876-54-321",
+}
+
+// validPin returns true if the setup code is accepted by controllers
+func validPin(pin string) bool {
+	return pinRE.MatchString(pin) && !slices.Contains(invalidPins, pin)
+}
+
+// randomPin creates a random valid setup code
+func randomPin() (string, error) {
+	for {
+		b := make([]byte, 8)
+		if _, err := rand.Read(b); err != nil {
+			return "", err
+		}
+
+		var digits strings.Builder
+		for i, v := range b {
+			if i == 3 || i == 5 {
+				digits.WriteByte('-')
+			}
+			digits.WriteByte('0' + v%10)
+		}
+
+		if pin := digits.String(); validPin(pin) {
+			return pin, nil
+		}
+	}
+}
+
+// randomDeviceID creates a random device id in MAC address format
+func randomDeviceID() (string, error) {
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return strings.ToUpper(net.HardwareAddr(b).String()), nil
+}
+
+// New creates the HomeKit bridge
+func New(conf Config, loadpoints []loadpoint.API, firmware string) (*HomeKit, error) {
+	if conf.Name == "" {
+		conf.Name = "evcc"
+	}
+
+	if conf.Port == 0 {
+		conf.Port = 51826
+	}
+
+	mode := api.ModePV
+	if conf.Mode != "" {
+		var err error
+		if mode, err = api.ChargeModeString(conf.Mode); err != nil {
+			return nil, err
+		}
+	}
+
+	if mode == api.ModeOff {
+		return nil, errors.New("invalid mode: off")
+	}
+
+	if conf.Pin != "" && !validPin(conf.Pin) {
+		return nil, fmt.Errorf("invalid pin: %s", conf.Pin)
+	}
+
+	hk := &HomeKit{
+		log:        util.NewLogger("homekit"),
+		conf:       conf,
+		mode:       mode,
+		loadpoints: loadpoints,
+		conns:      make(map[*conn]struct{}),
+	}
+
+	if err := hk.restore(); err != nil {
+		return nil, err
+	}
+
+	hk.pin = conf.Pin
+	if hk.pin == "" {
+		hk.pin = hk.state.Pin
+	}
+
+	// changing the setup code lifts the pair setup lockout
+	if hk.state.Failures > 0 && hk.state.Tried != hk.pin {
+		hk.state.Failures, hk.state.Tried = 0, ""
+		hk.persist()
+	}
+
+	hk.bridge = newBridgeAccessory(conf.Name, hk.state.DeviceID, firmware)
+
+	for i, lp := range loadpoints {
+		title := lp.Title()
+		if title == "" {
+			title = fmt.Sprintf("Loadpoint %d", i+1)
+		}
+
+		hk.accessories = append(hk.accessories, newLoadpointAccessory(uint64(i+2), title, hk.state.DeviceID, firmware, func(on bool) error {
+			mode := api.ModeOff
+			if on {
+				mode = hk.mode
+			}
+			lp.SetMode(mode)
+			return nil
+		}))
+	}
+
+	// configuration number changes with the accessory database
+	if hash := hk.databaseHash(); hash != hk.state.Hash {
+		hk.state.Hash = hash
+		hk.state.Config = hk.state.Config%65535 + 1
+		hk.persist()
+	}
+
+	return hk, nil
+}
+
+// restore loads or creates the bridge identity
+func (hk *HomeKit) restore() error {
+	if err := settings.Json(settingsKey, &hk.state); err != nil && !errors.Is(err, settings.ErrNotFound) {
+		return err
+	}
+
+	if hk.state.Pairings == nil {
+		hk.state.Pairings = make(map[string]pairing)
+	}
+
+	var err error
+	if hk.state.DeviceID == "" {
+		if hk.state.DeviceID, err = randomDeviceID(); err != nil {
+			return err
+		}
+	}
+
+	if len(hk.state.Seed) != ed25519.SeedSize {
+		hk.state.Seed = make([]byte, ed25519.SeedSize)
+		if _, err := rand.Read(hk.state.Seed); err != nil {
+			return err
+		}
+	}
+
+	if hk.state.Pin == "" {
+		if hk.state.Pin, err = randomPin(); err != nil {
+			return err
+		}
+	}
+
+	hk.key = ed25519.NewKeyFromSeed(hk.state.Seed)
+	hk.persist()
+
+	return nil
+}
+
+// persist stores the bridge identity
+func (hk *HomeKit) persist() {
+	if err := settings.SetJson(settingsKey, hk.state); err != nil {
+		hk.log.ERROR.Println(err)
+		return
+	}
+
+	if err := settings.Persist(); err != nil {
+		hk.log.ERROR.Println(err)
+	}
+}
+
+// databaseHash identifies the accessory layout
+func (hk *HomeKit) databaseHash() string {
+	b, _ := json.Marshal(hk.database())
+	h := sha512.Sum512(b)
+	return base64.StdEncoding.EncodeToString(h[:8])
+}
+
+// database returns the accessory database
+func (hk *HomeKit) database() map[string]any {
+	res := []map[string]any{hk.bridge.describe()}
+	for _, a := range hk.accessories {
+		res = append(res, a.describe())
+	}
+	return map[string]any{"accessories": res}
+}
+
+// accessory returns the accessory by aid
+func (hk *HomeKit) accessory(aid uint64) *accessory {
+	if aid == 1 {
+		return hk.bridge
+	}
+	if i := int(aid) - 2; i >= 0 && i < len(hk.accessories) {
+		return hk.accessories[i].accessory
+	}
+	return nil
+}
+
+// txt returns the mDNS service records
+func (hk *HomeKit) txt() []string {
+	sf := "0"
+	if len(hk.state.Pairings) == 0 {
+		sf = "1"
+	}
+
+	return []string{
+		"c#=" + strconv.Itoa(hk.state.Config),
+		"ff=0",
+		"id=" + hk.state.DeviceID,
+		"md=" + hk.conf.Name,
+		"pv=1.1",
+		"s#=1",
+		"sf=" + sf,
+		"ci=2", // bridge
+	}
+}
+
+// announce updates the mDNS records after pairing changes
+func (hk *HomeKit) announce() {
+	if hk.zc != nil {
+		hk.zc.SetText(hk.txt())
+	}
+}
+
+// closeUnpaired closes sessions of removed controllers once the current response has been sent
+func (hk *HomeKit) closeUnpaired() {
+	hk.announce()
+
+	go func() {
+		time.Sleep(time.Second)
+
+		hk.mu.Lock()
+		defer hk.mu.Unlock()
+
+		for c := range hk.conns {
+			if id := c.verified(); id != "" {
+				if _, ok := hk.state.Pairings[id]; !ok {
+					c.Close()
+				}
+			}
+		}
+	}()
+}
+
+type connKey struct{}
+
+// connFromContext returns the controller connection of a request
+func connFromContext(ctx context.Context) *conn {
+	return ctx.Value(connKey{}).(*conn)
+}
+
+// verified rejects requests without encrypted session
+func verified(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if connFromContext(r.Context()).verified() == "" {
+			writeJSON(w, 470, map[string]int{"status": statusInsufficientPrivs})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// writeJSON writes a HAP json response
+func writeJSON(w http.ResponseWriter, status int, res any) {
+	b, err := json.Marshal(res)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/hap+json")
+	w.Header().Set("Content-Length", strconv.Itoa(len(b)))
+	w.WriteHeader(status)
+	_, _ = w.Write(b)
+}
+
+// accessoriesHandler returns the accessory database
+func (hk *HomeKit) accessoriesHandler(w http.ResponseWriter, r *http.Request) {
+	hk.mu.Lock()
+	res := hk.database()
+	hk.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+// characteristicResult is the result for a single characteristic
+type characteristicResult struct {
+	AID    uint64 `json:"aid"`
+	IID    uint64 `json:"iid"`
+	Value  any    `json:"value,omitempty"`
+	Status *int   `json:"status,omitempty"`
+}
+
+// parseID parses a characteristic id aid.iid
+func parseID(s string) (uint64, uint64, error) {
+	a, i, ok := strings.Cut(s, ".")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid id: %s", s)
+	}
+
+	aid, err := strconv.ParseUint(a, 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	iid, err := strconv.ParseUint(i, 10, 64)
+	return aid, iid, err
+}
+
+// getCharacteristicsHandler reads characteristic values
+func (hk *HomeKit) getCharacteristicsHandler(w http.ResponseWriter, r *http.Request) {
+	var res []characteristicResult
+	failed := false
+
+	hk.mu.Lock()
+	for _, id := range strings.Split(r.URL.Query().Get("id"), ",") {
+		aid, iid, err := parseID(id)
+		if err != nil {
+			hk.mu.Unlock()
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		cr := characteristicResult{AID: aid, IID: iid}
+		status := statusSuccess
+
+		var c *characteristic
+		if a := hk.accessory(aid); a != nil {
+			c = a.characteristic(iid)
+		}
+
+		switch {
+		case c == nil:
+			status = statusNotFound
+		case !c.has("pr"):
+			status = statusWriteOnly
+		default:
+			cr.Value = c.value
+		}
+
+		if status != statusSuccess {
+			failed = true
+		}
+		cr.Status = &status
+
+		res = append(res, cr)
+	}
+	hk.mu.Unlock()
+
+	if !failed {
+		for i := range res {
+			res[i].Status = nil
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"characteristics": res})
+		return
+	}
+
+	writeJSON(w, http.StatusMultiStatus, map[string]any{"characteristics": res})
+}
+
+// putCharacteristicsHandler writes characteristic values and event subscriptions
+func (hk *HomeKit) putCharacteristicsHandler(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Characteristics []struct {
+			AID   uint64 `json:"aid"`
+			IID   uint64 `json:"iid"`
+			Value any    `json:"value"`
+			Ev    *bool  `json:"ev"`
+		} `json:"characteristics"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	c := connFromContext(r.Context())
+
+	var res []characteristicResult
+	failed := false
+
+	for _, cr := range req.Characteristics {
+		status := hk.putCharacteristic(c, cr.AID, cr.IID, cr.Value, cr.Ev)
+		if status != statusSuccess {
+			failed = true
+		}
+		res = append(res, characteristicResult{AID: cr.AID, IID: cr.IID, Status: &status})
+	}
+
+	if !failed {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	writeJSON(w, http.StatusMultiStatus, map[string]any{"characteristics": res})
+}
+
+// putCharacteristic writes a single characteristic
+func (hk *HomeKit) putCharacteristic(c *conn, aid, iid uint64, value any, ev *bool) int {
+	hk.mu.Lock()
+	var ch *characteristic
+	if a := hk.accessory(aid); a != nil {
+		ch = a.characteristic(iid)
+	}
+	hk.mu.Unlock()
+
+	if ch == nil {
+		return statusNotFound
+	}
+
+	if ev != nil {
+		if !ch.has("ev") {
+			return statusNotificationsDenied
+		}
+		c.subscribe(fmt.Sprintf("%d.%d", aid, iid), *ev)
+	}
+
+	if value == nil {
+		return statusSuccess
+	}
+
+	if !ch.has("pw") {
+		return statusReadOnly
+	}
+
+	v, err := ch.convert(value)
+	if err != nil {
+		return statusInvalidValue
+	}
+
+	if err := ch.write(v); err != nil {
+		hk.log.ERROR.Printf("write %d.%d: %v", aid, iid, err)
+		return statusCommunication
+	}
+
+	if ch.has("pr") {
+		hk.update(aid, ch, v, c)
+	}
+
+	return statusSuccess
+}
+
+// identifyHandler identifies the unpaired bridge
+func (hk *HomeKit) identifyHandler(w http.ResponseWriter, r *http.Request) {
+	hk.mu.Lock()
+	paired := len(hk.state.Pairings) > 0
+	hk.mu.Unlock()
+
+	if paired {
+		writeJSON(w, http.StatusBadRequest, map[string]int{"status": statusInsufficientPrivs})
+		return
+	}
+
+	hk.log.INFO.Println("identify")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// value returns a characteristic value
+func (hk *HomeKit) value(c *characteristic) any {
+	hk.mu.Lock()
+	defer hk.mu.Unlock()
+	return c.value
+}
+
+// update sets a characteristic value and notifies subscribed controllers except the writer
+func (hk *HomeKit) update(aid uint64, c *characteristic, value any, writer *conn) {
+	hk.mu.Lock()
+	defer hk.mu.Unlock()
+
+	if c.value == value {
+		return
+	}
+	c.value = value
+
+	id := fmt.Sprintf("%d.%d", aid, c.iid)
+
+	b, err := json.Marshal(map[string]any{
+		"characteristics": []characteristicResult{{AID: aid, IID: c.iid, Value: value}},
+	})
+	if err != nil {
+		return
+	}
+
+	msg := []byte(fmt.Sprintf("EVENT/1.0 200 OK\r\nContent-Type: application/hap+json\r\nContent-Length: %d\r\n\r\n%s", len(b), b))
+
+	for conn := range hk.conns {
+		if conn != writer && conn.verified() != "" && conn.subscribed(id) {
+			go func() {
+				if _, err := conn.Write(msg); err != nil {
+					hk.log.DEBUG.Println("event:", err)
+				}
+			}()
+		}
+	}
+}
+
+// Run updates the accessories from published values
+func (hk *HomeKit) Run(in <-chan util.Param) {
+	for p := range in {
+		if p.Loadpoint == nil || *p.Loadpoint >= len(hk.accessories) {
+			continue
+		}
+
+		a := hk.accessories[*p.Loadpoint]
+
+		switch p.Key {
+		case keys.Mode:
+			if mode, ok := p.Val.(api.ChargeMode); ok {
+				hk.update(a.aid, a.on, mode != api.ModeOff, nil)
+			}
+
+		case keys.Connected:
+			if connected, ok := p.Val.(bool); ok {
+				hk.update(a.aid, a.inUse, connected, nil)
+				if !connected {
+					hk.update(a.aid, a.chargingState, chargingStateNotChargeable, nil)
+				} else if hk.value(a.chargingState) == chargingStateNotChargeable {
+					hk.update(a.aid, a.chargingState, chargingStateNotCharging, nil)
+				}
+			}
+
+		case keys.Charging:
+			if charging, ok := p.Val.(bool); ok && hk.value(a.inUse) == true {
+				state := chargingStateNotCharging
+				if charging {
+					state = chargingStateCharging
+				}
+				hk.update(a.aid, a.chargingState, state, nil)
+			}
+
+		case keys.ChargePower:
+			if power, ok := p.Val.(float64); ok {
+				hk.update(a.aid, a.power, math.Round(power), nil)
+			}
+
+		case keys.ChargeTotalImport:
+			if energy, ok := p.Val.(float64); ok {
+				hk.update(a.aid, a.energy, math.Round(energy*1e3)/1e3, nil)
+			}
+
+		case keys.VehicleSoc:
+			if soc, ok := p.Val.(float64); ok {
+				level := uint8(min(max(math.Round(soc), 0), 100))
+				hk.update(a.aid, a.level, level, nil)
+
+				low := uint8(0)
+				if level < 20 {
+					low = 1
+				}
+				hk.update(a.aid, a.lowBattery, low, nil)
+			}
+		}
+	}
+}
+
+// ListenAndServe announces the bridge and serves controller connections
+func (hk *HomeKit) ListenAndServe() error {
+	l, err := net.Listen("tcp", fmt.Sprintf(":%d", hk.conf.Port))
+	if err != nil {
+		return err
+	}
+
+	router := mux.NewRouter()
+	router.Methods(http.MethodPost).Path("/pair-setup").HandlerFunc(hk.pairSetupHandler)
+	router.Methods(http.MethodPost).Path("/pair-verify").HandlerFunc(hk.pairVerifyHandler)
+	router.Methods(http.MethodPost).Path("/identify").HandlerFunc(hk.identifyHandler)
+
+	session := router.NewRoute().Subrouter()
+	session.Use(verified)
+	session.Methods(http.MethodPost).Path("/pairings").HandlerFunc(hk.pairingsHandler)
+	session.Methods(http.MethodGet).Path("/accessories").HandlerFunc(hk.accessoriesHandler)
+	session.Methods(http.MethodGet).Path("/characteristics").HandlerFunc(hk.getCharacteristicsHandler)
+	session.Methods(http.MethodPut).Path("/characteristics").HandlerFunc(hk.putCharacteristicsHandler)
+
+	srv := &http.Server{
+		Handler:  router,
+		ErrorLog: hk.log.ERROR,
+		ConnContext: func(ctx context.Context, c net.Conn) context.Context {
+			return context.WithValue(ctx, connKey{}, c)
+		},
+		ConnState: func(c net.Conn, state http.ConnState) {
+			hk.mu.Lock()
+			defer hk.mu.Unlock()
+
+			switch state {
+			case http.StateNew:
+				hk.conns[c.(*conn)] = struct{}{}
+			case http.StateClosed, http.StateHijacked:
+				delete(hk.conns, c.(*conn))
+				if hk.setupConn == c {
+					hk.setup, hk.setupConn = nil, nil
+				}
+			}
+		},
+	}
+
+	hk.mu.Lock()
+	hk.zc, err = zeroconf.Register(hk.conf.Name, "_hap._tcp", "local.", hk.conf.Port, hk.txt(), nil)
+	paired := len(hk.state.Pairings) > 0
+	hk.mu.Unlock()
+
+	if err != nil {
+		return fmt.Errorf("mDNS announcement: %w", err)
+	}
+	defer hk.zc.Shutdown()
+
+	if !paired {
+		hk.log.INFO.Printf("setup code: %s", hk.pin)
+	}
+
+	return srv.Serve(&listener{l})
+}
@@ -0,0 +1,153 @@
+package homekit
+
+import (
+	"bytes"
+	"io"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTLV(t *testing.T) {
+	long := bytes.Repeat([]byte{1}, 300)
+
+	var in tlv
+	in.add(tlvState, 1)
+	in.add(tlvPublicKey, long...)
+	in.add(tlvIdentifier, []byte("a")...)
+	in.add(tlvIdentifier, []byte("b")...)
+
+	out, err := decodeTLV(in.encode())
+	require.NoError(t, err)
+
+	state, ok := out.byte(tlvState)
+	assert.True(t, ok)
+	assert.Equal(t, byte(1), state)
+	assert.Equal(t, long, out.get(tlvPublicKey), "fragments merged")
+	assert.Equal(t, []byte("a"), out.get(tlvIdentifier))
+	assert.Len(t, out, 5, "separated items")
+
+	_, err = decodeTLV([]byte{tlvState, 2, 1})
+	assert.Error(t, err)
+}
+
+func TestSRP(t *testing.T) {
+	code := "031-45-154"
+
+	s, err := newSRPServer(code)
+	require.NoError(t, err)
+
+	// client side
+	a := big.NewInt(123456789)
+	A := new(big.Int).Exp(srpG, a, srpN)
+	B := new(big.Int).SetBytes(s.publicKey())
+
+	x := new(big.Int).SetBytes(srpHash(s.salt, srpHash([]byte(srpUsername+":"+code))))
+	k := new(big.Int).SetBytes(srpHash(srpN.Bytes(), pad(srpG)))
+	u := new(big.Int).SetBytes(srpHash(pad(A), pad(B)))
+
+	// S = (B - k*g^x) ^ (a + u*x)
+	base := new(big.Int).Sub(B, new(big.Int).Mul(k, new(big.Int).Exp(srpG, x, srpN)))
+	base.Mod(base, srpN)
+	exp := new(big.Int).Add(a, new(big.Int).Mul(u, x))
+	key := srpHash(pad(new(big.Int).Exp(base, exp, srpN)))
+
+	hn, hg := srpHash(srpN.Bytes()), srpHash(srpG.Bytes())
+	for i := range hn {
+		hn[i] ^= hg[i]
+	}
+	m1 := srpHash(hn, srpHash([]byte(srpUsername)), s.salt, pad(A), pad(B), key)
+
+	m2, err := s.verify(pad(A), m1)
+	require.NoError(t, err)
+	assert.Equal(t, key, s.key)
+	assert.Equal(t, srpHash(pad(A), m1, key), m2)
+
+	// wrong code
+	s, err = newSRPServer("111-22-333")
+	require.NoError(t, err)
+	_, err = s.verify(pad(A), m1)
+	assert.Error(t, err)
+}
+
+func TestConnEncryption(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+
+	shared := []byte("shared secret")
+
+	c := newConn(server)
+	require.NoError(t, c.encrypt(shared, "controller"))
+	assert.Equal(t, "controller", c.verified())
+
+	// controller side of the session has swapped keys
+	peer := newConn(client)
+	require.NoError(t, peer.encrypt(shared, "accessory"))
+	peer.read, peer.write = c.write, c.read
+
+	msg := bytes.Repeat([]byte("x"), 2500)
+
+	go func() {
+		_, err := c.Write(msg)
+		assert.NoError(t, err)
+	}()
+
+	res := make([]byte, len(msg))
+	_, err := io.ReadFull(peer, res)
+	require.NoError(t, err)
+	assert.Equal(t, msg, res)
+}
+
+func TestPin(t *testing.T) {
+	assert.True(t, validPin("031-45-154"))
+	assert.False(t, validPin("123-45-678"))
+	assert.False(t, validPin("03145154"))
+
+	pin, err := randomPin()
+	require.NoError(t, err)
+	assert.True(t, validPin(pin))
+}
+
+func TestPairSetupLockout(t *testing.T) {
+	hk := &HomeKit{
+		log:   util.NewLogger("homekit"),
+		pin:   "031-45-154",
+		state: state{Pairings: make(map[string]pairing)},
+	}
+
+	c := new(conn)
+
+	var invalid tlv
+	invalid.add(tlvPublicKey, 1)
+	invalid.add(tlvProof, 1)
+
+	failure := func(res tlv) byte {
+		code, _ := res.byte(tlvError)
+		return code
+	}
+
+	assert.Zero(t, failure(hk.pairSetupStart(c)))
+	assert.Equal(t, byte(tlvErrorAuthentication), failure(hk.pairSetupVerify(c, invalid)))
+	assert.Equal(t, 1, hk.state.Failures)
+
+	// retry is delayed
+	res := hk.pairSetupStart(c)
+	assert.Equal(t, byte(tlvErrorBackoff), failure(res))
+	assert.NotEmpty(t, res.get(tlvRetryDelay))
+
+	hk.failed = time.Time{}
+	assert.Zero(t, failure(hk.pairSetupStart(c)))
+
+	// locked after max attempts
+	hk.state.Failures = maxSetupTries - 1
+	assert.Equal(t, byte(tlvErrorMaxTries), failure(hk.pairSetupVerify(c, invalid)))
+
+	hk.failed = time.Time{}
+	assert.Equal(t, byte(tlvErrorMaxTries), failure(hk.pairSetupStart(c)))
+	assert.Equal(t, hk.pin, hk.state.Tried)
+}
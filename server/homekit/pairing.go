@@ -0,0 +1,452 @@
+package homekit
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// verifyState is the pair verify state of a connection
+type verifyState struct {
+	shared, key              []byte
+	accessoryPub, controller []byte
+}
+
+// readTLV reads a TLV8 request
+func readTLV(r *http.Request) (tlv, error) {
+	b, err := io.ReadAll(io.LimitReader(r.Body, 1<<16))
+	if err != nil {
+		return nil, err
+	}
+	return decodeTLV(b)
+}
+
+// writeTLV writes a TLV8 response with content length as required for the session upgrade after pair verify
+func writeTLV(w http.ResponseWriter, res tlv) {
+	b := res.encode()
+	w.Header().Set("Content-Type", "application/pairing+tlv8")
+	w.Header().Set("Content-Length", strconv.Itoa(len(b)))
+	_, _ = w.Write(b)
+}
+
+// tlvFailure returns an error response
+func tlvFailure(state, code byte) tlv {
+	var res tlv
+	res.add(tlvState, state)
+	res.add(tlvError, code)
+	return res
+}
+
+// pairSetupHandler pairs a new admin controller using the setup code
+func (hk *HomeKit) pairSetupHandler(w http.ResponseWriter, r *http.Request) {
+	c := connFromContext(r.Context())
+
+	req, err := readTLV(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var res tlv
+
+	switch state, _ := req.byte(tlvState); state {
+	case 1:
+		res = hk.pairSetupStart(c)
+	case 3:
+		res = hk.pairSetupVerify(c, req)
+	case 5:
+		res = hk.pairSetupExchange(c, req)
+	default:
+		http.Error(w, "invalid state", http.StatusBadRequest)
+		return
+	}
+
+	writeTLV(w, res)
+}
+
+const (
+	maxSetupTries   = 100         // unsuccessful pair setup attempts before pairing is locked
+	maxSetupBackoff = time.Minute // upper limit of the delay after unsuccessful attempts
+)
+
+// setupBackoff returns the remaining delay before the next pair setup attempt is accepted
+func (hk *HomeKit) setupBackoff() time.Duration {
+	delay := min(time.Duration(hk.state.Failures)*time.Second, maxSetupBackoff)
+	return max(0, time.Until(hk.failed.Add(delay)))
+}
+
+// pairSetupStart handles M1 and returns the SRP salt and public key
+func (hk *HomeKit) pairSetupStart(c *conn) tlv {
+	hk.mu.Lock()
+	defer hk.mu.Unlock()
+
+	if len(hk.state.Pairings) > 0 {
+		return tlvFailure(2, tlvErrorUnavailable)
+	}
+
+	if hk.state.Failures >= maxSetupTries {
+		return tlvFailure(2, tlvErrorMaxTries)
+	}
+
+	if delay := hk.setupBackoff(); delay > 0 {
+		res := tlvFailure(2, tlvErrorBackoff)
+		res.add(tlvRetryDelay, byte(min(delay.Seconds()+1, 255)))
+		return res
+	}
+
+	if hk.setup != nil && hk.setupConn != c {
+		return tlvFailure(2, tlvErrorBusy)
+	}
+
+	srp, err := newSRPServer(hk.pin)
+	if err != nil {
+		hk.log.ERROR.Println("pair setup:", err)
+		return tlvFailure(2, tlvErrorUnavailable)
+	}
+
+	hk.setup, hk.setupConn = srp, c
+
+	var res tlv
+	res.add(tlvState, 2)
+	res.add(tlvSalt, srp.salt...)
+	res.add(tlvPublicKey, srp.publicKey()...)
+
+	return res
+}
+
+// pairSetupVerify handles M3 and verifies the controller's proof of the setup code
+func (hk *HomeKit) pairSetupVerify(c *conn, req tlv) tlv {
+	hk.mu.Lock()
+	defer hk.mu.Unlock()
+
+	if hk.setup == nil || hk.setupConn != c {
+		return tlvFailure(4, tlvErrorAuthentication)
+	}
+
+	proof, err := hk.setup.verify(req.get(tlvPublicKey), req.get(tlvProof))
+	if err != nil {
+		hk.state.Failures++
+		hk.state.Tried = hk.pin
+		hk.failed = time.Now()
+		hk.persist()
+
+		hk.log.WARN.Printf("pair setup: invalid setup code (%d/%d attempts)", hk.state.Failures, maxSetupTries)
+		hk.setup, hk.setupConn = nil, nil
+
+		if hk.state.Failures >= maxSetupTries {
+			hk.log.ERROR.Println("pair setup: too many attempts, pairing locked until the setup code is changed")
+			return tlvFailure(4, tlvErrorMaxTries)
+		}
+
+		return tlvFailure(4, tlvErrorAuthentication)
+	}
+
+	var res tlv
+	res.add(tlvState, 4)
+	res.add(tlvProof, proof...)
+
+	return res
+}
+
+// pairSetupExchange handles M5 and exchanges the long-term public keys
+func (hk *HomeKit) pairSetupExchange(c *conn, req tlv) tlv {
+	hk.mu.Lock()
+	defer hk.mu.Unlock()
+
+	srp := hk.setup
+	if srp == nil || srp.key == nil || hk.setupConn != c {
+		return tlvFailure(6, tlvErrorAuthentication)
+	}
+	hk.setup, hk.setupConn = nil, nil
+
+	res, err := hk.exchange(srp.key, req)
+	if err != nil {
+		hk.log.WARN.Println("pair setup:", err)
+		return tlvFailure(6, tlvErrorAuthentication)
+	}
+
+	return res
+}
+
+// exchange verifies the controller's long-term key and returns the accessory's
+func (hk *HomeKit) exchange(srpKey []byte, req tlv) (tlv, error) {
+	key, err := deriveKey(srpKey, "Pair-Setup-Encrypt-Salt", "Pair-Setup-Encrypt-Info")
+	if err != nil {
+		return nil, err
+	}
+
+	plain, err := open(key, "PS-Msg05", req.get(tlvEncryptedData))
+	if err != nil {
+		return nil, err
+	}
+
+	sub, err := decodeTLV(plain)
+	if err != nil {
+		return nil, err
+	}
+
+	id, ltpk, sig := sub.get(tlvIdentifier), sub.get(tlvPublicKey), sub.get(tlvSignature)
+	if len(ltpk) != ed25519.PublicKeySize {
+		return nil, errors.New("invalid public key")
+	}
+
+	controllerX, err := deriveKey(srpKey, "Pair-Setup-Controller-Sign-Salt", "Pair-Setup-Controller-Sign-Info")
+	if err != nil {
+		return nil, err
+	}
+
+	if !ed25519.Verify(ltpk, slices.Concat(controllerX, id, ltpk), sig) {
+		return nil, errors.New("invalid signature")
+	}
+
+	accessoryX, err := deriveKey(srpKey, "Pair-Setup-Accessory-Sign-Salt", "Pair-Setup-Accessory-Sign-Info")
+	if err != nil {
+		return nil, err
+	}
+
+	pub := hk.key.Public().(ed25519.PublicKey)
+	deviceID := []byte(hk.state.DeviceID)
+
+	var resSub tlv
+	resSub.add(tlvIdentifier, deviceID...)
+	resSub.add(tlvPublicKey, pub...)
+	resSub.add(tlvSignature, ed25519.Sign(hk.key, slices.Concat(accessoryX, deviceID, pub))...)
+
+	encrypted, err := seal(key, "PS-Msg06", resSub.encode())
+	if err != nil {
+		return nil, err
+	}
+
+	hk.state.Pairings[string(id)] = pairing{PublicKey: ltpk, Admin: true}
+	hk.state.Failures, hk.state.Tried = 0, ""
+	hk.persist()
+	hk.announce()
+
+	hk.log.INFO.Printf("paired with %s", id)
+
+	var res tlv
+	res.add(tlvState, 6)
+	res.add(tlvEncryptedData, encrypted...)
+
+	return res, nil
+}
+
+// pairVerifyHandler establishes an encrypted session with a paired controller
+func (hk *HomeKit) pairVerifyHandler(w http.ResponseWriter, r *http.Request) {
+	c := connFromContext(r.Context())
+
+	req, err := readTLV(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	switch state, _ := req.byte(tlvState); state {
+	case 1:
+		res, err := hk.pairVerifyStart(c, req)
+		if err != nil {
+			hk.log.WARN.Println("pair verify:", err)
+			res = tlvFailure(2, tlvErrorAuthentication)
+		}
+		writeTLV(w, res)
+
+	case 3:
+		id, err := hk.pairVerifyFinish(c, req)
+		if err != nil {
+			hk.log.WARN.Println("pair verify:", err)
+			writeTLV(w, tlvFailure(4, tlvErrorAuthentication))
+			return
+		}
+
+		var res tlv
+		res.add(tlvState, 4)
+		writeTLV(w, res)
+
+		// response must be sent unencrypted
+		if err := http.NewResponseController(w).Flush(); err != nil {
+			hk.log.ERROR.Println("pair verify:", err)
+			return
+		}
+
+		if err := c.encrypt(c.verify.shared, id); err != nil {
+			hk.log.ERROR.Println("pair verify:", err)
+		}
+
+	default:
+		http.Error(w, "invalid state", http.StatusBadRequest)
+	}
+}
+
+// pairVerifyStart handles M1 and performs the key agreement
+func (hk *HomeKit) pairVerifyStart(c *conn, req tlv) (tlv, error) {
+	controller := req.get(tlvPublicKey)
+
+	controllerKey, err := ecdh.X25519().NewPublicKey(controller)
+	if err != nil {
+		return nil, err
+	}
+
+	priv, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	shared, err := priv.ECDH(controllerKey)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := deriveKey(shared, "Pair-Verify-Encrypt-Salt", "Pair-Verify-Encrypt-Info")
+	if err != nil {
+		return nil, err
+	}
+
+	pub := priv.PublicKey().Bytes()
+	deviceID := []byte(hk.state.DeviceID)
+
+	var sub tlv
+	sub.add(tlvIdentifier, deviceID...)
+	sub.add(tlvSignature, ed25519.Sign(hk.key, slices.Concat(pub, deviceID, controller))...)
+
+	encrypted, err := seal(key, "PV-Msg02", sub.encode())
+	if err != nil {
+		return nil, err
+	}
+
+	c.verify = &verifyState{shared: shared, key: key, accessoryPub: pub, controller: controller}
+
+	var res tlv
+	res.add(tlvState, 2)
+	res.add(tlvPublicKey, pub...)
+	res.add(tlvEncryptedData, encrypted...)
+
+	return res, nil
+}
+
+// pairVerifyFinish handles M3 and verifies the controller's signature
+func (hk *HomeKit) pairVerifyFinish(c *conn, req tlv) (string, error) {
+	v := c.verify
+	if v == nil {
+		return "", errors.New("missing key agreement")
+	}
+
+	plain, err := open(v.key, "PV-Msg03", req.get(tlvEncryptedData))
+	if err != nil {
+		return "", err
+	}
+
+	sub, err := decodeTLV(plain)
+	if err != nil {
+		return "", err
+	}
+
+	id := sub.get(tlvIdentifier)
+
+	hk.mu.Lock()
+	p, ok := hk.state.Pairings[string(id)]
+	hk.mu.Unlock()
+
+	if !ok {
+		return "", errors.New("unknown controller")
+	}
+
+	if !ed25519.Verify(p.PublicKey, slices.Concat(v.controller, id, v.accessoryPub), sub.get(tlvSignature)) {
+		return "", errors.New("invalid signature")
+	}
+
+	return string(id), nil
+}
+
+// pairingsHandler adds, removes and lists pairings from admin controllers
+func (hk *HomeKit) pairingsHandler(w http.ResponseWriter, r *http.Request) {
+	c := connFromContext(r.Context())
+
+	req, err := readTLV(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	hk.mu.Lock()
+	defer hk.mu.Unlock()
+
+	if p, ok := hk.state.Pairings[c.verified()]; !ok || !p.Admin {
+		writeTLV(w, tlvFailure(2, tlvErrorAuthentication))
+		return
+	}
+
+	var res tlv
+	res.add(tlvState, 2)
+
+	switch method, _ := req.byte(tlvMethod); method {
+	case methodAddPairing:
+		id, pk := string(req.get(tlvIdentifier)), req.get(tlvPublicKey)
+		perms, _ := req.byte(tlvPermissions)
+
+		if p, ok := hk.state.Pairings[id]; ok && !bytes.Equal(p.PublicKey, pk) {
+			writeTLV(w, tlvFailure(2, 1))
+			return
+		}
+
+		if len(pk) != ed25519.PublicKeySize {
+			writeTLV(w, tlvFailure(2, 1))
+			return
+		}
+
+		hk.state.Pairings[id] = pairing{PublicKey: pk, Admin: perms&permissionAdmin != 0}
+		hk.log.INFO.Printf("added pairing %s", id)
+
+	case methodRemovePairing:
+		id := string(req.get(tlvIdentifier))
+		delete(hk.state.Pairings, id)
+		hk.log.INFO.Printf("removed pairing %s", id)
+
+		// without admin, the bridge becomes unpaired
+		if !slices.ContainsFunc(mapValues(hk.state.Pairings), func(p pairing) bool { return p.Admin }) {
+			clear(hk.state.Pairings)
+		}
+
+		// sessions of removed controllers are closed after the response
+		defer hk.closeUnpaired()
+
+	case methodListPairings:
+		first := true
+		for id, p := range hk.state.Pairings {
+			if !first {
+				res.add(tlvSeparator)
+			}
+			first = false
+
+			perms := byte(0)
+			if p.Admin {
+				perms = permissionAdmin
+			}
+
+			res.add(tlvIdentifier, []byte(id)...)
+			res.add(tlvPublicKey, p.PublicKey...)
+			res.add(tlvPermissions, perms)
+		}
+
+	default:
+		http.Error(w, "invalid method", http.StatusBadRequest)
+		return
+	}
+
+	hk.persist()
+	writeTLV(w, res)
+}
+
+func mapValues[K comparable, V any](m map[K]V) []V {
+	res := make([]V, 0, len(m))
+	for _, v := range m {
+		res = append(res, v)
+	}
+	return res
+}
@@ -0,0 +1,102 @@
+package homekit
+
+import (
+	"crypto/rand"
+	"crypto/sha512"
+	"crypto/subtle"
+	"errors"
+	"math/big"
+)
+
+// SRP-6a group parameters (RFC 5054, 3072 bit)
+var (
+	srpN, _ = new(big.Int).SetString("FFFFFFFFFFFFFFFFC90FDAA22168C234C4C6628B80DC1CD129024E088A67CC74020BBEA63B139B22514A08798E3404DDEF9519B3CD3A431B302B0A6DF25F14374FE1356D6D51C245E485B576625E7EC6F44C42E9A637ED6B0BFF5CB6F406B7EDEE386BFB5A899FA5AE9F24117C4B1FE649286651ECE45B3DC2007CB8A163BF0598DA48361C55D39A69163FA8FD24CF5F83655D23DCA3AD961C62F356208552BB9ED529077096966D670C354E4ABC9804F1746C08CA18217C32905E462E36CE3BE39E772C180E86039B2783A2EC07A28FB5C55DF06F4C52C9DE2BCBF6955817183995497CEA956AE515D2261898FA051015728E5A8AAAC42DAD33170D04507A33A85521ABDF1CBA64ECFB850458DBEF0A8AEA71575D060C7DB3970F85A6E1E4C7ABF5AE8CDB0933D71E8C94E04A25619DCEE3D2261AD2EE6BF12FFA06D98A0864D87602733EC86A64521F2B18177B200CBBE117577A615D6C770988C0BAD946E208E24FA074E5AB3143DB5BFCE0FD108E4B82D120A93AD2CAFFFFFFFFFFFFFFFF", 16)
+	srpG    = big.NewInt(5)
+)
+
+const srpUsername = "Pair-Setup"
+
+// srpHash returns the SHA-512 hash of the concatenated values
+func srpHash(vals ...[]byte) []byte {
+	h := sha512.New()
+	for _, v := range vals {
+		h.Write(v)
+	}
+	return h.Sum(nil)
+}
+
+// pad left pads i to the length of N
+func pad(i *big.Int) []byte {
+	return i.FillBytes(make([]byte, (srpN.BitLen()+7)/8))
+}
+
+// srpServer is the accessory side of the SRP-6a exchange using SHA-512
+type srpServer struct {
+	salt []byte
+	v, b *big.Int
+	B    *big.Int
+	key  []byte // session key K
+	m1   []byte
+}
+
+// newSRPServer creates the verifier for the setup code and the server public key
+func newSRPServer(code string) (*srpServer, error) {
+	s := &srpServer{salt: make([]byte, 16)}
+	if _, err := rand.Read(s.salt); err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	s.b = new(big.Int).SetBytes(b)
+
+	x := new(big.Int).SetBytes(srpHash(s.salt, srpHash([]byte(srpUsername+":"+code))))
+	s.v = new(big.Int).Exp(srpG, x, srpN)
+
+	// B = k*v + g^b
+	k := new(big.Int).SetBytes(srpHash(srpN.Bytes(), pad(srpG)))
+	s.B = new(big.Int).Mul(k, s.v)
+	s.B.Add(s.B, new(big.Int).Exp(srpG, s.b, srpN))
+	s.B.Mod(s.B, srpN)
+
+	return s, nil
+}
+
+// publicKey returns the server public key B
+func (s *srpServer) publicKey() []byte {
+	return pad(s.B)
+}
+
+// verify checks the client proof and returns the server proof
+func (s *srpServer) verify(a, m1 []byte) ([]byte, error) {
+	A := new(big.Int).SetBytes(a)
+	if A.Sign() <= 0 || A.Cmp(srpN) >= 0 {
+		return nil, errors.New("invalid public key")
+	}
+
+	u := new(big.Int).SetBytes(srpHash(pad(A), pad(s.B)))
+
+	// S = (A * v^u) ^ b
+	S := new(big.Int).Exp(s.v, u, srpN)
+	S.Mul(S, A)
+	S.Exp(S, s.b, srpN)
+
+	key := srpHash(pad(S))
+
+	hn, hg := srpHash(srpN.Bytes()), srpHash(srpG.Bytes())
+	for i := range hn {
+		hn[i] ^= hg[i]
+	}
+
+	expected := srpHash(hn, srpHash([]byte(srpUsername)), s.salt, pad(A), pad(s.B), key)
+	if subtle.ConstantTimeCompare(expected, m1) != 1 {
+		return nil, errors.New("invalid proof")
+	}
+
+	s.key = key
+	s.m1 = m1
+
+	return srpHash(pad(A), m1, key), nil
+}
@@ -0,0 +1,126 @@
+package homekit
+
+import (
+	"bytes"
+	"errors"
+)
+
+// TLV8 item types
+const (
+	tlvMethod        = 0x00
+	tlvIdentifier    = 0x01
+	tlvSalt          = 0x02
+	tlvPublicKey     = 0x03
+	tlvProof         = 0x04
+	tlvEncryptedData = 0x05
+	tlvState         = 0x06
+	tlvError         = 0x07
+	tlvRetryDelay    = 0x08
+	tlvSignature     = 0x0a
+	tlvPermissions   = 0x0b
+	tlvSeparator     = 0xff
+)
+
+// TLV8 error codes
+const (
+	tlvErrorAuthentication = 0x02
+	tlvErrorBackoff        = 0x03
+	tlvErrorMaxPeers       = 0x04
+	tlvErrorMaxTries       = 0x05
+	tlvErrorUnavailable    = 0x06
+	tlvErrorBusy           = 0x07
+)
+
+// pairing methods
+const (
+	methodPairSetup          = 0x00
+	methodAddPairing         = 0x03
+	methodRemovePairing      = 0x04
+	methodListPairings       = 0x05
+	permissionAdmin     byte = 0x01
+)
+
+// tlvItem is a single TLV8 item
+type tlvItem struct {
+	typ byte
+	val []byte
+}
+
+// tlv is an ordered list of TLV8 items
+type tlv []tlvItem
+
+// add appends an item
+func (t *tlv) add(typ byte, val ...byte) {
+	*t = append(*t, tlvItem{typ, val})
+}
+
+// get returns the value of the first item of type typ
+func (t tlv) get(typ byte) []byte {
+	for _, i := range t {
+		if i.typ == typ {
+			return i.val
+		}
+	}
+	return nil
+}
+
+// byte returns the first byte of the item of type typ
+func (t tlv) byte(typ byte) (byte, bool) {
+	if v := t.get(typ); len(v) > 0 {
+		return v[0], true
+	}
+	return 0, false
+}
+
+// encode encodes the items, splitting values longer than 255 bytes into fragments
+func (t tlv) encode() []byte {
+	var b bytes.Buffer
+
+	for i, item := range t {
+		// consecutive items of same type must be separated
+		if i > 0 && t[i-1].typ == item.typ && item.typ != tlvSeparator {
+			b.Write([]byte{tlvSeparator, 0})
+		}
+
+		val := item.val
+		for {
+			n := min(len(val), 255)
+			b.WriteByte(item.typ)
+			b.WriteByte(byte(n))
+			b.Write(val[:n])
+
+			if val = val[n:]; len(val) == 0 {
+				break
+			}
+		}
+	}
+
+	return b.Bytes()
+}
+
+// decodeTLV decodes TLV8 data, merging fragmented values
+func decodeTLV(b []byte) (tlv, error) {
+	var res tlv
+	fragment := false
+
+	for len(b) > 0 {
+		if len(b) < 2 || len(b) < 2+int(b[1]) {
+			return nil, errors.New("invalid tlv8")
+		}
+
+		typ, n := b[0], int(b[1])
+		val := b[2 : 2+n]
+		b = b[2+n:]
+
+		if fragment && res[len(res)-1].typ == typ {
+			res[len(res)-1].val = append(res[len(res)-1].val, val...)
+		} else {
+			res = append(res, tlvItem{typ, append([]byte{}, val...)})
+		}
+
+		// max length items continue in the next item of same type
+		fragment = n == 255
+	}
+
+	return res, nil
+}
@@ -1,7 +1,9 @@
 package server
 
 import (
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"time"
 
@@ -33,6 +35,34 @@ func NewHTTPd(addr string, hub *SocketHub) *HTTPd {
 	// websocket
 	router.HandleFunc("/ws", socketHandler(hub))
 
+	// server-sent events
+	router.HandleFunc("/api/events", hub.ServeEvents).Methods(http.MethodGet)
+
+	// static
+	registerStatic(router)
+
+	return newHTTPd(addr, router)
+}
+
+// newHTTPd creates the HTTP server for router
+func newHTTPd(addr string, router *mux.Router) *HTTPd {
+	srv := &HTTPd{
+		Server: &http.Server{
+			Addr:         addr,
+			Handler:      router,
+			ReadTimeout:  5 * time.Second,
+			WriteTimeout: 10 * time.Second,
+			IdleTimeout:  120 * time.Second,
+			ErrorLog:     log.ERROR,
+		},
+	}
+	srv.SetKeepAlivesEnabled(true)
+
+	return srv
+}
+
+// registerStatic adds the ui handlers
+func registerStatic(router *mux.Router) {
 	// static - individual handlers per root and folders
 	static := router.PathPrefix("/").Subrouter()
 	static.Use(handlers.CompressHandler)
@@ -54,20 +84,6 @@ func NewHTTPd(addr string, hub *SocketHub) *HTTPd {
 		static.PathPrefix("/" + dir).Handler(http.FileServer(http.FS(assets.Web)))
 	}
 	static.PathPrefix("/i18n").Handler(http.StripPrefix("/i18n", http.FileServer(http.FS(assets.I18n))))
-
-	srv := &HTTPd{
-		Server: &http.Server{
-			Addr:         addr,
-			Handler:      router,
-			ReadTimeout:  5 * time.Second,
-			WriteTimeout: 10 * time.Second,
-			IdleTimeout:  120 * time.Second,
-			ErrorLog:     log.ERROR,
-		},
-	}
-	srv.SetKeepAlivesEnabled(true)
-
-	return srv
 }
 
 // Router returns the main router
@@ -86,10 +102,13 @@ func (s *HTTPd) RegisterSiteHandlers(site site.API, cache *util.Cache) {
 	api.Use(handlers.CORS(
 		handlers.AllowedHeaders([]string{"Content-Type"}),
 	))
+	api.Use(auditHandler)
 
 	// site api
 	routes := map[string]route{
 		"health":                  {[]string{"GET"}, "/health", healthHandler(site)},
+		"timing":                  {[]string{"GET"}, "/health/timing", timingHandler(site)},
+		"providermetrics":         {[]string{"GET"}, "/metrics/providers", providerMetricsHandler},
 		"state":                   {[]string{"GET"}, "/state", stateHandler(cache)},
 		"config":                  {[]string{"GET"}, "/config/templates/{class:[a-z]+}", templatesHandler},
 		"products":                {[]string{"GET"}, "/config/products/{class:[a-z]+}", productsHandler},
@@ -104,18 +123,40 @@ func (s *HTTPd) RegisterSiteHandlers(site site.API, cache *util.Cache) {
 		"deletedevice":            {[]string{"DELETE", "OPTIONS"}, "/config/devices/{class:[a-z]+}/{id:[0-9.]+}", deleteDeviceHandler},
 		"testconfig":              {[]string{"POST", "OPTIONS"}, "/config/test/{class:[a-z]+}", testConfigHandler},
 		"testmerged":              {[]string{"POST", "OPTIONS"}, "/config/test/{class:[a-z]+}/merge/{id:[0-9.]+}", testConfigHandler},
+		"toutariff":               {[]string{"GET"}, "/config/tariffs/{name:grid|feedin}", touTariffHandler},
+		"updatetoutariff":         {[]string{"PUT", "OPTIONS"}, "/config/tariffs/{name:grid|feedin}", updateTouTariffHandler},
+		"previewtoutariff":        {[]string{"POST", "OPTIONS"}, "/config/tariffs/preview", previewTouTariffHandler},
 		"buffersoc":               {[]string{"POST", "OPTIONS"}, "/buffersoc/{value:[0-9.]+}", floatHandler(site.SetBufferSoc, site.GetBufferSoc)},
 		"bufferstartsoc":          {[]string{"POST", "OPTIONS"}, "/bufferstartsoc/{value:[0-9.]+}", floatHandler(site.SetBufferStartSoc, site.GetBufferStartSoc)},
 		"batterydischargecontrol": {[]string{"POST", "OPTIONS"}, "/batterydischargecontrol/{value:[a-z]+}", boolHandler(site.SetBatteryDischargeControl, site.GetBatteryDischargeControl)},
 		"prioritysoc":             {[]string{"POST", "OPTIONS"}, "/prioritysoc/{value:[0-9.]+}", floatHandler(site.SetPrioritySoc, site.GetPrioritySoc)},
 		"residualpower":           {[]string{"POST", "OPTIONS"}, "/residualpower/{value:[-0-9.]+}", floatHandler(site.SetResidualPower, site.GetResidualPower)},
+		"referenceprice":          {[]string{"POST", "OPTIONS"}, "/referenceprice/{value:[0-9.]+}", floatHandler(site.SetReferencePrice, site.GetReferencePrice)},
 		"smartcost":               {[]string{"POST", "OPTIONS"}, "/smartcostlimit/{value:[-0-9.]+}", updateSmartCostLimit(site)},
 		"tariff":                  {[]string{"GET"}, "/tariff/{tariff:[a-z]+}", tariffHandler(site)},
+		"circuits":                {[]string{"GET"}, "/circuits", circuitsHandler(site)},
 		"sessions":                {[]string{"GET"}, "/sessions", sessionHandler},
+		"history":                 {[]string{"GET"}, "/history/flows", historyHandler},
+		"balance":                 {[]string{"GET"}, "/history/balance", balanceHandler},
+		"audit":                   {[]string{"GET"}, "/audit", auditLogHandler},
+		"statistics":              {[]string{"GET"}, "/statistics", statisticsHandler},
+		"userreport":              {[]string{"GET"}, "/sessions/users", userReportHandler},
+		"tariffreport":            {[]string{"GET"}, "/sessions/tariffs", tariffReportHandler},
+		"vehiclereport":           {[]string{"GET"}, "/sessions/vehicles", vehicleReportHandler},
+		"vehiclehealth":           {[]string{"GET"}, "/sessions/health", vehicleHealthHandler(site)},
+		"plancalendar":            {[]string{"GET"}, "/plan.ics", planCalendarHandler(site)},
+		"users":                   {[]string{"GET"}, "/users", usersHandler},
 		"updatesession":           {[]string{"PUT", "OPTIONS"}, "/session/{id:[0-9]+}", updateSessionHandler},
 		"deletesession":           {[]string{"DELETE", "OPTIONS"}, "/session/{id:[0-9]+}", deleteSessionHandler},
+		"importsessions":          {[]string{"POST", "OPTIONS"}, "/sessions/import", importSessionHandler},
+		"fleet":                   {[]string{"GET"}, "/fleet", fleetHandler(site)},
+		"reservation":             {[]string{"POST", "OPTIONS"}, "/fleet/reservations", reservationHandler(site)},
+		"reservation2":            {[]string{"DELETE", "OPTIONS"}, "/fleet/reservations/{id:[0-9]+}", reservationRemoveHandler(site)},
+		"queue":                   {[]string{"POST", "OPTIONS"}, "/fleet/queue", queueHandler(site)},
+		"queue2":                  {[]string{"DELETE", "OPTIONS"}, "/fleet/queue/{name:[a-zA-Z0-9_.:-]+}", queueRemoveHandler(site)},
 		"telemetry":               {[]string{"GET"}, "/settings/telemetry", boolGetHandler(telemetry.Enabled)},
 		"telemetry2":              {[]string{"POST", "OPTIONS"}, "/settings/telemetry/{value:[a-z]+}", boolHandler(telemetry.Enable, telemetry.Enabled)},
+		"webhook":                 {[]string{"POST"}, "/webhook/{id:[a-zA-Z0-9_-]+}", webhookHandler},
 	}
 
 	for _, r := range routes {
@@ -124,10 +165,13 @@ func (s *HTTPd) RegisterSiteHandlers(site site.API, cache *util.Cache) {
 
 	// vehicle api
 	vehicles := map[string]route{
-		"minsoc":   {[]string{"POST", "OPTIONS"}, "/vehicles/{name:[a-zA-Z0-9_.:-]+}/minsoc/{value:[0-9]+}", minSocHandler(site)},
-		"limitsoc": {[]string{"POST", "OPTIONS"}, "/vehicles/{name:[a-zA-Z0-9_.:-]+}/limitsoc/{value:[0-9]+}", limitSocHandler(site)},
-		"plan":     {[]string{"POST", "OPTIONS"}, "/vehicles/{name:[a-zA-Z0-9_.:-]+}/plan/soc/{value:[0-9]+}/{time:[0-9TZ:.-]+}", planSocHandler(site)},
-		"plan2":    {[]string{"DELETE", "OPTIONS"}, "/vehicles/{name:[a-zA-Z0-9_.:-]+}/plan/soc", planSocRemoveHandler(site)},
+		"minsoc":       {[]string{"POST", "OPTIONS"}, "/vehicles/{name:[a-zA-Z0-9_.:-]+}/minsoc/{value:[0-9]+}", minSocHandler(site)},
+		"limitsoc":     {[]string{"POST", "OPTIONS"}, "/vehicles/{name:[a-zA-Z0-9_.:-]+}/limitsoc/{value:[0-9]+}", limitSocHandler(site)},
+		"triplimitsoc": {[]string{"POST", "OPTIONS"}, "/vehicles/{name:[a-zA-Z0-9_.:-]+}/triplimitsoc/{value:[0-9]+}", tripLimitSocHandler(site)},
+		"plan":         {[]string{"POST", "OPTIONS"}, "/vehicles/{name:[a-zA-Z0-9_.:-]+}/plan/soc/{value:[0-9]+}/{time:[0-9TZ:.-]+}", planSocHandler(site)},
+		"plan2":        {[]string{"DELETE", "OPTIONS"}, "/vehicles/{name:[a-zA-Z0-9_.:-]+}/plan/soc", planSocRemoveHandler(site)},
+		"plan3":        {[]string{"GET"}, "/vehicles/{name:[a-zA-Z0-9_.:-]+}/plan.ics", planCalendarHandler(site)},
+		"plan4":        {[]string{"POST", "OPTIONS"}, "/vehicles/{name:[a-zA-Z0-9_.:-]+}/plan/precondition/{value:[0-9]+}", planPreconditionHandler(site)},
 
 		// config ui
 		// "mode":     {[]string{"POST", "OPTIONS"}, "/mode/{value:[a-z]+}", chargeModeHandler(v)},
@@ -145,23 +189,38 @@ func (s *HTTPd) RegisterSiteHandlers(site site.API, cache *util.Cache) {
 		api := api.PathPrefix(fmt.Sprintf("/loadpoints/%d", id+1)).Subrouter()
 
 		routes := map[string]route{
-			"mode":             {[]string{"POST", "OPTIONS"}, "/mode/{value:[a-z]+}", handler(eapi.ChargeModeString, pass(lp.SetMode), lp.GetMode)},
-			"limitsoc":         {[]string{"POST", "OPTIONS"}, "/limitsoc/{value:[0-9]+}", intHandler(pass(lp.SetLimitSoc), lp.GetLimitSoc)},
-			"limitenergy":      {[]string{"POST", "OPTIONS"}, "/limitenergy/{value:[0-9.]+}", floatHandler(pass(lp.SetLimitEnergy), lp.GetLimitEnergy)},
-			"mincurrent":       {[]string{"POST", "OPTIONS"}, "/mincurrent/{value:[0-9.]+}", floatHandler(lp.SetMinCurrent, lp.GetMinCurrent)},
-			"maxcurrent":       {[]string{"POST", "OPTIONS"}, "/maxcurrent/{value:[0-9.]+}", floatHandler(lp.SetMaxCurrent, lp.GetMaxCurrent)},
-			"phases":           {[]string{"POST", "OPTIONS"}, "/phases/{value:[0-9]+}", intHandler(lp.SetPhases, lp.GetPhases)},
-			"plan":             {[]string{"GET"}, "/plan", planHandler(lp)},
-			"planpreview":      {[]string{"GET"}, "/plan/preview/{type:(?:soc|energy)}/{value:[0-9.]+}/{time:[0-9TZ:.-]+}", planPreviewHandler(lp)},
-			"planenergy":       {[]string{"POST", "OPTIONS"}, "/plan/energy/{value:[0-9.]+}/{time:[0-9TZ:.-]+}", planEnergyHandler(lp)},
-			"planenergy2":      {[]string{"DELETE", "OPTIONS"}, "/plan/energy", planRemoveHandler(lp)},
-			"vehicle":          {[]string{"POST", "OPTIONS"}, "/vehicle/{name:[a-zA-Z0-9_.:-]+}", vehicleSelectHandler(site, lp)},
-			"vehicle2":         {[]string{"DELETE", "OPTIONS"}, "/vehicle", vehicleRemoveHandler(lp)},
-			"vehicleDetect":    {[]string{"PATCH", "OPTIONS"}, "/vehicle", vehicleDetectHandler(lp)},
-			"remotedemand":     {[]string{"POST", "OPTIONS"}, "/remotedemand/{demand:[a-z]+}/{source:[0-9a-zA-Z_-]+}", remoteDemandHandler(lp)},
-			"enableThreshold":  {[]string{"POST", "OPTIONS"}, "/enable/threshold/{value:-?[0-9.]+}", floatHandler(pass(lp.SetEnableThreshold), lp.GetEnableThreshold)},
-			"disableThreshold": {[]string{"POST", "OPTIONS"}, "/disable/threshold/{value:-?[0-9.]+}", floatHandler(pass(lp.SetDisableThreshold), lp.GetDisableThreshold)},
-			"smartCostLimit":   {[]string{"POST", "OPTIONS"}, "/smartcostlimit/{value:[0-9.]+}", floatHandler(pass(lp.SetSmartCostLimit), lp.GetSmartCostLimit)},
+			"mode":                {[]string{"POST", "OPTIONS"}, "/mode/{value:[a-z]+}", handler(eapi.ChargeModeString, pass(lp.SetMode), lp.GetMode)},
+			"limitsoc":            {[]string{"POST", "OPTIONS"}, "/limitsoc/{value:[0-9]+}", intHandler(pass(lp.SetLimitSoc), lp.GetLimitSoc)},
+			"limitenergy":         {[]string{"POST", "OPTIONS"}, "/limitenergy/{value:[0-9.]+}", floatHandler(pass(lp.SetLimitEnergy), lp.GetLimitEnergy)},
+			"mincurrent":          {[]string{"POST", "OPTIONS"}, "/mincurrent/{value:[0-9.]+}", floatHandler(lp.SetMinCurrent, lp.GetMinCurrent)},
+			"maxcurrent":          {[]string{"POST", "OPTIONS"}, "/maxcurrent/{value:[0-9.]+}", floatHandler(lp.SetMaxCurrent, lp.GetMaxCurrent)},
+			"phases":              {[]string{"POST", "OPTIONS"}, "/phases/{value:[0-9]+}", intHandler(lp.SetPhases, lp.GetPhases)},
+			"plan":                {[]string{"GET"}, "/plan", planHandler(lp)},
+			"planexplain":         {[]string{"GET"}, "/plan/explain", planExplainHandler(lp)},
+			"planpreview":         {[]string{"GET"}, "/plan/preview/{type:(?:soc|energy)}/{value:[0-9.]+}/{time:[0-9TZ:.-]+}", planPreviewHandler(lp)},
+			"planenergy":          {[]string{"POST", "OPTIONS"}, "/plan/energy/{value:[0-9.]+}/{time:[0-9TZ:.-]+}", planEnergyHandler(lp)},
+			"planenergy2":         {[]string{"DELETE", "OPTIONS"}, "/plan/energy", planRemoveHandler(lp)},
+			"vehicle":             {[]string{"POST", "OPTIONS"}, "/vehicle/{name:[a-zA-Z0-9_.:-]+}", vehicleSelectHandler(site, lp)},
+			"vehicle2":            {[]string{"DELETE", "OPTIONS"}, "/vehicle", vehicleRemoveHandler(lp)},
+			"vehicleDetect":       {[]string{"PATCH", "OPTIONS"}, "/vehicle", vehicleDetectHandler(lp)},
+			"vehiclesoc":          {[]string{"POST", "OPTIONS"}, "/vehiclesoc/{value:[0-9.]+}", floatHandler(lp.SetVehicleSoc, lp.GetVehicleSoc)},
+			"remotedemand":        {[]string{"POST", "OPTIONS"}, "/remotedemand/{demand:[a-z]+}/{source:[0-9a-zA-Z_-]+}", remoteDemandHandler(lp)},
+			"lock":                {[]string{"POST", "OPTIONS"}, "/lock", socketLockHandler(lp.LockSocket)},
+			"unlock":              {[]string{"POST", "OPTIONS"}, "/unlock", socketLockHandler(lp.UnlockSocket)},
+			"guest":               {[]string{"POST", "OPTIONS"}, "/guest/{energy:[0-9.]+}/{cost:[0-9.]+}", guestSessionHandler(lp)},
+			"guest2":              {[]string{"DELETE", "OPTIONS"}, "/guest", guestSessionRemoveHandler(lp)},
+			"boost":               {[]string{"POST", "OPTIONS"}, "/boost/{energy:[0-9.]+}/{minutes:[0-9]+}", boostHandler(lp)},
+			"boost2":              {[]string{"DELETE", "OPTIONS"}, "/boost", boostRemoveHandler(lp)},
+			"enableThreshold":     {[]string{"POST", "OPTIONS"}, "/enable/threshold/{value:-?[0-9.]+}", floatHandler(pass(lp.SetEnableThreshold), lp.GetEnableThreshold)},
+			"disableThreshold":    {[]string{"POST", "OPTIONS"}, "/disable/threshold/{value:-?[0-9.]+}", floatHandler(pass(lp.SetDisableThreshold), lp.GetDisableThreshold)},
+			"enableDelay":         {[]string{"POST", "OPTIONS"}, "/enable/delay/{value:[0-9]+}", durationHandler(pass(lp.SetEnableDelay), lp.GetEnableDelay)},
+			"disableDelay":        {[]string{"POST", "OPTIONS"}, "/disable/delay/{value:[0-9]+}", durationHandler(pass(lp.SetDisableDelay), lp.GetDisableDelay)},
+			"rampRate":            {[]string{"POST", "OPTIONS"}, "/ramprate/{value:[0-9.]+}", floatHandler(lp.SetRampRate, lp.GetRampRate)},
+			"smartCostLimit":      {[]string{"POST", "OPTIONS"}, "/smartcostlimit/{value:[0-9.]+}", floatHandler(pass(lp.SetSmartCostLimit), lp.GetSmartCostLimit)},
+			"externalPower":       {[]string{"POST", "OPTIONS"}, "/external/power/{value:[0-9.]+}", floatHandler(pass(lp.SetExternalPower), lp.GetExternalPower)},
+			"externalCurrent":     {[]string{"POST", "OPTIONS"}, "/external/current/{value:[0-9.]+}", floatHandler(pass(lp.SetExternalCurrent), lp.GetExternalCurrent)},
+			"smartCostPercentile": {[]string{"POST", "OPTIONS"}, "/smartcostpercentile/{value:[0-9.]+}", floatHandler(pass(lp.SetSmartCostPercentile), lp.GetSmartCostPercentile)},
+			"strictPV":            {[]string{"POST", "OPTIONS"}, "/strictpv/{value:[a-z]+}", boolHandler(pass(lp.SetStrictPV), lp.GetStrictPV)},
 			// "priority":         {[]string{"POST", "OPTIONS"}, "/priority/{value:[0-9.]+}", floatHandler(pass(lp.SetPriority), lp.GetPriority)},
 		}
 
@@ -171,6 +230,33 @@ func (s *HTTPd) RegisterSiteHandlers(site site.API, cache *util.Cache) {
 	}
 }
 
+// RegisterProfileHandlers exposes the pprof profiles registered with the default mux for requests carrying the token.
+// Without token, profiles are only available from localhost.
+func (s *HTTPd) RegisterProfileHandlers(token string) {
+	router := s.Server.Handler.(*mux.Router)
+
+	debug := router.PathPrefix("/debug/").Subrouter()
+	if token != "" {
+		debug.Use(publicAuth(token))
+	} else {
+		debug.Use(localhostOnly)
+	}
+	debug.PathPrefix("/").Handler(http.DefaultServeMux)
+}
+
+// localhostOnly rejects requests not originating from the loopback interface
+func localhostOnly(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if ip := net.ParseIP(host); err != nil || ip == nil || !ip.IsLoopback() {
+			jsonError(w, http.StatusForbidden, errors.New("profiles only available from localhost without profile token"))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
 // RegisterShutdownHandler connects the http handlers to the site
 func (s *HTTPd) RegisterShutdownHandler(callback func()) {
 	router := s.Server.Handler.(*mux.Router)
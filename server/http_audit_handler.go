@@ -0,0 +1,99 @@
+package server
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/evcc-io/evcc/core/audit"
+	"github.com/evcc-io/evcc/server/db"
+)
+
+// auditResponseWriter captures the response status
+type auditResponseWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *auditResponseWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// auditHandler records api calls changing state. Request bodies are not recorded since they may contain credentials.
+func auditHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		default:
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		aw := &auditResponseWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(aw, r)
+
+		// requests issued by the ui are same-origin browser requests
+		source := audit.SourceAPI
+		if r.Header.Get("Sec-Fetch-Site") == "same-origin" {
+			source = audit.SourceUI
+		}
+
+		client := r.RemoteAddr
+		if host, _, err := net.SplitHostPort(client); err == nil {
+			client = host
+		}
+
+		e := audit.Entry{
+			Source: source,
+			Client: client,
+			Action: r.Method + " " + r.URL.Path,
+		}
+
+		if aw.status >= http.StatusBadRequest {
+			e.Error = http.StatusText(aw.status)
+		}
+
+		audit.Record(e)
+	})
+}
+
+// auditLogHandler returns the audit log of the last days, 7 by default
+func auditLogHandler(w http.ResponseWriter, r *http.Request) {
+	if db.Instance == nil {
+		jsonError(w, http.StatusBadRequest, errors.New("database offline"))
+		return
+	}
+
+	q := r.URL.Query()
+
+	days := 7
+	if s := q.Get("days"); s != "" {
+		var err error
+		if days, err = strconv.Atoi(s); err != nil || days <= 0 {
+			jsonError(w, http.StatusBadRequest, errors.New("invalid days"))
+			return
+		}
+	}
+
+	limit := 1000
+	if s := q.Get("limit"); s != "" {
+		var err error
+		if limit, err = strconv.Atoi(s); err != nil || limit <= 0 {
+			jsonError(w, http.StatusBadRequest, errors.New("invalid limit"))
+			return
+		}
+	}
+
+	from := time.Now().Add(-min(time.Duration(days)*24*time.Hour, audit.Retention))
+
+	res, err := audit.Entries(from, q.Get("source"), limit)
+	if err != nil {
+		jsonError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonResult(w, res)
+}
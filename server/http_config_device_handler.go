@@ -177,7 +177,7 @@ func deviceStatusHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 func newDevice[T any](class templates.Class, req map[string]any, newFromConf func(string, map[string]any) (T, error), h config.Handler[T]) (*config.Config, error) {
-	instance, err := newFromConf(typeTemplate, req)
+	instance, err := newFromResolvedConf(newFromConf, req)
 	if err != nil {
 		return nil, err
 	}
@@ -367,7 +367,7 @@ func deleteDeviceHandler(w http.ResponseWriter, r *http.Request) {
 
 func testConfig[T any](id int, class templates.Class, conf map[string]any, newFromConf func(string, map[string]any) (T, error), h config.Handler[T]) (T, error) {
 	if id == 0 {
-		return newFromConf(typeTemplate, conf)
+		return newFromResolvedConf(newFromConf, conf)
 	}
 
 	_, instance, _, err := deviceInstanceFromMergedConfig(id, class, conf, newFromConf, h)
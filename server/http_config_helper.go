@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/config"
 	"github.com/evcc-io/evcc/util/templates"
 )
@@ -56,7 +57,8 @@ func sanitizeMasked(class templates.Class, conf map[string]any) (map[string]any,
 	res := make(map[string]any, len(conf))
 
 	for k, v := range conf {
-		if i, p := tmpl.ParamByName(k); i >= 0 && p.IsMasked() {
+		// secret references are not secret
+		if i, p := tmpl.ParamByName(k); i >= 0 && p.IsMasked() && !util.IsSecretRef(v) {
 			v = masked
 		}
 
@@ -98,11 +100,23 @@ func deviceInstanceFromMergedConfig[T any](id int, class templates.Class, conf m
 		return nil, zero, nil, err
 	}
 
-	instance, err := newFromConf(typeTemplate, merged)
+	instance, err := newFromResolvedConf(newFromConf, merged)
 
 	return dev, instance, merged, err
 }
 
+// newFromResolvedConf creates a device instance with environment and file references resolved.
+// The configuration is stored with unresolved references.
+func newFromResolvedConf[T any](newFromConf func(string, map[string]any) (T, error), conf map[string]any) (T, error) {
+	resolved, err := util.ResolveSecretsMap(conf)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return newFromConf(typeTemplate, resolved)
+}
+
 type testResult = struct {
 	Value any    `json:"value"`
 	Error string `json:"error"`
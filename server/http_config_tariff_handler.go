@@ -0,0 +1,77 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/evcc-io/evcc/tariff"
+	"github.com/gorilla/mux"
+)
+
+// touTariffHandler returns the time-of-use tariff configured in the ui
+func touTariffHandler(w http.ResponseWriter, r *http.Request) {
+	var res tariff.TouConfig
+	if err := settings.Json(tariff.TouSettingsKey(mux.Vars(r)["name"]), &res); err != nil && !errors.Is(err, settings.ErrNotFound) {
+		jsonError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonResult(w, res)
+}
+
+// updateTouTariffHandler validates and stores the time-of-use tariff. An empty body removes the tariff.
+func updateTouTariffHandler(w http.ResponseWriter, r *http.Request) {
+	key := tariff.TouSettingsKey(mux.Vars(r)["name"])
+
+	if r.ContentLength == 0 {
+		settings.SetString(key, "")
+		setConfigDirty()
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	var conf tariff.TouConfig
+	if err := json.NewDecoder(r.Body).Decode(&conf); err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if _, err := tariff.NewTou(conf); err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := settings.SetJson(key, conf); err != nil {
+		jsonError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	setConfigDirty()
+
+	jsonResult(w, conf)
+}
+
+// previewTouTariffHandler returns the price curve of the next week for the time-of-use tariff
+func previewTouTariffHandler(w http.ResponseWriter, r *http.Request) {
+	var conf tariff.TouConfig
+	if err := json.NewDecoder(r.Body).Decode(&conf); err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	t, err := tariff.NewTou(conf)
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	rates, err := t.Rates()
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	jsonResult(w, rates)
+}
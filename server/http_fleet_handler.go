@@ -0,0 +1,99 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/evcc-io/evcc/core/fleet"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/gorilla/mux"
+)
+
+// fleetHandler returns reservations, queue and loadpoint assignments
+func fleetHandler(site site.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		state, assignments := site.GetFleet()
+
+		res := struct {
+			fleet.State
+			Assignments []fleet.Assignment `json:"assignments"`
+		}{
+			State:       state,
+			Assignments: assignments,
+		}
+
+		jsonResult(w, res)
+	}
+}
+
+// reservationHandler claims a loadpoint for a vehicle
+func reservationHandler(site site.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req fleet.Reservation
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		res, err := site.Reserve(req)
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		jsonResult(w, res)
+	}
+}
+
+// reservationRemoveHandler cancels a reservation
+func reservationRemoveHandler(site site.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		id, err := strconv.Atoi(vars["id"])
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		if err := site.CancelReservation(id); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		res := struct{}{}
+		jsonResult(w, res)
+	}
+}
+
+// queueHandler adds a vehicle waiting for a free loadpoint
+func queueHandler(site site.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req fleet.Request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		if err := site.Enqueue(req); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		res := struct{}{}
+		jsonResult(w, res)
+	}
+}
+
+// queueRemoveHandler removes a vehicle from the queue
+func queueRemoveHandler(site site.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		site.Dequeue(vars["name"])
+
+		res := struct{}{}
+		jsonResult(w, res)
+	}
+}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/evcc-io/evcc/core/history"
+	"github.com/evcc-io/evcc/server/db"
+)
+
+// historyHandler returns the power flow history of the last hours, 24 by default
+func historyHandler(w http.ResponseWriter, r *http.Request) {
+	if db.Instance == nil {
+		jsonError(w, http.StatusBadRequest, errors.New("database offline"))
+		return
+	}
+
+	hours := 24
+	if s := r.URL.Query().Get("hours"); s != "" {
+		var err error
+		if hours, err = strconv.Atoi(s); err != nil || hours <= 0 {
+			jsonError(w, http.StatusBadRequest, errors.New("invalid hours"))
+			return
+		}
+	}
+
+	from := time.Now().Add(-min(time.Duration(hours)*time.Hour, history.Retention))
+
+	res, err := history.Flows(db.Instance, from)
+	if err != nil {
+		jsonError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonResult(w, res)
+}
+
+// balanceHandler returns the energy balances of the last hours per bucket, e.g. for Sankey diagrams
+func balanceHandler(w http.ResponseWriter, r *http.Request) {
+	if db.Instance == nil {
+		jsonError(w, http.StatusBadRequest, errors.New("database offline"))
+		return
+	}
+
+	hours := 24
+	if s := r.URL.Query().Get("hours"); s != "" {
+		var err error
+		if hours, err = strconv.Atoi(s); err != nil || hours <= 0 {
+			jsonError(w, http.StatusBadRequest, errors.New("invalid hours"))
+			return
+		}
+	}
+
+	var bucket time.Duration
+	if s := r.URL.Query().Get("bucket"); s != "" {
+		var err error
+		if bucket, err = time.ParseDuration(s); err != nil || bucket < history.Interval {
+			jsonError(w, http.StatusBadRequest, errors.New("invalid bucket"))
+			return
+		}
+	}
+
+	from := time.Now().Add(-min(time.Duration(hours)*time.Hour, history.Retention))
+
+	flows, err := history.Flows(db.Instance, from)
+	if err != nil {
+		jsonError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonResult(w, history.Balances(flows, bucket))
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/planner"
 	"github.com/evcc-io/evcc/core/site"
 	"github.com/gorilla/mux"
 )
@@ -69,6 +70,36 @@ func planHandler(lp loadpoint.API) http.HandlerFunc {
 	}
 }
 
+// planExplainHandler returns the current plan and the planner's reasoning
+func planExplainHandler(lp loadpoint.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		maxPower := lp.EffectiveMaxPower()
+		planTime := lp.EffectivePlanTime()
+
+		goal, _ := lp.GetPlanGoal()
+		requiredDuration := lp.GetPlanRequiredDuration(goal, maxPower)
+		exp, err := lp.GetPlanExplanation(planTime, requiredDuration)
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		res := struct {
+			planner.Explanation
+			PlanTime time.Time `json:"planTime"`
+			Duration int64     `json:"duration"`
+			Power    float64   `json:"power"`
+		}{
+			Explanation: exp,
+			PlanTime:    planTime,
+			Duration:    int64(requiredDuration.Seconds()),
+			Power:       maxPower,
+		}
+
+		jsonResult(w, res)
+	}
+}
+
 // planPreviewHandler returns a plan preview for given parameters
 func planPreviewHandler(lp loadpoint.API) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -216,3 +247,93 @@ func vehicleDetectHandler(lp loadpoint.API) http.HandlerFunc {
 		jsonResult(w, res)
 	}
 }
+
+// socketLockHandler locks or releases the charger's socket lock
+func socketLockHandler(fun func() error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := fun(); err != nil {
+			status := http.StatusBadRequest
+			if errors.Is(err, api.ErrNotAvailable) {
+				status = http.StatusNotImplemented
+			}
+
+			jsonError(w, status, err)
+			return
+		}
+
+		res := struct{}{}
+		jsonResult(w, res)
+	}
+}
+
+// guestSessionHandler starts a guest session with energy (kWh) and cost cap, 0 for none
+func guestSessionHandler(lp loadpoint.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		energy, err := parseFloat(vars["energy"])
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		cost, err := parseFloat(vars["cost"])
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		if err := lp.StartGuestSession(energy, cost); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		jsonResult(w, lp.GetGuestSession())
+	}
+}
+
+// guestSessionRemoveHandler stops the guest session
+func guestSessionRemoveHandler(lp loadpoint.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		lp.StopGuestSession()
+
+		res := struct{}{}
+		jsonResult(w, res)
+	}
+}
+
+// boostHandler starts a boost with energy (kWh) and duration (minutes), 0 for none
+func boostHandler(lp loadpoint.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		energy, err := parseFloat(vars["energy"])
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		minutes, err := strconv.Atoi(vars["minutes"])
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		if err := lp.StartBoost(energy, minutes); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		jsonResult(w, lp.GetBoost())
+	}
+}
+
+// boostRemoveHandler stops the boost
+func boostRemoveHandler(lp loadpoint.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		lp.StopBoost()
+
+		res := struct{}{}
+		jsonResult(w, res)
+	}
+}
@@ -0,0 +1,128 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	ics "github.com/arran4/golang-ical"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/gorilla/mux"
+)
+
+// planSlot is a contiguous charging window of a plan
+type planSlot struct {
+	Start, End time.Time
+	Price      float64 // average price
+}
+
+// planSlots merges adjacent plan rates into contiguous charging windows
+func planSlots(plan api.Rates) []planSlot {
+	var res []planSlot
+
+	for _, r := range plan {
+		if !r.End.After(r.Start) {
+			continue
+		}
+
+		if n := len(res); n > 0 && res[n-1].End.Equal(r.Start) {
+			last := &res[n-1]
+			prev, dur := last.End.Sub(last.Start), r.End.Sub(r.Start)
+			last.Price = (last.Price*prev.Hours() + r.Price*dur.Hours()) / (prev + dur).Hours()
+			last.End = r.End
+			continue
+		}
+
+		res = append(res, planSlot{Start: r.Start, End: r.End, Price: r.Price})
+	}
+
+	return res
+}
+
+// vehicleName returns the name of the loadpoint's active vehicle
+func vehicleName(site site.API, lp loadpoint.API) (string, string) {
+	v := lp.GetVehicle()
+	if v == nil {
+		return "", ""
+	}
+
+	for _, vv := range site.Vehicles().Settings() {
+		if vv.Instance() == v {
+			return vv.Name(), v.Title()
+		}
+	}
+
+	return "", v.Title()
+}
+
+// planCalendar adds the loadpoint's charging plan to the calendar
+func planCalendar(cal *ics.Calendar, id int, lp loadpoint.API, name, title string, now time.Time) {
+	planTime := lp.EffectivePlanTime()
+	if planTime.IsZero() || planTime.Before(now) {
+		return
+	}
+
+	goal, isSoc := lp.GetPlanGoal()
+	requiredDuration := lp.GetPlanRequiredDuration(goal, lp.EffectiveMaxPower())
+
+	plan, err := lp.GetPlan(planTime, requiredDuration)
+	if err != nil {
+		return
+	}
+
+	unit := "kWh"
+	if isSoc {
+		unit = "%"
+	}
+
+	for _, slot := range planSlots(plan) {
+		if !slot.End.After(now) {
+			continue
+		}
+
+		ev := cal.AddEvent(fmt.Sprintf("plan-%d-%s-%d@evcc", id, name, slot.Start.Unix()))
+		ev.SetDtStampTime(now)
+		ev.SetStartAt(slot.Start)
+		ev.SetEndAt(slot.End)
+		ev.SetSummary(fmt.Sprintf("Charging %s", title))
+		ev.SetLocation(lp.Title())
+		ev.SetDescription(fmt.Sprintf("Goal: %.0f%s by %s\nAverage price: %.3f", goal, unit, planTime.Local().Format(time.DateTime), slot.Price))
+	}
+}
+
+// planCalendarHandler serves the computed charging plans as iCal feed, optionally restricted to a single vehicle.
+// Plans are only available while the vehicle is connected to a loadpoint.
+func planCalendarHandler(site site.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		filter := mux.Vars(r)["name"]
+		if filter != "" {
+			if _, err := site.Vehicles().ByName(filter); err != nil {
+				jsonError(w, http.StatusNotFound, err)
+				return
+			}
+		}
+
+		cal := ics.NewCalendar()
+		cal.SetMethod(ics.MethodPublish)
+		cal.SetProductId("-//evcc//charging plan//EN")
+		cal.SetXWRCalName("evcc charging plan")
+		cal.SetRefreshInterval("PT15M")
+
+		now := time.Now()
+
+		for id, lp := range site.Loadpoints() {
+			name, title := vehicleName(site, lp)
+			if title == "" || filter != "" && name != filter {
+				continue
+			}
+
+			planCalendar(cal, id+1, lp, name, title, now)
+		}
+
+		w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+		w.Header().Set("Content-Disposition", `inline; filename="plan.ics"`)
+		_ = cal.SerializeTo(w)
+	}
+}
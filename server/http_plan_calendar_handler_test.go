@@ -0,0 +1,27 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanSlots(t *testing.T) {
+	ts := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	rate := func(start, end int, price float64) api.Rate {
+		return api.Rate{
+			Start: ts.Add(time.Duration(start) * time.Hour),
+			End:   ts.Add(time.Duration(end) * time.Hour),
+			Price: price,
+		}
+	}
+
+	res := planSlots(api.Rates{rate(1, 2, 0.1), rate(2, 4, 0.4), rate(6, 7, 0.2)})
+
+	assert.Equal(t, []planSlot{
+		{Start: ts.Add(time.Hour), End: ts.Add(4 * time.Hour), Price: 0.3},
+		{Start: ts.Add(6 * time.Hour), End: ts.Add(7 * time.Hour), Price: 0.2},
+	}, res)
+}
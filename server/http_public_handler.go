@@ -0,0 +1,156 @@
+package server
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/util"
+	"github.com/gorilla/handlers"
+	"github.com/gorilla/mux"
+)
+
+// PublicConfig configures the read-only dashboard
+type PublicConfig struct {
+	Port  int    // separate port serving only the dashboard, e.g. for guest networks
+	Token string // access token, dashboard is unauthenticated if empty
+}
+
+// site values exposed by the dashboard
+var publicSiteKeys = map[string]bool{
+	keys.SiteTitle:         true,
+	keys.Currency:          true,
+	keys.GridConfigured:    true,
+	keys.GridPower:         true,
+	keys.PvConfigured:      true,
+	keys.PvPower:           true,
+	keys.HomePower:         true,
+	keys.BatteryConfigured: true,
+	keys.BatteryPower:      true,
+	keys.BatterySoc:        true,
+	keys.GreenShareHome:    true,
+	keys.TariffGrid:        true,
+}
+
+// loadpoint values exposed by the dashboard
+var publicLoadpointKeys = map[string]bool{
+	keys.Title:                   true,
+	keys.Mode:                    true,
+	keys.Connected:               true,
+	keys.Charging:                true,
+	keys.ChargePower:             true,
+	keys.ChargedEnergy:           true,
+	keys.ChargeDuration:          true,
+	keys.ChargeRemainingDuration: true,
+	keys.VehicleSoc:              true,
+	keys.EffectiveLimitSoc:       true,
+	keys.PhasesActive:            true,
+}
+
+// publicKey returns true if the websocket key is exposed by the dashboard
+func publicKey(key string) bool {
+	if lpKey, ok := strings.CutPrefix(key, "loadpoints."); ok {
+		_, lpKey, ok = strings.Cut(lpKey, ".")
+		return ok && publicLoadpointKeys[lpKey]
+	}
+	return publicSiteKeys[key]
+}
+
+// publicParam returns true if the param is exposed by the dashboard
+func publicParam(p util.Param) bool {
+	if p.Loadpoint != nil {
+		return publicLoadpointKeys[p.Key]
+	}
+	return publicSiteKeys[p.Key]
+}
+
+// publicAuth rejects requests without valid token
+func publicAuth(token string) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if token != "" {
+				t := r.URL.Query().Get("token")
+				if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+					t = bearer
+				}
+
+				if subtle.ConstantTimeCompare([]byte(t), []byte(token)) != 1 {
+					jsonError(w, http.StatusUnauthorized, errors.New("invalid token"))
+					return
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// publicStateHandler returns the dashboard values
+func publicStateHandler(cache *util.Cache) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		res := map[string]any{}
+		lps := make(map[int]map[string]any)
+
+		for _, p := range cache.All() {
+			if !publicParam(p) {
+				continue
+			}
+
+			if p.Loadpoint == nil {
+				res[p.Key] = p.Val
+				continue
+			}
+
+			lp, ok := lps[*p.Loadpoint]
+			if !ok {
+				lp = make(map[string]any)
+				lps[*p.Loadpoint] = lp
+			}
+			lp[p.Key] = p.Val
+		}
+
+		loadpoints := make([]map[string]any, len(lps))
+		for id, lp := range lps {
+			if id < len(loadpoints) {
+				loadpoints[id] = lp
+			}
+		}
+		res["loadpoints"] = loadpoints
+
+		encodeFloats(res)
+
+		jsonResult(w, res)
+	}
+}
+
+// registerPublic adds the read-only dashboard api
+func registerPublic(router *mux.Router, hub *SocketHub, cache *util.Cache, token string) {
+	public := router.PathPrefix("/api/public").Subrouter()
+	public.Use(publicAuth(token))
+	public.Use(handlers.CORS())
+
+	public.Methods(http.MethodGet).Path("/events").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hub.serveEvents(w, r, publicKey)
+	})
+
+	state := public.Methods(http.MethodGet).Subrouter()
+	state.Use(jsonHandler, handlers.CompressHandler)
+	state.Path("/state").Handler(publicStateHandler(cache))
+}
+
+// RegisterPublicHandlers adds the read-only dashboard api to the main server
+func (s *HTTPd) RegisterPublicHandlers(hub *SocketHub, cache *util.Cache, conf PublicConfig) {
+	registerPublic(s.Router(), hub, cache, conf.Token)
+}
+
+// NewPublicHTTPd creates a separate server exposing only the read-only dashboard
+func NewPublicHTTPd(addr string, hub *SocketHub, cache *util.Cache, conf PublicConfig) *HTTPd {
+	router := mux.NewRouter().StrictSlash(true)
+
+	registerPublic(router, hub, cache, conf.Token)
+	registerStatic(router)
+
+	return newHTTPd(addr, router)
+}
@@ -0,0 +1,84 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublicKey(t *testing.T) {
+	assert.True(t, publicKey("pvPower"))
+	assert.True(t, publicKey("loadpoints.1.chargePower"))
+	assert.False(t, publicKey("loadpoints.1.maxCurrent"))
+	assert.False(t, publicKey("loadpoints"))
+	assert.False(t, publicKey("vehicles"))
+}
+
+func TestPublicState(t *testing.T) {
+	lp := 0
+	cache := util.NewCache()
+	cache.Add("pvPower", util.Param{Key: "pvPower", Val: 1000.0})
+	cache.Add("residualPower", util.Param{Key: "residualPower", Val: 100.0})
+	cache.Add("lp1.chargePower", util.Param{Loadpoint: &lp, Key: "chargePower", Val: 2000.0})
+	cache.Add("lp1.maxCurrent", util.Param{Loadpoint: &lp, Key: "maxCurrent", Val: 16.0})
+
+	router := mux.NewRouter()
+	registerPublic(router, NewSocketHub(), cache, "secret")
+
+	req := httptest.NewRequest(http.MethodGet, "/api/public/state", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/public/state?token=secret", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var res struct {
+		Result map[string]any
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+
+	assert.Equal(t, map[string]any{
+		"pvPower":    1000.0,
+		"loadpoints": []any{map[string]any{"chargePower": 2000.0}},
+	}, res.Result)
+}
+
+func TestProfileLocalhost(t *testing.T) {
+	s := &HTTPd{Server: &http.Server{Handler: mux.NewRouter()}}
+	s.RegisterProfileHandlers("")
+
+	req := httptest.NewRequest(http.MethodGet, "/debug/pprof/", nil)
+	req.RemoteAddr = "192.0.2.1:1234"
+	w := httptest.NewRecorder()
+	s.Handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	req.RemoteAddr = "127.0.0.1:1234"
+	w = httptest.NewRecorder()
+	s.Handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestProfileAuth(t *testing.T) {
+	s := &HTTPd{Server: &http.Server{Handler: mux.NewRouter()}}
+	s.RegisterProfileHandlers("secret")
+
+	req := httptest.NewRequest(http.MethodGet, "/debug/pprof/", nil)
+	w := httptest.NewRecorder()
+	s.Handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	req.Header.Set("Authorization", "Bearer secret")
+	w = httptest.NewRecorder()
+	s.Handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
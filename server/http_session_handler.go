@@ -11,6 +11,8 @@ import (
 
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/session"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/core/user"
 	"github.com/evcc-io/evcc/server/db"
 	"github.com/evcc-io/evcc/util/locale"
 	"github.com/gorilla/mux"
@@ -28,39 +30,83 @@ func csvResult(ctx context.Context, w http.ResponseWriter, res any, filename str
 	}
 }
 
-// sessionHandler returns the list of charging sessions
-func sessionHandler(w http.ResponseWriter, r *http.Request) {
+// sessionRepository returns the session repository or writes an error if the database is offline
+func sessionRepository(w http.ResponseWriter) (session.Repository, bool) {
 	if db.Instance == nil {
 		jsonError(w, http.StatusBadRequest, errors.New("database offline"))
-		return
+		return nil, false
 	}
 
+	return session.NewRepository(db.Instance), true
+}
+
+// querySessions returns the charging sessions filtered by tag, year and month query parameters
+func querySessions(repo session.Repository, r *http.Request) (session.Sessions, string, error) {
 	var (
-		res  session.Sessions
-		cond []string
-		args []any
+		filter   session.Filter
+		filename string
 	)
 
-	push := func(field, val string) {
-		cond = append(cond, field)
-		args = append(args, val)
+	if tag := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("tag"))); tag != "" {
+		filename += "-" + tag
+		filter.Tag = tag
 	}
 
-	filename := "session"
 	if year := r.URL.Query().Get("year"); year != "" {
 		filename += "-" + year
-		push("STRFTIME('%Y', created) LIKE ?", year)
+		filter.Year = year
 
 		if month := fmt.Sprintf("%02s", r.URL.Query().Get("month")); month != "00" {
 			filename += "-" + month
-			push("STRFTIME('%m', created) LIKE ?", month)
+			filter.Month = month
+		}
+	}
+
+	res, err := repo.Find(filter)
+
+	return res, filename, err
+}
+
+// addConsumption adds distance and consumption based on the odometer readings of all sessions
+func addConsumption(repo session.Repository, res session.Sessions) error {
+	all, err := repo.Odometers()
+	if err != nil {
+		return err
+	}
+
+	res.AddConsumption(all)
+
+	return nil
+}
+
+// requestLanguage returns the language from query or request header
+func requestLanguage(r *http.Request) string {
+	lang := r.URL.Query().Get("lang")
+	if lang == "" {
+		// get request language
+		lang = r.Header.Get("Accept-Language")
+		if tags, _, err := language.ParseAcceptLanguage(lang); err == nil && len(tags) > 0 {
+			lang = tags[0].String()
 		}
 	}
+	return lang
+}
+
+// sessionHandler returns the list of charging sessions
+func sessionHandler(w http.ResponseWriter, r *http.Request) {
+	repo, ok := sessionRepository(w)
+	if !ok {
+		return
+	}
 
-	// TODO support other databases than Sqlite
-	query := strings.Join(append([]string{"charged_kwh>=0.05"}, cond...), " AND ")
-	if txn := db.Instance.Where(query, args...).Order("created DESC").Find(&res); txn.Error != nil {
-		jsonError(w, http.StatusInternalServerError, txn.Error)
+	res, filename, err := querySessions(repo, r)
+	if err != nil {
+		jsonError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err := addConsumption(repo, res); err != nil {
+		jsonError(w, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -73,27 +119,123 @@ func sessionHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if r.URL.Query().Get("format") == "csv" {
-		lang := r.URL.Query().Get("lang")
-		if lang == "" {
-			// get request language
-			lang = r.Header.Get("Accept-Language")
-			if tags, _, err := language.ParseAcceptLanguage(lang); err == nil && len(tags) > 0 {
-				lang = tags[0].String()
-			}
-		}
+		ctx := context.WithValue(context.Background(), locale.Locale, requestLanguage(r))
+		csvResult(ctx, w, &res, "session"+filename)
+		return
+	}
+
+	jsonResult(w, res)
+}
+
+// userReportHandler returns charged energy and cost per user and month
+func userReportHandler(w http.ResponseWriter, r *http.Request) {
+	repo, ok := sessionRepository(w)
+	if !ok {
+		return
+	}
+
+	sessions, filename, err := querySessions(repo, r)
+	if err != nil {
+		jsonError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	res := sessions.UserReports()
+
+	if r.URL.Query().Get("format") == "csv" {
+		ctx := context.WithValue(context.Background(), locale.Locale, requestLanguage(r))
+		csvResult(ctx, w, &res, "users"+filename)
+		return
+	}
+
+	jsonResult(w, res)
+}
+
+// tariffReportHandler returns charged energy, price and co2 per session and tariff slot
+func tariffReportHandler(w http.ResponseWriter, r *http.Request) {
+	repo, ok := sessionRepository(w)
+	if !ok {
+		return
+	}
 
-		ctx := context.WithValue(context.Background(), locale.Locale, lang)
-		csvResult(ctx, w, &res, filename)
+	sessions, filename, err := querySessions(repo, r)
+	if err != nil {
+		jsonError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	res := sessions.TariffSlots()
+
+	if r.URL.Query().Get("format") == "csv" {
+		ctx := context.WithValue(context.Background(), locale.Locale, requestLanguage(r))
+		csvResult(ctx, w, &res, "tariffs"+filename)
 		return
 	}
 
 	jsonResult(w, res)
 }
 
+// vehicleReportHandler returns distance and consumption per vehicle and month
+func vehicleReportHandler(w http.ResponseWriter, r *http.Request) {
+	repo, ok := sessionRepository(w)
+	if !ok {
+		return
+	}
+
+	sessions, filename, err := querySessions(repo, r)
+	if err != nil {
+		jsonError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if err := addConsumption(repo, sessions); err != nil {
+		jsonError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	res := sessions.VehicleReports()
+
+	if r.URL.Query().Get("format") == "csv" {
+		ctx := context.WithValue(context.Background(), locale.Locale, requestLanguage(r))
+		csvResult(ctx, w, &res, "vehicles"+filename)
+		return
+	}
+
+	jsonResult(w, res)
+}
+
+// vehicleHealthHandler returns the estimated battery capacity trend per vehicle
+func vehicleHealthHandler(site site.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		repo, ok := sessionRepository(w)
+		if !ok {
+			return
+		}
+
+		sessions, err := repo.WithSoc()
+		if err != nil {
+			jsonError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		res := sessions.VehicleHealth()
+
+		for _, v := range site.Vehicles().Instances() {
+			for i := range res {
+				if res[i].Vehicle == v.Title() {
+					res[i].SetCapacity(v.Capacity())
+				}
+			}
+		}
+
+		jsonResult(w, res)
+	}
+}
+
 // deleteSessionHandler removes session in sessions table with given id
 func deleteSessionHandler(w http.ResponseWriter, r *http.Request) {
-	if db.Instance == nil {
-		jsonError(w, http.StatusBadRequest, errors.New("database offline"))
+	repo, ok := sessionRepository(w)
+	if !ok {
 		return
 	}
 
@@ -102,35 +244,107 @@ func deleteSessionHandler(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id := vars["id"]
 
-	if txn := db.Instance.Table("sessions").Delete(&res, id); txn.Error != nil {
-		jsonError(w, http.StatusBadRequest, txn.Error)
+	if err := repo.Delete(id); err != nil {
+		jsonError(w, http.StatusBadRequest, err)
 		return
 	}
 
 	jsonResult(w, res)
 }
 
+// importSessionHandler imports sessions from a csv export of another charging system
+func importSessionHandler(w http.ResponseWriter, r *http.Request) {
+	repo, ok := sessionRepository(w)
+	if !ok {
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "generic"
+	}
+
+	sessions, err := session.Import(r.Body, format, r.URL.Query().Get("loadpoint"))
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	added, err := repo.Import(sessions)
+	if err != nil {
+		jsonError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	res := struct {
+		Sessions int `json:"sessions"`
+		Added    int `json:"added"`
+	}{
+		Sessions: len(sessions),
+		Added:    added,
+	}
+
+	jsonResult(w, res)
+}
+
 // updateSessionHandler updates the data of an existing session
 func updateSessionHandler(w http.ResponseWriter, r *http.Request) {
-	if db.Instance == nil {
-		jsonError(w, http.StatusBadRequest, errors.New("database offline"))
+	repo, ok := sessionRepository(w)
+	if !ok {
 		return
 	}
 
 	vars := mux.Vars(r)
 	id := vars["id"]
 
-	var session struct {
+	var data struct {
 		Vehicle string
+		User    string
+		Notes   *string
+		Tags    *[]string
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&session); err != nil {
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
 		jsonError(w, http.StatusBadRequest, err)
 		return
 	}
 
-	if txn := db.Instance.Table("sessions").Where("id = ?", id).Updates(&session); txn.Error != nil {
-		jsonError(w, http.StatusBadRequest, txn.Error)
+	// vehicle and user are only updated if not empty, notes and tags can be cleared
+	updates := make(map[string]any)
+	if data.Vehicle != "" {
+		updates["vehicle"] = data.Vehicle
+	}
+	if data.User != "" {
+		updates["user"] = data.User
+	}
+	if data.Notes != nil {
+		updates["notes"] = strings.TrimSpace(*data.Notes)
+	}
+	if data.Tags != nil {
+		b, err := json.Marshal(session.NormalizeTags(*data.Tags))
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+		updates["tags"] = string(b)
+	}
+
+	if len(updates) == 0 {
+		return
+	}
+
+	if err := repo.Update(id, updates); err != nil {
+		jsonError(w, http.StatusBadRequest, err)
 		return
 	}
 }
+
+// usersHandler returns the configured users without their identifiers
+func usersHandler(w http.ResponseWriter, r *http.Request) {
+	res := user.All()
+	for i := range res {
+		res[i].Identifiers = nil
+	}
+
+	jsonResult(w, res)
+}
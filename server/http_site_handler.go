@@ -1,21 +1,29 @@
 package server
 
 import (
+	"cmp"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/fs"
 	"math"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
+	"text/tabwriter"
 	"text/template"
+	"time"
 
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/provider"
 	"github.com/evcc-io/evcc/server/assets"
+	"github.com/evcc-io/evcc/tariff"
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/jq"
+	"github.com/evcc-io/evcc/util/webhook"
 	"github.com/gorilla/mux"
 	"github.com/itchyny/gojq"
 	"golang.org/x/text/language"
@@ -95,8 +103,10 @@ func handler[T any](conv func(string) (T, error), set func(T) error, get func()
 			err = set(val)
 		}
 
+		// include effective value for verifying rejected requests
 		if err != nil {
-			jsonError(w, http.StatusBadRequest, err)
+			w.WriteHeader(http.StatusBadRequest)
+			jsonWrite(w, map[string]any{"error": err.Error(), "value": get()})
 			return
 		}
 
@@ -119,6 +129,11 @@ func boolHandler(set func(bool) error, get func() bool) http.HandlerFunc {
 	return handler(strconv.ParseBool, set, get)
 }
 
+// durationHandler updates duration-param api in seconds
+func durationHandler(set func(time.Duration) error, get func() time.Duration) http.HandlerFunc {
+	return handler(parseDuration, set, func() time.Duration { return get() / time.Second })
+}
+
 // boolGetHandler retrieves bool api values
 func boolGetHandler(get func() bool) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -197,26 +212,67 @@ func stateHandler(cache *util.Cache) http.HandlerFunc {
 	}
 }
 
-// healthHandler returns current charge mode
+// healthHandler returns the control loop and device health status
 func healthHandler(site site.API) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		if site == nil || !site.Healthy() {
+		if site == nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 
-		w.WriteHeader(http.StatusOK)
-		fmt.Fprintln(w, "OK")
+		res := site.HealthStatus()
+		if !res.Healthy {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+
+		jsonWrite(w, res)
 	}
 }
 
+// timingHandler returns a plain text report of control loop and device read durations, slowest devices first
+func timingHandler(site site.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if site == nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		res := site.HealthStatus()
+
+		names := make([]string, 0, len(res.Devices))
+		for name := range res.Devices {
+			names = append(names, name)
+		}
+
+		slices.SortFunc(names, func(a, b string) int {
+			return cmp.Or(cmp.Compare(res.Devices[b].AvgDuration, res.Devices[a].AvgDuration), cmp.Compare(a, b))
+		})
+
+		w.Header().Set("Content-Type", "text/plain; charset=UTF-8")
+
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+		fmt.Fprintln(tw, "\tlast (s)\tavg (s)\tmax (s)\terrors\t")
+		fmt.Fprintf(tw, "loop\t%.3f\t%.3f\t%.3f\t%d\t\n", res.Latency, res.AvgLatency, res.MaxLatency, res.Errors)
+
+		for _, name := range names {
+			d := res.Devices[name]
+			fmt.Fprintf(tw, "%s\t%.3f\t%.3f\t%.3f\t%d\t\n", name, d.Duration, d.AvgDuration, d.MaxDuration, d.Errors)
+		}
+
+		_ = tw.Flush()
+	}
+}
+
+// providerMetricsHandler returns the plugin call statistics
+func providerMetricsHandler(w http.ResponseWriter, r *http.Request) {
+	jsonResult(w, provider.Metrics())
+}
+
 // tariffHandler returns the configured tariff
 func tariffHandler(site site.API) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
-		tariff := vars["tariff"]
-
-		t := site.GetTariff(tariff)
+		t := site.GetTariff(vars["tariff"])
 		if t == nil {
 			jsonError(w, http.StatusNotFound, errors.New("tariff not available"))
 			return
@@ -230,8 +286,10 @@ func tariffHandler(site site.API) http.HandlerFunc {
 
 		res := struct {
 			Rates api.Rates `json:"rates"`
+			Stale bool      `json:"stale,omitempty"`
 		}{
 			Rates: rates,
+			Stale: tariff.IsStale(t),
 		}
 
 		jsonResult(w, res)
@@ -244,3 +302,32 @@ func socketHandler(hub *SocketHub) http.HandlerFunc {
 		hub.ServeWebsocket(w, r)
 	}
 }
+
+// circuitsHandler returns the circuits' limits and live utilization
+func circuitsHandler(site site.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		jsonResult(w, site.GetCircuits())
+	}
+}
+
+// webhookHandler dispatches payloads pushed by vendor clouds or devices to the registered device
+func webhookHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	payload, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := webhook.Handle(vars["id"], payload); err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, webhook.ErrUnknown) {
+			status = http.StatusNotFound
+		}
+		jsonError(w, status, err)
+		return
+	}
+
+	jsonResult(w, true)
+}
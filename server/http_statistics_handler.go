@@ -0,0 +1,57 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/evcc-io/evcc/core/statistics"
+	"github.com/evcc-io/evcc/server/db"
+)
+
+// statisticsHandler returns the daily, monthly (default) or yearly site or loadpoint statistics within the from and to date range
+func statisticsHandler(w http.ResponseWriter, r *http.Request) {
+	if db.Instance == nil {
+		jsonError(w, http.StatusBadRequest, errors.New("database offline"))
+		return
+	}
+
+	period := statistics.Period(r.URL.Query().Get("period"))
+	switch period {
+	case "":
+		period = statistics.Month
+	case statistics.Day, statistics.Month, statistics.Year:
+	default:
+		jsonError(w, http.StatusBadRequest, fmt.Errorf("invalid period: %s", period))
+		return
+	}
+
+	parse := func(key string, def time.Time) (time.Time, error) {
+		if s := r.URL.Query().Get(key); s != "" {
+			return time.ParseInLocation(time.DateOnly, s, time.Local)
+		}
+		return def, nil
+	}
+
+	from, err := parse("from", time.Time{})
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	to, err := parse("to", time.Now())
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// include the end date
+	res, err := statistics.Query(db.Instance, period, r.URL.Query().Get("loadpoint"), from, to.AddDate(0, 0, 1))
+	if err != nil {
+		jsonError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonResult(w, res)
+}
@@ -67,6 +67,35 @@ func limitSocHandler(site site.API) http.HandlerFunc {
 	}
 }
 
+// tripLimitSocHandler updates the limit soc for the next session
+func tripLimitSocHandler(site site.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		v, err := site.Vehicles().ByName(vars["name"])
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		soc, err := strconv.Atoi(vars["value"])
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		v.SetTripLimitSoc(soc)
+
+		res := struct {
+			Soc int `json:"soc"`
+		}{
+			Soc: v.GetTripLimitSoc(),
+		}
+
+		jsonResult(w, res)
+	}
+}
+
 // planSocHandler updates plan soc and time
 func planSocHandler(site site.API) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -129,3 +158,26 @@ func planSocRemoveHandler(site site.API) http.HandlerFunc {
 		jsonResult(w, res)
 	}
 }
+
+// planPreconditionHandler updates the battery preconditioning duration before plan time
+func planPreconditionHandler(site site.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		v, err := site.Vehicles().ByName(vars["name"])
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		d, err := parseDuration(vars["value"])
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		v.SetPlanPrecondition(d)
+
+		jsonResult(w, v.GetPlanPrecondition()/time.Second)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/audit"
 	"github.com/evcc-io/evcc/core/loadpoint"
 	"github.com/evcc-io/evcc/core/site"
 	"github.com/evcc-io/evcc/core/vehicle"
@@ -115,8 +116,7 @@ func (m *MQTT) publishComplex(topic string, retained bool, payload interface{})
 }
 
 func (m *MQTT) publishString(topic string, retained bool, payload string) {
-	token := m.Handler.Client.Publish(topic, m.Handler.Qos, retained, m.encode(payload))
-	go m.Handler.WaitForToken("send", topic, token)
+	_ = m.Handler.Publish(topic, retained, m.encode(payload))
 }
 
 func (m *MQTT) publishSingleValue(topic string, retained bool, payload interface{}) {
@@ -141,6 +141,36 @@ func (m *MQTT) publish(topic string, retained bool, payload interface{}) {
 	m.publishComplex(topic, retained, payload)
 }
 
+// acknowledge publishes the setter result including the effective value to the result topic
+func (m *MQTT) acknowledge(topic string, s setter) func(string) error {
+	return func(payload string) error {
+		err := s.fun(payload)
+
+		res := setterResult{Payload: payload}
+		if err != nil {
+			res.Error = err.Error()
+		}
+
+		audit.Record(audit.Entry{
+			Source: audit.SourceMqtt,
+			Action: strings.TrimPrefix(topic, m.root),
+			Value:  payload,
+			Error:  res.Error,
+		})
+		if s.get != nil {
+			res.Value = s.get()
+		}
+
+		if b, err := json.Marshal(res); err == nil {
+			m.publisher(topic+"/result", false, string(b))
+		} else {
+			m.log.ERROR.Printf("result: %s: %v", topic, err)
+		}
+
+		return err
+	}
+}
+
 func (m *MQTT) Listen(site site.API) error {
 	if err := m.listenSiteSetters(m.root+"/site", site); err != nil {
 		return err
@@ -167,12 +197,12 @@ func (m *MQTT) Listen(site site.API) error {
 
 func (m *MQTT) listenSiteSetters(topic string, site site.API) error {
 	for _, s := range []setter{
-		{"/prioritySoc", floatSetter(site.SetPrioritySoc)},
-		{"/bufferSoc", floatSetter(site.SetBufferSoc)},
-		{"/bufferStartSoc", floatSetter(site.SetBufferStartSoc)},
-		{"/residualPower", floatSetter(site.SetResidualPower)},
+		{"/prioritySoc", floatSetter(site.SetPrioritySoc), getter(site.GetPrioritySoc)},
+		{"/bufferSoc", floatSetter(site.SetBufferSoc), getter(site.GetBufferSoc)},
+		{"/bufferStartSoc", floatSetter(site.SetBufferStartSoc), getter(site.GetBufferStartSoc)},
+		{"/residualPower", floatSetter(site.SetResidualPower), getter(site.GetResidualPower)},
 	} {
-		if err := m.Handler.ListenSetter(topic+s.topic, s.fun); err != nil {
+		if err := m.Handler.ListenSetter(topic+s.topic, m.acknowledge(topic+s.topic, s)); err != nil {
 			return err
 		}
 	}
@@ -182,15 +212,29 @@ func (m *MQTT) listenSiteSetters(topic string, site site.API) error {
 
 func (m *MQTT) listenLoadpointSetters(topic string, site site.API, lp loadpoint.API) error {
 	for _, s := range []setter{
-		{"/mode", setterFunc(api.ChargeModeString, pass(lp.SetMode))},
-		{"/phases", intSetter(lp.SetPhases)},
-		{"/limitSoc", intSetter(pass(lp.SetLimitSoc))},
-		{"/minCurrent", floatSetter(lp.SetMinCurrent)},
-		{"/maxCurrent", floatSetter(lp.SetMaxCurrent)},
-		{"/limitEnergy", floatSetter(pass(lp.SetLimitEnergy))},
-		{"/enableThreshold", floatSetter(pass(lp.SetEnableThreshold))},
-		{"/disableThreshold", floatSetter(pass(lp.SetDisableThreshold))},
-		{"/smartCostLimit", floatSetter(pass(lp.SetSmartCostLimit))},
+		{"/mode", setterFunc(api.ChargeModeString, pass(lp.SetMode)), getter(lp.GetMode)},
+		{"/phases", intSetter(lp.SetPhases), getter(lp.GetPhases)},
+		{"/limitSoc", intSetter(pass(lp.SetLimitSoc)), getter(lp.GetLimitSoc)},
+		{"/minCurrent", floatSetter(lp.SetMinCurrent), getter(lp.GetMinCurrent)},
+		{"/maxCurrent", floatSetter(lp.SetMaxCurrent), getter(lp.GetMaxCurrent)},
+		{"/limitEnergy", floatSetter(pass(lp.SetLimitEnergy)), getter(lp.GetLimitEnergy)},
+		{"/vehicleSoc", floatSetter(lp.SetVehicleSoc), getter(lp.GetVehicleSoc)},
+		{"/enableThreshold", floatSetter(pass(lp.SetEnableThreshold)), getter(lp.GetEnableThreshold)},
+		{"/disableThreshold", floatSetter(pass(lp.SetDisableThreshold)), getter(lp.GetDisableThreshold)},
+		{"/enableDelay", durationSetter(pass(lp.SetEnableDelay)), durationGetter(lp.GetEnableDelay)},
+		{"/disableDelay", durationSetter(pass(lp.SetDisableDelay)), durationGetter(lp.GetDisableDelay)},
+		{"/rampRate", floatSetter(lp.SetRampRate), getter(lp.GetRampRate)},
+		{"/smartCostLimit", floatSetter(pass(lp.SetSmartCostLimit)), getter(lp.GetSmartCostLimit)},
+		{"/smartCostPercentile", floatSetter(pass(lp.SetSmartCostPercentile)), getter(lp.GetSmartCostPercentile)},
+		{"/strictPV", boolSetter(pass(lp.SetStrictPV)), getter(lp.GetStrictPV)},
+		{"/externalPower", floatSetter(pass(lp.SetExternalPower)), getter(lp.GetExternalPower)},
+		{"/externalCurrent", floatSetter(pass(lp.SetExternalCurrent)), getter(lp.GetExternalCurrent)},
+		{"/lock", func(string) error {
+			return lp.LockSocket()
+		}, nil},
+		{"/unlock", func(string) error {
+			return lp.UnlockSocket()
+		}, nil},
 		{"/planEnergy", func(payload string) error {
 			var plan struct {
 				Time  time.Time `json:"time"`
@@ -201,6 +245,39 @@ func (m *MQTT) listenLoadpointSetters(topic string, site site.API, lp loadpoint.
 				err = lp.SetPlanEnergy(plan.Time, plan.Value)
 			}
 			return err
+		}, func() any {
+			ts, energy := lp.GetPlanEnergy()
+			return planResult{ts, energy}
+		}},
+		{"/guestSession", func(payload string) error {
+			// https://github.com/evcc-io/evcc/issues/11184 empty payload is swallowed by listener
+			if payload == "-" {
+				lp.StopGuestSession()
+				return nil
+			}
+			var guest loadpoint.GuestSession
+			err := json.Unmarshal([]byte(payload), &guest)
+			if err == nil {
+				err = lp.StartGuestSession(guest.Energy, guest.Cost)
+			}
+			return err
+		}, func() any {
+			return lp.GetGuestSession()
+		}},
+		{"/boost", func(payload string) error {
+			// https://github.com/evcc-io/evcc/issues/11184 empty payload is swallowed by listener
+			if payload == "-" {
+				lp.StopBoost()
+				return nil
+			}
+			var boost loadpoint.Boost
+			err := json.Unmarshal([]byte(payload), &boost)
+			if err == nil {
+				err = lp.StartBoost(boost.Energy, boost.Minutes)
+			}
+			return err
+		}, func() any {
+			return lp.GetBoost()
 		}},
 		{"/vehicle", func(payload string) error {
 			// https://github.com/evcc-io/evcc/issues/11184 empty payload is swallowed by listener
@@ -213,9 +290,14 @@ func (m *MQTT) listenLoadpointSetters(topic string, site site.API, lp loadpoint.
 				lp.SetVehicle(vehicle.Instance())
 			}
 			return err
+		}, func() any {
+			if v := lp.GetVehicle(); v != nil {
+				return vehicle.Settings(m.log, v).Name()
+			}
+			return nil
 		}},
 	} {
-		if err := m.Handler.ListenSetter(topic+s.topic, s.fun); err != nil {
+		if err := m.Handler.ListenSetter(topic+s.topic, m.acknowledge(topic+s.topic, s)); err != nil {
 			return err
 		}
 	}
@@ -225,8 +307,9 @@ func (m *MQTT) listenLoadpointSetters(topic string, site site.API, lp loadpoint.
 
 func (m *MQTT) listenVehicleSetters(topic string, v vehicle.API) error {
 	for _, s := range []setter{
-		{topic + "/limitSoc", intSetter(pass(v.SetLimitSoc))},
-		{topic + "/minSoc", intSetter(pass(v.SetMinSoc))},
+		{topic + "/limitSoc", intSetter(pass(v.SetLimitSoc)), getter(v.GetLimitSoc)},
+		{topic + "/minSoc", intSetter(pass(v.SetMinSoc)), getter(v.GetMinSoc)},
+		{topic + "/tripLimitSoc", intSetter(pass(v.SetTripLimitSoc)), getter(v.GetTripLimitSoc)},
 		{topic + "/planSoc", func(payload string) error {
 			var plan struct {
 				Time  time.Time `json:"time"`
@@ -237,9 +320,12 @@ func (m *MQTT) listenVehicleSetters(topic string, v vehicle.API) error {
 				err = v.SetPlanSoc(plan.Time, plan.Value)
 			}
 			return err
+		}, func() any {
+			ts, soc := v.GetPlanSoc()
+			return planResult{ts, soc}
 		}},
 	} {
-		if err := m.Handler.ListenSetter(s.topic, s.fun); err != nil {
+		if err := m.Handler.ListenSetter(s.topic, m.acknowledge(s.topic, s)); err != nil {
 			return err
 		}
 	}
@@ -2,11 +2,26 @@ package server
 
 import (
 	"strconv"
+	"time"
 )
 
 type setter struct {
 	topic string
 	fun   func(string) error
+	get   func() any // effective value published as acknowledgement, may be nil
+}
+
+// setterResult acknowledges a setter on the result topic
+type setterResult struct {
+	Payload string `json:"payload"`         // received payload
+	Value   any    `json:"value,omitempty"` // effective value after validation and clamping
+	Error   string `json:"error,omitempty"`
+}
+
+// planResult is the effective plan
+type planResult struct {
+	Time  time.Time `json:"time"`
+	Value any       `json:"value"`
 }
 
 func setterFunc[T any](conv func(string) (T, error), set func(T) error) func(string) error {
@@ -26,3 +41,25 @@ func floatSetter(set func(float64) error) func(string) error {
 func intSetter(set func(int) error) func(string) error {
 	return setterFunc(strconv.Atoi, set)
 }
+
+func boolSetter(set func(bool) error) func(string) error {
+	return setterFunc(strconv.ParseBool, set)
+}
+
+func durationSetter(set func(time.Duration) error) func(string) error {
+	return setterFunc(parseDuration, set)
+}
+
+// getter converts typed getters for acknowledgements
+func getter[T any](get func() T) func() any {
+	return func() any {
+		return get()
+	}
+}
+
+// durationGetter converts duration getters to seconds for acknowledgements
+func durationGetter(get func() time.Duration) func() any {
+	return func() any {
+		return int(get() / time.Second)
+	}
+}
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"errors"
 	"math"
 	"strconv"
 	"testing"
@@ -54,3 +55,32 @@ func TestPublishTypes(t *testing.T) {
 	assert.Equal(t, []string{`2`, `10`, `20`}, payloads, "slice mismatch")
 	reset()
 }
+
+func TestSetterAcknowledge(t *testing.T) {
+	var topics, payloads []string
+
+	m := &MQTT{
+		publisher: func(topic string, retained bool, payload string) {
+			topics = append(topics, topic)
+			payloads = append(payloads, payload)
+		},
+	}
+
+	var val float64
+	set := m.acknowledge("test", setter{"/test", floatSetter(func(v float64) error {
+		if v < 0 {
+			return errors.New("negative")
+		}
+		val = min(v, 16)
+		return nil
+	}), getter(func() float64 { return val })})
+
+	// clamped
+	require.NoError(t, set("32"))
+	assert.Equal(t, []string{"test/result"}, topics)
+	assert.JSONEq(t, `{"payload":"32","value":16}`, payloads[0])
+
+	// rejected
+	require.Error(t, set("-1"))
+	assert.JSONEq(t, `{"payload":"-1","value":16,"error":"negative"}`, payloads[1])
+}
@@ -0,0 +1,417 @@
+package ocpi
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/session"
+	"github.com/evcc-io/evcc/util"
+	"github.com/gorilla/mux"
+)
+
+const (
+	version    = "2.2.1"
+	basePath   = "/ocpi"
+	maxLimit   = 100
+	locationID = "1"
+)
+
+// Config is the OCPI configuration
+type Config struct {
+	Token       string // token the roaming platform uses for authorization
+	URI         string // external base url, derived from requests if empty
+	CountryCode string // ISO 3166-1 alpha-2 country code of the CPO
+	PartyID     string // CPO party id
+	Currency    string // ISO 4217 currency code for charge detail records
+	Location    LocationConfig
+}
+
+// LocationConfig describes the location of the site
+type LocationConfig struct {
+	Name       string
+	Address    string
+	City       string
+	PostalCode string
+	Country    string // ISO 3166-1 alpha-3 country code
+	Lat, Lon   float64
+}
+
+// Sessions provides charging sessions for charge detail records
+type Sessions func() (session.Sessions, error)
+
+// OCPI exposes loadpoints as OCPI locations and charging sessions as charge detail records (CPO role, sender interfaces only)
+type OCPI struct {
+	log        *util.Logger
+	conf       Config
+	loadpoints []loadpoint.API
+	sessions   Sessions
+	started    time.Time
+}
+
+// New creates an OCPI module
+func New(cc Config, loadpoints []loadpoint.API, sessions Sessions) (*OCPI, error) {
+	if cc.Token == "" {
+		return nil, errors.New("missing token")
+	}
+
+	if len(cc.CountryCode) != 2 || len(cc.PartyID) != 3 {
+		return nil, errors.New("country code must have 2 and party id 3 characters")
+	}
+
+	if cc.Currency == "" {
+		cc.Currency = "EUR"
+	}
+
+	if len(cc.Location.Country) != 3 {
+		return nil, errors.New("location country must be ISO 3166-1 alpha-3 code")
+	}
+
+	return &OCPI{
+		log:        util.NewLogger("ocpi"),
+		conf:       cc,
+		loadpoints: loadpoints,
+		sessions:   sessions,
+		started:    time.Now().UTC().Truncate(time.Second),
+	}, nil
+}
+
+// Register adds the OCPI endpoints to the router
+func (o *OCPI) Register(router *mux.Router) {
+	r := router.PathPrefix(basePath).Subrouter()
+	r.Use(o.authorize)
+
+	r.HandleFunc("/versions", o.versionsHandler).Methods(http.MethodGet)
+	r.HandleFunc("/"+version, o.versionDetailsHandler).Methods(http.MethodGet)
+	r.HandleFunc("/"+version+"/locations", o.locationsHandler).Methods(http.MethodGet)
+	r.HandleFunc("/"+version+"/locations/{location}", o.locationHandler).Methods(http.MethodGet)
+	r.HandleFunc("/"+version+"/locations/{location}/{evse}", o.evseHandler).Methods(http.MethodGet)
+	r.HandleFunc("/"+version+"/cdrs", o.cdrsHandler).Methods(http.MethodGet)
+}
+
+// authorize validates the OCPI token, which may be sent plain or base64 encoded
+func (o *OCPI) authorize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Token ")
+
+		valid := ok && subtle.ConstantTimeCompare([]byte(token), []byte(o.conf.Token)) == 1
+		if b, err := base64.StdEncoding.DecodeString(token); ok && !valid && err == nil {
+			valid = subtle.ConstantTimeCompare(b, []byte(o.conf.Token)) == 1
+		}
+
+		if !valid {
+			o.respond(w, http.StatusUnauthorized, nil, StatusClientError, "invalid token")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (o *OCPI) respond(w http.ResponseWriter, status int, data any, code int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(Response{
+		Data:          data,
+		StatusCode:    code,
+		StatusMessage: msg,
+		Timestamp:     time.Now().UTC(),
+	}); err != nil {
+		o.log.ERROR.Println(err)
+	}
+}
+
+func (o *OCPI) success(w http.ResponseWriter, data any) {
+	o.respond(w, http.StatusOK, data, StatusSuccess, "Success")
+}
+
+// baseURI returns the external OCPI base url
+func (o *OCPI) baseURI(r *http.Request) string {
+	if o.conf.URI != "" {
+		return strings.TrimRight(o.conf.URI, "/") + basePath
+	}
+
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+
+	return fmt.Sprintf("%s://%s%s", scheme, r.Host, basePath)
+}
+
+func (o *OCPI) versionsHandler(w http.ResponseWriter, r *http.Request) {
+	o.success(w, []Version{{Version: version, URL: o.baseURI(r) + "/" + version}})
+}
+
+func (o *OCPI) versionDetailsHandler(w http.ResponseWriter, r *http.Request) {
+	uri := o.baseURI(r) + "/" + version
+
+	o.success(w, VersionDetails{
+		Version: version,
+		Endpoints: []Endpoint{
+			{Identifier: "locations", Role: "SENDER", URL: uri + "/locations"},
+			{Identifier: "cdrs", Role: "SENDER", URL: uri + "/cdrs"},
+		},
+	})
+}
+
+// evseStatus maps the loadpoint status to OCPI EVSE status
+func evseStatus(status api.ChargeStatus) string {
+	switch status {
+	case api.StatusA:
+		return "AVAILABLE"
+	case api.StatusB, api.StatusC:
+		return "CHARGING"
+	case api.StatusE, api.StatusF:
+		return "OUTOFORDER"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+func (o *OCPI) evseUID(id int) string {
+	return strconv.Itoa(id + 1)
+}
+
+func (o *OCPI) evseID(id int) string {
+	return fmt.Sprintf("%s*%s*E%d", strings.ToUpper(o.conf.CountryCode), strings.ToUpper(o.conf.PartyID), id+1)
+}
+
+func powerType(phases int) string {
+	if phases == 1 {
+		return "AC_1_PHASE"
+	}
+	return "AC_3_PHASE"
+}
+
+func (o *OCPI) evse(id int, lp loadpoint.API) EVSE {
+	phases := lp.GetPhases()
+	if phases == 0 {
+		phases = 3
+	}
+
+	return EVSE{
+		UID:    o.evseUID(id),
+		EvseID: o.evseID(id),
+		Status: evseStatus(lp.GetStatus()),
+		Connectors: []Connector{{
+			ID:          "1",
+			Standard:    "IEC_62196_T2",
+			Format:      "SOCKET",
+			PowerType:   powerType(phases),
+			MaxVoltage:  230,
+			MaxAmperage: int(lp.GetMaxCurrent()),
+			LastUpdated: o.started,
+		}},
+		LastUpdated: time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+func (o *OCPI) coordinates() GeoLocation {
+	return GeoLocation{
+		Latitude:  strconv.FormatFloat(o.conf.Location.Lat, 'f', 6, 64),
+		Longitude: strconv.FormatFloat(o.conf.Location.Lon, 'f', 6, 64),
+	}
+}
+
+func (o *OCPI) location() Location {
+	loc := o.conf.Location
+
+	res := Location{
+		CountryCode: strings.ToUpper(o.conf.CountryCode),
+		PartyID:     strings.ToUpper(o.conf.PartyID),
+		ID:          locationID,
+		Publish:     true,
+		Name:        loc.Name,
+		Address:     loc.Address,
+		City:        loc.City,
+		PostalCode:  loc.PostalCode,
+		Country:     loc.Country,
+		Coordinates: o.coordinates(),
+		TimeZone:    time.Local.String(),
+		LastUpdated: time.Now().UTC().Truncate(time.Second),
+	}
+
+	for id, lp := range o.loadpoints {
+		res.EVSEs = append(res.EVSEs, o.evse(id, lp))
+	}
+
+	return res
+}
+
+func (o *OCPI) locationsHandler(w http.ResponseWriter, r *http.Request) {
+	o.success(w, []Location{o.location()})
+}
+
+func (o *OCPI) locationHandler(w http.ResponseWriter, r *http.Request) {
+	if mux.Vars(r)["location"] != locationID {
+		o.respond(w, http.StatusNotFound, nil, StatusUnknownObject, "unknown location")
+		return
+	}
+
+	o.success(w, o.location())
+}
+
+func (o *OCPI) evseHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	id, err := strconv.Atoi(vars["evse"])
+	if vars["location"] != locationID || err != nil || id < 1 || id > len(o.loadpoints) {
+		o.respond(w, http.StatusNotFound, nil, StatusUnknownObject, "unknown evse")
+		return
+	}
+
+	o.success(w, o.evse(id-1, o.loadpoints[id-1]))
+}
+
+// loadpointID returns the index of the session's loadpoint or -1 if not found
+func (o *OCPI) loadpointID(s session.Session) int {
+	return slices.IndexFunc(o.loadpoints, func(lp loadpoint.API) bool {
+		return lp.Title() == s.Loadpoint
+	})
+}
+
+// cdr converts a finished charging session at the given loadpoint into a charge detail record
+func (o *OCPI) cdr(id int, s session.Session) CDR {
+	phases := o.loadpoints[id].GetPhases()
+
+	uid, tokenType := s.Identifier, "RFID"
+	if uid == "" {
+		uid, tokenType = "evcc-"+strconv.FormatUint(uint64(s.ID), 10), "OTHER"
+	}
+
+	contract := uid
+	if s.User != "" {
+		contract = s.User
+	}
+
+	res := CDR{
+		CountryCode:   strings.ToUpper(o.conf.CountryCode),
+		PartyID:       strings.ToUpper(o.conf.PartyID),
+		ID:            strconv.FormatUint(uint64(s.ID), 10),
+		StartDateTime: s.Created.UTC(),
+		EndDateTime:   s.Finished.UTC(),
+		CdrToken: CdrToken{
+			CountryCode: strings.ToUpper(o.conf.CountryCode),
+			PartyID:     strings.ToUpper(o.conf.PartyID),
+			UID:         uid,
+			Type:        tokenType,
+			ContractID:  contract,
+		},
+		AuthMethod: "WHITELIST",
+		CdrLocation: CdrLocation{
+			ID:                 locationID,
+			Name:               o.conf.Location.Name,
+			Address:            o.conf.Location.Address,
+			City:               o.conf.Location.City,
+			PostalCode:         o.conf.Location.PostalCode,
+			Country:            o.conf.Location.Country,
+			Coordinates:        o.coordinates(),
+			EvseUID:            o.evseUID(id),
+			EvseID:             o.evseID(id),
+			ConnectorID:        "1",
+			ConnectorStandard:  "IEC_62196_T2",
+			ConnectorFormat:    "SOCKET",
+			ConnectorPowerType: powerType(phases),
+		},
+		Currency:    o.conf.Currency,
+		TotalEnergy: s.ChargedEnergy,
+		TotalTime:   s.Finished.Sub(s.Created).Hours(),
+		LastUpdated: s.Finished.UTC(),
+	}
+
+	if s.Price != nil {
+		res.TotalCost.ExclVat = *s.Price
+	}
+
+	if s.ChargeDuration != nil {
+		res.TotalParkingTime = max(res.TotalTime-s.ChargeDuration.Hours(), 0)
+	}
+
+	if s.SignedStart != "" || s.SignedStop != "" {
+		res.SignedData = &SignedData{
+			EncodingMethod: "OCMF",
+			SignedValues: []SignedValue{
+				{Nature: "Start", SignedData: s.SignedStart},
+				{Nature: "End", SignedData: s.SignedStop},
+			},
+		}
+	}
+
+	return res
+}
+
+func (o *OCPI) cdrsHandler(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	var from, to time.Time
+	if v := q.Get("date_from"); v != "" {
+		var err error
+		if from, err = time.Parse(time.RFC3339, v); err != nil {
+			o.respond(w, http.StatusBadRequest, nil, StatusClientError, "invalid date_from")
+			return
+		}
+	}
+	if v := q.Get("date_to"); v != "" {
+		var err error
+		if to, err = time.Parse(time.RFC3339, v); err != nil {
+			o.respond(w, http.StatusBadRequest, nil, StatusClientError, "invalid date_to")
+			return
+		}
+	}
+
+	offset, _ := strconv.Atoi(q.Get("offset"))
+	limit, err := strconv.Atoi(q.Get("limit"))
+	if err != nil || limit <= 0 || limit > maxLimit {
+		limit = maxLimit
+	}
+
+	sessions, err := o.sessions()
+	if err != nil {
+		o.respond(w, http.StatusInternalServerError, nil, StatusServerError, err.Error())
+		return
+	}
+
+	// finished sessions updated within the requested period, sessions of unknown (e.g. renamed or removed) loadpoints can't be assigned to an evse
+	sessions = slices.DeleteFunc(sessions, func(s session.Session) bool {
+		return s.Finished.IsZero() || s.Created.IsZero() || o.loadpointID(s) < 0 ||
+			!from.IsZero() && s.Finished.Before(from) || !to.IsZero() && !s.Finished.Before(to)
+	})
+
+	slices.SortFunc(sessions, func(a, b session.Session) int {
+		return a.Finished.Compare(b.Finished)
+	})
+
+	total := len(sessions)
+	offset = min(max(offset, 0), total)
+	end := min(offset+limit, total)
+
+	res := make([]CDR, 0, end-offset)
+	for _, s := range sessions[offset:end] {
+		res = append(res, o.cdr(o.loadpointID(s), s))
+	}
+
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	w.Header().Set("X-Limit", strconv.Itoa(maxLimit))
+
+	if end < total {
+		next := *r.URL
+		nq := next.Query()
+		nq.Set("offset", strconv.Itoa(end))
+		nq.Set("limit", strconv.Itoa(limit))
+		next.RawQuery = nq.Encode()
+		w.Header().Set("Link", fmt.Sprintf(`<%s/%s/cdrs?%s>; rel="next"`, o.baseURI(r), version, next.RawQuery))
+	}
+
+	o.success(w, res)
+}
@@ -0,0 +1,130 @@
+package ocpi
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/session"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func testOCPI(t *testing.T, sessions session.Sessions) *mux.Router {
+	ctrl := gomock.NewController(t)
+
+	lp := loadpoint.NewMockAPI(ctrl)
+	lp.EXPECT().Title().Return("Garage").AnyTimes()
+	lp.EXPECT().GetStatus().Return(api.StatusC).AnyTimes()
+	lp.EXPECT().GetPhases().Return(1).AnyTimes()
+	lp.EXPECT().GetMaxCurrent().Return(16.0).AnyTimes()
+
+	o, err := New(Config{
+		Token:       "secret",
+		CountryCode: "de",
+		PartyID:     "evc",
+		Location:    LocationConfig{Country: "DEU"},
+	}, []loadpoint.API{lp}, func() (session.Sessions, error) {
+		return slices.Clone(sessions), nil
+	})
+	require.NoError(t, err)
+
+	router := mux.NewRouter()
+	o.Register(router)
+
+	return router
+}
+
+func request(router http.Handler, uri, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, uri, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Token "+token)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestAuthorization(t *testing.T) {
+	router := testOCPI(t, nil)
+
+	assert.Equal(t, http.StatusUnauthorized, request(router, "/ocpi/versions", "").Code)
+	assert.Equal(t, http.StatusUnauthorized, request(router, "/ocpi/versions", "wrong").Code)
+	assert.Equal(t, http.StatusOK, request(router, "/ocpi/versions", "secret").Code)
+	assert.Equal(t, http.StatusOK, request(router, "/ocpi/versions", base64.StdEncoding.EncodeToString([]byte("secret"))).Code)
+}
+
+func TestLocations(t *testing.T) {
+	router := testOCPI(t, nil)
+
+	w := request(router, "/ocpi/2.2.1/locations/1/1", "secret")
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var res struct {
+		Data       EVSE
+		StatusCode int `json:"status_code"`
+	}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&res))
+
+	assert.Equal(t, StatusSuccess, res.StatusCode)
+	assert.Equal(t, "DE*EVC*E1", res.Data.EvseID)
+	assert.Equal(t, "CHARGING", res.Data.Status)
+	assert.Equal(t, "AC_1_PHASE", res.Data.Connectors[0].PowerType)
+
+	assert.Equal(t, http.StatusNotFound, request(router, "/ocpi/2.2.1/locations/1/2", "secret").Code)
+}
+
+func TestCdrs(t *testing.T) {
+	start := time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC)
+	price := 3.5
+
+	router := testOCPI(t, session.Sessions{
+		{ID: 2, Loadpoint: "Garage", Created: start.Add(24 * time.Hour), Finished: start.Add(26 * time.Hour), ChargedEnergy: 5},
+		{ID: 1, Loadpoint: "Garage", Identifier: "04A1", User: "alice", Created: start, Finished: start.Add(2 * time.Hour), ChargedEnergy: 10, Price: &price},
+		{ID: 3, Loadpoint: "Garage", Created: start.Add(48 * time.Hour)},              // unfinished
+		{ID: 4, Loadpoint: "Carport", Created: start, Finished: start.Add(time.Hour)}, // unknown loadpoint
+	})
+
+	w := request(router, "/ocpi/2.2.1/cdrs?limit=1", "secret")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "2", w.Header().Get("X-Total-Count"))
+	assert.Contains(t, w.Header().Get("Link"), "offset=1")
+
+	var res struct {
+		Data []CDR
+	}
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&res))
+	require.Len(t, res.Data, 1)
+
+	cdr := res.Data[0]
+	assert.Equal(t, "1", cdr.ID)
+	assert.Equal(t, "04A1", cdr.CdrToken.UID)
+	assert.Equal(t, "alice", cdr.CdrToken.ContractID)
+	assert.Equal(t, 3.5, cdr.TotalCost.ExclVat)
+	assert.Equal(t, 2.0, cdr.TotalTime)
+
+	// date filter
+	w = request(router, "/ocpi/2.2.1/cdrs?date_from=2026-10-02T00:00:00Z", "secret")
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&res))
+	require.Len(t, res.Data, 1)
+	assert.Equal(t, "2", res.Data[0].ID)
+
+	// unknown loadpoint skipped
+	w = request(router, "/ocpi/2.2.1/cdrs", "secret")
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&res))
+	require.Len(t, res.Data, 2)
+	for _, cdr := range res.Data {
+		assert.NotEqual(t, "4", cdr.ID)
+		assert.Equal(t, "DE*EVC*E1", cdr.CdrLocation.EvseID)
+	}
+}
@@ -0,0 +1,144 @@
+package ocpi
+
+import "time"
+
+// OCPI 2.2.1 status codes
+const (
+	StatusSuccess       = 1000
+	StatusClientError   = 2000
+	StatusUnknownObject = 2004
+	StatusServerError   = 3000
+)
+
+// Response is the OCPI response envelope
+type Response struct {
+	Data          any       `json:"data,omitempty"`
+	StatusCode    int       `json:"status_code"`
+	StatusMessage string    `json:"status_message,omitempty"`
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+// Version is a supported OCPI version
+type Version struct {
+	Version string `json:"version"`
+	URL     string `json:"url"`
+}
+
+// Endpoint is an OCPI module endpoint
+type Endpoint struct {
+	Identifier string `json:"identifier"`
+	Role       string `json:"role"`
+	URL        string `json:"url"`
+}
+
+// VersionDetails lists the module endpoints of a version
+type VersionDetails struct {
+	Version   string     `json:"version"`
+	Endpoints []Endpoint `json:"endpoints"`
+}
+
+// GeoLocation is an OCPI coordinate pair
+type GeoLocation struct {
+	Latitude  string `json:"latitude"`
+	Longitude string `json:"longitude"`
+}
+
+// Location is an OCPI charging location
+type Location struct {
+	CountryCode string      `json:"country_code"`
+	PartyID     string      `json:"party_id"`
+	ID          string      `json:"id"`
+	Publish     bool        `json:"publish"`
+	Name        string      `json:"name,omitempty"`
+	Address     string      `json:"address"`
+	City        string      `json:"city"`
+	PostalCode  string      `json:"postal_code,omitempty"`
+	Country     string      `json:"country"`
+	Coordinates GeoLocation `json:"coordinates"`
+	EVSEs       []EVSE      `json:"evses,omitempty"`
+	TimeZone    string      `json:"time_zone"`
+	LastUpdated time.Time   `json:"last_updated"`
+}
+
+// EVSE is an OCPI charging point
+type EVSE struct {
+	UID         string      `json:"uid"`
+	EvseID      string      `json:"evse_id,omitempty"`
+	Status      string      `json:"status"`
+	Connectors  []Connector `json:"connectors"`
+	LastUpdated time.Time   `json:"last_updated"`
+}
+
+// Connector is an OCPI EVSE connector
+type Connector struct {
+	ID          string    `json:"id"`
+	Standard    string    `json:"standard"`
+	Format      string    `json:"format"`
+	PowerType   string    `json:"power_type"`
+	MaxVoltage  int       `json:"max_voltage"`
+	MaxAmperage int       `json:"max_amperage"`
+	LastUpdated time.Time `json:"last_updated"`
+}
+
+// CdrToken identifies the token used for a charging session
+type CdrToken struct {
+	CountryCode string `json:"country_code"`
+	PartyID     string `json:"party_id"`
+	UID         string `json:"uid"`
+	Type        string `json:"type"`
+	ContractID  string `json:"contract_id"`
+}
+
+// CdrLocation is the location of a charge detail record
+type CdrLocation struct {
+	ID                 string      `json:"id"`
+	Name               string      `json:"name,omitempty"`
+	Address            string      `json:"address"`
+	City               string      `json:"city"`
+	PostalCode         string      `json:"postal_code,omitempty"`
+	Country            string      `json:"country"`
+	Coordinates        GeoLocation `json:"coordinates"`
+	EvseUID            string      `json:"evse_uid"`
+	EvseID             string      `json:"evse_id"`
+	ConnectorID        string      `json:"connector_id"`
+	ConnectorStandard  string      `json:"connector_standard"`
+	ConnectorFormat    string      `json:"connector_format"`
+	ConnectorPowerType string      `json:"connector_power_type"`
+}
+
+// Price is an OCPI price
+type Price struct {
+	ExclVat float64 `json:"excl_vat"`
+}
+
+// CDR is an OCPI charge detail record
+type CDR struct {
+	CountryCode      string      `json:"country_code"`
+	PartyID          string      `json:"party_id"`
+	ID               string      `json:"id"`
+	StartDateTime    time.Time   `json:"start_date_time"`
+	EndDateTime      time.Time   `json:"end_date_time"`
+	CdrToken         CdrToken    `json:"cdr_token"`
+	AuthMethod       string      `json:"auth_method"`
+	CdrLocation      CdrLocation `json:"cdr_location"`
+	Currency         string      `json:"currency"`
+	TotalCost        Price       `json:"total_cost"`
+	TotalEnergy      float64     `json:"total_energy"`
+	TotalTime        float64     `json:"total_time"`
+	TotalParkingTime float64     `json:"total_parking_time,omitempty"`
+	SignedData       *SignedData `json:"signed_data,omitempty"`
+	LastUpdated      time.Time   `json:"last_updated"`
+}
+
+// SignedValue is a signed meter value
+type SignedValue struct {
+	Nature     string `json:"nature"`
+	PlainData  string `json:"plain_data"`
+	SignedData string `json:"signed_data"`
+}
+
+// SignedData holds the signed meter values of a charge detail record
+type SignedData struct {
+	EncodingMethod string        `json:"encoding_method"`
+	SignedValues   []SignedValue `json:"signed_values"`
+}
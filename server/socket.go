@@ -2,8 +2,10 @@ package server
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"net/http"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -15,12 +17,106 @@ import (
 const (
 	// Time allowed to write a message to the peer
 	socketWriteTimeout = 10 * time.Second
+
+	// Max number of queued updates combined into a single message
+	socketBatchSize = 256
 )
 
 // socketSubscriber is a middleman between the websocket connection and the hub.
 type socketSubscriber struct {
 	send      chan []byte
 	closeSlow func()
+
+	allow  func(string) bool // restricts keys, e.g. for public clients
+	mu     sync.Mutex
+	filter []string          // subscribed keys, all if empty
+	last   map[string]string // last sent values, owned by the hub
+}
+
+// socketRequest is a client message changing the subscribed keys.
+// Keys ending in * subscribe all keys with the given prefix, e.g. loadpoints.0.*
+type socketRequest struct {
+	Subscribe   []string `json:"subscribe"`
+	Unsubscribe []string `json:"unsubscribe"`
+}
+
+// socketValue is an encoded param
+type socketValue struct {
+	key, val string
+}
+
+// parseFilter splits a comma-separated list of keys
+func parseFilter(s string) []string {
+	var res []string
+	for _, k := range strings.Split(s, ",") {
+		if k = strings.TrimSpace(k); k != "" {
+			res = append(res, k)
+		}
+	}
+	return res
+}
+
+// update applies subscription changes
+func (s *socketSubscriber) update(req socketRequest) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, k := range req.Unsubscribe {
+		s.filter = slices.DeleteFunc(s.filter, func(f string) bool { return f == k })
+	}
+
+	for _, k := range req.Subscribe {
+		if !slices.Contains(s.filter, k) {
+			s.filter = append(s.filter, k)
+		}
+	}
+}
+
+// subscribed returns true if key matches the subscription filter
+func (s *socketSubscriber) subscribed(key string) bool {
+	if s.allow != nil && !s.allow(key) {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.filter) == 0 {
+		return true
+	}
+
+	for _, f := range s.filter {
+		if prefix, ok := strings.CutSuffix(f, "*"); ok && strings.HasPrefix(key, prefix) || f == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+// message combines subscribed and changed values into a single message
+func (s *socketSubscriber) message(values []socketValue, always bool) []byte {
+	var msg strings.Builder
+	msg.WriteString("{")
+
+	for _, v := range values {
+		if !s.subscribed(v.key) || s.last[v.key] == v.val {
+			continue
+		}
+		s.last[v.key] = v.val
+
+		if msg.Len() > 1 {
+			msg.WriteString(",")
+		}
+		msg.WriteString("\"" + v.key + "\":" + v.val)
+	}
+
+	if msg.Len() == 1 && !always {
+		return nil
+	}
+
+	msg.WriteString("}")
+	return []byte(msg.String())
 }
 
 func writeTimeout(ctx context.Context, timeout time.Duration, c *websocket.Conn, msg []byte) error {
@@ -65,7 +161,7 @@ func (h *SocketHub) ServeWebsocket(w http.ResponseWriter, r *http.Request) {
 	}
 	defer conn.Close(websocket.StatusInternalError, "")
 
-	err = h.subscribe(r.Context(), conn)
+	err = h.subscribe(r.Context(), conn, parseFilter(r.URL.Query().Get("filter")))
 
 	if errors.Is(err, context.Canceled) {
 		return
@@ -79,14 +175,16 @@ func (h *SocketHub) ServeWebsocket(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func (h *SocketHub) subscribe(ctx context.Context, conn *websocket.Conn) error {
-	ctx = conn.CloseRead(ctx)
+func (h *SocketHub) subscribe(ctx context.Context, conn *websocket.Conn, filter []string) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
 	s := &socketSubscriber{
 		send: make(chan []byte, 1024),
 		closeSlow: func() {
 			conn.Close(websocket.StatusPolicyViolation, "connection too slow to keep up with messages")
 		},
+		filter: filter,
 	}
 
 	h.addSubscriber(s)
@@ -95,6 +193,32 @@ func (h *SocketHub) subscribe(ctx context.Context, conn *websocket.Conn) error {
 	// send welcome message
 	h.register <- s
 
+	// handle subscription changes
+	go func() {
+		defer cancel()
+
+		for {
+			_, b, err := conn.Read(ctx)
+			if err != nil {
+				return
+			}
+
+			var req socketRequest
+			if err := json.Unmarshal(b, &req); err != nil {
+				continue
+			}
+
+			s.update(req)
+
+			// resend current state for added keys
+			select {
+			case h.register <- s:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
 	for {
 		select {
 		case msg := <-s.send:
@@ -121,34 +245,54 @@ func (h *SocketHub) deleteSubscriber(s *socketSubscriber) {
 	h.mu.Unlock()
 }
 
-func (h *SocketHub) welcome(subscriber *socketSubscriber, params []util.Param) {
-	var msg strings.Builder
-	msg.WriteString("{")
+// encodeValues encodes params once for all subscribers
+func encodeValues(params []util.Param) []socketValue {
+	res := make([]socketValue, 0, len(params))
 	for _, p := range params {
-		if msg.Len() > 1 {
-			msg.WriteString(",")
-		}
-		msg.WriteString(kv(p))
+		key, val := keyVal(p)
+		res = append(res, socketValue{key, val})
 	}
-	msg.WriteString("}")
+	return res
+}
+
+func (h *SocketHub) welcome(subscriber *socketSubscriber, params []util.Param) {
+	// full state
+	subscriber.last = make(map[string]string)
+	msg := subscriber.message(encodeValues(params), true)
 
-	// should not block
-	subscriber.send <- []byte(msg.String())
+	select {
+	case subscriber.send <- msg:
+	default:
+		subscriber.closeSlow()
+	}
 }
 
-func (h *SocketHub) broadcast(p util.Param) {
+func (h *SocketHub) broadcast(params []util.Param) {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
 
-	if len(h.subscribers) > 0 {
-		msg := "{" + kv(p) + "}"
+	if len(h.subscribers) == 0 {
+		return
+	}
 
-		for s := range h.subscribers {
-			select {
-			case s.send <- []byte(msg):
-			default:
-				s.closeSlow()
-			}
+	values := encodeValues(params)
+
+	for s := range h.subscribers {
+		// not welcomed yet
+		if s.last == nil {
+			continue
+		}
+
+		// delta only
+		msg := s.message(values, false)
+		if msg == nil {
+			continue
+		}
+
+		select {
+		case s.send <- msg:
+		default:
+			s.closeSlow()
 		}
 	}
 }
@@ -159,11 +303,27 @@ func (h *SocketHub) Run(in <-chan util.Param, cache *util.Cache) {
 		select {
 		case client := <-h.register:
 			h.welcome(client, cache.All())
-		case msg, ok := <-in:
+		case p, ok := <-in:
 			if !ok {
 				return // break if channel closed
 			}
-			h.broadcast(msg)
+
+			// combine queued updates into a single message
+			params := []util.Param{p}
+		BATCH:
+			for len(params) < socketBatchSize {
+				select {
+				case p, ok := <-in:
+					if !ok {
+						break BATCH
+					}
+					params = append(params, p)
+				default:
+					break BATCH
+				}
+			}
+
+			h.broadcast(params)
 		}
 	}
 }
@@ -54,6 +54,12 @@ func encodeSlice(v interface{}) (string, error) {
 }
 
 func kv(p util.Param) string {
+	key, val := keyVal(p)
+	return "\"" + key + "\":" + val
+}
+
+// keyVal returns the message key and the encoded value of a param
+func keyVal(p util.Param) (string, string) {
 	var (
 		val string
 		err error
@@ -72,17 +78,13 @@ func kv(p util.Param) string {
 
 	if p.Key == "" && val == "" {
 		log.ERROR.Printf("invalid key/val for %+v, please report to https://github.com/evcc-io/evcc/issues/6439", p)
-		return "\"foo\":\"bar\""
+		return "foo", "\"bar\""
 	}
 
-	var msg strings.Builder
-	msg.WriteString("\"")
+	key := p.Key
 	if p.Loadpoint != nil {
-		msg.WriteString(fmt.Sprintf("loadpoints.%d.", *p.Loadpoint))
+		key = fmt.Sprintf("loadpoints.%d.%s", *p.Loadpoint, p.Key)
 	}
-	msg.WriteString(p.Key)
-	msg.WriteString("\":")
-	msg.WriteString(val)
 
-	return msg.String()
+	return key, val
 }
@@ -49,3 +49,33 @@ func TestEncodeSlice(t *testing.T) {
 		assert.Equal(t, tc.out, out)
 	}
 }
+
+func TestSocketSubscriberFilter(t *testing.T) {
+	s := &socketSubscriber{filter: parseFilter("gridPower, loadpoints.0.*")}
+
+	assert.True(t, s.subscribed("gridPower"))
+	assert.True(t, s.subscribed("loadpoints.0.chargePower"))
+	assert.False(t, s.subscribed("loadpoints.1.chargePower"))
+	assert.False(t, s.subscribed("pvPower"))
+
+	s.update(socketRequest{Subscribe: []string{"pvPower"}, Unsubscribe: []string{"gridPower"}})
+	assert.True(t, s.subscribed("pvPower"))
+	assert.False(t, s.subscribed("gridPower"))
+
+	s.update(socketRequest{Unsubscribe: []string{"pvPower", "loadpoints.0.*"}})
+	assert.True(t, s.subscribed("anything"), "empty filter subscribes all")
+}
+
+func TestSocketSubscriberDelta(t *testing.T) {
+	s := &socketSubscriber{filter: []string{"a", "b"}, last: make(map[string]string)}
+
+	values := []socketValue{{"a", "1"}, {"b", "2"}, {"c", "3"}}
+	assert.Equal(t, `{"a":1,"b":2}`, string(s.message(values, false)))
+
+	// unchanged values are not sent
+	assert.Nil(t, s.message(values, false))
+	assert.Equal(t, `{}`, string(s.message(values, true)))
+
+	values[1].val = "4"
+	assert.Equal(t, `{"b":4}`, string(s.message(values, false)))
+}
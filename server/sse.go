@@ -0,0 +1,78 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Interval of keep-alive comments on idle event streams
+const sseKeepAlive = 30 * time.Second
+
+// ServeEvents streams the same updates as the websocket as server-sent events.
+// The optional filter query parameter limits the stream to a comma-separated list of keys.
+func (h *SocketHub) ServeEvents(w http.ResponseWriter, r *http.Request) {
+	h.serveEvents(w, r, nil)
+}
+
+// serveEvents streams the updates whose keys are allowed
+func (h *SocketHub) serveEvents(w http.ResponseWriter, r *http.Request, allow func(string) bool) {
+	rc := http.NewResponseController(w)
+
+	// streams outlive the server's write timeout
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		log.DEBUG.Println("events:", err)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.WriteHeader(http.StatusOK)
+
+	if err := rc.Flush(); err != nil {
+		log.ERROR.Println("events:", err)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	s := &socketSubscriber{
+		send:      make(chan []byte, 1024),
+		closeSlow: cancel,
+		allow:     allow,
+		filter:    parseFilter(r.URL.Query().Get("filter")),
+	}
+
+	h.addSubscriber(s)
+	defer h.deleteSubscriber(s)
+
+	// send welcome message
+	h.register <- s
+
+	ticker := time.NewTicker(sseKeepAlive)
+	defer ticker.Stop()
+
+	for {
+		var err error
+
+		select {
+		case msg := <-s.send:
+			_, err = fmt.Fprintf(w, "data: %s\n\n", msg)
+		case <-ticker.C:
+			_, err = fmt.Fprint(w, ": keep-alive\n\n")
+		case <-ctx.Done():
+			return
+		}
+
+		if err == nil {
+			err = rc.Flush()
+		}
+
+		if err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package server
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServeEvents(t *testing.T) {
+	cache := util.NewCache()
+	cache.Add("gridPower", util.Param{Key: "gridPower", Val: 1000.0})
+	cache.Add("pvPower", util.Param{Key: "pvPower", Val: 2000.0})
+
+	in := make(chan util.Param)
+	defer close(in)
+
+	hub := NewSocketHub()
+	go hub.Run(in, cache)
+
+	srv := httptest.NewServer(http.HandlerFunc(hub.ServeEvents))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "?filter=gridPower")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	r := bufio.NewReader(resp.Body)
+	readEvent := func() string {
+		line, err := r.ReadString('\n')
+		require.NoError(t, err)
+		_, err = r.ReadString('\n')
+		require.NoError(t, err)
+		return line
+	}
+
+	assert.Equal(t, "data: {\"gridPower\":1000}\n", readEvent())
+
+	in <- util.Param{Key: "pvPower", Val: 2500.0}
+	in <- util.Param{Key: "gridPower", Val: 500.0}
+	assert.Equal(t, "data: {\"gridPower\":500}\n", readEvent())
+}
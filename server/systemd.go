@@ -0,0 +1,62 @@
+//go:build !windows
+
+package server
+
+import (
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/evcc-io/evcc/core/site"
+)
+
+// sdNotify sends a state notification to the systemd notify socket, see sd_notify(3)
+func sdNotify(socket, state string) error {
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.Write([]byte(state))
+	return err
+}
+
+// SystemdWatchdog notifies the systemd watchdog as long as the site is healthy.
+// During startup, until the site has become healthy once, the watchdog is notified unconditionally
+// since the first control cycles may take longer than the watchdog timeout (e.g. slow device discovery).
+// It is only active if the watchdog is enabled by the service's WatchdogSec setting.
+func SystemdWatchdog(site site.API) {
+	socket := os.Getenv("NOTIFY_SOCKET")
+	usec, err := strconv.ParseInt(os.Getenv("WATCHDOG_USEC"), 10, 64)
+	if socket == "" || err != nil || usec <= 0 {
+		return
+	}
+
+	if pid := os.Getenv("WATCHDOG_PID"); pid != "" && pid != strconv.Itoa(os.Getpid()) {
+		return
+	}
+
+	interval := time.Duration(usec) * time.Microsecond / 2
+	log.DEBUG.Printf("systemd watchdog: %v", 2*interval)
+
+	var started bool
+
+	for tick := time.Tick(interval); ; <-tick {
+		healthy := site.Healthy()
+		if healthy && !started {
+			log.DEBUG.Println("systemd watchdog: started")
+			started = true
+		}
+
+		if started && !healthy {
+			log.DEBUG.Println("systemd watchdog: unhealthy")
+			continue
+		}
+
+		if err := sdNotify(socket, "WATCHDOG=1"); err != nil {
+			log.ERROR.Println("systemd watchdog:", err)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+//go:build windows
+
+package server
+
+import "github.com/evcc-io/evcc/core/site"
+
+// SystemdWatchdog notifies the systemd watchdog
+func SystemdWatchdog(_ site.API) {
+	// nop
+}
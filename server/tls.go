@@ -0,0 +1,189 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// TLSConfig configures https for the builtin web server
+type TLSConfig struct {
+	Port     int        // https listening port
+	Cert     string     // certificate file, e.g. issued by an external ACME client, reloaded on change
+	Key      string     // private key file
+	ACME     ACMEConfig // automatic certificates
+	Redirect bool       // redirect http to https
+}
+
+// ACMEConfig configures automatic certificates using the HTTP-01 and TLS-ALPN-01 challenges,
+// or the DNS-01 challenge if a dns hook is configured
+type ACMEConfig struct {
+	Domains   []string
+	Email     string
+	Cache     string // certificate cache directory
+	Directory string // CA directory url, defaults to Let's Encrypt
+	DNS       string // hook command publishing DNS-01 TXT records, see dnsManager
+}
+
+// Enabled returns true if https is configured
+func (c TLSConfig) Enabled() bool {
+	return c.Cert != "" || len(c.ACME.Domains) > 0
+}
+
+// certFile serves a certificate from file and reloads it when the file changes
+type certFile struct {
+	mu        sync.Mutex
+	cert, key string
+	modified  time.Time
+	tls       *tls.Certificate
+}
+
+func (c *certFile) getCertificate(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	fi, err := os.Stat(c.cert)
+	if err != nil {
+		if c.tls != nil {
+			return c.tls, nil
+		}
+		return nil, err
+	}
+
+	if c.tls == nil || fi.ModTime().After(c.modified) {
+		cert, err := tls.LoadX509KeyPair(c.cert, c.key)
+		if err != nil {
+			if c.tls != nil {
+				log.ERROR.Println("tls:", err)
+				return c.tls, nil
+			}
+			return nil, err
+		}
+
+		c.tls = &cert
+		c.modified = fi.ModTime()
+	}
+
+	return c.tls, nil
+}
+
+// redirectHandler redirects http requests to https
+func redirectHandler(port int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+
+		if port != 443 {
+			host = net.JoinHostPort(host, strconv.Itoa(port))
+		}
+
+		http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusMovedPermanently)
+	})
+}
+
+// ListenAndServeHTTPS serves https on the tls port. The http port remains available for ACME challenges and
+// either serves the ui or redirects to https.
+func (s *HTTPd) ListenAndServeHTTPS(conf TLSConfig) error {
+	if conf.Port == 0 {
+		conf.Port = 443
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+
+	handler := s.Server.Handler
+	if conf.Redirect {
+		handler = redirectHandler(conf.Port)
+	}
+
+	switch {
+	case len(conf.ACME.Domains) > 0:
+		if conf.ACME.Cache == "" {
+			return errors.New("tls: missing acme cache directory")
+		}
+
+		dir, err := homedir.Expand(conf.ACME.Cache)
+		if err != nil {
+			return fmt.Errorf("tls: %w", err)
+		}
+
+		if conf.ACME.DNS != "" {
+			m := newDNSManager(conf.ACME, dir)
+			go m.run(context.Background())
+
+			tlsConfig.GetCertificate = m.certFile().getCertificate
+			break
+		}
+
+		m := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(conf.ACME.Domains...),
+			Cache:      autocert.DirCache(dir),
+			Email:      conf.ACME.Email,
+		}
+
+		if conf.ACME.Directory != "" {
+			m.Client = &acme.Client{DirectoryURL: conf.ACME.Directory}
+		}
+
+		tlsConfig = m.TLSConfig()
+		tlsConfig.MinVersion = tls.VersionTLS12
+		handler = m.HTTPHandler(handler)
+
+	case conf.Cert != "":
+		cf := &certFile{cert: conf.Cert, key: conf.Key}
+		if _, err := cf.getCertificate(nil); err != nil {
+			return fmt.Errorf("tls: %w", err)
+		}
+
+		tlsConfig.GetCertificate = cf.getCertificate
+
+	default:
+		return errors.New("tls: missing certificate or acme domains")
+	}
+
+	srv := &http.Server{
+		Addr:         ":" + strconv.Itoa(conf.Port),
+		Handler:      s.Server.Handler,
+		TLSConfig:    tlsConfig,
+		ReadTimeout:  s.Server.ReadTimeout,
+		WriteTimeout: s.Server.WriteTimeout,
+		IdleTimeout:  s.Server.IdleTimeout,
+		ErrorLog:     s.Server.ErrorLog,
+	}
+
+	plain := &http.Server{
+		Addr:         s.Server.Addr,
+		Handler:      handler,
+		ReadTimeout:  s.Server.ReadTimeout,
+		WriteTimeout: s.Server.WriteTimeout,
+		IdleTimeout:  s.Server.IdleTimeout,
+		ErrorLog:     s.Server.ErrorLog,
+	}
+
+	errC := make(chan error, 2)
+
+	go func() {
+		errC <- plain.ListenAndServe()
+	}()
+
+	go func() {
+		errC <- srv.ListenAndServeTLS("", "")
+	}()
+
+	return <-errC
+}
@@ -0,0 +1,286 @@
+package server
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kballard/go-shellquote"
+	"golang.org/x/crypto/acme"
+)
+
+const (
+	dnsRenewBefore = 30 * 24 * time.Hour // renew certificates expiring within this period
+	dnsCheckPeriod = 12 * time.Hour      // certificate expiry check interval
+	dnsHookTimeout = 5 * time.Minute     // max duration of a dns hook call, including propagation
+)
+
+// dnsManager obtains and renews certificates using the ACME DNS-01 challenge.
+// TXT records are published by an external hook command compatible with lego's exec provider:
+//
+//	<hook> present _acme-challenge.example.com. <value>
+//	<hook> cleanup _acme-challenge.example.com. <value>
+//
+// The hook must return once the record is visible to the CA.
+type dnsManager struct {
+	client  *acme.Client
+	domains []string
+	email   string
+	dir     string
+	hook    string
+}
+
+func newDNSManager(conf ACMEConfig, dir string) *dnsManager {
+	return &dnsManager{
+		client:  &acme.Client{DirectoryURL: conf.Directory},
+		domains: conf.Domains,
+		email:   conf.Email,
+		dir:     dir,
+		hook:    conf.DNS,
+	}
+}
+
+// certFile returns the certificate files served by certFile
+func (m *dnsManager) certFile() *certFile {
+	return &certFile{
+		cert: filepath.Join(m.dir, "cert.pem"),
+		key:  filepath.Join(m.dir, "key.pem"),
+	}
+}
+
+// run obtains the certificate if missing and renews it before expiry
+func (m *dnsManager) run(ctx context.Context) {
+	for {
+		if renew, err := needsRenewal(m.certFile().cert, time.Now()); renew {
+			if err != nil {
+				log.DEBUG.Println("tls:", err)
+			}
+
+			if err := m.obtain(ctx); err != nil {
+				log.ERROR.Println("tls: dns-01:", err)
+			} else {
+				log.INFO.Printf("tls: dns-01: certificate issued for %s", strings.Join(m.domains, ", "))
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(dnsCheckPeriod):
+		}
+	}
+}
+
+// needsRenewal returns true if the certificate file is missing, invalid or about to expire
+func needsRenewal(file string, now time.Time) (bool, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return true, err
+	}
+
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return true, errors.New("invalid certificate")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return true, err
+	}
+
+	return now.Add(dnsRenewBefore).After(cert.NotAfter), nil
+}
+
+// accountKey loads or creates the ACME account key
+func (m *dnsManager) accountKey() (crypto.Signer, error) {
+	file := filepath.Join(m.dir, "acme_account.key")
+
+	if b, err := os.ReadFile(file); err == nil {
+		block, _ := pem.Decode(b)
+		if block == nil {
+			return nil, errors.New("invalid account key")
+		}
+		return x509.ParseECPrivateKey(block.Bytes)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return key, os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: b}), 0o600)
+}
+
+// obtain requests a certificate for all domains
+func (m *dnsManager) obtain(ctx context.Context) error {
+	if err := os.MkdirAll(m.dir, 0o700); err != nil {
+		return err
+	}
+
+	key, err := m.accountKey()
+	if err != nil {
+		return fmt.Errorf("account key: %w", err)
+	}
+	m.client.Key = key
+
+	var contact []string
+	if m.email != "" {
+		contact = []string{"mailto:" + m.email}
+	}
+
+	if _, err := m.client.Register(ctx, &acme.Account{Contact: contact}, acme.AcceptTOS); err != nil && !errors.Is(err, acme.ErrAccountAlreadyExists) {
+		return fmt.Errorf("register: %w", err)
+	}
+
+	order, err := m.client.AuthorizeOrder(ctx, acme.DomainIDs(m.domains...))
+	if err != nil {
+		return fmt.Errorf("order: %w", err)
+	}
+
+	for _, uri := range order.AuthzURLs {
+		if err := m.authorize(ctx, uri); err != nil {
+			return err
+		}
+	}
+
+	if order, err = m.client.WaitOrder(ctx, order.URI); err != nil {
+		return fmt.Errorf("order: %w", err)
+	}
+
+	certKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{DNSNames: m.domains}, certKey)
+	if err != nil {
+		return err
+	}
+
+	chain, _, err := m.client.CreateOrderCert(ctx, order.FinalizeURL, csr, true)
+	if err != nil {
+		return fmt.Errorf("certificate: %w", err)
+	}
+
+	return m.store(certKey, chain)
+}
+
+// authorize completes a single domain authorization using the DNS-01 challenge
+func (m *dnsManager) authorize(ctx context.Context, uri string) error {
+	authz, err := m.client.GetAuthorization(ctx, uri)
+	if err != nil {
+		return fmt.Errorf("authorization: %w", err)
+	}
+
+	if authz.Status == acme.StatusValid {
+		return nil
+	}
+
+	var chal *acme.Challenge
+	for _, c := range authz.Challenges {
+		if c.Type == "dns-01" {
+			chal = c
+			break
+		}
+	}
+
+	if chal == nil {
+		return fmt.Errorf("authorization: no dns-01 challenge for %s", authz.Identifier.Value)
+	}
+
+	value, err := m.client.DNS01ChallengeRecord(chal.Token)
+	if err != nil {
+		return err
+	}
+
+	// wildcard authorizations use the base domain
+	fqdn := "_acme-challenge." + authz.Identifier.Value + "."
+
+	if err := m.runHook(ctx, "present", fqdn, value); err != nil {
+		return err
+	}
+
+	defer func() {
+		if err := m.runHook(ctx, "cleanup", fqdn, value); err != nil {
+			log.WARN.Println("tls: dns-01:", err)
+		}
+	}()
+
+	if _, err := m.client.Accept(ctx, chal); err != nil {
+		return fmt.Errorf("accept: %w", err)
+	}
+
+	if _, err := m.client.WaitAuthorization(ctx, authz.URI); err != nil {
+		return fmt.Errorf("authorization: %w", err)
+	}
+
+	return nil
+}
+
+// runHook executes the dns hook command
+func (m *dnsManager) runHook(ctx context.Context, action, fqdn, value string) error {
+	args, err := shellquote.Split(m.hook)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return errors.New("missing dns hook")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, dnsHookTimeout)
+	defer cancel()
+
+	args = append(args, action, fqdn, value)
+
+	if b, err := exec.CommandContext(ctx, args[0], args[1:]...).CombinedOutput(); err != nil {
+		return fmt.Errorf("dns hook %s: %w: %s", action, err, strings.TrimSpace(string(b)))
+	}
+
+	return nil
+}
+
+// store writes key and certificate chain. The key is written first since certFile reloads on certificate change.
+func (m *dnsManager) store(key *ecdsa.PrivateKey, chain [][]byte) error {
+	b, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return err
+	}
+
+	cf := m.certFile()
+
+	if err := writeFileAtomic(cf.key, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: b}), 0o600); err != nil {
+		return err
+	}
+
+	var certs []byte
+	for _, der := range chain {
+		certs = append(certs, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})...)
+	}
+
+	return writeFileAtomic(cf.cert, certs, 0o644)
+}
+
+// writeFileAtomic replaces the file by renaming a temporary file
+func writeFileAtomic(name string, data []byte, perm os.FileMode) error {
+	tmp := name + ".tmp"
+	if err := os.WriteFile(tmp, data, perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp, name)
+}
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"math/big"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDNSNeedsRenewal(t *testing.T) {
+	now := time.Now()
+	m := &dnsManager{dir: t.TempDir(), domains: []string{"evcc.example.com"}}
+
+	// missing
+	renew, err := needsRenewal(m.certFile().cert, now)
+	assert.True(t, renew)
+	assert.Error(t, err)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		DNSNames:     m.domains,
+		NotBefore:    now,
+		NotAfter:     now.Add(90 * 24 * time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	require.NoError(t, m.store(key, [][]byte{der}))
+
+	// valid
+	renew, err = needsRenewal(m.certFile().cert, now)
+	require.NoError(t, err)
+	assert.False(t, renew)
+
+	// expiring
+	renew, err = needsRenewal(m.certFile().cert, now.Add(61*24*time.Hour))
+	require.NoError(t, err)
+	assert.True(t, renew)
+
+	// stored certificate is served
+	cert, err := m.certFile().getCertificate(nil)
+	require.NoError(t, err)
+	assert.Equal(t, der, cert.Certificate[0])
+}
+
+func TestDNSHook(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell hook")
+	}
+
+	dir := t.TempDir()
+	out := filepath.Join(dir, "out")
+
+	hook := filepath.Join(dir, "hook.sh")
+	require.NoError(t, os.WriteFile(hook, []byte("#!/bin/sh\necho \"$@\" >> "+out+"\n"), 0o755))
+
+	m := &dnsManager{hook: hook}
+	require.NoError(t, m.runHook(context.Background(), "present", "_acme-challenge.evcc.example.com.", "token"))
+
+	b, err := os.ReadFile(out)
+	require.NoError(t, err)
+	assert.Equal(t, "present _acme-challenge.evcc.example.com. token\n", string(b))
+
+	m.hook = "false"
+	assert.Error(t, m.runHook(context.Background(), "cleanup", "", ""))
+}
@@ -0,0 +1,28 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedirectHandler(t *testing.T) {
+	tc := []struct {
+		port      int
+		url, want string
+	}{
+		{443, "http://evcc.local:7070/api/state?jq=.", "https://evcc.local/api/state?jq=."},
+		{7443, "http://evcc.local:7070/", "https://evcc.local:7443/"},
+		{443, "http://evcc.local/", "https://evcc.local/"},
+	}
+
+	for _, tc := range tc {
+		w := httptest.NewRecorder()
+		redirectHandler(tc.port).ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.url, nil))
+
+		assert.Equal(t, http.StatusMovedPermanently, w.Code)
+		assert.Equal(t, tc.want, w.Header().Get("Location"))
+	}
+}
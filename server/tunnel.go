@@ -0,0 +1,225 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/evcc-io/evcc/util"
+	"github.com/gorilla/mux"
+	"golang.org/x/crypto/ssh"
+)
+
+// TunnelConfig configures remote access through an SSH reverse tunnel (remote port forwarding), avoiding
+// router port forwarding. Any SSH server permitting remote forwarding can be used, e.g. OpenSSH on a VPS.
+type TunnelConfig struct {
+	Server   string // ssh server host:port
+	User     string // ssh user
+	Key      string // ssh private key, PEM or file
+	HostKey  string // ssh server public key in authorized_keys format
+	Remote   string // address the ssh server listens on for clients
+	Password string // password protecting the UI served through the tunnel
+}
+
+const tunnelTimeout = 10 * time.Second
+
+// tunnelListener implements net.Listener for client connections forwarded by the ssh server.
+// The ssh connection is re-established if lost.
+type tunnelListener struct {
+	log     *util.Logger
+	conf    TunnelConfig
+	sshConf *ssh.ClientConfig
+	connC   chan net.Conn
+	ctx     context.Context
+	cancel  context.CancelFunc
+	once    sync.Once
+}
+
+// NewTunnelListener creates a listener accepting client connections forwarded by the ssh server
+func NewTunnelListener(conf TunnelConfig) (net.Listener, error) {
+	if conf.Server == "" || conf.User == "" || conf.Key == "" || conf.HostKey == "" {
+		return nil, errors.New("tunnel: missing server, user, key or hostkey")
+	}
+
+	if conf.Remote == "" {
+		conf.Remote = "localhost:7070"
+	}
+
+	conf.Server = util.DefaultPort(conf.Server, 22)
+
+	key := []byte(conf.Key)
+	if !strings.Contains(conf.Key, "-----BEGIN") {
+		var err error
+		if key, err = os.ReadFile(conf.Key); err != nil {
+			return nil, fmt.Errorf("tunnel: key: %w", err)
+		}
+	}
+
+	signer, err := ssh.ParsePrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("tunnel: key: %w", err)
+	}
+
+	hostKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(conf.HostKey))
+	if err != nil {
+		return nil, fmt.Errorf("tunnel: hostkey: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	l := &tunnelListener{
+		log:  util.NewLogger("tunnel"),
+		conf: conf,
+		sshConf: &ssh.ClientConfig{
+			User:            conf.User,
+			Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+			HostKeyCallback: ssh.FixedHostKey(hostKey),
+			Timeout:         tunnelTimeout,
+		},
+		connC:  make(chan net.Conn),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+
+	go l.run()
+
+	return l, nil
+}
+
+// run keeps the ssh connection established
+func (l *tunnelListener) run() {
+	bo := backoff.NewExponentialBackOff()
+	bo.MaxElapsedTime = 0
+	bo.MaxInterval = 5 * time.Minute
+
+	for l.ctx.Err() == nil {
+		err := l.forward(bo)
+		if l.ctx.Err() != nil {
+			return
+		}
+
+		d := bo.NextBackOff()
+		l.log.ERROR.Printf("%v, retry in %v", err, d.Round(time.Second))
+
+		select {
+		case <-l.ctx.Done():
+		case <-time.After(d):
+		}
+	}
+}
+
+// forward connects to the ssh server and hands forwarded client connections to Accept until the connection is lost
+func (l *tunnelListener) forward(bo backoff.BackOff) error {
+	client, err := ssh.Dial("tcp", l.conf.Server, l.sshConf)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	// close connection on shutdown
+	stop := context.AfterFunc(l.ctx, func() { client.Close() })
+	defer stop()
+
+	ln, err := client.Listen("tcp", l.conf.Remote)
+	if err != nil {
+		return fmt.Errorf("remote forwarding: %w", err)
+	}
+
+	l.log.INFO.Printf("forwarding %s at %s", l.conf.Remote, l.conf.Server)
+	bo.Reset()
+
+	done := make(chan struct{})
+	defer close(done)
+
+	go l.keepalive(client, done)
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return err
+		}
+
+		select {
+		case l.connC <- conn:
+		case <-l.ctx.Done():
+			conn.Close()
+			return nil
+		}
+	}
+}
+
+// keepalive detects lost ssh connections
+func (l *tunnelListener) keepalive(client *ssh.Client, done <-chan struct{}) {
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			if _, _, err := client.SendRequest("keepalive@openssh.com", true, nil); err != nil {
+				client.Close()
+				return
+			}
+		}
+	}
+}
+
+// Accept implements the net.Listener interface
+func (l *tunnelListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.connC:
+		return conn, nil
+	case <-l.ctx.Done():
+		return nil, net.ErrClosed
+	}
+}
+
+// Close implements the net.Listener interface
+func (l *tunnelListener) Close() error {
+	l.once.Do(l.cancel)
+	return nil
+}
+
+// Addr implements the net.Listener interface
+func (l *tunnelListener) Addr() net.Addr {
+	return tunnelAddr(l.conf.Remote)
+}
+
+type tunnelAddr string
+
+func (a tunnelAddr) Network() string { return "tunnel" }
+func (a tunnelAddr) String() string  { return string(a) }
+
+// tunnelAuth rejects requests without valid password using basic authentication
+func tunnelAuth(password string) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, p, ok := r.BasicAuth(); !ok || subtle.ConstantTimeCompare([]byte(p), []byte(password)) != 1 {
+				w.Header().Set("WWW-Authenticate", `Basic realm="evcc"`)
+				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// NewTunnelHTTPd creates the password protected HTTP server serving the full UI of httpd through the tunnel
+func NewTunnelHTTPd(httpd *HTTPd, password string) *HTTPd {
+	router := mux.NewRouter()
+	router.Use(tunnelAuth(password))
+	router.PathPrefix("/").Handler(httpd.Handler)
+
+	return newHTTPd("", router)
+}
@@ -0,0 +1,176 @@
+package server
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+// sshForwarder is a minimal ssh server supporting remote port forwarding
+func sshForwarder(t *testing.T, clientKey ssh.PublicKey) (string, ssh.PublicKey, <-chan string) {
+	_, hostPriv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	hostSigner, err := ssh.NewSignerFromKey(hostPriv)
+	require.NoError(t, err)
+
+	conf := &ssh.ServerConfig{
+		PublicKeyCallback: func(_ ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if string(key.Marshal()) != string(clientKey.Marshal()) {
+				return nil, io.ErrUnexpectedEOF
+			}
+			return nil, nil
+		},
+	}
+	conf.AddHostKey(hostSigner)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+
+	addrC := make(chan string, 1)
+
+	go func() {
+		c, err := l.Accept()
+		if err != nil {
+			return
+		}
+
+		conn, chans, reqs, err := ssh.NewServerConn(c, conf)
+		if err != nil {
+			return
+		}
+		go ssh.DiscardRequests(nil)
+
+		go func() {
+			for ch := range chans {
+				_ = ch.Reject(ssh.Prohibited, "")
+			}
+		}()
+
+		for req := range reqs {
+			if req.Type != "tcpip-forward" {
+				_ = req.Reply(false, nil)
+				continue
+			}
+
+			// client connections are forwarded from this listener
+			fl, err := net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				_ = req.Reply(false, nil)
+				continue
+			}
+			t.Cleanup(func() { fl.Close() })
+
+			var fwd struct {
+				Addr string
+				Port uint32
+			}
+			_ = ssh.Unmarshal(req.Payload, &fwd)
+			_ = req.Reply(true, nil)
+
+			addrC <- fl.Addr().String()
+
+			go func() {
+				for {
+					client, err := fl.Accept()
+					if err != nil {
+						return
+					}
+
+					host, port, _ := net.SplitHostPort(client.RemoteAddr().String())
+					p, _ := strconv.Atoi(port)
+
+					ch, reqs, err := conn.OpenChannel("forwarded-tcpip", ssh.Marshal(struct {
+						Addr       string
+						Port       uint32
+						OriginAddr string
+						OriginPort uint32
+					}{fwd.Addr, fwd.Port, host, uint32(p)}))
+					if err != nil {
+						client.Close()
+						continue
+					}
+					go ssh.DiscardRequests(reqs)
+
+					go func() {
+						_, _ = io.Copy(ch, client)
+						ch.CloseWrite()
+					}()
+					go func() {
+						_, _ = io.Copy(client, ch)
+						client.Close()
+					}()
+				}
+			}()
+		}
+	}()
+
+	return l.Addr().String(), hostSigner.PublicKey(), addrC
+}
+
+func TestTunnel(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	block, err := ssh.MarshalPrivateKey(priv, "")
+	require.NoError(t, err)
+
+	clientKey, err := ssh.NewPublicKey(pub)
+	require.NoError(t, err)
+
+	server, hostKey, addrC := sshForwarder(t, clientKey)
+
+	l, err := NewTunnelListener(TunnelConfig{
+		Server:  server,
+		User:    "evcc",
+		Key:     string(pem.EncodeToMemory(block)),
+		HostKey: string(ssh.MarshalAuthorizedKey(hostKey)),
+		Remote:  "localhost:7070",
+	})
+	require.NoError(t, err)
+	defer l.Close()
+
+	router := mux.NewRouter()
+	router.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	})
+
+	srv := NewTunnelHTTPd(&HTTPd{Server: &http.Server{Handler: router}}, "secret")
+	go func() { _ = srv.Serve(l) }()
+	defer srv.Close()
+
+	uri := "http://" + <-addrC + "/"
+
+	// unauthorized, forwarding becomes active once the ssh server's reply has been processed
+	require.Eventually(t, func() bool {
+		resp, err := http.Get(uri)
+		if err != nil {
+			return false
+		}
+		resp.Body.Close()
+		return resp.StatusCode == http.StatusUnauthorized
+	}, time.Second, 10*time.Millisecond)
+
+	req, err := http.NewRequest(http.MethodGet, uri, nil)
+	require.NoError(t, err)
+	req.SetBasicAuth("", "secret")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", string(b))
+}
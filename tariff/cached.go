@@ -0,0 +1,95 @@
+package tariff
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/evcc-io/evcc/util"
+)
+
+// Cached persists the rates of a forecast tariff and serves them while the tariff is unavailable,
+// e.g. after restart or during a provider outage
+type Cached struct {
+	api.Tariff
+	mu    sync.Mutex
+	log   *util.Logger
+	key   string
+	rates api.Rates
+	stale bool
+}
+
+// NewCached creates a caching tariff. Static tariffs are returned unchanged.
+func NewCached(name string, t api.Tariff) api.Tariff {
+	if t.Type() == api.TariffTypePriceStatic {
+		return t
+	}
+
+	c := &Cached{
+		Tariff: t,
+		log:    util.NewLogger(name),
+		key:    "tariff." + name,
+	}
+
+	if err := settings.Json(c.key, &c.rates); err == nil {
+		c.log.DEBUG.Printf("restored %d cached rates", len(c.rates))
+	}
+
+	return c
+}
+
+// Rates implements the api.Tariff interface
+func (t *Cached) Rates() (api.Rates, error) {
+	rates, err := t.Tariff.Rates()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err == nil && len(rates) > 0 {
+		if t.stale {
+			t.log.INFO.Println("rates available again")
+		}
+		t.stale = false
+
+		if !slices.Equal(rates, t.rates) {
+			t.rates = slices.Clone(rates)
+			if err := settings.SetJson(t.key, t.rates); err != nil {
+				t.log.ERROR.Println("cache:", err)
+			}
+		}
+
+		return rates, nil
+	}
+
+	// serve remaining cached rates
+	now := time.Now()
+	res := slices.DeleteFunc(slices.Clone(t.rates), func(r api.Rate) bool {
+		return !r.End.After(now)
+	})
+
+	if len(res) == 0 {
+		return rates, err
+	}
+
+	if !t.stale {
+		t.log.WARN.Printf("using cached rates: %v", err)
+	}
+	t.stale = true
+
+	return res, nil
+}
+
+// Stale returns true if cached rates are served
+func (t *Cached) Stale() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.stale
+}
+
+// IsStale returns true if the tariff serves cached rates
+func IsStale(t api.Tariff) bool {
+	c, ok := t.(*Cached)
+	return ok && c.Stale()
+}
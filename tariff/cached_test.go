@@ -0,0 +1,64 @@
+package tariff
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCached(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	now := time.Now().Truncate(time.Hour)
+	rates := api.Rates{
+		{Start: now.Add(-2 * time.Hour), End: now.Add(-time.Hour), Price: 0.1},
+		{Start: now.Add(-time.Hour), End: now.Add(time.Hour), Price: 0.2},
+	}
+
+	tf := api.NewMockTariff(ctrl)
+	tf.EXPECT().Type().Return(api.TariffTypePriceForecast)
+
+	c := NewCached("test", tf)
+
+	tf.EXPECT().Rates().Return(rates, nil)
+	res, err := c.Rates()
+	require.NoError(t, err)
+	assert.Equal(t, rates, res)
+	assert.False(t, IsStale(c))
+
+	// provider outage serves remaining cached rates
+	tf.EXPECT().Rates().Return(nil, api.ErrOutdated)
+	res, err = c.Rates()
+	require.NoError(t, err)
+	assert.Equal(t, rates[1:], res)
+	assert.True(t, IsStale(c))
+
+	// restored after restart
+	tf.EXPECT().Type().Return(api.TariffTypePriceForecast)
+	c = NewCached("test", tf)
+
+	tf.EXPECT().Rates().Return(nil, api.ErrOutdated)
+	res, err = c.Rates()
+	require.NoError(t, err)
+	assert.Len(t, res, 1)
+	assert.True(t, IsStale(c))
+
+	// fresh data
+	tf.EXPECT().Rates().Return(rates, nil)
+	_, err = c.Rates()
+	require.NoError(t, err)
+	assert.False(t, IsStale(c))
+}
+
+func TestCachedStatic(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	tf := api.NewMockTariff(ctrl)
+	tf.EXPECT().Type().Return(api.TariffTypePriceStatic)
+
+	assert.Equal(t, api.Tariff(tf), NewCached("static", tf))
+}
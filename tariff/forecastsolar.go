@@ -0,0 +1,140 @@
+package tariff
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+)
+
+// ForecastSolar provides a pv power forecast from https://forecast.solar
+type ForecastSolar struct {
+	log  *util.Logger
+	uri  string
+	data *util.Monitor[api.Rates]
+}
+
+type forecastSolarResponse struct {
+	Result struct {
+		Watts map[string]float64
+	}
+	Message struct {
+		Code int
+		Text string
+		Info struct {
+			Timezone string
+		}
+	}
+}
+
+var _ api.Tariff = (*ForecastSolar)(nil)
+
+func init() {
+	registry.Add("forecast-solar", NewForecastSolarFromConfig)
+}
+
+func NewForecastSolarFromConfig(other map[string]interface{}) (api.Tariff, error) {
+	var cc struct {
+		Lat, Lon    float64
+		Declination float64
+		Azimuth     float64
+		Kwp         float64
+		Apikey      string
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.Kwp <= 0 {
+		return nil, errors.New("missing kwp")
+	}
+
+	uri := "https://api.forecast.solar"
+	if cc.Apikey != "" {
+		uri += "/" + cc.Apikey
+	}
+
+	t := &ForecastSolar{
+		log:  util.NewLogger("forecast-solar"),
+		uri:  fmt.Sprintf("%s/estimate/%g/%g/%g/%g/%g", uri, cc.Lat, cc.Lon, cc.Declination, cc.Azimuth, cc.Kwp),
+		data: util.NewMonitor[api.Rates](2 * time.Hour),
+	}
+
+	done := make(chan error)
+	go t.run(done)
+	err := <-done
+
+	return t, err
+}
+
+func (t *ForecastSolar) run(done chan error) {
+	var once sync.Once
+	client := request.NewHelper(t.log)
+	bo := newBackoff()
+
+	for ; true; <-time.Tick(time.Hour) {
+		var res forecastSolarResponse
+
+		if err := backoff.Retry(func() error {
+			return backoffPermanentError(client.GetJSON(t.uri, &res))
+		}, bo); err != nil {
+			once.Do(func() { done <- err })
+
+			t.log.ERROR.Println(err)
+			continue
+		}
+
+		loc, err := time.LoadLocation(res.Message.Info.Timezone)
+		if err != nil {
+			loc = time.Local
+		}
+
+		data := make(api.Rates, 0, len(res.Result.Watts))
+		for ts, power := range res.Result.Watts {
+			start, err := time.ParseInLocation(time.DateTime, ts, loc)
+			if err != nil {
+				t.log.ERROR.Println(err)
+				continue
+			}
+
+			data = append(data, api.Rate{
+				Start: start.Local(),
+				Price: power,
+			})
+		}
+		data.Sort()
+
+		// forecast values are irregularly spaced, each slot lasts until the next one
+		for i := range data {
+			if i < len(data)-1 {
+				data[i].End = data[i+1].Start
+			} else {
+				data[i].End = data[i].Start.Add(time.Hour)
+			}
+		}
+
+		t.data.Set(data)
+		once.Do(func() { close(done) })
+	}
+}
+
+// Rates implements the api.Tariff interface
+func (t *ForecastSolar) Rates() (api.Rates, error) {
+	var res api.Rates
+	err := t.data.GetFunc(func(val api.Rates) {
+		res = slices.Clone(val)
+	})
+	return res, err
+}
+
+// Type implements the api.Tariff interface
+func (t *ForecastSolar) Type() api.TariffType {
+	return api.TariffTypeSolar
+}
@@ -1,9 +1,14 @@
 package tariff
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
+	"sync"
 	"time"
 
+	"github.com/cenkalti/backoff/v4"
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/provider"
 	"github.com/evcc-io/evcc/util"
@@ -12,7 +17,10 @@ import (
 
 type Tariff struct {
 	*embed
-	priceG func() (float64, error)
+	log       *util.Logger
+	priceG    func() (float64, error)
+	forecastG func() (string, error)
+	data      *util.Monitor[api.Rates]
 }
 
 var _ api.Tariff = (*Tariff)(nil)
@@ -22,30 +30,146 @@ func init() {
 }
 
 func NewConfigurableFromConfig(other map[string]interface{}) (api.Tariff, error) {
-	var cc struct {
-		embed `mapstructure:",squash"`
-		Price provider.Config
+	cc := struct {
+		embed    `mapstructure:",squash"`
+		Price    *provider.Config
+		Forecast *provider.Config
+		Interval time.Duration
+	}{
+		Interval: time.Hour,
 	}
 
 	if err := util.DecodeOther(other, &cc); err != nil {
 		return nil, err
 	}
 
-	priceG, err := provider.NewFloatGetterFromConfig(cc.Price)
-	if err != nil {
-		return nil, fmt.Errorf("price: %w", err)
+	t := &Tariff{
+		embed: &cc.embed,
+		log:   util.NewLogger("tariff"),
+		data:  util.NewMonitor[api.Rates](2 * cc.Interval),
 	}
 
-	t := &Tariff{
-		embed:  &cc.embed,
-		priceG: priceG,
+	switch {
+	case cc.Forecast != nil:
+		forecastG, err := provider.NewStringGetterFromConfig(*cc.Forecast)
+		if err != nil {
+			return nil, fmt.Errorf("forecast: %w", err)
+		}
+		t.forecastG = forecastG
+
+		done := make(chan error)
+		go t.run(cc.Interval, done)
+		err = <-done
+
+		return t, err
+
+	case cc.Price != nil:
+		priceG, err := provider.NewFloatGetterFromConfig(*cc.Price)
+		if err != nil {
+			return nil, fmt.Errorf("price: %w", err)
+		}
+		t.priceG = priceG
+
+		return t, nil
+
+	default:
+		return nil, errors.New("missing either price or forecast")
 	}
+}
 
-	return t, nil
+// forecastRate is a rate of the forecast series. Price can be given as value and end defaults to the next rate's start or one hour.
+type forecastRate struct {
+	Start time.Time  `json:"start"`
+	End   *time.Time `json:"end"`
+	Price *float64   `json:"price"`
+	Value *float64   `json:"value"`
+}
+
+// parseForecast parses a json rate series
+func parseForecast(s string) (api.Rates, error) {
+	var series []forecastRate
+	if err := json.Unmarshal([]byte(s), &series); err != nil {
+		return nil, fmt.Errorf("invalid forecast: %w", err)
+	}
+
+	slices.SortStableFunc(series, func(a, b forecastRate) int {
+		return a.Start.Compare(b.Start)
+	})
+
+	res := make(api.Rates, 0, len(series))
+
+	for i, r := range series {
+		price := r.Price
+		if price == nil {
+			price = r.Value
+		}
+		if r.Start.IsZero() || price == nil {
+			return nil, fmt.Errorf("invalid forecast: rate %d requires start and price", i)
+		}
+
+		end := r.Start.Add(time.Hour)
+		switch {
+		case r.End != nil:
+			end = *r.End
+		case i+1 < len(series):
+			end = series[i+1].Start
+		}
+
+		if !end.After(r.Start) {
+			return nil, fmt.Errorf("invalid forecast: rate %d ends before start", i)
+		}
+
+		res = append(res, api.Rate{
+			Start: r.Start.Local(),
+			End:   end.Local(),
+			Price: *price,
+		})
+	}
+
+	return res, nil
+}
+
+func (t *Tariff) run(interval time.Duration, done chan error) {
+	var once sync.Once
+	bo := newBackoff()
+
+	for ; true; <-time.Tick(interval) {
+		var data api.Rates
+
+		if err := backoff.Retry(func() error {
+			s, err := t.forecastG()
+			if err != nil {
+				return err
+			}
+
+			data, err = parseForecast(s)
+			return backoff.Permanent(err)
+		}, bo); err != nil {
+			once.Do(func() { done <- err })
+
+			t.log.ERROR.Println(err)
+			continue
+		}
+
+		for i, r := range data {
+			data[i].Price = t.totalPrice(r.Price)
+		}
+
+		t.data.Set(data)
+		once.Do(func() { close(done) })
+	}
 }
 
 // Rates implements the api.Tariff interface
 func (t *Tariff) Rates() (api.Rates, error) {
+	if t.forecastG != nil {
+		var res api.Rates
+		err := t.data.GetFunc(func(val api.Rates) {
+			res = slices.Clone(val)
+		})
+		return res, err
+	}
+
 	price, err := t.priceG()
 	if err != nil {
 		return nil, err
@@ -68,5 +192,8 @@ func (t *Tariff) Rates() (api.Rates, error) {
 
 // Type implements the api.Tariff interface
 func (t *Tariff) Type() api.TariffType {
+	if t.forecastG != nil {
+		return api.TariffTypePriceForecast
+	}
 	return api.TariffTypePriceDynamic
 }
@@ -0,0 +1,59 @@
+package tariff
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseForecast(t *testing.T) {
+	rates, err := parseForecast(`[
+		{"start": "2026-10-15T01:00:00Z", "value": 0.2},
+		{"start": "2026-10-15T00:00:00Z", "end": "2026-10-15T01:00:00Z", "price": 0.3},
+		{"start": "2026-10-15T02:00:00Z", "price": 0.1}
+	]`)
+	require.NoError(t, err)
+
+	ts := func(hour int) time.Time {
+		return time.Date(2026, 10, 15, hour, 0, 0, 0, time.UTC).Local()
+	}
+
+	assert.Equal(t, api.Rates{
+		{Start: ts(0), End: ts(1), Price: 0.3},
+		{Start: ts(1), End: ts(2), Price: 0.2},
+		{Start: ts(2), End: ts(3), Price: 0.1},
+	}, rates)
+
+	for _, s := range []string{
+		`{}`,
+		`[{"start": "2026-10-15T00:00:00Z"}]`,
+		`[{"price": 0.1}]`,
+		`[{"start": "2026-10-15T01:00:00Z", "end": "2026-10-15T00:00:00Z", "price": 0.1}]`,
+	} {
+		_, err := parseForecast(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestCustomForecast(t *testing.T) {
+	tf, err := NewConfigurableFromConfig(map[string]interface{}{
+		"charges": 0.1,
+		"forecast": map[string]interface{}{
+			"source": "script",
+			"cmd":    `echo '[{"start":"2026-10-15T00:00:00Z","price":0.2}]'`,
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, api.TariffTypePriceForecast, tf.Type())
+
+	rates, err := tf.Rates()
+	require.NoError(t, err)
+	require.Len(t, rates, 1)
+	assert.InDelta(t, 0.3, rates[0].Price, 1e-6)
+
+	_, err = NewConfigurableFromConfig(map[string]interface{}{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,227 @@
+package tariff
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/tariff/fixed"
+	"github.com/evcc-io/evcc/util"
+	"github.com/jinzhu/now"
+)
+
+// TouConfig is the structured time-of-use tariff configuration editable in the ui
+type TouConfig struct {
+	Price   float64     `json:"price"` // price outside of any window
+	Seasons []TouSeason `json:"seasons"`
+}
+
+// TouSeason defines the price windows for weekdays and weekends of the season's months
+type TouSeason struct {
+	Name    string      `json:"name,omitempty"`
+	Months  string      `json:"months,omitempty"` // e.g. 4-9,11, empty for the full year
+	Weekday []TouWindow `json:"weekday,omitempty"`
+	Weekend []TouWindow `json:"weekend,omitempty"`
+}
+
+// TouWindow is a daily time window with its price
+type TouWindow struct {
+	Hours string  `json:"hours"` // e.g. 06:00-22:00
+	Price float64 `json:"price"`
+}
+
+type touWindow struct {
+	hours fixed.TimeRange
+	price float64
+}
+
+type touSeason struct {
+	weekday, weekend []touWindow
+}
+
+type Tou struct {
+	clock   clock.Clock
+	price   float64
+	seasons [13]*touSeason // by month
+	dynamic bool
+}
+
+var _ api.Tariff = (*Tou)(nil)
+
+func init() {
+	registry.Add("tou", NewTouFromConfig)
+}
+
+// TouSettingsKey returns the settings key of the time-of-use tariff configured in the ui
+func TouSettingsKey(name string) string {
+	return "tariffs." + name
+}
+
+func NewTouFromConfig(other map[string]interface{}) (api.Tariff, error) {
+	var cc TouConfig
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	return NewTou(cc)
+}
+
+// NewTou validates the time-of-use configuration and creates the tariff
+func NewTou(cc TouConfig) (*Tou, error) {
+	t := &Tou{
+		clock: clock.New(),
+		price: cc.Price,
+	}
+
+	for i, s := range cc.Seasons {
+		name := cmp.Or(s.Name, strconv.Itoa(i+1))
+
+		months, err := parseMonths(s.Months)
+		if err != nil {
+			return nil, fmt.Errorf("season %s: %w", name, err)
+		}
+
+		season := new(touSeason)
+
+		if season.weekday, err = parseTouWindows(s.Weekday); err != nil {
+			return nil, fmt.Errorf("season %s: weekday: %w", name, err)
+		}
+
+		if season.weekend, err = parseTouWindows(s.Weekend); err != nil {
+			return nil, fmt.Errorf("season %s: weekend: %w", name, err)
+		}
+
+		for _, m := range months {
+			if t.seasons[m] != nil {
+				return nil, fmt.Errorf("season %s: month %d already defined", name, m)
+			}
+			t.seasons[m] = season
+		}
+
+		t.dynamic = t.dynamic || len(season.weekday)+len(season.weekend) > 0
+	}
+
+	return t, nil
+}
+
+// parseMonths parses a list of month ranges, empty for all months
+func parseMonths(s string) ([]time.Month, error) {
+	if strings.TrimSpace(s) == "" {
+		s = "1-12"
+	}
+
+	var res []time.Month
+
+	for _, segment := range strings.Split(s, ",") {
+		from, to, ok := strings.Cut(segment, "-")
+		if !ok {
+			to = from
+		}
+
+		f, err := strconv.Atoi(strings.TrimSpace(from))
+		if err != nil || f < 1 || f > 12 {
+			return nil, fmt.Errorf("invalid month: %s", from)
+		}
+
+		l, err := strconv.Atoi(strings.TrimSpace(to))
+		if err != nil || l < f || l > 12 {
+			return nil, fmt.Errorf("invalid month range: %s", segment)
+		}
+
+		for m := f; m <= l; m++ {
+			res = append(res, time.Month(m))
+		}
+	}
+
+	return res, nil
+}
+
+// parseTouWindows parses and sorts the windows and ensures they don't overlap
+func parseTouWindows(windows []TouWindow) ([]touWindow, error) {
+	res := make([]touWindow, 0, len(windows))
+
+	for _, w := range windows {
+		tr, err := fixed.ParseTimeRange(w.Hours)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, touWindow{hours: tr, price: w.Price})
+	}
+
+	slices.SortFunc(res, func(a, b touWindow) int {
+		return cmp.Compare(a.hours.From.Minutes(), b.hours.From.Minutes())
+	})
+
+	for i := 1; i < len(res); i++ {
+		if prev := res[i-1].hours; prev.To.IsNil() || prev.To.Minutes() > res[i].hours.From.Minutes() {
+			return nil, errors.New("overlapping windows: " + prev.String() + ", " + res[i].hours.String())
+		}
+	}
+
+	return res, nil
+}
+
+// windowEnd returns the end of the window in minutes of the day
+func windowEnd(tr fixed.TimeRange) int {
+	if tr.To.IsNil() {
+		return 24 * 60
+	}
+	return tr.To.Minutes()
+}
+
+// Rates implements the api.Tariff interface
+func (t *Tou) Rates() (api.Rates, error) {
+	var res api.Rates
+
+	start := now.With(t.clock.Now().Local()).BeginningOfDay()
+	for i := 0; i < 7; i++ {
+		dayStart := start.AddDate(0, 0, i)
+		ts := func(minutes int) time.Time {
+			return dayStart.Add(time.Minute * time.Duration(minutes))
+		}
+
+		var windows []touWindow
+		if season := t.seasons[dayStart.Month()]; season != nil {
+			windows = season.weekday
+			if wd := dayStart.Weekday(); wd == time.Saturday || wd == time.Sunday {
+				windows = season.weekend
+			}
+		}
+
+		// split into hourly slots like fixed tariffs for the planner
+		add := func(price float64, from, to int) {
+			for from < to {
+				end := min(to, (from/60+1)*60)
+				res = append(res, api.Rate{Price: price, Start: ts(from), End: ts(end)})
+				from = end
+			}
+		}
+
+		var cursor int
+		for _, w := range windows {
+			add(t.price, cursor, w.hours.From.Minutes())
+			cursor = windowEnd(w.hours)
+			add(w.price, w.hours.From.Minutes(), cursor)
+		}
+
+		add(t.price, cursor, 24*60)
+	}
+
+	return res, nil
+}
+
+// Type implements the api.Tariff interface
+func (t *Tou) Type() api.TariffType {
+	if t.dynamic {
+		return api.TariffTypePriceForecast
+	}
+	return api.TariffTypePriceStatic
+}
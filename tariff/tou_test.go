@@ -0,0 +1,81 @@
+package tariff
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTou(t *testing.T) {
+	at, err := NewTouFromConfig(map[string]interface{}{
+		"price": 0.3,
+		"seasons": []map[string]interface{}{
+			{
+				"name":    "summer",
+				"months":  "4-9",
+				"weekday": []map[string]interface{}{{"hours": "17-20", "price": 0.5}, {"hours": "0-6:30", "price": 0.2}},
+				"weekend": []map[string]interface{}{{"hours": "10-16", "price": 0.1}},
+			},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, api.TariffTypePriceForecast, at.Type())
+
+	tf := at.(*Tou)
+	mock := clock.NewMock()
+	tf.clock = mock
+
+	// Friday, 2026-07-03
+	mock.Set(time.Date(2026, 7, 3, 12, 0, 0, 0, time.Local))
+
+	rates, err := tf.Rates()
+	require.NoError(t, err)
+
+	price := func(ts time.Time) float64 {
+		r, err := rates.Current(ts)
+		require.NoError(t, err)
+		return r.Price
+	}
+
+	day := time.Date(2026, 7, 3, 0, 0, 0, 0, time.Local)
+	assert.Equal(t, 0.2, price(day.Add(6*time.Hour)))
+	assert.Equal(t, 0.3, price(day.Add(6*time.Hour+30*time.Minute)))
+	assert.Equal(t, 0.5, price(day.Add(19*time.Hour)))
+	assert.Equal(t, 0.3, price(day.Add(20*time.Hour)))
+
+	// Saturday
+	assert.Equal(t, 0.1, price(day.AddDate(0, 0, 1).Add(12*time.Hour)))
+	assert.Equal(t, 0.3, price(day.AddDate(0, 0, 1).Add(6*time.Hour)))
+
+	// hourly slots
+	for _, r := range rates {
+		assert.LessOrEqual(t, r.End.Sub(r.Start), time.Hour)
+	}
+
+	// outside of season
+	mock.Set(time.Date(2026, 12, 1, 12, 0, 0, 0, time.Local))
+	rates, err = tf.Rates()
+	require.NoError(t, err)
+	assert.Equal(t, 0.3, price(time.Date(2026, 12, 1, 19, 0, 0, 0, time.Local)))
+}
+
+func TestTouValidation(t *testing.T) {
+	for _, tc := range []TouConfig{
+		{Seasons: []TouSeason{{Months: "13"}}},
+		{Seasons: []TouSeason{{Months: "5-3"}}},
+		{Seasons: []TouSeason{{Months: "1-6"}, {Months: "6-12"}}},
+		{Seasons: []TouSeason{{Weekday: []TouWindow{{Hours: "6-10"}, {Hours: "9-12"}}}}},
+		{Seasons: []TouSeason{{Weekend: []TouWindow{{Hours: "22-6"}}}}},
+	} {
+		_, err := NewTou(tc)
+		assert.Error(t, err, tc)
+	}
+
+	tf, err := NewTou(TouConfig{Price: 0.3})
+	require.NoError(t, err)
+	assert.Equal(t, api.TariffTypePriceStatic, tf.Type())
+}
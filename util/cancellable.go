@@ -0,0 +1,66 @@
+package util
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Cancellable makes a getter cancellable. When the context is cancelled the getter continues in the background.
+// Calls made while the getter is still running wait for its result instead of starting another call,
+// so that a hanging device does not accumulate goroutines.
+func Cancellable[T any](g func() (T, error)) func(context.Context) (T, error) {
+	type call struct {
+		done chan struct{}
+		val  T
+		err  error
+	}
+
+	var (
+		mu      sync.Mutex
+		pending *call
+	)
+
+	return func(ctx context.Context) (T, error) {
+		mu.Lock()
+		c := pending
+		if c == nil {
+			c = &call{done: make(chan struct{})}
+			pending = c
+
+			go func() {
+				c.val, c.err = g()
+
+				mu.Lock()
+				pending = nil
+				mu.Unlock()
+
+				close(c.done)
+			}()
+		}
+		mu.Unlock()
+
+		select {
+		case <-c.done:
+			return c.val, c.err
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+	}
+}
+
+// WithTimeout returns a getter cancelled after the timeout, see Cancellable. A timeout of 0 returns the getter unchanged.
+func WithTimeout[T any](g func() (T, error), timeout time.Duration) func() (T, error) {
+	if timeout <= 0 {
+		return g
+	}
+
+	gc := Cancellable(g)
+
+	return func() (T, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		return gc(ctx)
+	}
+}
@@ -0,0 +1,55 @@
+package util
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCancellable(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+
+	g := Cancellable(func() (int, error) {
+		calls.Add(1)
+		<-release
+		return 42, nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := g(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Eventually(t, func() bool { return calls.Load() == 1 }, time.Second, time.Millisecond)
+
+	// hanging getter is not called again while pending
+	for range 3 {
+		_, err := g(ctx)
+		require.ErrorIs(t, err, context.Canceled)
+	}
+	require.Equal(t, int32(1), calls.Load())
+
+	close(release)
+
+	res, err := g(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 42, res)
+	require.LessOrEqual(t, calls.Load(), int32(2))
+}
+
+func TestWithTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	g := WithTimeout(func() (int, error) {
+		<-release
+		return 42, nil
+	}, time.Millisecond)
+
+	_, err := g()
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -138,6 +138,13 @@ func LogLevelToThreshold(level string) jww.Threshold {
 
 var uiChan chan<- Param
 
+// LogMessage is a log entry as published to the ui
+type LogMessage struct {
+	Message   string `json:"message"`
+	Level     string `json:"level"`
+	Loadpoint int    `json:"lp,omitempty"`
+}
+
 type uiWriter struct {
 	re    *regexp.Regexp
 	level string
@@ -148,11 +155,7 @@ func (w *uiWriter) Write(p []byte) (n int, err error) {
 	// trim level and timestamp
 	s := string(w.re.ReplaceAll(p, []byte{}))
 
-	val := struct {
-		Message   string `json:"message"`
-		Level     string `json:"level"`
-		Loadpoint int    `json:"lp,omitempty"`
-	}{
+	val := LogMessage{
 		Message:   strings.Trim(strconv.Quote(strings.TrimSpace(s)), "\""),
 		Level:     w.level,
 		Loadpoint: w.lp,
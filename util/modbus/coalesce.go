@@ -0,0 +1,230 @@
+package modbus
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/grid-x/modbus"
+	"golang.org/x/sync/singleflight"
+)
+
+const maxBlockLength = 125 // max registers per read request
+
+type registerRange struct {
+	addr, length uint16
+}
+
+func (r registerRange) end() int {
+	return int(r.addr) + int(r.length)
+}
+
+func (r registerRange) contains(o registerRange) bool {
+	return o.addr >= r.addr && o.end() <= r.end()
+}
+
+type blockKey struct {
+	slaveID, funcCode uint8
+}
+
+type cachedBlock struct {
+	registerRange
+	data    []byte
+	updated time.Time
+}
+
+// coalescer merges adjacent register reads of a slave into blocks and shares the block data between reads.
+// Identical reads in flight at the same time are always executed only once.
+type coalescer struct {
+	mu       sync.Mutex
+	ranges   map[blockKey][]registerRange
+	disabled map[blockKey]bool
+	cache    map[blockKey][]cachedBlock
+	inflight singleflight.Group
+}
+
+func newCoalescer() *coalescer {
+	return &coalescer{
+		ranges:   make(map[blockKey][]registerRange),
+		disabled: make(map[blockKey]bool),
+		cache:    make(map[blockKey][]cachedBlock),
+	}
+}
+
+// register adds a register range read by a plugin
+func (c *coalescer) register(key blockKey, r registerRange) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !slices.Contains(c.ranges[key], r) {
+		c.ranges[key] = append(c.ranges[key], r)
+	}
+}
+
+// block returns the block of adjacent or overlapping registered ranges containing the range
+func (c *coalescer) block(key blockKey, r registerRange) registerRange {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.disabled[key] {
+		return r
+	}
+
+	ranges := slices.Clone(c.ranges[key])
+	slices.SortFunc(ranges, func(a, b registerRange) int {
+		return int(a.addr) - int(b.addr)
+	})
+
+	var block *registerRange
+	for _, rr := range ranges {
+		if block != nil && int(rr.addr) <= block.end() && max(block.end(), rr.end())-int(block.addr) <= maxBlockLength {
+			block.length = uint16(max(block.end(), rr.end()) - int(block.addr))
+			continue
+		}
+
+		if block != nil && block.contains(r) {
+			return *block
+		}
+
+		block = &registerRange{rr.addr, rr.length}
+	}
+
+	if block != nil && block.contains(r) {
+		return *block
+	}
+
+	return r
+}
+
+// isException returns true if the device responded with a modbus exception, e.g. illegal data address
+func isException(err error) bool {
+	var me *modbus.Error
+	return errors.As(err, &me)
+}
+
+// disable stops coalescing for devices that reject block reads
+func (c *coalescer) disable(key blockKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.disabled[key] = true
+}
+
+// get returns the cached register data if not older than ttl
+func (c *coalescer) get(key blockKey, r registerRange, ttl time.Duration) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, b := range c.cache[key] {
+		if b.contains(r) && time.Since(b.updated) < ttl {
+			offset := 2 * int(r.addr-b.addr)
+			return slices.Clone(b.data[offset : offset+2*int(r.length)]), true
+		}
+	}
+
+	return nil, false
+}
+
+// put caches the block data
+func (c *coalescer) put(key blockKey, r registerRange, data []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	blocks := slices.DeleteFunc(c.cache[key], func(b cachedBlock) bool {
+		return b.registerRange == r
+	})
+
+	c.cache[key] = append(blocks, cachedBlock{registerRange: r, data: data, updated: time.Now()})
+}
+
+// invalidate removes all cached data of the slave after writing
+func (c *coalescer) invalidate(slaveID uint8) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key := range c.cache {
+		if key.slaveID == slaveID {
+			delete(c.cache, key)
+		}
+	}
+}
+
+// read executes the read once for all concurrent callers reading the same range
+func (c *coalescer) read(key blockKey, r registerRange, read func() ([]byte, error)) ([]byte, error) {
+	res, err, shared := c.inflight.Do(fmt.Sprintf("%d:%d:%d:%d", key.slaveID, key.funcCode, r.addr, r.length), func() (any, error) {
+		return read()
+	})
+
+	b, _ := res.([]byte)
+	if shared {
+		b = slices.Clone(b)
+	}
+
+	return b, err
+}
+
+// RegisterRead announces a register read for coalescing with adjacent reads of the same slave
+func (mb *Connection) RegisterRead(funcCode uint8, address, quantity uint16) {
+	if funcCode == modbus.FuncCodeReadHoldingRegisters || funcCode == modbus.FuncCodeReadInputRegisters {
+		mb.blocks.register(blockKey{mb.slaveID, funcCode}, registerRange{address, quantity})
+	}
+}
+
+func (mb *Connection) readRegisters(funcCode uint8, r registerRange) ([]byte, error) {
+	return mb.blocks.read(blockKey{mb.slaveID, funcCode}, r, func() ([]byte, error) {
+		if funcCode == modbus.FuncCodeReadInputRegisters {
+			return mb.ReadInputRegistersWithSlave(mb.slaveID, r.addr, r.length)
+		}
+		return mb.ReadHoldingRegistersWithSlave(mb.slaveID, r.addr, r.length)
+	})
+}
+
+// ReadRegistersCached reads holding or input registers. Adjacent registered reads are coalesced into a single
+// request and the result is shared with all reads within the ttl. A ttl of 0 disables caching and block reads,
+// identical concurrent reads are still executed only once.
+func (mb *Connection) ReadRegistersCached(funcCode uint8, address, quantity uint16, ttl time.Duration) ([]byte, error) {
+	key := blockKey{mb.slaveID, funcCode}
+	r := registerRange{address, quantity}
+
+	if ttl <= 0 {
+		return mb.readRegisters(funcCode, r)
+	}
+
+	if b, ok := mb.blocks.get(key, r, ttl); ok {
+		return b, nil
+	}
+
+	if block := mb.blocks.block(key, r); block != r {
+		b, err := mb.readRegisters(funcCode, block)
+		if err == nil && len(b) == 2*int(block.length) {
+			mb.blocks.put(key, block, b)
+			offset := 2 * int(r.addr-block.addr)
+			return slices.Clone(b[offset : offset+2*int(r.length)]), nil
+		}
+
+		// transient errors like timeouts are not caused by the block read
+		if err != nil && !isException(err) {
+			return nil, err
+		}
+
+		b, err = mb.readRegisters(funcCode, r)
+		if err != nil {
+			return nil, err
+		}
+
+		// device rejects block reads, fall back to single reads
+		mb.blocks.disable(key)
+		mb.blocks.put(key, r, b)
+
+		return b, nil
+	}
+
+	b, err := mb.readRegisters(funcCode, r)
+	if err == nil {
+		mb.blocks.put(key, r, b)
+	}
+
+	return b, err
+}
@@ -0,0 +1,125 @@
+package modbus
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/grid-x/modbus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoalescerBlock(t *testing.T) {
+	c := newCoalescer()
+	key := blockKey{1, 3}
+
+	for _, r := range []registerRange{{100, 2}, {102, 2}, {103, 4}, {110, 1}, {200, 100}, {290, 50}} {
+		c.register(key, r)
+	}
+
+	tc := []struct {
+		r, block registerRange
+	}{
+		{registerRange{100, 2}, registerRange{100, 7}},
+		{registerRange{103, 4}, registerRange{100, 7}},
+		{registerRange{110, 1}, registerRange{110, 1}},
+		{registerRange{200, 100}, registerRange{200, 100}}, // exceeds max block length
+		{registerRange{290, 50}, registerRange{290, 50}},
+		{registerRange{500, 1}, registerRange{500, 1}}, // not registered
+	}
+
+	for _, tc := range tc {
+		require.Equal(t, tc.block, c.block(key, tc.r), tc.r)
+	}
+
+	// other slave
+	require.Equal(t, registerRange{100, 2}, c.block(blockKey{2, 3}, registerRange{100, 2}))
+
+	c.disable(key)
+	require.Equal(t, registerRange{100, 2}, c.block(key, registerRange{100, 2}))
+}
+
+func TestCoalescerCache(t *testing.T) {
+	c := newCoalescer()
+	key := blockKey{1, 3}
+
+	c.put(key, registerRange{100, 3}, []byte{1, 2, 3, 4, 5, 6})
+
+	b, ok := c.get(key, registerRange{101, 2}, time.Minute)
+	require.True(t, ok)
+	require.Equal(t, []byte{3, 4, 5, 6}, b)
+
+	_, ok = c.get(key, registerRange{102, 2}, time.Minute)
+	require.False(t, ok)
+
+	_, ok = c.get(key, registerRange{100, 1}, 0)
+	require.False(t, ok)
+
+	c.invalidate(1)
+	_, ok = c.get(key, registerRange{100, 1}, time.Minute)
+	require.False(t, ok)
+}
+
+func TestCoalescerInflight(t *testing.T) {
+	c := newCoalescer()
+	key := blockKey{1, 3}
+
+	var calls atomic.Int32
+	started, release := make(chan struct{}), make(chan struct{})
+
+	read := func() ([]byte, error) {
+		if calls.Add(1) == 1 {
+			close(started)
+		}
+		<-release
+		return []byte{1, 2}, nil
+	}
+
+	const readers = 10
+
+	var wg sync.WaitGroup
+	res := make([][]byte, readers)
+
+	for i := range readers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			b, err := c.read(key, registerRange{100, 1}, read)
+			require.NoError(t, err)
+			res[i] = b
+		}()
+
+		// all further reads start while the first is in flight
+		if i == 0 {
+			<-started
+		}
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	require.Equal(t, int32(1), calls.Load())
+	for _, b := range res {
+		require.Equal(t, []byte{1, 2}, b)
+	}
+
+	// results are not shared between callers
+	res[0][0] = 0
+	require.Equal(t, byte(1), res[1][0])
+
+	// different range is read separately
+	_, err := c.read(key, registerRange{101, 1}, read)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), calls.Load())
+}
+
+func TestIsException(t *testing.T) {
+	exc := &modbus.Error{FunctionCode: 3, ExceptionCode: modbus.ExceptionCodeIllegalDataAddress}
+	require.True(t, isException(exc))
+	require.True(t, isException(fmt.Errorf("read: %w", exc)))
+	require.False(t, isException(errors.New("i/o timeout")))
+}
@@ -1,6 +1,7 @@
 package modbus
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
 	"strings"
@@ -50,9 +51,11 @@ func (s *Settings) String() string {
 
 // Connection decorates a meters.Connection with transparent slave id and error handling
 type Connection struct {
+	addr    string // uri or device of the physical connection
 	slaveID uint8
-	mu      sync.Mutex
+	mu      *sync.Mutex // shared by all users of the physical connection
 	conn    meters.Connection
+	blocks  *coalescer // shared by all users of the physical connection
 	delay   time.Duration
 }
 
@@ -103,6 +106,7 @@ func (mb *Connection) WriteSingleCoilWithSlave(slaveID uint8, address, value uin
 	mb.mu.Lock()
 	defer mb.mu.Unlock()
 	mb.prepare(slaveID)
+	mb.blocks.invalidate(slaveID)
 	return mb.handle(mb.conn.ModbusClient().WriteSingleCoil(address, value))
 }
 
@@ -127,6 +131,7 @@ func (mb *Connection) WriteSingleRegisterWithSlave(slaveID uint8, address, value
 	mb.mu.Lock()
 	defer mb.mu.Unlock()
 	mb.prepare(slaveID)
+	mb.blocks.invalidate(slaveID)
 	return mb.handle(mb.conn.ModbusClient().WriteSingleRegister(address, value))
 }
 
@@ -135,6 +140,7 @@ func (mb *Connection) WriteMultipleRegistersWithSlave(slaveID uint8, address, qu
 	mb.mu.Lock()
 	defer mb.mu.Unlock()
 	mb.prepare(slaveID)
+	mb.blocks.invalidate(slaveID)
 	return mb.handle(mb.conn.ModbusClient().WriteMultipleRegisters(address, quantity, value))
 }
 
@@ -151,6 +157,7 @@ func (mb *Connection) WriteMultipleCoilsWithSlave(slaveID uint8, address, quanti
 	mb.mu.Lock()
 	defer mb.mu.Unlock()
 	mb.prepare(slaveID)
+	mb.blocks.invalidate(slaveID)
 	return mb.handle(mb.conn.ModbusClient().WriteMultipleCoils(address, quantity, value))
 }
 
@@ -159,6 +166,7 @@ func (mb *Connection) ReadWriteMultipleRegistersWithSlave(slaveID uint8, readAdd
 	mb.mu.Lock()
 	defer mb.mu.Unlock()
 	mb.prepare(slaveID)
+	mb.blocks.invalidate(slaveID)
 	return mb.handle(mb.conn.ModbusClient().ReadWriteMultipleRegisters(readAddress, readQuantity, writeAddress, writeQuantity, value))
 }
 
@@ -222,12 +230,21 @@ func (mb *Connection) ReadFIFOQueue(address uint16) (results []byte, err error)
 	return mb.ReadFIFOQueueWithSlave(mb.slaveID, address)
 }
 
+// physical is a physical connection shared by all devices using the same uri or device.
+// Requests are serialized since devices like the Huawei SDongle only accept a single connection
+// and fail when requests are interleaved.
+type physical struct {
+	meters.Connection
+	mu     sync.Mutex
+	blocks *coalescer
+}
+
 var (
-	connections = make(map[string]meters.Connection)
+	connections = make(map[string]*physical)
 	mu          sync.Mutex
 )
 
-func registeredConnection(key string, newConn meters.Connection) meters.Connection {
+func registeredConnection(key string, newConn meters.Connection) *physical {
 	mu.Lock()
 	defer mu.Unlock()
 
@@ -235,9 +252,10 @@ func registeredConnection(key string, newConn meters.Connection) meters.Connecti
 		return conn
 	}
 
-	connections[key] = newConn
+	conn := &physical{Connection: newConn, blocks: newCoalescer()}
+	connections[key] = conn
 
-	return newConn
+	return conn
 }
 
 // ProtocolFromRTU identifies the wire format from the RTU setting
@@ -250,7 +268,7 @@ func ProtocolFromRTU(rtu *bool) Protocol {
 
 // NewConnection creates physical modbus device from config
 func NewConnection(uri, device, comset string, baudrate int, proto Protocol, slaveID uint8) (*Connection, error) {
-	var conn meters.Connection
+	var conn *physical
 
 	if device != "" && uri != "" {
 		return nil, errors.New("invalid modbus configuration: can only have either uri or device")
@@ -294,8 +312,11 @@ func NewConnection(uri, device, comset string, baudrate int, proto Protocol, sla
 	}
 
 	slaveConn := &Connection{
+		addr:    cmp.Or(uri, device),
 		slaveID: slaveID,
-		conn:    conn,
+		mu:      &conn.mu,
+		conn:    conn.Connection,
+		blocks:  conn.blocks,
 	}
 
 	return slaveConn, nil
@@ -23,3 +23,18 @@ func TestParsePoint(t *testing.T) {
 		require.Equal(t, tc.ops, ops)
 	}
 }
+
+func TestSharedConnection(t *testing.T) {
+	a, err := NewConnection("192.0.2.2:502", "", "", 0, Tcp, 1)
+	require.NoError(t, err)
+
+	b, err := NewConnection("192.0.2.2", "", "", 0, Tcp, 2)
+	require.NoError(t, err)
+
+	c, err := NewConnection("192.0.2.3:502", "", "", 0, Tcp, 1)
+	require.NoError(t, err)
+
+	// requests to the same physical connection are serialized
+	require.Same(t, a.mu, b.mu)
+	require.NotSame(t, a.mu, c.mu)
+}
@@ -0,0 +1,87 @@
+package modbus
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/grid-x/modbus"
+	"github.com/volkszaehler/mbmd/meters"
+	"github.com/volkszaehler/mbmd/meters/sunspec"
+)
+
+// discoveryClient serves the register reads of a previous SunSpec model discovery and records new ones.
+// Discovery only reads the model chain headers and the common model, which are static.
+type discoveryClient struct {
+	modbus.Client
+	mu     sync.Mutex
+	reads  map[string][]byte
+	cached int // number of reads served from cache
+	dirty  bool
+}
+
+func discoveryKey(address, quantity uint16) string {
+	return fmt.Sprintf("%d:%d", address, quantity)
+}
+
+// ReadHoldingRegisters implements the modbus.Client interface
+func (c *discoveryClient) ReadHoldingRegisters(address, quantity uint16) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := discoveryKey(address, quantity)
+	if b, ok := c.reads[key]; ok && len(b) == 2*int(quantity) {
+		c.cached++
+		return b, nil
+	}
+
+	b, err := c.Client.ReadHoldingRegisters(address, quantity)
+	if err == nil {
+		c.reads[key] = b
+		c.dirty = true
+	}
+
+	return b, err
+}
+
+func (mb *Connection) sunspecKey(device *sunspec.SunSpec) string {
+	return fmt.Sprintf("sunspec.%s#%d.%d", mb.addr, mb.slaveID, device.Descriptor().SubDevice)
+}
+
+// InitializeDevice initializes the device. The SunSpec model discovery is persisted per device, so that
+// subsequent startups don't need to re-scan the model chain.
+func (mb *Connection) InitializeDevice(device meters.Device) error {
+	ss, ok := device.(*sunspec.SunSpec)
+	if !ok {
+		return device.Initialize(mb)
+	}
+
+	key := mb.sunspecKey(ss)
+
+	client := &discoveryClient{
+		Client: mb,
+		reads:  make(map[string][]byte),
+	}
+
+	if err := settings.Json(key, &client.reads); err != nil || client.reads == nil {
+		client.reads = make(map[string][]byte)
+	}
+
+	err := ss.Initialize(client)
+
+	// cached model chain is outdated, e.g. after firmware update
+	if err != nil && !errors.Is(err, meters.ErrPartiallyOpened) && client.cached > 0 {
+		client.reads = make(map[string][]byte)
+		client.cached = 0
+		err = ss.Initialize(client)
+	}
+
+	if (err == nil || errors.Is(err, meters.ErrPartiallyOpened)) && client.dirty {
+		if err := settings.SetJson(key, client.reads); err != nil {
+			return err
+		}
+	}
+
+	return err
+}
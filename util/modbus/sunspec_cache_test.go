@@ -0,0 +1,50 @@
+package modbus
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/grid-x/modbus"
+	"github.com/stretchr/testify/require"
+)
+
+type countingClient struct {
+	modbus.Client
+	reads int
+}
+
+func (c *countingClient) ReadHoldingRegisters(address, quantity uint16) ([]byte, error) {
+	c.reads++
+	if address == 0 {
+		return nil, errors.New("illegal address")
+	}
+	return make([]byte, 2*quantity), nil
+}
+
+func TestDiscoveryClient(t *testing.T) {
+	conn := new(countingClient)
+	c := &discoveryClient{
+		Client: conn,
+		reads:  make(map[string][]byte),
+	}
+
+	_, err := c.ReadHoldingRegisters(40000, 2)
+	require.NoError(t, err)
+	_, err = c.ReadHoldingRegisters(0, 2)
+	require.Error(t, err)
+	require.True(t, c.dirty)
+	require.Len(t, c.reads, 1)
+
+	// replay
+	c = &discoveryClient{
+		Client: conn,
+		reads:  c.reads,
+	}
+
+	b, err := c.ReadHoldingRegisters(40000, 2)
+	require.NoError(t, err)
+	require.Len(t, b, 4)
+	require.Equal(t, 2, conn.reads)
+	require.Equal(t, 1, c.cached)
+	require.False(t, c.dirty)
+}
@@ -0,0 +1,201 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/evcc-io/evcc/server/oauth2redirect"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+	"golang.org/x/oauth2"
+)
+
+// Auth implements api.AuthProvider for the oauth2 authorization code flow with PKCE.
+// The user logs in through the ui and the tokens are persisted in the settings store.
+// Interactive challenges like captchas are handed off to the provider's login page.
+type Auth struct {
+	mu       sync.Mutex
+	log      *util.Logger
+	oc       *oauth2.Config
+	key      string
+	ctx      context.Context
+	ts       oauth2.TokenSource
+	fallback oauth2.TokenSource
+	token    *oauth2.Token
+	baseURL  string
+	authC    chan<- bool
+	verifier string
+	state    string
+	authOpts []oauth2.AuthCodeOption
+}
+
+var _ api.AuthProvider = (*Auth)(nil)
+
+// SettingsKey returns the settings key for storing tokens obtained by login
+func SettingsKey(key string) string {
+	return "oauth." + key
+}
+
+// NewAuth creates an oauth2 ui login persisting tokens under the settings key. The optional fallback token source is used while not logged in.
+func NewAuth(log *util.Logger, oc *oauth2.Config, key string, fallback oauth2.TokenSource, opts ...oauth2.AuthCodeOption) *Auth {
+	a := &Auth{
+		log:      log,
+		oc:       oc,
+		key:      key,
+		ctx:      context.WithValue(context.Background(), oauth2.HTTPClient, request.NewClient(log)),
+		fallback: fallback,
+		authOpts: opts,
+	}
+
+	var token oauth2.Token
+	if err := settings.Json(a.key, &token); err == nil && token.RefreshToken != "" {
+		a.setToken(&token)
+	}
+
+	return a
+}
+
+// setToken must be called while holding the lock
+func (a *Auth) setToken(token *oauth2.Token) {
+	a.token = token
+	a.ts = nil
+
+	if token != nil {
+		a.ts = a.oc.TokenSource(a.ctx, token)
+	}
+}
+
+func (a *Auth) publish(authenticated bool) {
+	if a.authC != nil {
+		a.authC <- authenticated
+	}
+}
+
+// Token implements oauth2.TokenSource
+func (a *Auth) Token() (*oauth2.Token, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.ts == nil {
+		if a.fallback != nil {
+			return a.fallback.Token()
+		}
+		return nil, api.ErrLoginRequired
+	}
+
+	token, err := a.ts.Token()
+	if err != nil {
+		var re *oauth2.RetrieveError
+		if errors.As(err, &re) && re.Response != nil && re.Response.StatusCode < http.StatusInternalServerError {
+			a.log.ERROR.Printf("token refresh: %v, login required", err)
+			a.logout()
+			return nil, api.ErrLoginRequired
+		}
+
+		return nil, err
+	}
+
+	// persist refreshed tokens
+	if token.AccessToken != a.token.AccessToken {
+		a.token = token
+		if err := settings.SetJson(a.key, token); err != nil {
+			a.log.ERROR.Println("token:", err)
+		}
+	}
+
+	return token, nil
+}
+
+// Authenticated returns true if logged in through the ui
+func (a *Auth) Authenticated() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.ts != nil
+}
+
+// RequireLogin hands off to the interactive ui login, e.g. if the provider requests a captcha
+func (a *Auth) RequireLogin() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.logout()
+}
+
+// logout must be called while holding the lock
+func (a *Auth) logout() {
+	a.setToken(nil)
+	settings.SetString(a.key, "")
+	a.publish(false)
+}
+
+// SetCallbackParams implements api.AuthProvider
+func (a *Auth) SetCallbackParams(baseURL, redirectURL string, authC chan<- bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.baseURL = baseURL
+	a.oc.RedirectURL = redirectURL
+	a.authC = authC
+
+	a.publish(a.ts != nil)
+}
+
+// LoginHandler implements api.AuthProvider
+func (a *Auth) LoginHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		a.mu.Lock()
+		a.verifier = oauth2.GenerateVerifier()
+		a.state = oauth2redirect.Register(a.callbackHandler)
+		opts := append([]oauth2.AuthCodeOption{oauth2.S256ChallengeOption(a.verifier)}, a.authOpts...)
+		uri := a.oc.AuthCodeURL(a.state, opts...)
+		a.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(struct {
+			LoginUri string `json:"loginUri"`
+		}{
+			LoginUri: uri,
+		})
+	}
+}
+
+// LogoutHandler implements api.AuthProvider
+func (a *Auth) LogoutHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		a.RequireLogin()
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (a *Auth) callbackHandler(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if q.Get("state") != a.state || a.verifier == "" {
+		http.Error(w, "invalid state", http.StatusBadRequest)
+		return
+	}
+
+	token, err := a.oc.Exchange(a.ctx, q.Get("code"), oauth2.VerifierOption(a.verifier))
+	a.verifier = ""
+	if err != nil {
+		a.log.ERROR.Println("login:", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	a.setToken(token)
+	if err := settings.SetJson(a.key, token); err != nil {
+		a.log.ERROR.Println("token:", err)
+	}
+
+	a.publish(true)
+
+	http.Redirect(w, r, a.baseURL, http.StatusFound)
+}
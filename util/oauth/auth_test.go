@@ -0,0 +1,77 @@
+package oauth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func TestAuthLogin(t *testing.T) {
+	var verifier string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		verifier = r.Form.Get("code_verifier")
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"access","refresh_token":"refresh","expires_in":3600}`))
+	}))
+	defer srv.Close()
+
+	oc := &oauth2.Config{
+		ClientID: "client",
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  srv.URL + "/authorize",
+			TokenURL: srv.URL + "/token",
+		},
+	}
+
+	a := NewAuth(util.NewLogger("foo"), oc, SettingsKey("test"), nil)
+
+	_, err := a.Token()
+	assert.ErrorIs(t, err, api.ErrLoginRequired)
+
+	authC := make(chan bool, 2)
+	a.SetCallbackParams("http://evcc", "http://evcc/oauth/callback", authC)
+	assert.False(t, <-authC)
+
+	// login
+	w := httptest.NewRecorder()
+	a.LoginHandler()(w, httptest.NewRequest(http.MethodPost, "/login", nil))
+
+	var res struct {
+		LoginUri string
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+
+	u, err := url.Parse(res.LoginUri)
+	require.NoError(t, err)
+	assert.Equal(t, "S256", u.Query().Get("code_challenge_method"))
+
+	// callback
+	w = httptest.NewRecorder()
+	a.callbackHandler(w, httptest.NewRequest(http.MethodGet, "/oauth/callback?code=code&state="+url.QueryEscape(u.Query().Get("state")), nil))
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.True(t, <-authC)
+	assert.NotEmpty(t, verifier)
+
+	token, err := a.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "access", token.AccessToken)
+
+	// tokens are restored
+	assert.True(t, NewAuth(util.NewLogger("foo"), oc, SettingsKey("test"), nil).Authenticated())
+
+	// logout
+	a.RequireLogin()
+	assert.False(t, <-authC)
+	assert.False(t, NewAuth(util.NewLogger("foo"), oc, SettingsKey("test"), nil).Authenticated())
+}
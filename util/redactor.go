@@ -40,6 +40,12 @@ func (l *Redactor) Write(p []byte) (n int, err error) {
 		p = bytes.ReplaceAll(p, []byte(s), []byte(RedactReplacement))
 	}
 	l.mu.Unlock()
+
+	secretsMu.Lock()
+	for _, s := range secrets {
+		p = bytes.ReplaceAll(p, []byte(s), []byte(RedactReplacement))
+	}
+	secretsMu.Unlock()
 	return os.Stdout.Write(p)
 }
 
@@ -0,0 +1,130 @@
+package util
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/mitchellh/go-homedir"
+)
+
+const filePrefix = "file://"
+
+var (
+	envRE = regexp.MustCompile(`\$\{env:([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+	secretsMu sync.Mutex
+	secrets   []string // resolved secrets redacted from all log output
+)
+
+// IsSecretRef returns true if the value references an environment variable or file
+func IsSecretRef(v any) bool {
+	s, ok := v.(string)
+	return ok && (strings.HasPrefix(s, filePrefix) || envRE.MatchString(s))
+}
+
+// addSecret registers a resolved secret for redaction
+func addSecret(s string) {
+	secretsMu.Lock()
+	defer secretsMu.Unlock()
+
+	if len(s) > 0 && RedactHook != nil {
+		secrets = append(secrets, RedactHook(s)...)
+	}
+}
+
+// resolveSecret replaces ${env:VAR} references with the environment variable and file:// references
+// with the file content
+func resolveSecret(s string) (string, error) {
+	if file, ok := strings.CutPrefix(s, filePrefix); ok {
+		file, err := homedir.Expand(file)
+		if err != nil {
+			return "", err
+		}
+
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return "", fmt.Errorf("secret: %w", err)
+		}
+
+		res := strings.TrimRight(string(b), "\r\n")
+		addSecret(res)
+
+		return res, nil
+	}
+
+	var err error
+	res := envRE.ReplaceAllStringFunc(s, func(ref string) string {
+		key := envRE.FindStringSubmatch(ref)[1]
+
+		val, ok := os.LookupEnv(key)
+		if !ok && err == nil {
+			err = fmt.Errorf("secret: missing environment variable %s", key)
+		}
+
+		addSecret(val)
+
+		return val
+	})
+
+	return res, err
+}
+
+// ResolveSecrets resolves all environment and file references in the configuration
+func ResolveSecrets(v any) (any, error) {
+	switch val := v.(type) {
+	case string:
+		if !IsSecretRef(val) {
+			return val, nil
+		}
+		return resolveSecret(val)
+
+	case map[string]any:
+		res := make(map[string]any, len(val))
+		for k, v := range val {
+			r, err := ResolveSecrets(v)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", k, err)
+			}
+			res[k] = r
+		}
+		return res, nil
+
+	case map[any]any:
+		res := make(map[any]any, len(val))
+		for k, v := range val {
+			r, err := ResolveSecrets(v)
+			if err != nil {
+				return nil, fmt.Errorf("%v: %w", k, err)
+			}
+			res[k] = r
+		}
+		return res, nil
+
+	case []any:
+		res := make([]any, 0, len(val))
+		for i, v := range val {
+			r, err := ResolveSecrets(v)
+			if err != nil {
+				return nil, fmt.Errorf("%d: %w", i, err)
+			}
+			res = append(res, r)
+		}
+		return res, nil
+
+	default:
+		return v, nil
+	}
+}
+
+// ResolveSecretsMap resolves all environment and file references in the configuration map.
+// It applies to database configurations, the config file is resolved once when reading it.
+func ResolveSecretsMap(other map[string]any) (map[string]any, error) {
+	res, err := ResolveSecrets(other)
+	if err != nil || res == nil {
+		return other, err
+	}
+	return res.(map[string]any), nil
+}
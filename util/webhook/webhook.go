@@ -0,0 +1,57 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrUnknown is returned for payloads pushed to an unregistered webhook
+var ErrUnknown = errors.New("unknown webhook")
+
+// Handler processes a payload pushed by a vendor cloud or device
+type Handler func(payload []byte) error
+
+var (
+	mu       sync.RWMutex
+	handlers = make(map[string]Handler)
+)
+
+// Register adds a handler for the webhook id. The id is part of the webhook url and should be kept secret.
+func Register(id string, handler Handler) error {
+	if id == "" {
+		return errors.New("missing webhook id")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if _, ok := handlers[id]; ok {
+		return fmt.Errorf("duplicate webhook id: %s", id)
+	}
+
+	handlers[id] = handler
+
+	return nil
+}
+
+// Unregister removes the handler for the webhook id
+func Unregister(id string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	delete(handlers, id)
+}
+
+// Handle dispatches the payload to the handler registered for the webhook id
+func Handle(id string, payload []byte) error {
+	mu.RLock()
+	handler, ok := handlers[id]
+	mu.RUnlock()
+
+	if !ok {
+		return ErrUnknown
+	}
+
+	return handler(payload)
+}
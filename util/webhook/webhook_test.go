@@ -0,0 +1,27 @@
+package webhook
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhook(t *testing.T) {
+	var received []byte
+
+	require.NoError(t, Register("secret", func(payload []byte) error {
+		received = payload
+		return nil
+	}))
+	defer Unregister("secret")
+
+	assert.Error(t, Register("secret", nil), "duplicate id")
+	assert.Error(t, Register("", nil), "missing id")
+
+	require.NoError(t, Handle("secret", []byte("foo")))
+	assert.Equal(t, []byte("foo"), received)
+
+	assert.True(t, errors.Is(Handle("other", nil), ErrUnknown))
+}
@@ -1,18 +1,23 @@
 package vehicle
 
 import (
+	"slices"
+	"time"
+
 	"github.com/evcc-io/evcc/api"
 )
 
 // TODO align phases with OnIdentify
 type embed struct {
-	Title_       string           `mapstructure:"title"`
-	Icon_        string           `mapstructure:"icon"`
-	Capacity_    float64          `mapstructure:"capacity"`
-	Phases_      int              `mapstructure:"phases"`
-	Identifiers_ []string         `mapstructure:"identifiers"`
-	Features_    []api.Feature    `mapstructure:"features"`
-	OnIdentify   api.ActionConfig `mapstructure:"onIdentify"`
+	Title_              string           `mapstructure:"title"`
+	Icon_               string           `mapstructure:"icon"`
+	Capacity_           float64          `mapstructure:"capacity"`
+	Phases_             int              `mapstructure:"phases"`
+	PhaseSwitchPenalty_ time.Duration    `mapstructure:"phaseSwitchPenalty"`
+	Identifiers_        []string         `mapstructure:"identifiers"`
+	Features_           []api.Feature    `mapstructure:"features"`
+	OnIdentify          api.ActionConfig `mapstructure:"onIdentify"`
+	ChargeCurve_        api.ChargeCurve  `mapstructure:"chargeCurve"`
 }
 
 // Title implements the api.Vehicle interface
@@ -68,3 +73,19 @@ var _ api.FeatureDescriber = (*embed)(nil)
 func (v *embed) Features() []api.Feature {
 	return v.Features_
 }
+
+var _ api.PhaseSwitchPenaltyDescriber = (*embed)(nil)
+
+// PhaseSwitchPenalty implements the api.PhaseSwitchPenaltyDescriber interface
+func (v *embed) PhaseSwitchPenalty() time.Duration {
+	return v.PhaseSwitchPenalty_
+}
+
+var _ api.ChargeCurveDescriber = (*embed)(nil)
+
+// ChargeCurve implements the api.ChargeCurveDescriber interface
+func (v *embed) ChargeCurve() api.ChargeCurve {
+	res := slices.Clone(v.ChargeCurve_)
+	res.Sort()
+	return res
+}
@@ -0,0 +1,66 @@
+package vehicle
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+)
+
+// Manual is an api.Vehicle implementation for vehicles without api. The soc is entered
+// by the user and advanced by the loadpoint's charged energy.
+type Manual struct {
+	*embed
+	mu  sync.Mutex
+	soc *float64
+}
+
+func init() {
+	registry.Add("manual", NewManualFromConfig)
+}
+
+// NewManualFromConfig creates a new vehicle
+func NewManualFromConfig(other map[string]interface{}) (api.Vehicle, error) {
+	var cc embed
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.Capacity_ <= 0 {
+		return nil, errors.New("missing capacity")
+	}
+
+	return &Manual{embed: &cc}, nil
+}
+
+var _ api.SocSetter = (*Manual)(nil)
+
+// SetSoc implements the api.SocSetter interface
+func (v *Manual) SetSoc(soc float64) error {
+	if soc < 0 || soc > 100 {
+		return fmt.Errorf("invalid soc: %.0f", soc)
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.soc = &soc
+
+	return nil
+}
+
+// Soc implements the api.Vehicle interface
+func (v *Manual) Soc() (float64, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	// retry until the soc has been entered
+	if v.soc == nil {
+		return 0, api.ErrMustRetry
+	}
+
+	return *v.soc, nil
+}
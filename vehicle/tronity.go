@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"net/http"
 	"slices"
+	"sync"
 	"time"
 
 	"github.com/evcc-io/evcc/api"
@@ -38,7 +39,10 @@ import (
 type Tronity struct {
 	*embed
 	*request.Helper
+	*oauth.Auth
 	oc    *oauth2.Config
+	mu    sync.Mutex
+	vin   string
 	vid   string
 	bulkG func() (tronity.Bulk, error)
 }
@@ -85,24 +89,28 @@ func NewTronityFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 		embed:  &cc.embed,
 		Helper: request.NewHelper(log),
 		oc:     oc,
+		vin:    cc.VIN,
 	}
 
-	var ts oauth2.TokenSource
+	var fallback oauth2.TokenSource
 	token, err := cc.Tokens.Token()
 
 	// https://app.platform.tronity.io/docs#tag/Authentication
 	if err != nil {
 		// use app flow if we don't have tokens
-		ts = oauth.RefreshTokenSource(nil, v)
+		fallback = oauth.RefreshTokenSource(nil, v)
 	} else {
 		// use provided tokens generated by code flow
 		ctx := context.WithValue(context.Background(), oauth2.HTTPClient, request.NewClient(log))
-		ts = oc.TokenSource(ctx, token)
+		fallback = oc.TokenSource(ctx, token)
 	}
 
+	// tokens from ui login take precedence
+	v.Auth = oauth.NewAuth(log, oc, tronity.SettingsKey(cc.VIN, cc.Credentials.ID), fallback)
+
 	// replace client transport with authenticated transport
 	v.Client.Transport = &oauth2.Transport{
-		Source: ts,
+		Source: v.Auth,
 		Base:   v.Client.Transport,
 	}
 
@@ -112,11 +120,17 @@ func NewTronityFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 			return v.VIN
 		},
 	)
-	if err != nil {
+
+	switch {
+	case err == nil:
+		v.vid = vehicle.ID
+	case !v.Authenticated():
+		// vehicle is resolved after login through the ui, assume all scopes are granted
+		log.WARN.Printf("login required: %v", err)
+		vehicle.Scopes = oc.Scopes
+	default:
 		return nil, err
 	}
-
-	v.vid = vehicle.ID
 	v.bulkG = provider.Cached(v.bulk, cc.Cache)
 
 	var status func() (api.ChargeStatus, error)
@@ -158,6 +172,28 @@ func (v *Tronity) RefreshToken(_ *oauth2.Token) (*oauth2.Token, error) {
 	return (*oauth2.Token)(&token), err
 }
 
+// vehicleID returns the vehicle id, resolving the vehicle after login through the ui
+func (v *Tronity) vehicleID() (string, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.vid != "" {
+		return v.vid, nil
+	}
+
+	vehicle, err := ensureVehicleEx(
+		v.vin, v.vehicles,
+		func(v tronity.Vehicle) string {
+			return v.VIN
+		},
+	)
+	if err == nil {
+		v.vid = vehicle.ID
+	}
+
+	return v.vid, err
+}
+
 // vehicles implements the vehicles api
 func (v *Tronity) vehicles() ([]tronity.Vehicle, error) {
 	uri := fmt.Sprintf("%s/tronity/vehicles", tronity.URI)
@@ -170,10 +206,15 @@ func (v *Tronity) vehicles() ([]tronity.Vehicle, error) {
 
 // bulk implements the bulk api
 func (v *Tronity) bulk() (tronity.Bulk, error) {
-	uri := fmt.Sprintf("%s/tronity/vehicles/%s/last_record", tronity.URI, v.vid)
-
 	var res tronity.Bulk
-	err := v.GetJSON(uri, &res)
+
+	vid, err := v.vehicleID()
+	if err != nil {
+		return res, err
+	}
+
+	uri := fmt.Sprintf("%s/tronity/vehicles/%s/last_record", tronity.URI, vid)
+	err = v.GetJSON(uri, &res)
 
 	return res, err
 }
@@ -234,12 +275,22 @@ func (v *Tronity) post(uri string) error {
 
 // startCharge implements the api.VehicleChargeController interface
 func (v *Tronity) startCharge() error {
-	uri := fmt.Sprintf("%s/tronity/vehicles/%s/start_charging", tronity.URI, v.vid)
+	vid, err := v.vehicleID()
+	if err != nil {
+		return err
+	}
+
+	uri := fmt.Sprintf("%s/tronity/vehicles/%s/start_charging", tronity.URI, vid)
 	return v.post(uri)
 }
 
 // stopCharge implements the api.VehicleChargeController interface
 func (v *Tronity) stopCharge() error {
-	uri := fmt.Sprintf("%s/tronity/vehicles/%s/stop_charging", tronity.URI, v.vid)
+	vid, err := v.vehicleID()
+	if err != nil {
+		return err
+	}
+
+	uri := fmt.Sprintf("%s/tronity/vehicles/%s/stop_charging", tronity.URI, vid)
 	return v.post(uri)
 }
@@ -1,6 +1,10 @@
 package tronity
 
 import (
+	"cmp"
+	"strings"
+
+	"github.com/evcc-io/evcc/util/oauth"
 	"golang.org/x/oauth2"
 )
 
@@ -17,3 +21,8 @@ func OAuth2Config(id, secret string) (*oauth2.Config, error) {
 		Scopes: []string{"read_vin", "read_vehicle_info", "read_odometer", "read_charge", "read_charge", "read_battery", "read_location", "write_charge_start_stop", "write_wake_up"},
 	}, nil
 }
+
+// SettingsKey is the settings key of tokens obtained by login
+func SettingsKey(vin, id string) string {
+	return oauth.SettingsKey("tronity." + strings.ToLower(cmp.Or(vin, id)))
+}
@@ -8,7 +8,7 @@ import (
 	"github.com/evcc-io/evcc/util"
 )
 
-//go:generate go run ../cmd/tools/decorate.go -f decorateVehicle -b api.Vehicle -t "api.ChargeState,Status,func() (api.ChargeStatus, error)" -t "api.VehicleRange,Range,func() (int64, error)" -t "api.VehicleOdometer,Odometer,func() (float64, error)" -t "api.VehicleClimater,Climater,func() (bool, error)" -t "api.Resurrector,WakeUp,func() error"
+//go:generate go run ../cmd/tools/decorate.go -f decorateVehicle -b api.Vehicle -t "api.ChargeState,Status,func() (api.ChargeStatus, error)" -t "api.VehicleRange,Range,func() (int64, error)" -t "api.VehicleOdometer,Odometer,func() (float64, error)" -t "api.VehicleClimater,Climater,func() (bool, error)" -t "api.Resurrector,WakeUp,func() error" -t "api.VehiclePreconditioner,Precondition,func() error"
 
 // Vehicle is an api.Vehicle implementation with configurable getters and setters.
 type Vehicle struct {
@@ -23,13 +23,14 @@ func init() {
 // NewConfigurableFromConfig creates a new Vehicle
 func NewConfigurableFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 	var cc struct {
-		embed    `mapstructure:",squash"`
-		Soc      provider.Config
-		Status   *provider.Config
-		Range    *provider.Config
-		Odometer *provider.Config
-		Climater *provider.Config
-		Wakeup   *provider.Config
+		embed        `mapstructure:",squash"`
+		Soc          provider.Config
+		Status       *provider.Config
+		Range        *provider.Config
+		Odometer     *provider.Config
+		Climater     *provider.Config
+		Wakeup       *provider.Config
+		Precondition *provider.Config
 	}
 
 	if err := util.DecodeOther(other, &cc); err != nil {
@@ -104,7 +105,19 @@ func NewConfigurableFromConfig(other map[string]interface{}) (api.Vehicle, error
 		}
 	}
 
-	return decorateVehicle(v, status, rng, odo, climater, wakeup), nil
+	// decorate precondition
+	var precondition func() error
+	if cc.Precondition != nil {
+		preconditionS, err := provider.NewBoolSetterFromConfig("precondition", *cc.Precondition)
+		if err != nil {
+			return nil, fmt.Errorf("precondition: %w", err)
+		}
+		precondition = func() error {
+			return preconditionS(true)
+		}
+	}
+
+	return decorateVehicle(v, status, rng, odo, climater, wakeup, precondition), nil
 }
 
 // Soc implements the api.Vehicle interface
@@ -6,12 +6,12 @@ import (
 	"github.com/evcc-io/evcc/api"
 )
 
-func decorateVehicle(base api.Vehicle, chargeState func() (api.ChargeStatus, error), vehicleRange func() (int64, error), vehicleOdometer func() (float64, error), vehicleClimater func() (bool, error), resurrector func() error) api.Vehicle {
+func decorateVehicle(base api.Vehicle, chargeState func() (api.ChargeStatus, error), vehicleRange func() (int64, error), vehicleOdometer func() (float64, error), vehicleClimater func() (bool, error), resurrector func() error, vehiclePreconditioner func() error) api.Vehicle {
 	switch {
-	case chargeState == nil && resurrector == nil && vehicleClimater == nil && vehicleOdometer == nil && vehicleRange == nil:
+	case chargeState == nil && resurrector == nil && vehicleClimater == nil && vehicleOdometer == nil && vehiclePreconditioner == nil && vehicleRange == nil:
 		return base
 
-	case chargeState != nil && resurrector == nil && vehicleClimater == nil && vehicleOdometer == nil && vehicleRange == nil:
+	case chargeState != nil && resurrector == nil && vehicleClimater == nil && vehicleOdometer == nil && vehiclePreconditioner == nil && vehicleRange == nil:
 		return &struct {
 			api.Vehicle
 			api.ChargeState
@@ -22,7 +22,7 @@ func decorateVehicle(base api.Vehicle, chargeState func() (api.ChargeStatus, err
 			},
 		}
 
-	case chargeState == nil && resurrector == nil && vehicleClimater == nil && vehicleOdometer == nil && vehicleRange != nil:
+	case chargeState == nil && resurrector == nil && vehicleClimater == nil && vehicleOdometer == nil && vehiclePreconditioner == nil && vehicleRange != nil:
 		return &struct {
 			api.Vehicle
 			api.VehicleRange
@@ -33,7 +33,7 @@ func decorateVehicle(base api.Vehicle, chargeState func() (api.ChargeStatus, err
 			},
 		}
 
-	case chargeState != nil && resurrector == nil && vehicleClimater == nil && vehicleOdometer == nil && vehicleRange != nil:
+	case chargeState != nil && resurrector == nil && vehicleClimater == nil && vehicleOdometer == nil && vehiclePreconditioner == nil && vehicleRange != nil:
 		return &struct {
 			api.Vehicle
 			api.ChargeState
@@ -48,7 +48,7 @@ func decorateVehicle(base api.Vehicle, chargeState func() (api.ChargeStatus, err
 			},
 		}
 
-	case chargeState == nil && resurrector == nil && vehicleClimater == nil && vehicleOdometer != nil && vehicleRange == nil:
+	case chargeState == nil && resurrector == nil && vehicleClimater == nil && vehicleOdometer != nil && vehiclePreconditioner == nil && vehicleRange == nil:
 		return &struct {
 			api.Vehicle
 			api.VehicleOdometer
@@ -59,7 +59,7 @@ func decorateVehicle(base api.Vehicle, chargeState func() (api.ChargeStatus, err
 			},
 		}
 
-	case chargeState != nil && resurrector == nil && vehicleClimater == nil && vehicleOdometer != nil && vehicleRange == nil:
+	case chargeState != nil && resurrector == nil && vehicleClimater == nil && vehicleOdometer != nil && vehiclePreconditioner == nil && vehicleRange == nil:
 		return &struct {
 			api.Vehicle
 			api.ChargeState
@@ -74,7 +74,7 @@ func decorateVehicle(base api.Vehicle, chargeState func() (api.ChargeStatus, err
 			},
 		}
 
-	case chargeState == nil && resurrector == nil && vehicleClimater == nil && vehicleOdometer != nil && vehicleRange != nil:
+	case chargeState == nil && resurrector == nil && vehicleClimater == nil && vehicleOdometer != nil && vehiclePreconditioner == nil && vehicleRange != nil:
 		return &struct {
 			api.Vehicle
 			api.VehicleOdometer
@@ -89,7 +89,7 @@ func decorateVehicle(base api.Vehicle, chargeState func() (api.ChargeStatus, err
 			},
 		}
 
-	case chargeState != nil && resurrector == nil && vehicleClimater == nil && vehicleOdometer != nil && vehicleRange != nil:
+	case chargeState != nil && resurrector == nil && vehicleClimater == nil && vehicleOdometer != nil && vehiclePreconditioner == nil && vehicleRange != nil:
 		return &struct {
 			api.Vehicle
 			api.ChargeState
@@ -108,7 +108,7 @@ func decorateVehicle(base api.Vehicle, chargeState func() (api.ChargeStatus, err
 			},
 		}
 
-	case chargeState == nil && resurrector == nil && vehicleClimater != nil && vehicleOdometer == nil && vehicleRange == nil:
+	case chargeState == nil && resurrector == nil && vehicleClimater != nil && vehicleOdometer == nil && vehiclePreconditioner == nil && vehicleRange == nil:
 		return &struct {
 			api.Vehicle
 			api.VehicleClimater
@@ -119,7 +119,7 @@ func decorateVehicle(base api.Vehicle, chargeState func() (api.ChargeStatus, err
 			},
 		}
 
-	case chargeState != nil && resurrector == nil && vehicleClimater != nil && vehicleOdometer == nil && vehicleRange == nil:
+	case chargeState != nil && resurrector == nil && vehicleClimater != nil && vehicleOdometer == nil && vehiclePreconditioner == nil && vehicleRange == nil:
 		return &struct {
 			api.Vehicle
 			api.ChargeState
@@ -134,7 +134,7 @@ func decorateVehicle(base api.Vehicle, chargeState func() (api.ChargeStatus, err
 			},
 		}
 
-	case chargeState == nil && resurrector == nil && vehicleClimater != nil && vehicleOdometer == nil && vehicleRange != nil:
+	case chargeState == nil && resurrector == nil && vehicleClimater != nil && vehicleOdometer == nil && vehiclePreconditioner == nil && vehicleRange != nil:
 		return &struct {
 			api.Vehicle
 			api.VehicleClimater
@@ -149,7 +149,7 @@ func decorateVehicle(base api.Vehicle, chargeState func() (api.ChargeStatus, err
 			},
 		}
 
-	case chargeState != nil && resurrector == nil && vehicleClimater != nil && vehicleOdometer == nil && vehicleRange != nil:
+	case chargeState != nil && resurrector == nil && vehicleClimater != nil && vehicleOdometer == nil && vehiclePreconditioner == nil && vehicleRange != nil:
 		return &struct {
 			api.Vehicle
 			api.ChargeState
@@ -168,7 +168,7 @@ func decorateVehicle(base api.Vehicle, chargeState func() (api.ChargeStatus, err
 			},
 		}
 
-	case chargeState == nil && resurrector == nil && vehicleClimater != nil && vehicleOdometer != nil && vehicleRange == nil:
+	case chargeState == nil && resurrector == nil && vehicleClimater != nil && vehicleOdometer != nil && vehiclePreconditioner == nil && vehicleRange == nil:
 		return &struct {
 			api.Vehicle
 			api.VehicleClimater
@@ -183,7 +183,7 @@ func decorateVehicle(base api.Vehicle, chargeState func() (api.ChargeStatus, err
 			},
 		}
 
-	case chargeState != nil && resurrector == nil && vehicleClimater != nil && vehicleOdometer != nil && vehicleRange == nil:
+	case chargeState != nil && resurrector == nil && vehicleClimater != nil && vehicleOdometer != nil && vehiclePreconditioner == nil && vehicleRange == nil:
 		return &struct {
 			api.Vehicle
 			api.ChargeState
@@ -202,7 +202,7 @@ func decorateVehicle(base api.Vehicle, chargeState func() (api.ChargeStatus, err
 			},
 		}
 
-	case chargeState == nil && resurrector == nil && vehicleClimater != nil && vehicleOdometer != nil && vehicleRange != nil:
+	case chargeState == nil && resurrector == nil && vehicleClimater != nil && vehicleOdometer != nil && vehiclePreconditioner == nil && vehicleRange != nil:
 		return &struct {
 			api.Vehicle
 			api.VehicleClimater
@@ -221,7 +221,7 @@ func decorateVehicle(base api.Vehicle, chargeState func() (api.ChargeStatus, err
 			},
 		}
 
-	case chargeState != nil && resurrector == nil && vehicleClimater != nil && vehicleOdometer != nil && vehicleRange != nil:
+	case chargeState != nil && resurrector == nil && vehicleClimater != nil && vehicleOdometer != nil && vehiclePreconditioner == nil && vehicleRange != nil:
 		return &struct {
 			api.Vehicle
 			api.ChargeState
@@ -244,7 +244,7 @@ func decorateVehicle(base api.Vehicle, chargeState func() (api.ChargeStatus, err
 			},
 		}
 
-	case chargeState == nil && resurrector != nil && vehicleClimater == nil && vehicleOdometer == nil && vehicleRange == nil:
+	case chargeState == nil && resurrector != nil && vehicleClimater == nil && vehicleOdometer == nil && vehiclePreconditioner == nil && vehicleRange == nil:
 		return &struct {
 			api.Vehicle
 			api.Resurrector
@@ -255,7 +255,7 @@ func decorateVehicle(base api.Vehicle, chargeState func() (api.ChargeStatus, err
 			},
 		}
 
-	case chargeState != nil && resurrector != nil && vehicleClimater == nil && vehicleOdometer == nil && vehicleRange == nil:
+	case chargeState != nil && resurrector != nil && vehicleClimater == nil && vehicleOdometer == nil && vehiclePreconditioner == nil && vehicleRange == nil:
 		return &struct {
 			api.Vehicle
 			api.ChargeState
@@ -270,7 +270,7 @@ func decorateVehicle(base api.Vehicle, chargeState func() (api.ChargeStatus, err
 			},
 		}
 
-	case chargeState == nil && resurrector != nil && vehicleClimater == nil && vehicleOdometer == nil && vehicleRange != nil:
+	case chargeState == nil && resurrector != nil && vehicleClimater == nil && vehicleOdometer == nil && vehiclePreconditioner == nil && vehicleRange != nil:
 		return &struct {
 			api.Vehicle
 			api.Resurrector
@@ -285,7 +285,7 @@ func decorateVehicle(base api.Vehicle, chargeState func() (api.ChargeStatus, err
 			},
 		}
 
-	case chargeState != nil && resurrector != nil && vehicleClimater == nil && vehicleOdometer == nil && vehicleRange != nil:
+	case chargeState != nil && resurrector != nil && vehicleClimater == nil && vehicleOdometer == nil && vehiclePreconditioner == nil && vehicleRange != nil:
 		return &struct {
 			api.Vehicle
 			api.ChargeState
@@ -304,7 +304,7 @@ func decorateVehicle(base api.Vehicle, chargeState func() (api.ChargeStatus, err
 			},
 		}
 
-	case chargeState == nil && resurrector != nil && vehicleClimater == nil && vehicleOdometer != nil && vehicleRange == nil:
+	case chargeState == nil && resurrector != nil && vehicleClimater == nil && vehicleOdometer != nil && vehiclePreconditioner == nil && vehicleRange == nil:
 		return &struct {
 			api.Vehicle
 			api.Resurrector
@@ -319,7 +319,7 @@ func decorateVehicle(base api.Vehicle, chargeState func() (api.ChargeStatus, err
 			},
 		}
 
-	case chargeState != nil && resurrector != nil && vehicleClimater == nil && vehicleOdometer != nil && vehicleRange == nil:
+	case chargeState != nil && resurrector != nil && vehicleClimater == nil && vehicleOdometer != nil && vehiclePreconditioner == nil && vehicleRange == nil:
 		return &struct {
 			api.Vehicle
 			api.ChargeState
@@ -338,7 +338,7 @@ func decorateVehicle(base api.Vehicle, chargeState func() (api.ChargeStatus, err
 			},
 		}
 
-	case chargeState == nil && resurrector != nil && vehicleClimater == nil && vehicleOdometer != nil && vehicleRange != nil:
+	case chargeState == nil && resurrector != nil && vehicleClimater == nil && vehicleOdometer != nil && vehiclePreconditioner == nil && vehicleRange != nil:
 		return &struct {
 			api.Vehicle
 			api.Resurrector
@@ -357,7 +357,7 @@ func decorateVehicle(base api.Vehicle, chargeState func() (api.ChargeStatus, err
 			},
 		}
 
-	case chargeState != nil && resurrector != nil && vehicleClimater == nil && vehicleOdometer != nil && vehicleRange != nil:
+	case chargeState != nil && resurrector != nil && vehicleClimater == nil && vehicleOdometer != nil && vehiclePreconditioner == nil && vehicleRange != nil:
 		return &struct {
 			api.Vehicle
 			api.ChargeState
@@ -380,7 +380,7 @@ func decorateVehicle(base api.Vehicle, chargeState func() (api.ChargeStatus, err
 			},
 		}
 
-	case chargeState == nil && resurrector != nil && vehicleClimater != nil && vehicleOdometer == nil && vehicleRange == nil:
+	case chargeState == nil && resurrector != nil && vehicleClimater != nil && vehicleOdometer == nil && vehiclePreconditioner == nil && vehicleRange == nil:
 		return &struct {
 			api.Vehicle
 			api.Resurrector
@@ -395,7 +395,7 @@ func decorateVehicle(base api.Vehicle, chargeState func() (api.ChargeStatus, err
 			},
 		}
 
-	case chargeState != nil && resurrector != nil && vehicleClimater != nil && vehicleOdometer == nil && vehicleRange == nil:
+	case chargeState != nil && resurrector != nil && vehicleClimater != nil && vehicleOdometer == nil && vehiclePreconditioner == nil && vehicleRange == nil:
 		return &struct {
 			api.Vehicle
 			api.ChargeState
@@ -414,7 +414,7 @@ func decorateVehicle(base api.Vehicle, chargeState func() (api.ChargeStatus, err
 			},
 		}
 
-	case chargeState == nil && resurrector != nil && vehicleClimater != nil && vehicleOdometer == nil && vehicleRange != nil:
+	case chargeState == nil && resurrector != nil && vehicleClimater != nil && vehicleOdometer == nil && vehiclePreconditioner == nil && vehicleRange != nil:
 		return &struct {
 			api.Vehicle
 			api.Resurrector
@@ -433,7 +433,7 @@ func decorateVehicle(base api.Vehicle, chargeState func() (api.ChargeStatus, err
 			},
 		}
 
-	case chargeState != nil && resurrector != nil && vehicleClimater != nil && vehicleOdometer == nil && vehicleRange != nil:
+	case chargeState != nil && resurrector != nil && vehicleClimater != nil && vehicleOdometer == nil && vehiclePreconditioner == nil && vehicleRange != nil:
 		return &struct {
 			api.Vehicle
 			api.ChargeState
@@ -456,7 +456,7 @@ func decorateVehicle(base api.Vehicle, chargeState func() (api.ChargeStatus, err
 			},
 		}
 
-	case chargeState == nil && resurrector != nil && vehicleClimater != nil && vehicleOdometer != nil && vehicleRange == nil:
+	case chargeState == nil && resurrector != nil && vehicleClimater != nil && vehicleOdometer != nil && vehiclePreconditioner == nil && vehicleRange == nil:
 		return &struct {
 			api.Vehicle
 			api.Resurrector
@@ -475,7 +475,7 @@ func decorateVehicle(base api.Vehicle, chargeState func() (api.ChargeStatus, err
 			},
 		}
 
-	case chargeState != nil && resurrector != nil && vehicleClimater != nil && vehicleOdometer != nil && vehicleRange == nil:
+	case chargeState != nil && resurrector != nil && vehicleClimater != nil && vehicleOdometer != nil && vehiclePreconditioner == nil && vehicleRange == nil:
 		return &struct {
 			api.Vehicle
 			api.ChargeState
@@ -498,7 +498,7 @@ func decorateVehicle(base api.Vehicle, chargeState func() (api.ChargeStatus, err
 			},
 		}
 
-	case chargeState == nil && resurrector != nil && vehicleClimater != nil && vehicleOdometer != nil && vehicleRange != nil:
+	case chargeState == nil && resurrector != nil && vehicleClimater != nil && vehicleOdometer != nil && vehiclePreconditioner == nil && vehicleRange != nil:
 		return &struct {
 			api.Vehicle
 			api.Resurrector
@@ -521,7 +521,7 @@ func decorateVehicle(base api.Vehicle, chargeState func() (api.ChargeStatus, err
 			},
 		}
 
-	case chargeState != nil && resurrector != nil && vehicleClimater != nil && vehicleOdometer != nil && vehicleRange != nil:
+	case chargeState != nil && resurrector != nil && vehicleClimater != nil && vehicleOdometer != nil && vehiclePreconditioner == nil && vehicleRange != nil:
 		return &struct {
 			api.Vehicle
 			api.ChargeState
@@ -547,6 +547,678 @@ func decorateVehicle(base api.Vehicle, chargeState func() (api.ChargeStatus, err
 				vehicleRange: vehicleRange,
 			},
 		}
+
+	case chargeState == nil && resurrector == nil && vehicleClimater == nil && vehicleOdometer == nil && vehiclePreconditioner != nil && vehicleRange == nil:
+		return &struct {
+			api.Vehicle
+			api.VehiclePreconditioner
+		}{
+			Vehicle: base,
+			VehiclePreconditioner: &decorateVehicleVehiclePreconditionerImpl{
+				vehiclePreconditioner: vehiclePreconditioner,
+			},
+		}
+
+	case chargeState != nil && resurrector == nil && vehicleClimater == nil && vehicleOdometer == nil && vehiclePreconditioner != nil && vehicleRange == nil:
+		return &struct {
+			api.Vehicle
+			api.ChargeState
+			api.VehiclePreconditioner
+		}{
+			Vehicle: base,
+			ChargeState: &decorateVehicleChargeStateImpl{
+				chargeState: chargeState,
+			},
+			VehiclePreconditioner: &decorateVehicleVehiclePreconditionerImpl{
+				vehiclePreconditioner: vehiclePreconditioner,
+			},
+		}
+
+	case chargeState == nil && resurrector == nil && vehicleClimater == nil && vehicleOdometer == nil && vehiclePreconditioner != nil && vehicleRange != nil:
+		return &struct {
+			api.Vehicle
+			api.VehiclePreconditioner
+			api.VehicleRange
+		}{
+			Vehicle: base,
+			VehiclePreconditioner: &decorateVehicleVehiclePreconditionerImpl{
+				vehiclePreconditioner: vehiclePreconditioner,
+			},
+			VehicleRange: &decorateVehicleVehicleRangeImpl{
+				vehicleRange: vehicleRange,
+			},
+		}
+
+	case chargeState != nil && resurrector == nil && vehicleClimater == nil && vehicleOdometer == nil && vehiclePreconditioner != nil && vehicleRange != nil:
+		return &struct {
+			api.Vehicle
+			api.ChargeState
+			api.VehiclePreconditioner
+			api.VehicleRange
+		}{
+			Vehicle: base,
+			ChargeState: &decorateVehicleChargeStateImpl{
+				chargeState: chargeState,
+			},
+			VehiclePreconditioner: &decorateVehicleVehiclePreconditionerImpl{
+				vehiclePreconditioner: vehiclePreconditioner,
+			},
+			VehicleRange: &decorateVehicleVehicleRangeImpl{
+				vehicleRange: vehicleRange,
+			},
+		}
+
+	case chargeState == nil && resurrector == nil && vehicleClimater == nil && vehicleOdometer != nil && vehiclePreconditioner != nil && vehicleRange == nil:
+		return &struct {
+			api.Vehicle
+			api.VehicleOdometer
+			api.VehiclePreconditioner
+		}{
+			Vehicle: base,
+			VehicleOdometer: &decorateVehicleVehicleOdometerImpl{
+				vehicleOdometer: vehicleOdometer,
+			},
+			VehiclePreconditioner: &decorateVehicleVehiclePreconditionerImpl{
+				vehiclePreconditioner: vehiclePreconditioner,
+			},
+		}
+
+	case chargeState != nil && resurrector == nil && vehicleClimater == nil && vehicleOdometer != nil && vehiclePreconditioner != nil && vehicleRange == nil:
+		return &struct {
+			api.Vehicle
+			api.ChargeState
+			api.VehicleOdometer
+			api.VehiclePreconditioner
+		}{
+			Vehicle: base,
+			ChargeState: &decorateVehicleChargeStateImpl{
+				chargeState: chargeState,
+			},
+			VehicleOdometer: &decorateVehicleVehicleOdometerImpl{
+				vehicleOdometer: vehicleOdometer,
+			},
+			VehiclePreconditioner: &decorateVehicleVehiclePreconditionerImpl{
+				vehiclePreconditioner: vehiclePreconditioner,
+			},
+		}
+
+	case chargeState == nil && resurrector == nil && vehicleClimater == nil && vehicleOdometer != nil && vehiclePreconditioner != nil && vehicleRange != nil:
+		return &struct {
+			api.Vehicle
+			api.VehicleOdometer
+			api.VehiclePreconditioner
+			api.VehicleRange
+		}{
+			Vehicle: base,
+			VehicleOdometer: &decorateVehicleVehicleOdometerImpl{
+				vehicleOdometer: vehicleOdometer,
+			},
+			VehiclePreconditioner: &decorateVehicleVehiclePreconditionerImpl{
+				vehiclePreconditioner: vehiclePreconditioner,
+			},
+			VehicleRange: &decorateVehicleVehicleRangeImpl{
+				vehicleRange: vehicleRange,
+			},
+		}
+
+	case chargeState != nil && resurrector == nil && vehicleClimater == nil && vehicleOdometer != nil && vehiclePreconditioner != nil && vehicleRange != nil:
+		return &struct {
+			api.Vehicle
+			api.ChargeState
+			api.VehicleOdometer
+			api.VehiclePreconditioner
+			api.VehicleRange
+		}{
+			Vehicle: base,
+			ChargeState: &decorateVehicleChargeStateImpl{
+				chargeState: chargeState,
+			},
+			VehicleOdometer: &decorateVehicleVehicleOdometerImpl{
+				vehicleOdometer: vehicleOdometer,
+			},
+			VehiclePreconditioner: &decorateVehicleVehiclePreconditionerImpl{
+				vehiclePreconditioner: vehiclePreconditioner,
+			},
+			VehicleRange: &decorateVehicleVehicleRangeImpl{
+				vehicleRange: vehicleRange,
+			},
+		}
+
+	case chargeState == nil && resurrector == nil && vehicleClimater != nil && vehicleOdometer == nil && vehiclePreconditioner != nil && vehicleRange == nil:
+		return &struct {
+			api.Vehicle
+			api.VehicleClimater
+			api.VehiclePreconditioner
+		}{
+			Vehicle: base,
+			VehicleClimater: &decorateVehicleVehicleClimaterImpl{
+				vehicleClimater: vehicleClimater,
+			},
+			VehiclePreconditioner: &decorateVehicleVehiclePreconditionerImpl{
+				vehiclePreconditioner: vehiclePreconditioner,
+			},
+		}
+
+	case chargeState != nil && resurrector == nil && vehicleClimater != nil && vehicleOdometer == nil && vehiclePreconditioner != nil && vehicleRange == nil:
+		return &struct {
+			api.Vehicle
+			api.ChargeState
+			api.VehicleClimater
+			api.VehiclePreconditioner
+		}{
+			Vehicle: base,
+			ChargeState: &decorateVehicleChargeStateImpl{
+				chargeState: chargeState,
+			},
+			VehicleClimater: &decorateVehicleVehicleClimaterImpl{
+				vehicleClimater: vehicleClimater,
+			},
+			VehiclePreconditioner: &decorateVehicleVehiclePreconditionerImpl{
+				vehiclePreconditioner: vehiclePreconditioner,
+			},
+		}
+
+	case chargeState == nil && resurrector == nil && vehicleClimater != nil && vehicleOdometer == nil && vehiclePreconditioner != nil && vehicleRange != nil:
+		return &struct {
+			api.Vehicle
+			api.VehicleClimater
+			api.VehiclePreconditioner
+			api.VehicleRange
+		}{
+			Vehicle: base,
+			VehicleClimater: &decorateVehicleVehicleClimaterImpl{
+				vehicleClimater: vehicleClimater,
+			},
+			VehiclePreconditioner: &decorateVehicleVehiclePreconditionerImpl{
+				vehiclePreconditioner: vehiclePreconditioner,
+			},
+			VehicleRange: &decorateVehicleVehicleRangeImpl{
+				vehicleRange: vehicleRange,
+			},
+		}
+
+	case chargeState != nil && resurrector == nil && vehicleClimater != nil && vehicleOdometer == nil && vehiclePreconditioner != nil && vehicleRange != nil:
+		return &struct {
+			api.Vehicle
+			api.ChargeState
+			api.VehicleClimater
+			api.VehiclePreconditioner
+			api.VehicleRange
+		}{
+			Vehicle: base,
+			ChargeState: &decorateVehicleChargeStateImpl{
+				chargeState: chargeState,
+			},
+			VehicleClimater: &decorateVehicleVehicleClimaterImpl{
+				vehicleClimater: vehicleClimater,
+			},
+			VehiclePreconditioner: &decorateVehicleVehiclePreconditionerImpl{
+				vehiclePreconditioner: vehiclePreconditioner,
+			},
+			VehicleRange: &decorateVehicleVehicleRangeImpl{
+				vehicleRange: vehicleRange,
+			},
+		}
+
+	case chargeState == nil && resurrector == nil && vehicleClimater != nil && vehicleOdometer != nil && vehiclePreconditioner != nil && vehicleRange == nil:
+		return &struct {
+			api.Vehicle
+			api.VehicleClimater
+			api.VehicleOdometer
+			api.VehiclePreconditioner
+		}{
+			Vehicle: base,
+			VehicleClimater: &decorateVehicleVehicleClimaterImpl{
+				vehicleClimater: vehicleClimater,
+			},
+			VehicleOdometer: &decorateVehicleVehicleOdometerImpl{
+				vehicleOdometer: vehicleOdometer,
+			},
+			VehiclePreconditioner: &decorateVehicleVehiclePreconditionerImpl{
+				vehiclePreconditioner: vehiclePreconditioner,
+			},
+		}
+
+	case chargeState != nil && resurrector == nil && vehicleClimater != nil && vehicleOdometer != nil && vehiclePreconditioner != nil && vehicleRange == nil:
+		return &struct {
+			api.Vehicle
+			api.ChargeState
+			api.VehicleClimater
+			api.VehicleOdometer
+			api.VehiclePreconditioner
+		}{
+			Vehicle: base,
+			ChargeState: &decorateVehicleChargeStateImpl{
+				chargeState: chargeState,
+			},
+			VehicleClimater: &decorateVehicleVehicleClimaterImpl{
+				vehicleClimater: vehicleClimater,
+			},
+			VehicleOdometer: &decorateVehicleVehicleOdometerImpl{
+				vehicleOdometer: vehicleOdometer,
+			},
+			VehiclePreconditioner: &decorateVehicleVehiclePreconditionerImpl{
+				vehiclePreconditioner: vehiclePreconditioner,
+			},
+		}
+
+	case chargeState == nil && resurrector == nil && vehicleClimater != nil && vehicleOdometer != nil && vehiclePreconditioner != nil && vehicleRange != nil:
+		return &struct {
+			api.Vehicle
+			api.VehicleClimater
+			api.VehicleOdometer
+			api.VehiclePreconditioner
+			api.VehicleRange
+		}{
+			Vehicle: base,
+			VehicleClimater: &decorateVehicleVehicleClimaterImpl{
+				vehicleClimater: vehicleClimater,
+			},
+			VehicleOdometer: &decorateVehicleVehicleOdometerImpl{
+				vehicleOdometer: vehicleOdometer,
+			},
+			VehiclePreconditioner: &decorateVehicleVehiclePreconditionerImpl{
+				vehiclePreconditioner: vehiclePreconditioner,
+			},
+			VehicleRange: &decorateVehicleVehicleRangeImpl{
+				vehicleRange: vehicleRange,
+			},
+		}
+
+	case chargeState != nil && resurrector == nil && vehicleClimater != nil && vehicleOdometer != nil && vehiclePreconditioner != nil && vehicleRange != nil:
+		return &struct {
+			api.Vehicle
+			api.ChargeState
+			api.VehicleClimater
+			api.VehicleOdometer
+			api.VehiclePreconditioner
+			api.VehicleRange
+		}{
+			Vehicle: base,
+			ChargeState: &decorateVehicleChargeStateImpl{
+				chargeState: chargeState,
+			},
+			VehicleClimater: &decorateVehicleVehicleClimaterImpl{
+				vehicleClimater: vehicleClimater,
+			},
+			VehicleOdometer: &decorateVehicleVehicleOdometerImpl{
+				vehicleOdometer: vehicleOdometer,
+			},
+			VehiclePreconditioner: &decorateVehicleVehiclePreconditionerImpl{
+				vehiclePreconditioner: vehiclePreconditioner,
+			},
+			VehicleRange: &decorateVehicleVehicleRangeImpl{
+				vehicleRange: vehicleRange,
+			},
+		}
+
+	case chargeState == nil && resurrector != nil && vehicleClimater == nil && vehicleOdometer == nil && vehiclePreconditioner != nil && vehicleRange == nil:
+		return &struct {
+			api.Vehicle
+			api.Resurrector
+			api.VehiclePreconditioner
+		}{
+			Vehicle: base,
+			Resurrector: &decorateVehicleResurrectorImpl{
+				resurrector: resurrector,
+			},
+			VehiclePreconditioner: &decorateVehicleVehiclePreconditionerImpl{
+				vehiclePreconditioner: vehiclePreconditioner,
+			},
+		}
+
+	case chargeState != nil && resurrector != nil && vehicleClimater == nil && vehicleOdometer == nil && vehiclePreconditioner != nil && vehicleRange == nil:
+		return &struct {
+			api.Vehicle
+			api.ChargeState
+			api.Resurrector
+			api.VehiclePreconditioner
+		}{
+			Vehicle: base,
+			ChargeState: &decorateVehicleChargeStateImpl{
+				chargeState: chargeState,
+			},
+			Resurrector: &decorateVehicleResurrectorImpl{
+				resurrector: resurrector,
+			},
+			VehiclePreconditioner: &decorateVehicleVehiclePreconditionerImpl{
+				vehiclePreconditioner: vehiclePreconditioner,
+			},
+		}
+
+	case chargeState == nil && resurrector != nil && vehicleClimater == nil && vehicleOdometer == nil && vehiclePreconditioner != nil && vehicleRange != nil:
+		return &struct {
+			api.Vehicle
+			api.Resurrector
+			api.VehiclePreconditioner
+			api.VehicleRange
+		}{
+			Vehicle: base,
+			Resurrector: &decorateVehicleResurrectorImpl{
+				resurrector: resurrector,
+			},
+			VehiclePreconditioner: &decorateVehicleVehiclePreconditionerImpl{
+				vehiclePreconditioner: vehiclePreconditioner,
+			},
+			VehicleRange: &decorateVehicleVehicleRangeImpl{
+				vehicleRange: vehicleRange,
+			},
+		}
+
+	case chargeState != nil && resurrector != nil && vehicleClimater == nil && vehicleOdometer == nil && vehiclePreconditioner != nil && vehicleRange != nil:
+		return &struct {
+			api.Vehicle
+			api.ChargeState
+			api.Resurrector
+			api.VehiclePreconditioner
+			api.VehicleRange
+		}{
+			Vehicle: base,
+			ChargeState: &decorateVehicleChargeStateImpl{
+				chargeState: chargeState,
+			},
+			Resurrector: &decorateVehicleResurrectorImpl{
+				resurrector: resurrector,
+			},
+			VehiclePreconditioner: &decorateVehicleVehiclePreconditionerImpl{
+				vehiclePreconditioner: vehiclePreconditioner,
+			},
+			VehicleRange: &decorateVehicleVehicleRangeImpl{
+				vehicleRange: vehicleRange,
+			},
+		}
+
+	case chargeState == nil && resurrector != nil && vehicleClimater == nil && vehicleOdometer != nil && vehiclePreconditioner != nil && vehicleRange == nil:
+		return &struct {
+			api.Vehicle
+			api.Resurrector
+			api.VehicleOdometer
+			api.VehiclePreconditioner
+		}{
+			Vehicle: base,
+			Resurrector: &decorateVehicleResurrectorImpl{
+				resurrector: resurrector,
+			},
+			VehicleOdometer: &decorateVehicleVehicleOdometerImpl{
+				vehicleOdometer: vehicleOdometer,
+			},
+			VehiclePreconditioner: &decorateVehicleVehiclePreconditionerImpl{
+				vehiclePreconditioner: vehiclePreconditioner,
+			},
+		}
+
+	case chargeState != nil && resurrector != nil && vehicleClimater == nil && vehicleOdometer != nil && vehiclePreconditioner != nil && vehicleRange == nil:
+		return &struct {
+			api.Vehicle
+			api.ChargeState
+			api.Resurrector
+			api.VehicleOdometer
+			api.VehiclePreconditioner
+		}{
+			Vehicle: base,
+			ChargeState: &decorateVehicleChargeStateImpl{
+				chargeState: chargeState,
+			},
+			Resurrector: &decorateVehicleResurrectorImpl{
+				resurrector: resurrector,
+			},
+			VehicleOdometer: &decorateVehicleVehicleOdometerImpl{
+				vehicleOdometer: vehicleOdometer,
+			},
+			VehiclePreconditioner: &decorateVehicleVehiclePreconditionerImpl{
+				vehiclePreconditioner: vehiclePreconditioner,
+			},
+		}
+
+	case chargeState == nil && resurrector != nil && vehicleClimater == nil && vehicleOdometer != nil && vehiclePreconditioner != nil && vehicleRange != nil:
+		return &struct {
+			api.Vehicle
+			api.Resurrector
+			api.VehicleOdometer
+			api.VehiclePreconditioner
+			api.VehicleRange
+		}{
+			Vehicle: base,
+			Resurrector: &decorateVehicleResurrectorImpl{
+				resurrector: resurrector,
+			},
+			VehicleOdometer: &decorateVehicleVehicleOdometerImpl{
+				vehicleOdometer: vehicleOdometer,
+			},
+			VehiclePreconditioner: &decorateVehicleVehiclePreconditionerImpl{
+				vehiclePreconditioner: vehiclePreconditioner,
+			},
+			VehicleRange: &decorateVehicleVehicleRangeImpl{
+				vehicleRange: vehicleRange,
+			},
+		}
+
+	case chargeState != nil && resurrector != nil && vehicleClimater == nil && vehicleOdometer != nil && vehiclePreconditioner != nil && vehicleRange != nil:
+		return &struct {
+			api.Vehicle
+			api.ChargeState
+			api.Resurrector
+			api.VehicleOdometer
+			api.VehiclePreconditioner
+			api.VehicleRange
+		}{
+			Vehicle: base,
+			ChargeState: &decorateVehicleChargeStateImpl{
+				chargeState: chargeState,
+			},
+			Resurrector: &decorateVehicleResurrectorImpl{
+				resurrector: resurrector,
+			},
+			VehicleOdometer: &decorateVehicleVehicleOdometerImpl{
+				vehicleOdometer: vehicleOdometer,
+			},
+			VehiclePreconditioner: &decorateVehicleVehiclePreconditionerImpl{
+				vehiclePreconditioner: vehiclePreconditioner,
+			},
+			VehicleRange: &decorateVehicleVehicleRangeImpl{
+				vehicleRange: vehicleRange,
+			},
+		}
+
+	case chargeState == nil && resurrector != nil && vehicleClimater != nil && vehicleOdometer == nil && vehiclePreconditioner != nil && vehicleRange == nil:
+		return &struct {
+			api.Vehicle
+			api.Resurrector
+			api.VehicleClimater
+			api.VehiclePreconditioner
+		}{
+			Vehicle: base,
+			Resurrector: &decorateVehicleResurrectorImpl{
+				resurrector: resurrector,
+			},
+			VehicleClimater: &decorateVehicleVehicleClimaterImpl{
+				vehicleClimater: vehicleClimater,
+			},
+			VehiclePreconditioner: &decorateVehicleVehiclePreconditionerImpl{
+				vehiclePreconditioner: vehiclePreconditioner,
+			},
+		}
+
+	case chargeState != nil && resurrector != nil && vehicleClimater != nil && vehicleOdometer == nil && vehiclePreconditioner != nil && vehicleRange == nil:
+		return &struct {
+			api.Vehicle
+			api.ChargeState
+			api.Resurrector
+			api.VehicleClimater
+			api.VehiclePreconditioner
+		}{
+			Vehicle: base,
+			ChargeState: &decorateVehicleChargeStateImpl{
+				chargeState: chargeState,
+			},
+			Resurrector: &decorateVehicleResurrectorImpl{
+				resurrector: resurrector,
+			},
+			VehicleClimater: &decorateVehicleVehicleClimaterImpl{
+				vehicleClimater: vehicleClimater,
+			},
+			VehiclePreconditioner: &decorateVehicleVehiclePreconditionerImpl{
+				vehiclePreconditioner: vehiclePreconditioner,
+			},
+		}
+
+	case chargeState == nil && resurrector != nil && vehicleClimater != nil && vehicleOdometer == nil && vehiclePreconditioner != nil && vehicleRange != nil:
+		return &struct {
+			api.Vehicle
+			api.Resurrector
+			api.VehicleClimater
+			api.VehiclePreconditioner
+			api.VehicleRange
+		}{
+			Vehicle: base,
+			Resurrector: &decorateVehicleResurrectorImpl{
+				resurrector: resurrector,
+			},
+			VehicleClimater: &decorateVehicleVehicleClimaterImpl{
+				vehicleClimater: vehicleClimater,
+			},
+			VehiclePreconditioner: &decorateVehicleVehiclePreconditionerImpl{
+				vehiclePreconditioner: vehiclePreconditioner,
+			},
+			VehicleRange: &decorateVehicleVehicleRangeImpl{
+				vehicleRange: vehicleRange,
+			},
+		}
+
+	case chargeState != nil && resurrector != nil && vehicleClimater != nil && vehicleOdometer == nil && vehiclePreconditioner != nil && vehicleRange != nil:
+		return &struct {
+			api.Vehicle
+			api.ChargeState
+			api.Resurrector
+			api.VehicleClimater
+			api.VehiclePreconditioner
+			api.VehicleRange
+		}{
+			Vehicle: base,
+			ChargeState: &decorateVehicleChargeStateImpl{
+				chargeState: chargeState,
+			},
+			Resurrector: &decorateVehicleResurrectorImpl{
+				resurrector: resurrector,
+			},
+			VehicleClimater: &decorateVehicleVehicleClimaterImpl{
+				vehicleClimater: vehicleClimater,
+			},
+			VehiclePreconditioner: &decorateVehicleVehiclePreconditionerImpl{
+				vehiclePreconditioner: vehiclePreconditioner,
+			},
+			VehicleRange: &decorateVehicleVehicleRangeImpl{
+				vehicleRange: vehicleRange,
+			},
+		}
+
+	case chargeState == nil && resurrector != nil && vehicleClimater != nil && vehicleOdometer != nil && vehiclePreconditioner != nil && vehicleRange == nil:
+		return &struct {
+			api.Vehicle
+			api.Resurrector
+			api.VehicleClimater
+			api.VehicleOdometer
+			api.VehiclePreconditioner
+		}{
+			Vehicle: base,
+			Resurrector: &decorateVehicleResurrectorImpl{
+				resurrector: resurrector,
+			},
+			VehicleClimater: &decorateVehicleVehicleClimaterImpl{
+				vehicleClimater: vehicleClimater,
+			},
+			VehicleOdometer: &decorateVehicleVehicleOdometerImpl{
+				vehicleOdometer: vehicleOdometer,
+			},
+			VehiclePreconditioner: &decorateVehicleVehiclePreconditionerImpl{
+				vehiclePreconditioner: vehiclePreconditioner,
+			},
+		}
+
+	case chargeState != nil && resurrector != nil && vehicleClimater != nil && vehicleOdometer != nil && vehiclePreconditioner != nil && vehicleRange == nil:
+		return &struct {
+			api.Vehicle
+			api.ChargeState
+			api.Resurrector
+			api.VehicleClimater
+			api.VehicleOdometer
+			api.VehiclePreconditioner
+		}{
+			Vehicle: base,
+			ChargeState: &decorateVehicleChargeStateImpl{
+				chargeState: chargeState,
+			},
+			Resurrector: &decorateVehicleResurrectorImpl{
+				resurrector: resurrector,
+			},
+			VehicleClimater: &decorateVehicleVehicleClimaterImpl{
+				vehicleClimater: vehicleClimater,
+			},
+			VehicleOdometer: &decorateVehicleVehicleOdometerImpl{
+				vehicleOdometer: vehicleOdometer,
+			},
+			VehiclePreconditioner: &decorateVehicleVehiclePreconditionerImpl{
+				vehiclePreconditioner: vehiclePreconditioner,
+			},
+		}
+
+	case chargeState == nil && resurrector != nil && vehicleClimater != nil && vehicleOdometer != nil && vehiclePreconditioner != nil && vehicleRange != nil:
+		return &struct {
+			api.Vehicle
+			api.Resurrector
+			api.VehicleClimater
+			api.VehicleOdometer
+			api.VehiclePreconditioner
+			api.VehicleRange
+		}{
+			Vehicle: base,
+			Resurrector: &decorateVehicleResurrectorImpl{
+				resurrector: resurrector,
+			},
+			VehicleClimater: &decorateVehicleVehicleClimaterImpl{
+				vehicleClimater: vehicleClimater,
+			},
+			VehicleOdometer: &decorateVehicleVehicleOdometerImpl{
+				vehicleOdometer: vehicleOdometer,
+			},
+			VehiclePreconditioner: &decorateVehicleVehiclePreconditionerImpl{
+				vehiclePreconditioner: vehiclePreconditioner,
+			},
+			VehicleRange: &decorateVehicleVehicleRangeImpl{
+				vehicleRange: vehicleRange,
+			},
+		}
+
+	case chargeState != nil && resurrector != nil && vehicleClimater != nil && vehicleOdometer != nil && vehiclePreconditioner != nil && vehicleRange != nil:
+		return &struct {
+			api.Vehicle
+			api.ChargeState
+			api.Resurrector
+			api.VehicleClimater
+			api.VehicleOdometer
+			api.VehiclePreconditioner
+			api.VehicleRange
+		}{
+			Vehicle: base,
+			ChargeState: &decorateVehicleChargeStateImpl{
+				chargeState: chargeState,
+			},
+			Resurrector: &decorateVehicleResurrectorImpl{
+				resurrector: resurrector,
+			},
+			VehicleClimater: &decorateVehicleVehicleClimaterImpl{
+				vehicleClimater: vehicleClimater,
+			},
+			VehicleOdometer: &decorateVehicleVehicleOdometerImpl{
+				vehicleOdometer: vehicleOdometer,
+			},
+			VehiclePreconditioner: &decorateVehicleVehiclePreconditionerImpl{
+				vehiclePreconditioner: vehiclePreconditioner,
+			},
+			VehicleRange: &decorateVehicleVehicleRangeImpl{
+				vehicleRange: vehicleRange,
+			},
+		}
 	}
 
 	return nil
@@ -584,6 +1256,14 @@ func (impl *decorateVehicleVehicleOdometerImpl) Odometer() (float64, error) {
 	return impl.vehicleOdometer()
 }
 
+type decorateVehicleVehiclePreconditionerImpl struct {
+	vehiclePreconditioner func() error
+}
+
+func (impl *decorateVehicleVehiclePreconditionerImpl) Precondition() error {
+	return impl.vehiclePreconditioner()
+}
+
 type decorateVehicleVehicleRangeImpl struct {
 	vehicleRange func() (int64, error)
 }